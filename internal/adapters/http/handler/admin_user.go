@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/approval"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
 )
 
@@ -12,17 +15,96 @@ import (
 type ListUsersQuery struct {
 	response.PaginationQueryDTO
 
-	// Search 搜索关键词（用户名或邮箱）
+	// Search 搜索关键词（用户名、邮箱或全名）
 	Search string `form:"search" json:"search" binding:"omitempty"`
+	// Status 状态过滤，可重复传入（任一匹配）
+	Status []string `form:"status" json:"status" binding:"omitempty,dive,oneof=active inactive banned pending"`
+	// RoleID 角色过滤，可重复传入（拥有任一角色，含用户组角色）
+	RoleID []uint `form:"role_id" json:"role_id" binding:"omitempty,dive,gt=0"`
+
+	// CreatedFrom/CreatedTo 创建时间范围 [from, to)，RFC3339 格式
+	CreatedFrom *time.Time `form:"created_from" json:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to" json:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	// UpdatedFrom/UpdatedTo 更新时间范围 [from, to)，RFC3339 格式
+	UpdatedFrom *time.Time `form:"updated_from" json:"updated_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedTo   *time.Time `form:"updated_to" json:"updated_to" time_format:"2006-01-02T15:04:05Z07:00"`
+
+	// TwoFAEnabled 是否启用了第二因素
+	TwoFAEnabled *bool `form:"twofa_enabled" json:"twofa_enabled"`
+
+	// LastLoginFrom/LastLoginTo 最近登录时间范围 [from, to)，RFC3339 格式
+	LastLoginFrom *time.Time `form:"last_login_from" json:"last_login_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	LastLoginTo   *time.Time `form:"last_login_to" json:"last_login_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	// NeverLoggedIn 仅返回从未登录的用户
+	NeverLoggedIn bool `form:"never_logged_in" json:"never_logged_in"`
+	// LoginCountMin/LoginCountMax 登录次数范围（含两端）
+	LoginCountMin *int64 `form:"login_count_min" json:"login_count_min" binding:"omitempty,gte=0"`
+	LoginCountMax *int64 `form:"login_count_max" json:"login_count_max" binding:"omitempty,gte=0"`
+
+	// Sort 排序，逗号分隔，"-" 前缀表示降序，如 "status,-created_at"
+	// 可用字段：id, username, email, full_name, status, created_at, updated_at, last_login_at, login_count
+	Sort string `form:"sort" json:"sort" binding:"omitempty,max=200"`
+	// Cursor 游标分页，取自上一页响应的 meta.next_cursor，设置后忽略 page
+	Cursor string `form:"cursor" json:"cursor" binding:"omitempty,max=2048"`
+	// WithTotal 是否统计总数，偏移分页默认统计，游标分页默认不统计
+	WithTotal *bool `form:"with_total" json:"with_total"`
 }
 
 // ToQuery 转换为 Application 层 Query 对象
 func (q *ListUsersQuery) ToQuery() user.ListUsersQuery {
+	withTotal := q.Cursor == ""
+	if q.WithTotal != nil {
+		withTotal = *q.WithTotal
+	}
+
 	return user.ListUsersQuery{
-		Page:   q.GetPage(),
-		Limit:  q.GetLimit(),
-		Search: q.Search,
+		Page:          q.GetPage(),
+		Limit:         q.GetLimit(),
+		Search:        q.Search,
+		Statuses:      q.Status,
+		RoleIDs:       q.RoleID,
+		CreatedFrom:   q.CreatedFrom,
+		CreatedTo:     q.CreatedTo,
+		UpdatedFrom:   q.UpdatedFrom,
+		UpdatedTo:     q.UpdatedTo,
+		TwoFAEnabled:  q.TwoFAEnabled,
+		LastLoginFrom: q.LastLoginFrom,
+		LastLoginTo:   q.LastLoginTo,
+		NeverLoggedIn: q.NeverLoggedIn,
+		LoginCountMin: q.LoginCountMin,
+		LoginCountMax: q.LoginCountMax,
+		Sort:          q.Sort,
+		Cursor:        q.Cursor,
+		WithTotal:     withTotal,
+	}
+}
+
+// listUsersMeta 根据查询结果构造分页元数据
+// 未统计总数时不计算总页数，以 has_more / next_cursor 判断是否有下一页
+func listUsersMeta(q user.ListUsersQuery, result *user.UserListDTO) *response.PaginationMeta {
+	var meta *response.PaginationMeta
+	if q.WithTotal {
+		meta = response.NewPaginationMeta(int(result.Total), q.Page, q.Limit)
+	} else {
+		meta = &response.PaginationMeta{Page: q.Page, PerPage: q.Limit}
+	}
+	if q.Cursor != "" {
+		meta.Page = 0
+		meta.TotalPages = 0
+		meta.Warning = ""
 	}
+	meta.HasMore = result.HasMore
+	meta.NextCursor = result.NextCursor
+	return meta
+}
+
+// writeListUsersError 将列表查询错误映射为 HTTP 响应
+func writeListUsersError(c *gin.Context, err error) {
+	if errors.Is(err, user.ErrInvalidListCriteria) {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	response.InternalError(c, err.Error())
 }
 
 // AdminUserHandler handles admin user management operations
@@ -32,8 +114,13 @@ type AdminUserHandler struct {
 	deleteUserHandler      *user.DeleteUserHandler
 	assignRolesHandler     *user.AssignRolesHandler
 	batchCreateUserHandler *user.BatchCreateUsersHandler
+	resetPasswordHandler   *user.ResetPasswordHandler
+	inviteUserHandler      *user.InviteUserHandler
+	resendInviteHandler    *user.ResendInvitationHandler
+	approveUserHandler     *user.ApproveUserHandler
 	getUserHandler         *user.GetUserHandler
 	listUsersHandler       *user.ListUsersHandler
+	submitChangeHandler    *approval.SubmitChangeRequestHandler
 }
 
 // NewAdminUserHandler creates a new AdminUserHandler instance
@@ -43,8 +130,13 @@ func NewAdminUserHandler(
 	deleteUserHandler *user.DeleteUserHandler,
 	assignRolesHandler *user.AssignRolesHandler,
 	batchCreateUserHandler *user.BatchCreateUsersHandler,
+	resetPasswordHandler *user.ResetPasswordHandler,
+	inviteUserHandler *user.InviteUserHandler,
+	resendInviteHandler *user.ResendInvitationHandler,
+	approveUserHandler *user.ApproveUserHandler,
 	getUserHandler *user.GetUserHandler,
 	listUsersHandler *user.ListUsersHandler,
+	submitChangeHandler *approval.SubmitChangeRequestHandler,
 ) *AdminUserHandler {
 	return &AdminUserHandler{
 		createUserHandler:      createUserHandler,
@@ -52,8 +144,13 @@ func NewAdminUserHandler(
 		deleteUserHandler:      deleteUserHandler,
 		assignRolesHandler:     assignRolesHandler,
 		batchCreateUserHandler: batchCreateUserHandler,
+		resetPasswordHandler:   resetPasswordHandler,
+		inviteUserHandler:      inviteUserHandler,
+		resendInviteHandler:    resendInviteHandler,
+		approveUserHandler:     approveUserHandler,
 		getUserHandler:         getUserHandler,
 		listUsersHandler:       listUsersHandler,
+		submitChangeHandler:    submitChangeHandler,
 	}
 }
 
@@ -67,9 +164,9 @@ func NewAdminUserHandler(
 // @Security     BearerAuth
 // @Param        request body user.CreateUserDTO true "用户信息"
 // @Success      201 {object} response.DataResponse[user.UserWithRolesDTO] "用户创建成功"
-// @Failure      400 {object} response.ErrorResponse "参数错误或用户名/邮箱已存在"
+// @Failure      400 {object} response.ErrorResponse "参数错误、用户名/邮箱已存在、角色不存在或自定义属性无效"
 // @Failure      401 {object} response.ErrorResponse "未授权"
-// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      403 {object} response.ErrorResponse "权限不足或初始角色含管理权限"
 // @Failure      500 {object} response.ErrorResponse "服务器内部错误"
 // @Router       /api/admin/users [post]
 // @x-permission {"scope":"admin:users:create"}
@@ -82,6 +179,14 @@ func (h *AdminUserHandler) CreateUser(c *gin.Context) {
 
 	result, err := h.createUserHandler.Handle(c.Request.Context(), user.CreateUserCommand(dto))
 	if err != nil {
+		if isAttributeValueError(err) || errors.Is(err, user.ErrRoleNotFound) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		if errors.Is(err, user.ErrPrivilegedRoleAssignment) {
+			response.Forbidden(c, err.Error())
+			return
+		}
 		response.InternalError(c, err.Error())
 		return
 	}
@@ -101,13 +206,15 @@ func (h *AdminUserHandler) CreateUser(c *gin.Context) {
 // ListUsers lists all users with pagination (admin only)
 //
 // @Summary      获取用户列表
-// @Description  分页获取所有用户列表（包含角色信息）
+// @Description  分页获取用户列表，支持按状态、角色、时间范围、2FA、最近登录、登录次数过滤，多字段排序，偏移或游标分页。
+// @Description  按自定义属性过滤使用 attr[属性键]=值（精确匹配，可组合多个属性）
 // @Tags         管理员 - 用户管理 (Admin - User Management)
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        params query handler.ListUsersQuery false "查询参数"
-// @Success      200 {object} response.PagedResponse[user.UserWithRolesDTO] "用户列表"
+// @Success      200 {object} response.PagedResponse[user.UserDTO] "用户列表"
+// @Failure      400 {object} response.ErrorResponse "参数错误（排序字段、游标或属性过滤无效）"
 // @Failure      401 {object} response.ErrorResponse "未授权"
 // @Failure      403 {object} response.ErrorResponse "权限不足"
 // @Failure      500 {object} response.ErrorResponse "服务器内部错误"
@@ -120,14 +227,16 @@ func (h *AdminUserHandler) ListUsers(c *gin.Context) {
 		return
 	}
 
-	result, err := h.listUsersHandler.Handle(c.Request.Context(), q.ToQuery())
+	query := q.ToQuery()
+	query.Attributes = c.QueryMap("attr")
+	query.ActorID = c.GetUint("user_id")
+	result, err := h.listUsersHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		response.InternalError(c, err.Error())
+		writeListUsersError(c, err)
 		return
 	}
 
-	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
-	response.List(c, "success", result.Users, meta)
+	response.List(c, "success", result.Users, listUsersMeta(query, result))
 }
 
 // GetUser gets a user by ID (admin only)
@@ -176,7 +285,7 @@ func (h *AdminUserHandler) GetUser(c *gin.Context) {
 // @Param        id path int true "用户ID" minimum(1)
 // @Param        request body user.UpdateUserDTO true "更新信息"
 // @Success      200 {object} response.DataResponse[user.UserWithRolesDTO] "用户更新成功"
-// @Failure      400 {object} response.ErrorResponse "无效的用户ID或参数错误"
+// @Failure      400 {object} response.ErrorResponse "无效的用户ID、参数错误或自定义属性无效"
 // @Failure      401 {object} response.ErrorResponse "未授权"
 // @Failure      403 {object} response.ErrorResponse "权限不足"
 // @Failure      404 {object} response.ErrorResponse "用户不存在"
@@ -204,8 +313,20 @@ func (h *AdminUserHandler) UpdateUser(c *gin.Context) {
 		Avatar:   dto.Avatar,
 		Bio:      dto.Bio,
 		Status:   dto.Status,
+
+		Department: dto.Department,
+		Attributes: dto.Attributes,
+		ActorID:    c.GetUint("user_id"),
 	})
 	if err != nil {
+		if errors.Is(err, user.ErrAccessDenied) {
+			response.Forbidden(c, err.Error())
+			return
+		}
+		if isAttributeValueError(err) {
+			response.BadRequest(c, err.Error())
+			return
+		}
 		response.InternalError(c, err.Error())
 		return
 	}
@@ -225,13 +346,14 @@ func (h *AdminUserHandler) UpdateUser(c *gin.Context) {
 // DeleteUser deletes a user (admin only)
 //
 // @Summary      删除用户
-// @Description  管理员删除指定用户（物理删除或软删除）
+// @Description  管理员删除指定用户（物理删除或软删除）。启用双人审批时返回 202 与待审批的变更请求
 // @Tags         管理员 - 用户管理 (Admin - User Management)
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "用户ID" minimum(1)
 // @Success      200 {object} response.MessageResponse "用户删除成功"
+// @Success      202 {object} response.DataResponse[approval.ChangeRequestDTO] "已提交审批"
 // @Failure      400 {object} response.ErrorResponse "无效的用户ID"
 // @Failure      401 {object} response.ErrorResponse "未授权"
 // @Failure      403 {object} response.ErrorResponse "权限不足"
@@ -246,9 +368,13 @@ func (h *AdminUserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.deleteUserHandler.Handle(c.Request.Context(), user.DeleteUserCommand{
-		UserID: uint(id),
-	}); err != nil {
+	cmd := user.DeleteUserCommand{UserID: uint(id)}
+	if h.submitChangeHandler.Required(c.Request.Context(), approval.OperationDeleteUser) {
+		submitChangeRequest(c, h.submitChangeHandler, approval.OperationDeleteUser, c.Param("id"), cmd)
+		return
+	}
+
+	if err := h.deleteUserHandler.Handle(c.Request.Context(), cmd); err != nil {
 		response.InternalError(c, err.Error())
 		return
 	}
@@ -256,10 +382,198 @@ func (h *AdminUserHandler) DeleteUser(c *gin.Context) {
 	response.OK(c, "user deleted successfully", nil)
 }
 
+// ResetPassword resets a user's password (admin only)
+//
+// @Summary      重置用户密码
+// @Description  管理员为指定用户设置临时密码，用户下次登录后必须先修改密码
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户ID" minimum(1)
+// @Param        request body user.ResetPasswordDTO true "临时密码"
+// @Success      200 {object} response.MessageResponse "密码重置成功"
+// @Failure      400 {object} response.ErrorResponse "无效的用户ID、参数错误或密码不符合策略"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/{id}/password [put]
+// @x-permission {"scope":"admin:users:update"}
+func (h *AdminUserHandler) ResetPassword(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	var req user.ResetPasswordDTO
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	if err = h.resetPasswordHandler.Handle(c.Request.Context(), user.ResetPasswordCommand{
+		UserID:      uint(id),
+		NewPassword: req.NewPassword,
+		ActorID:     c.GetUint("user_id"),
+	}); err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			response.NotFound(c, "user")
+		case errors.Is(err, user.ErrWeakPassword):
+			response.BadRequest(c, err.Error())
+		case errors.Is(err, user.ErrPasswordManagedExternally), errors.Is(err, user.ErrAccessDenied):
+			response.Forbidden(c, err.Error())
+		default:
+			response.InternalError(c, err.Error())
+		}
+		return
+	}
+
+	response.OK(c, "password reset successfully", nil)
+}
+
+// InviteUser invites a new user by email (admin only)
+//
+// @Summary      邀请用户
+// @Description  管理员创建待激活用户，并向其邮箱发送设置密码的邀请链接
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body user.InviteUserDTO true "受邀用户信息"
+// @Success      201 {object} response.DataResponse[user.InvitationResultDTO] "邀请已发送"
+// @Failure      400 {object} response.ErrorResponse "参数错误或角色不存在"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足或初始角色含管理权限"
+// @Failure      409 {object} response.ErrorResponse "用户名或邮箱已存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/invite [post]
+// @x-permission {"scope":"admin:users:create"}
+func (h *AdminUserHandler) InviteUser(c *gin.Context) {
+	var req user.InviteUserDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.inviteUserHandler.Handle(c.Request.Context(), user.InviteUserCommand{
+		Username:  req.Username,
+		Email:     req.Email,
+		FullName:  req.FullName,
+		RoleIDs:   req.RoleIDs,
+		InvitedBy: c.GetUint("user_id"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUsernameAlreadyExists), errors.Is(err, user.ErrEmailAlreadyExists):
+			response.Conflict(c, err.Error())
+		case errors.Is(err, user.ErrRoleNotFound):
+			response.BadRequest(c, err.Error())
+		case errors.Is(err, user.ErrPrivilegedRoleAssignment):
+			response.Forbidden(c, err.Error())
+		default:
+			response.InternalError(c, err.Error())
+		}
+		return
+	}
+
+	response.Created(c, "invitation sent successfully", result)
+}
+
+// ResendInvitation resends the invitation email to a pending user (admin only)
+//
+// @Summary      重发邀请
+// @Description  为尚未激活的用户重新生成邀请链接并发送邮件，旧链接立即失效
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户ID" minimum(1)
+// @Success      200 {object} response.DataResponse[user.InvitationResultDTO] "邀请已重新发送"
+// @Failure      400 {object} response.ErrorResponse "无效的用户ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户不存在"
+// @Failure      409 {object} response.ErrorResponse "用户已激活"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/{id}/invitation [post]
+// @x-permission {"scope":"admin:users:create"}
+func (h *AdminUserHandler) ResendInvitation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	result, err := h.resendInviteHandler.Handle(c.Request.Context(), user.ResendInvitationCommand{
+		UserID:    uint(id),
+		InvitedBy: c.GetUint("user_id"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			response.NotFound(c, "user")
+		case errors.Is(err, user.ErrUserNotPending):
+			response.Conflict(c, err.Error())
+		default:
+			response.InternalError(c, err.Error())
+		}
+		return
+	}
+
+	response.OK(c, "invitation resent successfully", result)
+}
+
+// ApproveUser approves a pending registration (admin only)
+//
+// @Summary      审批注册用户
+// @Description  审批模式下激活自助注册的 inactive 用户
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户ID" minimum(1)
+// @Success      200 {object} response.MessageResponse "审批通过"
+// @Failure      400 {object} response.ErrorResponse "无效的用户ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户不存在"
+// @Failure      409 {object} response.ErrorResponse "用户不是待审批状态"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/{id}/approve [post]
+// @x-permission {"scope":"admin:users:update"}
+func (h *AdminUserHandler) ApproveUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	if err = h.approveUserHandler.Handle(c.Request.Context(), user.ApproveUserCommand{
+		UserID:  uint(id),
+		ActorID: c.GetUint("user_id"),
+	}); err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			response.NotFound(c, "user")
+		case errors.Is(err, user.ErrAccessDenied):
+			response.Forbidden(c, err.Error())
+		case errors.Is(err, user.ErrUserNotPending):
+			response.Conflict(c, err.Error())
+		default:
+			response.InternalError(c, err.Error())
+		}
+		return
+	}
+
+	response.OK(c, "user approved successfully", nil)
+}
+
 // AssignRoles assigns roles to a user (admin only)
 //
 // @Summary      分配用户角色
-// @Description  管理员为指定用户分配角色（会覆盖现有角色）
+// @Description  管理员为指定用户分配角色（会覆盖现有角色）。启用双人审批时返回 202 与待审批的变更请求
 // @Tags         管理员 - 用户管理 (Admin - User Management)
 // @Accept       json
 // @Produce      json
@@ -267,6 +581,7 @@ func (h *AdminUserHandler) DeleteUser(c *gin.Context) {
 // @Param        id path int true "用户ID" minimum(1)
 // @Param        request body user.AssignRolesDTO true "角色ID列表"
 // @Success      200 {object} response.DataResponse[user.UserWithRolesDTO] "角色分配成功"
+// @Success      202 {object} response.DataResponse[approval.ChangeRequestDTO] "已提交审批"
 // @Failure      400 {object} response.ErrorResponse "无效的用户ID或参数错误"
 // @Failure      401 {object} response.ErrorResponse "未授权"
 // @Failure      403 {object} response.ErrorResponse "权限不足"
@@ -287,10 +602,21 @@ func (h *AdminUserHandler) AssignRoles(c *gin.Context) {
 		return
 	}
 
-	if err = h.assignRolesHandler.Handle(c.Request.Context(), user.AssignRolesCommand{
+	cmd := user.AssignRolesCommand{
 		UserID:  uint(id),
 		RoleIDs: req.RoleIDs,
-	}); err != nil {
+		ActorID: c.GetUint("user_id"),
+	}
+	if h.submitChangeHandler.Required(c.Request.Context(), approval.OperationAssignUserRoles) {
+		submitChangeRequest(c, h.submitChangeHandler, approval.OperationAssignUserRoles, c.Param("id"), cmd)
+		return
+	}
+
+	if err = h.assignRolesHandler.Handle(c.Request.Context(), cmd); err != nil {
+		if errors.Is(err, user.ErrAccessDenied) {
+			response.Forbidden(c, err.Error())
+			return
+		}
 		response.InternalError(c, err.Error())
 		return
 	}
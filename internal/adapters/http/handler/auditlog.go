@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
@@ -94,7 +95,10 @@ func (h *AuditLogHandler) ListLogs(c *gin.Context) {
 		return
 	}
 
-	result, err := h.listLogsHandler.Handle(c.Request.Context(), q.ToQuery())
+	query := q.ToQuery()
+	query.ActorID = c.GetUint("user_id")
+
+	result, err := h.listLogsHandler.Handle(c.Request.Context(), query)
 	if err != nil {
 		response.InternalError(c, "failed to list audit logs")
 		return
@@ -128,10 +132,15 @@ func (h *AuditLogHandler) GetLog(c *gin.Context) {
 	}
 
 	log, err := h.getLogHandler.Handle(c.Request.Context(), auditlog.GetLogQuery{
-		LogID: uint(id),
+		LogID:   uint(id),
+		ActorID: c.GetUint("user_id"),
 	})
 
 	if err != nil {
+		if errors.Is(err, auditlog.ErrAccessDenied) {
+			response.Forbidden(c, err.Error())
+			return
+		}
 		response.NotFound(c, "audit log")
 		return
 	}
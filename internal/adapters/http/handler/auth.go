@@ -12,35 +12,44 @@ import (
 type AuthHandler struct {
 	loginHandler        *auth.LoginHandler
 	login2FAHandler     *auth.Login2FAHandler
+	send2FACodeHandler  *auth.Send2FACodeHandler
 	registerHandler     *auth.RegisterHandler
 	refreshTokenHandler *auth.RefreshTokenHandler
+
+	acceptInvitationHandler *auth.AcceptInvitationHandler
 }
 
 // NewAuthHandler 创建认证处理器
 func NewAuthHandler(
 	loginHandler *auth.LoginHandler,
 	login2FAHandler *auth.Login2FAHandler,
+	send2FACodeHandler *auth.Send2FACodeHandler,
 	registerHandler *auth.RegisterHandler,
 	refreshTokenHandler *auth.RefreshTokenHandler,
+	acceptInvitationHandler *auth.AcceptInvitationHandler,
 ) *AuthHandler {
 	return &AuthHandler{
 		loginHandler:        loginHandler,
 		login2FAHandler:     login2FAHandler,
+		send2FACodeHandler:  send2FACodeHandler,
 		registerHandler:     registerHandler,
 		refreshTokenHandler: refreshTokenHandler,
+
+		acceptInvitationHandler: acceptInvitationHandler,
 	}
 }
 
 // Register 用户注册
 //
 // @Summary      用户注册
-// @Description  创建新用户账号，注册成功后自动登录并返回访问令牌
+// @Description  创建新用户账号。开放注册时自动登录并返回访问令牌；审批模式下返回 pending_approval，待管理员审批后可登录
 // @Tags         认证 (Authentication)
 // @Accept       json
 // @Produce      json
 // @Param        request body auth.RegisterDTO true "注册信息"
 // @Success      201 {object} response.DataResponse[auth.RegisterResultDTO] "注册成功"
 // @Failure      400 {object} response.ErrorResponse "参数错误或用户名/邮箱已存在"
+// @Failure      403 {object} response.ErrorResponse "注册已关闭、仅限邀请或邮箱域名不允许"
 // @Router       /api/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req auth.RegisterDTO
@@ -53,13 +62,57 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	result, err := h.registerHandler.Handle(c.Request.Context(), auth.RegisterCommand(req))
 
 	if err != nil {
+		if errors.Is(err, auth.ErrRegistrationClosed) ||
+			errors.Is(err, auth.ErrInvitationRequired) ||
+			errors.Is(err, auth.ErrEmailDomainNotAllowed) {
+			response.Forbidden(c, err.Error())
+			return
+		}
 		response.BadRequest(c, err.Error())
 		return
 	}
 
+	if result.PendingApproval {
+		response.Created(c, "registration submitted, pending approval", result)
+		return
+	}
+
 	response.Created(c, "user registered successfully", result)
 }
 
+// AcceptInvitation 接受邀请
+//
+// @Summary      接受邀请
+// @Description  受邀用户使用邀请邮件中的令牌设置密码并激活账号
+// @Tags         认证 (Authentication)
+// @Accept       json
+// @Produce      json
+// @Param        request body auth.AcceptInvitationDTO true "邀请令牌与新密码"
+// @Success      200 {object} response.DataResponse[auth.UserBriefDTO] "账号已激活"
+// @Failure      400 {object} response.ErrorResponse "参数错误、邀请无效或已过期"
+// @Router       /api/auth/invitations/accept [post]
+func (h *AuthHandler) AcceptInvitation(c *gin.Context) {
+	var req auth.AcceptInvitationDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.acceptInvitationHandler.Handle(c.Request.Context(), auth.AcceptInvitationCommand(req))
+	if err != nil {
+		if errors.Is(err, auth.ErrInvitationNotFound) ||
+			errors.Is(err, auth.ErrInvitationExpired) ||
+			errors.Is(err, auth.ErrInvitationAccepted) {
+			response.BadRequest(c, "invalid or expired invitation")
+			return
+		}
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.OK(c, "invitation accepted, account activated", result)
+}
+
 // Login 用户登录
 //
 // @Summary      用户登录
@@ -95,10 +148,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// 检查是否需要 2FA
 	if result.Requires2FA {
-		response.OK(c, "Two factor authentication required", &auth.TwoFARequiredDTO{
-			Requires2FA:  true,
-			SessionToken: result.SessionToken,
-		})
+		response.OK(c, "Two factor authentication required", auth.ToTwoFARequiredDTO(result.SessionToken, result.TwoFAMethods))
 		return
 	}
 
@@ -109,7 +159,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // Login2FA 二次认证登录
 //
 // @Summary      二次认证登录
-// @Description  使用session_token和2FA验证码完成登录（适用于启用了2FA的账户）
+// @Description  使用session_token和2FA验证码完成登录（适用于启用了2FA的账户）。method 可选 totp（默认）、email、sms
 // @Tags         认证 (Authentication)
 // @Accept       json
 // @Produce      json
@@ -128,6 +178,7 @@ func (h *AuthHandler) Login2FA(c *gin.Context) {
 	result, err := h.login2FAHandler.Handle(c.Request.Context(), auth.Login2FACommand{
 		SessionToken:  req.SessionToken,
 		TwoFactorCode: req.TwoFactorCode,
+		Method:        req.Method,
 		ClientIP:      c.ClientIP(),
 		UserAgent:     c.Request.UserAgent(),
 	})
@@ -140,6 +191,41 @@ func (h *AuthHandler) Login2FA(c *gin.Context) {
 	response.OK(c, "login successful", result.ToLoginResponse())
 }
 
+// Send2FACode 下发二次认证验证码
+//
+// @Summary      下发二次认证验证码
+// @Description  向已绑定的邮箱或手机号发送一次性验证码，用于随后的二次认证登录。验证码有效期5分钟，发送受频率限制
+// @Tags         认证 (Authentication)
+// @Accept       json
+// @Produce      json
+// @Param        request body auth.Send2FACodeDTO true "会话令牌和验证方式"
+// @Success      200 {object} response.DataResponse[auth.Send2FACodeResultDTO] "验证码已发送"
+// @Failure      400 {object} response.ErrorResponse "验证方式不可用或发送过于频繁"
+// @Failure      401 {object} response.ErrorResponse "session_token无效"
+// @Router       /api/auth/login/2fa/send [post]
+func (h *AuthHandler) Send2FACode(c *gin.Context) {
+	var req auth.Send2FACodeDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.send2FACodeHandler.Handle(c.Request.Context(), auth.Send2FACodeCommand{
+		SessionToken: req.SessionToken,
+		Method:       req.Method,
+	})
+	if err != nil {
+		if errors.Is(err, auth.ErrOTPThrottled) {
+			response.TooManyRequests(c)
+			return
+		}
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.OK(c, "verification code sent", result)
+}
+
 // RefreshToken 刷新访问令牌
 //
 // @Summary      刷新访问令牌
@@ -163,10 +249,14 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	if err != nil {
 		// 处理特定错误
-		if errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrTokenExpired) {
+		if errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrTokenExpired) || errors.Is(err, auth.ErrTokenRevoked) {
 			response.Unauthorized(c, "invalid or expired token")
 			return
 		}
+		if errors.Is(err, auth.ErrPasswordChangeRequired) {
+			response.Unauthorized(c, "password change required, please login again")
+			return
+		}
 		response.Unauthorized(c, err.Error())
 		return
 	}
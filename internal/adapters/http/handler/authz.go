@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/authz"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
+)
+
+// AuthzHandler handles authorization decision explanations (DDD+CQRS Use Case Pattern)
+//
+// 按路由试运行时从权限注册表反查路由所需的权限和角色，
+// 注册表在路由初始化时填充，因此只能解释经权限守卫注册的路由。
+type AuthzHandler struct {
+	explainHandler *authz.ExplainPermissionHandler
+	registry       *role.PermissionRegistry
+}
+
+// NewAuthzHandler creates a new AuthzHandler instance
+func NewAuthzHandler(explainHandler *authz.ExplainPermissionHandler, registry *role.PermissionRegistry) *AuthzHandler {
+	return &AuthzHandler{
+		explainHandler: explainHandler,
+		registry:       registry,
+	}
+}
+
+// ExplainPermission explains whether a user holds a permission
+//
+// @Summary      解释权限决策
+// @Description  解释用户是否拥有指定权限：匹配的角色授予及其继承来源、命中的通配符模式、ABAC 条件、PAT 权限范围和权限缓存状态
+// @Tags         管理员 - 授权解释 (Admin - Authorization)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        params query authz.ExplainPermissionDTO true "查询参数"
+// @Success      200 {object} response.DataResponse[authz.ExplanationDTO] "解释结果"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户或令牌不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/authz/explain [get]
+// @x-permission {"scope":"admin:authz:read"}
+func (h *AuthzHandler) ExplainPermission(c *gin.Context) {
+	var req authz.ExplainPermissionDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	h.explain(c, authz.ExplainPermissionQuery{
+		UserID:         req.UserID,
+		Permission:     req.Permission,
+		OrganizationID: req.OrganizationID,
+		PATID:          req.PATID,
+	})
+}
+
+// ExplainRoute dry-runs authorization of a route for a user
+//
+// @Summary      路由授权试运行
+// @Description  按请求方法和路径（路由模板或具体路径）反查所需权限与角色，并解释指定用户能否访问
+// @Tags         管理员 - 授权解释 (Admin - Authorization)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body authz.ExplainRouteDTO true "路由信息"
+// @Success      200 {object} response.DataResponse[authz.ExplanationDTO] "解释结果"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "路由、用户或令牌不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/authz/explain [post]
+// @x-permission {"scope":"admin:authz:read"}
+func (h *AuthzHandler) ExplainRoute(c *gin.Context) {
+	var req authz.ExplainRouteDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	query, ok := h.routeQuery(c, req.Method, req.Path)
+	if !ok {
+		return
+	}
+	query.UserID = req.UserID
+	query.OrganizationID = req.OrganizationID
+	query.PATID = req.PATID
+
+	h.explain(c, query)
+}
+
+// ExplainOwnPermission explains whether the current user holds a permission
+//
+// @Summary      解释我的权限
+// @Description  解释当前用户是否拥有指定权限；携带租户标识时按组织内权限解释，使用 PAT 时附带令牌权限范围
+// @Tags         用户 - 授权解释 (User - Authorization)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        params query authz.ExplainSelfDTO true "查询参数"
+// @Success      200 {object} response.DataResponse[authz.ExplanationDTO] "解释结果"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/authz/explain [get]
+// @x-permission {"scope":"user:authz:read"}
+func (h *AuthzHandler) ExplainOwnPermission(c *gin.Context) {
+	var req authz.ExplainSelfDTO
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	h.explain(c, authz.ExplainPermissionQuery{
+		UserID:         c.GetUint("user_id"),
+		Permission:     req.Permission,
+		OrganizationID: c.GetUint("organization_id"),
+		PATID:          c.GetUint("pat_id"),
+	})
+}
+
+// ExplainOwnRoute dry-runs authorization of a route for the current user
+//
+// @Summary      我的路由授权试运行
+// @Description  解释当前用户能否访问指定路由
+// @Tags         用户 - 授权解释 (User - Authorization)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body authz.ExplainSelfRouteDTO true "路由信息"
+// @Success      200 {object} response.DataResponse[authz.ExplanationDTO] "解释结果"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      404 {object} response.ErrorResponse "路由不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/authz/explain [post]
+// @x-permission {"scope":"user:authz:read"}
+func (h *AuthzHandler) ExplainOwnRoute(c *gin.Context) {
+	var req authz.ExplainSelfRouteDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	query, ok := h.routeQuery(c, req.Method, req.Path)
+	if !ok {
+		return
+	}
+	query.UserID = c.GetUint("user_id")
+	query.OrganizationID = c.GetUint("organization_id")
+	query.PATID = c.GetUint("pat_id")
+
+	h.explain(c, query)
+}
+
+// routeQuery 从权限注册表反查路由要求，路由未登记时返回 404
+func (h *AuthzHandler) routeQuery(c *gin.Context, method, path string) (authz.ExplainPermissionQuery, bool) {
+	binding, ok := h.registry.LookupRoute(method, path)
+	if !ok {
+		response.NotFound(c, "route")
+		return authz.ExplainPermissionQuery{}, false
+	}
+
+	return authz.ExplainPermissionQuery{
+		Permission:   binding.Permission,
+		RequiredRole: binding.Role,
+		Method:       binding.Method,
+		Route:        binding.Path,
+	}, true
+}
+
+// explain 执行解释查询并输出结果
+func (h *AuthzHandler) explain(c *gin.Context, query authz.ExplainPermissionQuery) {
+	result, err := h.explainHandler.Handle(c.Request.Context(), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, authz.ErrUserNotFound):
+			response.NotFound(c, "user")
+		case errors.Is(err, authz.ErrTokenNotFound):
+			response.NotFound(c, "token")
+		default:
+			response.InternalError(c, err.Error())
+		}
+		return
+	}
+
+	response.OK(c, "success", result)
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/approval"
+)
+
+// ListChangeRequestsQuery 变更请求列表查询参数
+type ListChangeRequestsQuery struct {
+	response.PaginationQueryDTO
+
+	// Status 状态过滤
+	Status string `form:"status" json:"status" binding:"omitempty,oneof=pending approved rejected expired failed" enums:"pending,approved,rejected,expired,failed"`
+	// Operation 操作过滤
+	Operation string `form:"operation" json:"operation" binding:"omitempty,oneof=role.set_permissions role.set_parent role.set_condition user.assign_roles user.delete user.grant_role group.set_roles group.add_members" enums:"role.set_permissions,role.set_parent,role.set_condition,user.assign_roles,user.delete,user.grant_role,group.set_roles,group.add_members"`
+	// RequesterID 按申请人过滤
+	RequesterID *uint `form:"requester_id" json:"requester_id" binding:"omitempty,gt=0"`
+}
+
+// ToQuery 转换为 Application 层 Query 对象
+func (q *ListChangeRequestsQuery) ToQuery() approval.ListChangeRequestsQuery {
+	return approval.ListChangeRequestsQuery{
+		Status:      q.Status,
+		Operation:   q.Operation,
+		RequesterID: q.RequesterID,
+		Page:        q.GetPage(),
+		Limit:       q.GetLimit(),
+	}
+}
+
+// ChangeRequestHandler handles four-eyes change request operations (DDD+CQRS Use Case Pattern)
+type ChangeRequestHandler struct {
+	// Command Handlers
+	approveHandler *approval.ApproveChangeRequestHandler
+	rejectHandler  *approval.RejectChangeRequestHandler
+
+	// Query Handlers
+	getHandler  *approval.GetChangeRequestHandler
+	listHandler *approval.ListChangeRequestsHandler
+}
+
+// NewChangeRequestHandler creates a new ChangeRequestHandler instance
+func NewChangeRequestHandler(
+	approveHandler *approval.ApproveChangeRequestHandler,
+	rejectHandler *approval.RejectChangeRequestHandler,
+	getHandler *approval.GetChangeRequestHandler,
+	listHandler *approval.ListChangeRequestsHandler,
+) *ChangeRequestHandler {
+	return &ChangeRequestHandler{
+		approveHandler: approveHandler,
+		rejectHandler:  rejectHandler,
+		getHandler:     getHandler,
+		listHandler:    listHandler,
+	}
+}
+
+// ListChangeRequests lists change requests
+//
+// @Summary      变更请求列表
+// @Description  分页获取待审批及历史变更请求，可按状态、操作与申请人过滤
+// @Tags         管理员 - 变更审批 (Admin - Change Requests)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        params query ListChangeRequestsQuery false "查询参数"
+// @Success      200 {object} response.PagedResponse[approval.ChangeRequestDTO] "变更请求列表"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/change-requests [get]
+// @x-permission {"scope":"admin:change_requests:read"}
+func (h *ChangeRequestHandler) ListChangeRequests(c *gin.Context) {
+	var q ListChangeRequestsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.listHandler.Handle(c.Request.Context(), q.ToQuery())
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
+	response.List(c, "success", result.Requests, meta)
+}
+
+// GetChangeRequest gets a change request by ID
+//
+// @Summary      获取变更请求详情
+// @Description  获取变更请求详情，包括原始命令内容与审批结果
+// @Tags         管理员 - 变更审批 (Admin - Change Requests)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "变更请求ID" minimum(1)
+// @Success      200 {object} response.DataResponse[approval.ChangeRequestDTO] "变更请求详情"
+// @Failure      400 {object} response.ErrorResponse "无效的变更请求ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "变更请求不存在"
+// @Router       /api/admin/change-requests/{id} [get]
+// @x-permission {"scope":"admin:change_requests:read"}
+func (h *ChangeRequestHandler) GetChangeRequest(c *gin.Context) {
+	id, ok := changeRequestIDFrom(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getHandler.Handle(c.Request.Context(), approval.GetChangeRequestQuery{RequestID: id})
+	if err != nil {
+		handleChangeRequestError(c, err)
+		return
+	}
+
+	response.OK(c, "success", result)
+}
+
+// ApproveChangeRequest approves and applies a change request
+//
+// @Summary      批准变更请求
+// @Description  第二名管理员批准变更请求后立即执行原始操作；申请人不能批准自己的请求，审批人需具备该操作所需的权限。执行失败时请求状态为 failed
+// @Tags         管理员 - 变更审批 (Admin - Change Requests)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "变更请求ID" minimum(1)
+// @Param        request body approval.ReviewChangeRequestDTO false "审批意见"
+// @Success      200 {object} response.DataResponse[approval.ChangeRequestDTO] "审批结果"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "申请人不能批准自己的请求或审批人权限不足"
+// @Failure      404 {object} response.ErrorResponse "变更请求不存在"
+// @Failure      409 {object} response.ErrorResponse "变更请求已处理或已过期"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/change-requests/{id}/approve [post]
+// @x-permission {"scope":"admin:change_requests:review"}
+func (h *ChangeRequestHandler) ApproveChangeRequest(c *gin.Context) {
+	id, req, reviewerID, ok := bindReview(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.approveHandler.Handle(c.Request.Context(), approval.ApproveChangeRequestCommand{
+		RequestID:    id,
+		ReviewerID:   reviewerID,
+		ReviewerName: c.GetString("username"),
+		Comment:      req.Comment,
+	})
+	if err != nil {
+		handleChangeRequestError(c, err)
+		return
+	}
+
+	if result.Status == approval.StatusFailed {
+		response.OK(c, "change request approved but execution failed", result)
+		return
+	}
+	response.OK(c, "change request approved and applied", result)
+}
+
+// RejectChangeRequest rejects a change request
+//
+// @Summary      拒绝变更请求
+// @Description  拒绝变更请求，申请人拒绝自己的请求即为撤回
+// @Tags         管理员 - 变更审批 (Admin - Change Requests)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "变更请求ID" minimum(1)
+// @Param        request body approval.ReviewChangeRequestDTO false "拒绝原因"
+// @Success      200 {object} response.DataResponse[approval.ChangeRequestDTO] "已拒绝"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "审批人权限不足"
+// @Failure      404 {object} response.ErrorResponse "变更请求不存在"
+// @Failure      409 {object} response.ErrorResponse "变更请求已处理或已过期"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/change-requests/{id}/reject [post]
+// @x-permission {"scope":"admin:change_requests:review"}
+func (h *ChangeRequestHandler) RejectChangeRequest(c *gin.Context) {
+	id, req, reviewerID, ok := bindReview(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.rejectHandler.Handle(c.Request.Context(), approval.RejectChangeRequestCommand{
+		RequestID:    id,
+		ReviewerID:   reviewerID,
+		ReviewerName: c.GetString("username"),
+		Comment:      req.Comment,
+	})
+	if err != nil {
+		handleChangeRequestError(c, err)
+		return
+	}
+
+	response.OK(c, "change request rejected", result)
+}
+
+// submitChangeRequest 将需要审批的操作提交为变更请求，响应 202 Accepted
+func submitChangeRequest(c *gin.Context, h *approval.SubmitChangeRequestHandler, operation, resourceID string, payload any) {
+	requesterID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.Handle(c.Request.Context(), approval.SubmitChangeRequestCommand{
+		Operation:     operation,
+		ResourceID:    resourceID,
+		Payload:       payload,
+		RequesterID:   requesterID,
+		RequesterName: c.GetString("username"),
+	})
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, "change request submitted for approval", result)
+}
+
+// bindReview 解析审批请求的路径 ID、审批意见与当前用户
+func bindReview(c *gin.Context) (uint, approval.ReviewChangeRequestDTO, uint, bool) {
+	var req approval.ReviewChangeRequestDTO
+
+	id, ok := changeRequestIDFrom(c)
+	if !ok {
+		return 0, req, 0, false
+	}
+
+	// 审批意见可选，允许空请求体
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err.Error())
+			return 0, req, 0, false
+		}
+	}
+
+	reviewerID, ok := getUserID(c)
+	if !ok {
+		return 0, req, 0, false
+	}
+
+	return id, req, reviewerID, true
+}
+
+// changeRequestIDFrom 解析路径中的变更请求 ID
+func changeRequestIDFrom(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		response.BadRequest(c, "invalid change request ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleChangeRequestError 变更审批错误映射
+func handleChangeRequestError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, approval.ErrChangeRequestNotFound):
+		response.NotFound(c, "change request")
+	case errors.Is(err, approval.ErrSelfApproval), errors.Is(err, approval.ErrReviewerNotAuthorized):
+		response.Forbidden(c, err.Error())
+	case errors.Is(err, approval.ErrChangeRequestNotPending), errors.Is(err, approval.ErrChangeRequestExpired):
+		response.Conflict(c, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
@@ -0,0 +1,502 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/approval"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/group"
+)
+
+// ListGroupsQuery 用户组列表查询参数
+type ListGroupsQuery struct {
+	response.PaginationQueryDTO
+}
+
+// ToQuery 转换为 Application 层 Query 对象
+func (q *ListGroupsQuery) ToQuery() group.ListGroupsQuery {
+	return group.ListGroupsQuery{
+		Page:  q.GetPage(),
+		Limit: q.GetLimit(),
+	}
+}
+
+// ListGroupMembersQuery 用户组成员列表查询参数
+type ListGroupMembersQuery struct {
+	response.PaginationQueryDTO
+}
+
+// ToQuery 转换为 Application 层 Query 对象
+func (q *ListGroupMembersQuery) ToQuery(groupID uint) group.ListGroupMembersQuery {
+	return group.ListGroupMembersQuery{
+		GroupID: groupID,
+		Page:    q.GetPage(),
+		Limit:   q.GetLimit(),
+	}
+}
+
+// GroupHandler handles user group operations (DDD+CQRS Use Case Pattern)
+type GroupHandler struct {
+	// Command Handlers
+	createHandler        *group.CreateGroupHandler
+	updateHandler        *group.UpdateGroupHandler
+	deleteHandler        *group.DeleteGroupHandler
+	addMembersHandler    *group.AddGroupMembersHandler
+	removeMembersHandler *group.RemoveGroupMembersHandler
+	setRolesHandler      *group.SetGroupRolesHandler
+	importHandler        *group.ImportGroupsHandler
+
+	// Query Handlers
+	getHandler         *group.GetGroupHandler
+	listHandler        *group.ListGroupsHandler
+	listMembersHandler *group.ListGroupMembersHandler
+
+	// 设置用户组角色、添加成员会改变成员权限，受双人审批保护
+	submitChangeHandler *approval.SubmitChangeRequestHandler
+}
+
+// NewGroupHandler creates a new GroupHandler instance
+func NewGroupHandler(
+	createHandler *group.CreateGroupHandler,
+	updateHandler *group.UpdateGroupHandler,
+	deleteHandler *group.DeleteGroupHandler,
+	addMembersHandler *group.AddGroupMembersHandler,
+	removeMembersHandler *group.RemoveGroupMembersHandler,
+	setRolesHandler *group.SetGroupRolesHandler,
+	importHandler *group.ImportGroupsHandler,
+	getHandler *group.GetGroupHandler,
+	listHandler *group.ListGroupsHandler,
+	listMembersHandler *group.ListGroupMembersHandler,
+	submitChangeHandler *approval.SubmitChangeRequestHandler,
+) *GroupHandler {
+	return &GroupHandler{
+		createHandler:        createHandler,
+		updateHandler:        updateHandler,
+		deleteHandler:        deleteHandler,
+		addMembersHandler:    addMembersHandler,
+		removeMembersHandler: removeMembersHandler,
+		setRolesHandler:      setRolesHandler,
+		importHandler:        importHandler,
+		getHandler:           getHandler,
+		listHandler:          listHandler,
+		listMembersHandler:   listMembersHandler,
+		submitChangeHandler:  submitChangeHandler,
+	}
+}
+
+// CreateGroup creates a new user group
+//
+// @Summary      创建用户组
+// @Description  管理员创建用户组，可同时分配全局角色；组内成员共同获得这些角色
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body group.CreateGroupDTO true "用户组信息"
+// @Success      201 {object} response.DataResponse[group.GroupDTO] "用户组创建成功"
+// @Failure      400 {object} response.ErrorResponse "参数错误、名称格式无效或角色不是全局角色"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "角色不存在"
+// @Failure      409 {object} response.ErrorResponse "用户组名称已存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/groups [post]
+// @x-permission {"scope":"admin:groups:create"}
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	var req group.CreateGroupDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.createHandler.Handle(c.Request.Context(), group.CreateGroupCommand{
+		Name:        req.Name,
+		Description: req.Description,
+		RoleIDs:     req.RoleIDs,
+	})
+	if err != nil {
+		handleGroupError(c, err)
+		return
+	}
+
+	response.Created(c, "group created successfully", result)
+}
+
+// ListGroups lists all user groups
+//
+// @Summary      用户组列表
+// @Description  分页获取所有用户组及其角色
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        params query ListGroupsQuery false "查询参数"
+// @Success      200 {object} response.PagedResponse[group.GroupDTO] "用户组列表"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/groups [get]
+// @x-permission {"scope":"admin:groups:read"}
+func (h *GroupHandler) ListGroups(c *gin.Context) {
+	var q ListGroupsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.listHandler.Handle(c.Request.Context(), q.ToQuery())
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
+	response.List(c, "success", result.Groups, meta)
+}
+
+// GetGroup gets a user group by ID
+//
+// @Summary      获取用户组详情
+// @Description  根据用户组ID获取用户组及其角色
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户组ID" minimum(1)
+// @Success      200 {object} response.DataResponse[group.GroupDTO] "用户组详情"
+// @Failure      400 {object} response.ErrorResponse "无效的用户组ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户组不存在"
+// @Router       /api/admin/groups/{id} [get]
+// @x-permission {"scope":"admin:groups:read"}
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+	id, ok := groupIDFrom(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getHandler.Handle(c.Request.Context(), group.GetGroupQuery{GroupID: id})
+	if err != nil {
+		handleGroupError(c, err)
+		return
+	}
+
+	response.OK(c, "success", result)
+}
+
+// UpdateGroup updates a user group
+//
+// @Summary      更新用户组
+// @Description  管理员更新用户组名称和描述
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户组ID" minimum(1)
+// @Param        request body group.UpdateGroupDTO true "更新信息"
+// @Success      200 {object} response.DataResponse[group.GroupDTO] "用户组更新成功"
+// @Failure      400 {object} response.ErrorResponse "无效的用户组ID或参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户组不存在"
+// @Failure      409 {object} response.ErrorResponse "用户组名称已存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/groups/{id} [put]
+// @x-permission {"scope":"admin:groups:update"}
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+	id, ok := groupIDFrom(c)
+	if !ok {
+		return
+	}
+
+	var req group.UpdateGroupDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.updateHandler.Handle(c.Request.Context(), group.UpdateGroupCommand{
+		GroupID:     id,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		handleGroupError(c, err)
+		return
+	}
+
+	response.OK(c, "group updated successfully", result)
+}
+
+// DeleteGroup deletes a user group
+//
+// @Summary      删除用户组
+// @Description  管理员删除用户组，成员随即失去经由该用户组获得的角色
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户组ID" minimum(1)
+// @Success      200 {object} response.MessageResponse "用户组删除成功"
+// @Failure      400 {object} response.ErrorResponse "无效的用户组ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户组不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/groups/{id} [delete]
+// @x-permission {"scope":"admin:groups:delete"}
+func (h *GroupHandler) DeleteGroup(c *gin.Context) {
+	id, ok := groupIDFrom(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteHandler.Handle(c.Request.Context(), group.DeleteGroupCommand{GroupID: id}); err != nil {
+		handleGroupError(c, err)
+		return
+	}
+
+	response.OK(c, "group deleted successfully", nil)
+}
+
+// SetGroupRoles sets the roles carried by a user group
+//
+// @Summary      设置用户组角色
+// @Description  覆盖用户组的角色（仅限全局角色），组内全部成员的权限随之变更。启用双人审批时提交变更请求，批准后生效
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户组ID" minimum(1)
+// @Param        request body group.SetGroupRolesDTO true "角色ID列表"
+// @Success      200 {object} response.DataResponse[group.GroupDTO] "角色设置成功"
+// @Success      202 {object} response.DataResponse[approval.ChangeRequestDTO] "已提交审批"
+// @Failure      400 {object} response.ErrorResponse "参数错误或角色不是全局角色"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户组或角色不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/groups/{id}/roles [put]
+// @x-permission {"scope":"admin:groups:update"}
+func (h *GroupHandler) SetGroupRoles(c *gin.Context) {
+	id, ok := groupIDFrom(c)
+	if !ok {
+		return
+	}
+
+	var req group.SetGroupRolesDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	cmd := group.SetGroupRolesCommand{
+		GroupID: id,
+		RoleIDs: req.RoleIDs,
+	}
+	if h.submitChangeHandler.Required(c.Request.Context(), approval.OperationSetGroupRoles) {
+		submitChangeRequest(c, h.submitChangeHandler, approval.OperationSetGroupRoles, c.Param("id"), cmd)
+		return
+	}
+
+	result, err := h.setRolesHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		handleGroupError(c, err)
+		return
+	}
+
+	response.OK(c, "group roles updated successfully", result)
+}
+
+// ListGroupMembers lists members of a user group
+//
+// @Summary      用户组成员列表
+// @Description  分页获取用户组成员
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户组ID" minimum(1)
+// @Param        params query ListGroupMembersQuery false "查询参数"
+// @Success      200 {object} response.PagedResponse[group.GroupMemberDTO] "成员列表"
+// @Failure      400 {object} response.ErrorResponse "无效的用户组ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户组不存在"
+// @Router       /api/admin/groups/{id}/members [get]
+// @x-permission {"scope":"admin:groups:read"}
+func (h *GroupHandler) ListGroupMembers(c *gin.Context) {
+	id, ok := groupIDFrom(c)
+	if !ok {
+		return
+	}
+
+	var q ListGroupMembersQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.listMembersHandler.Handle(c.Request.Context(), q.ToQuery(id))
+	if err != nil {
+		handleGroupError(c, err)
+		return
+	}
+
+	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
+	response.List(c, "success", result.Members, meta)
+}
+
+// AddGroupMembers adds users to a user group
+//
+// @Summary      添加用户组成员
+// @Description  批量将用户加入用户组（已是成员的用户忽略），成员即时获得用户组角色。启用双人审批时提交变更请求，批准后生效
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户组ID" minimum(1)
+// @Param        request body group.GroupMembersDTO true "用户ID列表（最多 500 个）"
+// @Success      200 {object} response.MessageResponse "成员添加成功"
+// @Success      202 {object} response.DataResponse[approval.ChangeRequestDTO] "已提交审批"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户组或用户不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/groups/{id}/members [post]
+// @x-permission {"scope":"admin:groups:update"}
+func (h *GroupHandler) AddGroupMembers(c *gin.Context) {
+	id, ok := groupIDFrom(c)
+	if !ok {
+		return
+	}
+
+	var req group.GroupMembersDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	cmd := group.AddGroupMembersCommand{
+		GroupID: id,
+		UserIDs: req.UserIDs,
+	}
+	if h.submitChangeHandler.Required(c.Request.Context(), approval.OperationAddGroupMembers) {
+		submitChangeRequest(c, h.submitChangeHandler, approval.OperationAddGroupMembers, c.Param("id"), cmd)
+		return
+	}
+
+	if err := h.addMembersHandler.Handle(c.Request.Context(), cmd); err != nil {
+		handleGroupError(c, err)
+		return
+	}
+
+	response.OK(c, "group members added successfully", nil)
+}
+
+// RemoveGroupMember removes a user from a user group
+//
+// @Summary      移除用户组成员
+// @Description  将用户移出用户组，用户随即失去经由该用户组获得的角色
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户组ID" minimum(1)
+// @Param        user_id path int true "用户ID" minimum(1)
+// @Success      200 {object} response.MessageResponse "成员移除成功"
+// @Failure      400 {object} response.ErrorResponse "无效的ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户组不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/groups/{id}/members/{user_id} [delete]
+// @x-permission {"scope":"admin:groups:update"}
+func (h *GroupHandler) RemoveGroupMember(c *gin.Context) {
+	id, ok := groupIDFrom(c)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil || userID == 0 {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	if err = h.removeMembersHandler.Handle(c.Request.Context(), group.RemoveGroupMembersCommand{
+		GroupID: id,
+		UserIDs: []uint{uint(userID)},
+	}); err != nil {
+		handleGroupError(c, err)
+		return
+	}
+
+	response.OK(c, "group member removed successfully", nil)
+}
+
+// ImportGroups imports user groups in bulk
+//
+// @Summary      批量导入用户组
+// @Description  按名称创建或更新用户组，并按角色名和用户名同步角色与成员；省略的字段保持不变，支持部分失败
+// @Tags         管理员 - 用户组管理 (Admin - Group Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body group.ImportGroupsDTO true "用户组列表（最多 100 个）"
+// @Success      200 {object} response.DataResponse[group.ImportGroupsResultDTO] "导入结果"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/groups/import [post]
+// @x-permission {"scope":"admin:groups:import"}
+func (h *GroupHandler) ImportGroups(c *gin.Context) {
+	var req group.ImportGroupsDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.importHandler.Handle(c.Request.Context(), group.ImportGroupsCommand{
+		Groups: req.Groups,
+	})
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "groups imported", result)
+}
+
+// groupIDFrom 解析路径中的用户组 ID
+func groupIDFrom(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		response.BadRequest(c, "invalid group ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleGroupError 用户组管理错误映射
+func handleGroupError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, group.ErrGroupNotFound):
+		response.NotFound(c, "group")
+	case errors.Is(err, group.ErrUserNotFound):
+		response.NotFound(c, "user")
+	case errors.Is(err, group.ErrRoleNotFound):
+		response.NotFound(c, "role")
+	case errors.Is(err, group.ErrGroupNameExists):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, group.ErrInvalidGroupName), errors.Is(err, group.ErrRoleNotGlobal):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
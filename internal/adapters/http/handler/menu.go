@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -17,8 +18,9 @@ type MenuHandler struct {
 	reorderMenusHandler *menu.ReorderMenusHandler
 
 	// Query Handlers
-	getMenuHandler   *menu.GetMenuHandler
-	listMenusHandler *menu.ListMenusHandler
+	getMenuHandler       *menu.GetMenuHandler
+	listMenusHandler     *menu.ListMenusHandler
+	listUserMenusHandler *menu.ListUserMenusHandler
 }
 
 // NewMenuHandler creates a new MenuHandler instance
@@ -29,6 +31,7 @@ func NewMenuHandler(
 	reorderMenusHandler *menu.ReorderMenusHandler,
 	getMenuHandler *menu.GetMenuHandler,
 	listMenusHandler *menu.ListMenusHandler,
+	listUserMenusHandler *menu.ListUserMenusHandler,
 ) *MenuHandler {
 	return &MenuHandler{
 		createMenuHandler:   createMenuHandler,
@@ -37,6 +40,8 @@ func NewMenuHandler(
 		reorderMenusHandler: reorderMenusHandler,
 		getMenuHandler:      getMenuHandler,
 		listMenusHandler:    listMenusHandler,
+
+		listUserMenusHandler: listUserMenusHandler,
 	}
 }
 
@@ -48,6 +53,8 @@ type CreateMenuRequest struct {
 	ParentID *uint  `json:"parent_id" example:"0"`
 	Order    int    `json:"order" example:"1"`
 	Visible  *bool  `json:"visible" example:"true"`
+	// Permission 访问菜单所需的权限代码，支持通配符，留空表示不限制
+	Permission string `json:"permission" binding:"omitempty,max=100" example:"admin:users:*"`
 }
 
 // UpdateMenuRequest 更新菜单请求
@@ -58,6 +65,8 @@ type UpdateMenuRequest struct {
 	ParentID *uint   `json:"parent_id"`
 	Order    *int    `json:"order"`
 	Visible  *bool   `json:"visible"`
+	// Permission 传空字符串表示取消权限限制
+	Permission *string `json:"permission" binding:"omitempty,max=100"`
 }
 
 // ReorderMenusRequest 批量更新排序请求
@@ -105,9 +114,15 @@ func (h *MenuHandler) Create(c *gin.Context) {
 		ParentID: req.ParentID,
 		Order:    req.Order,
 		Visible:  visible,
+
+		Permission: req.Permission,
 	})
 
 	if err != nil {
+		if errors.Is(err, menu.ErrInvalidMenuPermission) {
+			response.BadRequest(c, "Invalid menu permission")
+			return
+		}
 		response.InternalError(c, "Failed to create menu")
 		return
 	}
@@ -209,7 +224,7 @@ func (h *MenuHandler) Update(c *gin.Context) {
 	}
 
 	// 调用 Use Case Handler
-	menu, err := h.updateMenuHandler.Handle(c.Request.Context(), menu.UpdateMenuCommand{
+	result, err := h.updateMenuHandler.Handle(c.Request.Context(), menu.UpdateMenuCommand{
 		MenuID:   uint(id),
 		Title:    req.Title,
 		Path:     req.Path,
@@ -217,14 +232,20 @@ func (h *MenuHandler) Update(c *gin.Context) {
 		ParentID: req.ParentID,
 		Order:    req.Order,
 		Visible:  req.Visible,
+
+		Permission: req.Permission,
 	})
 
 	if err != nil {
+		if errors.Is(err, menu.ErrInvalidMenuPermission) {
+			response.BadRequest(c, "Invalid menu permission")
+			return
+		}
 		response.InternalError(c, "Failed to update menu")
 		return
 	}
 
-	response.OK(c, "menu updated successfully", menu)
+	response.OK(c, "menu updated successfully", result)
 }
 
 // Delete 删除菜单
@@ -307,3 +328,32 @@ func (h *MenuHandler) Reorder(c *gin.Context) {
 
 	response.NoContent(c)
 }
+
+// ListMine 获取当前用户可访问的菜单树
+//
+// @Summary      获取我的菜单
+// @Description  返回按当前用户权限裁剪后的菜单树（隐藏菜单与无权菜单被移除，保留排序），用于构建前端侧边栏
+// @Tags         用户 - 菜单 (User - Menus)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.DataResponse[[]menu.MenuDTO] "菜单树"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/menus [get]
+// @x-permission {"scope":"user:menus:read"}
+func (h *MenuHandler) ListMine(c *gin.Context) {
+	permissions, _ := c.Get("permissions")
+	permissionList, _ := permissions.([]string)
+
+	menus, err := h.listUserMenusHandler.Handle(c.Request.Context(), menu.ListUserMenusQuery{
+		Permissions: permissionList,
+	})
+	if err != nil {
+		response.InternalError(c, "Failed to fetch menus")
+		return
+	}
+
+	response.OK(c, "success", menus)
+}
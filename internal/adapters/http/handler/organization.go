@@ -0,0 +1,527 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/organization"
+)
+
+// ListOrganizationsQuery 组织列表查询参数
+type ListOrganizationsQuery struct {
+	response.PaginationQueryDTO
+}
+
+// ToQuery 转换为 Application 层 Query 对象
+func (q *ListOrganizationsQuery) ToQuery() organization.ListOrganizationsQuery {
+	return organization.ListOrganizationsQuery{
+		Page:  q.GetPage(),
+		Limit: q.GetLimit(),
+	}
+}
+
+// ListMembersQuery 组织成员列表查询参数
+type ListMembersQuery struct {
+	response.PaginationQueryDTO
+}
+
+// ToQuery 转换为 Application 层 Query 对象
+func (q *ListMembersQuery) ToQuery(organizationID uint) organization.ListMembersQuery {
+	return organization.ListMembersQuery{
+		OrganizationID: organizationID,
+		Page:           q.GetPage(),
+		Limit:          q.GetLimit(),
+	}
+}
+
+// OrganizationHandler handles organization and membership operations (DDD+CQRS Use Case Pattern)
+//
+// 成员管理接口同时挂载在 /api/admin/organizations/:id 和租户路由 /api/org 下，
+// 组织 ID 优先取路径参数，否则取 Tenant 中间件解析出的当前组织。
+type OrganizationHandler struct {
+	// Command Handlers
+	createHandler         *organization.CreateOrganizationHandler
+	updateHandler         *organization.UpdateOrganizationHandler
+	deleteHandler         *organization.DeleteOrganizationHandler
+	addMemberHandler      *organization.AddMemberHandler
+	removeMemberHandler   *organization.RemoveMemberHandler
+	setMemberRolesHandler *organization.SetMemberRolesHandler
+
+	// Query Handlers
+	getHandler                   *organization.GetOrganizationHandler
+	listHandler                  *organization.ListOrganizationsHandler
+	listMembersHandler           *organization.ListMembersHandler
+	listUserOrganizationsHandler *organization.ListUserOrganizationsHandler
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler instance
+func NewOrganizationHandler(
+	createHandler *organization.CreateOrganizationHandler,
+	updateHandler *organization.UpdateOrganizationHandler,
+	deleteHandler *organization.DeleteOrganizationHandler,
+	addMemberHandler *organization.AddMemberHandler,
+	removeMemberHandler *organization.RemoveMemberHandler,
+	setMemberRolesHandler *organization.SetMemberRolesHandler,
+	getHandler *organization.GetOrganizationHandler,
+	listHandler *organization.ListOrganizationsHandler,
+	listMembersHandler *organization.ListMembersHandler,
+	listUserOrganizationsHandler *organization.ListUserOrganizationsHandler,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		createHandler:                createHandler,
+		updateHandler:                updateHandler,
+		deleteHandler:                deleteHandler,
+		addMemberHandler:             addMemberHandler,
+		removeMemberHandler:          removeMemberHandler,
+		setMemberRolesHandler:        setMemberRolesHandler,
+		getHandler:                   getHandler,
+		listHandler:                  listHandler,
+		listMembersHandler:           listMembersHandler,
+		listUserOrganizationsHandler: listUserOrganizationsHandler,
+	}
+}
+
+// CreateOrganization creates a new organization
+//
+// @Summary      创建组织
+// @Description  管理员创建新的组织（租户），组织标识用于请求头和子域名解析
+// @Tags         管理员 - 组织管理 (Admin - Organization Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body organization.CreateOrganizationDTO true "组织信息"
+// @Success      201 {object} response.DataResponse[organization.OrganizationDTO] "组织创建成功"
+// @Failure      400 {object} response.ErrorResponse "参数错误或组织标识格式无效"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      409 {object} response.ErrorResponse "组织标识已存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/organizations [post]
+// @x-permission {"scope":"admin:organizations:create"}
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req organization.CreateOrganizationDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.createHandler.Handle(c.Request.Context(), organization.CreateOrganizationCommand{
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, organization.ErrInvalidSlug):
+			response.BadRequest(c, err.Error())
+		case errors.Is(err, organization.ErrSlugAlreadyExists):
+			response.Conflict(c, err.Error())
+		default:
+			response.InternalError(c, err.Error())
+		}
+		return
+	}
+
+	response.Created(c, "organization created successfully", result)
+}
+
+// ListOrganizations lists all organizations
+//
+// @Summary      组织列表
+// @Description  分页获取所有组织
+// @Tags         管理员 - 组织管理 (Admin - Organization Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        params query ListOrganizationsQuery false "查询参数"
+// @Success      200 {object} response.PagedResponse[organization.OrganizationDTO] "组织列表"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/organizations [get]
+// @x-permission {"scope":"admin:organizations:read"}
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	var q ListOrganizationsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.listHandler.Handle(c.Request.Context(), q.ToQuery())
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
+	response.List(c, "success", result.Organizations, meta)
+}
+
+// GetOrganization gets an organization by ID
+//
+// @Summary      获取组织详情
+// @Description  根据组织ID获取组织详细信息
+// @Tags         管理员 - 组织管理 (Admin - Organization Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "组织ID" minimum(1)
+// @Success      200 {object} response.DataResponse[organization.OrganizationDTO] "组织详情"
+// @Failure      400 {object} response.ErrorResponse "无效的组织ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "组织不存在"
+// @Router       /api/admin/organizations/{id} [get]
+// @x-permission {"scope":"admin:organizations:read"}
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid organization ID")
+		return
+	}
+
+	result, err := h.getHandler.Handle(c.Request.Context(), organization.GetOrganizationQuery{
+		OrganizationID: uint(id),
+	})
+	if err != nil {
+		if errors.Is(err, organization.ErrOrganizationNotFound) {
+			response.NotFound(c, "organization")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "success", result)
+}
+
+// UpdateOrganization updates an organization
+//
+// @Summary      更新组织信息
+// @Description  管理员更新组织名称、描述和状态（停用后成员无法进入该组织）
+// @Tags         管理员 - 组织管理 (Admin - Organization Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "组织ID" minimum(1)
+// @Param        request body organization.UpdateOrganizationDTO true "更新信息"
+// @Success      200 {object} response.DataResponse[organization.OrganizationDTO] "组织更新成功"
+// @Failure      400 {object} response.ErrorResponse "无效的组织ID或参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "组织不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/organizations/{id} [put]
+// @x-permission {"scope":"admin:organizations:update"}
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid organization ID")
+		return
+	}
+
+	var req organization.UpdateOrganizationDTO
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.updateHandler.Handle(c.Request.Context(), organization.UpdateOrganizationCommand{
+		OrganizationID: uint(id),
+		Name:           req.Name,
+		Description:    req.Description,
+		Status:         req.Status,
+	})
+	if err != nil {
+		if errors.Is(err, organization.ErrOrganizationNotFound) {
+			response.NotFound(c, "organization")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "organization updated successfully", result)
+}
+
+// DeleteOrganization deletes an organization
+//
+// @Summary      删除组织
+// @Description  管理员删除组织（同时移除全部成员关系）
+// @Tags         管理员 - 组织管理 (Admin - Organization Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "组织ID" minimum(1)
+// @Success      200 {object} response.MessageResponse "组织删除成功"
+// @Failure      400 {object} response.ErrorResponse "无效的组织ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "组织不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/organizations/{id} [delete]
+// @x-permission {"scope":"admin:organizations:delete"}
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid organization ID")
+		return
+	}
+
+	if err = h.deleteHandler.Handle(c.Request.Context(), organization.DeleteOrganizationCommand{
+		OrganizationID: uint(id),
+	}); err != nil {
+		if errors.Is(err, organization.ErrOrganizationNotFound) {
+			response.NotFound(c, "organization")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "organization deleted successfully", nil)
+}
+
+// ListMembers lists members of an organization
+//
+// @Summary      组织成员列表
+// @Description  分页获取组织成员及其在组织内的角色；租户路由下取当前组织
+// @Tags         组织 - 成员管理 (Organization - Member Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "组织ID" minimum(1)
+// @Param        params query ListMembersQuery false "查询参数"
+// @Success      200 {object} response.PagedResponse[organization.MemberDTO] "成员列表"
+// @Failure      400 {object} response.ErrorResponse "无效的组织ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "组织不存在"
+// @Router       /api/admin/organizations/{id}/members [get]
+// @x-permission {"scope":"admin:organizations:read"}
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	orgID, ok := organizationIDFrom(c)
+	if !ok {
+		return
+	}
+
+	var q ListMembersQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.listMembersHandler.Handle(c.Request.Context(), q.ToQuery(orgID))
+	if err != nil {
+		if errors.Is(err, organization.ErrOrganizationNotFound) {
+			response.NotFound(c, "organization")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
+	response.List(c, "success", result.Members, meta)
+}
+
+// AddMember adds a user to an organization
+//
+// @Summary      添加组织成员
+// @Description  将用户加入组织，可同时分配全局角色或本组织角色
+// @Tags         组织 - 成员管理 (Organization - Member Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "组织ID" minimum(1)
+// @Param        request body organization.AddMemberDTO true "成员信息"
+// @Success      201 {object} response.DataResponse[organization.MemberDTO] "成员添加成功"
+// @Failure      400 {object} response.ErrorResponse "参数错误或角色不属于该组织"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "组织、用户或角色不存在"
+// @Failure      409 {object} response.ErrorResponse "用户已是组织成员"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/organizations/{id}/members [post]
+// @x-permission {"scope":"admin:organizations:update"}
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	orgID, ok := organizationIDFrom(c)
+	if !ok {
+		return
+	}
+
+	var req organization.AddMemberDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.addMemberHandler.Handle(c.Request.Context(), organization.AddMemberCommand{
+		OrganizationID: orgID,
+		UserID:         req.UserID,
+		RoleIDs:        req.RoleIDs,
+	})
+	if err != nil {
+		handleMemberError(c, err)
+		return
+	}
+
+	response.Created(c, "member added successfully", result)
+}
+
+// RemoveMember removes a user from an organization
+//
+// @Summary      移除组织成员
+// @Description  将用户移出组织，同时清除其在组织内的角色
+// @Tags         组织 - 成员管理 (Organization - Member Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "组织ID" minimum(1)
+// @Param        user_id path int true "用户ID" minimum(1)
+// @Success      200 {object} response.MessageResponse "成员移除成功"
+// @Failure      400 {object} response.ErrorResponse "无效的ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户不是组织成员"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/organizations/{id}/members/{user_id} [delete]
+// @x-permission {"scope":"admin:organizations:update"}
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	orgID, ok := organizationIDFrom(c)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	if err = h.removeMemberHandler.Handle(c.Request.Context(), organization.RemoveMemberCommand{
+		OrganizationID: orgID,
+		UserID:         uint(userID),
+	}); err != nil {
+		handleMemberError(c, err)
+		return
+	}
+
+	response.OK(c, "member removed successfully", nil)
+}
+
+// SetMemberRoles sets the roles of a member within an organization
+//
+// @Summary      设置成员组织内角色
+// @Description  覆盖成员在组织内的角色，可分配全局角色或本组织角色
+// @Tags         组织 - 成员管理 (Organization - Member Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "组织ID" minimum(1)
+// @Param        user_id path int true "用户ID" minimum(1)
+// @Param        request body organization.SetMemberRolesDTO true "角色ID列表"
+// @Success      200 {object} response.DataResponse[organization.MemberDTO] "角色设置成功"
+// @Failure      400 {object} response.ErrorResponse "参数错误或角色不属于该组织"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户不是组织成员或角色不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/organizations/{id}/members/{user_id}/roles [put]
+// @x-permission {"scope":"admin:organizations:update"}
+func (h *OrganizationHandler) SetMemberRoles(c *gin.Context) {
+	orgID, ok := organizationIDFrom(c)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	var req organization.SetMemberRolesDTO
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.setMemberRolesHandler.Handle(c.Request.Context(), organization.SetMemberRolesCommand{
+		OrganizationID: orgID,
+		UserID:         uint(userID),
+		RoleIDs:        req.RoleIDs,
+	})
+	if err != nil {
+		handleMemberError(c, err)
+		return
+	}
+
+	response.OK(c, "member roles updated successfully", result)
+}
+
+// ListMyOrganizations lists organizations the current user belongs to
+//
+// @Summary      我的组织
+// @Description  获取当前用户所属的组织，可用于在前端切换租户（通过请求头或子域名）
+// @Tags         用户 - 组织 (User - Organizations)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.DataResponse[[]organization.OrganizationDTO] "组织列表"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/organizations [get]
+// @x-permission {"scope":"user:organizations:read"}
+func (h *OrganizationHandler) ListMyOrganizations(c *gin.Context) {
+	result, err := h.listUserOrganizationsHandler.Handle(c.Request.Context(), organization.ListUserOrganizationsQuery{
+		UserID: c.GetUint("user_id"),
+	})
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "success", result)
+}
+
+// organizationIDFrom 获取目标组织 ID：优先取路径参数，否则取 Tenant 中间件解析出的当前组织
+func organizationIDFrom(c *gin.Context) (uint, bool) {
+	if param := c.Param("id"); param != "" {
+		id, err := strconv.ParseUint(param, 10, 32)
+		if err != nil || id == 0 {
+			response.BadRequest(c, "invalid organization ID")
+			return 0, false
+		}
+		return uint(id), true
+	}
+
+	orgID := c.GetUint("organization_id")
+	if orgID == 0 {
+		response.BadRequest(c, "organization is required")
+		return 0, false
+	}
+	return orgID, true
+}
+
+// handleMemberError 成员管理错误映射
+func handleMemberError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, organization.ErrOrganizationNotFound):
+		response.NotFound(c, "organization")
+	case errors.Is(err, organization.ErrUserNotFound):
+		response.NotFound(c, "user")
+	case errors.Is(err, organization.ErrRoleNotFound):
+		response.NotFound(c, "role")
+	case errors.Is(err, organization.ErrNotMember):
+		response.NotFound(c, "member")
+	case errors.Is(err, organization.ErrMemberAlreadyExists):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, organization.ErrRoleNotInOrganization):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
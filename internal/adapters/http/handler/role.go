@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/approval"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
 )
 
@@ -41,11 +43,15 @@ type RoleHandler struct {
 	updateRoleHandler     *role.UpdateRoleHandler
 	deleteRoleHandler     *role.DeleteRoleHandler
 	setPermissionsHandler *role.SetPermissionsHandler
+	setConditionHandler   *role.SetPermissionConditionHandler
+	submitChangeHandler   *approval.SubmitChangeRequestHandler
 
 	// Query Handlers
-	getRoleHandler         *role.GetRoleHandler
-	listRolesHandler       *role.ListRolesHandler
-	listPermissionsHandler *role.ListPermissionsHandler
+	getRoleHandler                 *role.GetRoleHandler
+	listRolesHandler               *role.ListRolesHandler
+	listPermissionsHandler         *role.ListPermissionsHandler
+	getEffectivePermissionsHandler *role.GetEffectivePermissionsHandler
+	listGrantsHandler              *role.ListGrantsHandler
 }
 
 // NewRoleHandler creates a new RoleHandler instance
@@ -57,15 +63,23 @@ func NewRoleHandler(
 	getRoleHandler *role.GetRoleHandler,
 	listRolesHandler *role.ListRolesHandler,
 	listPermissionsHandler *role.ListPermissionsHandler,
+	getEffectivePermissionsHandler *role.GetEffectivePermissionsHandler,
+	setConditionHandler *role.SetPermissionConditionHandler,
+	listGrantsHandler *role.ListGrantsHandler,
+	submitChangeHandler *approval.SubmitChangeRequestHandler,
 ) *RoleHandler {
 	return &RoleHandler{
-		createRoleHandler:      createRoleHandler,
-		updateRoleHandler:      updateRoleHandler,
-		deleteRoleHandler:      deleteRoleHandler,
-		setPermissionsHandler:  setPermissionsHandler,
-		getRoleHandler:         getRoleHandler,
-		listRolesHandler:       listRolesHandler,
-		listPermissionsHandler: listPermissionsHandler,
+		createRoleHandler:              createRoleHandler,
+		updateRoleHandler:              updateRoleHandler,
+		deleteRoleHandler:              deleteRoleHandler,
+		setPermissionsHandler:          setPermissionsHandler,
+		getRoleHandler:                 getRoleHandler,
+		listRolesHandler:               listRolesHandler,
+		listPermissionsHandler:         listPermissionsHandler,
+		getEffectivePermissionsHandler: getEffectivePermissionsHandler,
+		setConditionHandler:            setConditionHandler,
+		listGrantsHandler:              listGrantsHandler,
+		submitChangeHandler:            submitChangeHandler,
 	}
 }
 
@@ -81,7 +95,7 @@ func NewRoleHandler(
 // @Success      201 {object} response.DataResponse[role.CreateRoleResultDTO] "角色创建成功"
 // @Failure      400 {object} response.ErrorResponse "参数错误或角色名已存在"
 // @Failure      401 {object} response.ErrorResponse "未授权"
-// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      403 {object} response.ErrorResponse "权限不足或父角色不属于当前组织"
 // @Failure      500 {object} response.ErrorResponse "服务器内部错误"
 // @Router       /api/admin/roles [post]
 // @x-permission {"scope":"admin:roles:create"}
@@ -97,7 +111,14 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 	result, err := h.createRoleHandler.Handle(c.Request.Context(), role.CreateRoleCommand(req))
 
 	if err != nil {
-		response.InternalError(c, err.Error())
+		switch {
+		case errors.Is(err, role.ErrParentRoleNotFound):
+			response.BadRequest(c, err.Error())
+		case errors.Is(err, role.ErrTenantMismatch):
+			response.Forbidden(c, err.Error())
+		default:
+			response.InternalError(c, err.Error())
+		}
 		return
 	}
 
@@ -181,7 +202,7 @@ func (h *RoleHandler) GetRole(c *gin.Context) {
 // UpdateRole updates a role
 //
 // @Summary      更新角色信息
-// @Description  管理员更新角色的显示名称和描述
+// @Description  管理员更新角色的显示名称、描述和父角色（parent_id 为 0 时移除父角色）。启用双人审批时变更父角色返回 202 与待审批的变更请求
 // @Tags         管理员 - 角色管理 (Admin - Role Management)
 // @Accept       json
 // @Produce      json
@@ -189,10 +210,12 @@ func (h *RoleHandler) GetRole(c *gin.Context) {
 // @Param        id path int true "角色ID" minimum(1)
 // @Param        request body role.UpdateRoleDTO true "更新信息"
 // @Success      200 {object} response.DataResponse[role.RoleDTO] "角色更新成功"
+// @Success      202 {object} response.DataResponse[approval.ChangeRequestDTO] "已提交审批"
 // @Failure      400 {object} response.ErrorResponse "无效的角色ID或参数错误"
 // @Failure      401 {object} response.ErrorResponse "未授权"
-// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      403 {object} response.ErrorResponse "权限不足或父角色不属于当前组织"
 // @Failure      404 {object} response.ErrorResponse "角色不存在"
+// @Failure      409 {object} response.ErrorResponse "角色继承关系形成环"
 // @Failure      500 {object} response.ErrorResponse "服务器内部错误"
 // @Router       /api/admin/roles/{id} [put]
 // @x-permission {"scope":"admin:roles:update"}
@@ -210,21 +233,77 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 		return
 	}
 
-	// 调用 Use Case Handler
-	result, err := h.updateRoleHandler.Handle(c.Request.Context(), role.UpdateRoleCommand{
+	cmd := role.UpdateRoleCommand{
 		RoleID:      uint(id),
 		DisplayName: req.DisplayName,
 		Description: req.Description,
-	})
+		ParentID:    req.ParentID,
+	}
+	// 父角色决定继承的权限，变更父角色与设置权限同样受审批保护
+	if cmd.ParentID != nil && h.submitChangeHandler.Required(c.Request.Context(), approval.OperationSetRoleParent) {
+		submitChangeRequest(c, h.submitChangeHandler, approval.OperationSetRoleParent, c.Param("id"), cmd)
+		return
+	}
+
+	// 调用 Use Case Handler
+	result, err := h.updateRoleHandler.Handle(c.Request.Context(), cmd)
 
 	if err != nil {
-		response.InternalError(c, err.Error())
+		switch {
+		case errors.Is(err, role.ErrParentRoleNotFound):
+			response.BadRequest(c, err.Error())
+		case errors.Is(err, role.ErrRoleHierarchyCycle):
+			response.Conflict(c, err.Error())
+		case errors.Is(err, role.ErrTenantMismatch):
+			response.Forbidden(c, err.Error())
+		default:
+			response.InternalError(c, err.Error())
+		}
 		return
 	}
 
 	response.OK(c, "role updated successfully", result)
 }
 
+// GetEffectivePermissions gets the effective permissions of a role
+//
+// @Summary      获取角色有效权限
+// @Description  返回角色自身权限与继承链上所有祖先角色权限的并集
+// @Tags         管理员 - 角色管理 (Admin - Role Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "角色ID" minimum(1)
+// @Success      200 {object} response.DataResponse[role.EffectivePermissionsDTO] "有效权限"
+// @Failure      400 {object} response.ErrorResponse "无效的角色ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "角色不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/roles/{id}/effective-permissions [get]
+// @x-permission {"scope":"admin:roles:read"}
+func (h *RoleHandler) GetEffectivePermissions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid role ID")
+		return
+	}
+
+	result, err := h.getEffectivePermissionsHandler.Handle(c.Request.Context(), role.GetEffectivePermissionsQuery{
+		RoleID: uint(id),
+	})
+	if err != nil {
+		if errors.Is(err, role.ErrRoleNotFound) {
+			response.NotFound(c, "role")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "success", result)
+}
+
 // DeleteRole deletes a role
 //
 // @Summary      删除角色
@@ -255,6 +334,10 @@ func (h *RoleHandler) DeleteRole(c *gin.Context) {
 	})
 
 	if err != nil {
+		if errors.Is(err, role.ErrTenantMismatch) {
+			response.Forbidden(c, err.Error())
+			return
+		}
 		response.InternalError(c, err.Error())
 		return
 	}
@@ -265,7 +348,7 @@ func (h *RoleHandler) DeleteRole(c *gin.Context) {
 // SetPermissions sets permissions for a role
 //
 // @Summary      设置角色权限
-// @Description  管理员为指定角色设置权限（会覆盖现有权限）
+// @Description  管理员为指定角色设置权限（会覆盖现有权限）。启用双人审批时返回 202 与待审批的变更请求
 // @Tags         管理员 - 角色管理 (Admin - Role Management)
 // @Accept       json
 // @Produce      json
@@ -273,6 +356,7 @@ func (h *RoleHandler) DeleteRole(c *gin.Context) {
 // @Param        id path int true "角色ID" minimum(1)
 // @Param        request body role.SetPermissionsDTO true "权限ID列表"
 // @Success      200 {object} response.MessageResponse "权限设置成功"
+// @Success      202 {object} response.DataResponse[approval.ChangeRequestDTO] "已提交审批"
 // @Failure      400 {object} response.ErrorResponse "无效的角色ID或参数错误"
 // @Failure      401 {object} response.ErrorResponse "未授权"
 // @Failure      403 {object} response.ErrorResponse "权限不足"
@@ -294,13 +378,23 @@ func (h *RoleHandler) SetPermissions(c *gin.Context) {
 		return
 	}
 
-	// 调用 Use Case Handler
-	err = h.setPermissionsHandler.Handle(c.Request.Context(), role.SetPermissionsCommand{
+	cmd := role.SetPermissionsCommand{
 		RoleID:        uint(id),
 		PermissionIDs: req.PermissionIDs,
-	})
+	}
+	if h.submitChangeHandler.Required(c.Request.Context(), approval.OperationSetRolePermissions) {
+		submitChangeRequest(c, h.submitChangeHandler, approval.OperationSetRolePermissions, c.Param("id"), cmd)
+		return
+	}
+
+	// 调用 Use Case Handler
+	err = h.setPermissionsHandler.Handle(c.Request.Context(), cmd)
 
 	if err != nil {
+		if errors.Is(err, role.ErrTenantMismatch) {
+			response.Forbidden(c, err.Error())
+			return
+		}
 		response.InternalError(c, err.Error())
 		return
 	}
@@ -339,3 +433,107 @@ func (h *RoleHandler) ListPermissions(c *gin.Context) {
 	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
 	response.List(c, "success", result.Permissions, meta)
 }
+
+// SetPermissionCondition sets the policy condition of a role permission grant
+//
+// @Summary      设置权限授予条件
+// @Description  为角色已授予的权限附加属性条件（如 resource.department == subject.department），空条件表示移除限制。启用双人审批时返回 202 与待审批的变更请求
+// @Tags         管理员 - 角色管理 (Admin - Role Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "角色ID" minimum(1)
+// @Param        permission_id path int true "权限ID" minimum(1)
+// @Param        request body role.SetPermissionConditionDTO true "策略条件"
+// @Success      200 {object} response.DataResponse[role.GrantDTO] "条件设置成功"
+// @Success      202 {object} response.DataResponse[approval.ChangeRequestDTO] "已提交审批"
+// @Failure      400 {object} response.ErrorResponse "参数错误、条件语法错误或角色未被授予该权限"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "角色不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/roles/{id}/permissions/{permission_id}/condition [put]
+// @x-permission {"scope":"admin:roles:update"}
+func (h *RoleHandler) SetPermissionCondition(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid role ID")
+		return
+	}
+	permissionID, err := strconv.ParseUint(c.Param("permission_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid permission ID")
+		return
+	}
+
+	var req role.SetPermissionConditionDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	cmd := role.SetPermissionConditionCommand{
+		RoleID:       uint(id),
+		PermissionID: uint(permissionID),
+		Condition:    req.Condition,
+	}
+	// 移除或放宽条件会扩大授权范围，与设置权限同样受审批保护
+	if h.submitChangeHandler.Required(c.Request.Context(), approval.OperationSetGrantCondition) {
+		submitChangeRequest(c, h.submitChangeHandler, approval.OperationSetGrantCondition, c.Param("id"), cmd)
+		return
+	}
+
+	result, err := h.setConditionHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, role.ErrRoleNotFound):
+			response.NotFound(c, "role")
+		case errors.Is(err, role.ErrPermissionNotGranted), errors.Is(err, role.ErrInvalidCondition):
+			response.BadRequest(c, err.Error())
+		case errors.Is(err, role.ErrTenantMismatch):
+			response.Forbidden(c, err.Error())
+		default:
+			response.InternalError(c, err.Error())
+		}
+		return
+	}
+
+	response.OK(c, "permission condition updated successfully", result)
+}
+
+// ListGrants lists the permission grants of a role
+//
+// @Summary      获取角色权限授予
+// @Description  返回角色直接授予的权限及其附加的策略条件
+// @Tags         管理员 - 角色管理 (Admin - Role Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "角色ID" minimum(1)
+// @Success      200 {object} response.DataResponse[[]role.GrantDTO] "权限授予列表"
+// @Failure      400 {object} response.ErrorResponse "无效的角色ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "角色不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/roles/{id}/grants [get]
+// @x-permission {"scope":"admin:roles:read"}
+func (h *RoleHandler) ListGrants(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid role ID")
+		return
+	}
+
+	result, err := h.listGrantsHandler.Handle(c.Request.Context(), role.ListGrantsQuery{RoleID: uint(id)})
+	if err != nil {
+		if errors.Is(err, role.ErrRoleNotFound) {
+			response.NotFound(c, "role")
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "success", result)
+}
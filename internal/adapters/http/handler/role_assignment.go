@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/approval"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
+)
+
+// ListExpiringAssignmentsQuery 即将到期授权查询参数
+type ListExpiringAssignmentsQuery struct {
+	response.PaginationQueryDTO
+
+	// Days 查询未来多少天内到期的授权，默认 7 天
+	Days int `form:"days" json:"days" binding:"omitempty,min=1,max=365"`
+}
+
+// ToQuery 转换为 Application 层 Query 对象
+func (q *ListExpiringAssignmentsQuery) ToQuery() user.ListExpiringRoleAssignmentsQuery {
+	return user.ListExpiringRoleAssignmentsQuery{
+		Within: time.Duration(q.Days) * 24 * time.Hour,
+		Page:   q.GetPage(),
+		Limit:  q.GetLimit(),
+	}
+}
+
+// RoleAssignmentHandler handles time-bound role assignment operations (DDD+CQRS Use Case Pattern)
+//
+// 与 PUT /api/admin/users/:id/roles 的整体替换不同，这里按单个角色授予或撤销，
+// 支持 starts_at / expires_at 时间窗口，由后台任务负责激活和到期。
+type RoleAssignmentHandler struct {
+	// Command Handlers
+	grantHandler  *user.GrantRoleHandler
+	revokeHandler *user.RevokeRoleHandler
+
+	// Query Handlers
+	listHandler         *user.ListRoleAssignmentsHandler
+	listExpiringHandler *user.ListExpiringRoleAssignmentsHandler
+
+	// 授予角色受双人审批保护
+	submitChangeHandler *approval.SubmitChangeRequestHandler
+}
+
+// NewRoleAssignmentHandler creates a new RoleAssignmentHandler instance
+func NewRoleAssignmentHandler(
+	grantHandler *user.GrantRoleHandler,
+	revokeHandler *user.RevokeRoleHandler,
+	listHandler *user.ListRoleAssignmentsHandler,
+	listExpiringHandler *user.ListExpiringRoleAssignmentsHandler,
+	submitChangeHandler *approval.SubmitChangeRequestHandler,
+) *RoleAssignmentHandler {
+	return &RoleAssignmentHandler{
+		grantHandler:        grantHandler,
+		revokeHandler:       revokeHandler,
+		listHandler:         listHandler,
+		listExpiringHandler: listExpiringHandler,
+		submitChangeHandler: submitChangeHandler,
+	}
+}
+
+// ListAssignments lists role assignments of a user
+//
+// @Summary      用户角色授权列表
+// @Description  获取用户的全部角色授权，包含计划中的授权及其生效窗口
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户ID" minimum(1)
+// @Success      200 {object} response.DataResponse[[]user.RoleAssignmentDTO] "授权列表"
+// @Failure      400 {object} response.ErrorResponse "无效的用户ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/{id}/role-assignments [get]
+// @x-permission {"scope":"admin:users:read"}
+func (h *RoleAssignmentHandler) ListAssignments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	assignments, err := h.listHandler.Handle(c.Request.Context(), user.ListRoleAssignmentsQuery{UserID: uint(id)})
+	if err != nil {
+		handleRoleAssignmentError(c, err)
+		return
+	}
+
+	response.OK(c, "success", assignments)
+}
+
+// GrantRole grants a (time-bound) role to a user
+//
+// @Summary      授予限时角色
+// @Description  为用户授予单个角色，可指定开始和到期时间；已存在的授权会被覆盖。启用双人审批时提交变更请求，批准后生效
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户ID" minimum(1)
+// @Param        request body user.GrantRoleDTO true "授权信息"
+// @Success      201 {object} response.DataResponse[user.RoleAssignmentDTO] "授权成功"
+// @Success      202 {object} response.DataResponse[approval.ChangeRequestDTO] "已提交审批"
+// @Failure      400 {object} response.ErrorResponse "参数错误或时间窗口无效"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户或角色不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/{id}/role-assignments [post]
+// @x-permission {"scope":"admin:users:update"}
+func (h *RoleAssignmentHandler) GrantRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	var req user.GrantRoleDTO
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	cmd := user.GrantRoleCommand{
+		UserID:    uint(id),
+		RoleID:    req.RoleID,
+		StartsAt:  req.StartsAt,
+		ExpiresAt: req.ExpiresAt,
+		ActorID:   c.GetUint("user_id"),
+	}
+	if h.submitChangeHandler.Required(c.Request.Context(), approval.OperationGrantUserRole) {
+		submitChangeRequest(c, h.submitChangeHandler, approval.OperationGrantUserRole, c.Param("id"), cmd)
+		return
+	}
+
+	assignment, err := h.grantHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		handleRoleAssignmentError(c, err)
+		return
+	}
+
+	response.Created(c, "role granted successfully", assignment)
+}
+
+// RevokeRole revokes a role from a user
+//
+// @Summary      撤销角色授权
+// @Description  撤销用户的单个角色授权（永久或限时）
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户ID" minimum(1)
+// @Param        role_id path int true "角色ID" minimum(1)
+// @Success      200 {object} response.MessageResponse "撤销成功"
+// @Failure      400 {object} response.ErrorResponse "无效的ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/{id}/role-assignments/{role_id} [delete]
+// @x-permission {"scope":"admin:users:update"}
+func (h *RoleAssignmentHandler) RevokeRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	roleID, err := strconv.ParseUint(c.Param("role_id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid role ID")
+		return
+	}
+
+	if err = h.revokeHandler.Handle(c.Request.Context(), user.RevokeRoleCommand{
+		UserID:  uint(id),
+		RoleID:  uint(roleID),
+		ActorID: c.GetUint("user_id"),
+	}); err != nil {
+		handleRoleAssignmentError(c, err)
+		return
+	}
+
+	response.OK(c, "role revoked successfully", nil)
+}
+
+// ListExpiring lists role assignments expiring soon
+//
+// @Summary      即将到期的角色授权
+// @Description  分页获取指定天数内到期的限时授权，按到期时间升序
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        params query ListExpiringAssignmentsQuery false "查询参数"
+// @Success      200 {object} response.PagedResponse[user.RoleAssignmentDTO] "授权列表"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/role-assignments/expiring [get]
+// @x-permission {"scope":"admin:users:read"}
+func (h *RoleAssignmentHandler) ListExpiring(c *gin.Context) {
+	var q ListExpiringAssignmentsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.listExpiringHandler.Handle(c.Request.Context(), q.ToQuery())
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
+	response.List(c, "success", result.Assignments, meta)
+}
+
+// handleRoleAssignmentError 将角色授权相关错误映射为 HTTP 响应
+func handleRoleAssignmentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, user.ErrUserNotFound):
+		response.NotFound(c, "user")
+	case errors.Is(err, user.ErrRoleNotFound):
+		response.NotFound(c, "role")
+	case errors.Is(err, user.ErrInvalidAssignmentWindow):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, user.ErrAccessDenied):
+		response.Forbidden(c, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/trash"
+)
+
+// TrashHandler handles soft-deleted records (DDD+CQRS Use Case Pattern)
+//
+// 回收站接口：列出、恢复、永久删除已软删除的用户、菜单、个人访问令牌与审计日志，
+// resource 路径参数取值 users / menus / tokens / auditlogs。
+type TrashHandler struct {
+	listHandler    *trash.ListDeletedHandler
+	restoreHandler *trash.RestoreItemHandler
+	purgeHandler   *trash.PurgeItemHandler
+}
+
+// NewTrashHandler creates a new TrashHandler instance
+func NewTrashHandler(
+	listHandler *trash.ListDeletedHandler,
+	restoreHandler *trash.RestoreItemHandler,
+	purgeHandler *trash.PurgeItemHandler,
+) *TrashHandler {
+	return &TrashHandler{
+		listHandler:    listHandler,
+		restoreHandler: restoreHandler,
+		purgeHandler:   purgeHandler,
+	}
+}
+
+// List lists soft-deleted records of a resource
+//
+// @Summary      回收站列表
+// @Description  按删除时间倒序分页列出指定资源中已删除的记录。超过保留期（系统配置 general.trash_retention_days）的记录会被自动永久删除。
+// @Tags         管理员 - 回收站 (Admin - Trash)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        resource path string true "资源类型" Enums(users, menus, tokens, auditlogs)
+// @Param        params query response.PaginationQueryDTO false "分页参数"
+// @Success      200 {object} response.PagedResponse[trash.TrashItemDTO] "已删除记录"
+// @Failure      400 {object} response.ErrorResponse "参数错误或资源不支持回收站"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/trash/{resource} [get]
+// @x-permission {"scope":"admin:trash:read"}
+func (h *TrashHandler) List(c *gin.Context) {
+	var q response.PaginationQueryDTO
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.listHandler.Handle(c.Request.Context(), trash.ListDeletedQuery{
+		Resource: c.Param("resource"),
+		Page:     q.GetPage(),
+		Limit:    q.GetLimit(),
+	})
+	if err != nil {
+		handleTrashError(c, err)
+		return
+	}
+
+	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
+	response.List(c, "success", result.Items, meta)
+}
+
+// Restore restores a soft-deleted record
+//
+// @Summary      恢复已删除记录
+// @Description  恢复回收站中的记录。用户名或邮箱已被其他用户占用、父菜单已删除、令牌所属用户不可用时返回 409。
+// @Tags         管理员 - 回收站 (Admin - Trash)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        resource path string true "资源类型" Enums(users, menus, tokens, auditlogs)
+// @Param        id path int true "记录ID" minimum(1)
+// @Success      200 {object} response.MessageResponse "恢复成功"
+// @Failure      400 {object} response.ErrorResponse "参数错误或资源不支持回收站"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "回收站中不存在该记录"
+// @Failure      409 {object} response.ErrorResponse "恢复冲突"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/trash/{resource}/{id}/restore [post]
+// @x-permission {"scope":"admin:trash:restore"}
+func (h *TrashHandler) Restore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid ID")
+		return
+	}
+
+	if err = h.restoreHandler.Handle(c.Request.Context(), trash.RestoreItemCommand{
+		Resource: c.Param("resource"),
+		ID:       uint(id),
+	}); err != nil {
+		handleTrashError(c, err)
+		return
+	}
+
+	response.OK(c, "item restored successfully", nil)
+}
+
+// Purge permanently deletes a soft-deleted record
+//
+// @Summary      永久删除记录
+// @Description  永久删除回收站中的记录，不可恢复。永久删除用户会同时删除其角色、令牌、2FA 等从属数据，并匿名化其审计日志。
+// @Tags         管理员 - 回收站 (Admin - Trash)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        resource path string true "资源类型" Enums(users, menus, tokens, auditlogs)
+// @Param        id path int true "记录ID" minimum(1)
+// @Success      204 "永久删除成功"
+// @Failure      400 {object} response.ErrorResponse "参数错误或资源不支持回收站"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "回收站中不存在该记录"
+// @Failure      409 {object} response.ErrorResponse "记录仍被引用（如菜单仍有子菜单）"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/trash/{resource}/{id} [delete]
+// @x-permission {"scope":"admin:trash:purge"}
+func (h *TrashHandler) Purge(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid ID")
+		return
+	}
+
+	if err = h.purgeHandler.Handle(c.Request.Context(), trash.PurgeItemCommand{
+		Resource: c.Param("resource"),
+		ID:       uint(id),
+	}); err != nil {
+		handleTrashError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// handleTrashError 将回收站相关错误映射为 HTTP 响应
+func handleTrashError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, trash.ErrUnsupportedResource):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, trash.ErrItemNotFound):
+		response.NotFound(c, "deleted item")
+	case errors.Is(err, trash.ErrRestoreConflict), errors.Is(err, trash.ErrPurgeConflict):
+		response.Conflict(c, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
@@ -13,6 +15,11 @@ type TwoFAHandler struct {
 	verifyEnableHandler *twofa.VerifyEnableHandler
 	disableHandler      *twofa.DisableHandler
 	getStatusHandler    *twofa.GetStatusHandler
+
+	setupChannelHandler   *twofa.SetupChannelHandler
+	verifyChannelHandler  *twofa.VerifyChannelHandler
+	disableChannelHandler *twofa.DisableChannelHandler
+	listMethodsHandler    *twofa.ListMethodsHandler
 }
 
 // NewTwoFAHandler 创建 2FA 处理器
@@ -21,12 +28,21 @@ func NewTwoFAHandler(
 	verifyEnableHandler *twofa.VerifyEnableHandler,
 	disableHandler *twofa.DisableHandler,
 	getStatusHandler *twofa.GetStatusHandler,
+	setupChannelHandler *twofa.SetupChannelHandler,
+	verifyChannelHandler *twofa.VerifyChannelHandler,
+	disableChannelHandler *twofa.DisableChannelHandler,
+	listMethodsHandler *twofa.ListMethodsHandler,
 ) *TwoFAHandler {
 	return &TwoFAHandler{
 		setupHandler:        setupHandler,
 		verifyEnableHandler: verifyEnableHandler,
 		disableHandler:      disableHandler,
 		getStatusHandler:    getStatusHandler,
+
+		setupChannelHandler:   setupChannelHandler,
+		verifyChannelHandler:  verifyChannelHandler,
+		disableChannelHandler: disableChannelHandler,
+		listMethodsHandler:    listMethodsHandler,
 	}
 }
 
@@ -175,6 +191,154 @@ func (h *TwoFAHandler) GetStatus(c *gin.Context) {
 	response.OK(c, "success", resp)
 }
 
+// SetupChannel 绑定邮件/短信验证通道
+//
+// @Summary      绑定邮件/短信验证通道
+// @Description  为当前用户绑定邮件或短信二次认证通道，并向该地址发送确认码。邮件通道未指定地址时使用账户邮箱
+// @Tags         认证 - 两步验证 (Authentication - 2FA)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body twofa.SetupChannelDTO true "验证方式和投递地址"
+// @Success      200 {object} response.DataResponse[twofa.ChannelSetupDTO] "确认码已发送"
+// @Failure      400 {object} response.ErrorResponse "验证方式不可用或地址无效"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      429 {object} response.ErrorResponse "发送过于频繁"
+// @Router       /api/auth/2fa/channels/setup [post]
+func (h *TwoFAHandler) SetupChannel(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req twofa.SetupChannelDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.setupChannelHandler.Handle(c.Request.Context(), twofa.SetupChannelCommand{
+		UserID:      userID,
+		Method:      req.Method,
+		Destination: req.Destination,
+	})
+	if err != nil {
+		if errors.Is(err, twofa.ErrOTPThrottled) {
+			response.TooManyRequests(c)
+			return
+		}
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.OK(c, "verification code sent", twofa.ChannelSetupDTO{
+		Method:      result.Method,
+		Destination: result.Destination,
+	})
+}
+
+// VerifyChannel 确认并启用邮件/短信验证通道
+//
+// @Summary      确认邮件/短信验证通道
+// @Description  提交收到的确认码，成功后该通道可在登录二次认证时选择
+// @Tags         认证 - 两步验证 (Authentication - 2FA)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body twofa.VerifyChannelDTO true "验证方式和确认码"
+// @Success      200 {object} response.MessageResponse "通道已启用"
+// @Failure      400 {object} response.ErrorResponse "确认码错误或已过期"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Router       /api/auth/2fa/channels/verify [post]
+func (h *TwoFAHandler) VerifyChannel(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req twofa.VerifyChannelDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	if err := h.verifyChannelHandler.Handle(c.Request.Context(), twofa.VerifyChannelCommand{
+		UserID: userID,
+		Method: req.Method,
+		Code:   req.Code,
+	}); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.OK(c, "2FA channel enabled successfully", nil)
+}
+
+// DisableChannel 解绑邮件/短信验证通道
+//
+// @Summary      解绑邮件/短信验证通道
+// @Description  解绑当前用户的邮件或短信二次认证通道
+// @Tags         认证 - 两步验证 (Authentication - 2FA)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body twofa.DisableChannelDTO true "验证方式"
+// @Success      200 {object} response.MessageResponse "通道已解绑"
+// @Failure      400 {object} response.ErrorResponse "通道不存在"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Router       /api/auth/2fa/channels/disable [post]
+func (h *TwoFAHandler) DisableChannel(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req twofa.DisableChannelDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	if err := h.disableChannelHandler.Handle(c.Request.Context(), twofa.DisableChannelCommand{
+		UserID: userID,
+		Method: req.Method,
+	}); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.OK(c, "2FA channel disabled successfully", nil)
+}
+
+// ListChannels 获取已启用的邮件/短信验证方式
+//
+// @Summary      获取已启用的邮件/短信验证方式
+// @Description  获取当前用户已确认启用的邮件、短信二次认证方式
+// @Tags         认证 - 两步验证 (Authentication - 2FA)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.DataResponse[twofa.MethodsDTO] "已启用的验证方式"
+// @Failure      400 {object} response.ErrorResponse "获取失败"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Router       /api/auth/2fa/channels [get]
+func (h *TwoFAHandler) ListChannels(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	methods, err := h.listMethodsHandler.Handle(c.Request.Context(), twofa.ListMethodsQuery{
+		UserID: userID,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	response.OK(c, "success", twofa.MethodsDTO{Methods: methods})
+}
+
 // getUserID 从上下文获取用户ID，并输出统一未认证响应
 func getUserID(c *gin.Context) (uint, bool) {
 	userID, exists := c.Get("user_id")
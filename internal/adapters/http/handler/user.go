@@ -101,14 +101,14 @@ func (h *UserHandler) List(c *gin.Context) {
 		return
 	}
 
-	result, err := h.listUsersHandler.Handle(c.Request.Context(), q.ToQuery())
+	query := q.ToQuery()
+	result, err := h.listUsersHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		response.InternalError(c, err.Error())
+		writeListUsersError(c, err)
 		return
 	}
 
-	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
-	response.List(c, "success", result.Users, meta)
+	response.List(c, "success", result.Users, listUsersMeta(query, result))
 }
 
 // Update 更新用户
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
+)
+
+// UserAttributeHandler handles custom user attribute definitions (DDD+CQRS Use Case Pattern)
+//
+// 管理员定义自定义用户属性（类型、必填、校验规则、可见性），属性值随用户的创建、
+// 更新接口提交，并可在用户列表中按属性过滤。
+type UserAttributeHandler struct {
+	createHandler *user.CreateAttributeDefinitionHandler
+	updateHandler *user.UpdateAttributeDefinitionHandler
+	deleteHandler *user.DeleteAttributeDefinitionHandler
+	listHandler   *user.ListAttributeDefinitionsHandler
+}
+
+// NewUserAttributeHandler creates a new UserAttributeHandler instance
+func NewUserAttributeHandler(
+	createHandler *user.CreateAttributeDefinitionHandler,
+	updateHandler *user.UpdateAttributeDefinitionHandler,
+	deleteHandler *user.DeleteAttributeDefinitionHandler,
+	listHandler *user.ListAttributeDefinitionsHandler,
+) *UserAttributeHandler {
+	return &UserAttributeHandler{
+		createHandler: createHandler,
+		updateHandler: updateHandler,
+		deleteHandler: deleteHandler,
+		listHandler:   listHandler,
+	}
+}
+
+// ListAttributes lists all custom attribute definitions
+//
+// @Summary      自定义属性列表
+// @Description  获取全部自定义用户属性定义（按排序值排列）
+// @Tags         管理员 - 用户属性 (Admin - User Attributes)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.DataResponse[[]user.AttributeDefinitionDTO] "属性定义列表"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/user-attributes [get]
+// @x-permission {"scope":"admin:user_attributes:read"}
+func (h *UserAttributeHandler) ListAttributes(c *gin.Context) {
+	defs, err := h.listHandler.Handle(c.Request.Context(), user.ListAttributeDefinitionsQuery{})
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "success", defs)
+}
+
+// CreateAttribute creates a custom attribute definition
+//
+// @Summary      创建自定义属性
+// @Description  定义新的用户属性。类型为 string/number/boolean/date/enum；pattern 须完整匹配；
+// @Description  visibility 为 user（本人与管理员可见）或 admin（仅管理员可见）；user_editable 允许用户在个人资料中编辑
+// @Tags         管理员 - 用户属性 (Admin - User Attributes)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body user.CreateAttributeDefinitionDTO true "属性定义"
+// @Success      201 {object} response.DataResponse[user.AttributeDefinitionDTO] "创建成功"
+// @Failure      400 {object} response.ErrorResponse "参数错误或属性定义无效"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      409 {object} response.ErrorResponse "属性键已存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/user-attributes [post]
+// @x-permission {"scope":"admin:user_attributes:create"}
+func (h *UserAttributeHandler) CreateAttribute(c *gin.Context) {
+	var req user.CreateAttributeDefinitionDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	def, err := h.createHandler.Handle(c.Request.Context(), user.CreateAttributeDefinitionCommand(req))
+	if err != nil {
+		handleUserAttributeError(c, err)
+		return
+	}
+
+	response.Created(c, "attribute created successfully", def)
+}
+
+// UpdateAttribute updates a custom attribute definition
+//
+// @Summary      更新自定义属性
+// @Description  更新属性定义（key 与 type 不可修改）。规则变更不回溯校验已保存的值，在下次编辑属性时生效
+// @Tags         管理员 - 用户属性 (Admin - User Attributes)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "属性定义ID" minimum(1)
+// @Param        request body user.UpdateAttributeDefinitionDTO true "更新内容"
+// @Success      200 {object} response.DataResponse[user.AttributeDefinitionDTO] "更新成功"
+// @Failure      400 {object} response.ErrorResponse "参数错误或属性定义无效"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "属性定义不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/user-attributes/{id} [put]
+// @x-permission {"scope":"admin:user_attributes:update"}
+func (h *UserAttributeHandler) UpdateAttribute(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid attribute ID")
+		return
+	}
+
+	var req user.UpdateAttributeDefinitionDTO
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	def, err := h.updateHandler.Handle(c.Request.Context(), user.UpdateAttributeDefinitionCommand{
+		ID:           uint(id),
+		Label:        req.Label,
+		Description:  req.Description,
+		Required:     req.Required,
+		Pattern:      req.Pattern,
+		Options:      req.Options,
+		Visibility:   req.Visibility,
+		UserEditable: req.UserEditable,
+		SortOrder:    req.SortOrder,
+	})
+	if err != nil {
+		handleUserAttributeError(c, err)
+		return
+	}
+
+	response.OK(c, "attribute updated successfully", def)
+}
+
+// DeleteAttribute deletes a custom attribute definition
+//
+// @Summary      删除自定义属性
+// @Description  删除属性定义，同时删除所有用户在该属性上的值
+// @Tags         管理员 - 用户属性 (Admin - User Attributes)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "属性定义ID" minimum(1)
+// @Success      200 {object} response.MessageResponse "删除成功"
+// @Failure      400 {object} response.ErrorResponse "无效的属性定义ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "属性定义不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/user-attributes/{id} [delete]
+// @x-permission {"scope":"admin:user_attributes:delete"}
+func (h *UserAttributeHandler) DeleteAttribute(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid attribute ID")
+		return
+	}
+
+	if err = h.deleteHandler.Handle(c.Request.Context(), user.DeleteAttributeDefinitionCommand{ID: uint(id)}); err != nil {
+		handleUserAttributeError(c, err)
+		return
+	}
+
+	response.OK(c, "attribute deleted successfully", nil)
+}
+
+// ListProfileAttributes lists the custom attributes visible to the current user
+//
+// @Summary      个人资料属性定义
+// @Description  获取当前用户可见的自定义属性定义，user_editable 为 true 的属性可通过更新个人资料接口修改
+// @Tags         用户 - 个人资料 (User - Profile)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.DataResponse[[]user.AttributeDefinitionDTO] "属性定义列表"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/profile/attributes [get]
+// @x-permission {"scope":"user:profile:read"}
+func (h *UserAttributeHandler) ListProfileAttributes(c *gin.Context) {
+	defs, err := h.listHandler.Handle(c.Request.Context(), user.ListAttributeDefinitionsQuery{UserVisibleOnly: true})
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "success", defs)
+}
+
+// handleUserAttributeError 将自定义属性定义相关错误映射为 HTTP 响应
+func handleUserAttributeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, user.ErrAttributeDefinitionNotFound):
+		response.NotFound(c, "attribute")
+	case errors.Is(err, user.ErrAttributeKeyExists):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, user.ErrInvalidAttributeDefinition):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
+
+// isAttributeValueError 检查是否为自定义属性值校验错误（用户创建、更新时提交的属性值）
+func isAttributeValueError(err error) bool {
+	return errors.Is(err, user.ErrUnknownAttribute) ||
+		errors.Is(err, user.ErrInvalidAttributeValue) ||
+		errors.Is(err, user.ErrAttributeRequired) ||
+		errors.Is(err, user.ErrAttributeNotEditable)
+}
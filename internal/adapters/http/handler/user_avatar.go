@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
+)
+
+// 头像缓存策略：带版本参数的地址内容不变，可长期缓存；其余地址需定期重新验证
+const (
+	avatarCacheImmutable = "public, max-age=31536000, immutable"
+	avatarCacheDefault   = "public, max-age=300"
+)
+
+// UserAvatarHandler handles avatar upload and serving (DDD+CQRS Use Case Pattern)
+type UserAvatarHandler struct {
+	uploadHandler *user.UploadAvatarHandler
+	deleteHandler *user.DeleteAvatarHandler
+	getHandler    *user.GetAvatarHandler
+}
+
+// NewUserAvatarHandler creates a new UserAvatarHandler instance
+func NewUserAvatarHandler(
+	uploadHandler *user.UploadAvatarHandler,
+	deleteHandler *user.DeleteAvatarHandler,
+	getHandler *user.GetAvatarHandler,
+) *UserAvatarHandler {
+	return &UserAvatarHandler{
+		uploadHandler: uploadHandler,
+		deleteHandler: deleteHandler,
+		getHandler:    getHandler,
+	}
+}
+
+// UploadAvatar uploads an avatar image for the current user
+//
+// @Summary      上传头像
+// @Description  上传 JPEG/PNG/GIF 头像（大小上限由 storage.avatar-max-size 配置）。图片按中心裁剪为正方形，
+// @Description  缩放为 256/128/64 像素的 PNG 保存，返回的地址带版本参数，可通过 size 参数选择尺寸。
+// @Tags         用户 - 个人资料 (User - Profile)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file formData file true "头像图片"
+// @Success      200 {object} response.DataResponse[user.AvatarDTO] "上传成功"
+// @Failure      400 {object} response.ErrorResponse "文件缺失或图片无效"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      413 {object} response.ErrorResponse "文件过大"
+// @Failure      415 {object} response.ErrorResponse "不支持的图片类型"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/avatar [post]
+// @x-permission {"scope":"user:profile:update"}
+func (h *UserAvatarHandler) UploadAvatar(c *gin.Context) {
+	uid, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required")
+		return
+	}
+	if fileHeader.Size > h.uploadHandler.MaxSize() {
+		handleAvatarError(c, user.ErrAvatarTooLarge)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(c, "failed to read file")
+		return
+	}
+	defer file.Close()
+	// 多读一个字节，超出上限时由用例返回 ErrAvatarTooLarge
+	content, err := io.ReadAll(io.LimitReader(file, h.uploadHandler.MaxSize()+1))
+	if err != nil {
+		response.BadRequest(c, "failed to read file")
+		return
+	}
+
+	avatar, err := h.uploadHandler.Handle(c.Request.Context(), user.UploadAvatarCommand{UserID: uid, Content: content})
+	if err != nil {
+		handleAvatarError(c, err)
+		return
+	}
+
+	response.OK(c, "avatar uploaded", avatar)
+}
+
+// DeleteAvatar removes the current user's avatar
+//
+// @Summary      删除头像
+// @Description  删除已上传的头像或外部头像地址，恢复为默认头像
+// @Tags         用户 - 个人资料 (User - Profile)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.MessageResponse "删除成功"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/avatar [delete]
+// @x-permission {"scope":"user:profile:update"}
+func (h *UserAvatarHandler) DeleteAvatar(c *gin.Context) {
+	uid, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteHandler.Handle(c.Request.Context(), user.DeleteAvatarCommand{UserID: uid}); err != nil {
+		handleAvatarError(c, err)
+		return
+	}
+
+	response.OK(c, "avatar deleted", nil)
+}
+
+// GetAvatar serves a user's avatar image
+//
+// @Summary      获取头像
+// @Description  返回用户头像 PNG。未上传头像（含外部头像地址）时生成由用户 ID 决定的默认头像（identicon）。
+// @Description  带版本参数 v 且与当前版本一致时响应可长期缓存；支持 If-None-Match 条件请求。
+// @Tags         用户 - 个人资料 (User - Profile)
+// @Produce      image/png
+// @Param        id path int true "用户ID" minimum(1)
+// @Param        size query int false "期望边长 (返回不小于该值的最小尺寸)" default(128)
+// @Param        v query string false "头像版本"
+// @Success      200 {file} file "头像图片"
+// @Success      304 "未修改"
+// @Failure      400 {object} response.ErrorResponse "无效的用户ID"
+// @Failure      404 {object} response.ErrorResponse "用户不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/avatars/{id} [get]
+func (h *UserAvatarHandler) GetAvatar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+	size, _ := strconv.Atoi(c.Query("size"))
+
+	avatar, err := h.getHandler.Handle(c.Request.Context(), user.GetAvatarQuery{UserID: uint(id), Size: size})
+	if err != nil {
+		handleAvatarError(c, err)
+		return
+	}
+
+	cacheControl := avatarCacheDefault
+	if avatar.Version != "" && c.Query("v") == avatar.Version {
+		cacheControl = avatarCacheImmutable
+	}
+	c.Header("Cache-Control", cacheControl)
+	c.Header("ETag", avatar.ETag)
+
+	if c.GetHeader("If-None-Match") == avatar.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, avatar.ContentType, avatar.Content)
+}
+
+// handleAvatarError 统一处理头像相关错误
+func handleAvatarError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, user.ErrUserNotFound):
+		response.NotFound(c, "user")
+	case errors.Is(err, user.ErrAvatarTooLarge):
+		response.Failure(c, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, user.ErrUnsupportedAvatarType):
+		response.Failure(c, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, user.ErrInvalidAvatar):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
+)
+
+// UserDataExportHandler handles self-service personal data export (DDD+CQRS Use Case Pattern)
+type UserDataExportHandler struct {
+	requestHandler *user.RequestDataExportHandler
+	getHandler     *user.GetDataExportHandler
+}
+
+// NewUserDataExportHandler creates a new UserDataExportHandler instance
+func NewUserDataExportHandler(
+	requestHandler *user.RequestDataExportHandler,
+	getHandler *user.GetDataExportHandler,
+) *UserDataExportHandler {
+	return &UserDataExportHandler{
+		requestHandler: requestHandler,
+		getHandler:     getHandler,
+	}
+}
+
+// RequestExport queues a personal data export for the current user
+//
+// @Summary      导出个人数据
+// @Description  申请导出本人数据（个人资料、访问令牌元数据、登录会话、操作记录），由后台任务生成 ZIP 归档（JSON 文件）。
+// @Description  已有排队或生成中的导出任务时直接返回该任务。归档生成后 7 天内可下载。
+// @Tags         用户 - 个人资料 (User - Profile)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      202 {object} response.DataResponse[user.DataExportDTO] "导出任务已排队"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/account/export [post]
+// @x-permission {"scope":"user:account:export"}
+func (h *UserDataExportHandler) RequestExport(c *gin.Context) {
+	uid, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.requestHandler.Handle(c.Request.Context(), user.RequestDataExportCommand{UserID: uid})
+	if err != nil {
+		handleDataExportError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, "data export queued", export)
+}
+
+// GetExport returns the status of a personal data export
+//
+// @Summary      个人数据导出状态
+// @Description  查询本人数据导出任务的状态
+// @Tags         用户 - 个人资料 (User - Profile)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "导出任务ID" minimum(1)
+// @Success      200 {object} response.DataResponse[user.DataExportDTO] "导出任务"
+// @Failure      400 {object} response.ErrorResponse "无效的任务ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      404 {object} response.ErrorResponse "任务不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/account/export/{id} [get]
+// @x-permission {"scope":"user:account:export"}
+func (h *UserDataExportHandler) GetExport(c *gin.Context) {
+	query, ok := dataExportQuery(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.getHandler.Handle(c.Request.Context(), query)
+	if err != nil {
+		handleDataExportError(c, err)
+		return
+	}
+
+	response.OK(c, "success", export)
+}
+
+// DownloadExport downloads a completed personal data export archive
+//
+// @Summary      下载个人数据归档
+// @Description  下载已生成的个人数据 ZIP 归档（manifest.json、profile.json、tokens.json、sessions.json、activity.json）
+// @Tags         用户 - 个人资料 (User - Profile)
+// @Produce      application/zip
+// @Security     BearerAuth
+// @Param        id path int true "导出任务ID" minimum(1)
+// @Success      200 {file} file "ZIP 归档"
+// @Failure      400 {object} response.ErrorResponse "无效的任务ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      404 {object} response.ErrorResponse "任务不存在"
+// @Failure      409 {object} response.ErrorResponse "归档尚未生成"
+// @Failure      410 {object} response.ErrorResponse "归档已过期"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/account/export/{id}/download [get]
+// @x-permission {"scope":"user:account:export"}
+func (h *UserDataExportHandler) DownloadExport(c *gin.Context) {
+	query, ok := dataExportQuery(c)
+	if !ok {
+		return
+	}
+
+	archive, err := h.getHandler.Download(c.Request.Context(), query)
+	if err != nil {
+		handleDataExportError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, archive.FileName))
+	c.Data(http.StatusOK, "application/zip", archive.Content)
+}
+
+// dataExportQuery 解析路径中的导出任务 ID，失败时已写出响应
+func dataExportQuery(c *gin.Context) (user.GetDataExportQuery, bool) {
+	uid, ok := getUserID(c)
+	if !ok {
+		return user.GetDataExportQuery{}, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid data export ID")
+		return user.GetDataExportQuery{}, false
+	}
+	return user.GetDataExportQuery{ID: uint(id), UserID: uid}, true
+}
+
+// handleDataExportError 统一处理个人数据导出相关错误
+func handleDataExportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, user.ErrDataExportNotFound):
+		response.NotFound(c, "data export")
+	case errors.Is(err, user.ErrDataExportNotReady):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, user.ErrDataExportExpired):
+		response.Failure(c, http.StatusGone, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
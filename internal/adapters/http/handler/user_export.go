@@ -0,0 +1,373 @@
+package handler
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
+)
+
+// exportFlushInterval 每写出多少行刷新一次响应缓冲
+const exportFlushInterval = 500
+
+// userExportColumns 表格格式（CSV/XLSX）的列
+var userExportColumns = []string{"id", "username", "email", "full_name", "status", "department", "roles", "created_at", "updated_at"}
+
+// ExportUsersQuery 用户导出查询参数
+// 过滤条件与用户列表相同；分页、排序、游标与统计参数被忽略，导出始终按 ID 顺序
+type ExportUsersQuery struct {
+	ListUsersQuery
+
+	// Format 导出格式
+	Format string `form:"format" json:"format" binding:"omitempty,oneof=csv ndjson xlsx"`
+}
+
+// UserExportHandler handles streaming user export (DDD+CQRS Use Case Pattern)
+type UserExportHandler struct {
+	exportHandler *user.ExportUsersHandler
+}
+
+// NewUserExportHandler creates a new UserExportHandler instance
+func NewUserExportHandler(exportHandler *user.ExportUsersHandler) *UserExportHandler {
+	return &UserExportHandler{exportHandler: exportHandler}
+}
+
+// ExportUsers streams the user list as CSV, NDJSON or XLSX
+//
+// @Summary      导出用户
+// @Description  按与用户列表相同的过滤条件导出用户（含角色名称），按 ID 分批读取并流式写出；分页、排序与游标参数被忽略。
+// @Description  按自定义属性过滤使用 attr[属性键]=值（精确匹配，可组合多个属性）
+// @Description  CSV/XLSX 中多个角色以分号分隔；NDJSON 每行一个 JSON 对象。每次导出记录一条 export 审计日志。
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     BearerAuth
+// @Param        params query handler.ExportUsersQuery false "查询参数"
+// @Success      200 {file} file "导出文件"
+// @Failure      400 {object} response.ErrorResponse "参数错误（过滤条件或属性过滤无效）"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/export [get]
+// @x-permission {"scope":"admin:users:export"}
+func (h *UserExportHandler) ExportUsers(c *gin.Context) {
+	var q ExportUsersQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+	if q.Format == "" {
+		q.Format = user.ExportFormatCSV
+	}
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	// 首行写出前才发送响应头，查询失败时仍可返回 JSON 错误
+	var w userExportWriter
+	written := 0
+	emit := func(dto *user.UserExportDTO) error {
+		if w == nil {
+			w = h.startExport(c, q.Format)
+		}
+		if err := w.WriteRow(dto); err != nil {
+			return err
+		}
+		written++
+		if written%exportFlushInterval == 0 {
+			return w.Flush()
+		}
+		return nil
+	}
+
+	filter := q.ToQuery()
+	filter.Attributes = c.QueryMap("attr")
+	_, err := h.exportHandler.Handle(c.Request.Context(), user.ExportUsersQuery{
+		Filter:    filter,
+		Format:    q.Format,
+		ActorID:   userID,
+		ActorName: c.GetString("username"),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}, emit)
+	if err != nil && w == nil {
+		writeListUsersError(c, err)
+		return
+	}
+	if err != nil {
+		// 响应已开始，无法再更改状态码，中止输出使客户端得到不完整的文件
+		slog.Error("user export aborted", "error", err, "written", written)
+		c.Abort()
+		return
+	}
+
+	if w == nil {
+		w = h.startExport(c, q.Format)
+	}
+	if err := w.Close(); err != nil {
+		slog.Error("failed to finish user export", "error", err)
+	}
+}
+
+// startExport 写出响应头并创建对应格式的写出器
+func (h *UserExportHandler) startExport(c *gin.Context, format string) userExportWriter {
+	filename := "users-" + time.Now().UTC().Format("20060102-150405") + "." + format
+
+	var w userExportWriter
+	switch format {
+	case user.ExportFormatNDJSON:
+		c.Header("Content-Type", "application/x-ndjson")
+		w = newNDJSONExportWriter(c.Writer)
+	case user.ExportFormatXLSX:
+		c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w = newXLSXExportWriter(c.Writer)
+	default:
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		w = newCSVExportWriter(c.Writer)
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+	return w
+}
+
+// userExportWriter 按格式逐行写出导出数据
+type userExportWriter interface {
+	WriteRow(dto *user.UserExportDTO) error
+	Flush() error
+	Close() error
+}
+
+// exportCells 将用户转换为表格行
+func exportCells(dto *user.UserExportDTO) []string {
+	return []string{
+		strconv.FormatUint(uint64(dto.ID), 10),
+		dto.Username,
+		dto.Email,
+		dto.FullName,
+		dto.Status,
+		dto.Department,
+		strings.Join(dto.Roles, ";"),
+		dto.CreatedAt.UTC().Format(time.RFC3339),
+		dto.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// flushResponse 在底层写入器支持时刷新 HTTP 响应
+func flushResponse(w io.Writer) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// csvExportWriter CSV 写出器
+type csvExportWriter struct {
+	out    io.Writer
+	w      *csv.Writer
+	header bool
+}
+
+func newCSVExportWriter(out io.Writer) *csvExportWriter {
+	return &csvExportWriter{out: out, w: csv.NewWriter(out)}
+}
+
+func (e *csvExportWriter) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+	return e.w.Write(userExportColumns)
+}
+
+func (e *csvExportWriter) WriteRow(dto *user.UserExportDTO) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	cells := exportCells(dto)
+	for i, v := range cells {
+		cells[i] = escapeSpreadsheetFormula(v)
+	}
+	return e.w.Write(cells)
+}
+
+func (e *csvExportWriter) Flush() error {
+	e.w.Flush()
+	flushResponse(e.out)
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	return e.Flush()
+}
+
+// escapeSpreadsheetFormula 防止以公式字符开头的值在电子表格中被当作公式执行（CSV 注入）
+func escapeSpreadsheetFormula(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+// ndjsonExportWriter JSON Lines 写出器
+type ndjsonExportWriter struct {
+	out io.Writer
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONExportWriter(out io.Writer) *ndjsonExportWriter {
+	buf := bufio.NewWriter(out)
+	return &ndjsonExportWriter{out: out, buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (e *ndjsonExportWriter) WriteRow(dto *user.UserExportDTO) error {
+	return e.enc.Encode(dto)
+}
+
+func (e *ndjsonExportWriter) Flush() error {
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	flushResponse(e.out)
+	return nil
+}
+
+func (e *ndjsonExportWriter) Close() error {
+	return e.Flush()
+}
+
+// xlsxExportWriter 流式 XLSX 写出器
+//
+// 先写出固定的工作簿结构，再将单个工作表的行以内联字符串逐行写入 zip 条目，
+// 不需要在内存中保留整个表格。
+type xlsxExportWriter struct {
+	out   io.Writer
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	row   int
+	err   error
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Users" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+func newXLSXExportWriter(out io.Writer) *xlsxExportWriter {
+	e := &xlsxExportWriter{out: out, zw: zip.NewWriter(out)}
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		if e.err = e.writePart(part.name, part.content); e.err != nil {
+			return e
+		}
+	}
+
+	sheet, err := e.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		e.err = err
+		return e
+	}
+	e.sheet = bufio.NewWriter(sheet)
+	_, e.err = e.sheet.WriteString(xlsxSheetStart)
+	if e.err == nil {
+		e.err = e.writeCells(userExportColumns)
+	}
+	return e
+}
+
+func (e *xlsxExportWriter) writePart(name, content string) error {
+	w, err := e.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+func (e *xlsxExportWriter) writeCells(cells []string) error {
+	e.row++
+	fmt.Fprintf(e.sheet, `<row r="%d">`, e.row)
+	for _, v := range cells {
+		e.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(e.sheet, []byte(v)); err != nil {
+			return err
+		}
+		e.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := e.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (e *xlsxExportWriter) WriteRow(dto *user.UserExportDTO) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.err = e.writeCells(exportCells(dto))
+	return e.err
+}
+
+func (e *xlsxExportWriter) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.err = e.sheet.Flush(); e.err != nil {
+		return e.err
+	}
+	if e.err = e.zw.Flush(); e.err != nil {
+		return e.err
+	}
+	flushResponse(e.out)
+	return nil
+}
+
+func (e *xlsxExportWriter) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	if _, err := e.sheet.WriteString(xlsxSheetEnd); err != nil {
+		return err
+	}
+	if err := e.sheet.Flush(); err != nil {
+		return err
+	}
+	return e.zw.Close()
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
+)
+
+// maxImportFileSize 导入文件大小上限
+const maxImportFileSize = 10 << 20
+
+// UserImportHandler handles CSV/XLSX user import operations (DDD+CQRS Use Case Pattern)
+//
+// 与 POST /api/admin/users/batch 的 JSON 批量创建不同，这里接受文件上传，
+// 支持列映射与预检（dry-run），大文件由后台任务处理并可轮询进度。
+type UserImportHandler struct {
+	// Command Handlers
+	importHandler *user.ImportUsersHandler
+
+	// Query Handlers
+	getJobHandler *user.GetImportJobHandler
+}
+
+// NewUserImportHandler creates a new UserImportHandler instance
+func NewUserImportHandler(
+	importHandler *user.ImportUsersHandler,
+	getJobHandler *user.GetImportJobHandler,
+) *UserImportHandler {
+	return &UserImportHandler{
+		importHandler: importHandler,
+		getJobHandler: getJobHandler,
+	}
+}
+
+// ImportUsers imports users from an uploaded CSV/XLSX file
+//
+// @Summary      导入用户
+// @Description  上传 CSV 或 XLSX 文件批量创建用户。第一行为表头，默认按列名 username/email/password/full_name/status/roles 匹配，
+// @Description  可通过 mapping（JSON，字段名到列名）自定义；roles 以分号或逗号分隔角色名称，含管理权限的角色不能通过导入分配（该行失败）。
+// @Description  dry_run=true 时仅逐行校验不写入。不超过 100 行的文件同步处理并返回逐行结果，更大的文件返回 202 并在后台处理。
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file formData file true "CSV 或 XLSX 文件（最大 10MB）"
+// @Param        mapping formData string false "列映射 JSON，如 {\"username\":\"Login\"}"
+// @Param        format formData string false "文件格式（csv/xlsx），默认按扩展名识别"
+// @Param        dry_run formData bool false "仅校验不写入"
+// @Success      200 {object} response.DataResponse[user.ImportJobDTO] "导入完成（含逐行结果）"
+// @Success      202 {object} response.DataResponse[user.ImportJobDTO] "已创建后台导入任务"
+// @Failure      400 {object} response.ErrorResponse "文件无效、格式不支持或行数超限"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/imports [post]
+// @x-permission {"scope":"admin:users:create"}
+func (h *UserImportHandler) ImportUsers(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required")
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		response.BadRequest(c, "file exceeds 10MB limit")
+		return
+	}
+
+	var mapping map[string]string
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err = json.Unmarshal([]byte(raw), &mapping); err != nil {
+			response.BadRequest(c, "invalid mapping: "+err.Error())
+			return
+		}
+	}
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(c, "failed to read file")
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		response.BadRequest(c, "failed to read file")
+		return
+	}
+
+	job, err := h.importHandler.Handle(c.Request.Context(), user.ImportUsersCommand{
+		CreatedBy: userID,
+		FileName:  fileHeader.Filename,
+		Format:    c.PostForm("format"),
+		Content:   content,
+		Mapping:   mapping,
+		DryRun:    dryRun,
+	})
+	if err != nil {
+		handleUserImportError(c, err)
+		return
+	}
+
+	if job.Status == "pending" {
+		response.Success(c, http.StatusAccepted, "import job queued", job)
+		return
+	}
+	response.OK(c, "import finished", job)
+}
+
+// GetImportJob returns the progress of an import job
+//
+// @Summary      导入任务进度
+// @Description  查询导入任务的状态与处理进度（不含逐行结果，逐行结果请下载报告）
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "导入任务ID" minimum(1)
+// @Success      200 {object} response.DataResponse[user.ImportJobDTO] "任务进度"
+// @Failure      400 {object} response.ErrorResponse "无效的任务ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "任务不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/imports/{id} [get]
+// @x-permission {"scope":"admin:users:create"}
+func (h *UserImportHandler) GetImportJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid import job ID")
+		return
+	}
+
+	job, err := h.getJobHandler.Handle(c.Request.Context(), user.GetImportJobQuery{ID: uint(id)})
+	if err != nil {
+		handleUserImportError(c, err)
+		return
+	}
+
+	response.OK(c, "success", job)
+}
+
+// DownloadImportReport downloads the per-row report of an import job as CSV
+//
+// @Summary      下载导入报告
+// @Description  下载导入任务的逐行结果（CSV：line,username,email,status,error），任务处理中时仅包含已处理的行
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        id path int true "导入任务ID" minimum(1)
+// @Success      200 {file} file "CSV 报告"
+// @Failure      400 {object} response.ErrorResponse "无效的任务ID"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "任务不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/imports/{id}/report [get]
+// @x-permission {"scope":"admin:users:create"}
+func (h *UserImportHandler) DownloadImportReport(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid import job ID")
+		return
+	}
+
+	job, err := h.getJobHandler.Handle(c.Request.Context(), user.GetImportJobQuery{ID: uint(id), IncludeResults: true})
+	if err != nil {
+		handleUserImportError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-import-%d-report.csv"`, job.ID))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"line", "username", "email", "status", "error"})
+	for _, r := range job.Results {
+		_ = w.Write([]string{strconv.Itoa(r.Line), r.Username, r.Email, r.Status, r.Error})
+	}
+	w.Flush()
+}
+
+// handleUserImportError 统一处理导入相关错误
+func handleUserImportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, user.ErrImportJobNotFound):
+		response.NotFound(c, "import job")
+	case errors.Is(err, user.ErrUnsupportedImportFormat),
+		errors.Is(err, user.ErrInvalidImportFile),
+		errors.Is(err, user.ErrTooManyImportRows):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
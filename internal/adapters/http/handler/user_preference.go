@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/setting"
+)
+
+// UserPreferenceHandler handles per-user preference operations (DDD+CQRS Use Case Pattern)
+type UserPreferenceHandler struct {
+	getPreferencesHandler    *setting.GetUserPreferencesHandler
+	updatePreferencesHandler *setting.UpdateUserPreferencesHandler
+}
+
+// NewUserPreferenceHandler creates a new UserPreferenceHandler instance
+func NewUserPreferenceHandler(
+	getPreferencesHandler *setting.GetUserPreferencesHandler,
+	updatePreferencesHandler *setting.UpdateUserPreferencesHandler,
+) *UserPreferenceHandler {
+	return &UserPreferenceHandler{
+		getPreferencesHandler:    getPreferencesHandler,
+		updatePreferencesHandler: updatePreferencesHandler,
+	}
+}
+
+// UpdatePreferencesRequest 更新个人偏好请求
+type UpdatePreferencesRequest struct {
+	// Preferences 偏好键到新值的映射，值为 null 时恢复管理员默认值
+	Preferences map[string]any `json:"preferences" binding:"required"`
+}
+
+// GetPreferences 获取个人偏好
+//
+// @Summary      获取个人偏好
+// @Description  返回当前用户的生效偏好（语言、时区、主题、通知订阅等），未覆盖的项取管理员在 preference 分类下配置的默认值
+// @Tags         用户 - 个人资料 (User - Profile)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.DataResponse[[]setting.PreferenceDTO] "生效偏好"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/preferences [get]
+// @x-permission {"scope":"user:preferences:read"}
+func (h *UserPreferenceHandler) GetPreferences(c *gin.Context) {
+	uid, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.getPreferencesHandler.Handle(c.Request.Context(), setting.GetUserPreferencesQuery{UserID: uid})
+	if err != nil {
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "success", prefs)
+}
+
+// UpdatePreferences 更新个人偏好
+//
+// @Summary      更新个人偏好
+// @Description  按键覆盖个人偏好，值按默认项的 value_type 校验；值为 null 时删除覆盖恢复默认。任一键不合法时整体不生效
+// @Tags         用户 - 个人资料 (User - Profile)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body UpdatePreferencesRequest true "偏好覆盖值"
+// @Success      200 {object} response.DataResponse[[]setting.PreferenceDTO] "更新后的生效偏好"
+// @Failure      400 {object} response.ErrorResponse "未知偏好项或值不合法"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/user/preferences [put]
+// @x-permission {"scope":"user:preferences:update"}
+func (h *UserPreferenceHandler) UpdatePreferences(c *gin.Context) {
+	uid, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	prefs, err := h.updatePreferencesHandler.Handle(c.Request.Context(), setting.UpdateUserPreferencesCommand{
+		UserID: uid,
+		Values: req.Preferences,
+	})
+	if err != nil {
+		if errors.Is(err, setting.ErrUnknownPreference) || errors.Is(err, setting.ErrInvalidPreferenceValue) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		response.InternalError(c, err.Error())
+		return
+	}
+
+	response.OK(c, "preferences updated successfully", prefs)
+}
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
@@ -11,7 +14,7 @@ type UserProfileHandler struct {
 	getUserHandler        *user.GetUserHandler
 	updateUserHandler     *user.UpdateUserHandler
 	changePasswordHandler *user.ChangePasswordHandler
-	deleteUserHandler     *user.DeleteUserHandler
+	requestDeletion       *user.RequestAccountDeletionHandler
 }
 
 // NewUserProfileHandler creates a new UserProfileHandler instance
@@ -19,13 +22,13 @@ func NewUserProfileHandler(
 	getUserHandler *user.GetUserHandler,
 	updateUserHandler *user.UpdateUserHandler,
 	changePasswordHandler *user.ChangePasswordHandler,
-	deleteUserHandler *user.DeleteUserHandler,
+	requestDeletion *user.RequestAccountDeletionHandler,
 ) *UserProfileHandler {
 	return &UserProfileHandler{
 		getUserHandler:        getUserHandler,
 		updateUserHandler:     updateUserHandler,
 		changePasswordHandler: changePasswordHandler,
-		deleteUserHandler:     deleteUserHandler,
+		requestDeletion:       requestDeletion,
 	}
 }
 
@@ -58,6 +61,7 @@ func (h *UserProfileHandler) GetProfile(c *gin.Context) {
 	u, err := h.getUserHandler.Handle(c.Request.Context(), user.GetUserQuery{
 		UserID:    uid,
 		WithRoles: true,
+		SelfView:  true,
 	})
 	if err != nil {
 		response.NotFound(c, "user")
@@ -72,19 +76,22 @@ type UpdateProfileRequest struct {
 	FullName *string `json:"full_name" binding:"omitempty,max=100" example:"张三"`
 	Avatar   *string `json:"avatar" binding:"omitempty,max=255" example:"https://example.com/avatar.jpg"`
 	Bio      *string `json:"bio" example:"这是我的个人简介"`
+
+	// Attributes 自定义属性变更，仅限用户可编辑的属性（见 GET /api/user/profile/attributes），值为 null 表示清除
+	Attributes map[string]any `json:"attributes"`
 }
 
 // UpdateProfile updates the current user's profile
 //
 // @Summary      更新个人资料
-// @Description  用户更新自己的姓名、头像和个人简介
+// @Description  用户更新自己的姓名、头像、个人简介和可编辑的自定义属性
 // @Tags         用户 - 个人资料 (User - Profile)
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        request body UpdateProfileRequest true "更新信息"
 // @Success      200 {object} response.DataResponse[user.UserWithRolesDTO] "资料更新成功"
-// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      400 {object} response.ErrorResponse "参数错误或自定义属性无效、不可编辑"
 // @Failure      401 {object} response.ErrorResponse "未授权"
 // @Failure      500 {object} response.ErrorResponse "服务器内部错误"
 // @Router       /api/user/profile [put]
@@ -114,7 +121,14 @@ func (h *UserProfileHandler) UpdateProfile(c *gin.Context) {
 		FullName: req.FullName,
 		Avatar:   req.Avatar,
 		Bio:      req.Bio,
+
+		Attributes:  req.Attributes,
+		SelfService: true,
 	}); err != nil {
+		if isAttributeValueError(err) {
+			response.BadRequest(c, err.Error())
+			return
+		}
 		response.InternalError(c, err.Error())
 		return
 	}
@@ -122,6 +136,7 @@ func (h *UserProfileHandler) UpdateProfile(c *gin.Context) {
 	updatedUser, err := h.getUserHandler.Handle(c.Request.Context(), user.GetUserQuery{
 		UserID:    uid,
 		WithRoles: true,
+		SelfView:  true,
 	})
 	if err != nil {
 		response.InternalError(c, err.Error())
@@ -143,6 +158,7 @@ func (h *UserProfileHandler) UpdateProfile(c *gin.Context) {
 // @Success      200 {object} response.MessageResponse "密码修改成功"
 // @Failure      400 {object} response.ErrorResponse "参数错误或旧密码不正确"
 // @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "目录用户的密码由外部身份源管理"
 // @Router       /api/user/password [put]
 // @x-permission {"scope":"user:password:update"}
 func (h *UserProfileHandler) ChangePassword(c *gin.Context) {
@@ -169,6 +185,10 @@ func (h *UserProfileHandler) ChangePassword(c *gin.Context) {
 		OldPassword: req.OldPassword,
 		NewPassword: req.NewPassword,
 	}); err != nil {
+		if errors.Is(err, user.ErrPasswordManagedExternally) {
+			response.Forbidden(c, err.Error())
+			return
+		}
 		response.BadRequest(c, err.Error())
 		return
 	}
@@ -176,15 +196,17 @@ func (h *UserProfileHandler) ChangePassword(c *gin.Context) {
 	response.OK(c, "password changed successfully", nil)
 }
 
-// DeleteAccount deletes the current user's account
+// DeleteAccount schedules deletion of the current user's account
 //
 // @Summary      删除账号
-// @Description  用户删除自己的账号（不可恢复）
+// @Description  申请删除自己的账号。账号进入宽限期（系统配置 security.account_deletion_grace_days），
+// @Description  宽限期内重新登录即撤销删除；到期后后台任务匿名化审计日志、吊销访问令牌、删除 2FA 数据并删除账号。
+// @Description  删除前可通过 POST /api/user/account/export 导出个人数据。
 // @Tags         用户 - 个人资料 (User - Profile)
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200 {object} response.MessageResponse "账号删除成功"
+// @Success      202 {object} response.DataResponse[user.AccountDeletionDTO] "删除已排期"
 // @Failure      401 {object} response.ErrorResponse "未授权"
 // @Failure      500 {object} response.ErrorResponse "服务器内部错误"
 // @Router       /api/user/account [delete]
@@ -202,12 +224,17 @@ func (h *UserProfileHandler) DeleteAccount(c *gin.Context) {
 		return
 	}
 
-	if err := h.deleteUserHandler.Handle(c.Request.Context(), user.DeleteUserCommand{
+	result, err := h.requestDeletion.Handle(c.Request.Context(), user.RequestAccountDeletionCommand{
 		UserID: uid,
-	}); err != nil {
+	})
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			response.NotFound(c, "user")
+			return
+		}
 		response.InternalError(c, err.Error())
 		return
 	}
 
-	response.OK(c, "account deleted successfully", nil)
+	response.Success(c, http.StatusAccepted, "account deletion scheduled", result)
 }
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
+)
+
+// UserStatusHandler handles the user status lifecycle (DDD+CQRS Use Case Pattern)
+//
+// 显式的状态变更接口：封禁附带原因和可选的到期时间，到期后由后台任务自动解封；
+// 每次变更记录状态历史。
+type UserStatusHandler struct {
+	changeHandler  *user.ChangeUserStatusHandler
+	historyHandler *user.GetUserStatusHistoryHandler
+}
+
+// NewUserStatusHandler creates a new UserStatusHandler instance
+func NewUserStatusHandler(
+	changeHandler *user.ChangeUserStatusHandler,
+	historyHandler *user.GetUserStatusHistoryHandler,
+) *UserStatusHandler {
+	return &UserStatusHandler{
+		changeHandler:  changeHandler,
+		historyHandler: historyHandler,
+	}
+}
+
+// ChangeStatus changes a user's status
+//
+// @Summary      变更用户状态
+// @Description  将用户设为 active / inactive / banned，须提供原因。封禁可指定 banned_until（为空表示永久封禁），
+// @Description  到期后自动解封；封禁会使该用户已签发的令牌立即失效并撤销其全部个人访问令牌。
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户ID" minimum(1)
+// @Param        request body user.ChangeUserStatusDTO true "状态变更信息"
+// @Success      200 {object} response.DataResponse[user.UserStatusDTO] "变更成功"
+// @Failure      400 {object} response.ErrorResponse "参数错误或封禁期限无效"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足或不能变更自己的状态"
+// @Failure      404 {object} response.ErrorResponse "用户不存在"
+// @Failure      409 {object} response.ErrorResponse "用户已处于目标状态"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/{id}/status [post]
+// @x-permission {"scope":"admin:users:update"}
+func (h *UserStatusHandler) ChangeStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	var req user.ChangeUserStatusDTO
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	status, err := h.changeHandler.Handle(c.Request.Context(), user.ChangeUserStatusCommand{
+		UserID:      uint(id),
+		OperatorID:  c.GetUint("user_id"),
+		Status:      req.Status,
+		Reason:      req.Reason,
+		BannedUntil: req.BannedUntil,
+	})
+	if err != nil {
+		handleUserStatusError(c, err)
+		return
+	}
+
+	response.OK(c, "user status changed successfully", status)
+}
+
+// ListStatusHistory lists a user's status changes
+//
+// @Summary      用户状态变更历史
+// @Description  分页获取用户的状态变更记录（按时间倒序），operator_id 为 0 表示系统自动解封
+// @Tags         管理员 - 用户管理 (Admin - User Management)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "用户ID" minimum(1)
+// @Param        params query response.PaginationQueryDTO false "分页参数"
+// @Success      200 {object} response.PagedResponse[user.StatusChangeDTO] "变更记录"
+// @Failure      400 {object} response.ErrorResponse "参数错误"
+// @Failure      401 {object} response.ErrorResponse "未授权"
+// @Failure      403 {object} response.ErrorResponse "权限不足"
+// @Failure      404 {object} response.ErrorResponse "用户不存在"
+// @Failure      500 {object} response.ErrorResponse "服务器内部错误"
+// @Router       /api/admin/users/{id}/status-history [get]
+// @x-permission {"scope":"admin:users:read"}
+func (h *UserStatusHandler) ListStatusHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.BadRequest(c, "invalid user ID")
+		return
+	}
+
+	var q response.PaginationQueryDTO
+	if err = c.ShouldBindQuery(&q); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	result, err := h.historyHandler.Handle(c.Request.Context(), user.GetUserStatusHistoryQuery{
+		UserID: uint(id),
+		Page:   q.GetPage(),
+		Limit:  q.GetLimit(),
+	})
+	if err != nil {
+		handleUserStatusError(c, err)
+		return
+	}
+
+	meta := response.NewPaginationMeta(int(result.Total), q.GetPage(), q.GetLimit())
+	response.List(c, "success", result.Changes, meta)
+}
+
+// handleUserStatusError 将用户状态相关错误映射为 HTTP 响应
+func handleUserStatusError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, user.ErrUserNotFound):
+		response.NotFound(c, "user")
+	case errors.Is(err, user.ErrCannotChangeOwnStatus), errors.Is(err, user.ErrAccessDenied):
+		response.Forbidden(c, err.Error())
+	case errors.Is(err, user.ErrUserStatusUnchanged):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, user.ErrInvalidUserStatus), errors.Is(err, user.ErrInvalidBanExpiry):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalError(c, err.Error())
+	}
+}
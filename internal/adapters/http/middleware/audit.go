@@ -82,7 +82,7 @@ func AuditMiddleware(handler *auditlog.CreateLogHandler) gin.HandlerFunc {
 			ResourceID: resourceID,
 			IPAddress:  c.ClientIP(),
 			UserAgent:  c.Request.UserAgent(),
-			Details:    formatDetails(c.Request.Method, requestBody, c.Writer.Status(), duration),
+			Details:    formatDetails(c.Request.Method, c.GetString("auth_type"), requestBody, c.Writer.Status(), duration),
 			Status:     status,
 		}
 
@@ -174,9 +174,12 @@ func isAction(segment string) bool {
 }
 
 // formatDetails formats request details for audit log
-func formatDetails(method string, requestBody []byte, statusCode int, duration time.Duration) string {
+func formatDetails(method, authType string, requestBody []byte, statusCode int, duration time.Duration) string {
 	details := make(map[string]any)
 	details["method"] = method
+	if authType != "" {
+		details["auth_type"] = authType
+	}
 	details["status_code"] = statusCode
 	details["duration_ms"] = duration.Milliseconds()
 
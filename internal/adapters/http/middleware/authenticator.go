@@ -0,0 +1,270 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
+)
+
+// 认证方式，写入上下文 auth_type 并记录到审计日志
+const (
+	AuthTypeJWT    = "jwt"
+	AuthTypePAT    = "pat"
+	AuthTypeBasic  = "basic"
+	AuthTypeAPIKey = "api_key"
+	AuthTypeMTLS   = "mtls"
+)
+
+// APIKeyHeader API Key 认证使用的请求头（值为 PAT）
+const APIKeyHeader = "X-API-Key"
+
+// Identity 认证成功后的调用方身份
+// Roles/Permissions 为 nil 时由认证链从 PermissionCacheService 查询
+type Identity struct {
+	UserID      uint
+	Username    string
+	Email       string
+	Roles       []string
+	Permissions []string
+	AuthType    string
+	PATID       uint
+	Scope       string
+
+	// IssuedAt 令牌签发时间（仅 JWT），用于检查会话是否已被吊销
+	IssuedAt time.Time
+}
+
+// Authenticator 可插拔认证器
+//
+// Authenticate 返回 (nil, nil) 表示请求未携带该认证器支持的凭证，
+// 由认证链中的下一个认证器继续处理；返回错误表示凭证存在但无效，认证链立即终止。
+type Authenticator interface {
+	Name() string
+	Authenticate(c *gin.Context) (*Identity, error)
+}
+
+// Authenticate 按顺序执行认证器链，第一个识别出凭证的认证器决定认证结果
+func Authenticate(permCacheService *auth.PermissionCacheService, authenticators ...Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, err := runAuthenticators(c, authenticators)
+		if err == nil && identity == nil {
+			response.Unauthorized(c, noCredentialsMessage(c))
+			c.Abort()
+			return
+		}
+		if err == nil && !identity.IssuedAt.IsZero() {
+			err = checkSessionRevoked(c.Request.Context(), permCacheService, identity)
+		}
+		if err == nil && identity.Permissions == nil {
+			identity.Roles, identity.Permissions, err = permCacheService.GetUserPermissions(c.Request.Context(), identity.UserID)
+			if err != nil {
+				err = fmt.Errorf("failed to get user permissions: %w", err)
+			}
+		}
+		if err != nil {
+			response.Unauthorized(c, err.Error())
+			c.Abort()
+			return
+		}
+
+		setIdentity(c, identity)
+
+		// 受限令牌仅能访问作用域允许的路由
+		if !scopeAllowsRoute(identity.Scope, c.Request.Method, c.FullPath()) {
+			response.Forbidden(c, "password change required")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// checkSessionRevoked 拒绝在用户会话吊销（如封禁）之前签发的令牌
+func checkSessionRevoked(ctx context.Context, permCacheService *auth.PermissionCacheService, identity *Identity) error {
+	revoked, err := permCacheService.IsSessionRevoked(ctx, identity.UserID, identity.IssuedAt)
+	if err != nil {
+		return fmt.Errorf("failed to check session: %w", err)
+	}
+	if revoked {
+		return errors.New("token has been revoked")
+	}
+	return nil
+}
+
+// runAuthenticators 依次尝试认证器，返回第一个识别出凭证的结果
+func runAuthenticators(c *gin.Context, authenticators []Authenticator) (*Identity, error) {
+	for _, a := range authenticators {
+		identity, err := a.Authenticate(c)
+		if err != nil {
+			return nil, err
+		}
+		if identity != nil {
+			if identity.AuthType == "" {
+				identity.AuthType = a.Name()
+			}
+			return identity, nil
+		}
+	}
+	return nil, nil //nolint:nilnil // 无任何凭证由调用方处理
+}
+
+// noCredentialsMessage 没有任何认证器识别出凭证时的提示
+func noCredentialsMessage(c *gin.Context) string {
+	if c.GetHeader("Authorization") == "" {
+		return "Authorization header is required"
+	}
+	return "Authorization header format must be Bearer {token}"
+}
+
+// setIdentity 将身份信息写入上下文
+func setIdentity(c *gin.Context, identity *Identity) {
+	c.Set("user_id", identity.UserID)
+	c.Set("username", identity.Username)
+	c.Set("email", identity.Email)
+	c.Set("roles", identity.Roles)
+	c.Set("permissions", identity.Permissions)
+	c.Set("auth_type", identity.AuthType)
+	c.Set("token_scope", identity.Scope)
+	if identity.PATID != 0 {
+		c.Set("pat_id", identity.PATID) // 额外存储 PAT ID，用于审计
+	}
+}
+
+// bearerAuthenticator Authorization: Bearer <token>，支持 JWT 和 PAT
+type bearerAuthenticator struct {
+	jwtManager *auth.JWTManager
+	patService *auth.PATService
+}
+
+// NewBearerAuthenticator 创建 Bearer 认证器（PAT 以 "pat_" 开头，否则按 JWT 处理）
+func NewBearerAuthenticator(jwtManager *auth.JWTManager, patService *auth.PATService) Authenticator {
+	return &bearerAuthenticator{jwtManager: jwtManager, patService: patService}
+}
+
+func (a *bearerAuthenticator) Name() string { return AuthTypeJWT }
+
+func (a *bearerAuthenticator) Authenticate(c *gin.Context) (*Identity, error) {
+	scheme, tokenString, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || scheme != "Bearer" {
+		return nil, nil //nolint:nilnil // 非 Bearer 凭证交由下一个认证器
+	}
+
+	if strings.HasPrefix(tokenString, "pat_") {
+		token, err := a.patService.ValidateTokenWithIP(c.Request.Context(), tokenString, c.ClientIP())
+		if err != nil {
+			return nil, err
+		}
+		identity, err := patIdentity(c.Request.Context(), a.patService, token)
+		if err != nil {
+			return nil, err
+		}
+		identity.AuthType = AuthTypePAT
+		return identity, nil
+	}
+
+	return identityFromJWT(a.jwtManager, tokenString)
+}
+
+// basicAuthenticator HTTP Basic 认证（用户名 + PAT，与 git 客户端兼容）
+type basicAuthenticator struct {
+	patService *auth.PATService
+}
+
+// NewBasicAuthenticator 创建 HTTP Basic 认证器，密码位置填写 PAT
+func NewBasicAuthenticator(patService *auth.PATService) Authenticator {
+	return &basicAuthenticator{patService: patService}
+}
+
+func (a *basicAuthenticator) Name() string { return AuthTypeBasic }
+
+func (a *basicAuthenticator) Authenticate(c *gin.Context) (*Identity, error) {
+	username, password, ok := c.Request.BasicAuth()
+	if !ok {
+		return nil, nil //nolint:nilnil // 非 Basic 凭证交由下一个认证器
+	}
+
+	token, err := a.patService.ValidateBasicCredentials(c.Request.Context(), username, password, c.ClientIP())
+	if err != nil {
+		return nil, err
+	}
+	identity, err := patIdentity(c.Request.Context(), a.patService, token)
+	if err != nil {
+		return nil, err
+	}
+	identity.Username = username
+	return identity, nil
+}
+
+// apiKeyAuthenticator X-API-Key 请求头认证（值为 PAT）
+type apiKeyAuthenticator struct {
+	patService *auth.PATService
+}
+
+// NewAPIKeyAuthenticator 创建 API Key 认证器
+func NewAPIKeyAuthenticator(patService *auth.PATService) Authenticator {
+	return &apiKeyAuthenticator{patService: patService}
+}
+
+func (a *apiKeyAuthenticator) Name() string { return AuthTypeAPIKey }
+
+func (a *apiKeyAuthenticator) Authenticate(c *gin.Context) (*Identity, error) {
+	key := c.GetHeader(APIKeyHeader)
+	if key == "" {
+		return nil, nil //nolint:nilnil // 未携带 API Key
+	}
+
+	token, err := a.patService.ValidateTokenWithIP(c.Request.Context(), key, c.ClientIP())
+	if err != nil {
+		return nil, err
+	}
+	return patIdentity(c.Request.Context(), a.patService, token)
+}
+
+// patIdentity 构造令牌认证的身份
+// 令牌所属用户必须修改密码时与 JWT 受限令牌一样只授予作用域权限，由认证链限制可访问的路由
+func patIdentity(ctx context.Context, patService *auth.PATService, token *pat.PersonalAccessToken) (*Identity, error) {
+	scope, err := patService.PasswordChangeScope(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{UserID: token.UserID, PATID: token.ID, Scope: scope}
+	if scope != "" {
+		identity.Permissions = append([]string{}, scopedPermissions[scope]...)
+	}
+	return identity, nil
+}
+
+// clientCertAuthenticator mTLS 客户端证书认证，证书 CN 映射到服务账号
+type clientCertAuthenticator struct {
+	certService *auth.ClientCertService
+}
+
+// NewClientCertAuthenticator 创建客户端证书认证器
+func NewClientCertAuthenticator(certService *auth.ClientCertService) Authenticator {
+	return &clientCertAuthenticator{certService: certService}
+}
+
+func (a *clientCertAuthenticator) Name() string { return AuthTypeMTLS }
+
+func (a *clientCertAuthenticator) Authenticate(c *gin.Context) (*Identity, error) {
+	// 仅信任 TLS 层已使用 client CA 校验过的证书链
+	tlsState := c.Request.TLS
+	if a.certService == nil || !a.certService.Enabled() || tlsState == nil || len(tlsState.VerifiedChains) == 0 {
+		return nil, nil //nolint:nilnil // 未携带已验证的客户端证书
+	}
+
+	u, err := a.certService.Authenticate(c.Request.Context(), tlsState.VerifiedChains[0][0])
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{UserID: u.ID, Username: u.Username, Email: u.Email}, nil
+}
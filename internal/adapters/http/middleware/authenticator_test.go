@@ -0,0 +1,381 @@
+package middleware
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
+)
+
+// 测试用户
+const (
+	aliceID   uint = 1 // 普通用户
+	bobID     uint = 2 // 必须修改密码的用户
+	serviceID uint = 3 // mTLS 服务账号
+)
+
+const serviceCertCN = "deploy-bot"
+
+// stubUserQueryRepo 按 ID / 用户名返回预置用户
+type stubUserQueryRepo struct {
+	user.QueryRepository
+
+	users map[uint]*user.User
+}
+
+func (r *stubUserQueryRepo) GetByID(_ context.Context, id uint) (*user.User, error) {
+	if u, ok := r.users[id]; ok {
+		return u, nil
+	}
+	return nil, user.ErrUserNotFound
+}
+
+func (r *stubUserQueryRepo) GetByIDWithRoles(ctx context.Context, id uint) (*user.User, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *stubUserQueryRepo) GetByUsername(_ context.Context, username string) (*user.User, error) {
+	for _, u := range r.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return nil, user.ErrUserNotFound
+}
+
+// stubPATQueryRepo 按令牌哈希返回预置 PAT（返回副本，避免与异步刷新使用时间竞争）
+type stubPATQueryRepo struct {
+	pat.QueryRepository
+
+	tokens map[string]pat.PersonalAccessToken
+}
+
+func (r *stubPATQueryRepo) FindByToken(_ context.Context, tokenHash string) (*pat.PersonalAccessToken, error) {
+	token, ok := r.tokens[tokenHash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &token, nil
+}
+
+// stubPATCommandRepo 忽略最近使用时间的刷新
+type stubPATCommandRepo struct {
+	pat.CommandRepository
+}
+
+func (r *stubPATCommandRepo) Update(context.Context, *pat.PersonalAccessToken) error {
+	return nil
+}
+
+// newUnavailableRedis 返回始终连接失败的 Redis 客户端，权限缓存全部回落到仓储查询
+func newUnavailableRedis() *redis.Client {
+	return redis.NewClient(&redis.Options{
+		MaxRetries:         -1,
+		DialerRetries:      1,
+		DialerRetryTimeout: time.Millisecond,
+		Dialer: func(context.Context, string, string) (net.Conn, error) {
+			return nil, errors.New("redis unavailable")
+		},
+	})
+}
+
+func newTestUsers() *stubUserQueryRepo {
+	developer := role.Role{ID: 10, Name: "developer", Permissions: []role.Permission{{ID: 100, Code: "user:profile:read"}}}
+	return &stubUserQueryRepo{users: map[uint]*user.User{
+		aliceID:   {ID: aliceID, Username: "alice", Status: "active", Roles: []role.Role{developer}},
+		bobID:     {ID: bobID, Username: "bob", Status: "active", MustChangePassword: true, Roles: []role.Role{developer}},
+		serviceID: {ID: serviceID, Username: "deployer", Status: "active", Roles: []role.Role{developer}},
+	}}
+}
+
+// authTestEnv 组装与路由一致的认证链及各用户的凭证
+type authTestEnv struct {
+	router     *gin.Engine
+	jwtManager *auth.JWTManager
+	pats       map[uint]string // 用户 ID -> PAT 明文
+	serviceTLS *tls.ConnectionState
+}
+
+func newAuthTestEnv(t *testing.T) *authTestEnv {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	users := newTestUsers()
+	tokenGen := auth.NewTokenGenerator()
+	patQuery := &stubPATQueryRepo{tokens: map[string]pat.PersonalAccessToken{}}
+	pats := make(map[uint]string)
+	for i, userID := range []uint{aliceID, bobID} {
+		plain, hash, prefix, err := tokenGen.GeneratePAT()
+		require.NoError(t, err)
+		patQuery.tokens[hash] = pat.PersonalAccessToken{ID: uint(i + 1), UserID: userID, Token: hash, TokenPrefix: prefix, Status: pat.StatusActive}
+		pats[userID] = plain
+	}
+
+	jwtManager := auth.NewJWTManager("test-secret", time.Hour, time.Hour)
+	patService := auth.NewPATService(&stubPATCommandRepo{}, patQuery, users, nil, tokenGen, nil)
+	permCache := auth.NewPermissionCacheService(newUnavailableRedis(), users, nil, nil, nil, "test:")
+	certService, err := auth.NewClientCertService(users, serviceCertCN+"=deployer")
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(Authenticate(permCache,
+		NewBearerAuthenticator(jwtManager, patService),
+		NewBasicAuthenticator(patService),
+		NewAPIKeyAuthenticator(patService),
+		NewClientCertAuthenticator(certService),
+	))
+	echo := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"user_id":     c.GetUint("user_id"),
+			"auth_type":   c.GetString("auth_type"),
+			"permissions": c.GetStringSlice("permissions"),
+		})
+	}
+	router.GET("/api/user/profile", echo)
+	router.PUT("/api/user/password", echo)
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: serviceCertCN}}
+	return &authTestEnv{
+		router:     router,
+		jwtManager: jwtManager,
+		pats:       pats,
+		serviceTLS: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}, VerifiedChains: [][]*x509.Certificate{{cert}}},
+	}
+}
+
+func (e *authTestEnv) jwt(t *testing.T, userID uint, username, scope string) string {
+	t.Helper()
+	token, err := e.jwtManager.GenerateScopedAccessToken(userID, username, username+"@example.com", scope)
+	require.NoError(t, err)
+	return token
+}
+
+type authResult struct {
+	UserID      uint     `json:"user_id"`
+	AuthType    string   `json:"auth_type"`
+	Permissions []string `json:"permissions"`
+}
+
+func (e *authTestEnv) do(t *testing.T, method, path string, prepare func(*http.Request)) (int, authResult) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	prepare(req)
+	w := httptest.NewRecorder()
+	e.router.ServeHTTP(w, req)
+
+	var result authResult
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	}
+	return w.Code, result
+}
+
+func TestAuthenticate_Credentials(t *testing.T) {
+	env := newAuthTestEnv(t)
+
+	tests := []struct {
+		name         string
+		prepare      func(*http.Request)
+		wantStatus   int
+		wantAuthType string
+		wantUserID   uint
+	}{
+		{
+			name: "Bearer JWT",
+			prepare: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+env.jwt(t, aliceID, "alice", ""))
+			},
+			wantStatus: http.StatusOK, wantAuthType: AuthTypeJWT, wantUserID: aliceID,
+		},
+		{
+			name:       "Bearer PAT",
+			prepare:    func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+env.pats[aliceID]) },
+			wantStatus: http.StatusOK, wantAuthType: AuthTypePAT, wantUserID: aliceID,
+		},
+		{
+			name:       "Basic 用户名与 PAT 所属用户一致",
+			prepare:    func(r *http.Request) { r.SetBasicAuth("alice", env.pats[aliceID]) },
+			wantStatus: http.StatusOK, wantAuthType: AuthTypeBasic, wantUserID: aliceID,
+		},
+		{
+			name:       "Basic 用户名与 PAT 所属用户不一致",
+			prepare:    func(r *http.Request) { r.SetBasicAuth("bob", env.pats[aliceID]) },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "X-API-Key",
+			prepare:    func(r *http.Request) { r.Header.Set(APIKeyHeader, env.pats[aliceID]) },
+			wantStatus: http.StatusOK, wantAuthType: AuthTypeAPIKey, wantUserID: aliceID,
+		},
+		{
+			name:       "X-API-Key 无效",
+			prepare:    func(r *http.Request) { r.Header.Set(APIKeyHeader, "pat_invalid_token") },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "mTLS 已验证的客户端证书",
+			prepare:    func(r *http.Request) { r.TLS = env.serviceTLS },
+			wantStatus: http.StatusOK, wantAuthType: AuthTypeMTLS, wantUserID: serviceID,
+		},
+		{
+			name: "mTLS 证书未经验证",
+			prepare: func(r *http.Request) {
+				r.TLS = &tls.ConnectionState{PeerCertificates: env.serviceTLS.PeerCertificates}
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "未携带凭证",
+			prepare:    func(*http.Request) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, result := env.do(t, http.MethodGet, "/api/user/profile", tt.prepare)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantAuthType, result.AuthType)
+				assert.Equal(t, tt.wantUserID, result.UserID)
+				assert.Equal(t, []string{"user:profile:read"}, result.Permissions)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_ChainOrder(t *testing.T) {
+	env := newAuthTestEnv(t)
+
+	tests := []struct {
+		name         string
+		prepare      func(*http.Request)
+		wantStatus   int
+		wantAuthType string
+		wantUserID   uint
+	}{
+		{
+			name: "Bearer 优先于 X-API-Key 与 mTLS",
+			prepare: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+env.jwt(t, aliceID, "alice", ""))
+				r.Header.Set(APIKeyHeader, env.pats[bobID])
+				r.TLS = env.serviceTLS
+			},
+			wantStatus: http.StatusOK, wantAuthType: AuthTypeJWT, wantUserID: aliceID,
+		},
+		{
+			name: "Basic 优先于 X-API-Key 与 mTLS",
+			prepare: func(r *http.Request) {
+				r.SetBasicAuth("alice", env.pats[aliceID])
+				r.Header.Set(APIKeyHeader, env.pats[bobID])
+				r.TLS = env.serviceTLS
+			},
+			wantStatus: http.StatusOK, wantAuthType: AuthTypeBasic, wantUserID: aliceID,
+		},
+		{
+			name: "X-API-Key 优先于 mTLS",
+			prepare: func(r *http.Request) {
+				r.Header.Set(APIKeyHeader, env.pats[aliceID])
+				r.TLS = env.serviceTLS
+			},
+			wantStatus: http.StatusOK, wantAuthType: AuthTypeAPIKey, wantUserID: aliceID,
+		},
+		{
+			name: "前序凭证无效时不回落到后续认证器",
+			prepare: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer invalid")
+				r.Header.Set(APIKeyHeader, env.pats[aliceID])
+				r.TLS = env.serviceTLS
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "非 Bearer/Basic 的 Authorization 交由后续认证器",
+			prepare: func(r *http.Request) {
+				r.Header.Set("Authorization", "Token abc")
+				r.Header.Set(APIKeyHeader, env.pats[aliceID])
+			},
+			wantStatus: http.StatusOK, wantAuthType: AuthTypeAPIKey, wantUserID: aliceID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, result := env.do(t, http.MethodGet, "/api/user/profile", tt.prepare)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.wantAuthType, result.AuthType)
+				assert.Equal(t, tt.wantUserID, result.UserID)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_PasswordChangeScope(t *testing.T) {
+	env := newAuthTestEnv(t)
+
+	credentials := []struct {
+		name     string
+		authType string
+		prepare  func(*http.Request)
+	}{
+		{
+			name:     "受限 JWT",
+			authType: AuthTypeJWT,
+			prepare: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+env.jwt(t, bobID, "bob", auth.ScopePasswordChange))
+			},
+		},
+		{
+			name:     "Bearer PAT",
+			authType: AuthTypePAT,
+			prepare:  func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+env.pats[bobID]) },
+		},
+		{
+			name:     "Basic",
+			authType: AuthTypeBasic,
+			prepare:  func(r *http.Request) { r.SetBasicAuth("bob", env.pats[bobID]) },
+		},
+		{
+			name:     "X-API-Key",
+			authType: AuthTypeAPIKey,
+			prepare:  func(r *http.Request) { r.Header.Set(APIKeyHeader, env.pats[bobID]) },
+		},
+	}
+
+	for _, cred := range credentials {
+		t.Run(cred.name+" 不能访问作用域外的路由", func(t *testing.T) {
+			status, _ := env.do(t, http.MethodGet, "/api/user/profile", cred.prepare)
+
+			assert.Equal(t, http.StatusForbidden, status)
+		})
+
+		t.Run(cred.name+" 仅获得作用域权限", func(t *testing.T) {
+			status, result := env.do(t, http.MethodPut, "/api/user/password", cred.prepare)
+
+			require.Equal(t, http.StatusOK, status)
+			assert.Equal(t, cred.authType, result.AuthType)
+			assert.Equal(t, bobID, result.UserID)
+			assert.Equal(t, scopedPermissions[auth.ScopePasswordChange], result.Permissions)
+		})
+	}
+}
@@ -3,6 +3,7 @@
 // 本包实现了 Gin 框架的中间件，用于请求处理管道：
 //
 // 认证中间件：
+//   - Authenticate: 可插拔认证链（Bearer JWT/PAT、HTTP Basic、X-API-Key、mTLS 客户端证书）
 //   - Auth: 统一认证（支持 JWT 和 PAT 双模式）
 //   - JWTAuth: 仅 JWT 认证（已废弃，保留向后兼容）
 //
@@ -15,14 +16,17 @@
 //   - Logger: 基于 slog 的请求日志
 //   - AuditMiddleware: 审计日志记录
 //
+// 受限令牌：
+// 带 scope 声明的 JWT（如强制修改密码）以及所属用户必须修改密码的 PAT 仅能访问 scopedRoutes 中声明的路由，
+// 权限也仅限于 scopedPermissions 中声明的权限。
+//
 // 权限缓存机制：
 // 新架构中，JWT/PAT 仅存储 user_id，权限信息从 PermissionCacheService
 // 实时查询，支持权限变更后立即生效。
 package middleware
 
 import (
-	"context"
-	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -30,45 +34,29 @@ import (
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
 )
 
-// Auth 统一认证中间件 - 支持 JWT 和 PAT
+// Auth 统一认证中间件 - 支持 JWT 和 PAT（Bearer）
 // 新架构：权限信息统一从 PermissionCacheService 查询
+// 需要 Basic、API Key、mTLS 等认证方式时使用 Authenticate 组装认证链
 func Auth(jwtManager *auth.JWTManager, patService *auth.PATService, permCacheService *auth.PermissionCacheService) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// 从请求头获取 Authorization
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			response.Unauthorized(c, "Authorization header is required")
-			c.Abort()
-			return
-		}
-
-		// 验证格式：Bearer <token>
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			response.Unauthorized(c, "Authorization header format must be Bearer {token}")
-			c.Abort()
-			return
-		}
-
-		tokenString := parts[1]
+	return Authenticate(permCacheService, NewBearerAuthenticator(jwtManager, patService))
+}
 
-		// 判断 token 类型: PAT 以 "pat_" 开头，使用相应的认证方式
-		ctx := c.Request.Context()
-		var authErr error
-		if strings.HasPrefix(tokenString, "pat_") {
-			authErr = authenticateWithPAT(ctx, c, patService, permCacheService, tokenString)
-		} else {
-			authErr = authenticateWithJWT(ctx, c, jwtManager, permCacheService, tokenString)
-		}
+// scopedRoutes 受限令牌作用域允许访问的路由（METHOD + 路由模板）
+var scopedRoutes = map[string][]string{
+	auth.ScopePasswordChange: {"PUT /api/user/password"},
+}
 
-		if authErr != nil {
-			response.Unauthorized(c, authErr.Error())
-			c.Abort()
-			return
-		}
+// scopedPermissions 受限令牌作用域授予的权限
+var scopedPermissions = map[string][]string{
+	auth.ScopePasswordChange: {"user:password:update"},
+}
 
-		c.Next()
+// scopeAllowsRoute 检查令牌作用域是否允许访问指定路由，空作用域不受限
+func scopeAllowsRoute(scope, method, fullPath string) bool {
+	if scope == "" {
+		return true
 	}
+	return slices.Contains(scopedRoutes[scope], method+" "+fullPath)
 }
 
 // JWTAuth JWT 认证中间件 (向后兼容，已废弃)
@@ -111,63 +99,36 @@ func JWTAuth(jwtManager *auth.JWTManager) gin.HandlerFunc {
 	}
 }
 
-// authenticateWithJWT 使用 JWT 进行认证
-// 新架构：从 token 获取 user_id，权限信息从缓存实时查询
-func authenticateWithJWT(ctx context.Context, c *gin.Context, jwtManager *auth.JWTManager, permCacheService *auth.PermissionCacheService, tokenString string) error {
+// identityFromJWT 使用 JWT 进行认证
+// 新架构：从 token 获取 user_id，权限信息由认证链从缓存实时查询
+func identityFromJWT(jwtManager *auth.JWTManager, tokenString string) (*Identity, error) {
 	claims, err := jwtManager.ValidateToken(tokenString)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// 从缓存查询权限信息（向后兼容：优先使用 token 中的权限，如果为空则查询缓存）
-	var roles, permissions []string
-	if len(claims.Roles) > 0 || len(claims.Permissions) > 0 {
-		// 旧 token 包含权限信息，直接使用（向后兼容）
-		roles = claims.Roles
-		permissions = claims.Permissions
-	} else {
-		// 新 token 不包含权限信息，从缓存查询
-		roles, permissions, err = permCacheService.GetUserPermissions(ctx, claims.UserID)
-		if err != nil {
-			return fmt.Errorf("failed to get user permissions: %w", err)
-		}
+	identity := &Identity{
+		UserID:   claims.UserID,
+		Username: claims.Username,
+		Email:    claims.Email,
+		AuthType: AuthTypeJWT,
+		Scope:    claims.Scope,
 	}
-
-	// 将用户信息存入上下文
-	c.Set("user_id", claims.UserID)
-	c.Set("username", claims.Username)
-	c.Set("email", claims.Email)
-	c.Set("roles", roles)
-	c.Set("permissions", permissions)
-	c.Set("auth_type", "jwt")
-
-	return nil
-}
-
-// authenticateWithPAT 使用 Personal Access Token 进行认证
-// 新架构：PAT 自动继承用户全部权限，从缓存实时查询
-func authenticateWithPAT(ctx context.Context, c *gin.Context, patService *auth.PATService, permCacheService *auth.PermissionCacheService, tokenString string) error {
-	// 验证 PAT (包含 IP 白名单检查)
-	clientIP := c.ClientIP()
-	pat, err := patService.ValidateTokenWithIP(ctx, tokenString, clientIP)
-	if err != nil {
-		return err
+	if claims.IssuedAt != nil {
+		identity.IssuedAt = claims.IssuedAt.Time
 	}
 
-	// 从缓存查询用户权限（PAT 自动继承用户全部权限）
-	roles, permissions, err := permCacheService.GetUserPermissions(ctx, pat.UserID)
-	if err != nil {
-		return fmt.Errorf("failed to get user permissions: %w", err)
+	if claims.Scope != "" {
+		// 受限令牌只授予作用域声明的权限
+		identity.Permissions = append([]string{}, scopedPermissions[claims.Scope]...)
+	} else if len(claims.Roles) > 0 || len(claims.Permissions) > 0 {
+		// 旧 token 包含权限信息，直接使用（向后兼容）
+		identity.Roles = claims.Roles
+		identity.Permissions = claims.Permissions
+		if identity.Permissions == nil {
+			identity.Permissions = []string{}
+		}
 	}
 
-	// 将用户信息存入上下文
-	c.Set("user_id", pat.UserID)
-	c.Set("username", "") // PAT 不存储 username，可从用户表查询
-	c.Set("email", "")
-	c.Set("roles", roles)
-	c.Set("permissions", permissions) // 从缓存查询的完整权限
-	c.Set("auth_type", "pat")
-	c.Set("pat_id", pat.ID) // 额外存储 PAT ID，用于审计
-
-	return nil
+	return identity, nil
 }
@@ -3,10 +3,10 @@ package middleware
 import (
 	"slices"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
 )
 
 // RequireRole creates a middleware that checks if the user has a specific role
@@ -244,35 +244,10 @@ func RequireAdminOrOwnership(paramName ...string) gin.HandlerFunc {
 //   - matchPermission("*:users:create", "admin:users:create") -> true (domain wildcard)
 //   - matchPermission("admin:*:*", "admin:users:create") -> true (all admin permissions)
 //   - matchPermission("*:*:*", "admin:users:create") -> true (super admin)
+//
+// 与角色授予、授权解释使用同一匹配规则（role.MatchCode）。
 func matchPermission(userPerm, requiredPerm string) bool {
-	// Exact match
-	if userPerm == requiredPerm {
-		return true
-	}
-
-	// Split both permissions into parts
-	userParts := strings.Split(userPerm, ":")
-	requiredParts := strings.Split(requiredPerm, ":")
-
-	// Must be three-part format
-	if len(userParts) != 3 || len(requiredParts) != 3 {
-		return userPerm == requiredPerm // Fallback to exact match for non-standard format
-	}
-
-	// Check each part: domain, resource, action
-	for i := range 3 {
-		// Wildcard in user permission matches anything
-		if userParts[i] == "*" {
-			continue
-		}
-
-		// Parts must match exactly
-		if userParts[i] != requiredParts[i] {
-			return false
-		}
-	}
-
-	return true
+	return role.MatchPermission(userPerm, requiredPerm)
 }
 
 // isAdmin 检查当前用户是否具有 admin 角色
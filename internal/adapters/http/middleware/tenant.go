@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/response"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
+)
+
+// Tenant 多租户中间件，需放在认证中间件之后
+//
+// 租户标识优先从请求头读取，其次从子域名解析（baseDomain 为空时不解析子域名）。
+// 解析成功后校验当前用户是否为组织成员，并将权限替换为全局角色与组织内角色的并集；
+// 未携带租户标识的请求保持平台级上下文，直接放行。
+//
+// PAT 与会话一样继承用户当前的全部权限，组织内同样替换为并集；
+// 受限令牌（如待修改密码）只能访问作用域内的路由，组织内仅校验成员身份，不扩展权限。
+func Tenant(resolveHandler *organization.ResolveTenantHandler, permCacheService *auth.PermissionCacheService, header, baseDomain string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identifier := strings.TrimSpace(c.GetHeader(header))
+		if identifier == "" {
+			identifier = subdomainOf(c.Request.Host, baseDomain)
+		}
+		if identifier == "" {
+			c.Next()
+			return
+		}
+
+		tenant, err := resolveHandler.Handle(c.Request.Context(), organization.ResolveTenantQuery{Identifier: identifier})
+		if err != nil {
+			switch {
+			case errors.Is(err, organization.ErrOrganizationNotFound):
+				response.NotFound(c, "organization")
+			case errors.Is(err, organization.ErrOrganizationSuspended):
+				response.Forbidden(c, err.Error())
+			default:
+				response.InternalError(c, err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		roles, permissions, err := permCacheService.GetMemberPermissions(c.Request.Context(), tenant.OrganizationID, c.GetUint("user_id"))
+		if err != nil {
+			if errors.Is(err, organization.ErrNotMember) {
+				response.Forbidden(c, err.Error())
+			} else {
+				response.InternalError(c, err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set("organization_id", tenant.OrganizationID)
+		c.Set("organization_slug", tenant.Slug)
+		if c.GetString("token_scope") == "" {
+			c.Set("roles", roles)
+			c.Set("permissions", permissions)
+		}
+		c.Request = c.Request.WithContext(organization.WithTenant(c.Request.Context(), tenant.OrganizationID))
+
+		c.Next()
+	}
+}
+
+// RequireTenant 要求请求必须携带租户上下文，需放在 Tenant 中间件之后
+func RequireTenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetUint("organization_id") == 0 {
+			response.BadRequest(c, "organization is required")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// subdomainOf 从 Host 中解析 baseDomain 下的一级子域名，例如 acme.example.com -> acme
+func subdomainOf(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	suffix := "." + strings.ToLower(strings.TrimPrefix(baseDomain, "."))
+	label, ok := strings.CutSuffix(strings.ToLower(host), suffix)
+	if !ok || label == "" || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
@@ -0,0 +1,255 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appOrg "github.com/lwmacct/251117-go-ddd-template/internal/application/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
+)
+
+// stubOrgQueryRepo 按 ID / slug 返回预置组织及成员
+type stubOrgQueryRepo struct {
+	organization.QueryRepository
+
+	orgs    []*organization.Organization
+	members map[uint]map[uint]*organization.Member // 组织 ID -> 用户 ID -> 成员
+}
+
+func (r *stubOrgQueryRepo) GetByID(_ context.Context, id uint) (*organization.Organization, error) {
+	for _, o := range r.orgs {
+		if o.ID == id {
+			return o, nil
+		}
+	}
+	return nil, organization.ErrOrganizationNotFound
+}
+
+func (r *stubOrgQueryRepo) GetBySlug(_ context.Context, slug string) (*organization.Organization, error) {
+	for _, o := range r.orgs {
+		if o.Slug == slug {
+			return o, nil
+		}
+	}
+	return nil, organization.ErrOrganizationNotFound
+}
+
+func (r *stubOrgQueryRepo) GetMember(_ context.Context, organizationID, userID uint) (*organization.Member, error) {
+	if m, ok := r.members[organizationID][userID]; ok {
+		return m, nil
+	}
+	return nil, organization.ErrNotMember
+}
+
+type tenantResult struct {
+	OrganizationID uint     `json:"organization_id"`
+	Slug           string   `json:"organization_slug"`
+	ContextTenant  uint     `json:"context_tenant"`
+	Permissions    []string `json:"permissions"`
+}
+
+func newTenantTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	projectReader := role.Role{ID: 20, Name: "project-reader", Permissions: []role.Permission{{ID: 200, Code: "org:project:read"}}}
+	orgs := &stubOrgQueryRepo{
+		orgs: []*organization.Organization{
+			{ID: 1, Slug: "acme", Status: organization.StatusActive},
+			{ID: 2, Slug: "beta", Status: organization.StatusActive},
+			{ID: 3, Slug: "frozen", Status: organization.StatusSuspended},
+			{ID: 4, Slug: "gamma", Status: organization.StatusActive},
+		},
+		members: map[uint]map[uint]*organization.Member{
+			1: {aliceID: {OrganizationID: 1, UserID: aliceID, Roles: []role.Role{projectReader}}},
+			2: {aliceID: {OrganizationID: 2, UserID: aliceID}},
+		},
+	}
+	permCache := auth.NewPermissionCacheService(newUnavailableRedis(), newTestUsers(), nil, orgs, nil, "test:")
+
+	router := gin.New()
+	// 模拟认证中间件写入的身份
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", aliceID)
+		c.Set("permissions", []string{"user:profile:read"})
+		if c.GetHeader("X-Test-PAT") != "" {
+			c.Set("pat_id", uint(1))
+		}
+		if scope := c.GetHeader("X-Test-Scope"); scope != "" {
+			c.Set("token_scope", scope)
+			c.Set("permissions", scopedPermissions[scope])
+		}
+	})
+	router.Use(Tenant(appOrg.NewResolveTenantHandler(orgs), permCache, "X-Organization", "example.com"))
+	router.GET("/api/projects", func(c *gin.Context) {
+		tenantID, _ := organization.TenantFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, tenantResult{
+			OrganizationID: c.GetUint("organization_id"),
+			Slug:           c.GetString("organization_slug"),
+			ContextTenant:  tenantID,
+			Permissions:    c.GetStringSlice("permissions"),
+		})
+	})
+	return router
+}
+
+func TestTenant(t *testing.T) {
+	router := newTenantTestRouter()
+
+	tests := []struct {
+		name            string
+		host            string
+		header          string
+		pat             bool
+		scope           string
+		wantStatus      int
+		wantOrgID       uint
+		wantSlug        string
+		wantPermissions []string
+	}{
+		{
+			name:            "未携带租户标识时保持平台级上下文",
+			host:            "api.internal",
+			wantStatus:      http.StatusOK,
+			wantPermissions: []string{"user:profile:read"},
+		},
+		{
+			name:            "基础域名本身不解析为租户",
+			host:            "example.com",
+			wantStatus:      http.StatusOK,
+			wantPermissions: []string{"user:profile:read"},
+		},
+		{
+			name:            "请求头携带 slug",
+			header:          "acme",
+			wantStatus:      http.StatusOK,
+			wantOrgID:       1,
+			wantSlug:        "acme",
+			wantPermissions: []string{"user:profile:read", "org:project:read"},
+		},
+		{
+			name:            "请求头携带组织 ID",
+			header:          "1",
+			wantStatus:      http.StatusOK,
+			wantOrgID:       1,
+			wantSlug:        "acme",
+			wantPermissions: []string{"user:profile:read", "org:project:read"},
+		},
+		{
+			name:            "从子域名解析",
+			host:            "acme.example.com",
+			wantStatus:      http.StatusOK,
+			wantOrgID:       1,
+			wantSlug:        "acme",
+			wantPermissions: []string{"user:profile:read", "org:project:read"},
+		},
+		{
+			name:            "请求头优先于子域名",
+			host:            "acme.example.com",
+			header:          "beta",
+			wantStatus:      http.StatusOK,
+			wantOrgID:       2,
+			wantSlug:        "beta",
+			wantPermissions: []string{"user:profile:read"},
+		},
+		{
+			name:            "PAT 与会话一样获得组织内权限",
+			header:          "acme",
+			pat:             true,
+			wantStatus:      http.StatusOK,
+			wantOrgID:       1,
+			wantSlug:        "acme",
+			wantPermissions: []string{"user:profile:read", "org:project:read"},
+		},
+		{
+			name:            "受限令牌不扩展组织内权限",
+			header:          "acme",
+			pat:             true,
+			scope:           auth.ScopePasswordChange,
+			wantStatus:      http.StatusOK,
+			wantOrgID:       1,
+			wantSlug:        "acme",
+			wantPermissions: scopedPermissions[auth.ScopePasswordChange],
+		},
+		{
+			name:       "组织不存在",
+			header:     "missing",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "组织已停用",
+			header:     "frozen",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "非组织成员",
+			host:       "gamma.example.com",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			if tt.header != "" {
+				req.Header.Set("X-Organization", tt.header)
+			}
+			if tt.pat {
+				req.Header.Set("X-Test-PAT", "1")
+			}
+			if tt.scope != "" {
+				req.Header.Set("X-Test-Scope", tt.scope)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var result tenantResult
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+			assert.Equal(t, tt.wantOrgID, result.OrganizationID)
+			assert.Equal(t, tt.wantSlug, result.Slug)
+			assert.Equal(t, tt.wantOrgID, result.ContextTenant)
+			assert.ElementsMatch(t, tt.wantPermissions, result.Permissions)
+		})
+	}
+}
+
+func TestSubdomainOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		host       string
+		baseDomain string
+		want       string
+	}{
+		{"一级子域名", "acme.example.com", "example.com", "acme"},
+		{"带端口", "acme.example.com:8080", "example.com", "acme"},
+		{"大小写不敏感", "ACME.Example.com", "example.com", "acme"},
+		{"基础域名带前导点", "acme.example.com", ".example.com", "acme"},
+		{"多级子域名", "a.acme.example.com", "example.com", ""},
+		{"基础域名本身", "example.com", "example.com", ""},
+		{"其他域名", "acme.example.org", "example.com", ""},
+		{"后缀相同但非子域名", "acmeexample.com", "example.com", ""},
+		{"未配置基础域名", "acme.example.com", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, subdomainOf(tt.host, tt.baseDomain))
+		})
+	}
+}
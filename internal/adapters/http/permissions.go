@@ -0,0 +1,219 @@
+package http
+
+import (
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/middleware"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/config"
+)
+
+// 路由权限定义（三段式 domain:resource:action）。
+// 路由通过 requirePermission 引用这里的定义并登记到权限注册表，
+// 种子数据和 `permissions sync` 命令从注册表读取权限目录。
+var (
+	// Admin domain - User management
+	permAdminUsersCreate = role.PermissionDefinition{Code: "admin:users:create", Description: "Create users"}
+	permAdminUsersRead   = role.PermissionDefinition{Code: "admin:users:read", Description: "Read all users"}
+	permAdminUsersUpdate = role.PermissionDefinition{Code: user.PermissionUpdate, Description: "Update any user"}
+	permAdminUsersDelete = role.PermissionDefinition{Code: "admin:users:delete", Description: "Delete users"}
+	permAdminUsersExport = role.PermissionDefinition{Code: "admin:users:export", Description: "Export user lists"}
+
+	// Admin domain - Custom user attributes
+	permAdminUserAttributesCreate = role.PermissionDefinition{Code: "admin:user_attributes:create", Description: "Create custom user attributes"}
+	permAdminUserAttributesRead   = role.PermissionDefinition{Code: "admin:user_attributes:read", Description: "Read custom user attribute definitions"}
+	permAdminUserAttributesUpdate = role.PermissionDefinition{Code: "admin:user_attributes:update", Description: "Update custom user attributes"}
+	permAdminUserAttributesDelete = role.PermissionDefinition{Code: "admin:user_attributes:delete", Description: "Delete custom user attributes and their values"}
+
+	// Admin domain - Role management
+	permAdminRolesCreate = role.PermissionDefinition{Code: "admin:roles:create", Description: "Create roles"}
+	permAdminRolesRead   = role.PermissionDefinition{Code: "admin:roles:read", Description: "Read all roles"}
+	permAdminRolesUpdate = role.PermissionDefinition{Code: "admin:roles:update", Description: "Update roles"}
+	permAdminRolesDelete = role.PermissionDefinition{Code: "admin:roles:delete", Description: "Delete roles"}
+
+	// Admin domain - Permission management
+	permAdminPermissionsRead = role.PermissionDefinition{Code: "admin:permissions:read", Description: "Read all permissions"}
+
+	// Admin domain - Overview dashboard
+	permAdminOverviewRead = role.PermissionDefinition{Code: "admin:overview:read", Description: "View system overview stats"}
+
+	// Admin domain - Menu management
+	permAdminMenusCreate = role.PermissionDefinition{Code: "admin:menus:create", Description: "Create menus"}
+	permAdminMenusRead   = role.PermissionDefinition{Code: "admin:menus:read", Description: "Read menus"}
+	permAdminMenusUpdate = role.PermissionDefinition{Code: "admin:menus:update", Description: "Update menus"}
+	permAdminMenusDelete = role.PermissionDefinition{Code: "admin:menus:delete", Description: "Delete menus"}
+
+	// Admin domain - Settings management
+	permAdminSettingsCreate = role.PermissionDefinition{Code: "admin:settings:create", Description: "Create settings"}
+	permAdminSettingsRead   = role.PermissionDefinition{Code: "admin:settings:read", Description: "Read settings"}
+	permAdminSettingsUpdate = role.PermissionDefinition{Code: "admin:settings:update", Description: "Update settings"}
+	permAdminSettingsDelete = role.PermissionDefinition{Code: "admin:settings:delete", Description: "Delete settings"}
+
+	// Admin domain - Audit log management
+	permAdminAuditLogsRead = role.PermissionDefinition{Code: auditlog.PermissionRead, Description: "Read audit logs"}
+
+	// Admin domain - Organization management
+	permAdminOrganizationsCreate = role.PermissionDefinition{Code: "admin:organizations:create", Description: "Create organizations"}
+	permAdminOrganizationsRead   = role.PermissionDefinition{Code: "admin:organizations:read", Description: "Read all organizations and members"}
+	permAdminOrganizationsUpdate = role.PermissionDefinition{Code: "admin:organizations:update", Description: "Update organizations and manage members"}
+	permAdminOrganizationsDelete = role.PermissionDefinition{Code: "admin:organizations:delete", Description: "Delete organizations"}
+
+	// Admin domain - Group management
+	permAdminGroupsCreate = role.PermissionDefinition{Code: "admin:groups:create", Description: "Create user groups"}
+	permAdminGroupsRead   = role.PermissionDefinition{Code: "admin:groups:read", Description: "Read user groups and members"}
+	permAdminGroupsUpdate = role.PermissionDefinition{Code: "admin:groups:update", Description: "Update user groups, their roles and members"}
+	permAdminGroupsDelete = role.PermissionDefinition{Code: "admin:groups:delete", Description: "Delete user groups"}
+	permAdminGroupsImport = role.PermissionDefinition{Code: "admin:groups:import", Description: "Import user groups in bulk"}
+
+	// Admin domain - Change request approval
+	permAdminChangeRequestsRead   = role.PermissionDefinition{Code: "admin:change_requests:read", Description: "Read change requests awaiting approval"}
+	permAdminChangeRequestsReview = role.PermissionDefinition{Code: "admin:change_requests:review", Description: "Approve or reject change requests"}
+
+	// Admin domain - Trash (soft-deleted records)
+	permAdminTrashRead    = role.PermissionDefinition{Code: "admin:trash:read", Description: "List soft-deleted records"}
+	permAdminTrashRestore = role.PermissionDefinition{Code: "admin:trash:restore", Description: "Restore soft-deleted records"}
+	permAdminTrashPurge   = role.PermissionDefinition{Code: "admin:trash:purge", Description: "Permanently delete soft-deleted records"}
+
+	// Admin domain - Authorization explain
+	permAdminAuthzRead = role.PermissionDefinition{Code: "admin:authz:read", Description: "Explain authorization decisions for any user"}
+
+	// User domain - Profile management
+	permUserProfileRead   = role.PermissionDefinition{Code: "user:profile:read", Description: "Read own profile"}
+	permUserProfileUpdate = role.PermissionDefinition{Code: "user:profile:update", Description: "Update own profile"}
+	permUserProfileDelete = role.PermissionDefinition{Code: "user:profile:delete", Description: "Delete own account"}
+
+	// User domain - Personal data export
+	permUserAccountExport = role.PermissionDefinition{Code: "user:account:export", Description: "Export own personal data"}
+
+	// User domain - Preferences
+	permUserPreferencesRead   = role.PermissionDefinition{Code: "user:preferences:read", Description: "Read own preferences"}
+	permUserPreferencesUpdate = role.PermissionDefinition{Code: "user:preferences:update", Description: "Update own preferences"}
+
+	// User domain - Password management
+	permUserPasswordUpdate = role.PermissionDefinition{Code: "user:password:update", Description: "Change own password"}
+
+	// User domain - Token management
+	permUserTokensCreate  = role.PermissionDefinition{Code: "user:tokens:create", Description: "Create personal access tokens"}
+	permUserTokensRead    = role.PermissionDefinition{Code: "user:tokens:read", Description: "List own tokens"}
+	permUserTokensDisable = role.PermissionDefinition{Code: "user:tokens:disable", Description: "Disable own tokens"}
+	permUserTokensEnable  = role.PermissionDefinition{Code: "user:tokens:enable", Description: "Enable own tokens"}
+	permUserTokensDelete  = role.PermissionDefinition{Code: "user:tokens:delete", Description: "Delete own tokens"}
+
+	// User domain - Organization membership
+	permUserOrganizationsRead = role.PermissionDefinition{Code: "user:organizations:read", Description: "List own organizations"}
+
+	// User domain - Menus
+	permUserMenusRead = role.PermissionDefinition{Code: "user:menus:read", Description: "Read own navigation menus"}
+
+	// User domain - Authorization explain
+	permUserAuthzRead = role.PermissionDefinition{Code: "user:authz:read", Description: "Explain own authorization decisions"}
+
+	// Organization domain - 租户内管理（由组织内角色授予）
+	permOrgMembersRead   = role.PermissionDefinition{Code: "org:members:read", Description: "Read organization members"}
+	permOrgMembersUpdate = role.PermissionDefinition{Code: "org:members:update", Description: "Manage organization members"}
+	permOrgRolesCreate   = role.PermissionDefinition{Code: "org:roles:create", Description: "Create organization roles"}
+	permOrgRolesRead     = role.PermissionDefinition{Code: "org:roles:read", Description: "Read organization roles"}
+	permOrgRolesUpdate   = role.PermissionDefinition{Code: "org:roles:update", Description: "Update organization roles"}
+	permOrgRolesDelete   = role.PermissionDefinition{Code: "org:roles:delete", Description: "Delete organization roles"}
+)
+
+// permissionGuard 登记路由所需权限并生成权限检查中间件
+//
+// 经 group 包装的路由组在注册路由时把 require / requireRole 声明的要求
+// 绑定到具体路由，供授权解释按路由反查所需权限。
+type permissionGuard struct {
+	registry *role.PermissionRegistry
+	pending  *role.PermissionDefinition // require 已声明、尚未绑定到路由的权限
+	role     string                     // requireRole 已声明、尚未绑定到路由组的角色
+}
+
+// require 登记权限定义并返回 RequirePermission 中间件
+// 必须作为受保护路由组中路由的处理器参数使用，未被路由消费的声明属于编程错误
+func (g *permissionGuard) require(def role.PermissionDefinition) gin.HandlerFunc {
+	if g.pending != nil {
+		panic("permission " + g.pending.Code + " declared outside a guarded route group")
+	}
+	g.registry.Register(def)
+	g.pending = &def
+	return middleware.RequirePermission(def.Code)
+}
+
+// requireRole 声明路由组要求的角色并返回 RequireRole 中间件，须在受保护路由组的 Use 中使用
+func (g *permissionGuard) requireRole(name string) gin.HandlerFunc {
+	g.role = name
+	return middleware.RequireRole(name)
+}
+
+// group 包装路由组，组内路由注册时登记路由绑定
+func (g *permissionGuard) group(rg *gin.RouterGroup) *guardedGroup {
+	return &guardedGroup{RouterGroup: rg, guard: g}
+}
+
+// guardedGroup 登记路由绑定的路由组
+type guardedGroup struct {
+	*gin.RouterGroup
+	guard *permissionGuard
+	role  string
+}
+
+// Use 添加中间件，消费 requireRole 声明的角色
+func (g *guardedGroup) Use(handlers ...gin.HandlerFunc) gin.IRoutes {
+	if g.guard.role != "" {
+		g.role, g.guard.role = g.guard.role, ""
+	}
+	return g.RouterGroup.Use(handlers...)
+}
+
+// GET 注册 GET 路由
+func (g *guardedGroup) GET(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.handle(http.MethodGet, relativePath, handlers)
+}
+
+// POST 注册 POST 路由
+func (g *guardedGroup) POST(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.handle(http.MethodPost, relativePath, handlers)
+}
+
+// PUT 注册 PUT 路由
+func (g *guardedGroup) PUT(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.handle(http.MethodPut, relativePath, handlers)
+}
+
+// PATCH 注册 PATCH 路由
+func (g *guardedGroup) PATCH(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.handle(http.MethodPatch, relativePath, handlers)
+}
+
+// DELETE 注册 DELETE 路由
+func (g *guardedGroup) DELETE(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.handle(http.MethodDelete, relativePath, handlers)
+}
+
+// handle 登记路由绑定并注册路由，消费 require 声明的权限
+func (g *guardedGroup) handle(method, relativePath string, handlers []gin.HandlerFunc) gin.IRoutes {
+	binding := role.RouteBinding{
+		Method: method,
+		Path:   path.Join(g.BasePath(), relativePath),
+		Role:   g.role,
+	}
+	if g.guard.pending != nil {
+		binding.Permission = g.guard.pending.Code
+		g.guard.pending = nil
+	}
+	g.guard.registry.BindRoute(binding)
+
+	return g.Handle(method, relativePath, handlers...)
+}
+
+// CollectRoutePermissions 构建路由表并返回路由声明的权限注册表
+// 仅注册路由不处理请求，供 `permissions sync`、`seed` 等离线命令使用
+func CollectRoutePermissions(cfg *config.Config) *role.PermissionRegistry {
+	registry := role.NewPermissionRegistry()
+	SetupRouterWithDeps(&RouterDependencies{Config: cfg, PermissionRegistry: registry})
+	return registry
+}
@@ -118,6 +118,7 @@ type PaginationMeta struct {
 	TotalPages int    `json:"total_pages,omitempty"` // 总页数
 	HasMore    bool   `json:"has_more,omitempty"`    // 是否有下一页
 	Warning    string `json:"warning,omitempty"`     // 页码越界警告
+	NextCursor string `json:"next_cursor,omitempty"` // 下一页游标（支持游标分页的列表）
 }
 
 // ============================================================================
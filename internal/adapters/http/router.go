@@ -9,6 +9,7 @@
 //   - /api/auth/*: 认证相关（登录、注册、刷新令牌）
 //   - /api/admin/*: 管理后台（用户、角色、权限、菜单管理）
 //   - /api/user/*: 用户中心（个人资料、PAT 管理）
+//   - /api/org/*: 租户管理（组织成员、组织内角色），需携带组织标识
 //   - /swagger/*: API 文档
 //   - /docs/*: VitePress 文档
 //   - /health: 健康检查
@@ -44,6 +45,8 @@ import (
 
 	// 引入应用层包
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
 
 	// 引入基础设施包
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
@@ -57,13 +60,18 @@ type RouterDependencies struct {
 	RedisClient *redis.Client
 
 	// Application Handlers (for middleware)
-	CreateLogHandler *auditlog.CreateLogHandler
+	CreateLogHandler     *auditlog.CreateLogHandler
+	ResolveTenantHandler *organization.ResolveTenantHandler
 
 	// Infrastructure Services
 	JWTManager             *auth.JWTManager
 	PATService             *auth.PATService
+	ClientCertService      *auth.ClientCertService
 	PermissionCacheService *auth.PermissionCacheService
 
+	// PermissionRegistry 收集路由声明的权限，为空时使用临时注册表
+	PermissionRegistry *role.PermissionRegistry
+
 	// HTTP Handlers
 	HealthHandler      *handler.HealthHandler
 	AuthHandler        *handler.AuthHandler
@@ -78,6 +86,20 @@ type RouterDependencies struct {
 	OverviewHandler    *handler.OverviewHandler
 	TwoFAHandler       *handler.TwoFAHandler
 	CacheHandler       *handler.CacheHandler
+
+	OrganizationHandler   *handler.OrganizationHandler
+	GroupHandler          *handler.GroupHandler
+	ChangeRequestHandler  *handler.ChangeRequestHandler
+	RoleAssignmentHandler *handler.RoleAssignmentHandler
+	UserImportHandler     *handler.UserImportHandler
+	UserExportHandler     *handler.UserExportHandler
+	UserDataExportHandler *handler.UserDataExportHandler
+	UserAvatarHandler     *handler.UserAvatarHandler
+	UserStatusHandler     *handler.UserStatusHandler
+	UserAttributeHandler  *handler.UserAttributeHandler
+	UserPreferenceHandler *handler.UserPreferenceHandler
+	AuthzHandler          *handler.AuthzHandler
+	TrashHandler          *handler.TrashHandler
 }
 
 // SetupRouterWithDeps 使用依赖对象配置路由（推荐方式）
@@ -136,93 +158,226 @@ func SetupRouterWithDeps(deps *RouterDependencies) *gin.Engine {
 func setupAPIRoutes(r *gin.Engine, deps *RouterDependencies) {
 	api := r.Group("/api")
 
+	// 路由所需权限统一登记到权限注册表
+	if deps.PermissionRegistry == nil {
+		deps.PermissionRegistry = role.NewPermissionRegistry()
+	}
+	guard := &permissionGuard{registry: deps.PermissionRegistry}
+
+	// 认证链：按顺序尝试 Bearer（JWT/PAT）、HTTP Basic（用户名 + PAT）、X-API-Key、mTLS 客户端证书
+	authMiddleware := middleware.Authenticate(deps.PermissionCacheService,
+		middleware.NewBearerAuthenticator(deps.JWTManager, deps.PATService),
+		middleware.NewBasicAuthenticator(deps.PATService),
+		middleware.NewAPIKeyAuthenticator(deps.PATService),
+		middleware.NewClientCertAuthenticator(deps.ClientCertService),
+	)
+
+	// 租户解析：请求头或子域名携带组织标识时切换到组织内权限
+	tenantMiddleware := middleware.Tenant(deps.ResolveTenantHandler, deps.PermissionCacheService,
+		deps.Config.Tenant.Header, deps.Config.Tenant.BaseDomain)
+
 	// 认证路由 (公开)
 	auth := api.Group("/auth")
 	{
 		auth.POST("/register", deps.AuthHandler.Register)
 		auth.POST("/login", deps.AuthHandler.Login)
 		auth.POST("/login/2fa", deps.AuthHandler.Login2FA)
+		auth.POST("/login/2fa/send", deps.AuthHandler.Send2FACode)
 		auth.POST("/refresh", deps.AuthHandler.RefreshToken)
+		auth.POST("/invitations/accept", deps.AuthHandler.AcceptInvitation)
 		auth.GET("/captcha", deps.CaptchaHandler.GetCaptcha)
 	}
 
+	// 用户头像 (公开)
+	api.GET("/avatars/:id", deps.UserAvatarHandler.GetAvatar)
+
 	// 2FA 路由（需要认证）
-	twofa := api.Group("/auth/2fa")
-	twofa.Use(middleware.Auth(deps.JWTManager, deps.PATService, deps.PermissionCacheService))
+	twofa := guard.group(api.Group("/auth/2fa"))
+	twofa.Use(authMiddleware)
 	{
 		twofa.POST("/setup", deps.TwoFAHandler.Setup)            // 设置 2FA
 		twofa.POST("/verify", deps.TwoFAHandler.VerifyAndEnable) // 验证并启用 2FA
 		twofa.POST("/disable", deps.TwoFAHandler.Disable)        // 禁用 2FA
 		twofa.GET("/status", deps.TwoFAHandler.GetStatus)        // 获取 2FA 状态
+
+		// 邮件/短信验证通道
+		twofa.GET("/channels", deps.TwoFAHandler.ListChannels)            // 已启用的通道
+		twofa.POST("/channels/setup", deps.TwoFAHandler.SetupChannel)     // 绑定通道并发送确认码
+		twofa.POST("/channels/verify", deps.TwoFAHandler.VerifyChannel)   // 确认并启用通道
+		twofa.POST("/channels/disable", deps.TwoFAHandler.DisableChannel) // 解绑通道
 	}
 
 	// 管理员路由 (/api/admin/*) - 使用三段式权限控制
-	admin := api.Group("/admin")
-	admin.Use(middleware.Auth(deps.JWTManager, deps.PATService, deps.PermissionCacheService))
+	admin := guard.group(api.Group("/admin"))
+	admin.Use(authMiddleware)
 	admin.Use(middleware.AuditMiddleware(deps.CreateLogHandler))
-	admin.Use(middleware.RequireRole("admin"))
+	admin.Use(guard.requireRole("admin"))
 	{
 		// 用户管理
-		admin.POST("/users", middleware.RequirePermission("admin:users:create"), deps.AdminUserHandler.CreateUser)
-		admin.POST("/users/batch", middleware.RequirePermission("admin:users:create"), deps.AdminUserHandler.BatchCreateUsers)
-		admin.GET("/users", middleware.RequirePermission("admin:users:read"), deps.AdminUserHandler.ListUsers)
-		admin.GET("/users/:id", middleware.RequirePermission("admin:users:read"), deps.AdminUserHandler.GetUser)
-		admin.PUT("/users/:id", middleware.RequirePermission("admin:users:update"), deps.AdminUserHandler.UpdateUser)
-		admin.DELETE("/users/:id", middleware.RequirePermission("admin:users:delete"), deps.AdminUserHandler.DeleteUser)
-		admin.PUT("/users/:id/roles", middleware.RequirePermission("admin:users:update"), deps.AdminUserHandler.AssignRoles)
+		admin.POST("/users", guard.require(permAdminUsersCreate), deps.AdminUserHandler.CreateUser)
+		admin.POST("/users/batch", guard.require(permAdminUsersCreate), deps.AdminUserHandler.BatchCreateUsers)
+		admin.POST("/users/imports", guard.require(permAdminUsersCreate), deps.UserImportHandler.ImportUsers)
+		admin.GET("/users/imports/:id", guard.require(permAdminUsersCreate), deps.UserImportHandler.GetImportJob)
+		admin.GET("/users/imports/:id/report", guard.require(permAdminUsersCreate), deps.UserImportHandler.DownloadImportReport)
+		admin.POST("/users/invite", guard.require(permAdminUsersCreate), deps.AdminUserHandler.InviteUser)
+		admin.POST("/users/:id/invitation", guard.require(permAdminUsersCreate), deps.AdminUserHandler.ResendInvitation)
+		admin.POST("/users/:id/approve", guard.require(permAdminUsersUpdate), deps.AdminUserHandler.ApproveUser)
+		admin.GET("/users", guard.require(permAdminUsersRead), deps.AdminUserHandler.ListUsers)
+		admin.GET("/users/export", guard.require(permAdminUsersExport), deps.UserExportHandler.ExportUsers)
+		admin.GET("/users/:id", guard.require(permAdminUsersRead), deps.AdminUserHandler.GetUser)
+		admin.PUT("/users/:id", guard.require(permAdminUsersUpdate), deps.AdminUserHandler.UpdateUser)
+		admin.DELETE("/users/:id", guard.require(permAdminUsersDelete), deps.AdminUserHandler.DeleteUser)
+		admin.PUT("/users/:id/roles", guard.require(permAdminUsersUpdate), deps.AdminUserHandler.AssignRoles)
+		admin.PUT("/users/:id/password", guard.require(permAdminUsersUpdate), deps.AdminUserHandler.ResetPassword)
+		admin.POST("/users/:id/status", guard.require(permAdminUsersUpdate), deps.UserStatusHandler.ChangeStatus)
+		admin.GET("/users/:id/status-history", guard.require(permAdminUsersRead), deps.UserStatusHandler.ListStatusHistory)
+		admin.GET("/users/:id/role-assignments", guard.require(permAdminUsersRead), deps.RoleAssignmentHandler.ListAssignments)
+		admin.POST("/users/:id/role-assignments", guard.require(permAdminUsersUpdate), deps.RoleAssignmentHandler.GrantRole)
+		admin.DELETE("/users/:id/role-assignments/:role_id", guard.require(permAdminUsersUpdate), deps.RoleAssignmentHandler.RevokeRole)
+		admin.GET("/role-assignments/expiring", guard.require(permAdminUsersRead), deps.RoleAssignmentHandler.ListExpiring)
+
+		// 自定义用户属性
+		admin.GET("/user-attributes", guard.require(permAdminUserAttributesRead), deps.UserAttributeHandler.ListAttributes)
+		admin.POST("/user-attributes", guard.require(permAdminUserAttributesCreate), deps.UserAttributeHandler.CreateAttribute)
+		admin.PUT("/user-attributes/:id", guard.require(permAdminUserAttributesUpdate), deps.UserAttributeHandler.UpdateAttribute)
+		admin.DELETE("/user-attributes/:id", guard.require(permAdminUserAttributesDelete), deps.UserAttributeHandler.DeleteAttribute)
 
 		// 角色管理
-		admin.POST("/roles", middleware.RequirePermission("admin:roles:create"), deps.RoleHandler.CreateRole)
-		admin.GET("/roles", middleware.RequirePermission("admin:roles:read"), deps.RoleHandler.ListRoles)
-		admin.GET("/roles/:id", middleware.RequirePermission("admin:roles:read"), deps.RoleHandler.GetRole)
-		admin.PUT("/roles/:id", middleware.RequirePermission("admin:roles:update"), deps.RoleHandler.UpdateRole)
-		admin.DELETE("/roles/:id", middleware.RequirePermission("admin:roles:delete"), deps.RoleHandler.DeleteRole)
-		admin.PUT("/roles/:id/permissions", middleware.RequirePermission("admin:roles:update"), deps.RoleHandler.SetPermissions)
+		admin.POST("/roles", guard.require(permAdminRolesCreate), deps.RoleHandler.CreateRole)
+		admin.GET("/roles", guard.require(permAdminRolesRead), deps.RoleHandler.ListRoles)
+		admin.GET("/roles/:id", guard.require(permAdminRolesRead), deps.RoleHandler.GetRole)
+		admin.PUT("/roles/:id", guard.require(permAdminRolesUpdate), deps.RoleHandler.UpdateRole)
+		admin.DELETE("/roles/:id", guard.require(permAdminRolesDelete), deps.RoleHandler.DeleteRole)
+		admin.PUT("/roles/:id/permissions", guard.require(permAdminRolesUpdate), deps.RoleHandler.SetPermissions)
+		admin.GET("/roles/:id/effective-permissions", guard.require(permAdminRolesRead), deps.RoleHandler.GetEffectivePermissions)
+		admin.GET("/roles/:id/grants", guard.require(permAdminRolesRead), deps.RoleHandler.ListGrants)
+		admin.PUT("/roles/:id/permissions/:permission_id/condition", guard.require(permAdminRolesUpdate), deps.RoleHandler.SetPermissionCondition)
+
+		// 组织管理
+		admin.POST("/organizations", guard.require(permAdminOrganizationsCreate), deps.OrganizationHandler.CreateOrganization)
+		admin.GET("/organizations", guard.require(permAdminOrganizationsRead), deps.OrganizationHandler.ListOrganizations)
+		admin.GET("/organizations/:id", guard.require(permAdminOrganizationsRead), deps.OrganizationHandler.GetOrganization)
+		admin.PUT("/organizations/:id", guard.require(permAdminOrganizationsUpdate), deps.OrganizationHandler.UpdateOrganization)
+		admin.DELETE("/organizations/:id", guard.require(permAdminOrganizationsDelete), deps.OrganizationHandler.DeleteOrganization)
+		admin.GET("/organizations/:id/members", guard.require(permAdminOrganizationsRead), deps.OrganizationHandler.ListMembers)
+		admin.POST("/organizations/:id/members", guard.require(permAdminOrganizationsUpdate), deps.OrganizationHandler.AddMember)
+		admin.DELETE("/organizations/:id/members/:user_id", guard.require(permAdminOrganizationsUpdate), deps.OrganizationHandler.RemoveMember)
+		admin.PUT("/organizations/:id/members/:user_id/roles", guard.require(permAdminOrganizationsUpdate), deps.OrganizationHandler.SetMemberRoles)
+
+		// 用户组管理
+		admin.POST("/groups", guard.require(permAdminGroupsCreate), deps.GroupHandler.CreateGroup)
+		admin.GET("/groups", guard.require(permAdminGroupsRead), deps.GroupHandler.ListGroups)
+		admin.POST("/groups/import", guard.require(permAdminGroupsImport), deps.GroupHandler.ImportGroups)
+		admin.GET("/groups/:id", guard.require(permAdminGroupsRead), deps.GroupHandler.GetGroup)
+		admin.PUT("/groups/:id", guard.require(permAdminGroupsUpdate), deps.GroupHandler.UpdateGroup)
+		admin.DELETE("/groups/:id", guard.require(permAdminGroupsDelete), deps.GroupHandler.DeleteGroup)
+		admin.PUT("/groups/:id/roles", guard.require(permAdminGroupsUpdate), deps.GroupHandler.SetGroupRoles)
+		admin.GET("/groups/:id/members", guard.require(permAdminGroupsRead), deps.GroupHandler.ListGroupMembers)
+		admin.POST("/groups/:id/members", guard.require(permAdminGroupsUpdate), deps.GroupHandler.AddGroupMembers)
+		admin.DELETE("/groups/:id/members/:user_id", guard.require(permAdminGroupsUpdate), deps.GroupHandler.RemoveGroupMember)
+
+		// 变更审批（双人审批）
+		admin.GET("/change-requests", guard.require(permAdminChangeRequestsRead), deps.ChangeRequestHandler.ListChangeRequests)
+		admin.GET("/change-requests/:id", guard.require(permAdminChangeRequestsRead), deps.ChangeRequestHandler.GetChangeRequest)
+		admin.POST("/change-requests/:id/approve", guard.require(permAdminChangeRequestsReview), deps.ChangeRequestHandler.ApproveChangeRequest)
+		admin.POST("/change-requests/:id/reject", guard.require(permAdminChangeRequestsReview), deps.ChangeRequestHandler.RejectChangeRequest)
 
 		// 权限列表
-		admin.GET("/permissions", middleware.RequirePermission("admin:permissions:read"), deps.RoleHandler.ListPermissions)
+		admin.GET("/permissions", guard.require(permAdminPermissionsRead), deps.RoleHandler.ListPermissions)
+
+		// 授权解释
+		admin.GET("/authz/explain", guard.require(permAdminAuthzRead), deps.AuthzHandler.ExplainPermission)
+		admin.POST("/authz/explain", guard.require(permAdminAuthzRead), deps.AuthzHandler.ExplainRoute)
 
 		// 审计日志
-		admin.GET("/auditlogs", middleware.RequirePermission("admin:audit_logs:read"), deps.AuditLogHandler.ListLogs)
-		admin.GET("/auditlogs/:id", middleware.RequirePermission("admin:audit_logs:read"), deps.AuditLogHandler.GetLog)
+		admin.GET("/auditlogs", guard.require(permAdminAuditLogsRead), deps.AuditLogHandler.ListLogs)
+		admin.GET("/auditlogs/:id", guard.require(permAdminAuditLogsRead), deps.AuditLogHandler.GetLog)
+
+		// 回收站
+		admin.GET("/trash/:resource", guard.require(permAdminTrashRead), deps.TrashHandler.List)
+		admin.POST("/trash/:resource/:id/restore", guard.require(permAdminTrashRestore), deps.TrashHandler.Restore)
+		admin.DELETE("/trash/:resource/:id", guard.require(permAdminTrashPurge), deps.TrashHandler.Purge)
 
 		// 菜单管理
-		admin.POST("/menus", middleware.RequirePermission("admin:menus:create"), deps.MenuHandler.Create)
-		admin.GET("/menus", middleware.RequirePermission("admin:menus:read"), deps.MenuHandler.List)
-		admin.GET("/menus/:id", middleware.RequirePermission("admin:menus:read"), deps.MenuHandler.Get)
-		admin.PUT("/menus/:id", middleware.RequirePermission("admin:menus:update"), deps.MenuHandler.Update)
-		admin.DELETE("/menus/:id", middleware.RequirePermission("admin:menus:delete"), deps.MenuHandler.Delete)
-		admin.POST("/menus/reorder", middleware.RequirePermission("admin:menus:update"), deps.MenuHandler.Reorder)
+		admin.POST("/menus", guard.require(permAdminMenusCreate), deps.MenuHandler.Create)
+		admin.GET("/menus", guard.require(permAdminMenusRead), deps.MenuHandler.List)
+		admin.GET("/menus/:id", guard.require(permAdminMenusRead), deps.MenuHandler.Get)
+		admin.PUT("/menus/:id", guard.require(permAdminMenusUpdate), deps.MenuHandler.Update)
+		admin.DELETE("/menus/:id", guard.require(permAdminMenusDelete), deps.MenuHandler.Delete)
+		admin.POST("/menus/reorder", guard.require(permAdminMenusUpdate), deps.MenuHandler.Reorder)
 
 		// 系统概览
-		admin.GET("/overview/stats", middleware.RequirePermission("admin:overview:read"), deps.OverviewHandler.GetStats)
+		admin.GET("/overview/stats", guard.require(permAdminOverviewRead), deps.OverviewHandler.GetStats)
 
 		// 系统配置
-		admin.GET("/settings", middleware.RequirePermission("admin:settings:read"), deps.SettingHandler.GetSettings)
-		admin.GET("/settings/:key", middleware.RequirePermission("admin:settings:read"), deps.SettingHandler.GetSetting)
-		admin.POST("/settings", middleware.RequirePermission("admin:settings:create"), deps.SettingHandler.CreateSetting)
-		admin.PUT("/settings/:key", middleware.RequirePermission("admin:settings:update"), deps.SettingHandler.UpdateSetting)
-		admin.DELETE("/settings/:key", middleware.RequirePermission("admin:settings:delete"), deps.SettingHandler.DeleteSetting)
-		admin.POST("/settings/batch", middleware.RequirePermission("admin:settings:update"), deps.SettingHandler.BatchUpdateSettings)
+		admin.GET("/settings", guard.require(permAdminSettingsRead), deps.SettingHandler.GetSettings)
+		admin.GET("/settings/:key", guard.require(permAdminSettingsRead), deps.SettingHandler.GetSetting)
+		admin.POST("/settings", guard.require(permAdminSettingsCreate), deps.SettingHandler.CreateSetting)
+		admin.PUT("/settings/:key", guard.require(permAdminSettingsUpdate), deps.SettingHandler.UpdateSetting)
+		admin.DELETE("/settings/:key", guard.require(permAdminSettingsDelete), deps.SettingHandler.DeleteSetting)
+		admin.POST("/settings/batch", guard.require(permAdminSettingsUpdate), deps.SettingHandler.BatchUpdateSettings)
 	}
 
 	// 用户路由 (/api/user/*) - 使用三段式权限控制
-	userGroup := api.Group("/user")
-	userGroup.Use(middleware.Auth(deps.JWTManager, deps.PATService, deps.PermissionCacheService))
+	userGroup := guard.group(api.Group("/user"))
+	userGroup.Use(authMiddleware)
+	userGroup.Use(tenantMiddleware)
 	{
 		// 个人资料管理
-		userGroup.GET("/profile", middleware.RequirePermission("user:profile:read"), deps.UserProfileHandler.GetProfile)
-		userGroup.PUT("/profile", middleware.RequirePermission("user:profile:update"), deps.UserProfileHandler.UpdateProfile)
-		userGroup.PUT("/password", middleware.RequirePermission("user:password:update"), deps.UserProfileHandler.ChangePassword)
-		userGroup.DELETE("/account", middleware.RequirePermission("user:profile:delete"), deps.UserProfileHandler.DeleteAccount)
+		userGroup.GET("/profile", guard.require(permUserProfileRead), deps.UserProfileHandler.GetProfile)
+		userGroup.PUT("/profile", guard.require(permUserProfileUpdate), deps.UserProfileHandler.UpdateProfile)
+		userGroup.GET("/profile/attributes", guard.require(permUserProfileRead), deps.UserAttributeHandler.ListProfileAttributes)
+		userGroup.GET("/preferences", guard.require(permUserPreferencesRead), deps.UserPreferenceHandler.GetPreferences)
+		userGroup.PUT("/preferences", guard.require(permUserPreferencesUpdate), deps.UserPreferenceHandler.UpdatePreferences)
+		userGroup.PUT("/password", guard.require(permUserPasswordUpdate), deps.UserProfileHandler.ChangePassword)
+		userGroup.POST("/avatar", guard.require(permUserProfileUpdate), deps.UserAvatarHandler.UploadAvatar)
+		userGroup.DELETE("/avatar", guard.require(permUserProfileUpdate), deps.UserAvatarHandler.DeleteAvatar)
+		userGroup.DELETE("/account", guard.require(permUserProfileDelete), deps.UserProfileHandler.DeleteAccount)
+
+		// 个人数据导出
+		userGroup.POST("/account/export", guard.require(permUserAccountExport), deps.UserDataExportHandler.RequestExport)
+		userGroup.GET("/account/export/:id", guard.require(permUserAccountExport), deps.UserDataExportHandler.GetExport)
+		userGroup.GET("/account/export/:id/download", guard.require(permUserAccountExport), deps.UserDataExportHandler.DownloadExport)
 
 		// Personal Access Token 管理
-		userGroup.POST("/tokens", middleware.RequirePermission("user:tokens:create"), deps.PATHandler.CreateToken)
-		userGroup.GET("/tokens", middleware.RequirePermission("user:tokens:read"), deps.PATHandler.ListTokens)
-		userGroup.GET("/tokens/:id", middleware.RequirePermission("user:tokens:read"), deps.PATHandler.GetToken)
-		userGroup.DELETE("/tokens/:id", middleware.RequirePermission("user:tokens:delete"), deps.PATHandler.DeleteToken)
-		userGroup.PATCH("/tokens/:id/disable", middleware.RequirePermission("user:tokens:disable"), deps.PATHandler.DisableToken)
-		userGroup.PATCH("/tokens/:id/enable", middleware.RequirePermission("user:tokens:enable"), deps.PATHandler.EnableToken)
+		userGroup.POST("/tokens", guard.require(permUserTokensCreate), deps.PATHandler.CreateToken)
+		userGroup.GET("/tokens", guard.require(permUserTokensRead), deps.PATHandler.ListTokens)
+		userGroup.GET("/tokens/:id", guard.require(permUserTokensRead), deps.PATHandler.GetToken)
+		userGroup.DELETE("/tokens/:id", guard.require(permUserTokensDelete), deps.PATHandler.DeleteToken)
+		userGroup.PATCH("/tokens/:id/disable", guard.require(permUserTokensDisable), deps.PATHandler.DisableToken)
+		userGroup.PATCH("/tokens/:id/enable", guard.require(permUserTokensEnable), deps.PATHandler.EnableToken)
+
+		// 所属组织
+		userGroup.GET("/organizations", guard.require(permUserOrganizationsRead), deps.OrganizationHandler.ListMyOrganizations)
+
+		// 导航菜单（按权限裁剪）
+		userGroup.GET("/menus", guard.require(permUserMenusRead), deps.MenuHandler.ListMine)
+
+		// 授权解释（自助）
+		userGroup.GET("/authz/explain", guard.require(permUserAuthzRead), deps.AuthzHandler.ExplainOwnPermission)
+		userGroup.POST("/authz/explain", guard.require(permUserAuthzRead), deps.AuthzHandler.ExplainOwnRoute)
+	}
+
+	// 租户路由 (/api/org/*) - 作用于当前组织，权限来自全局角色与组织内角色的并集
+	org := guard.group(api.Group("/org"))
+	org.Use(authMiddleware)
+	org.Use(tenantMiddleware)
+	org.Use(middleware.RequireTenant())
+	org.Use(middleware.AuditMiddleware(deps.CreateLogHandler))
+	{
+		// 成员管理
+		org.GET("/members", guard.require(permOrgMembersRead), deps.OrganizationHandler.ListMembers)
+		org.POST("/members", guard.require(permOrgMembersUpdate), deps.OrganizationHandler.AddMember)
+		org.DELETE("/members/:user_id", guard.require(permOrgMembersUpdate), deps.OrganizationHandler.RemoveMember)
+		org.PUT("/members/:user_id/roles", guard.require(permOrgMembersUpdate), deps.OrganizationHandler.SetMemberRoles)
+
+		// 组织内角色（仓储按租户自动隔离，全局角色只读可见）
+		org.POST("/roles", guard.require(permOrgRolesCreate), deps.RoleHandler.CreateRole)
+		org.GET("/roles", guard.require(permOrgRolesRead), deps.RoleHandler.ListRoles)
+		org.GET("/roles/:id", guard.require(permOrgRolesRead), deps.RoleHandler.GetRole)
+		org.PUT("/roles/:id", guard.require(permOrgRolesUpdate), deps.RoleHandler.UpdateRole)
+		org.DELETE("/roles/:id", guard.require(permOrgRolesDelete), deps.RoleHandler.DeleteRole)
+		org.PUT("/roles/:id/permissions", guard.require(permOrgRolesUpdate), deps.RoleHandler.SetPermissions)
 	}
 
 	// 缓存操作示例 (公开，仅用于演示)
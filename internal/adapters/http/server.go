@@ -2,7 +2,12 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,8 +15,10 @@ import (
 
 // Server HTTP服务器
 type Server struct {
-	router *gin.Engine
-	server *http.Server
+	router   *gin.Engine
+	server   *http.Server
+	certFile string
+	keyFile  string
 }
 
 // NewServer 创建HTTP服务器
@@ -30,8 +37,41 @@ func NewServer(router *gin.Engine, addr string) *Server {
 	}
 }
 
+// ConfigureTLS 启用 HTTPS
+// clientCAFile 不为空时校验客户端证书（证书可选，未携带证书的请求仍走其他认证方式）
+func (s *Server) ConfigureTLS(certFile, keyFile, clientCAFile string) error {
+	if certFile == "" || keyFile == "" {
+		if clientCAFile != "" {
+			return errors.New("client CA requires tls-cert-file and tls-key-file")
+		}
+		return nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if clientCAFile != "" {
+		caPEM, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return errors.New("no valid certificates found in client CA file")
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	s.server.TLSConfig = tlsConfig
+	s.certFile = certFile
+	s.keyFile = keyFile
+	return nil
+}
+
 // Start 启动服务器
 func (s *Server) Start() error {
+	if s.certFile != "" {
+		return s.server.ListenAndServeTLS(s.certFile, s.keyFile)
+	}
 	return s.server.ListenAndServe()
 }
 
@@ -0,0 +1,61 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+)
+
+// 审计日志中的变更请求资源类型与操作
+const (
+	auditResource = "change_requests"
+
+	AuditActionSubmit  = "change_request.submit"
+	AuditActionApprove = "change_request.approve"
+	AuditActionReject  = "change_request.reject"
+	AuditActionExpire  = "change_request.expire"
+	AuditActionExecute = "change_request.execute"
+)
+
+// 审计日志状态
+const (
+	auditSuccess = domainAuditLog.StatusSuccess
+	auditFailed  = domainAuditLog.StatusFailed
+)
+
+// systemActor 后台任务写入审计日志时使用的操作者名称
+const systemActor = "system"
+
+// auditRecorder 将变更请求的每一步写入审计日志，写入失败不阻塞审批流程
+type auditRecorder struct {
+	handler *auditlog.CreateLogHandler
+}
+
+// record 写入一条审计日志，actorID 为 0 表示系统操作
+func (a auditRecorder) record(ctx context.Context, r *approval.ChangeRequest, action string, actorID uint, actorName, status string) {
+	if a.handler == nil {
+		return
+	}
+
+	details, _ := json.Marshal(map[string]any{
+		"operation":   r.Operation,
+		"resource_id": r.ResourceID,
+		"state":       r.Status,
+		"comment":     r.ReviewComment,
+		"error":       r.Error,
+	})
+
+	_ = a.handler.Handle(context.WithoutCancel(ctx), auditlog.CreateLogCommand{
+		UserID:     actorID,
+		Username:   actorName,
+		Action:     action,
+		Resource:   auditResource,
+		ResourceID: strconv.FormatUint(uint64(r.ID), 10),
+		Details:    string(details),
+		Status:     status,
+	})
+}
@@ -0,0 +1,9 @@
+package approval
+
+// ApproveChangeRequestCommand 批准变更请求命令
+type ApproveChangeRequestCommand struct {
+	RequestID    uint
+	ReviewerID   uint
+	ReviewerName string
+	Comment      string
+}
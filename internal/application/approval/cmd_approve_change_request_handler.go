@@ -0,0 +1,77 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ApproveChangeRequestHandler 批准变更请求命令处理器
+type ApproveChangeRequestHandler struct {
+	changeRequestCommandRepo approval.CommandRepository
+	changeRequestQueryRepo   approval.QueryRepository
+	userQueryRepo            user.QueryRepository
+	executors                Executors
+	audit                    auditRecorder
+}
+
+// NewApproveChangeRequestHandler 创建批准变更请求命令处理器
+func NewApproveChangeRequestHandler(
+	changeRequestCommandRepo approval.CommandRepository,
+	changeRequestQueryRepo approval.QueryRepository,
+	userQueryRepo user.QueryRepository,
+	executors Executors,
+	auditLogHandler *auditlog.CreateLogHandler,
+) *ApproveChangeRequestHandler {
+	return &ApproveChangeRequestHandler{
+		changeRequestCommandRepo: changeRequestCommandRepo,
+		changeRequestQueryRepo:   changeRequestQueryRepo,
+		userQueryRepo:            userQueryRepo,
+		executors:                executors,
+		audit:                    auditRecorder{handler: auditLogHandler},
+	}
+}
+
+// Handle 处理批准变更请求命令
+// 批准后立即执行原始命令；执行失败时请求状态为 failed 并记录失败原因，不返回错误
+func (h *ApproveChangeRequestHandler) Handle(ctx context.Context, cmd ApproveChangeRequestCommand) (*ChangeRequestDTO, error) {
+	request, err := h.changeRequestQueryRepo.GetByID(ctx, cmd.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	execute, ok := h.executors[request.Operation]
+	if !ok {
+		return nil, approval.ErrUnknownOperation
+	}
+
+	if err = authorizeReviewer(ctx, h.userQueryRepo, cmd.ReviewerID, request.Operation); err != nil {
+		return nil, err
+	}
+
+	// 1. 批准：条件更新确保同一请求只被一名审批人认领执行
+	if err = request.Approve(cmd.ReviewerID, cmd.ReviewerName, cmd.Comment, time.Now()); err != nil {
+		return nil, err
+	}
+	if err = h.changeRequestCommandRepo.UpdateStatus(ctx, request, approval.StatusPending); err != nil {
+		return nil, err
+	}
+	h.audit.record(ctx, request, AuditActionApprove, cmd.ReviewerID, cmd.ReviewerName, auditSuccess)
+
+	// 2. 执行原始命令
+	if execErr := execute(ctx, []byte(request.Payload)); execErr != nil {
+		request.MarkFailed(execErr.Error())
+		if err = h.changeRequestCommandRepo.UpdateStatus(ctx, request, approval.StatusApproved); err != nil {
+			return nil, fmt.Errorf("failed to record change request failure: %w", err)
+		}
+		h.audit.record(ctx, request, AuditActionExecute, cmd.ReviewerID, cmd.ReviewerName, auditFailed)
+		return ToChangeRequestDTO(request), nil
+	}
+
+	h.audit.record(ctx, request, AuditActionExecute, cmd.ReviewerID, cmd.ReviewerName, auditSuccess)
+	return ToChangeRequestDTO(request), nil
+}
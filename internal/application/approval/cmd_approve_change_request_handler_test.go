@@ -0,0 +1,194 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainApproval "github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+)
+
+type approveMocks struct {
+	cmdRepo   *MockChangeRequestCommandRepository
+	qryRepo   *MockChangeRequestQueryRepository
+	userQry   *MockUserQueryRepository
+	auditRepo *MockAuditLogCommandRepository
+}
+
+func newApproveMocks() *approveMocks {
+	return &approveMocks{
+		cmdRepo:   new(MockChangeRequestCommandRepository),
+		qryRepo:   new(MockChangeRequestQueryRepository),
+		userQry:   new(MockUserQueryRepository),
+		auditRepo: new(MockAuditLogCommandRepository),
+	}
+}
+
+func (m *approveMocks) handler(executors Executors) *ApproveChangeRequestHandler {
+	return NewApproveChangeRequestHandler(m.cmdRepo, m.qryRepo, m.userQry, executors, auditlog.NewCreateLogHandler(m.auditRepo))
+}
+
+// expectAudit 期望写入指定操作与状态的审计日志
+func (m *approveMocks) expectAudit(action, status string) {
+	m.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(log *domainAuditLog.AuditLog) bool {
+		return log.Action == action && log.Status == status && log.Resource == auditResource
+	})).Return(nil).Once()
+}
+
+func newPendingDeleteRequest() *domainApproval.ChangeRequest {
+	return &domainApproval.ChangeRequest{
+		ID:          1,
+		Operation:   OperationDeleteUser,
+		ResourceID:  "42",
+		Payload:     `{"UserID":42}`,
+		Status:      domainApproval.StatusPending,
+		RequesterID: 10,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+}
+
+func newReviewer(id uint, permissionCode string) *domainUser.User {
+	return &domainUser.User{
+		ID: id,
+		Roles: []role.Role{{
+			ID:          1,
+			Name:        "admin",
+			Permissions: []role.Permission{{Code: permissionCode}},
+		}},
+	}
+}
+
+type deleteUserPayload struct {
+	UserID uint
+}
+
+func TestApproveChangeRequestHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("批准后执行原始命令", func(t *testing.T) {
+		m := newApproveMocks()
+		m.qryRepo.On("GetByID", ctx, uint(1)).Return(newPendingDeleteRequest(), nil)
+		m.userQry.On("GetByIDWithRoles", ctx, uint(20)).Return(newReviewer(20, "admin:users:*"), nil)
+		m.cmdRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(r *domainApproval.ChangeRequest) bool {
+			return r.Status == domainApproval.StatusApproved
+		}), domainApproval.StatusPending).Return(nil)
+		m.expectAudit(AuditActionApprove, auditSuccess)
+		m.expectAudit(AuditActionExecute, auditSuccess)
+
+		var executed deleteUserPayload
+		executors := Executors{OperationDeleteUser: Bind(func(_ context.Context, cmd deleteUserPayload) error {
+			executed = cmd
+			return nil
+		})}
+
+		result, err := m.handler(executors).Handle(ctx, ApproveChangeRequestCommand{RequestID: 1, ReviewerID: 20, ReviewerName: "reviewer"})
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusApproved, result.Status)
+		assert.Equal(t, uint(42), executed.UserID)
+		m.cmdRepo.AssertExpectations(t)
+		m.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("执行失败时记录失败原因", func(t *testing.T) {
+		m := newApproveMocks()
+		m.qryRepo.On("GetByID", ctx, uint(1)).Return(newPendingDeleteRequest(), nil)
+		m.userQry.On("GetByIDWithRoles", ctx, uint(20)).Return(newReviewer(20, "admin:users:delete"), nil)
+		m.cmdRepo.On("UpdateStatus", ctx, mock.Anything, domainApproval.StatusPending).Return(nil)
+		m.cmdRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(r *domainApproval.ChangeRequest) bool {
+			return r.Status == domainApproval.StatusFailed && r.Error == "user not found"
+		}), domainApproval.StatusApproved).Return(nil)
+		m.expectAudit(AuditActionApprove, auditSuccess)
+		m.expectAudit(AuditActionExecute, auditFailed)
+
+		executors := Executors{OperationDeleteUser: func(context.Context, []byte) error {
+			return errors.New("user not found")
+		}}
+
+		result, err := m.handler(executors).Handle(ctx, ApproveChangeRequestCommand{RequestID: 1, ReviewerID: 20})
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusFailed, result.Status)
+		assert.Equal(t, "user not found", result.Error)
+		m.cmdRepo.AssertExpectations(t)
+		m.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("申请人不能批准自己的请求", func(t *testing.T) {
+		m := newApproveMocks()
+		m.qryRepo.On("GetByID", ctx, uint(1)).Return(newPendingDeleteRequest(), nil)
+		m.userQry.On("GetByIDWithRoles", ctx, uint(10)).Return(newReviewer(10, "admin:users:delete"), nil)
+
+		executors := Executors{OperationDeleteUser: func(context.Context, []byte) error {
+			t.Fatal("不应执行")
+			return nil
+		}}
+
+		_, err := m.handler(executors).Handle(ctx, ApproveChangeRequestCommand{RequestID: 1, ReviewerID: 10})
+
+		require.ErrorIs(t, err, ErrSelfApproval)
+		m.cmdRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("审批人缺少操作权限", func(t *testing.T) {
+		m := newApproveMocks()
+		m.qryRepo.On("GetByID", ctx, uint(1)).Return(newPendingDeleteRequest(), nil)
+		m.userQry.On("GetByIDWithRoles", ctx, uint(20)).Return(newReviewer(20, "admin:users:read"), nil)
+
+		_, err := m.handler(Executors{OperationDeleteUser: func(context.Context, []byte) error { return nil }}).
+			Handle(ctx, ApproveChangeRequestCommand{RequestID: 1, ReviewerID: 20})
+
+		require.ErrorIs(t, err, ErrReviewerNotAuthorized)
+	})
+
+	t.Run("请求已被其他审批人处理", func(t *testing.T) {
+		m := newApproveMocks()
+		m.qryRepo.On("GetByID", ctx, uint(1)).Return(newPendingDeleteRequest(), nil)
+		m.userQry.On("GetByIDWithRoles", ctx, uint(20)).Return(newReviewer(20, "admin:users:delete"), nil)
+		m.cmdRepo.On("UpdateStatus", ctx, mock.Anything, domainApproval.StatusPending).Return(domainApproval.ErrChangeRequestNotPending)
+
+		executors := Executors{OperationDeleteUser: func(context.Context, []byte) error {
+			t.Fatal("不应执行")
+			return nil
+		}}
+
+		_, err := m.handler(executors).Handle(ctx, ApproveChangeRequestCommand{RequestID: 1, ReviewerID: 20})
+
+		require.ErrorIs(t, err, ErrChangeRequestNotPending)
+	})
+
+	t.Run("未注册执行器的操作", func(t *testing.T) {
+		m := newApproveMocks()
+		m.qryRepo.On("GetByID", ctx, uint(1)).Return(newPendingDeleteRequest(), nil)
+
+		_, err := m.handler(Executors{}).Handle(ctx, ApproveChangeRequestCommand{RequestID: 1, ReviewerID: 20})
+
+		require.ErrorIs(t, err, ErrUnknownOperation)
+	})
+}
+
+func TestBindResult(t *testing.T) {
+	var got deleteUserPayload
+	executor := BindResult(func(_ context.Context, cmd deleteUserPayload) (*deleteUserPayload, error) {
+		got = cmd
+		return &cmd, nil
+	})
+
+	require.NoError(t, executor(context.Background(), []byte(`{"UserID":42}`)))
+	assert.Equal(t, uint(42), got.UserID)
+
+	failing := BindResult(func(context.Context, deleteUserPayload) (*deleteUserPayload, error) {
+		return nil, errors.New("boom")
+	})
+	require.EqualError(t, failing(context.Background(), []byte(`{}`)), "boom")
+}
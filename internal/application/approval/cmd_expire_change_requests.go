@@ -0,0 +1,8 @@
+package approval
+
+import "time"
+
+// ExpireChangeRequestsCommand 过期处理命令
+type ExpireChangeRequestsCommand struct {
+	Now time.Time
+}
@@ -0,0 +1,60 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+)
+
+// expireBatchSize 单次处理的过期请求上限，剩余请求留待下一轮
+const expireBatchSize = 100
+
+// ExpireChangeRequestsHandler 过期处理命令处理器
+type ExpireChangeRequestsHandler struct {
+	changeRequestCommandRepo approval.CommandRepository
+	changeRequestQueryRepo   approval.QueryRepository
+	audit                    auditRecorder
+}
+
+// NewExpireChangeRequestsHandler 创建过期处理命令处理器
+func NewExpireChangeRequestsHandler(
+	changeRequestCommandRepo approval.CommandRepository,
+	changeRequestQueryRepo approval.QueryRepository,
+	auditLogHandler *auditlog.CreateLogHandler,
+) *ExpireChangeRequestsHandler {
+	return &ExpireChangeRequestsHandler{
+		changeRequestCommandRepo: changeRequestCommandRepo,
+		changeRequestQueryRepo:   changeRequestQueryRepo,
+		audit:                    auditRecorder{handler: auditLogHandler},
+	}
+}
+
+// Handle 将超过审批期限的待审批请求标记为过期
+func (h *ExpireChangeRequestsHandler) Handle(ctx context.Context, cmd ExpireChangeRequestsCommand) (*ExpireChangeRequestsResultDTO, error) {
+	requests, err := h.changeRequestQueryRepo.ListExpired(ctx, cmd.Now, expireBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired change requests: %w", err)
+	}
+
+	result := &ExpireChangeRequestsResultDTO{}
+	for _, request := range requests {
+		if err = request.Expire(cmd.Now); err != nil {
+			continue
+		}
+		if err = h.changeRequestCommandRepo.UpdateStatus(ctx, request, approval.StatusPending); err != nil {
+			// 已被审批人处理，跳过
+			if errors.Is(err, approval.ErrChangeRequestNotPending) {
+				continue
+			}
+			return result, fmt.Errorf("failed to expire change request %d: %w", request.ID, err)
+		}
+
+		h.audit.record(ctx, request, AuditActionExpire, 0, systemActor, auditSuccess)
+		result.Expired++
+	}
+
+	return result, nil
+}
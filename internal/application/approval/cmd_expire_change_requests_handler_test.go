@@ -0,0 +1,49 @@
+package approval
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainApproval "github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+)
+
+func TestExpireChangeRequestsHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	expired := func(id uint) *domainApproval.ChangeRequest {
+		return &domainApproval.ChangeRequest{ID: id, Status: domainApproval.StatusPending, ExpiresAt: now.Add(-time.Minute)}
+	}
+
+	cmdRepo := new(MockChangeRequestCommandRepository)
+	qryRepo := new(MockChangeRequestQueryRepository)
+	auditRepo := new(MockAuditLogCommandRepository)
+
+	qryRepo.On("ListExpired", ctx, now, expireBatchSize).Return([]*domainApproval.ChangeRequest{expired(1), expired(2)}, nil)
+	cmdRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(r *domainApproval.ChangeRequest) bool {
+		return r.ID == 1 && r.Status == domainApproval.StatusExpired
+	}), domainApproval.StatusPending).Return(nil)
+	// 2 号请求在过期前已被审批人处理
+	cmdRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(r *domainApproval.ChangeRequest) bool {
+		return r.ID == 2
+	}), domainApproval.StatusPending).Return(domainApproval.ErrChangeRequestNotPending)
+	auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(log *domainAuditLog.AuditLog) bool {
+		return log.Action == AuditActionExpire && log.ResourceID == "1" && log.IsSystemAction()
+	})).Return(nil).Once()
+
+	h := NewExpireChangeRequestsHandler(cmdRepo, qryRepo, auditlog.NewCreateLogHandler(auditRepo))
+	result, err := h.Handle(ctx, ExpireChangeRequestsCommand{Now: now})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Expired)
+	cmdRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
@@ -0,0 +1,9 @@
+package approval
+
+// RejectChangeRequestCommand 拒绝变更请求命令
+type RejectChangeRequestCommand struct {
+	RequestID    uint
+	ReviewerID   uint
+	ReviewerName string
+	Comment      string
+}
@@ -0,0 +1,59 @@
+package approval
+
+import (
+	"context"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// RejectChangeRequestHandler 拒绝变更请求命令处理器
+type RejectChangeRequestHandler struct {
+	changeRequestCommandRepo approval.CommandRepository
+	changeRequestQueryRepo   approval.QueryRepository
+	userQueryRepo            user.QueryRepository
+	audit                    auditRecorder
+}
+
+// NewRejectChangeRequestHandler 创建拒绝变更请求命令处理器
+func NewRejectChangeRequestHandler(
+	changeRequestCommandRepo approval.CommandRepository,
+	changeRequestQueryRepo approval.QueryRepository,
+	userQueryRepo user.QueryRepository,
+	auditLogHandler *auditlog.CreateLogHandler,
+) *RejectChangeRequestHandler {
+	return &RejectChangeRequestHandler{
+		changeRequestCommandRepo: changeRequestCommandRepo,
+		changeRequestQueryRepo:   changeRequestQueryRepo,
+		userQueryRepo:            userQueryRepo,
+		audit:                    auditRecorder{handler: auditLogHandler},
+	}
+}
+
+// Handle 处理拒绝变更请求命令
+// 申请人可以撤回自己的请求，其他审批人需具备执行该操作所需的权限
+func (h *RejectChangeRequestHandler) Handle(ctx context.Context, cmd RejectChangeRequestCommand) (*ChangeRequestDTO, error) {
+	request, err := h.changeRequestQueryRepo.GetByID(ctx, cmd.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.ReviewerID != request.RequesterID {
+		if err = authorizeReviewer(ctx, h.userQueryRepo, cmd.ReviewerID, request.Operation); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = request.Reject(cmd.ReviewerID, cmd.ReviewerName, cmd.Comment, time.Now()); err != nil {
+		return nil, err
+	}
+	if err = h.changeRequestCommandRepo.UpdateStatus(ctx, request, approval.StatusPending); err != nil {
+		return nil, err
+	}
+
+	h.audit.record(ctx, request, AuditActionReject, cmd.ReviewerID, cmd.ReviewerName, auditSuccess)
+
+	return ToChangeRequestDTO(request), nil
+}
@@ -0,0 +1,10 @@
+package approval
+
+// SubmitChangeRequestCommand 提交变更请求命令
+type SubmitChangeRequestCommand struct {
+	Operation     string
+	ResourceID    string
+	Payload       any // 原始命令，批准后由对应 Executor 反序列化执行
+	RequesterID   uint
+	RequesterName string
+}
@@ -0,0 +1,65 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+)
+
+// SubmitChangeRequestHandler 提交变更请求命令处理器
+type SubmitChangeRequestHandler struct {
+	changeRequestCommandRepo approval.CommandRepository
+	policy                   *Policy
+	audit                    auditRecorder
+}
+
+// NewSubmitChangeRequestHandler 创建提交变更请求命令处理器
+func NewSubmitChangeRequestHandler(
+	changeRequestCommandRepo approval.CommandRepository,
+	policy *Policy,
+	auditLogHandler *auditlog.CreateLogHandler,
+) *SubmitChangeRequestHandler {
+	return &SubmitChangeRequestHandler{
+		changeRequestCommandRepo: changeRequestCommandRepo,
+		policy:                   policy,
+		audit:                    auditRecorder{handler: auditLogHandler},
+	}
+}
+
+// Required 检查操作是否需要提交审批（未启用审批时返回 false）
+func (h *SubmitChangeRequestHandler) Required(ctx context.Context, operation string) bool {
+	return h != nil && h.policy.Required(ctx, operation)
+}
+
+// Handle 处理提交变更请求命令
+func (h *SubmitChangeRequestHandler) Handle(ctx context.Context, cmd SubmitChangeRequestCommand) (*ChangeRequestDTO, error) {
+	if _, ok := operationPermissions[cmd.Operation]; !ok {
+		return nil, approval.ErrUnknownOperation
+	}
+
+	payload, err := json.Marshal(cmd.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode change request payload: %w", err)
+	}
+
+	request := &approval.ChangeRequest{
+		Operation:     cmd.Operation,
+		ResourceID:    cmd.ResourceID,
+		Payload:       string(payload),
+		Status:        approval.StatusPending,
+		RequesterID:   cmd.RequesterID,
+		RequesterName: cmd.RequesterName,
+		ExpiresAt:     time.Now().Add(h.policy.Timeout()),
+	}
+	if err = h.changeRequestCommandRepo.Create(ctx, request); err != nil {
+		return nil, fmt.Errorf("failed to create change request: %w", err)
+	}
+
+	h.audit.record(ctx, request, AuditActionSubmit, cmd.RequesterID, cmd.RequesterName, auditSuccess)
+
+	return ToChangeRequestDTO(request), nil
+}
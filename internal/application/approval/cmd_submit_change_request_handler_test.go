@@ -0,0 +1,87 @@
+package approval
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainApproval "github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+)
+
+type assignRolesPayload struct {
+	UserID  uint
+	RoleIDs []uint
+}
+
+func TestPolicy_Required(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		permissions []string
+		ctx         context.Context
+		operation   string
+		want        bool
+	}{
+		{"未启用审批", nil, ctx, OperationDeleteUser, false},
+		{"精确匹配", []string{"admin:users:delete"}, ctx, OperationDeleteUser, true},
+		{"通配符匹配", []string{"admin:*:*"}, ctx, OperationSetRolePermissions, true},
+		{"未配置的权限", []string{"admin:users:delete"}, ctx, OperationAssignUserRoles, false},
+		{"限时授予角色", []string{"admin:users:update"}, ctx, OperationGrantUserRole, true},
+		{"设置用户组角色", []string{"admin:groups:update"}, ctx, OperationSetGroupRoles, true},
+		{"添加用户组成员", []string{"admin:groups:update"}, ctx, OperationAddGroupMembers, true},
+		{"租户内操作不受限", []string{"admin:roles:update"}, organization.WithTenant(ctx, 1), OperationSetRolePermissions, false},
+		{"未知操作", []string{"*:*:*"}, ctx, "unknown", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPolicy(tt.permissions, time.Hour)
+			assert.Equal(t, tt.want, p.Required(tt.ctx, tt.operation))
+		})
+	}
+}
+
+func TestSubmitChangeRequestHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("保存原始命令并设置审批期限", func(t *testing.T) {
+		cmdRepo := new(MockChangeRequestCommandRepository)
+		auditRepo := new(MockAuditLogCommandRepository)
+		cmdRepo.On("Create", ctx, mock.MatchedBy(func(r *domainApproval.ChangeRequest) bool {
+			return r.Operation == OperationAssignUserRoles &&
+				r.Payload == `{"UserID":7,"RoleIDs":[1]}` &&
+				r.Status == domainApproval.StatusPending &&
+				time.Until(r.ExpiresAt) > 23*time.Hour
+		})).Return(nil)
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		h := NewSubmitChangeRequestHandler(cmdRepo, NewPolicy([]string{"admin:users:update"}, 24*time.Hour), auditlog.NewCreateLogHandler(auditRepo))
+		result, err := h.Handle(ctx, SubmitChangeRequestCommand{
+			Operation:   OperationAssignUserRoles,
+			ResourceID:  "7",
+			Payload:     assignRolesPayload{UserID: 7, RoleIDs: []uint{1}},
+			RequesterID: 10,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusPending, result.Status)
+		cmdRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("未知操作", func(t *testing.T) {
+		h := NewSubmitChangeRequestHandler(new(MockChangeRequestCommandRepository), NewPolicy(nil, time.Hour), nil)
+
+		_, err := h.Handle(ctx, SubmitChangeRequestCommand{Operation: "unknown"})
+
+		require.ErrorIs(t, err, ErrUnknownOperation)
+	})
+}
@@ -0,0 +1,20 @@
+// Package approval 实现敏感变更双人审批（四眼原则）的应用层用例。
+//
+// 受保护的操作（见 operation.go）在 [Policy] 要求审批时不直接执行，
+// 而是由 Adapters 层提交为待审批的变更请求，另一名具备相应权限的管理员批准后，
+// 通过 bootstrap 注册的 [Executor] 执行原始命令。
+//
+// # Command（写操作）
+//
+//   - [SubmitChangeRequestHandler]: 提交变更请求
+//   - [ApproveChangeRequestHandler]: 批准并执行变更请求（申请人不能批准自己的请求）
+//   - [RejectChangeRequestHandler]: 拒绝变更请求（申请人拒绝即撤回）
+//   - [ExpireChangeRequestsHandler]: 将超过审批期限的请求标记为过期（由 worker 周期执行）
+//
+// # Query（读操作）
+//
+//   - [GetChangeRequestHandler]: 获取变更请求详情
+//   - [ListChangeRequestsHandler]: 变更请求分页列表
+//
+// 提交、批准、拒绝、过期与执行结果均写入审计日志（资源类型 change_requests）。
+package approval
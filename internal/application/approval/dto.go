@@ -0,0 +1,62 @@
+package approval
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+)
+
+// 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
+var (
+	ErrChangeRequestNotFound   = approval.ErrChangeRequestNotFound
+	ErrChangeRequestNotPending = approval.ErrChangeRequestNotPending
+	ErrChangeRequestExpired    = approval.ErrChangeRequestExpired
+	ErrSelfApproval            = approval.ErrSelfApproval
+	ErrReviewerNotAuthorized   = approval.ErrReviewerNotAuthorized
+	ErrUnknownOperation        = approval.ErrUnknownOperation
+)
+
+// 变更请求状态（供 Adapters 层判断执行结果）
+const (
+	StatusPending  = string(approval.StatusPending)
+	StatusApproved = string(approval.StatusApproved)
+	StatusRejected = string(approval.StatusRejected)
+	StatusExpired  = string(approval.StatusExpired)
+	StatusFailed   = string(approval.StatusFailed)
+)
+
+// ReviewChangeRequestDTO 批准或拒绝变更请求 DTO
+type ReviewChangeRequestDTO struct {
+	Comment string `json:"comment" binding:"max=500" example:"已与申请人确认"`
+}
+
+// ChangeRequestDTO 变更请求响应 DTO
+type ChangeRequestDTO struct {
+	ID            uint            `json:"id"`
+	Operation     string          `json:"operation" example:"role.set_permissions"`
+	ResourceID    string          `json:"resource_id" example:"2"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Status        string          `json:"status" example:"pending"`
+	RequesterID   uint            `json:"requester_id"`
+	RequesterName string          `json:"requester_name"`
+	ReviewerID    *uint           `json:"reviewer_id,omitempty"`
+	ReviewerName  string          `json:"reviewer_name,omitempty"`
+	ReviewComment string          `json:"review_comment,omitempty"`
+	ReviewedAt    *time.Time      `json:"reviewed_at,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	ExpiresAt     time.Time       `json:"expires_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// ChangeRequestListDTO 变更请求列表响应 DTO
+type ChangeRequestListDTO struct {
+	Requests []*ChangeRequestDTO `json:"requests"`
+	Total    int64               `json:"total"`
+}
+
+// ExpireChangeRequestsResultDTO 过期处理结果 DTO
+type ExpireChangeRequestsResultDTO struct {
+	Expired int `json:"expired"`
+}
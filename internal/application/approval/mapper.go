@@ -0,0 +1,37 @@
+package approval
+
+import (
+	"encoding/json"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+)
+
+// ToChangeRequestDTO 将变更请求实体转换为 DTO
+func ToChangeRequestDTO(r *approval.ChangeRequest) *ChangeRequestDTO {
+	if r == nil {
+		return nil
+	}
+
+	var payload json.RawMessage
+	if r.Payload != "" {
+		payload = json.RawMessage(r.Payload)
+	}
+
+	return &ChangeRequestDTO{
+		ID:            r.ID,
+		Operation:     r.Operation,
+		ResourceID:    r.ResourceID,
+		Payload:       payload,
+		Status:        string(r.Status),
+		RequesterID:   r.RequesterID,
+		RequesterName: r.RequesterName,
+		ReviewerID:    r.ReviewerID,
+		ReviewerName:  r.ReviewerName,
+		ReviewComment: r.ReviewComment,
+		ReviewedAt:    r.ReviewedAt,
+		Error:         r.Error,
+		ExpiresAt:     r.ExpiresAt,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
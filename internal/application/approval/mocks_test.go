@@ -0,0 +1,210 @@
+//nolint:forcetypeassert // Mock 返回值类型在测试中总是已知的
+package approval
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	domainApproval "github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// MockChangeRequestCommandRepository 变更请求写仓储 Mock
+type MockChangeRequestCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockChangeRequestCommandRepository) Create(ctx context.Context, request *domainApproval.ChangeRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *MockChangeRequestCommandRepository) UpdateStatus(ctx context.Context, request *domainApproval.ChangeRequest, expected domainApproval.Status) error {
+	args := m.Called(ctx, request, expected)
+	return args.Error(0)
+}
+
+// MockChangeRequestQueryRepository 变更请求读仓储 Mock
+type MockChangeRequestQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockChangeRequestQueryRepository) GetByID(ctx context.Context, id uint) (*domainApproval.ChangeRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainApproval.ChangeRequest), args.Error(1)
+}
+
+func (m *MockChangeRequestQueryRepository) List(ctx context.Context, filter domainApproval.FilterOptions, offset, limit int) ([]*domainApproval.ChangeRequest, int64, error) {
+	args := m.Called(ctx, filter, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domainApproval.ChangeRequest), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockChangeRequestQueryRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domainApproval.ChangeRequest, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainApproval.ChangeRequest), args.Error(1)
+}
+
+// MockAuditLogCommandRepository 审计日志写仓储 Mock
+type MockAuditLogCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogCommandRepository) Create(ctx context.Context, log *domainAuditLog.AuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogCommandRepository) DeleteOlderThan(ctx context.Context, days int) error {
+	args := m.Called(ctx, days)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogCommandRepository) AnonymizeUser(ctx context.Context, userID uint, pseudonym string) error {
+	args := m.Called(ctx, userID, pseudonym)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogCommandRepository) BatchCreate(ctx context.Context, logs []*domainAuditLog.AuditLog) error {
+	args := m.Called(ctx, logs)
+	return args.Error(0)
+}
+
+// MockUserQueryRepository 用户读仓储 Mock
+type MockUserQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserQueryRepository) GetByID(ctx context.Context, id uint) (*domainUser.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByUsername(ctx context.Context, username string) (*domainUser.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByEmail(ctx context.Context, email string) (*domainUser.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByUsernameWithRoles(ctx context.Context, username string) (*domainUser.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByEmailWithRoles(ctx context.Context, email string) (*domainUser.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByIDWithRoles(ctx context.Context, id uint) (*domainUser.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	args := m.Called(ctx, username)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) List(ctx context.Context, offset, limit int) ([]*domainUser.User, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) Search(ctx context.Context, keyword string, offset, limit int) ([]*domainUser.User, error) {
+	args := m.Called(ctx, keyword, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) CountBySearch(ctx context.Context, keyword string) (int64, error) {
+	args := m.Called(ctx, keyword)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) ListByCriteria(ctx context.Context, criteria domainUser.ListCriteria) ([]*domainUser.User, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) CountByCriteria(ctx context.Context, criteria domainUser.ListCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetRoles(ctx context.Context, userID uint) ([]uint, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetUserIDsByRole(ctx context.Context, roleID uint) ([]uint, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
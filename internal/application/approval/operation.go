@@ -0,0 +1,79 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// 受审批保护的操作
+const (
+	OperationSetRolePermissions = "role.set_permissions" // role.SetPermissionsCommand
+	OperationSetRoleParent      = "role.set_parent"      // role.UpdateRoleCommand（变更父角色）
+	OperationSetGrantCondition  = "role.set_condition"   // role.SetPermissionConditionCommand
+	OperationAssignUserRoles    = "user.assign_roles"    // user.AssignRolesCommand
+	OperationDeleteUser         = "user.delete"          // user.DeleteUserCommand
+	OperationGrantUserRole      = "user.grant_role"      // user.GrantRoleCommand
+	OperationSetGroupRoles      = "group.set_roles"      // group.SetGroupRolesCommand
+	OperationAddGroupMembers    = "group.add_members"    // group.AddGroupMembersCommand
+)
+
+// operationPermissions 操作到所需权限的映射，审批策略与审批人授权均据此判定
+var operationPermissions = map[string]string{
+	OperationSetRolePermissions: "admin:roles:update",
+	OperationSetRoleParent:      "admin:roles:update",
+	OperationSetGrantCondition:  "admin:roles:update",
+	OperationAssignUserRoles:    "admin:users:update",
+	OperationDeleteUser:         "admin:users:delete",
+	OperationGrantUserRole:      "admin:users:update",
+	OperationSetGroupRoles:      "admin:groups:update",
+	OperationAddGroupMembers:    "admin:groups:update",
+}
+
+// Executor 批准后执行变更，payload 为提交时保存的原始命令 JSON
+type Executor func(ctx context.Context, payload []byte) error
+
+// Executors 操作到执行器的映射，由 bootstrap 绑定到对应的命令处理器
+type Executors map[string]Executor
+
+// Bind 将命令处理器包装为 Executor，执行前把 payload 反序列化为命令 C
+func Bind[C any](handle func(context.Context, C) error) Executor {
+	return func(ctx context.Context, payload []byte) error {
+		var cmd C
+		if err := json.Unmarshal(payload, &cmd); err != nil {
+			return fmt.Errorf("failed to decode change request payload: %w", err)
+		}
+		return handle(ctx, cmd)
+	}
+}
+
+// BindResult 与 Bind 相同，用于返回结果的命令处理器，执行结果被丢弃
+func BindResult[C, R any](handle func(context.Context, C) (R, error)) Executor {
+	return Bind(func(ctx context.Context, cmd C) error {
+		_, err := handle(ctx, cmd)
+		return err
+	})
+}
+
+// authorizeReviewer 检查审批人是否具备执行该操作所需的权限（支持通配符）
+func authorizeReviewer(ctx context.Context, userQueryRepo user.QueryRepository, reviewerID uint, operation string) error {
+	code, ok := operationPermissions[operation]
+	if !ok {
+		return ErrUnknownOperation
+	}
+
+	reviewer, err := userQueryRepo.GetByIDWithRoles(ctx, reviewerID)
+	if err != nil {
+		return fmt.Errorf("failed to get reviewer: %w", err)
+	}
+
+	for _, p := range reviewer.GetPermissions() {
+		if role.MatchCode(p.Code, code) {
+			return nil
+		}
+	}
+	return ErrReviewerNotAuthorized
+}
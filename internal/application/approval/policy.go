@@ -0,0 +1,50 @@
+package approval
+
+import (
+	"context"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// Policy 审批策略：操作所需权限命中配置的权限列表（支持通配符）时需要审批
+type Policy struct {
+	permissions []string
+	timeout     time.Duration
+}
+
+// NewPolicy 创建审批策略，permissions 为空时不启用审批
+func NewPolicy(permissions []string, timeout time.Duration) *Policy {
+	return &Policy{
+		permissions: permissions,
+		timeout:     timeout,
+	}
+}
+
+// Required 检查操作是否需要审批
+// 租户内的操作由组织自行管理，不纳入平台审批流程
+func (p *Policy) Required(ctx context.Context, operation string) bool {
+	if p == nil || len(p.permissions) == 0 {
+		return false
+	}
+	if _, scoped := organization.TenantFromContext(ctx); scoped {
+		return false
+	}
+
+	code, ok := operationPermissions[operation]
+	if !ok {
+		return false
+	}
+	for _, pattern := range p.permissions {
+		if role.MatchCode(pattern, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// Timeout 返回变更请求的审批期限
+func (p *Policy) Timeout() time.Duration {
+	return p.timeout
+}
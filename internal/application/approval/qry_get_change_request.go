@@ -0,0 +1,6 @@
+package approval
+
+// GetChangeRequestQuery 获取变更请求查询
+type GetChangeRequestQuery struct {
+	RequestID uint
+}
@@ -0,0 +1,26 @@
+package approval
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+)
+
+// GetChangeRequestHandler 获取变更请求查询处理器
+type GetChangeRequestHandler struct {
+	changeRequestQueryRepo approval.QueryRepository
+}
+
+// NewGetChangeRequestHandler 创建获取变更请求查询处理器
+func NewGetChangeRequestHandler(changeRequestQueryRepo approval.QueryRepository) *GetChangeRequestHandler {
+	return &GetChangeRequestHandler{changeRequestQueryRepo: changeRequestQueryRepo}
+}
+
+// Handle 处理获取变更请求查询
+func (h *GetChangeRequestHandler) Handle(ctx context.Context, query GetChangeRequestQuery) (*ChangeRequestDTO, error) {
+	request, err := h.changeRequestQueryRepo.GetByID(ctx, query.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	return ToChangeRequestDTO(request), nil
+}
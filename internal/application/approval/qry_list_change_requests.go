@@ -0,0 +1,16 @@
+package approval
+
+// ListChangeRequestsQuery 变更请求列表查询
+type ListChangeRequestsQuery struct {
+	Status      string
+	Operation   string
+	RequesterID *uint
+	Page        int
+	Limit       int
+}
+
+// GetOffset 计算数据库查询偏移量
+func (q ListChangeRequestsQuery) GetOffset() int {
+	page := max(q.Page, 1)
+	return (page - 1) * q.Limit
+}
@@ -0,0 +1,44 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+)
+
+// ListChangeRequestsHandler 变更请求列表查询处理器
+type ListChangeRequestsHandler struct {
+	changeRequestQueryRepo approval.QueryRepository
+}
+
+// NewListChangeRequestsHandler 创建变更请求列表查询处理器
+func NewListChangeRequestsHandler(changeRequestQueryRepo approval.QueryRepository) *ListChangeRequestsHandler {
+	return &ListChangeRequestsHandler{
+		changeRequestQueryRepo: changeRequestQueryRepo,
+	}
+}
+
+// Handle 处理变更请求列表查询
+func (h *ListChangeRequestsHandler) Handle(ctx context.Context, query ListChangeRequestsQuery) (*ChangeRequestListDTO, error) {
+	filter := approval.FilterOptions{
+		Status:      approval.Status(query.Status),
+		Operation:   query.Operation,
+		RequesterID: query.RequesterID,
+	}
+
+	requests, total, err := h.changeRequestQueryRepo.List(ctx, filter, query.GetOffset(), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list change requests: %w", err)
+	}
+
+	result := make([]*ChangeRequestDTO, 0, len(requests))
+	for _, r := range requests {
+		result = append(result, ToChangeRequestDTO(r))
+	}
+
+	return &ChangeRequestListDTO{
+		Requests: result,
+		Total:    total,
+	}, nil
+}
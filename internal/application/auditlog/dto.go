@@ -13,6 +13,8 @@ type AuditLogDTO struct {
 	UserAgent string    `json:"user_agent"`
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
+	// CreatedAtLocal 按查询者偏好时区格式化的创建时间
+	CreatedAtLocal string `json:"created_at_local,omitempty"`
 }
 
 // ListLogsDTO 审计日志列表响应 DTO
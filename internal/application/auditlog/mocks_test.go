@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 )
 
 // ============================================================
@@ -33,6 +35,11 @@ func (m *MockAuditLogCommandRepository) DeleteOlderThan(ctx context.Context, day
 	return args.Error(0)
 }
 
+func (m *MockAuditLogCommandRepository) AnonymizeUser(ctx context.Context, userID uint, pseudonym string) error {
+	args := m.Called(ctx, userID, pseudonym)
+	return args.Error(0)
+}
+
 func (m *MockAuditLogCommandRepository) BatchCreate(ctx context.Context, logs []*domainAuditLog.AuditLog) error {
 	args := m.Called(ctx, logs)
 	return args.Error(0)
@@ -101,6 +108,38 @@ func (m *MockAuditLogQueryRepository) Search(ctx context.Context, keyword string
 
 // ============================================================
 // 测试辅助函数
+// ============================================================
+// MockPolicyResolver
+// ============================================================
+
+type MockPolicyResolver struct {
+	mock.Mock
+}
+
+func (m *MockPolicyResolver) Resolve(ctx context.Context, userID uint, permission string) (*policy.Decision, error) {
+	args := m.Called(ctx, userID, permission)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.Decision), args.Error(1)
+}
+
+// ============================================================
+// MockPreferenceResolver
+// ============================================================
+
+type MockPreferenceResolver struct {
+	mock.Mock
+}
+
+func (m *MockPreferenceResolver) Resolve(ctx context.Context, userID uint) (*setting.Preferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*setting.Preferences), args.Error(1)
+}
+
 // ============================================================
 
 func newTestAuditLog(id uint) *domainAuditLog.AuditLog {
@@ -0,0 +1,9 @@
+package auditlog
+
+import "github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+
+// PermissionRead 审计日志读取权限，列表与详情查询据此解析 ABAC 策略
+const PermissionRead = "admin:audit_logs:read"
+
+// ErrAccessDenied 重新导出策略拒绝错误供 Adapters 层使用
+var ErrAccessDenied = policy.ErrAccessDenied
@@ -2,5 +2,6 @@ package auditlog
 
 // GetLogQuery 获取审计日志查询
 type GetLogQuery struct {
-	LogID uint
+	LogID   uint
+	ActorID uint // 发起查询的用户，用于解析 ABAC 策略
 }
@@ -3,19 +3,32 @@ package auditlog
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 )
 
 // GetLogHandler 获取审计日志查询处理器
 type GetLogHandler struct {
 	auditLogQueryRepo auditlog.QueryRepository
+	policyResolver    policy.Resolver
+	preferences       setting.PreferenceResolver
 }
 
 // NewGetLogHandler 创建 GetLogHandler 实例
-func NewGetLogHandler(auditLogQueryRepo auditlog.QueryRepository) *GetLogHandler {
+// policyResolver 可选，为 nil 时不应用 ABAC 策略
+// preferences 可选，为 nil 时按默认时区格式化 CreatedAtLocal
+func NewGetLogHandler(
+	auditLogQueryRepo auditlog.QueryRepository,
+	policyResolver policy.Resolver,
+	preferences setting.PreferenceResolver,
+) *GetLogHandler {
 	return &GetLogHandler{
 		auditLogQueryRepo: auditLogQueryRepo,
+		policyResolver:    policyResolver,
+		preferences:       preferences,
 	}
 }
 
@@ -26,5 +39,16 @@ func (h *GetLogHandler) Handle(ctx context.Context, query GetLogQuery) (*AuditLo
 		return nil, errors.New("audit log not found")
 	}
 
-	return ToAuditLogDTO(log), nil
+	// 检查操作者的 ABAC 策略是否允许访问该日志
+	decision, err := policy.Resolve(ctx, h.policyResolver, query.ActorID, PermissionRead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve policy: %w", err)
+	}
+	if !decision.Allows(log.PolicyAttributes()) {
+		return nil, ErrAccessDenied
+	}
+
+	dto := ToAuditLogDTO(log)
+	dto.CreatedAtLocal = setting.ResolveFor(ctx, h.preferences, query.ActorID).FormatTime(log.CreatedAt)
+	return dto, nil
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
 )
 
 func TestGetLogHandler_Handle_Success(t *testing.T) {
@@ -17,7 +19,7 @@ func TestGetLogHandler_Handle_Success(t *testing.T) {
 	expectedLog := newTestAuditLog(1)
 	mockRepo.On("FindByID", mock.Anything, uint(1)).Return(expectedLog, nil)
 
-	handler := NewGetLogHandler(mockRepo)
+	handler := NewGetLogHandler(mockRepo, nil, nil)
 
 	// Act
 	result, err := handler.Handle(context.Background(), GetLogQuery{LogID: 1})
@@ -59,7 +61,7 @@ func TestGetLogHandler_Handle_NotFound(t *testing.T) {
 			mockRepo := new(MockAuditLogQueryRepository)
 			tt.setupMocks(mockRepo)
 
-			handler := NewGetLogHandler(mockRepo)
+			handler := NewGetLogHandler(mockRepo, nil, nil)
 
 			// Act
 			result, err := handler.Handle(context.Background(), GetLogQuery{LogID: 999})
@@ -71,3 +73,44 @@ func TestGetLogHandler_Handle_NotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLogHandler_Handle_Policy(t *testing.T) {
+	ownActions, err := policy.Parse("resource.user_id == subject.id")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		actorID uint
+		wantErr error
+	}{
+		{name: "自己的操作日志", actorID: 1},
+		{name: "他人的操作日志", actorID: 2, wantErr: ErrAccessDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockAuditLogQueryRepository)
+			mockResolver := new(MockPolicyResolver)
+			mockRepo.On("FindByID", mock.Anything, uint(1)).Return(newTestAuditLog(1), nil)
+			mockResolver.On("Resolve", mock.Anything, tt.actorID, PermissionRead).Return(&policy.Decision{
+				Subject:  policy.Attributes{"id": tt.actorID},
+				Policies: []*policy.Policy{ownActions},
+			}, nil)
+
+			handler := NewGetLogHandler(mockRepo, mockResolver, nil)
+
+			// Act
+			result, err := handler.Handle(context.Background(), GetLogQuery{LogID: 1, ActorID: tt.actorID})
+
+			// Assert
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint(1), result.ID)
+		})
+	}
+}
@@ -12,4 +12,6 @@ type ListLogsQuery struct {
 	Status    string
 	StartDate *time.Time
 	EndDate   *time.Time
+
+	ActorID uint // 发起查询的用户，用于解析 ABAC 策略
 }
@@ -5,17 +5,29 @@ import (
 	"fmt"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 )
 
 // ListLogsHandler 获取审计日志列表查询处理器
 type ListLogsHandler struct {
 	auditLogQueryRepo auditlog.QueryRepository
+	policyResolver    policy.Resolver
+	preferences       setting.PreferenceResolver
 }
 
 // NewListLogsHandler 创建 ListLogsHandler 实例
-func NewListLogsHandler(auditLogQueryRepo auditlog.QueryRepository) *ListLogsHandler {
+// policyResolver 可选，为 nil 时不应用 ABAC 策略
+// preferences 可选，为 nil 时按默认时区格式化 CreatedAtLocal
+func NewListLogsHandler(
+	auditLogQueryRepo auditlog.QueryRepository,
+	policyResolver policy.Resolver,
+	preferences setting.PreferenceResolver,
+) *ListLogsHandler {
 	return &ListLogsHandler{
 		auditLogQueryRepo: auditLogQueryRepo,
+		policyResolver:    policyResolver,
+		preferences:       preferences,
 	}
 }
 
@@ -32,15 +44,29 @@ func (h *ListLogsHandler) Handle(ctx context.Context, query ListLogsQuery) (*Lis
 		EndDate:   query.EndDate,
 	}
 
+	// 将操作者的 ABAC 策略转换为仓储过滤条件
+	decision, err := policy.Resolve(ctx, h.policyResolver, query.ActorID, PermissionRead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve policy: %w", err)
+	}
+	policyFilter, err := decision.Filter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build policy filter: %w", err)
+	}
+	filter.Policy = &policyFilter
+
 	logs, total, err := h.auditLogQueryRepo.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list audit logs: %w", err)
 	}
 
-	// 转换为 DTO
+	// 转换为 DTO，时间按查询者偏好时区展示
+	prefs := setting.ResolveFor(ctx, h.preferences, query.ActorID)
 	logResponses := make([]*AuditLogDTO, 0, len(logs))
 	for i := range logs {
-		logResponses = append(logResponses, ToAuditLogDTO(&logs[i]))
+		dto := ToAuditLogDTO(&logs[i])
+		dto.CreatedAtLocal = prefs.FormatTime(logs[i].CreatedAt)
+		logResponses = append(logResponses, dto)
 	}
 
 	return &ListLogsDTO{
@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 )
 
 func TestListLogsHandler_Handle_Success(t *testing.T) {
@@ -25,7 +27,7 @@ func TestListLogsHandler_Handle_Success(t *testing.T) {
 
 	mockRepo.On("List", mock.Anything, mock.AnythingOfType("auditlog.FilterOptions")).Return(logs, int64(2), nil)
 
-	handler := NewListLogsHandler(mockRepo)
+	handler := NewListLogsHandler(mockRepo, nil, nil)
 
 	// Act
 	result, err := handler.Handle(context.Background(), ListLogsQuery{
@@ -44,13 +46,37 @@ func TestListLogsHandler_Handle_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestListLogsHandler_Handle_LocalTime(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockAuditLogQueryRepository)
+	mockPrefs := new(MockPreferenceResolver)
+
+	log := newTestAuditLog(1)
+	log.CreatedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockRepo.On("List", mock.Anything, mock.AnythingOfType("auditlog.FilterOptions")).Return([]domainAuditLog.AuditLog{*log}, int64(1), nil)
+	mockPrefs.On("Resolve", mock.Anything, uint(9)).Return(setting.ResolvePreferences([]*setting.Setting{
+		{Key: setting.KeyPreferenceTimezone, Value: "Asia/Shanghai", Category: setting.CategoryPreference, ValueType: setting.ValueTypeString},
+	}, nil), nil)
+
+	handler := NewListLogsHandler(mockRepo, nil, mockPrefs)
+
+	// Act
+	result, err := handler.Handle(context.Background(), ListLogsQuery{Page: 1, Limit: 10, ActorID: 9})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Logs, 1)
+	assert.Equal(t, "2026-01-02 11:04:05 +08:00", result.Logs[0].CreatedAtLocal)
+	mockPrefs.AssertExpectations(t)
+}
+
 func TestListLogsHandler_Handle_EmptyList(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockAuditLogQueryRepository)
 
 	mockRepo.On("List", mock.Anything, mock.AnythingOfType("auditlog.FilterOptions")).Return([]domainAuditLog.AuditLog{}, int64(0), nil)
 
-	handler := NewListLogsHandler(mockRepo)
+	handler := NewListLogsHandler(mockRepo, nil, nil)
 
 	// Act
 	result, err := handler.Handle(context.Background(), ListLogsQuery{
@@ -78,7 +104,7 @@ func TestListLogsHandler_Handle_WithFilters(t *testing.T) {
 		capturedFilter = args.Get(1).(domainAuditLog.FilterOptions)
 	}).Return([]domainAuditLog.AuditLog{}, int64(0), nil)
 
-	handler := NewListLogsHandler(mockRepo)
+	handler := NewListLogsHandler(mockRepo, nil, nil)
 
 	// Act
 	_, err := handler.Handle(context.Background(), ListLogsQuery{
@@ -110,7 +136,7 @@ func TestListLogsHandler_Handle_Error(t *testing.T) {
 
 	mockRepo.On("List", mock.Anything, mock.AnythingOfType("auditlog.FilterOptions")).Return(nil, int64(0), errors.New("database error"))
 
-	handler := NewListLogsHandler(mockRepo)
+	handler := NewListLogsHandler(mockRepo, nil, nil)
 
 	// Act
 	result, err := handler.Handle(context.Background(), ListLogsQuery{
@@ -135,7 +161,7 @@ func TestListLogsHandler_Handle_DTOConversion(t *testing.T) {
 
 	mockRepo.On("List", mock.Anything, mock.AnythingOfType("auditlog.FilterOptions")).Return([]domainAuditLog.AuditLog{*log}, int64(1), nil)
 
-	handler := NewListLogsHandler(mockRepo)
+	handler := NewListLogsHandler(mockRepo, nil, nil)
 
 	result, err := handler.Handle(context.Background(), ListLogsQuery{Page: 1, Limit: 10})
 
@@ -150,3 +176,36 @@ func TestListLogsHandler_Handle_DTOConversion(t *testing.T) {
 	assert.Equal(t, log.Details, dto.Details)
 	assert.Equal(t, log.Status, dto.Status)
 }
+
+func TestListLogsHandler_Handle_PolicyFilter(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockAuditLogQueryRepository)
+	mockResolver := new(MockPolicyResolver)
+
+	ownActions, err := policy.Parse("resource.user_id == subject.id")
+	require.NoError(t, err)
+	mockResolver.On("Resolve", mock.Anything, uint(7), PermissionRead).Return(&policy.Decision{
+		Subject:  policy.Attributes{"id": uint(7)},
+		Policies: []*policy.Policy{ownActions},
+	}, nil)
+
+	var captured domainAuditLog.FilterOptions
+	mockRepo.On("List", mock.Anything, mock.AnythingOfType("auditlog.FilterOptions")).
+		Run(func(args mock.Arguments) {
+			captured = args.Get(1).(domainAuditLog.FilterOptions)
+		}).
+		Return([]domainAuditLog.AuditLog{}, int64(0), nil)
+
+	handler := NewListLogsHandler(mockRepo, mockResolver, nil)
+
+	// Act
+	_, err = handler.Handle(context.Background(), ListLogsQuery{Page: 1, Limit: 10, ActorID: 7})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, captured.Policy)
+	assert.Equal(t, [][]policy.Predicate{
+		{{Field: "user_id", Op: policy.OpEqual, Value: uint(7)}},
+	}, captured.Policy.Alternatives)
+	mockResolver.AssertExpectations(t)
+}
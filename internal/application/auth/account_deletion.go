@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// cancelPendingDeletion 用户在删除宽限期内登录时撤销待删除状态，返回是否撤销
+// 撤销失败不阻断登录，仅记录日志（删除任务执行前会重新检查状态）
+func cancelPendingDeletion(ctx context.Context, userCommandRepo user.CommandRepository, u *user.User) bool {
+	if userCommandRepo == nil || !u.CancelDeletion() {
+		return false
+	}
+
+	if err := userCommandRepo.UpdateDeletionSchedule(ctx, u.ID, nil, nil); err != nil {
+		slog.Error("failed to cancel account deletion", "user_id", u.ID, "error", err)
+		return false
+	}
+	return true
+}
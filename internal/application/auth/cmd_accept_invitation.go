@@ -0,0 +1,7 @@
+package auth
+
+// AcceptInvitationCommand 接受邀请命令
+type AcceptInvitationCommand struct {
+	Token    string
+	Password string
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// AcceptInvitationHandler 接受邀请命令处理器
+// 受邀用户通过邀请令牌设置密码，账号随即激活
+type AcceptInvitationHandler struct {
+	userCommandRepo       user.CommandRepository
+	userQueryRepo         user.QueryRepository
+	invitationCommandRepo user.InvitationCommandRepository
+	invitationQueryRepo   user.InvitationQueryRepository
+	authService           auth.Service
+}
+
+// NewAcceptInvitationHandler 创建接受邀请命令处理器
+func NewAcceptInvitationHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	invitationCommandRepo user.InvitationCommandRepository,
+	invitationQueryRepo user.InvitationQueryRepository,
+	authService auth.Service,
+) *AcceptInvitationHandler {
+	return &AcceptInvitationHandler{
+		userCommandRepo:       userCommandRepo,
+		userQueryRepo:         userQueryRepo,
+		invitationCommandRepo: invitationCommandRepo,
+		invitationQueryRepo:   invitationQueryRepo,
+		authService:           authService,
+	}
+}
+
+// Handle 处理接受邀请命令
+func (h *AcceptInvitationHandler) Handle(ctx context.Context, cmd AcceptInvitationCommand) (*UserBriefDTO, error) {
+	now := time.Now()
+
+	// 1. 查找并校验邀请
+	invitation, err := h.invitationQueryRepo.FindByTokenHash(ctx, user.HashInvitationToken(cmd.Token))
+	if err != nil {
+		return nil, err
+	}
+	if err = invitation.CanAccept(now); err != nil {
+		return nil, err
+	}
+
+	// 2. 查找受邀用户
+	u, err := h.userQueryRepo.GetByID(ctx, invitation.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 验证密码策略并生成哈希
+	if err = h.authService.ValidatePasswordPolicy(ctx, cmd.Password); err != nil {
+		return nil, err
+	}
+	hashedPassword, err := h.authService.GeneratePasswordHash(ctx, cmd.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// 4. 设置密码并激活账号
+	if err = h.userCommandRepo.UpdatePassword(ctx, u.ID, hashedPassword); err != nil {
+		return nil, err
+	}
+	u.Activate()
+	if err = h.userCommandRepo.UpdateStatus(ctx, u.ID, u.Status); err != nil {
+		return nil, err
+	}
+
+	// 5. 标记邀请已使用
+	invitation.Accept(now)
+	if err = h.invitationCommandRepo.MarkAccepted(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	return &UserBriefDTO{UserID: u.ID, Username: u.Username}, nil
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestAcceptInvitationHandler_Handle(t *testing.T) {
+	token := "invite-token"
+	tokenHash := domainUser.HashInvitationToken(token)
+
+	t.Run("成功接受邀请并激活账号", func(t *testing.T) {
+		mockUserCmdRepo := new(MockUserCommandRepository)
+		mockUserQryRepo := new(MockUserQueryRepository)
+		mockInvCmdRepo := new(MockInvitationCommandRepository)
+		mockInvQryRepo := new(MockInvitationQueryRepository)
+		mockAuthService := new(MockAuthService)
+
+		invitation := &domainUser.Invitation{ID: 10, UserID: 1, TokenHash: tokenHash, ExpiresAt: time.Now().Add(time.Hour)}
+		mockInvQryRepo.On("FindByTokenHash", mock.Anything, tokenHash).Return(invitation, nil)
+		mockUserQryRepo.On("GetByID", mock.Anything, uint(1)).Return(&domainUser.User{ID: 1, Username: "invitee", Status: "inactive"}, nil)
+		mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "NewPass123").Return(nil)
+		mockAuthService.On("GeneratePasswordHash", mock.Anything, "NewPass123").Return("hashed", nil)
+		mockUserCmdRepo.On("UpdatePassword", mock.Anything, uint(1), "hashed").Return(nil)
+		mockUserCmdRepo.On("UpdateStatus", mock.Anything, uint(1), "active").Return(nil)
+		mockInvCmdRepo.On("MarkAccepted", mock.Anything, invitation).Return(nil)
+
+		handler := NewAcceptInvitationHandler(mockUserCmdRepo, mockUserQryRepo, mockInvCmdRepo, mockInvQryRepo, mockAuthService)
+
+		result, err := handler.Handle(context.Background(), AcceptInvitationCommand{Token: token, Password: "NewPass123"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), result.UserID)
+		assert.True(t, invitation.IsAccepted())
+		mockUserCmdRepo.AssertExpectations(t)
+		mockInvCmdRepo.AssertExpectations(t)
+	})
+
+	t.Run("邀请已过期", func(t *testing.T) {
+		mockUserCmdRepo := new(MockUserCommandRepository)
+		mockInvQryRepo := new(MockInvitationQueryRepository)
+
+		mockInvQryRepo.On("FindByTokenHash", mock.Anything, tokenHash).Return(&domainUser.Invitation{
+			UserID: 1, TokenHash: tokenHash, ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		handler := NewAcceptInvitationHandler(mockUserCmdRepo, new(MockUserQueryRepository), new(MockInvitationCommandRepository), mockInvQryRepo, new(MockAuthService))
+
+		result, err := handler.Handle(context.Background(), AcceptInvitationCommand{Token: token, Password: "NewPass123"})
+
+		assert.Nil(t, result)
+		require.ErrorIs(t, err, domainUser.ErrInvitationExpired)
+		mockUserCmdRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("邀请不存在", func(t *testing.T) {
+		mockInvQryRepo := new(MockInvitationQueryRepository)
+		mockInvQryRepo.On("FindByTokenHash", mock.Anything, mock.Anything).Return(nil, domainUser.ErrInvitationNotFound)
+
+		handler := NewAcceptInvitationHandler(new(MockUserCommandRepository), new(MockUserQueryRepository), new(MockInvitationCommandRepository), mockInvQryRepo, new(MockAuthService))
+
+		_, err := handler.Handle(context.Background(), AcceptInvitationCommand{Token: "unknown", Password: "NewPass123"})
+
+		require.ErrorIs(t, err, domainUser.ErrInvitationNotFound)
+	})
+}
@@ -14,6 +14,7 @@ type LoginCommand struct {
 type Login2FACommand struct {
 	SessionToken  string
 	TwoFactorCode string
+	Method        string // 验证方式：totp（默认）/ email / sms
 	ClientIP      string // 客户端 IP（用于审计日志）
 	UserAgent     string // 用户代理（用于审计日志）
 }
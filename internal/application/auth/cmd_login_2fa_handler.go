@@ -8,6 +8,8 @@ import (
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 	authInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
 	twofaInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/twofa"
@@ -15,27 +17,39 @@ import (
 
 // Login2FAHandler 二次认证登录命令处理器
 type Login2FAHandler struct {
-	userQueryRepo   user.QueryRepository
-	authService     auth.Service
-	loginSession    *authInfra.LoginSessionService
-	twofaService    *twofaInfra.Service
-	auditLogHandler *auditlog.CreateLogHandler
+	userQueryRepo    user.QueryRepository
+	userCommandRepo  user.CommandRepository
+	settingQueryRepo setting.QueryRepository
+	authService      auth.Service
+	loginSession     *authInfra.LoginSessionService
+	twofaService     *twofaInfra.Service
+	otpService       twofa.OTPService
+	auditLogHandler  *auditlog.CreateLogHandler
+	loginRecorder    user.LoginRecorder
 }
 
 // NewLogin2FAHandler 创建二次认证登录命令处理器
 func NewLogin2FAHandler(
 	userQueryRepo user.QueryRepository,
+	userCommandRepo user.CommandRepository,
+	settingQueryRepo setting.QueryRepository,
 	authService auth.Service,
 	loginSession *authInfra.LoginSessionService,
 	twofaService *twofaInfra.Service,
+	otpService twofa.OTPService,
 	auditLogHandler *auditlog.CreateLogHandler,
+	loginRecorder user.LoginRecorder,
 ) *Login2FAHandler {
 	return &Login2FAHandler{
-		userQueryRepo:   userQueryRepo,
-		authService:     authService,
-		loginSession:    loginSession,
-		twofaService:    twofaService,
-		auditLogHandler: auditLogHandler,
+		userQueryRepo:    userQueryRepo,
+		userCommandRepo:  userCommandRepo,
+		settingQueryRepo: settingQueryRepo,
+		authService:      authService,
+		loginSession:     loginSession,
+		twofaService:     twofaService,
+		otpService:       otpService,
+		auditLogHandler:  auditLogHandler,
+		loginRecorder:    loginRecorder,
 	}
 }
 
@@ -48,8 +62,8 @@ func (h *Login2FAHandler) Handle(ctx context.Context, cmd Login2FACommand) (*Log
 		return nil, errors.New("session expired or invalid, please login again")
 	}
 
-	// 2. 验证 2FA 验证码
-	valid, err := h.twofaService.Verify(ctx, sessionData.UserID, cmd.TwoFactorCode)
+	// 2. 验证 2FA 验证码（按所选方式）
+	valid, err := h.verifyCode(ctx, sessionData.UserID, cmd.Method, cmd.TwoFactorCode)
 	if err != nil {
 		h.logLoginEvent(ctx, sessionData.UserID, sessionData.Account, cmd.ClientIP, cmd.UserAgent, "2fa_verify_error", "failure")
 		return nil, fmt.Errorf("2FA verification failed: %w", err)
@@ -77,7 +91,24 @@ func (h *Login2FAHandler) Handle(ctx context.Context, cmd Login2FACommand) (*Log
 		}
 	}
 
-	// 5. 生成访问令牌
+	// 认证完成，记录最近登录时间、IP 与登录次数
+	recordLogin(h.loginRecorder, u.ID, cmd.ClientIP)
+
+	// 5. 宽限期内登录即撤销账号删除
+	deletionCancelled := cancelPendingDeletion(ctx, h.userCommandRepo, u)
+
+	// 6. 必须修改密码时仅签发受限令牌
+	if reason := passwordChangeReason(ctx, h.settingQueryRepo, u); reason != "" {
+		h.logLoginEvent(ctx, u.ID, u.Username, cmd.ClientIP, cmd.UserAgent, "password_change_required", "success")
+		result, issueErr := issuePasswordChangeLogin(ctx, h.authService, u, reason)
+		if issueErr != nil {
+			return nil, issueErr
+		}
+		result.AccountDeletionCancelled = deletionCancelled
+		return result, nil
+	}
+
+	// 7. 生成访问令牌
 	accessToken, expiresAt, err := h.authService.GenerateAccessToken(ctx, u.ID, u.Username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
@@ -101,9 +132,28 @@ func (h *Login2FAHandler) Handle(ctx context.Context, cmd Login2FACommand) (*Log
 		UserID:       u.ID,
 		Username:     u.Username,
 		Requires2FA:  false,
+
+		AccountDeletionCancelled: deletionCancelled,
 	}, nil
 }
 
+// verifyCode 按验证方式校验二次认证码
+func (h *Login2FAHandler) verifyCode(ctx context.Context, userID uint, rawMethod, code string) (bool, error) {
+	method, err := twofa.ParseMethod(rawMethod)
+	if err != nil {
+		return false, err
+	}
+
+	if method.IsOTPChannel() {
+		if h.otpService == nil {
+			return false, twofa.ErrUnsupportedMethod
+		}
+		return h.otpService.VerifyLoginCode(ctx, userID, method, code)
+	}
+
+	return h.twofaService.Verify(ctx, userID, code)
+}
+
 // logLoginEvent 异步记录登录事件到审计日志
 func (h *Login2FAHandler) logLoginEvent(ctx context.Context, userID uint, username, clientIP, userAgent, event, status string) {
 	if h.auditLogHandler == nil {
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	domainAuth "github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
 	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 	authInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
 )
@@ -40,7 +42,7 @@ func TestLogin2FAHandler_SessionExpired(t *testing.T) {
 	// 由于 twofaInfra.Service 需要真实的 repositories，这里我们测试 session 过期的场景
 	// 这个测试会在验证 session token 时失败，不会调用到 twofaService
 
-	handler := NewLogin2FAHandler(mockUserQryRepo, mockAuthService, loginSession, nil, nil)
+	handler := NewLogin2FAHandler(mockUserQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, nil, nil)
 
 	// Act - 使用无效的 session token
 	result, err := handler.Handle(context.Background(), Login2FACommand{
@@ -79,7 +81,7 @@ func TestLogin2FAHandler_EmptySessionToken(t *testing.T) {
 	mockAuthService := new(MockAuthService)
 	loginSession := authInfra.NewLoginSessionService()
 
-	handler := NewLogin2FAHandler(mockUserQryRepo, mockAuthService, loginSession, nil, nil)
+	handler := NewLogin2FAHandler(mockUserQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, nil, nil)
 
 	// Act - 空 session token
 	result, err := handler.Handle(context.Background(), Login2FACommand{
@@ -105,6 +107,73 @@ func TestLogin2FAHandler_Integration_Success(t *testing.T) {
 	t.Skip("需要完整的 2FA 服务设置，建议使用集成测试")
 }
 
+func TestLogin2FAHandler_OTPChannel(t *testing.T) {
+	ctx := context.Background()
+	user := &domainUser.User{ID: 1, Username: "admin", Status: "active"}
+
+	t.Run("邮件验证码登录成功", func(t *testing.T) {
+		loginSession := authInfra.NewLoginSessionService()
+		token, err := loginSession.GenerateSessionToken(ctx, 1, "admin")
+		require.NoError(t, err)
+
+		mockUserQryRepo := new(MockUserQueryRepository)
+		mockAuthService := new(MockAuthService)
+		mockOTPService := new(MockOTPService)
+		expiresAt := time.Now().Add(time.Hour)
+
+		mockOTPService.On("VerifyLoginCode", mock.Anything, uint(1), domainTwoFA.MethodEmail, "123456").Return(true, nil)
+		mockUserQryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(user, nil)
+		mockAuthService.On("GenerateAccessToken", mock.Anything, uint(1), "admin").Return("access_token", expiresAt, nil)
+		mockAuthService.On("GenerateRefreshToken", mock.Anything, uint(1)).Return("refresh_token", expiresAt, nil)
+
+		handler := NewLogin2FAHandler(mockUserQryRepo, nil, nil, mockAuthService, loginSession, nil, mockOTPService, nil, nil)
+		result, err := handler.Handle(ctx, Login2FACommand{
+			SessionToken:  token,
+			TwoFactorCode: "123456",
+			Method:        "email",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "access_token", result.AccessToken)
+		mockOTPService.AssertExpectations(t)
+	})
+
+	t.Run("短信验证码错误", func(t *testing.T) {
+		loginSession := authInfra.NewLoginSessionService()
+		token, err := loginSession.GenerateSessionToken(ctx, 1, "admin")
+		require.NoError(t, err)
+
+		mockOTPService := new(MockOTPService)
+		mockOTPService.On("VerifyLoginCode", mock.Anything, uint(1), domainTwoFA.MethodSMS, "000000").Return(false, nil)
+
+		handler := NewLogin2FAHandler(new(MockUserQueryRepository), nil, nil, new(MockAuthService), loginSession, nil, mockOTPService, nil, nil)
+		result, err := handler.Handle(ctx, Login2FACommand{
+			SessionToken:  token,
+			TwoFactorCode: "000000",
+			Method:        "sms",
+		})
+
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid two factor code")
+	})
+
+	t.Run("不支持的验证方式", func(t *testing.T) {
+		loginSession := authInfra.NewLoginSessionService()
+		token, err := loginSession.GenerateSessionToken(ctx, 1, "admin")
+		require.NoError(t, err)
+
+		handler := NewLogin2FAHandler(new(MockUserQueryRepository), nil, nil, new(MockAuthService), loginSession, nil, new(MockOTPService), nil, nil)
+		_, err = handler.Handle(ctx, Login2FACommand{
+			SessionToken:  token,
+			TwoFactorCode: "123456",
+			Method:        "fax",
+		})
+
+		require.ErrorIs(t, err, domainTwoFA.ErrUnsupportedMethod)
+	})
+}
+
 // ============================================================
 // 测试辅助：验证 Handler 结构正确创建
 // ============================================================
@@ -114,7 +183,7 @@ func TestNewLogin2FAHandler(t *testing.T) {
 	mockAuthService := new(MockAuthService)
 	loginSession := authInfra.NewLoginSessionService()
 
-	handler := NewLogin2FAHandler(mockUserQryRepo, mockAuthService, loginSession, nil, nil)
+	handler := NewLogin2FAHandler(mockUserQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, nil, nil)
 
 	assert.NotNil(t, handler)
 }
@@ -149,7 +218,7 @@ func TestLogin2FAHandler_NilAuditLogHandler(t *testing.T) {
 	mockAuthService := new(MockAuthService)
 	loginSession := authInfra.NewLoginSessionService()
 
-	handler := NewLogin2FAHandler(mockUserQryRepo, mockAuthService, loginSession, nil, nil)
+	handler := NewLogin2FAHandler(mockUserQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, nil, nil)
 
 	// 使用无效的 session token 触发错误路径
 	result, err := handler.Handle(context.Background(), Login2FACommand{
@@ -308,7 +377,7 @@ func TestLogin2FAHandler_FullFlow_WithRealLoginSession(t *testing.T) {
 
 	// 由于 twofaService 为 nil，我们无法测试完整流程
 	// 但可以验证 session token 的验证逻辑
-	handler := NewLogin2FAHandler(mockUserQryRepo, mockAuthService, loginSession, nil, nil)
+	handler := NewLogin2FAHandler(mockUserQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, nil, nil)
 
 	// 这里会失败，因为 twofaService 为 nil
 	// 但这验证了 session token 被正确验证
@@ -2,12 +2,14 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/captcha"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 	authInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
@@ -16,29 +18,44 @@ import (
 // LoginHandler 登录命令处理器
 type LoginHandler struct {
 	userQueryRepo      user.QueryRepository
+	userCommandRepo    user.CommandRepository
 	captchaCommandRepo captcha.CommandRepository
 	twofaQueryRepo     twofa.QueryRepository
+	settingQueryRepo   setting.QueryRepository
+	otpService         twofa.OTPService
 	authService        auth.Service
 	loginSession       *authInfra.LoginSessionService
+	identityProviders  *IdentityProviderChain
 	auditLogHandler    *auditlog.CreateLogHandler
+	loginRecorder      user.LoginRecorder
 }
 
 // NewLoginHandler 创建登录命令处理器
 func NewLoginHandler(
 	userQueryRepo user.QueryRepository,
+	userCommandRepo user.CommandRepository,
 	captchaCommandRepo captcha.CommandRepository,
 	twofaQueryRepo twofa.QueryRepository,
+	settingQueryRepo setting.QueryRepository,
+	otpService twofa.OTPService,
 	authService auth.Service,
 	loginSession *authInfra.LoginSessionService,
+	identityProviders *IdentityProviderChain,
 	auditLogHandler *auditlog.CreateLogHandler,
+	loginRecorder user.LoginRecorder,
 ) *LoginHandler {
 	return &LoginHandler{
 		userQueryRepo:      userQueryRepo,
+		userCommandRepo:    userCommandRepo,
 		captchaCommandRepo: captchaCommandRepo,
 		twofaQueryRepo:     twofaQueryRepo,
+		settingQueryRepo:   settingQueryRepo,
+		otpService:         otpService,
 		authService:        authService,
 		loginSession:       loginSession,
+		identityProviders:  identityProviders,
 		auditLogHandler:    auditLogHandler,
+		loginRecorder:      loginRecorder,
 	}
 }
 
@@ -54,37 +71,14 @@ func (h *LoginHandler) Handle(ctx context.Context, cmd LoginCommand) (*LoginResu
 		return nil, auth.ErrInvalidCaptcha
 	}
 
-	// 2. 查找用户（支持用户名或邮箱登录）
-	var u *user.User
-	if u, err = h.userQueryRepo.GetByUsernameWithRoles(ctx, cmd.Account); err != nil {
-		// 尝试通过邮箱查找
-		if u, err = h.userQueryRepo.GetByEmailWithRoles(ctx, cmd.Account); err != nil {
-			h.logLoginEvent(ctx, 0, cmd.Account, cmd.ClientIP, cmd.UserAgent, "user_not_found", "failure")
-			return nil, auth.ErrInvalidCredentials
-		}
-	}
-
-	// 3. 检查用户状态
-	if !u.CanLogin() {
-		if u.IsBanned() {
-			h.logLoginEvent(ctx, u.ID, u.Username, cmd.ClientIP, cmd.UserAgent, "user_banned", "failure")
-			return nil, auth.ErrUserBanned
-		}
-		if u.IsInactive() {
-			h.logLoginEvent(ctx, u.ID, u.Username, cmd.ClientIP, cmd.UserAgent, "user_inactive", "failure")
-			return nil, auth.ErrUserInactive
-		}
-	}
-
-	// 4. 验证密码
-	if err = h.authService.VerifyPassword(ctx, u.Password, cmd.Password); err != nil {
-		h.logLoginEvent(ctx, u.ID, u.Username, cmd.ClientIP, cmd.UserAgent, "invalid_password", "failure")
-		return nil, auth.ErrInvalidCredentials
+	// 2. 按身份提供者链认证（本地密码库、LDAP 等）
+	u, err := h.authenticateAccount(ctx, cmd)
+	if err != nil {
+		return nil, err
 	}
 
-	// 5. 检查是否启用 2FA
-	tfa, err := h.twofaQueryRepo.FindByUserID(ctx, u.ID)
-	if err == nil && tfa != nil && tfa.Enabled {
+	// 3. 检查是否启用 2FA（TOTP 或邮件/短信通道）
+	if methods := h.twoFAMethods(ctx, u.ID); len(methods) > 0 {
 		// 需要 2FA 验证，生成临时 session token
 		sessionToken, sessionErr := h.loginSession.GenerateSessionToken(ctx, u.ID, cmd.Account)
 		if sessionErr != nil {
@@ -97,9 +91,27 @@ func (h *LoginHandler) Handle(ctx context.Context, cmd LoginCommand) (*LoginResu
 			SessionToken: sessionToken,
 			UserID:       u.ID,
 			Username:     u.Username,
+			TwoFAMethods: methods,
 		}, nil
 	}
 
+	// 认证完成，记录最近登录时间、IP 与登录次数
+	recordLogin(h.loginRecorder, u.ID, cmd.ClientIP)
+
+	// 4. 宽限期内登录即撤销账号删除
+	deletionCancelled := cancelPendingDeletion(ctx, h.userCommandRepo, u)
+
+	// 5. 必须修改密码时仅签发受限令牌
+	if reason := passwordChangeReason(ctx, h.settingQueryRepo, u); reason != "" {
+		h.logLoginEvent(ctx, u.ID, u.Username, cmd.ClientIP, cmd.UserAgent, "password_change_required", "success")
+		result, issueErr := issuePasswordChangeLogin(ctx, h.authService, u, reason)
+		if issueErr != nil {
+			return nil, issueErr
+		}
+		result.AccountDeletionCancelled = deletionCancelled
+		return result, nil
+	}
+
 	// 6. 生成访问令牌（新架构：不传递 roles，权限从缓存查询）
 	accessToken, expiresAt, err := h.authService.GenerateAccessToken(ctx, u.ID, u.Username)
 	if err != nil {
@@ -124,9 +136,118 @@ func (h *LoginHandler) Handle(ctx context.Context, cmd LoginCommand) (*LoginResu
 		UserID:       u.ID,
 		Username:     u.Username,
 		Requires2FA:  false,
+
+		AccountDeletionCancelled: deletionCancelled,
 	}, nil
 }
 
+// errTryNextProvider 当前身份提供者无法认证该账号，由下一个提供者继续处理
+var errTryNextProvider = errors.New("try next identity provider")
+
+// authenticateAccount 按身份提供者链顺序认证账号
+// 本地存在的本地账号只由本地密码库认证，目录管理的账号只由外部提供者认证
+func (h *LoginHandler) authenticateAccount(ctx context.Context, cmd LoginCommand) (*user.User, error) {
+	providers := []string{auth.ProviderLocal}
+	if h.identityProviders != nil {
+		providers = h.identityProviders.Order()
+	}
+
+	var providerErr error
+	for _, name := range providers {
+		var u *user.User
+		var err error
+		if name == auth.ProviderLocal {
+			u, err = h.authenticateLocal(ctx, cmd)
+		} else {
+			u, err = h.identityProviders.Authenticate(ctx, name, cmd.Account, cmd.Password)
+			switch {
+			case err == nil:
+				err = h.checkUserStatus(ctx, u, cmd)
+			case errors.Is(err, auth.ErrInvalidCredentials):
+				err = errTryNextProvider
+			default:
+				// 目录不可用时继续尝试后续提供者，全部失败时再报告
+				providerErr = err
+				err = errTryNextProvider
+			}
+		}
+
+		if errors.Is(err, errTryNextProvider) {
+			continue
+		}
+		return u, err
+	}
+
+	if providerErr != nil {
+		return nil, fmt.Errorf("identity provider unavailable: %w", providerErr)
+	}
+	h.logLoginEvent(ctx, 0, cmd.Account, cmd.ClientIP, cmd.UserAgent, "user_not_found", "failure")
+	return nil, auth.ErrInvalidCredentials
+}
+
+// authenticateLocal 使用本地密码库认证（支持用户名或邮箱登录）
+func (h *LoginHandler) authenticateLocal(ctx context.Context, cmd LoginCommand) (*user.User, error) {
+	u, err := h.userQueryRepo.GetByUsernameWithRoles(ctx, cmd.Account)
+	if err != nil {
+		// 尝试通过邮箱查找
+		if u, err = h.userQueryRepo.GetByEmailWithRoles(ctx, cmd.Account); err != nil {
+			return nil, errTryNextProvider
+		}
+	}
+
+	// 目录管理的用户不使用本地密码
+	if u.IsExternallyManaged() {
+		return nil, errTryNextProvider
+	}
+
+	if err = h.checkUserStatus(ctx, u, cmd); err != nil {
+		return nil, err
+	}
+
+	if err = h.authService.VerifyPassword(ctx, u.Password, cmd.Password); err != nil {
+		h.logLoginEvent(ctx, u.ID, u.Username, cmd.ClientIP, cmd.UserAgent, "invalid_password", "failure")
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	return u, nil
+}
+
+// checkUserStatus 检查用户状态是否允许登录
+func (h *LoginHandler) checkUserStatus(ctx context.Context, u *user.User, cmd LoginCommand) error {
+	if u.CanLogin() {
+		return nil
+	}
+	if u.IsBanned() {
+		h.logLoginEvent(ctx, u.ID, u.Username, cmd.ClientIP, cmd.UserAgent, "user_banned", "failure")
+		return auth.ErrUserBanned
+	}
+	if u.IsInactive() {
+		h.logLoginEvent(ctx, u.ID, u.Username, cmd.ClientIP, cmd.UserAgent, "user_inactive", "failure")
+		return auth.ErrUserInactive
+	}
+	return nil
+}
+
+// twoFAMethods 返回用户已启用的二次认证方式，TOTP 优先
+func (h *LoginHandler) twoFAMethods(ctx context.Context, userID uint) []string {
+	var methods []string
+
+	tfa, err := h.twofaQueryRepo.FindByUserID(ctx, userID)
+	if err == nil && tfa != nil && tfa.Enabled {
+		methods = append(methods, twofa.MethodTOTP.String())
+	}
+
+	if h.otpService != nil {
+		if otpMethods, otpErr := h.otpService.EnabledMethods(ctx, userID); otpErr == nil {
+			for _, m := range otpMethods {
+				methods = append(methods, m.String())
+			}
+		}
+	}
+
+	return methods
+}
+
 // logLoginEvent 异步记录登录事件到审计日志
 func (h *LoginHandler) logLoginEvent(ctx context.Context, userID uint, username, clientIP, userAgent, event, status string) {
 	if h.auditLogHandler == nil {
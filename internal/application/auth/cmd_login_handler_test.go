@@ -11,9 +11,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	domainAuth "github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	domainSetting "github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
 	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 	authInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
+	ldapInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/ldap"
 )
 
 func TestLoginHandler_Handle_Success_Without2FA(t *testing.T) {
@@ -40,8 +43,12 @@ func TestLoginHandler_Handle_Success_Without2FA(t *testing.T) {
 	mockTwofaQryRepo.On("FindByUserID", mock.Anything, uint(1)).Return(nil, nil) // 2FA 未启用
 	mockAuthService.On("GenerateAccessToken", mock.Anything, uint(1), "testuser").Return("access_token", expiresAt, nil)
 	mockAuthService.On("GenerateRefreshToken", mock.Anything, uint(1)).Return("refresh_token", expiresAt.Add(7*24*time.Hour), nil)
+	mockRecorder := new(MockLoginRecorder)
+	mockRecorder.On("Record", mock.MatchedBy(func(a domainUser.LoginActivity) bool {
+		return a.UserID == 1 && a.IP == "127.0.0.1" && !a.At.IsZero() && !a.Repeat
+	})).Return()
 
-	handler := NewLoginHandler(mockUserQryRepo, mockCaptchaRepo, mockTwofaQryRepo, mockAuthService, loginSession, nil)
+	handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, mockTwofaQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, mockRecorder)
 
 	// Act
 	result, err := handler.Handle(context.Background(), LoginCommand{
@@ -67,6 +74,49 @@ func TestLoginHandler_Handle_Success_Without2FA(t *testing.T) {
 	mockUserQryRepo.AssertExpectations(t)
 	mockTwofaQryRepo.AssertExpectations(t)
 	mockAuthService.AssertExpectations(t)
+	mockRecorder.AssertExpectations(t)
+}
+
+func TestLoginHandler_Handle_CancelsPendingDeletion(t *testing.T) {
+	mockUserQryRepo := new(MockUserQueryRepository)
+	mockUserCmdRepo := new(MockUserCommandRepository)
+	mockCaptchaRepo := new(MockCaptchaCommandRepository)
+	mockTwofaQryRepo := new(MockTwoFAQueryRepository)
+	mockAuthService := new(MockAuthService)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	requestedAt := time.Now().Add(-24 * time.Hour)
+	scheduledAt := time.Now().Add(13 * 24 * time.Hour)
+	user := &domainUser.User{
+		ID:                  1,
+		Username:            "testuser",
+		Password:            "hashed_password",
+		Status:              "active",
+		DeletionRequestedAt: &requestedAt,
+		DeletionScheduledAt: &scheduledAt,
+	}
+
+	mockCaptchaRepo.On("Verify", mock.Anything, "captcha_id", "captcha_code").Return(true, nil)
+	mockUserQryRepo.On("GetByUsernameWithRoles", mock.Anything, "testuser").Return(user, nil)
+	mockAuthService.On("VerifyPassword", mock.Anything, "hashed_password", "password123").Return(nil)
+	mockTwofaQryRepo.On("FindByUserID", mock.Anything, uint(1)).Return(nil, nil)
+	mockUserCmdRepo.On("UpdateDeletionSchedule", mock.Anything, uint(1), (*time.Time)(nil), (*time.Time)(nil)).Return(nil)
+	mockAuthService.On("GenerateAccessToken", mock.Anything, uint(1), "testuser").Return("access_token", expiresAt, nil)
+	mockAuthService.On("GenerateRefreshToken", mock.Anything, uint(1)).Return("refresh_token", expiresAt, nil)
+
+	handler := NewLoginHandler(mockUserQryRepo, mockUserCmdRepo, mockCaptchaRepo, mockTwofaQryRepo, nil, nil, mockAuthService, authInfra.NewLoginSessionService(), nil, nil, nil)
+
+	result, err := handler.Handle(context.Background(), LoginCommand{
+		Account:   "testuser",
+		Password:  "password123",
+		CaptchaID: "captcha_id",
+		Captcha:   "captcha_code",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.AccountDeletionCancelled)
+	assert.False(t, user.IsDeletionPending())
+	mockUserCmdRepo.AssertExpectations(t)
 }
 
 func TestLoginHandler_Handle_Success_With2FA(t *testing.T) {
@@ -97,7 +147,7 @@ func TestLoginHandler_Handle_Success_With2FA(t *testing.T) {
 	mockAuthService.On("VerifyPassword", mock.Anything, "hashed_password", "password123").Return(nil)
 	mockTwofaQryRepo.On("FindByUserID", mock.Anything, uint(1)).Return(twofa, nil) // 2FA 已启用
 
-	handler := NewLoginHandler(mockUserQryRepo, mockCaptchaRepo, mockTwofaQryRepo, mockAuthService, loginSession, nil)
+	handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, mockTwofaQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, nil)
 
 	// Act
 	result, err := handler.Handle(context.Background(), LoginCommand{
@@ -125,6 +175,54 @@ func TestLoginHandler_Handle_Success_With2FA(t *testing.T) {
 	mockAuthService.AssertExpectations(t)
 }
 
+func TestLoginHandler_Handle_Success_WithOTPChannel(t *testing.T) {
+	// Arrange
+	mockUserQryRepo := new(MockUserQueryRepository)
+	mockCaptchaRepo := new(MockCaptchaCommandRepository)
+	mockTwofaQryRepo := new(MockTwoFAQueryRepository)
+	mockOTPService := new(MockOTPService)
+	mockAuthService := new(MockAuthService)
+	loginSession := authInfra.NewLoginSessionService()
+
+	user := &domainUser.User{
+		ID:       1,
+		Username: "admin",
+		Password: "hashed_password",
+		Status:   "active",
+	}
+
+	mockCaptchaRepo.On("Verify", mock.Anything, "captcha_id", "captcha_code").Return(true, nil)
+	mockUserQryRepo.On("GetByUsernameWithRoles", mock.Anything, "admin").Return(user, nil)
+	mockAuthService.On("VerifyPassword", mock.Anything, "hashed_password", "password123").Return(nil)
+	mockTwofaQryRepo.On("FindByUserID", mock.Anything, uint(1)).Return(nil, nil) // 未启用 TOTP
+	mockOTPService.On("EnabledMethods", mock.Anything, uint(1)).
+		Return([]domainTwoFA.Method{domainTwoFA.MethodEmail, domainTwoFA.MethodSMS}, nil)
+
+	mockRecorder := new(MockLoginRecorder)
+
+	handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, mockTwofaQryRepo, nil, mockOTPService, mockAuthService, loginSession, nil, nil, mockRecorder)
+
+	// Act
+	result, err := handler.Handle(context.Background(), LoginCommand{
+		Account:   "admin",
+		Password:  "password123",
+		CaptchaID: "captcha_id",
+		Captcha:   "captcha_code",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.Requires2FA)
+	assert.NotEmpty(t, result.SessionToken)
+	assert.Equal(t, []string{"email", "sms"}, result.TwoFAMethods)
+	assert.Empty(t, result.AccessToken)
+
+	mockOTPService.AssertExpectations(t)
+	mockAuthService.AssertExpectations(t)
+	// 第二因素验证通过前不记录登录
+	mockRecorder.AssertNotCalled(t, "Record", mock.Anything)
+}
+
 func TestLoginHandler_Handle_LoginByEmail(t *testing.T) {
 	// Arrange
 	mockUserQryRepo := new(MockUserQueryRepository)
@@ -153,7 +251,7 @@ func TestLoginHandler_Handle_LoginByEmail(t *testing.T) {
 	mockAuthService.On("GenerateAccessToken", mock.Anything, uint(1), "testuser").Return("token", expiresAt, nil)
 	mockAuthService.On("GenerateRefreshToken", mock.Anything, uint(1)).Return("refresh", expiresAt, nil)
 
-	handler := NewLoginHandler(mockUserQryRepo, mockCaptchaRepo, mockTwofaQryRepo, mockAuthService, loginSession, nil)
+	handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, mockTwofaQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, nil)
 
 	// Act
 	result, err := handler.Handle(context.Background(), LoginCommand{
@@ -293,7 +391,7 @@ func TestLoginHandler_Handle_Error(t *testing.T) {
 
 			tt.setupMocks(mockUserQryRepo, mockCaptchaRepo, mockTwofaQryRepo, mockAuthService)
 
-			handler := NewLoginHandler(mockUserQryRepo, mockCaptchaRepo, mockTwofaQryRepo, mockAuthService, loginSession, nil)
+			handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, mockTwofaQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, nil)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -324,7 +422,7 @@ func TestLoginHandler_Handle_NilAuditLogHandler(t *testing.T) {
 	// 模拟验证码无效（会触发 logLoginEvent）
 	mockCaptchaRepo.On("Verify", mock.Anything, "id", "wrong").Return(false, nil)
 
-	handler := NewLoginHandler(mockUserQryRepo, mockCaptchaRepo, mockTwofaQryRepo, mockAuthService, loginSession, nil)
+	handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, mockTwofaQryRepo, nil, nil, mockAuthService, loginSession, nil, nil, nil)
 
 	// Act - 不应 panic
 	_, err := handler.Handle(context.Background(), LoginCommand{
@@ -338,3 +436,255 @@ func TestLoginHandler_Handle_NilAuditLogHandler(t *testing.T) {
 	require.Error(t, err)
 	assert.ErrorIs(t, err, domainAuth.ErrInvalidCaptcha)
 }
+
+func TestLoginHandler_Handle_PasswordChangeRequired(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       *domainUser.User
+		maxAgeDays string
+		wantReason string
+	}{
+		{
+			name: "管理员重置后首次登录",
+			user: &domainUser.User{
+				ID: 1, Username: "testuser", Password: "hashed_password", Status: "active",
+				CreatedAt: time.Now(), MustChangePassword: true,
+			},
+			maxAgeDays: "0",
+			wantReason: domainUser.PasswordChangeReasonAdminReset,
+		},
+		{
+			name: "密码超过有效期",
+			user: &domainUser.User{
+				ID: 1, Username: "testuser", Password: "hashed_password", Status: "active",
+				CreatedAt: time.Now().Add(-60 * 24 * time.Hour),
+			},
+			maxAgeDays: "30",
+			wantReason: domainUser.PasswordChangeReasonExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserQryRepo := new(MockUserQueryRepository)
+			mockCaptchaRepo := new(MockCaptchaCommandRepository)
+			mockTwofaQryRepo := new(MockTwoFAQueryRepository)
+			mockSettingQryRepo := new(MockSettingQueryRepository)
+			mockAuthService := new(MockAuthService)
+			loginSession := authInfra.NewLoginSessionService()
+
+			mockCaptchaRepo.On("Verify", mock.Anything, "captcha_id", "captcha_code").Return(true, nil)
+			mockUserQryRepo.On("GetByUsernameWithRoles", mock.Anything, "testuser").Return(tt.user, nil)
+			mockAuthService.On("VerifyPassword", mock.Anything, "hashed_password", "password123").Return(nil)
+			mockTwofaQryRepo.On("FindByUserID", mock.Anything, uint(1)).Return(nil, nil)
+			mockSettingQryRepo.On("FindByKey", mock.Anything, domainSetting.KeyPasswordMaxAgeDays).Return(&domainSetting.Setting{
+				Key: domainSetting.KeyPasswordMaxAgeDays, Value: tt.maxAgeDays, ValueType: domainSetting.ValueTypeNumber,
+			}, nil)
+			mockAuthService.On("GenerateScopedAccessToken", mock.Anything, uint(1), "testuser", domainAuth.TokenScopePasswordChange).
+				Return("restricted_token", time.Now().Add(time.Hour), nil)
+
+			handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, mockTwofaQryRepo, mockSettingQryRepo, nil, mockAuthService, loginSession, nil, nil, nil)
+
+			result, err := handler.Handle(context.Background(), LoginCommand{
+				Account:   "testuser",
+				Password:  "password123",
+				CaptchaID: "captcha_id",
+				Captcha:   "captcha_code",
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.PasswordChangeRequired)
+			assert.Equal(t, tt.wantReason, result.PasswordChangeReason)
+			assert.Equal(t, "restricted_token", result.AccessToken)
+			assert.Empty(t, result.RefreshToken, "受限登录不签发刷新令牌")
+			mockAuthService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// newTestDirectoryChain 创建身份提供者链（默认 local → ldap），目录中包含 alice（developers、admins 组）
+func newTestDirectoryChain(userCmdRepo *MockUserCommandRepository, userQryRepo *MockUserQueryRepository, assignQryRepo *MockRoleAssignmentQueryRepository, roleQryRepo *MockRoleQueryRepository, authService *MockAuthService, order ...string) *IdentityProviderChain {
+	if len(order) == 0 {
+		order = []string{domainAuth.ProviderLocal, ldapInfra.ProviderName}
+	}
+
+	dir := ldapInfra.NewFakeDirectory()
+	dir.AddEntry("uid=alice,ou=people,dc=example,dc=com", "alice-pass", map[string][]string{
+		"uid":      {"alice"},
+		"mail":     {"alice@example.com"},
+		"cn":       {"Alice"},
+		"memberOf": {"cn=developers,ou=groups,dc=example,dc=com", "cn=admins,ou=groups,dc=example,dc=com"},
+	})
+	provider := ldapInfra.NewProvider(ldapInfra.Config{
+		BaseDN:     "ou=people,dc=example,dc=com",
+		GroupRoles: map[string]string{"developers": "developer", "admins": "admin"},
+	}, dir.Dialer())
+
+	return NewIdentityProviderChain(
+		order,
+		[]domainAuth.IdentityProvider{provider},
+		userCmdRepo, userQryRepo, assignQryRepo, roleQryRepo, authService, nil,
+	)
+}
+
+func TestLoginHandler_Handle_DirectoryUser(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	login := LoginCommand{Account: "alice", Password: "alice-pass", CaptchaID: "captcha_id", Captcha: "captcha_code"}
+
+	t.Run("首次登录即时创建用户并映射角色", func(t *testing.T) {
+		mockUserCmdRepo := new(MockUserCommandRepository)
+		mockUserQryRepo := new(MockUserQueryRepository)
+		mockAssignQryRepo := new(MockRoleAssignmentQueryRepository)
+		mockRoleQryRepo := new(MockRoleQueryRepository)
+		mockCaptchaRepo := new(MockCaptchaCommandRepository)
+		mockTwofaQryRepo := new(MockTwoFAQueryRepository)
+		mockAuthService := new(MockAuthService)
+
+		provisioned := &domainUser.User{ID: 10, Username: "alice", Email: "alice@example.com", Status: "active", AuthSource: domainUser.AuthSourceLDAP}
+
+		mockCaptchaRepo.On("Verify", mock.Anything, "captcha_id", "captcha_code").Return(true, nil)
+		mockUserQryRepo.On("GetByUsernameWithRoles", mock.Anything, "alice").Return(nil, domainUser.ErrUserNotFound)
+		mockUserQryRepo.On("GetByEmailWithRoles", mock.Anything, "alice").Return(nil, domainUser.ErrUserNotFound)
+		mockUserQryRepo.On("ExistsByEmail", mock.Anything, "alice@example.com").Return(false, nil)
+		mockAuthService.On("GeneratePasswordHash", mock.Anything, mock.Anything).Return("random_hash", nil)
+		mockUserCmdRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domainUser.User) bool {
+			return u.Username == "alice" && u.AuthSource == domainUser.AuthSourceLDAP &&
+				u.ExternalID == "uid=alice,ou=people,dc=example,dc=com" && u.Status == "active"
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domainUser.User).ID = 10
+		}).Return(nil)
+		mockAssignQryRepo.On("ListByUser", mock.Anything, uint(10)).Return([]*domainUser.RoleAssignment{}, nil)
+		mockRoleQryRepo.On("FindByName", mock.Anything, "developer").Return(&domainRole.Role{ID: 2, Name: "developer"}, nil)
+		mockRoleQryRepo.On("FindByName", mock.Anything, "admin").Return(&domainRole.Role{ID: 1, Name: "admin"}, nil)
+		mockRoleQryRepo.On("GetEffectivePermissions", mock.Anything, mock.Anything).Return([]domainRole.Permission{}, nil)
+		// 特权角色 admin 不能经目录组映射授予
+		mockUserCmdRepo.On("AssignRoles", mock.Anything, uint(10), []uint{2}).Return(nil)
+		mockUserQryRepo.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(provisioned, nil)
+		mockTwofaQryRepo.On("FindByUserID", mock.Anything, uint(10)).Return(nil, nil)
+		mockAuthService.On("GenerateAccessToken", mock.Anything, uint(10), "alice").Return("access_token", expiresAt, nil)
+		mockAuthService.On("GenerateRefreshToken", mock.Anything, uint(10)).Return("refresh_token", expiresAt, nil)
+
+		chain := newTestDirectoryChain(mockUserCmdRepo, mockUserQryRepo, mockAssignQryRepo, mockRoleQryRepo, mockAuthService)
+		handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, mockTwofaQryRepo, nil, nil, mockAuthService, authInfra.NewLoginSessionService(), chain, nil, nil)
+
+		result, err := handler.Handle(context.Background(), login)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(10), result.UserID)
+		assert.Equal(t, "access_token", result.AccessToken)
+		mockUserCmdRepo.AssertExpectations(t)
+		mockAuthService.AssertNotCalled(t, "VerifyPassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("已存在的目录用户每次登录同步资料", func(t *testing.T) {
+		mockUserCmdRepo := new(MockUserCommandRepository)
+		mockUserQryRepo := new(MockUserQueryRepository)
+		mockAssignQryRepo := new(MockRoleAssignmentQueryRepository)
+		mockRoleQryRepo := new(MockRoleQueryRepository)
+		mockCaptchaRepo := new(MockCaptchaCommandRepository)
+		mockTwofaQryRepo := new(MockTwoFAQueryRepository)
+		mockAuthService := new(MockAuthService)
+
+		existing := &domainUser.User{
+			ID: 10, Username: "alice", Email: "old@example.com", Status: "active",
+			AuthSource: domainUser.AuthSourceLDAP, Roles: []domainRole.Role{{ID: 2, Name: "developer"}},
+		}
+
+		mockCaptchaRepo.On("Verify", mock.Anything, "captcha_id", "captcha_code").Return(true, nil)
+		mockUserQryRepo.On("GetByUsernameWithRoles", mock.Anything, "alice").Return(existing, nil)
+		mockUserCmdRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domainUser.User) bool {
+			return u.Email == "alice@example.com" && u.FullName == "Alice"
+		})).Return(nil)
+		mockAssignQryRepo.On("ListByUser", mock.Anything, uint(10)).Return([]*domainUser.RoleAssignment{{UserID: 10, RoleID: 2}}, nil)
+		mockRoleQryRepo.On("FindByID", mock.Anything, uint(2)).Return(&domainRole.Role{ID: 2, Name: "developer"}, nil)
+		mockRoleQryRepo.On("FindByName", mock.Anything, "developer").Return(&domainRole.Role{ID: 2, Name: "developer"}, nil)
+		mockRoleQryRepo.On("FindByName", mock.Anything, "admin").Return(&domainRole.Role{ID: 1, Name: "admin"}, nil)
+		mockRoleQryRepo.On("GetEffectivePermissions", mock.Anything, mock.Anything).Return([]domainRole.Permission{}, nil)
+		mockUserQryRepo.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(existing, nil)
+		mockTwofaQryRepo.On("FindByUserID", mock.Anything, uint(10)).Return(nil, nil)
+		mockAuthService.On("GenerateAccessToken", mock.Anything, uint(10), "alice").Return("access_token", expiresAt, nil)
+		mockAuthService.On("GenerateRefreshToken", mock.Anything, uint(10)).Return("refresh_token", expiresAt, nil)
+
+		chain := newTestDirectoryChain(mockUserCmdRepo, mockUserQryRepo, mockAssignQryRepo, mockRoleQryRepo, mockAuthService)
+		handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, mockTwofaQryRepo, nil, nil, mockAuthService, authInfra.NewLoginSessionService(), chain, nil, nil)
+
+		_, err := handler.Handle(context.Background(), login)
+
+		require.NoError(t, err)
+		mockUserCmdRepo.AssertExpectations(t)
+		mockUserCmdRepo.AssertNotCalled(t, "AssignRoles", mock.Anything, mock.Anything, mock.Anything)
+		mockAuthService.AssertNotCalled(t, "VerifyPassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("目录密码错误", func(t *testing.T) {
+		mockUserQryRepo := new(MockUserQueryRepository)
+		mockCaptchaRepo := new(MockCaptchaCommandRepository)
+		mockAuthService := new(MockAuthService)
+
+		mockCaptchaRepo.On("Verify", mock.Anything, "captcha_id", "captcha_code").Return(true, nil)
+		mockUserQryRepo.On("GetByUsernameWithRoles", mock.Anything, "alice").Return(nil, domainUser.ErrUserNotFound)
+		mockUserQryRepo.On("GetByEmailWithRoles", mock.Anything, "alice").Return(nil, domainUser.ErrUserNotFound)
+
+		chain := newTestDirectoryChain(new(MockUserCommandRepository), mockUserQryRepo, new(MockRoleAssignmentQueryRepository), new(MockRoleQueryRepository), mockAuthService)
+		handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, new(MockTwoFAQueryRepository), nil, nil, mockAuthService, authInfra.NewLoginSessionService(), chain, nil, nil)
+
+		wrong := login
+		wrong.Password = "wrong"
+		_, err := handler.Handle(context.Background(), wrong)
+
+		require.ErrorIs(t, err, domainAuth.ErrInvalidCredentials)
+	})
+
+	t.Run("同名本地账号不会被目录身份接管", func(t *testing.T) {
+		mockUserQryRepo := new(MockUserQueryRepository)
+		mockCaptchaRepo := new(MockCaptchaCommandRepository)
+		mockAuthService := new(MockAuthService)
+		mockUserCmdRepo := new(MockUserCommandRepository)
+
+		local := &domainUser.User{ID: 3, Username: "alice", Password: "hashed_password", Status: "active", AuthSource: domainUser.AuthSourceLocal}
+
+		mockCaptchaRepo.On("Verify", mock.Anything, "captcha_id", "captcha_code").Return(true, nil)
+		mockUserQryRepo.On("GetByUsernameWithRoles", mock.Anything, "alice").Return(local, nil)
+		mockAuthService.On("VerifyPassword", mock.Anything, "hashed_password", "alice-pass").Return(errors.New("mismatch"))
+
+		chain := newTestDirectoryChain(mockUserCmdRepo, mockUserQryRepo, new(MockRoleAssignmentQueryRepository), new(MockRoleQueryRepository), mockAuthService,
+			ldapInfra.ProviderName, domainAuth.ProviderLocal)
+		handler := NewLoginHandler(mockUserQryRepo, nil, mockCaptchaRepo, new(MockTwoFAQueryRepository), nil, nil, mockAuthService, authInfra.NewLoginSessionService(), chain, nil, nil)
+
+		_, err := handler.Handle(context.Background(), login)
+
+		require.ErrorIs(t, err, domainAuth.ErrInvalidCredentials)
+		mockUserCmdRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestIdentityProviderChain_SyncRoles_PreservesNonDirectoryAssignments(t *testing.T) {
+	// Arrange: 长期持有 admin（特权）与 legacy，临时持有 oncall；目录映射 developer、admin、oncall
+	mockUserCmdRepo := new(MockUserCommandRepository)
+	mockAssignQryRepo := new(MockRoleAssignmentQueryRepository)
+	mockRoleQryRepo := new(MockRoleQueryRepository)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	mockAssignQryRepo.On("ListByUser", mock.Anything, uint(10)).Return([]*domainUser.RoleAssignment{
+		{UserID: 10, RoleID: 1},
+		{UserID: 10, RoleID: 3, ExpiresAt: &expiresAt},
+		{UserID: 10, RoleID: 4},
+	}, nil)
+	mockRoleQryRepo.On("FindByID", mock.Anything, uint(1)).Return(&domainRole.Role{ID: 1, Name: "admin"}, nil)
+	mockRoleQryRepo.On("FindByID", mock.Anything, uint(4)).Return(&domainRole.Role{ID: 4, Name: "legacy"}, nil)
+	mockRoleQryRepo.On("FindByName", mock.Anything, "developer").Return(&domainRole.Role{ID: 2, Name: "developer"}, nil)
+	mockRoleQryRepo.On("FindByName", mock.Anything, "admin").Return(&domainRole.Role{ID: 1, Name: "admin"}, nil)
+	mockRoleQryRepo.On("FindByName", mock.Anything, "oncall").Return(&domainRole.Role{ID: 3, Name: "oncall"}, nil)
+	mockRoleQryRepo.On("GetEffectivePermissions", mock.Anything, mock.Anything).Return([]domainRole.Permission{}, nil)
+	// 保留特权角色 admin，新增 developer，移除 legacy；临时授权 oncall 不参与替换
+	mockUserCmdRepo.On("AssignRoles", mock.Anything, uint(10), []uint{1, 2}).Return(nil)
+
+	chain := NewIdentityProviderChain(nil, nil, mockUserCmdRepo, nil, mockAssignQryRepo, mockRoleQryRepo, nil, nil)
+
+	// Act
+	err := chain.syncRoles(context.Background(), &domainUser.User{ID: 10}, []string{"developer", "admin", "oncall"})
+
+	// Assert
+	require.NoError(t, err)
+	mockUserCmdRepo.AssertExpectations(t)
+}
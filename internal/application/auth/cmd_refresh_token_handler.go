@@ -5,30 +5,34 @@ import (
 	"fmt"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
 // RefreshTokenHandler 刷新令牌命令处理器
 type RefreshTokenHandler struct {
-	userQueryRepo user.QueryRepository
-	authService   auth.Service
+	userQueryRepo    user.QueryRepository
+	settingQueryRepo setting.QueryRepository
+	authService      auth.Service
 }
 
 // NewRefreshTokenHandler 创建刷新令牌命令处理器
 func NewRefreshTokenHandler(
 	userQueryRepo user.QueryRepository,
+	settingQueryRepo setting.QueryRepository,
 	authService auth.Service,
 ) *RefreshTokenHandler {
 	return &RefreshTokenHandler{
-		userQueryRepo: userQueryRepo,
-		authService:   authService,
+		userQueryRepo:    userQueryRepo,
+		settingQueryRepo: settingQueryRepo,
+		authService:      authService,
 	}
 }
 
 // Handle 处理刷新令牌命令
 func (h *RefreshTokenHandler) Handle(ctx context.Context, cmd RefreshTokenCommand) (*RefreshTokenResultDTO, error) {
 	// 1. 验证 refresh token
-	userID, err := h.authService.ValidateRefreshToken(ctx, cmd.RefreshToken)
+	userID, issuedAt, err := h.authService.ValidateRefreshToken(ctx, cmd.RefreshToken)
 	if err != nil {
 		return nil, err
 	}
@@ -46,14 +50,22 @@ func (h *RefreshTokenHandler) Handle(ctx context.Context, cmd RefreshTokenComman
 		}
 		return nil, auth.ErrUserInactive
 	}
+	if u.IsSessionRevoked(issuedAt) {
+		return nil, auth.ErrTokenRevoked
+	}
+
+	// 4. 必须修改密码时拒绝刷新，需重新登录获取受限令牌
+	if passwordChangeReason(ctx, h.settingQueryRepo, u) != "" {
+		return nil, auth.ErrPasswordChangeRequired
+	}
 
-	// 4. 生成新的访问令牌（新架构：不传递 roles，权限从缓存查询）
+	// 5. 生成新的访问令牌（新架构：不传递 roles，权限从缓存查询）
 	accessToken, expiresAt, err := h.authService.GenerateAccessToken(ctx, u.ID, u.Username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	// 5. 生成新的刷新令牌
+	// 6. 生成新的刷新令牌
 	newRefreshToken, _, err := h.authService.GenerateRefreshToken(ctx, u.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
@@ -29,12 +29,12 @@ func TestRefreshTokenHandler_Handle_Success(t *testing.T) {
 		Status:   "active",
 	}
 
-	mockAuthService.On("ValidateRefreshToken", mock.Anything, "valid_refresh_token").Return(uint(1), nil)
+	mockAuthService.On("ValidateRefreshToken", mock.Anything, "valid_refresh_token").Return(uint(1), time.Now(), nil)
 	mockUserQryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(user, nil)
 	mockAuthService.On("GenerateAccessToken", mock.Anything, uint(1), "testuser").Return("new_access_token", expiresAt, nil)
 	mockAuthService.On("GenerateRefreshToken", mock.Anything, uint(1)).Return("new_refresh_token", refreshExpiresAt, nil)
 
-	handler := NewRefreshTokenHandler(mockUserQryRepo, mockAuthService)
+	handler := NewRefreshTokenHandler(mockUserQryRepo, nil, mockAuthService)
 
 	// Act
 	result, err := handler.Handle(context.Background(), RefreshTokenCommand{
@@ -63,7 +63,7 @@ func TestRefreshTokenHandler_Handle_Error(t *testing.T) {
 			name: "无效的刷新令牌",
 			cmd:  RefreshTokenCommand{RefreshToken: "invalid_token"},
 			setupMocks: func(qryRepo *MockUserQueryRepository, authService *MockAuthService) {
-				authService.On("ValidateRefreshToken", mock.Anything, "invalid_token").Return(uint(0), errors.New("invalid token"))
+				authService.On("ValidateRefreshToken", mock.Anything, "invalid_token").Return(uint(0), time.Now(), errors.New("invalid token"))
 			},
 			wantErr: "invalid token",
 		},
@@ -71,7 +71,7 @@ func TestRefreshTokenHandler_Handle_Error(t *testing.T) {
 			name: "用户不存在",
 			cmd:  RefreshTokenCommand{RefreshToken: "valid_token"},
 			setupMocks: func(qryRepo *MockUserQueryRepository, authService *MockAuthService) {
-				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(999), nil)
+				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(999), time.Now(), nil)
 				qryRepo.On("GetByIDWithRoles", mock.Anything, uint(999)).Return(nil, errors.New("user not found"))
 			},
 			wantErr: domainAuth.ErrUserNotFound.Error(),
@@ -80,7 +80,7 @@ func TestRefreshTokenHandler_Handle_Error(t *testing.T) {
 			name: "用户已被禁用",
 			cmd:  RefreshTokenCommand{RefreshToken: "valid_token"},
 			setupMocks: func(qryRepo *MockUserQueryRepository, authService *MockAuthService) {
-				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(1), nil)
+				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(1), time.Now(), nil)
 				qryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(&domainUser.User{
 					ID:       1,
 					Username: "banned",
@@ -93,7 +93,7 @@ func TestRefreshTokenHandler_Handle_Error(t *testing.T) {
 			name: "用户未激活",
 			cmd:  RefreshTokenCommand{RefreshToken: "valid_token"},
 			setupMocks: func(qryRepo *MockUserQueryRepository, authService *MockAuthService) {
-				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(1), nil)
+				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(1), time.Now(), nil)
 				qryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(&domainUser.User{
 					ID:       1,
 					Username: "inactive",
@@ -102,11 +102,26 @@ func TestRefreshTokenHandler_Handle_Error(t *testing.T) {
 			},
 			wantErr: domainAuth.ErrUserInactive.Error(),
 		},
+		{
+			name: "令牌在会话吊销之前签发",
+			cmd:  RefreshTokenCommand{RefreshToken: "valid_token"},
+			setupMocks: func(qryRepo *MockUserQueryRepository, authService *MockAuthService) {
+				revokedAt := time.Now().Truncate(time.Second)
+				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(1), revokedAt.Add(-time.Hour), nil)
+				qryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(&domainUser.User{
+					ID:                1,
+					Username:          "unbanned",
+					Status:            "active",
+					SessionsRevokedAt: &revokedAt,
+				}, nil)
+			},
+			wantErr: domainAuth.ErrTokenRevoked.Error(),
+		},
 		{
 			name: "生成访问令牌失败",
 			cmd:  RefreshTokenCommand{RefreshToken: "valid_token"},
 			setupMocks: func(qryRepo *MockUserQueryRepository, authService *MockAuthService) {
-				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(1), nil)
+				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(1), time.Now(), nil)
 				qryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(&domainUser.User{
 					ID:       1,
 					Username: "testuser",
@@ -120,7 +135,7 @@ func TestRefreshTokenHandler_Handle_Error(t *testing.T) {
 			name: "生成刷新令牌失败",
 			cmd:  RefreshTokenCommand{RefreshToken: "valid_token"},
 			setupMocks: func(qryRepo *MockUserQueryRepository, authService *MockAuthService) {
-				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(1), nil)
+				authService.On("ValidateRefreshToken", mock.Anything, "valid_token").Return(uint(1), time.Now(), nil)
 				qryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(&domainUser.User{
 					ID:       1,
 					Username: "testuser",
@@ -140,7 +155,7 @@ func TestRefreshTokenHandler_Handle_Error(t *testing.T) {
 			mockAuthService := new(MockAuthService)
 			tt.setupMocks(mockUserQryRepo, mockAuthService)
 
-			handler := NewRefreshTokenHandler(mockUserQryRepo, mockAuthService)
+			handler := NewRefreshTokenHandler(mockUserQryRepo, nil, mockAuthService)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -152,3 +167,21 @@ func TestRefreshTokenHandler_Handle_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshTokenHandler_Handle_PasswordChangeRequired(t *testing.T) {
+	mockUserQryRepo := new(MockUserQueryRepository)
+	mockAuthService := new(MockAuthService)
+
+	mockAuthService.On("ValidateRefreshToken", mock.Anything, "valid_refresh_token").Return(uint(1), time.Now(), nil)
+	mockUserQryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(&domainUser.User{
+		ID: 1, Username: "testuser", Status: "active", MustChangePassword: true,
+	}, nil)
+
+	handler := NewRefreshTokenHandler(mockUserQryRepo, nil, mockAuthService)
+
+	result, err := handler.Handle(context.Background(), RefreshTokenCommand{RefreshToken: "valid_refresh_token"})
+
+	require.ErrorIs(t, err, domainAuth.ErrPasswordChangeRequired)
+	assert.Nil(t, result)
+	mockAuthService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -5,37 +5,47 @@ import (
 	"fmt"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
 // RegisterHandler 注册命令处理器
 type RegisterHandler struct {
-	userCommandRepo user.CommandRepository
-	userQueryRepo   user.QueryRepository
-	authService     auth.Service
+	userCommandRepo  user.CommandRepository
+	userQueryRepo    user.QueryRepository
+	settingQueryRepo setting.QueryRepository
+	authService      auth.Service
 }
 
 // NewRegisterHandler 创建注册命令处理器
 func NewRegisterHandler(
 	userCommandRepo user.CommandRepository,
 	userQueryRepo user.QueryRepository,
+	settingQueryRepo setting.QueryRepository,
 	authService auth.Service,
 ) *RegisterHandler {
 	return &RegisterHandler{
-		userCommandRepo: userCommandRepo,
-		userQueryRepo:   userQueryRepo,
-		authService:     authService,
+		userCommandRepo:  userCommandRepo,
+		userQueryRepo:    userQueryRepo,
+		settingQueryRepo: settingQueryRepo,
+		authService:      authService,
 	}
 }
 
 // Handle 处理注册命令
 func (h *RegisterHandler) Handle(ctx context.Context, cmd RegisterCommand) (*RegisterResultDTO, error) {
-	// 1. 验证密码策略
+	// 1. 检查注册策略（注册模式 + 邮箱域名白名单）
+	policy := registrationPolicy(ctx, h.settingQueryRepo)
+	if err := policy.Check(cmd.Email); err != nil {
+		return nil, err
+	}
+
+	// 2. 验证密码策略
 	if err := h.authService.ValidatePasswordPolicy(ctx, cmd.Password); err != nil {
 		return nil, err
 	}
 
-	// 2. 检查用户名是否已存在
+	// 3. 检查用户名是否已存在
 	exists, err := h.userQueryRepo.ExistsByUsername(ctx, cmd.Username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check username existence: %w", err)
@@ -44,7 +54,7 @@ func (h *RegisterHandler) Handle(ctx context.Context, cmd RegisterCommand) (*Reg
 		return nil, user.ErrUsernameAlreadyExists
 	}
 
-	// 3. 检查邮箱是否已存在
+	// 4. 检查邮箱是否已存在
 	exists, err = h.userQueryRepo.ExistsByEmail(ctx, cmd.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check email existence: %w", err)
@@ -53,13 +63,13 @@ func (h *RegisterHandler) Handle(ctx context.Context, cmd RegisterCommand) (*Reg
 		return nil, user.ErrEmailAlreadyExists
 	}
 
-	// 4. 生成密码哈希
+	// 5. 生成密码哈希
 	hashedPassword, err := h.authService.GeneratePasswordHash(ctx, cmd.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	// 5. 创建用户
+	// 6. 创建用户（审批模式下为 inactive，待管理员审批）
 	newUser := &user.User{
 		Username: cmd.Username,
 		Email:    cmd.Email,
@@ -67,12 +77,24 @@ func (h *RegisterHandler) Handle(ctx context.Context, cmd RegisterCommand) (*Reg
 		FullName: cmd.FullName,
 		Status:   "active",
 	}
+	if policy.RequiresApproval() {
+		newUser.Deactivate()
+	}
 
 	if err = h.userCommandRepo.Create(ctx, newUser); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// 6. 生成访问令牌（新架构：不传递 roles，权限从缓存查询）
+	if policy.RequiresApproval() {
+		return &RegisterResultDTO{
+			UserID:          newUser.ID,
+			Username:        newUser.Username,
+			Email:           newUser.Email,
+			PendingApproval: true,
+		}, nil
+	}
+
+	// 7. 生成访问令牌（新架构：不传递 roles，权限从缓存查询）
 	accessToken, expiresAt, err := h.authService.GenerateAccessToken(ctx, newUser.ID, newUser.Username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
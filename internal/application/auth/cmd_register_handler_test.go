@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	domainAuth "github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	domainSetting "github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
@@ -54,7 +56,7 @@ func TestRegisterHandler_Handle_Success(t *testing.T) {
 			mockAuthService.On("GenerateAccessToken", mock.Anything, uint(1), tt.cmd.Username).Return("access_token", expiresAt, nil)
 			mockAuthService.On("GenerateRefreshToken", mock.Anything, uint(1)).Return("refresh_token", expiresAt.Add(7*24*time.Hour), nil)
 
-			handler := NewRegisterHandler(mockUserCmdRepo, mockUserQryRepo, mockAuthService)
+			handler := NewRegisterHandler(mockUserCmdRepo, mockUserQryRepo, nil, mockAuthService)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -189,7 +191,7 @@ func TestRegisterHandler_Handle_Error(t *testing.T) {
 			mockAuthService := new(MockAuthService)
 			tt.setupMocks(mockUserCmdRepo, mockUserQryRepo, mockAuthService)
 
-			handler := NewRegisterHandler(mockUserCmdRepo, mockUserQryRepo, mockAuthService)
+			handler := NewRegisterHandler(mockUserCmdRepo, mockUserQryRepo, nil, mockAuthService)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -201,3 +203,78 @@ func TestRegisterHandler_Handle_Error(t *testing.T) {
 		})
 	}
 }
+
+// registrationSettings 构造注册相关配置项
+func registrationSettings(mode, domains string) []*domainSetting.Setting {
+	return []*domainSetting.Setting{
+		{Key: domainSetting.KeyRegistrationMode, Value: mode, ValueType: domainSetting.ValueTypeString},
+		{Key: domainSetting.KeyRegistrationEmailDomains, Value: domains, ValueType: domainSetting.ValueTypeJSON},
+	}
+}
+
+func TestRegisterHandler_Handle_RegistrationPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		domains string
+		email   string
+		wantErr error
+	}{
+		{"注册已关闭", "closed", "[]", "user@example.com", domainAuth.ErrRegistrationClosed},
+		{"仅限邀请", "invite_only", "[]", "user@example.com", domainAuth.ErrInvitationRequired},
+		{"邮箱域名不在白名单", "open", `["corp.com"]`, "user@example.com", domainAuth.ErrEmailDomainNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserCmdRepo := new(MockUserCommandRepository)
+			mockUserQryRepo := new(MockUserQueryRepository)
+			mockSettingQryRepo := new(MockSettingQueryRepository)
+			mockAuthService := new(MockAuthService)
+
+			mockSettingQryRepo.On("FindByKeys", mock.Anything, mock.Anything).Return(registrationSettings(tt.mode, tt.domains), nil)
+
+			handler := NewRegisterHandler(mockUserCmdRepo, mockUserQryRepo, mockSettingQryRepo, mockAuthService)
+
+			result, err := handler.Handle(context.Background(), RegisterCommand{
+				Username: "user",
+				Email:    tt.email,
+				Password: "ValidPass123",
+			})
+
+			assert.Nil(t, result)
+			require.ErrorIs(t, err, tt.wantErr)
+			mockUserCmdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRegisterHandler_Handle_ApprovalRequired(t *testing.T) {
+	mockUserCmdRepo := new(MockUserCommandRepository)
+	mockUserQryRepo := new(MockUserQueryRepository)
+	mockSettingQryRepo := new(MockSettingQueryRepository)
+	mockAuthService := new(MockAuthService)
+
+	mockSettingQryRepo.On("FindByKeys", mock.Anything, mock.Anything).Return(registrationSettings("approval", `["example.com"]`), nil)
+	mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "ValidPass123").Return(nil)
+	mockUserQryRepo.On("ExistsByUsername", mock.Anything, "user").Return(false, nil)
+	mockUserQryRepo.On("ExistsByEmail", mock.Anything, "user@example.com").Return(false, nil)
+	mockAuthService.On("GeneratePasswordHash", mock.Anything, "ValidPass123").Return("hashed", nil)
+	mockUserCmdRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domainUser.User) bool {
+		return u.Status == "inactive"
+	})).Return(nil)
+
+	handler := NewRegisterHandler(mockUserCmdRepo, mockUserQryRepo, mockSettingQryRepo, mockAuthService)
+
+	result, err := handler.Handle(context.Background(), RegisterCommand{
+		Username: "user",
+		Email:    "user@example.com",
+		Password: "ValidPass123",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.PendingApproval)
+	assert.Empty(t, result.AccessToken, "待审批用户不签发令牌")
+	mockUserCmdRepo.AssertExpectations(t)
+	mockAuthService.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,7 @@
+package auth
+
+// Send2FACodeCommand 下发二次认证验证码命令（邮件/短信）
+type Send2FACodeCommand struct {
+	SessionToken string
+	Method       string // email / sms
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+	authInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
+)
+
+// Send2FACodeHandler 下发二次认证验证码命令处理器
+// 在密码校验通过后、提交二次认证前，向用户所选的邮件/短信通道发送验证码
+type Send2FACodeHandler struct {
+	loginSession *authInfra.LoginSessionService
+	otpService   twofa.OTPService
+}
+
+// NewSend2FACodeHandler 创建下发二次认证验证码命令处理器
+func NewSend2FACodeHandler(loginSession *authInfra.LoginSessionService, otpService twofa.OTPService) *Send2FACodeHandler {
+	return &Send2FACodeHandler{
+		loginSession: loginSession,
+		otpService:   otpService,
+	}
+}
+
+// Handle 处理下发二次认证验证码命令
+// session token 不会被消费，仍用于随后的二次认证登录
+func (h *Send2FACodeHandler) Handle(ctx context.Context, cmd Send2FACodeCommand) (*Send2FACodeResultDTO, error) {
+	sessionData, err := h.loginSession.PeekSessionToken(ctx, cmd.SessionToken)
+	if err != nil {
+		return nil, errors.New("session expired or invalid, please login again")
+	}
+
+	method, err := twofa.ParseMethod(cmd.Method)
+	if err != nil {
+		return nil, err
+	}
+	if !method.IsOTPChannel() {
+		return nil, twofa.ErrUnsupportedMethod
+	}
+
+	destination, err := h.otpService.SendLoginCode(ctx, sessionData.UserID, method)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Send2FACodeResultDTO{
+		Method:      method.String(),
+		Destination: destination,
+	}, nil
+}
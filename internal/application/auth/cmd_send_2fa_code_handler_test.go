@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+	authInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
+)
+
+func TestSend2FACodeHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("发送成功且不消费 session token", func(t *testing.T) {
+		loginSession := authInfra.NewLoginSessionService()
+		token, err := loginSession.GenerateSessionToken(ctx, 1, "admin")
+		require.NoError(t, err)
+
+		mockOTPService := new(MockOTPService)
+		mockOTPService.On("SendLoginCode", mock.Anything, uint(1), domainTwoFA.MethodEmail).Return("a***@example.com", nil)
+
+		handler := NewSend2FACodeHandler(loginSession, mockOTPService)
+		result, err := handler.Handle(ctx, Send2FACodeCommand{SessionToken: token, Method: "email"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "email", result.Method)
+		assert.Equal(t, "a***@example.com", result.Destination)
+
+		// session token 仍然可用于二次认证
+		_, err = loginSession.VerifySessionToken(ctx, token)
+		require.NoError(t, err)
+		mockOTPService.AssertExpectations(t)
+	})
+
+	t.Run("session token 无效", func(t *testing.T) {
+		mockOTPService := new(MockOTPService)
+		handler := NewSend2FACodeHandler(authInfra.NewLoginSessionService(), mockOTPService)
+
+		_, err := handler.Handle(ctx, Send2FACodeCommand{SessionToken: "invalid", Method: "email"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "session expired or invalid")
+		mockOTPService.AssertNotCalled(t, "SendLoginCode")
+	})
+
+	t.Run("TOTP 无需下发验证码", func(t *testing.T) {
+		loginSession := authInfra.NewLoginSessionService()
+		token, err := loginSession.GenerateSessionToken(ctx, 1, "admin")
+		require.NoError(t, err)
+
+		handler := NewSend2FACodeHandler(loginSession, new(MockOTPService))
+		_, err = handler.Handle(ctx, Send2FACodeCommand{SessionToken: token, Method: "totp"})
+
+		require.ErrorIs(t, err, domainTwoFA.ErrUnsupportedMethod)
+	})
+
+	t.Run("发送过于频繁", func(t *testing.T) {
+		loginSession := authInfra.NewLoginSessionService()
+		token, err := loginSession.GenerateSessionToken(ctx, 1, "admin")
+		require.NoError(t, err)
+
+		mockOTPService := new(MockOTPService)
+		mockOTPService.On("SendLoginCode", mock.Anything, uint(1), domainTwoFA.MethodSMS).Return("", domainTwoFA.ErrOTPThrottled)
+
+		handler := NewSend2FACodeHandler(loginSession, mockOTPService)
+		_, err = handler.Handle(ctx, Send2FACodeCommand{SessionToken: token, Method: "sms"})
+
+		require.ErrorIs(t, err, domainTwoFA.ErrOTPThrottled)
+	})
+}
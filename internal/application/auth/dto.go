@@ -1,11 +1,26 @@
 package auth
 
-import "github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+import (
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
 
 // 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
 var (
 	ErrInvalidToken = auth.ErrInvalidToken
 	ErrTokenExpired = auth.ErrTokenExpired
+	ErrTokenRevoked = auth.ErrTokenRevoked
+	ErrOTPThrottled = twofa.ErrOTPThrottled
+
+	ErrPasswordChangeRequired = auth.ErrPasswordChangeRequired
+
+	ErrRegistrationClosed    = auth.ErrRegistrationClosed
+	ErrInvitationRequired    = auth.ErrInvitationRequired
+	ErrEmailDomainNotAllowed = auth.ErrEmailDomainNotAllowed
+	ErrInvitationNotFound    = user.ErrInvitationNotFound
+	ErrInvitationExpired     = user.ErrInvitationExpired
+	ErrInvitationAccepted    = user.ErrInvitationAccepted
 )
 
 // LoginDTO 登录请求
@@ -19,7 +34,14 @@ type LoginDTO struct {
 // Login2FADTO 二次认证请求
 type Login2FADTO struct {
 	SessionToken  string `json:"session_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIs..."` // 登录时返回的临时会话令牌
-	TwoFactorCode string `json:"two_factor_code" binding:"required,len=6" example:"123456"`          // 6位TOTP验证码
+	TwoFactorCode string `json:"two_factor_code" binding:"required,len=6" example:"123456"`          // 6位验证码（TOTP/邮件/短信）
+	Method        string `json:"method" binding:"omitempty,oneof=totp email sms" example:"totp"`     // 验证方式，默认 totp
+}
+
+// Send2FACodeDTO 下发二次认证验证码请求
+type Send2FACodeDTO struct {
+	SessionToken string `json:"session_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIs..."` // 登录时返回的临时会话令牌
+	Method       string `json:"method" binding:"required,oneof=email sms" example:"email"`          // 验证方式
 }
 
 // RegisterDTO 注册请求
@@ -30,6 +52,12 @@ type RegisterDTO struct {
 	FullName string `json:"full_name" binding:"max=100" example:"John Doe"`
 }
 
+// AcceptInvitationDTO 接受邀请请求
+type AcceptInvitationDTO struct {
+	Token    string `json:"token" binding:"required" example:"3f2a..."`              // 邀请邮件中的令牌
+	Password string `json:"password" binding:"required,min=6" example:"password123"` // 新密码
+}
+
 // RefreshTokenDTO 刷新令牌请求
 type RefreshTokenDTO struct {
 	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
@@ -45,14 +73,28 @@ type TokenDTO struct {
 
 // LoginResultDTO 登录结果 DTO（Handler 返回类型）
 type LoginResultDTO struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	TokenType    string `json:"token_type"`
-	ExpiresIn    int    `json:"expires_in"`
-	UserID       uint   `json:"user_id"`
-	Username     string `json:"username"`
-	Requires2FA  bool   `json:"requires_2fa"`
-	SessionToken string `json:"session_token"`
+	AccessToken  string   `json:"access_token"`
+	RefreshToken string   `json:"refresh_token"`
+	TokenType    string   `json:"token_type"`
+	ExpiresIn    int      `json:"expires_in"`
+	UserID       uint     `json:"user_id"`
+	Username     string   `json:"username"`
+	Requires2FA  bool     `json:"requires_2fa"`
+	SessionToken string   `json:"session_token"`
+	TwoFAMethods []string `json:"two_fa_methods,omitempty"` // 可选的二次认证方式
+
+	// 强制修改密码（此时 AccessToken 为仅能修改密码的受限令牌）
+	PasswordChangeRequired bool   `json:"password_change_required"`
+	PasswordChangeReason   string `json:"password_change_reason,omitempty"` // admin_reset / expired
+
+	// 本次登录撤销了宽限期内的账号删除申请
+	AccountDeletionCancelled bool `json:"account_deletion_cancelled,omitempty"`
+}
+
+// Send2FACodeResultDTO 下发二次认证验证码结果 DTO
+type Send2FACodeResultDTO struct {
+	Method      string `json:"method"`      // 验证方式
+	Destination string `json:"destination"` // 脱敏后的投递地址
 }
 
 // RefreshTokenResultDTO 刷新令牌结果 DTO（Handler 返回类型）
@@ -64,14 +106,16 @@ type RefreshTokenResultDTO struct {
 }
 
 // RegisterResultDTO 注册结果 DTO（Handler 返回类型）
+// 审批模式下 PendingApproval 为 true，且不返回令牌
 type RegisterResultDTO struct {
-	UserID       uint   `json:"user_id"`
-	Username     string `json:"username"`
-	Email        string `json:"email"`
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	TokenType    string `json:"token_type"`
-	ExpiresIn    int    `json:"expires_in"`
+	UserID          uint   `json:"user_id"`
+	Username        string `json:"username"`
+	Email           string `json:"email"`
+	AccessToken     string `json:"access_token,omitempty"`
+	RefreshToken    string `json:"refresh_token,omitempty"`
+	TokenType       string `json:"token_type,omitempty"`
+	ExpiresIn       int    `json:"expires_in,omitempty"`
+	PendingApproval bool   `json:"pending_approval"`
 }
 
 // UserBriefDTO 用户简要信息响应 DTO
@@ -82,12 +126,13 @@ type UserBriefDTO struct {
 
 // TwoFARequiredDTO 需要二次认证响应 DTO
 type TwoFARequiredDTO struct {
-	Requires2FA  bool   `json:"requires_2fa"`
-	SessionToken string `json:"session_token"`
+	Requires2FA  bool     `json:"requires_2fa"`
+	SessionToken string   `json:"session_token"`
+	Methods      []string `json:"methods"` // 可选的二次认证方式（totp / email / sms）
 }
 
 // LoginResponseDTO 登录成功 HTTP 响应 DTO（与 HTTP API 响应格式匹配）
-// 支持两种场景：正常登录（返回 token）或需要 2FA（返回 session_token）
+// 支持三种场景：正常登录（返回 token）、需要 2FA（返回 session_token）或必须修改密码（返回受限 token）
 type LoginResponseDTO struct {
 	AccessToken  string       `json:"access_token,omitempty"`
 	RefreshToken string       `json:"refresh_token,omitempty"`
@@ -97,6 +142,9 @@ type LoginResponseDTO struct {
 	// 2FA 相关（当需要 2FA 时返回）
 	Requires2FA  bool   `json:"requires_2fa,omitempty"`
 	SessionToken string `json:"session_token,omitempty"`
+	// 强制修改密码相关（access_token 仅可用于 PUT /api/user/password）
+	PasswordChangeRequired bool   `json:"password_change_required,omitempty"`
+	PasswordChangeReason   string `json:"password_change_reason,omitempty"`
 }
 
 // ToLoginResponse 将 LoginResultDTO 转换为 HTTP 响应格式
@@ -112,5 +160,8 @@ func (r *LoginResultDTO) ToLoginResponse() *LoginResponseDTO {
 		},
 		Requires2FA:  r.Requires2FA,
 		SessionToken: r.SessionToken,
+
+		PasswordChangeRequired: r.PasswordChangeRequired,
+		PasswordChangeReason:   r.PasswordChangeReason,
 	}
 }
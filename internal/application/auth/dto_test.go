@@ -123,11 +123,12 @@ func TestToTwoFARequiredDTO(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := ToTwoFARequiredDTO(tt.sessionToken)
+			result := ToTwoFARequiredDTO(tt.sessionToken, []string{"totp", "email"})
 
 			assert.NotNil(t, result)
 			assert.True(t, result.Requires2FA, "Requires2FA 应始终为 true")
 			assert.Equal(t, tt.sessionToken, result.SessionToken)
+			assert.Equal(t, []string{"totp", "email"}, result.Methods)
 		})
 	}
 }
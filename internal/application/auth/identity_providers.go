@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// errAccountConflict 外部身份与本地账号（或其他身份源的账号）的用户名或邮箱冲突
+var errAccountConflict = errors.New("account belongs to another identity source")
+
+// errIncompleteIdentity 外部身份缺少创建本地账号所需的邮箱
+var errIncompleteIdentity = errors.New("external identity has no email")
+
+// IdentityProviderChain 登录身份提供者链
+//
+// 按配置顺序尝试：auth.ProviderLocal 表示本地密码库，其余名称对应外部身份提供者。
+// 外部身份认证成功后即时创建（JIT）或同步本地账号，并按目录组映射同步角色。
+type IdentityProviderChain struct {
+	order                   []string
+	providers               map[string]auth.IdentityProvider
+	userCommandRepo         user.CommandRepository
+	userQueryRepo           user.QueryRepository
+	roleAssignmentQueryRepo user.RoleAssignmentQueryRepository
+	roleQueryRepo           role.QueryRepository
+	authService             auth.Service
+	eventBus                event.EventBus
+}
+
+// NewIdentityProviderChain 创建登录身份提供者链
+// order 中未注册的外部提供者会被忽略；order 为空时仅使用本地密码库
+func NewIdentityProviderChain(
+	order []string,
+	providers []auth.IdentityProvider,
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	roleAssignmentQueryRepo user.RoleAssignmentQueryRepository,
+	roleQueryRepo role.QueryRepository,
+	authService auth.Service,
+	eventBus event.EventBus,
+) *IdentityProviderChain {
+	c := &IdentityProviderChain{
+		providers:               make(map[string]auth.IdentityProvider, len(providers)),
+		userCommandRepo:         userCommandRepo,
+		userQueryRepo:           userQueryRepo,
+		roleAssignmentQueryRepo: roleAssignmentQueryRepo,
+		roleQueryRepo:           roleQueryRepo,
+		authService:             authService,
+		eventBus:                eventBus,
+	}
+	for _, p := range providers {
+		c.providers[p.Name()] = p
+	}
+	for _, name := range order {
+		if (name == auth.ProviderLocal || c.providers[name] != nil) && !slices.Contains(c.order, name) {
+			c.order = append(c.order, name)
+		}
+	}
+	if len(c.order) == 0 {
+		c.order = []string{auth.ProviderLocal}
+	}
+	return c
+}
+
+// Order 返回生效的提供者顺序
+func (c *IdentityProviderChain) Order() []string {
+	return c.order
+}
+
+// Authenticate 使用指定外部提供者认证，并即时创建或同步本地账号
+// 凭证无效或账号归属冲突时返回 auth.ErrInvalidCredentials
+func (c *IdentityProviderChain) Authenticate(ctx context.Context, providerName, username, password string) (*user.User, error) {
+	provider, ok := c.providers[providerName]
+	if !ok {
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	identity, err := provider.Authenticate(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := c.provision(ctx, identity)
+	if errors.Is(err, errAccountConflict) || errors.Is(err, errIncompleteIdentity) {
+		return nil, auth.ErrInvalidCredentials
+	}
+	return u, err
+}
+
+// provision 创建或同步外部身份对应的本地账号
+func (c *IdentityProviderChain) provision(ctx context.Context, identity *auth.ExternalIdentity) (*user.User, error) {
+	u, err := c.userQueryRepo.GetByUsernameWithRoles(ctx, identity.Username)
+	switch {
+	case err == nil:
+		if u.AuthSource != identity.Provider {
+			return nil, errAccountConflict
+		}
+		if identity.Email != "" {
+			u.Email = identity.Email
+		}
+		u.FullName = identity.FullName
+		u.ExternalID = identity.ExternalID
+		if err = c.userCommandRepo.Update(ctx, u); err != nil {
+			return nil, fmt.Errorf("failed to sync directory user: %w", err)
+		}
+	case errors.Is(err, user.ErrUserNotFound):
+		if u, err = c.createUser(ctx, identity); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if err = c.syncRoles(ctx, u, identity.Roles); err != nil {
+		return nil, err
+	}
+
+	return c.userQueryRepo.GetByIDWithRoles(ctx, u.ID)
+}
+
+// createUser 即时创建目录用户，本地密码为不可用的随机值
+func (c *IdentityProviderChain) createUser(ctx context.Context, identity *auth.ExternalIdentity) (*user.User, error) {
+	if identity.Email == "" {
+		return nil, errIncompleteIdentity
+	}
+	if exists, err := c.userQueryRepo.ExistsByEmail(ctx, identity.Email); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, errAccountConflict
+	}
+
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate placeholder password: %w", err)
+	}
+	hashedPassword, err := c.authService.GeneratePasswordHash(ctx, hex.EncodeToString(buf))
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	u := &user.User{
+		Username:   identity.Username,
+		Email:      identity.Email,
+		Password:   hashedPassword,
+		FullName:   identity.FullName,
+		Status:     "active",
+		AuthSource: identity.Provider,
+		ExternalID: identity.ExternalID,
+	}
+	if err = c.userCommandRepo.Create(ctx, u); err != nil {
+		return nil, fmt.Errorf("failed to provision directory user: %w", err)
+	}
+	return u, nil
+}
+
+// syncRoles 将用户的长期角色同步为目录组映射的角色
+//
+// 目录组映射只能授予本地存在的全局非特权角色：管理权限须经角色分配接口授予（受双人审批保护），
+// 映射到特权角色时忽略并告警。临时授权、已有的特权角色和经由用户组获得的角色不受目录同步影响。
+func (c *IdentityProviderChain) syncRoles(ctx context.Context, u *user.User, roleNames []string) error {
+	assignments, err := c.roleAssignmentQueryRepo.ListByUser(ctx, u.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list role assignments: %w", err)
+	}
+
+	temporary := make(map[uint]bool, len(assignments))
+	current := make([]uint, 0, len(assignments))
+	roleIDs := make([]uint, 0, len(assignments)+len(roleNames))
+	for _, a := range assignments {
+		if a.IsTemporary() {
+			temporary[a.RoleID] = true
+			continue
+		}
+		current = append(current, a.RoleID)
+
+		r, err := c.roleQueryRepo.FindByID(ctx, a.RoleID)
+		if err != nil {
+			return fmt.Errorf("failed to find role %d: %w", a.RoleID, err)
+		}
+		privileged, err := c.isPrivileged(ctx, r)
+		if err != nil {
+			return err
+		}
+		if privileged {
+			roleIDs = append(roleIDs, a.RoleID)
+		}
+	}
+
+	for _, name := range roleNames {
+		r, err := c.roleQueryRepo.FindByName(ctx, name)
+		if err != nil || r == nil || !r.IsGlobal() || temporary[r.ID] || slices.Contains(roleIDs, r.ID) {
+			continue
+		}
+		privileged, err := c.isPrivileged(ctx, r)
+		if err != nil {
+			return err
+		}
+		if privileged {
+			slog.Warn("Directory group maps to a privileged role, ignored", "user_id", u.ID, "role", name)
+			continue
+		}
+		roleIDs = append(roleIDs, r.ID)
+	}
+
+	slices.Sort(roleIDs)
+	slices.Sort(current)
+	if slices.Equal(roleIDs, current) {
+		return nil
+	}
+
+	if err := c.userCommandRepo.AssignRoles(ctx, u.ID, roleIDs); err != nil {
+		return fmt.Errorf("failed to sync directory roles: %w", err)
+	}
+
+	// 发布用户角色分配事件，触发缓存失效
+	if c.eventBus != nil {
+		_ = c.eventBus.Publish(ctx, events.NewUserRoleAssignedEvent(u.ID, roleIDs)) // 缓存失效失败不阻塞业务
+	}
+	return nil
+}
+
+// isPrivileged 按含继承在内的有效权限判断角色是否为特权角色（角色不存在时返回 false）
+func (c *IdentityProviderChain) isPrivileged(ctx context.Context, r *role.Role) (bool, error) {
+	if r == nil {
+		return false, nil
+	}
+	inherited, err := c.roleQueryRepo.GetEffectivePermissions(ctx, r.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get permissions of role %q: %w", r.Name, err)
+	}
+	r.InheritedPermissions = inherited
+	return r.IsPrivileged(), nil
+}
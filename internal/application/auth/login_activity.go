@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// recordLogin 记录成功登录的时间与 IP，由记录器异步批量写入，不阻塞登录
+func recordLogin(recorder user.LoginRecorder, userID uint, clientIP string) {
+	if recorder == nil {
+		return
+	}
+	recorder.Record(user.LoginActivity{UserID: userID, At: time.Now(), IP: clientIP})
+}
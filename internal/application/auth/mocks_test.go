@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	domainAuth "github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	domainSetting "github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
 	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
@@ -94,6 +96,19 @@ func (m *MockUserQueryRepository) CountBySearch(ctx context.Context, keyword str
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockUserQueryRepository) ListByCriteria(ctx context.Context, criteria domainUser.ListCriteria) ([]*domainUser.User, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) CountByCriteria(ctx context.Context, criteria domainUser.ListCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockUserQueryRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	args := m.Called(ctx, username)
 	return args.Bool(0), args.Error(1)
@@ -152,11 +167,41 @@ func (m *MockUserCommandRepository) Delete(ctx context.Context, id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockUserCommandRepository) RecordLogins(ctx context.Context, summaries []domainUser.LoginSummary) error {
+	args := m.Called(ctx, summaries)
+	return args.Error(0)
+}
+
+func (m *MockUserCommandRepository) SaveStatus(ctx context.Context, u *domainUser.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserCommandRepository) SaveAttributes(ctx context.Context, userID uint, values map[string]string) error {
+	args := m.Called(ctx, userID, values)
+	return args.Error(0)
+}
+
+func (m *MockUserCommandRepository) UpdateAvatar(ctx context.Context, userID uint, avatar, version string) error {
+	args := m.Called(ctx, userID, avatar, version)
+	return args.Error(0)
+}
+
+func (m *MockUserCommandRepository) UpdateDeletionSchedule(ctx context.Context, userID uint, requestedAt, scheduledAt *time.Time) error {
+	args := m.Called(ctx, userID, requestedAt, scheduledAt)
+	return args.Error(0)
+}
+
 func (m *MockUserCommandRepository) UpdatePassword(ctx context.Context, id uint, hashedPassword string) error {
 	args := m.Called(ctx, id, hashedPassword)
 	return args.Error(0)
 }
 
+func (m *MockUserCommandRepository) ResetPassword(ctx context.Context, userID uint, hashedPassword string) error {
+	args := m.Called(ctx, userID, hashedPassword)
+	return args.Error(0)
+}
+
 func (m *MockUserCommandRepository) AssignRoles(ctx context.Context, userID uint, roleIDs []uint) error {
 	args := m.Called(ctx, userID, roleIDs)
 	return args.Error(0)
@@ -200,6 +245,11 @@ func (m *MockAuthService) GenerateAccessToken(ctx context.Context, userID uint,
 	return args.String(0), args.Get(1).(time.Time), args.Error(2)
 }
 
+func (m *MockAuthService) GenerateScopedAccessToken(ctx context.Context, userID uint, username, scope string) (string, time.Time, error) {
+	args := m.Called(ctx, userID, username, scope)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
 func (m *MockAuthService) GenerateRefreshToken(ctx context.Context, userID uint) (string, time.Time, error) {
 	args := m.Called(ctx, userID)
 	return args.String(0), args.Get(1).(time.Time), args.Error(2)
@@ -213,9 +263,9 @@ func (m *MockAuthService) ValidateAccessToken(ctx context.Context, token string)
 	return args.Get(0).(*domainAuth.TokenClaims), args.Error(1)
 }
 
-func (m *MockAuthService) ValidateRefreshToken(ctx context.Context, token string) (uint, error) {
+func (m *MockAuthService) ValidateRefreshToken(ctx context.Context, token string) (uint, time.Time, error) {
 	args := m.Called(ctx, token)
-	return args.Get(0).(uint), args.Error(1)
+	return args.Get(0).(uint), args.Get(1).(time.Time), args.Error(2)
 }
 
 func (m *MockAuthService) GeneratePATToken(ctx context.Context) (string, error) {
@@ -311,3 +361,264 @@ func (m *MockTwoFAService) Verify(ctx context.Context, userID uint, code string)
 	args := m.Called(ctx, userID, code)
 	return args.Bool(0), args.Error(1)
 }
+
+// ============================================================
+// MockOTPService (邮件/短信验证码服务)
+// ============================================================
+
+type MockOTPService struct {
+	mock.Mock
+}
+
+func (m *MockOTPService) SetupChannel(ctx context.Context, userID uint, method domainTwoFA.Method, destination string) (*domainTwoFA.ChannelSetupResult, error) {
+	args := m.Called(ctx, userID, method, destination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainTwoFA.ChannelSetupResult), args.Error(1)
+}
+
+func (m *MockOTPService) VerifyAndEnableChannel(ctx context.Context, userID uint, method domainTwoFA.Method, code string) error {
+	args := m.Called(ctx, userID, method, code)
+	return args.Error(0)
+}
+
+func (m *MockOTPService) DisableChannel(ctx context.Context, userID uint, method domainTwoFA.Method) error {
+	args := m.Called(ctx, userID, method)
+	return args.Error(0)
+}
+
+func (m *MockOTPService) SendLoginCode(ctx context.Context, userID uint, method domainTwoFA.Method) (string, error) {
+	args := m.Called(ctx, userID, method)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOTPService) VerifyLoginCode(ctx context.Context, userID uint, method domainTwoFA.Method, code string) (bool, error) {
+	args := m.Called(ctx, userID, method, code)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOTPService) EnabledMethods(ctx context.Context, userID uint) ([]domainTwoFA.Method, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainTwoFA.Method), args.Error(1)
+}
+
+// ============================================================
+// MockSettingQueryRepository
+// ============================================================
+
+type MockSettingQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettingQueryRepository) FindByID(ctx context.Context, id uint) (*domainSetting.Setting, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindByKey(ctx context.Context, key string) (*domainSetting.Setting, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindByKeys(ctx context.Context, keys []string) ([]*domainSetting.Setting, error) {
+	args := m.Called(ctx, keys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindByCategory(ctx context.Context, category string) ([]*domainSetting.Setting, error) {
+	args := m.Called(ctx, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindAll(ctx context.Context) ([]*domainSetting.Setting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainSetting.Setting), args.Error(1)
+}
+
+// ============================================================
+// MockInvitationCommandRepository / MockInvitationQueryRepository
+// ============================================================
+
+type MockInvitationCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvitationCommandRepository) Create(ctx context.Context, invitation *domainUser.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationCommandRepository) MarkAccepted(ctx context.Context, invitation *domainUser.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationCommandRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type MockInvitationQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvitationQueryRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*domainUser.Invitation, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.Invitation), args.Error(1)
+}
+
+// ============================================================
+// MockRoleQueryRepository
+// ============================================================
+
+type MockRoleQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleQueryRepository) FindByID(ctx context.Context, id uint) (*domainRole.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByName(ctx context.Context, name string) (*domainRole.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByIDWithPermissions(ctx context.Context, id uint) (*domainRole.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) List(ctx context.Context, page, limit int) ([]domainRole.Role, int64, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domainRole.Role), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRoleQueryRepository) GetPermissions(ctx context.Context, roleID uint) ([]domainRole.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetHierarchy(ctx context.Context) (domainRole.Hierarchy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domainRole.Hierarchy), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetEffectivePermissions(ctx context.Context, roleID uint) ([]domainRole.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetGrants(ctx context.Context, roleIDs []uint) ([]domainRole.Grant, error) {
+	args := m.Called(ctx, roleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Grant), args.Error(1)
+}
+
+// ============================================================
+// MockRoleAssignmentQueryRepository
+// ============================================================
+
+type MockRoleAssignmentQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleAssignmentQueryRepository) ListByUser(ctx context.Context, userID uint) ([]*domainUser.RoleAssignment, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.RoleAssignment), args.Error(1)
+}
+
+func (m *MockRoleAssignmentQueryRepository) ListDueActivations(ctx context.Context, now time.Time, limit int) ([]*domainUser.RoleAssignment, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.RoleAssignment), args.Error(1)
+}
+
+func (m *MockRoleAssignmentQueryRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domainUser.RoleAssignment, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.RoleAssignment), args.Error(1)
+}
+
+func (m *MockRoleAssignmentQueryRepository) ListExpiring(ctx context.Context, now, until time.Time, offset, limit int) ([]*domainUser.RoleAssignment, int64, error) {
+	args := m.Called(ctx, now, until, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domainUser.RoleAssignment), args.Get(1).(int64), args.Error(2)
+}
+
+// ============================================================
+// MockLoginRecorder
+// ============================================================
+
+type MockLoginRecorder struct {
+	mock.Mock
+}
+
+func (m *MockLoginRecorder) Record(activity domainUser.LoginActivity) {
+	m.Called(activity)
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// passwordChangeReason 返回用户登录后必须修改密码的原因，无需修改时返回空字符串
+func passwordChangeReason(ctx context.Context, settingQueryRepo setting.QueryRepository, u *user.User) string {
+	return u.PasswordChangeReason(setting.PasswordMaxAge(ctx, settingQueryRepo), time.Now())
+}
+
+// issuePasswordChangeLogin 为必须修改密码的用户签发受限令牌
+// 受限令牌仅允许调用修改密码接口，且不签发刷新令牌
+func issuePasswordChangeLogin(ctx context.Context, authService auth.Service, u *user.User, reason string) (*LoginResultDTO, error) {
+	accessToken, expiresAt, err := authService.GenerateScopedAccessToken(ctx, u.ID, u.Username, auth.TokenScopePasswordChange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return &LoginResultDTO{
+		AccessToken:            accessToken,
+		TokenType:              "Bearer",
+		ExpiresIn:              int(time.Until(expiresAt).Seconds()),
+		UserID:                 u.ID,
+		Username:               u.Username,
+		PasswordChangeRequired: true,
+		PasswordChangeReason:   reason,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+)
+
+// registrationPolicy 从系统配置读取注册策略
+// 未配置或读取失败时按开放注册、不限制邮箱域名处理
+func registrationPolicy(ctx context.Context, settingQueryRepo setting.QueryRepository) *auth.RegistrationPolicy {
+	policy := &auth.RegistrationPolicy{Mode: auth.RegistrationOpen}
+	if settingQueryRepo == nil {
+		return policy
+	}
+
+	settings, err := settingQueryRepo.FindByKeys(ctx, []string{
+		setting.KeyRegistrationMode,
+		setting.KeyRegistrationEmailDomains,
+	})
+	if err != nil {
+		return policy
+	}
+
+	for _, s := range settings {
+		switch s.Key {
+		case setting.KeyRegistrationMode:
+			policy.Mode = auth.ParseRegistrationMode(s.Value)
+		case setting.KeyRegistrationEmailDomains:
+			var domains []string
+			if s.ParseJSON(&domains) == nil {
+				policy.AllowedDomains = domains
+			}
+		}
+	}
+	return policy
+}
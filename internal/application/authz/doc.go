@@ -0,0 +1,12 @@
+// Package authz 实现授权决策解释的应用层用例。
+//
+// 本包提供 CQRS 模式的 Query Handler：
+//
+// # Query（读操作）
+//
+//   - [ExplainPermissionHandler]: 解释用户是否拥有某权限及原因
+//
+// 解释结果包括：覆盖该权限的角色授予（含继承来源与通配符模式）、
+// 授予上附加的 ABAC 条件、PAT 声明的权限范围，以及权限缓存与数据库是否一致。
+// 按路由试运行时由 Adapters 层从权限注册表反查路由所需权限与角色后调用。
+package authz
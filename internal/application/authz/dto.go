@@ -0,0 +1,105 @@
+package authz
+
+import (
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
+var (
+	ErrUserNotFound  = user.ErrUserNotFound
+	ErrTokenNotFound = pat.ErrTokenNotFound
+)
+
+// 授予来源
+const (
+	SourceUser         = "user"         // 用户的全局角色
+	SourceGroup        = "group"        // 经由用户组获得的全局角色
+	SourceOrganization = "organization" // 用户在组织内的角色
+)
+
+// ExplainPermissionDTO 按权限解释请求 DTO（Query 参数）
+type ExplainPermissionDTO struct {
+	UserID         uint   `form:"user_id" json:"user_id" binding:"required"`
+	Permission     string `form:"permission" json:"permission" binding:"required"`
+	OrganizationID uint   `form:"organization_id" json:"organization_id"`
+	PATID          uint   `form:"pat_id" json:"pat_id"`
+}
+
+// ExplainRouteDTO 按路由试运行请求 DTO
+type ExplainRouteDTO struct {
+	UserID         uint   `json:"user_id" binding:"required"`
+	Method         string `json:"method" binding:"required,oneof=GET POST PUT PATCH DELETE get post put patch delete" example:"PUT"`
+	Path           string `json:"path" binding:"required,startswith=/" example:"/api/admin/users/5/roles"`
+	OrganizationID uint   `json:"organization_id"`
+	PATID          uint   `json:"pat_id"`
+}
+
+// ExplainSelfDTO 自助查询请求 DTO（Query 参数），用户与租户取自当前请求
+type ExplainSelfDTO struct {
+	Permission string `form:"permission" json:"permission" binding:"required"`
+}
+
+// ExplainSelfRouteDTO 自助按路由试运行请求 DTO
+type ExplainSelfRouteDTO struct {
+	Method string `json:"method" binding:"required,oneof=GET POST PUT PATCH DELETE get post put patch delete" example:"DELETE"`
+	Path   string `json:"path" binding:"required,startswith=/" example:"/api/user/tokens/3"`
+}
+
+// ExplanationDTO 授权决策解释
+type ExplanationDTO struct {
+	UserID         uint   `json:"user_id"`
+	Username       string `json:"username"`
+	OrganizationID uint   `json:"organization_id,omitempty"`
+
+	// Route 按路由试运行时的路由模板，Permission 为空表示路由只要求登录
+	Method       string `json:"method,omitempty"`
+	Route        string `json:"route,omitempty"`
+	Permission   string `json:"permission,omitempty"`
+	RequiredRole string `json:"required_role,omitempty"`
+
+	// Allowed 基于数据库当前状态的决策；缓存过期前实际生效的决策见 Cache.Allowed
+	Allowed     bool     `json:"allowed"`
+	Conditional bool     `json:"conditional"` // 仅由附加 ABAC 条件的授予覆盖，资源级访问受条件限制
+	Reasons     []string `json:"reasons"`
+
+	Roles  []string         `json:"roles"`
+	Grants []*GrantMatchDTO `json:"grants"`
+	Token  *TokenScopeDTO   `json:"token,omitempty"`
+	Cache  *CacheStateDTO   `json:"cache"`
+}
+
+// GrantMatchDTO 覆盖目标权限的角色授予
+type GrantMatchDTO struct {
+	RoleID   uint   `json:"role_id"`
+	RoleName string `json:"role_name"`
+	// ViaRoleID 用户直接持有的角色；授予来自其祖先角色时与 RoleID 不同
+	ViaRoleID   uint   `json:"via_role_id"`
+	ViaRoleName string `json:"via_role_name"`
+	Inherited   bool   `json:"inherited"`
+	Source      string `json:"source"`
+	Pattern     string `json:"pattern"` // 授予的权限代码，可能包含通配符
+	Wildcard    bool   `json:"wildcard"`
+	Condition   string `json:"condition,omitempty"`
+}
+
+// TokenScopeDTO PAT 声明的权限范围
+type TokenScopeDTO struct {
+	ID             uint     `json:"id"`
+	Name           string   `json:"name"`
+	Status         string   `json:"status"`
+	Active         bool     `json:"active"`
+	Permissions    []string `json:"permissions"`
+	Covers         bool     `json:"covers"`
+	MatchedPattern string   `json:"matched_pattern,omitempty"`
+}
+
+// CacheStateDTO 权限缓存状态
+type CacheStateDTO struct {
+	Cached     bool  `json:"cached"`
+	TTLSeconds int64 `json:"ttl_seconds,omitempty"`
+	// Allowed 按缓存中的角色和权限得出的决策（缓存命中时即为当前请求实际生效的决策）
+	Allowed bool `json:"allowed"`
+	// Stale 缓存决策与数据库不一致，将在缓存过期或失效后纠正
+	Stale bool `json:"stale"`
+}
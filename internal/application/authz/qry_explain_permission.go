@@ -0,0 +1,18 @@
+package authz
+
+// ExplainPermissionQuery 授权决策解释查询
+//
+// Permission 为空表示目标路由只要求登录；RequiredRole 非空表示路由组额外要求该角色。
+// OrganizationID 非零时按租户上下文（全局角色与组织内角色的并集）解释；
+// PATID 非零时附带该令牌声明的权限范围。
+type ExplainPermissionQuery struct {
+	UserID         uint
+	Permission     string
+	RequiredRole   string
+	OrganizationID uint
+	PATID          uint
+
+	// 按路由试运行时的路由信息，仅用于回显
+	Method string
+	Route  string
+}
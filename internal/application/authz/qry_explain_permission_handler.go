@@ -0,0 +1,303 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ExplainPermissionHandler 授权决策解释查询处理器
+//
+// 授予沿角色继承链收集（与 PolicyResolver 一致），权限模式按 role.MatchCode 匹配，
+// 与 RequirePermission 中间件使用同一匹配规则。
+type ExplainPermissionHandler struct {
+	userQueryRepo  user.QueryRepository
+	roleQueryRepo  role.QueryRepository
+	orgQueryRepo   organization.QueryRepository
+	patQueryRepo   pat.QueryRepository
+	cacheInspector auth.PermissionCacheInspector
+}
+
+// NewExplainPermissionHandler 创建授权决策解释查询处理器
+func NewExplainPermissionHandler(
+	userQueryRepo user.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	orgQueryRepo organization.QueryRepository,
+	patQueryRepo pat.QueryRepository,
+	cacheInspector auth.PermissionCacheInspector,
+) *ExplainPermissionHandler {
+	return &ExplainPermissionHandler{
+		userQueryRepo:  userQueryRepo,
+		roleQueryRepo:  roleQueryRepo,
+		orgQueryRepo:   orgQueryRepo,
+		patQueryRepo:   patQueryRepo,
+		cacheInspector: cacheInspector,
+	}
+}
+
+// heldRole 用户持有的角色及其来源
+type heldRole struct {
+	role   role.Role
+	source string
+}
+
+// grantOrigin 授予角色经由哪个持有角色获得
+type grantOrigin struct {
+	via    role.Role
+	source string
+}
+
+// Handle 处理授权决策解释查询
+func (h *ExplainPermissionHandler) Handle(ctx context.Context, query ExplainPermissionQuery) (*ExplanationDTO, error) {
+	u, err := h.userQueryRepo.GetByIDWithRoles(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExplanationDTO{
+		UserID:         u.ID,
+		Username:       u.Username,
+		OrganizationID: query.OrganizationID,
+		Method:         query.Method,
+		Route:          query.Route,
+		Permission:     query.Permission,
+		RequiredRole:   query.RequiredRole,
+		Reasons:        []string{},
+		Grants:         []*GrantMatchDTO{},
+		Cache:          &CacheStateDTO{},
+	}
+
+	held := make([]heldRole, 0, len(u.Roles)+len(u.GroupRoles))
+	for _, r := range u.Roles {
+		held = append(held, heldRole{role: r, source: SourceUser})
+	}
+	for _, r := range u.GroupRoles {
+		held = append(held, heldRole{role: r, source: SourceGroup})
+	}
+
+	if query.OrganizationID != 0 {
+		member, err := h.orgQueryRepo.GetMember(ctx, query.OrganizationID, query.UserID)
+		if err != nil {
+			if errors.Is(err, organization.ErrNotMember) {
+				result.Roles = u.GetRoleNames()
+				result.Reasons = append(result.Reasons, fmt.Sprintf("user is not a member of organization %d", query.OrganizationID))
+				return result, nil
+			}
+			return nil, err
+		}
+		for _, r := range member.Roles {
+			held = append(held, heldRole{role: r, source: SourceOrganization})
+		}
+	}
+
+	result.Roles = roleNames(held)
+
+	if query.Permission != "" {
+		if result.Grants, err = h.matchGrants(ctx, held, query.Permission); err != nil {
+			return nil, err
+		}
+	}
+
+	h.decide(result)
+
+	if query.PATID != 0 {
+		if err := h.explainToken(ctx, query, result); err != nil {
+			return nil, err
+		}
+	}
+
+	h.inspectCache(ctx, query, result)
+
+	return result, nil
+}
+
+// matchGrants 收集覆盖目标权限的授予（包括经由祖先角色继承的授予）
+func (h *ExplainPermissionHandler) matchGrants(ctx context.Context, held []heldRole, permission string) ([]*GrantMatchDTO, error) {
+	hierarchy, err := h.roleQueryRepo.GetHierarchy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role hierarchy: %w", err)
+	}
+
+	names := make(map[uint]string, len(held))
+	origins := make(map[uint][]grantOrigin)
+	var roleIDs []uint
+	for _, hr := range held {
+		names[hr.role.ID] = hr.role.Name
+		for _, id := range append([]uint{hr.role.ID}, hierarchy.Ancestors(hr.role.ID)...) {
+			if _, ok := origins[id]; !ok {
+				roleIDs = append(roleIDs, id)
+			}
+			origins[id] = append(origins[id], grantOrigin{via: hr.role, source: hr.source})
+		}
+	}
+
+	grants, err := h.roleQueryRepo.GetGrants(ctx, roleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grants: %w", err)
+	}
+
+	matches := []*GrantMatchDTO{}
+	for _, g := range grants {
+		if !g.Covers(permission) {
+			continue
+		}
+
+		name, err := h.roleName(ctx, names, g.RoleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range origins[g.RoleID] {
+			matches = append(matches, &GrantMatchDTO{
+				RoleID:      g.RoleID,
+				RoleName:    name,
+				ViaRoleID:   o.via.ID,
+				ViaRoleName: o.via.Name,
+				Inherited:   o.via.ID != g.RoleID,
+				Source:      o.source,
+				Pattern:     g.PermissionCode,
+				Wildcard:    g.PermissionCode != permission,
+				Condition:   g.Condition,
+			})
+		}
+	}
+	return matches, nil
+}
+
+// roleName 返回角色名称，祖先角色按需查询
+func (h *ExplainPermissionHandler) roleName(ctx context.Context, names map[uint]string, roleID uint) (string, error) {
+	if name, ok := names[roleID]; ok {
+		return name, nil
+	}
+	r, err := h.roleQueryRepo.FindByID(ctx, roleID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get role: %w", err)
+	}
+	if r != nil {
+		names[roleID] = r.Name
+	}
+	return names[roleID], nil
+}
+
+// decide 根据持有角色和匹配的授予得出决策
+func (h *ExplainPermissionHandler) decide(result *ExplanationDTO) {
+	roleOK := result.RequiredRole == "" || slices.Contains(result.Roles, result.RequiredRole)
+	if !roleOK {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("route requires role %q which the user does not hold", result.RequiredRole))
+	}
+
+	permOK := true
+	switch {
+	case result.Permission == "":
+		result.Reasons = append(result.Reasons, "route requires authentication only")
+	case len(result.Grants) == 0:
+		permOK = false
+		result.Reasons = append(result.Reasons, fmt.Sprintf("no role grants permission %q", result.Permission))
+	default:
+		result.Reasons = append(result.Reasons, fmt.Sprintf("permission %q is granted by %d grant(s)", result.Permission, len(result.Grants)))
+		result.Conditional = !slices.ContainsFunc(result.Grants, func(g *GrantMatchDTO) bool { return g.Condition == "" })
+		if result.Conditional {
+			result.Reasons = append(result.Reasons, "all matching grants carry conditions; resource access is limited by them")
+		}
+	}
+
+	result.Allowed = roleOK && permOK
+}
+
+// explainToken 附带 PAT 声明的权限范围
+//
+// PAT 请求按令牌所有者的角色授权，令牌失效时请求在认证阶段即被拒绝。
+func (h *ExplainPermissionHandler) explainToken(ctx context.Context, query ExplainPermissionQuery, result *ExplanationDTO) error {
+	token, err := h.patQueryRepo.FindByID(ctx, query.PATID)
+	if err != nil {
+		return err
+	}
+	if token.UserID != query.UserID {
+		return pat.ErrTokenNotFound
+	}
+
+	scope := &TokenScopeDTO{
+		ID:          token.ID,
+		Name:        token.Name,
+		Status:      token.Status,
+		Active:      token.IsActive(),
+		Permissions: token.Permissions,
+		Covers:      query.Permission == "",
+	}
+	if scope.Permissions == nil {
+		scope.Permissions = []string{}
+	}
+	if query.Permission != "" {
+		for _, p := range token.Permissions {
+			if role.MatchCode(p, query.Permission) {
+				scope.Covers, scope.MatchedPattern = true, p
+				break
+			}
+		}
+	}
+	result.Token = scope
+
+	switch {
+	case !scope.Active:
+		result.Allowed = false
+		result.Reasons = append(result.Reasons, fmt.Sprintf("token %q is not active (%s)", token.Name, token.Status))
+	case !scope.Covers:
+		result.Reasons = append(result.Reasons, fmt.Sprintf("token %q does not list permission %q; requests are still authorized by the owner's roles", token.Name, query.Permission))
+	}
+	return nil
+}
+
+// inspectCache 对比权限缓存与数据库得出的决策
+// 缓存不可用不影响解释结果，仅记录原因
+func (h *ExplainPermissionHandler) inspectCache(ctx context.Context, query ExplainPermissionQuery, result *ExplanationDTO) {
+	if h.cacheInspector == nil {
+		return
+	}
+
+	entry, err := h.cacheInspector.InspectPermissions(ctx, query.OrganizationID, query.UserID)
+	if err != nil {
+		result.Reasons = append(result.Reasons, "permission cache unavailable: "+err.Error())
+		return
+	}
+	if !entry.Cached {
+		return
+	}
+
+	roleOK := query.RequiredRole == "" || slices.Contains(entry.Roles, query.RequiredRole)
+	permOK := query.Permission == "" || slices.ContainsFunc(entry.Permissions, func(p string) bool {
+		return role.MatchCode(p, query.Permission)
+	})
+
+	cached := roleOK && permOK
+
+	// 缓存只影响角色与权限判定，令牌状态不在缓存中
+	fresh := (query.RequiredRole == "" || slices.Contains(result.Roles, query.RequiredRole)) &&
+		(query.Permission == "" || len(result.Grants) > 0)
+
+	result.Cache = &CacheStateDTO{
+		Cached:     true,
+		TTLSeconds: int64(entry.TTL.Seconds()),
+		Allowed:    cached,
+		Stale:      cached != fresh,
+	}
+	if result.Cache.Stale {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("cached permissions are stale and will be refreshed within %ds", result.Cache.TTLSeconds))
+	}
+}
+
+// roleNames 返回去重后的角色名称，保持首次出现的顺序
+func roleNames(held []heldRole) []string {
+	names := make([]string, 0, len(held))
+	for _, hr := range held {
+		if !slices.Contains(names, hr.role.Name) {
+			names = append(names, hr.role.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,187 @@
+package authz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+type explainMocks struct {
+	users *MockUserQueryRepository
+	roles *MockRoleQueryRepository
+	orgs  *MockOrganizationQueryRepository
+	pats  *MockPATQueryRepository
+	cache *MockPermissionCacheInspector
+}
+
+func newExplainHandler() (*ExplainPermissionHandler, *explainMocks) {
+	m := &explainMocks{
+		users: new(MockUserQueryRepository),
+		roles: new(MockRoleQueryRepository),
+		orgs:  new(MockOrganizationQueryRepository),
+		pats:  new(MockPATQueryRepository),
+		cache: new(MockPermissionCacheInspector),
+	}
+	return NewExplainPermissionHandler(m.users, m.roles, m.orgs, m.pats, m.cache), m
+}
+
+func TestExplainPermissionHandler_Handle(t *testing.T) {
+	// editor(2) 继承 viewer(1)
+	parentID := uint(1)
+	editor := role.Role{ID: 2, Name: "editor", ParentID: &parentID}
+	hierarchy := role.Hierarchy{2: 1}
+
+	t.Run("通配符授予经由继承匹配", func(t *testing.T) {
+		handler, m := newExplainHandler()
+		m.users.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(&user.User{ID: 10, Username: "alice", Roles: []role.Role{editor}}, nil)
+		m.roles.On("GetHierarchy", mock.Anything).Return(hierarchy, nil)
+		m.roles.On("GetGrants", mock.Anything, []uint{2, 1}).Return([]role.Grant{
+			{RoleID: 2, PermissionCode: "admin:posts:update"},
+			{RoleID: 1, PermissionCode: "admin:users:*"},
+		}, nil)
+		m.roles.On("FindByID", mock.Anything, uint(1)).Return(&role.Role{ID: 1, Name: "viewer"}, nil)
+		m.cache.On("InspectPermissions", mock.Anything, uint(0), uint(10)).Return(&auth.PermissionCacheEntry{}, nil)
+
+		result, err := handler.Handle(context.Background(), ExplainPermissionQuery{UserID: 10, Permission: "admin:users:read"})
+
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		require.Len(t, result.Grants, 1)
+		g := result.Grants[0]
+		assert.Equal(t, "viewer", g.RoleName)
+		assert.Equal(t, "editor", g.ViaRoleName)
+		assert.True(t, g.Inherited)
+		assert.True(t, g.Wildcard)
+		assert.Equal(t, "admin:users:*", g.Pattern)
+		assert.False(t, result.Cache.Cached)
+	})
+
+	t.Run("缺少路由要求的角色", func(t *testing.T) {
+		handler, m := newExplainHandler()
+		m.users.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(&user.User{ID: 10, Roles: []role.Role{editor}}, nil)
+		m.cache.On("InspectPermissions", mock.Anything, uint(0), uint(10)).Return(&auth.PermissionCacheEntry{}, nil)
+
+		result, err := handler.Handle(context.Background(), ExplainPermissionQuery{UserID: 10, RequiredRole: "admin"})
+
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Contains(t, result.Reasons[0], "requires role")
+	})
+
+	t.Run("仅有条件授予", func(t *testing.T) {
+		handler, m := newExplainHandler()
+		m.users.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(&user.User{ID: 10, Roles: []role.Role{{ID: 3, Name: "auditor"}}}, nil)
+		m.roles.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{}, nil)
+		m.roles.On("GetGrants", mock.Anything, []uint{3}).Return([]role.Grant{
+			{RoleID: 3, PermissionCode: "admin:auditlogs:read", Condition: "resource.user_id == subject.id"},
+		}, nil)
+		m.cache.On("InspectPermissions", mock.Anything, uint(0), uint(10)).Return(&auth.PermissionCacheEntry{}, nil)
+
+		result, err := handler.Handle(context.Background(), ExplainPermissionQuery{UserID: 10, Permission: "admin:auditlogs:read"})
+
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.True(t, result.Conditional)
+	})
+
+	t.Run("非组织成员", func(t *testing.T) {
+		handler, m := newExplainHandler()
+		m.users.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(&user.User{ID: 10}, nil)
+		m.orgs.On("GetMember", mock.Anything, uint(7), uint(10)).Return(nil, organization.ErrNotMember)
+
+		result, err := handler.Handle(context.Background(), ExplainPermissionQuery{UserID: 10, Permission: "org:members:read", OrganizationID: 7})
+
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Contains(t, result.Reasons[0], "not a member")
+	})
+
+	t.Run("组织内角色授予", func(t *testing.T) {
+		handler, m := newExplainHandler()
+		m.users.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(&user.User{ID: 10}, nil)
+		m.orgs.On("GetMember", mock.Anything, uint(7), uint(10)).Return(&organization.Member{Roles: []role.Role{{ID: 5, Name: "org-admin"}}}, nil)
+		m.roles.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{}, nil)
+		m.roles.On("GetGrants", mock.Anything, []uint{5}).Return([]role.Grant{{RoleID: 5, PermissionCode: "org:members:read"}}, nil)
+		m.cache.On("InspectPermissions", mock.Anything, uint(7), uint(10)).Return(&auth.PermissionCacheEntry{}, nil)
+
+		result, err := handler.Handle(context.Background(), ExplainPermissionQuery{UserID: 10, Permission: "org:members:read", OrganizationID: 7})
+
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		require.Len(t, result.Grants, 1)
+		assert.Equal(t, SourceOrganization, result.Grants[0].Source)
+		assert.False(t, result.Grants[0].Wildcard)
+	})
+
+	t.Run("缓存过期未刷新", func(t *testing.T) {
+		handler, m := newExplainHandler()
+		m.users.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(&user.User{ID: 10}, nil)
+		m.roles.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{}, nil)
+		m.roles.On("GetGrants", mock.Anything, []uint(nil)).Return([]role.Grant{}, nil)
+		m.cache.On("InspectPermissions", mock.Anything, uint(0), uint(10)).Return(&auth.PermissionCacheEntry{
+			Cached:      true,
+			Permissions: []string{"admin:*:*"},
+			TTL:         90 * time.Second,
+		}, nil)
+
+		result, err := handler.Handle(context.Background(), ExplainPermissionQuery{UserID: 10, Permission: "admin:users:read"})
+
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.True(t, result.Cache.Cached)
+		assert.True(t, result.Cache.Allowed)
+		assert.True(t, result.Cache.Stale)
+		assert.Equal(t, int64(90), result.Cache.TTLSeconds)
+	})
+
+	t.Run("PAT 范围与失效令牌", func(t *testing.T) {
+		handler, m := newExplainHandler()
+		m.users.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(&user.User{ID: 10, Roles: []role.Role{{ID: 3, Name: "admin"}}}, nil)
+		m.roles.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{}, nil)
+		m.roles.On("GetGrants", mock.Anything, []uint{3}).Return([]role.Grant{{RoleID: 3, PermissionCode: "*:*:*"}}, nil)
+		m.pats.On("FindByID", mock.Anything, uint(4)).Return(&pat.PersonalAccessToken{
+			ID: 4, UserID: 10, Name: "ci", Status: pat.StatusDisabled, Permissions: pat.PermissionList{"admin:users:*"},
+		}, nil)
+		m.cache.On("InspectPermissions", mock.Anything, uint(0), uint(10)).Return(&auth.PermissionCacheEntry{}, nil)
+
+		result, err := handler.Handle(context.Background(), ExplainPermissionQuery{UserID: 10, Permission: "admin:users:read", PATID: 4})
+
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		require.NotNil(t, result.Token)
+		assert.True(t, result.Token.Covers)
+		assert.Equal(t, "admin:users:*", result.Token.MatchedPattern)
+		assert.False(t, result.Token.Active)
+	})
+
+	t.Run("PAT 不属于该用户", func(t *testing.T) {
+		handler, m := newExplainHandler()
+		m.users.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(&user.User{ID: 10}, nil)
+		m.roles.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{}, nil)
+		m.roles.On("GetGrants", mock.Anything, []uint(nil)).Return([]role.Grant{}, nil)
+		m.pats.On("FindByID", mock.Anything, uint(4)).Return(&pat.PersonalAccessToken{ID: 4, UserID: 99}, nil)
+
+		_, err := handler.Handle(context.Background(), ExplainPermissionQuery{UserID: 10, Permission: "admin:users:read", PATID: 4})
+
+		require.ErrorIs(t, err, pat.ErrTokenNotFound)
+	})
+
+	t.Run("用户不存在", func(t *testing.T) {
+		handler, m := newExplainHandler()
+		m.users.On("GetByIDWithRoles", mock.Anything, uint(10)).Return(nil, user.ErrUserNotFound)
+
+		_, err := handler.Handle(context.Background(), ExplainPermissionQuery{UserID: 10, Permission: "admin:users:read"})
+
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
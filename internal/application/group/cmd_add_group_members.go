@@ -0,0 +1,7 @@
+package group
+
+// AddGroupMembersCommand 添加用户组成员命令
+type AddGroupMembersCommand struct {
+	GroupID uint
+	UserIDs []uint
+}
@@ -0,0 +1,61 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// AddGroupMembersHandler 添加用户组成员命令处理器
+type AddGroupMembersHandler struct {
+	groupCommandRepo group.CommandRepository
+	groupQueryRepo   group.QueryRepository
+	userQueryRepo    user.QueryRepository
+	eventBus         event.EventBus
+}
+
+// NewAddGroupMembersHandler 创建添加用户组成员命令处理器
+func NewAddGroupMembersHandler(
+	groupCommandRepo group.CommandRepository,
+	groupQueryRepo group.QueryRepository,
+	userQueryRepo user.QueryRepository,
+	eventBus event.EventBus,
+) *AddGroupMembersHandler {
+	return &AddGroupMembersHandler{
+		groupCommandRepo: groupCommandRepo,
+		groupQueryRepo:   groupQueryRepo,
+		userQueryRepo:    userQueryRepo,
+		eventBus:         eventBus,
+	}
+}
+
+// Handle 处理添加用户组成员命令（已是成员的用户忽略）
+func (h *AddGroupMembersHandler) Handle(ctx context.Context, cmd AddGroupMembersCommand) error {
+	if _, err := h.groupQueryRepo.GetByID(ctx, cmd.GroupID); err != nil {
+		return err
+	}
+
+	for _, userID := range cmd.UserIDs {
+		exists, err := h.userQueryRepo.Exists(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to check user existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("%w: %d", user.ErrUserNotFound, userID)
+		}
+	}
+
+	if err := h.groupCommandRepo.AddMembers(ctx, cmd.GroupID, cmd.UserIDs); err != nil {
+		return fmt.Errorf("failed to add group members: %w", err)
+	}
+
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewGroupMembershipChangedEvent(cmd.GroupID, cmd.UserIDs))
+	}
+
+	return nil
+}
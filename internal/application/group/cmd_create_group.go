@@ -0,0 +1,8 @@
+package group
+
+// CreateGroupCommand 创建用户组命令
+type CreateGroupCommand struct {
+	Name        string
+	Description string
+	RoleIDs     []uint
+}
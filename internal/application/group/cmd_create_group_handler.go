@@ -0,0 +1,67 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// CreateGroupHandler 创建用户组命令处理器
+type CreateGroupHandler struct {
+	groupCommandRepo group.CommandRepository
+	groupQueryRepo   group.QueryRepository
+	roleQueryRepo    role.QueryRepository
+}
+
+// NewCreateGroupHandler 创建用户组命令处理器
+func NewCreateGroupHandler(
+	groupCommandRepo group.CommandRepository,
+	groupQueryRepo group.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+) *CreateGroupHandler {
+	return &CreateGroupHandler{
+		groupCommandRepo: groupCommandRepo,
+		groupQueryRepo:   groupQueryRepo,
+		roleQueryRepo:    roleQueryRepo,
+	}
+}
+
+// Handle 处理创建用户组命令
+// 新建的用户组没有成员，分配角色不影响任何用户的权限缓存
+func (h *CreateGroupHandler) Handle(ctx context.Context, cmd CreateGroupCommand) (*GroupDTO, error) {
+	// 1. 校验名称和角色
+	name := strings.TrimSpace(cmd.Name)
+	if err := group.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	exists, err := h.groupQueryRepo.ExistsByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check group name: %w", err)
+	}
+	if exists {
+		return nil, group.ErrGroupNameExists
+	}
+
+	g := &group.Group{Name: name, Description: cmd.Description}
+	roles, err := loadAssignableRoles(ctx, h.roleQueryRepo, g, cmd.RoleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 创建用户组并分配角色
+	if err := h.groupCommandRepo.Create(ctx, g); err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+	if len(cmd.RoleIDs) > 0 {
+		if err := h.groupCommandRepo.SetRoles(ctx, g.ID, cmd.RoleIDs); err != nil {
+			return nil, fmt.Errorf("failed to set group roles: %w", err)
+		}
+	}
+	g.Roles = roles
+
+	return ToGroupDTO(g), nil
+}
@@ -0,0 +1,6 @@
+package group
+
+// DeleteGroupCommand 删除用户组命令
+type DeleteGroupCommand struct {
+	GroupID uint
+}
@@ -0,0 +1,53 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+)
+
+// DeleteGroupHandler 删除用户组命令处理器
+type DeleteGroupHandler struct {
+	groupCommandRepo group.CommandRepository
+	groupQueryRepo   group.QueryRepository
+	eventBus         event.EventBus
+}
+
+// NewDeleteGroupHandler 创建删除用户组命令处理器
+func NewDeleteGroupHandler(
+	groupCommandRepo group.CommandRepository,
+	groupQueryRepo group.QueryRepository,
+	eventBus event.EventBus,
+) *DeleteGroupHandler {
+	return &DeleteGroupHandler{
+		groupCommandRepo: groupCommandRepo,
+		groupQueryRepo:   groupQueryRepo,
+		eventBus:         eventBus,
+	}
+}
+
+// Handle 处理删除用户组命令
+// 删除前记录成员，删除后失效其权限缓存（成员失去用户组角色）
+func (h *DeleteGroupHandler) Handle(ctx context.Context, cmd DeleteGroupCommand) error {
+	if _, err := h.groupQueryRepo.GetByID(ctx, cmd.GroupID); err != nil {
+		return err
+	}
+
+	memberIDs, err := h.groupQueryRepo.GetMemberIDs(ctx, cmd.GroupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group members: %w", err)
+	}
+
+	if err := h.groupCommandRepo.Delete(ctx, cmd.GroupID); err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	if h.eventBus != nil && len(memberIDs) > 0 {
+		_ = h.eventBus.Publish(ctx, events.NewGroupMembershipChangedEvent(cmd.GroupID, memberIDs))
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+package group
+
+// ImportGroupsCommand 批量导入用户组命令
+type ImportGroupsCommand struct {
+	Groups []ImportGroupItemDTO
+}
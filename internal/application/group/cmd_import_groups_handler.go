@@ -0,0 +1,220 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ImportGroupsHandler 批量导入用户组命令处理器
+type ImportGroupsHandler struct {
+	groupCommandRepo group.CommandRepository
+	groupQueryRepo   group.QueryRepository
+	roleQueryRepo    role.QueryRepository
+	userQueryRepo    user.QueryRepository
+	eventBus         event.EventBus
+}
+
+// NewImportGroupsHandler 创建批量导入用户组命令处理器
+func NewImportGroupsHandler(
+	groupCommandRepo group.CommandRepository,
+	groupQueryRepo group.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	userQueryRepo user.QueryRepository,
+	eventBus event.EventBus,
+) *ImportGroupsHandler {
+	return &ImportGroupsHandler{
+		groupCommandRepo: groupCommandRepo,
+		groupQueryRepo:   groupQueryRepo,
+		roleQueryRepo:    roleQueryRepo,
+		userQueryRepo:    userQueryRepo,
+		eventBus:         eventBus,
+	}
+}
+
+// Handle 处理批量导入用户组命令
+// 采用部分失败策略：单个用户组失败不影响其他用户组的导入
+func (h *ImportGroupsHandler) Handle(ctx context.Context, cmd ImportGroupsCommand) (*ImportGroupsResultDTO, error) {
+	result := &ImportGroupsResultDTO{
+		Total:  len(cmd.Groups),
+		Errors: make([]ImportGroupErrorDTO, 0),
+	}
+
+	for i, item := range cmd.Groups {
+		created, err := h.importSingleGroup(ctx, item)
+		switch {
+		case err != nil:
+			result.Failed++
+			result.Errors = append(result.Errors, ImportGroupErrorDTO{
+				Index: i,
+				Name:  item.Name,
+				Error: err.Error(),
+			})
+		case created:
+			result.Created++
+		default:
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// importSingleGroup 导入单个用户组：按名称创建或更新，并同步提供的角色与成员
+// 先解析全部角色和成员再写入，引用无效时不做任何修改
+func (h *ImportGroupsHandler) importSingleGroup(ctx context.Context, item ImportGroupItemDTO) (bool, error) {
+	// 1. 解析用户组、角色和成员
+	name := strings.TrimSpace(item.Name)
+	if err := group.ValidateName(name); err != nil {
+		return false, err
+	}
+
+	g, err := h.groupQueryRepo.GetByName(ctx, name)
+	created := errors.Is(err, group.ErrGroupNotFound)
+	switch {
+	case created:
+		g = &group.Group{Name: name}
+	case err != nil:
+		return false, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	var roleIDs []uint
+	if item.Roles != nil {
+		if roleIDs, err = h.resolveRoles(ctx, g, item.Roles); err != nil {
+			return false, err
+		}
+	}
+
+	var userIDs []uint
+	if item.Members != nil {
+		if userIDs, err = h.resolveMembers(ctx, item.Members); err != nil {
+			return false, err
+		}
+	}
+
+	// 2. 创建或更新用户组
+	if created {
+		if item.Description != nil {
+			g.Description = *item.Description
+		}
+		if err := h.groupCommandRepo.Create(ctx, g); err != nil {
+			return false, fmt.Errorf("failed to create group: %w", err)
+		}
+	} else if item.Description != nil && *item.Description != g.Description {
+		g.Description = *item.Description
+		if err := h.groupCommandRepo.Update(ctx, g); err != nil {
+			return false, fmt.Errorf("failed to update group: %w", err)
+		}
+	}
+
+	// 3. 同步角色与成员
+	if item.Roles != nil && !sameIDs(g.RoleIDs(), roleIDs) {
+		if err := h.groupCommandRepo.SetRoles(ctx, g.ID, roleIDs); err != nil {
+			return created, fmt.Errorf("failed to set group roles: %w", err)
+		}
+		if h.eventBus != nil && !created {
+			_ = h.eventBus.Publish(ctx, events.NewGroupRolesChangedEvent(g.ID))
+		}
+	}
+
+	if item.Members != nil {
+		if err := h.syncMembers(ctx, g.ID, userIDs); err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
+}
+
+// resolveRoles 按名称解析角色 ID（去重），角色必须存在且为全局角色
+func (h *ImportGroupsHandler) resolveRoles(ctx context.Context, g *group.Group, names []string) ([]uint, error) {
+	ids := make([]uint, 0, len(names))
+	for _, name := range names {
+		r, err := h.roleQueryRepo.FindByName(ctx, strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find role: %w", err)
+		}
+		if r == nil {
+			return nil, fmt.Errorf("%w: %s", role.ErrRoleNotFound, name)
+		}
+		if !g.CanHoldRole(r) {
+			return nil, fmt.Errorf("%w: %s", group.ErrRoleNotGlobal, r.Name)
+		}
+		if !slices.Contains(ids, r.ID) {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids, nil
+}
+
+// resolveMembers 按用户名解析用户 ID（去重）
+func (h *ImportGroupsHandler) resolveMembers(ctx context.Context, usernames []string) ([]uint, error) {
+	ids := make([]uint, 0, len(usernames))
+	for _, username := range usernames {
+		u, err := h.userQueryRepo.GetByUsername(ctx, strings.TrimSpace(username))
+		if err != nil {
+			if errors.Is(err, user.ErrUserNotFound) {
+				return nil, fmt.Errorf("%w: %s", user.ErrUserNotFound, username)
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if !slices.Contains(ids, u.ID) {
+			ids = append(ids, u.ID)
+		}
+	}
+	return ids, nil
+}
+
+// syncMembers 将用户组成员同步为 userIDs，仅对实际加入或离开的用户发布事件
+func (h *ImportGroupsHandler) syncMembers(ctx context.Context, groupID uint, userIDs []uint) error {
+	current, err := h.groupQueryRepo.GetMemberIDs(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group members: %w", err)
+	}
+
+	var toAdd, toRemove []uint
+	for _, id := range userIDs {
+		if !slices.Contains(current, id) {
+			toAdd = append(toAdd, id)
+		}
+	}
+	for _, id := range current {
+		if !slices.Contains(userIDs, id) {
+			toRemove = append(toRemove, id)
+		}
+	}
+
+	if err := h.groupCommandRepo.AddMembers(ctx, groupID, toAdd); err != nil {
+		return fmt.Errorf("failed to add group members: %w", err)
+	}
+	if err := h.groupCommandRepo.RemoveMembers(ctx, groupID, toRemove); err != nil {
+		return fmt.Errorf("failed to remove group members: %w", err)
+	}
+
+	if changed := slices.Concat(toAdd, toRemove); h.eventBus != nil && len(changed) > 0 {
+		_ = h.eventBus.Publish(ctx, events.NewGroupMembershipChangedEvent(groupID, changed))
+	}
+
+	return nil
+}
+
+// sameIDs 比较两个 ID 集合是否相同（忽略顺序）
+func sameIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, id := range a {
+		if !slices.Contains(b, id) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package group
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainEvent "github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	domainGroup "github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+type importMocks struct {
+	cmdRepo  *MockGroupCommandRepository
+	qryRepo  *MockGroupQueryRepository
+	roleRepo *MockRoleQueryRepository
+	userRepo *MockUserQueryRepository
+	eventBus *MockEventBus
+}
+
+func newImportMocks() *importMocks {
+	m := &importMocks{
+		cmdRepo:  new(MockGroupCommandRepository),
+		qryRepo:  new(MockGroupQueryRepository),
+		roleRepo: new(MockRoleQueryRepository),
+		userRepo: new(MockUserQueryRepository),
+		eventBus: new(MockEventBus),
+	}
+	m.roleRepo.On("FindByName", mock.Anything, "viewer").Return(&domainRole.Role{ID: 10, Name: "viewer"}, nil)
+	m.roleRepo.On("FindByName", mock.Anything, "ghost").Return(nil, nil)
+	m.userRepo.On("GetByUsername", mock.Anything, "alice").Return(&domainUser.User{ID: 1, Username: "alice"}, nil)
+	m.userRepo.On("GetByUsername", mock.Anything, "bob").Return(&domainUser.User{ID: 2, Username: "bob"}, nil)
+	return m
+}
+
+func (m *importMocks) handler() *ImportGroupsHandler {
+	return NewImportGroupsHandler(m.cmdRepo, m.qryRepo, m.roleRepo, m.userRepo, m.eventBus)
+}
+
+// membershipEvent 匹配指定用户组和变更用户的成员变更事件
+func membershipEvent(groupID uint, userIDs ...uint) any {
+	return mock.MatchedBy(func(evts []domainEvent.Event) bool {
+		evt, ok := evts[0].(*events.GroupMembershipChangedEvent)
+		return ok && evt.GroupID == groupID && assert.ObjectsAreEqual(userIDs, evt.UserIDs)
+	})
+}
+
+func TestImportGroupsHandler_Handle(t *testing.T) {
+	t.Run("创建新用户组并分配角色和成员", func(t *testing.T) {
+		m := newImportMocks()
+		m.qryRepo.On("GetByName", mock.Anything, "engineering").Return(nil, domainGroup.ErrGroupNotFound)
+		m.cmdRepo.On("Create", mock.Anything, mock.MatchedBy(func(g *domainGroup.Group) bool {
+			return g.Name == "engineering" && g.Description == "研发部"
+		})).Return(nil)
+		m.cmdRepo.On("SetRoles", mock.Anything, uint(1), []uint{10}).Return(nil)
+		m.qryRepo.On("GetMemberIDs", mock.Anything, uint(1)).Return([]uint{}, nil)
+		m.cmdRepo.On("AddMembers", mock.Anything, uint(1), []uint{1, 2}).Return(nil)
+		m.cmdRepo.On("RemoveMembers", mock.Anything, uint(1), []uint(nil)).Return(nil)
+		m.eventBus.On("Publish", mock.Anything, membershipEvent(1, 1, 2)).Return(nil)
+
+		description := "研发部"
+		result, err := m.handler().Handle(context.Background(), ImportGroupsCommand{Groups: []ImportGroupItemDTO{
+			{Name: "engineering", Description: &description, Roles: []string{"viewer"}, Members: []string{"alice", "bob", "alice"}},
+		}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Created)
+		assert.Zero(t, result.Failed)
+		m.cmdRepo.AssertExpectations(t)
+		m.eventBus.AssertExpectations(t)
+	})
+
+	t.Run("同步已有用户组的成员，仅通知变更的用户", func(t *testing.T) {
+		m := newImportMocks()
+		existing := &domainGroup.Group{ID: 5, Name: "engineering", Roles: []domainRole.Role{{ID: 10, Name: "viewer"}}}
+		m.qryRepo.On("GetByName", mock.Anything, "engineering").Return(existing, nil)
+		m.qryRepo.On("GetMemberIDs", mock.Anything, uint(5)).Return([]uint{2, 3}, nil)
+		m.cmdRepo.On("AddMembers", mock.Anything, uint(5), []uint{1}).Return(nil)
+		m.cmdRepo.On("RemoveMembers", mock.Anything, uint(5), []uint{3}).Return(nil)
+		m.eventBus.On("Publish", mock.Anything, membershipEvent(5, 1, 3)).Return(nil)
+
+		result, err := m.handler().Handle(context.Background(), ImportGroupsCommand{Groups: []ImportGroupItemDTO{
+			{Name: "engineering", Roles: []string{"viewer"}, Members: []string{"alice", "bob"}},
+		}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Updated)
+		m.cmdRepo.AssertNotCalled(t, "SetRoles", mock.Anything, mock.Anything, mock.Anything)
+		m.cmdRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		m.eventBus.AssertExpectations(t)
+	})
+
+	t.Run("引用无效时不修改该用户组，其余继续导入", func(t *testing.T) {
+		m := newImportMocks()
+		m.qryRepo.On("GetByName", mock.Anything, "engineering").Return(nil, domainGroup.ErrGroupNotFound)
+		m.qryRepo.On("GetByName", mock.Anything, "sales").Return(&domainGroup.Group{ID: 6, Name: "sales"}, nil)
+		m.cmdRepo.On("SetRoles", mock.Anything, uint(6), []uint{10}).Return(nil)
+		m.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := m.handler().Handle(context.Background(), ImportGroupsCommand{Groups: []ImportGroupItemDTO{
+			{Name: "engineering", Roles: []string{"ghost"}},
+			{Name: "sales", Roles: []string{"viewer"}},
+			{Name: "bad name"},
+		}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Total)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, 2, result.Failed)
+		require.Len(t, result.Errors, 2)
+		assert.Equal(t, 0, result.Errors[0].Index)
+		assert.Contains(t, result.Errors[0].Error, "ghost")
+		assert.Equal(t, 2, result.Errors[1].Index)
+		m.cmdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
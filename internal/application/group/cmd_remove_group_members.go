@@ -0,0 +1,7 @@
+package group
+
+// RemoveGroupMembersCommand 移除用户组成员命令
+type RemoveGroupMembersCommand struct {
+	GroupID uint
+	UserIDs []uint
+}
@@ -0,0 +1,47 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+)
+
+// RemoveGroupMembersHandler 移除用户组成员命令处理器
+type RemoveGroupMembersHandler struct {
+	groupCommandRepo group.CommandRepository
+	groupQueryRepo   group.QueryRepository
+	eventBus         event.EventBus
+}
+
+// NewRemoveGroupMembersHandler 创建移除用户组成员命令处理器
+func NewRemoveGroupMembersHandler(
+	groupCommandRepo group.CommandRepository,
+	groupQueryRepo group.QueryRepository,
+	eventBus event.EventBus,
+) *RemoveGroupMembersHandler {
+	return &RemoveGroupMembersHandler{
+		groupCommandRepo: groupCommandRepo,
+		groupQueryRepo:   groupQueryRepo,
+		eventBus:         eventBus,
+	}
+}
+
+// Handle 处理移除用户组成员命令（非成员的用户忽略）
+func (h *RemoveGroupMembersHandler) Handle(ctx context.Context, cmd RemoveGroupMembersCommand) error {
+	if _, err := h.groupQueryRepo.GetByID(ctx, cmd.GroupID); err != nil {
+		return err
+	}
+
+	if err := h.groupCommandRepo.RemoveMembers(ctx, cmd.GroupID, cmd.UserIDs); err != nil {
+		return fmt.Errorf("failed to remove group members: %w", err)
+	}
+
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewGroupMembershipChangedEvent(cmd.GroupID, cmd.UserIDs))
+	}
+
+	return nil
+}
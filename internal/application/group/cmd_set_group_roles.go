@@ -0,0 +1,7 @@
+package group
+
+// SetGroupRolesCommand 设置用户组角色命令
+type SetGroupRolesCommand struct {
+	GroupID uint
+	RoleIDs []uint
+}
@@ -0,0 +1,77 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// SetGroupRolesHandler 设置用户组角色命令处理器
+type SetGroupRolesHandler struct {
+	groupCommandRepo group.CommandRepository
+	groupQueryRepo   group.QueryRepository
+	roleQueryRepo    role.QueryRepository
+	eventBus         event.EventBus
+}
+
+// NewSetGroupRolesHandler 创建设置用户组角色命令处理器
+func NewSetGroupRolesHandler(
+	groupCommandRepo group.CommandRepository,
+	groupQueryRepo group.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	eventBus event.EventBus,
+) *SetGroupRolesHandler {
+	return &SetGroupRolesHandler{
+		groupCommandRepo: groupCommandRepo,
+		groupQueryRepo:   groupQueryRepo,
+		roleQueryRepo:    roleQueryRepo,
+		eventBus:         eventBus,
+	}
+}
+
+// Handle 处理设置用户组角色命令（替换现有角色）
+func (h *SetGroupRolesHandler) Handle(ctx context.Context, cmd SetGroupRolesCommand) (*GroupDTO, error) {
+	g, err := h.groupQueryRepo.GetByID(ctx, cmd.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := loadAssignableRoles(ctx, h.roleQueryRepo, g, cmd.RoleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.groupCommandRepo.SetRoles(ctx, cmd.GroupID, cmd.RoleIDs); err != nil {
+		return nil, fmt.Errorf("failed to set group roles: %w", err)
+	}
+	g.Roles = roles
+
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewGroupRolesChangedEvent(cmd.GroupID))
+	}
+
+	return ToGroupDTO(g), nil
+}
+
+// loadAssignableRoles 加载并校验角色：角色必须存在，且为全局角色
+func loadAssignableRoles(ctx context.Context, roleQueryRepo role.QueryRepository, g *group.Group, roleIDs []uint) ([]role.Role, error) {
+	roles := make([]role.Role, 0, len(roleIDs))
+	for _, id := range roleIDs {
+		r, err := roleQueryRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find role: %w", err)
+		}
+		if r == nil {
+			return nil, fmt.Errorf("%w: %d", role.ErrRoleNotFound, id)
+		}
+		if !g.CanHoldRole(r) {
+			return nil, fmt.Errorf("%w: %s", group.ErrRoleNotGlobal, r.Name)
+		}
+		roles = append(roles, *r)
+	}
+	return roles, nil
+}
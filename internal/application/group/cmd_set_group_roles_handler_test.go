@@ -0,0 +1,101 @@
+package group
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainEvent "github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	domainGroup "github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+func TestSetGroupRolesHandler_Handle(t *testing.T) {
+	orgID := uint(1)
+	viewer := &domainRole.Role{ID: 10, Name: "viewer"}
+	editor := &domainRole.Role{ID: 11, Name: "editor"}
+	orgRole := &domainRole.Role{ID: 12, Name: "org-admin", OrganizationID: &orgID}
+
+	tests := []struct {
+		name       string
+		cmd        SetGroupRolesCommand
+		setupMocks func(*MockGroupCommandRepository, *MockGroupQueryRepository, *MockRoleQueryRepository, *MockEventBus)
+		wantErr    error
+		wantRoles  []string
+	}{
+		{
+			name: "分配全局角色并失效成员缓存",
+			cmd:  SetGroupRolesCommand{GroupID: 3, RoleIDs: []uint{10, 11}},
+			setupMocks: func(cmdRepo *MockGroupCommandRepository, qryRepo *MockGroupQueryRepository, roleRepo *MockRoleQueryRepository, eventBus *MockEventBus) {
+				qryRepo.On("GetByID", mock.Anything, uint(3)).Return(&domainGroup.Group{ID: 3, Name: "engineering"}, nil)
+				roleRepo.On("FindByID", mock.Anything, uint(10)).Return(viewer, nil)
+				roleRepo.On("FindByID", mock.Anything, uint(11)).Return(editor, nil)
+				cmdRepo.On("SetRoles", mock.Anything, uint(3), []uint{10, 11}).Return(nil)
+				eventBus.On("Publish", mock.Anything, mock.MatchedBy(func(evts []domainEvent.Event) bool {
+					evt, ok := evts[0].(*events.GroupRolesChangedEvent)
+					return ok && evt.GroupID == 3
+				})).Return(nil)
+			},
+			wantRoles: []string{"viewer", "editor"},
+		},
+		{
+			name: "不能分配租户角色",
+			cmd:  SetGroupRolesCommand{GroupID: 3, RoleIDs: []uint{12}},
+			setupMocks: func(_ *MockGroupCommandRepository, qryRepo *MockGroupQueryRepository, roleRepo *MockRoleQueryRepository, _ *MockEventBus) {
+				qryRepo.On("GetByID", mock.Anything, uint(3)).Return(&domainGroup.Group{ID: 3, Name: "engineering"}, nil)
+				roleRepo.On("FindByID", mock.Anything, uint(12)).Return(orgRole, nil)
+			},
+			wantErr: domainGroup.ErrRoleNotGlobal,
+		},
+		{
+			name: "角色不存在",
+			cmd:  SetGroupRolesCommand{GroupID: 3, RoleIDs: []uint{99}},
+			setupMocks: func(_ *MockGroupCommandRepository, qryRepo *MockGroupQueryRepository, roleRepo *MockRoleQueryRepository, _ *MockEventBus) {
+				qryRepo.On("GetByID", mock.Anything, uint(3)).Return(&domainGroup.Group{ID: 3, Name: "engineering"}, nil)
+				roleRepo.On("FindByID", mock.Anything, uint(99)).Return(nil, nil)
+			},
+			wantErr: domainRole.ErrRoleNotFound,
+		},
+		{
+			name: "用户组不存在",
+			cmd:  SetGroupRolesCommand{GroupID: 404, RoleIDs: []uint{10}},
+			setupMocks: func(_ *MockGroupCommandRepository, qryRepo *MockGroupQueryRepository, _ *MockRoleQueryRepository, _ *MockEventBus) {
+				qryRepo.On("GetByID", mock.Anything, uint(404)).Return(nil, domainGroup.ErrGroupNotFound)
+			},
+			wantErr: domainGroup.ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmdRepo := new(MockGroupCommandRepository)
+			qryRepo := new(MockGroupQueryRepository)
+			roleRepo := new(MockRoleQueryRepository)
+			eventBus := new(MockEventBus)
+			tt.setupMocks(cmdRepo, qryRepo, roleRepo, eventBus)
+
+			handler := NewSetGroupRolesHandler(cmdRepo, qryRepo, roleRepo, eventBus)
+			result, err := handler.Handle(context.Background(), tt.cmd)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				cmdRepo.AssertNotCalled(t, "SetRoles", mock.Anything, mock.Anything, mock.Anything)
+				eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(result.Roles))
+			for _, r := range result.Roles {
+				names = append(names, r.Name)
+			}
+			assert.Equal(t, tt.wantRoles, names)
+			cmdRepo.AssertExpectations(t)
+			eventBus.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package group
+
+// UpdateGroupCommand 更新用户组命令
+type UpdateGroupCommand struct {
+	GroupID     uint
+	Name        *string
+	Description *string
+}
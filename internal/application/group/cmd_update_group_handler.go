@@ -0,0 +1,59 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+)
+
+// UpdateGroupHandler 更新用户组命令处理器
+type UpdateGroupHandler struct {
+	groupCommandRepo group.CommandRepository
+	groupQueryRepo   group.QueryRepository
+}
+
+// NewUpdateGroupHandler 创建更新用户组命令处理器
+func NewUpdateGroupHandler(groupCommandRepo group.CommandRepository, groupQueryRepo group.QueryRepository) *UpdateGroupHandler {
+	return &UpdateGroupHandler{
+		groupCommandRepo: groupCommandRepo,
+		groupQueryRepo:   groupQueryRepo,
+	}
+}
+
+// Handle 处理更新用户组命令
+func (h *UpdateGroupHandler) Handle(ctx context.Context, cmd UpdateGroupCommand) (*GroupDTO, error) {
+	g, err := h.groupQueryRepo.GetByID(ctx, cmd.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Name != nil {
+		name := strings.TrimSpace(*cmd.Name)
+		if name != g.Name {
+			if err := group.ValidateName(name); err != nil {
+				return nil, err
+			}
+			exists, err := h.groupQueryRepo.ExistsByName(ctx, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check group name: %w", err)
+			}
+			if exists {
+				return nil, group.ErrGroupNameExists
+			}
+			g.Name = name
+		}
+	}
+	if cmd.Description != nil {
+		g.Description = *cmd.Description
+	}
+
+	roles := g.Roles
+	if err := h.groupCommandRepo.Update(ctx, g); err != nil {
+		return nil, fmt.Errorf("failed to update group: %w", err)
+	}
+	g.Roles = roles
+
+	return ToGroupDTO(g), nil
+}
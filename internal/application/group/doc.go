@@ -0,0 +1,23 @@
+// Package group 实现用户组管理的应用层用例。
+//
+// 本包提供 CQRS 模式的 Command 和 Query Handler：
+//
+// # Command（写操作）
+//
+//   - [CreateGroupHandler]: 创建用户组（可同时分配角色）
+//   - [UpdateGroupHandler]: 更新用户组名称与描述
+//   - [DeleteGroupHandler]: 删除用户组
+//   - [AddGroupMembersHandler]: 添加用户组成员
+//   - [RemoveGroupMembersHandler]: 移除用户组成员
+//   - [SetGroupRolesHandler]: 设置用户组的角色
+//   - [ImportGroupsHandler]: 批量导入用户组（按名称创建或更新，同步角色与成员）
+//
+// # Query（读操作）
+//
+//   - [GetGroupHandler]: 获取用户组详情
+//   - [ListGroupsHandler]: 用户组分页列表
+//   - [ListGroupMembersHandler]: 用户组成员分页列表
+//
+// 成员变更（含删除用户组）发布 GroupMembershipChangedEvent，角色变更发布 GroupRolesChangedEvent，
+// 由缓存失效处理器清除受影响用户的权限缓存。
+package group
@@ -0,0 +1,110 @@
+package group
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
+var (
+	ErrGroupNotFound    = group.ErrGroupNotFound
+	ErrGroupNameExists  = group.ErrGroupNameExists
+	ErrInvalidGroupName = group.ErrInvalidGroupName
+	ErrRoleNotGlobal    = group.ErrRoleNotGlobal
+
+	ErrUserNotFound = user.ErrUserNotFound
+	ErrRoleNotFound = role.ErrRoleNotFound
+)
+
+// CreateGroupDTO 创建用户组 DTO
+type CreateGroupDTO struct {
+	Name        string `json:"name" binding:"required,max=50" example:"engineering"`
+	Description string `json:"description" binding:"max=255" example:"研发部"`
+	RoleIDs     []uint `json:"role_ids" binding:"omitempty,dive,gt=0"`
+}
+
+// UpdateGroupDTO 更新用户组 DTO
+type UpdateGroupDTO struct {
+	Name        *string `json:"name" binding:"omitempty,max=50"`
+	Description *string `json:"description" binding:"omitempty,max=255"`
+}
+
+// SetGroupRolesDTO 设置用户组角色 DTO
+type SetGroupRolesDTO struct {
+	RoleIDs []uint `json:"role_ids" binding:"omitempty,dive,gt=0"`
+}
+
+// GroupMembersDTO 添加或移除用户组成员 DTO
+type GroupMembersDTO struct {
+	UserIDs []uint `json:"user_ids" binding:"required,min=1,max=500,dive,gt=0"`
+}
+
+// ImportGroupsDTO 批量导入用户组 DTO
+type ImportGroupsDTO struct {
+	Groups []ImportGroupItemDTO `json:"groups" binding:"required,min=1,max=100,dive"`
+}
+
+// ImportGroupItemDTO 导入的单个用户组
+// 按名称匹配已有用户组；Description、Roles、Members 省略时保持不变，
+// 提供时（包括空数组）替换为给定的值
+type ImportGroupItemDTO struct {
+	Name        string   `json:"name" binding:"required,max=50" example:"engineering"`
+	Description *string  `json:"description,omitempty" binding:"omitempty,max=255"`
+	Roles       []string `json:"roles,omitempty" example:"developer"`
+	Members     []string `json:"members,omitempty" example:"alice"`
+}
+
+// GroupRoleDTO 用户组持有的角色
+type GroupRoleDTO struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// GroupDTO 用户组响应 DTO
+type GroupDTO struct {
+	ID          uint            `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Roles       []*GroupRoleDTO `json:"roles"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// GroupListDTO 用户组列表响应 DTO
+type GroupListDTO struct {
+	Groups []*GroupDTO `json:"groups"`
+	Total  int64       `json:"total"`
+}
+
+// GroupMemberDTO 用户组成员响应 DTO
+type GroupMemberDTO struct {
+	GroupID   uint      `json:"group_id"`
+	UserID    uint      `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GroupMemberListDTO 用户组成员列表响应 DTO
+type GroupMemberListDTO struct {
+	Members []*GroupMemberDTO `json:"members"`
+	Total   int64             `json:"total"`
+}
+
+// ImportGroupsResultDTO 批量导入用户组结果
+type ImportGroupsResultDTO struct {
+	Total   int                   `json:"total"`
+	Created int                   `json:"created"`
+	Updated int                   `json:"updated"`
+	Failed  int                   `json:"failed"`
+	Errors  []ImportGroupErrorDTO `json:"errors,omitempty"`
+}
+
+// ImportGroupErrorDTO 导入失败的用户组
+type ImportGroupErrorDTO struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,43 @@
+package group
+
+import (
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+)
+
+// ToGroupDTO 将用户组实体转换为 DTO
+func ToGroupDTO(g *group.Group) *GroupDTO {
+	if g == nil {
+		return nil
+	}
+
+	roles := make([]*GroupRoleDTO, 0, len(g.Roles))
+	for _, r := range g.Roles {
+		roles = append(roles, &GroupRoleDTO{
+			ID:          r.ID,
+			Name:        r.Name,
+			DisplayName: r.DisplayName,
+		})
+	}
+
+	return &GroupDTO{
+		ID:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		Roles:       roles,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
+
+// ToGroupMemberDTO 将成员关系转换为 DTO
+func ToGroupMemberDTO(member *group.Member) *GroupMemberDTO {
+	if member == nil {
+		return nil
+	}
+
+	return &GroupMemberDTO{
+		GroupID:   member.GroupID,
+		UserID:    member.UserID,
+		CreatedAt: member.CreatedAt,
+	}
+}
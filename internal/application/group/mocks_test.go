@@ -0,0 +1,342 @@
+//nolint:forcetypeassert // Mock 返回值类型在测试中总是已知的
+package group
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	domainEvent "github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	domainGroup "github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// MockGroupCommandRepository 用户组写仓储 Mock
+type MockGroupCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupCommandRepository) Create(ctx context.Context, g *domainGroup.Group) error {
+	args := m.Called(ctx, g)
+	if args.Error(0) == nil {
+		g.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *MockGroupCommandRepository) Update(ctx context.Context, g *domainGroup.Group) error {
+	args := m.Called(ctx, g)
+	return args.Error(0)
+}
+
+func (m *MockGroupCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockGroupCommandRepository) AddMembers(ctx context.Context, groupID uint, userIDs []uint) error {
+	args := m.Called(ctx, groupID, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockGroupCommandRepository) RemoveMembers(ctx context.Context, groupID uint, userIDs []uint) error {
+	args := m.Called(ctx, groupID, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockGroupCommandRepository) SetRoles(ctx context.Context, groupID uint, roleIDs []uint) error {
+	args := m.Called(ctx, groupID, roleIDs)
+	return args.Error(0)
+}
+
+// MockGroupQueryRepository 用户组读仓储 Mock
+type MockGroupQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupQueryRepository) GetByID(ctx context.Context, id uint) (*domainGroup.Group, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainGroup.Group), args.Error(1)
+}
+
+func (m *MockGroupQueryRepository) GetByName(ctx context.Context, name string) (*domainGroup.Group, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainGroup.Group), args.Error(1)
+}
+
+func (m *MockGroupQueryRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGroupQueryRepository) List(ctx context.Context, offset, limit int) ([]*domainGroup.Group, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domainGroup.Group), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockGroupQueryRepository) ListByUser(ctx context.Context, userID uint) ([]*domainGroup.Group, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainGroup.Group), args.Error(1)
+}
+
+func (m *MockGroupQueryRepository) ListMembers(ctx context.Context, groupID uint, offset, limit int) ([]*domainGroup.Member, int64, error) {
+	args := m.Called(ctx, groupID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domainGroup.Member), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockGroupQueryRepository) GetMemberIDs(ctx context.Context, groupID uint) ([]uint, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockGroupQueryRepository) GetUserIDsByRole(ctx context.Context, roleID uint) ([]uint, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+// MockUserQueryRepository 用户读仓储 Mock
+type MockUserQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserQueryRepository) GetByID(ctx context.Context, id uint) (*domainUser.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByUsername(ctx context.Context, username string) (*domainUser.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByEmail(ctx context.Context, email string) (*domainUser.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByUsernameWithRoles(ctx context.Context, username string) (*domainUser.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByEmailWithRoles(ctx context.Context, email string) (*domainUser.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByIDWithRoles(ctx context.Context, id uint) (*domainUser.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	args := m.Called(ctx, username)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) List(ctx context.Context, offset, limit int) ([]*domainUser.User, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) Search(ctx context.Context, keyword string, offset, limit int) ([]*domainUser.User, error) {
+	args := m.Called(ctx, keyword, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) CountBySearch(ctx context.Context, keyword string) (int64, error) {
+	args := m.Called(ctx, keyword)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) ListByCriteria(ctx context.Context, criteria domainUser.ListCriteria) ([]*domainUser.User, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) CountByCriteria(ctx context.Context, criteria domainUser.ListCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetRoles(ctx context.Context, userID uint) ([]uint, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetUserIDsByRole(ctx context.Context, roleID uint) ([]uint, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+// MockRoleQueryRepository 角色读仓储 Mock
+type MockRoleQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleQueryRepository) FindByID(ctx context.Context, id uint) (*domainRole.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByName(ctx context.Context, name string) (*domainRole.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByIDWithPermissions(ctx context.Context, id uint) (*domainRole.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) List(ctx context.Context, page, limit int) ([]domainRole.Role, int64, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domainRole.Role), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRoleQueryRepository) GetPermissions(ctx context.Context, roleID uint) ([]domainRole.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetHierarchy(ctx context.Context) (domainRole.Hierarchy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domainRole.Hierarchy), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetEffectivePermissions(ctx context.Context, roleID uint) ([]domainRole.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetGrants(ctx context.Context, roleIDs []uint) ([]domainRole.Grant, error) {
+	args := m.Called(ctx, roleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Grant), args.Error(1)
+}
+
+// MockEventBus 事件总线 Mock
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, events ...domainEvent.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Subscribe(eventName string, handler domainEvent.EventHandler) {
+	m.Called(eventName, handler)
+}
+
+func (m *MockEventBus) Unsubscribe(eventName string, handler domainEvent.EventHandler) {
+	m.Called(eventName, handler)
+}
+
+func (m *MockEventBus) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
@@ -0,0 +1,6 @@
+package group
+
+// GetGroupQuery 获取用户组详情查询
+type GetGroupQuery struct {
+	GroupID uint
+}
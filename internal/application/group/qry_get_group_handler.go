@@ -0,0 +1,28 @@
+package group
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+)
+
+// GetGroupHandler 获取用户组详情查询处理器
+type GetGroupHandler struct {
+	groupQueryRepo group.QueryRepository
+}
+
+// NewGetGroupHandler 创建获取用户组详情查询处理器
+func NewGetGroupHandler(groupQueryRepo group.QueryRepository) *GetGroupHandler {
+	return &GetGroupHandler{
+		groupQueryRepo: groupQueryRepo,
+	}
+}
+
+// Handle 处理获取用户组详情查询
+func (h *GetGroupHandler) Handle(ctx context.Context, query GetGroupQuery) (*GroupDTO, error) {
+	g, err := h.groupQueryRepo.GetByID(ctx, query.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return ToGroupDTO(g), nil
+}
@@ -0,0 +1,14 @@
+package group
+
+// ListGroupMembersQuery 用户组成员列表查询
+type ListGroupMembersQuery struct {
+	GroupID uint
+	Page    int
+	Limit   int
+}
+
+// GetOffset 计算数据库查询偏移量
+func (q ListGroupMembersQuery) GetOffset() int {
+	page := max(q.Page, 1)
+	return (page - 1) * q.Limit
+}
@@ -0,0 +1,42 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+)
+
+// ListGroupMembersHandler 用户组成员列表查询处理器
+type ListGroupMembersHandler struct {
+	groupQueryRepo group.QueryRepository
+}
+
+// NewListGroupMembersHandler 创建用户组成员列表查询处理器
+func NewListGroupMembersHandler(groupQueryRepo group.QueryRepository) *ListGroupMembersHandler {
+	return &ListGroupMembersHandler{
+		groupQueryRepo: groupQueryRepo,
+	}
+}
+
+// Handle 处理用户组成员列表查询
+func (h *ListGroupMembersHandler) Handle(ctx context.Context, query ListGroupMembersQuery) (*GroupMemberListDTO, error) {
+	if _, err := h.groupQueryRepo.GetByID(ctx, query.GroupID); err != nil {
+		return nil, err
+	}
+
+	members, total, err := h.groupQueryRepo.ListMembers(ctx, query.GroupID, query.GetOffset(), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %w", err)
+	}
+
+	result := make([]*GroupMemberDTO, 0, len(members))
+	for _, m := range members {
+		result = append(result, ToGroupMemberDTO(m))
+	}
+
+	return &GroupMemberListDTO{
+		Members: result,
+		Total:   total,
+	}, nil
+}
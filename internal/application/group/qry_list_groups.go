@@ -0,0 +1,13 @@
+package group
+
+// ListGroupsQuery 用户组列表查询
+type ListGroupsQuery struct {
+	Page  int
+	Limit int
+}
+
+// GetOffset 计算数据库查询偏移量
+func (q ListGroupsQuery) GetOffset() int {
+	page := max(q.Page, 1)
+	return (page - 1) * q.Limit
+}
@@ -0,0 +1,38 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+)
+
+// ListGroupsHandler 用户组列表查询处理器
+type ListGroupsHandler struct {
+	groupQueryRepo group.QueryRepository
+}
+
+// NewListGroupsHandler 创建用户组列表查询处理器
+func NewListGroupsHandler(groupQueryRepo group.QueryRepository) *ListGroupsHandler {
+	return &ListGroupsHandler{
+		groupQueryRepo: groupQueryRepo,
+	}
+}
+
+// Handle 处理用户组列表查询
+func (h *ListGroupsHandler) Handle(ctx context.Context, query ListGroupsQuery) (*GroupListDTO, error) {
+	groups, total, err := h.groupQueryRepo.List(ctx, query.GetOffset(), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	result := make([]*GroupDTO, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, ToGroupDTO(g))
+	}
+
+	return &GroupListDTO{
+		Groups: result,
+		Total:  total,
+	}, nil
+}
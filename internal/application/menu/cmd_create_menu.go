@@ -8,4 +8,6 @@ type CreateMenuCommand struct {
 	ParentID *uint
 	Order    int
 	Visible  bool
+	// Permission 访问菜单所需的权限代码（支持通配符），空表示不限制
+	Permission string
 }
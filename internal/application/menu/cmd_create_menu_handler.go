@@ -11,16 +11,19 @@ import (
 type CreateMenuHandler struct {
 	menuCommandRepo menu.CommandRepository
 	menuQueryRepo   menu.QueryRepository
+	treeCache       menu.TreeCache
 }
 
 // NewCreateMenuHandler 创建 CreateMenuHandler 实例
 func NewCreateMenuHandler(
 	menuCommandRepo menu.CommandRepository,
 	menuQueryRepo menu.QueryRepository,
+	treeCache menu.TreeCache,
 ) *CreateMenuHandler {
 	return &CreateMenuHandler{
 		menuCommandRepo: menuCommandRepo,
 		menuQueryRepo:   menuQueryRepo,
+		treeCache:       treeCache,
 	}
 }
 
@@ -36,12 +39,16 @@ func (h *CreateMenuHandler) Handle(ctx context.Context, cmd CreateMenuCommand) (
 
 	// 2. 创建菜单实体
 	menuEntity := &menu.Menu{
-		Title:    cmd.Title,
-		Path:     cmd.Path,
-		Icon:     cmd.Icon,
-		ParentID: cmd.ParentID,
-		Order:    cmd.Order,
-		Visible:  cmd.Visible,
+		Title:      cmd.Title,
+		Path:       cmd.Path,
+		Icon:       cmd.Icon,
+		ParentID:   cmd.ParentID,
+		Order:      cmd.Order,
+		Visible:    cmd.Visible,
+		Permission: cmd.Permission,
+	}
+	if err := menuEntity.ValidatePermission(); err != nil {
+		return nil, err
 	}
 
 	// 3. 保存菜单
@@ -49,6 +56,9 @@ func (h *CreateMenuHandler) Handle(ctx context.Context, cmd CreateMenuCommand) (
 		return nil, fmt.Errorf("failed to create menu: %w", err)
 	}
 
+	// 4. 失效菜单树缓存
+	h.treeCache.Invalidate(ctx)
+
 	return &CreateMenuResultDTO{
 		ID: menuEntity.ID,
 	}, nil
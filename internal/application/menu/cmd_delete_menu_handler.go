@@ -12,16 +12,19 @@ import (
 type DeleteMenuHandler struct {
 	menuCommandRepo menu.CommandRepository
 	menuQueryRepo   menu.QueryRepository
+	treeCache       menu.TreeCache
 }
 
 // NewDeleteMenuHandler 创建 DeleteMenuHandler 实例
 func NewDeleteMenuHandler(
 	menuCommandRepo menu.CommandRepository,
 	menuQueryRepo menu.QueryRepository,
+	treeCache menu.TreeCache,
 ) *DeleteMenuHandler {
 	return &DeleteMenuHandler{
 		menuCommandRepo: menuCommandRepo,
 		menuQueryRepo:   menuQueryRepo,
+		treeCache:       treeCache,
 	}
 }
 
@@ -49,5 +52,8 @@ func (h *DeleteMenuHandler) Handle(ctx context.Context, cmd DeleteMenuCommand) e
 		return fmt.Errorf("failed to delete menu: %w", err)
 	}
 
+	// 4. 失效菜单树缓存
+	h.treeCache.Invalidate(ctx)
+
 	return nil
 }
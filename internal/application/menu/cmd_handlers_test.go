@@ -54,7 +54,7 @@ func TestCreateMenuHandler_Handle_Success(t *testing.T) {
 			}
 			mockCmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*menu.Menu")).Return(nil)
 
-			handler := NewCreateMenuHandler(mockCmdRepo, mockQryRepo)
+			handler := NewCreateMenuHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 			result, err := handler.Handle(context.Background(), tt.cmd)
 
 			require.NoError(t, err)
@@ -95,7 +95,7 @@ func TestCreateMenuHandler_Handle_Error(t *testing.T) {
 			mockQryRepo := new(MockMenuQueryRepository)
 			tt.setupMocks(mockCmdRepo, mockQryRepo)
 
-			handler := NewCreateMenuHandler(mockCmdRepo, mockQryRepo)
+			handler := NewCreateMenuHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 			result, err := handler.Handle(context.Background(), tt.cmd)
 
 			assert.Nil(t, result)
@@ -117,7 +117,7 @@ func TestUpdateMenuHandler_Handle_Success(t *testing.T) {
 	mockQryRepo.On("FindByID", mock.Anything, uint(1)).Return(existingMenu, nil)
 	mockCmdRepo.On("Update", mock.Anything, mock.AnythingOfType("*menu.Menu")).Return(nil)
 
-	handler := NewUpdateMenuHandler(mockCmdRepo, mockQryRepo)
+	handler := NewUpdateMenuHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 
 	result, err := handler.Handle(context.Background(), UpdateMenuCommand{
 		MenuID: 1,
@@ -180,7 +180,7 @@ func TestUpdateMenuHandler_Handle_Error(t *testing.T) {
 			mockQryRepo := new(MockMenuQueryRepository)
 			tt.setupMocks(mockCmdRepo, mockQryRepo)
 
-			handler := NewUpdateMenuHandler(mockCmdRepo, mockQryRepo)
+			handler := NewUpdateMenuHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 			result, err := handler.Handle(context.Background(), tt.cmd)
 
 			assert.Nil(t, result)
@@ -202,7 +202,7 @@ func TestDeleteMenuHandler_Handle_Success(t *testing.T) {
 	mockQryRepo.On("FindByParentID", mock.Anything, ptrUint(1)).Return([]*domainMenu.Menu{}, nil)
 	mockCmdRepo.On("Delete", mock.Anything, uint(1)).Return(nil)
 
-	handler := NewDeleteMenuHandler(mockCmdRepo, mockQryRepo)
+	handler := NewDeleteMenuHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 
 	err := handler.Handle(context.Background(), DeleteMenuCommand{MenuID: 1})
 
@@ -263,7 +263,7 @@ func TestDeleteMenuHandler_Handle_Error(t *testing.T) {
 			mockQryRepo := new(MockMenuQueryRepository)
 			tt.setupMocks(mockCmdRepo, mockQryRepo)
 
-			handler := NewDeleteMenuHandler(mockCmdRepo, mockQryRepo)
+			handler := NewDeleteMenuHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 			err := handler.Handle(context.Background(), tt.cmd)
 
 			require.Error(t, err)
@@ -284,7 +284,7 @@ func TestUpdateMenuHandler_ApplyUpdates_AllFields(t *testing.T) {
 	mockQryRepo.On("FindByID", mock.Anything, uint(1)).Return(existingMenu, nil)
 	mockCmdRepo.On("Update", mock.Anything, mock.AnythingOfType("*menu.Menu")).Return(nil)
 
-	handler := NewUpdateMenuHandler(mockCmdRepo, mockQryRepo)
+	handler := NewUpdateMenuHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 
 	result, err := handler.Handle(context.Background(), UpdateMenuCommand{
 		MenuID:   1,
@@ -313,7 +313,7 @@ func TestUpdateMenuHandler_ValidateParentID_ZeroValue(t *testing.T) {
 	mockQryRepo.On("FindByID", mock.Anything, uint(1)).Return(existingMenu, nil)
 	mockCmdRepo.On("Update", mock.Anything, mock.AnythingOfType("*menu.Menu")).Return(nil)
 
-	handler := NewUpdateMenuHandler(mockCmdRepo, mockQryRepo)
+	handler := NewUpdateMenuHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 
 	// ParentID = 0 表示设为顶级菜单，应该成功
 	result, err := handler.Handle(context.Background(), UpdateMenuCommand{
@@ -324,3 +324,21 @@ func TestUpdateMenuHandler_ValidateParentID_ZeroValue(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 }
+
+func TestCreateMenuHandler_Handle_InvalidPermission(t *testing.T) {
+	mockCmdRepo := new(MockMenuCommandRepository)
+	mockQryRepo := new(MockMenuQueryRepository)
+	mockCache := new(MockMenuTreeCache)
+
+	handler := NewCreateMenuHandler(mockCmdRepo, mockQryRepo, mockCache)
+	result, err := handler.Handle(context.Background(), CreateMenuCommand{
+		Title:      "Users",
+		Path:       "/users",
+		Permission: "admin:users",
+	})
+
+	assert.Nil(t, result)
+	require.ErrorIs(t, err, domainMenu.ErrInvalidMenuPermission)
+	mockCmdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockCache.AssertNotCalled(t, "Invalidate", mock.Anything)
+}
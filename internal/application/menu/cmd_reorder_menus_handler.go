@@ -11,16 +11,19 @@ import (
 type ReorderMenusHandler struct {
 	menuCommandRepo menu.CommandRepository
 	menuQueryRepo   menu.QueryRepository
+	treeCache       menu.TreeCache
 }
 
 // NewReorderMenusHandler 创建 ReorderMenusHandler 实例
 func NewReorderMenusHandler(
 	menuCommandRepo menu.CommandRepository,
 	menuQueryRepo menu.QueryRepository,
+	treeCache menu.TreeCache,
 ) *ReorderMenusHandler {
 	return &ReorderMenusHandler{
 		menuCommandRepo: menuCommandRepo,
 		menuQueryRepo:   menuQueryRepo,
+		treeCache:       treeCache,
 	}
 }
 
@@ -60,5 +63,8 @@ func (h *ReorderMenusHandler) Handle(ctx context.Context, cmd ReorderMenusComman
 		return fmt.Errorf("failed to update menu order: %w", err)
 	}
 
+	// 4. 排序变化影响所有用户的菜单树
+	h.treeCache.Invalidate(ctx)
+
 	return nil
 }
@@ -9,4 +9,6 @@ type UpdateMenuCommand struct {
 	ParentID *uint
 	Order    *int
 	Visible  *bool
+	// Permission 访问菜单所需的权限代码，传空字符串表示取消限制
+	Permission *string
 }
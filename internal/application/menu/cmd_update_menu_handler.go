@@ -12,16 +12,19 @@ import (
 type UpdateMenuHandler struct {
 	menuCommandRepo menu.CommandRepository
 	menuQueryRepo   menu.QueryRepository
+	treeCache       menu.TreeCache
 }
 
 // NewUpdateMenuHandler 创建 UpdateMenuHandler 实例
 func NewUpdateMenuHandler(
 	menuCommandRepo menu.CommandRepository,
 	menuQueryRepo menu.QueryRepository,
+	treeCache menu.TreeCache,
 ) *UpdateMenuHandler {
 	return &UpdateMenuHandler{
 		menuCommandRepo: menuCommandRepo,
 		menuQueryRepo:   menuQueryRepo,
+		treeCache:       treeCache,
 	}
 }
 
@@ -40,12 +43,18 @@ func (h *UpdateMenuHandler) Handle(ctx context.Context, cmd UpdateMenuCommand) (
 
 	// 3. 更新字段
 	h.applyUpdates(menuEntity, &cmd)
+	if err := menuEntity.ValidatePermission(); err != nil {
+		return nil, err
+	}
 
 	// 4. 保存更新
 	if err := h.menuCommandRepo.Update(ctx, menuEntity); err != nil {
 		return nil, fmt.Errorf("failed to update menu: %w", err)
 	}
 
+	// 5. 失效菜单树缓存
+	h.treeCache.Invalidate(ctx)
+
 	return ToMenuDTO(menuEntity), nil
 }
 
@@ -86,4 +95,7 @@ func (h *UpdateMenuHandler) applyUpdates(menuEntity *menu.Menu, cmd *UpdateMenuC
 	if cmd.Visible != nil {
 		menuEntity.Visible = *cmd.Visible
 	}
+	if cmd.Permission != nil {
+		menuEntity.Permission = *cmd.Permission
+	}
 }
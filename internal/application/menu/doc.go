@@ -13,6 +13,7 @@
 //
 //   - [query.GetMenuHandler]: 获取菜单详情
 //   - [query.ListMenusHandler]: 菜单树形列表查询
+//   - [query.ListUserMenusHandler]: 按调用者权限裁剪的菜单树（按权限集合缓存）
 //
 // # DTO 与映射
 //
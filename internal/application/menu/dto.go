@@ -1,18 +1,30 @@
 package menu
 
-import "time"
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+)
+
+// 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
+var (
+	ErrInvalidMenuPermission = menu.ErrInvalidMenuPermission
+)
 
 // MenuDTO 菜单响应 DTO
 type MenuDTO struct {
-	ID        uint      `json:"id"`
-	Title     string    `json:"title"`
-	Path      string    `json:"path"`
-	Icon      string    `json:"icon"`
-	ParentID  *uint     `json:"parent_id"`
-	Order     int       `json:"order"`
-	Visible   bool      `json:"visible"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Path     string `json:"path"`
+	Icon     string `json:"icon"`
+	ParentID *uint  `json:"parent_id"`
+	Order    int    `json:"order"`
+	Visible  bool   `json:"visible"`
+	// Permission 访问菜单所需的权限代码，空表示不限制
+	Permission string     `json:"permission,omitempty"`
+	Children   []*MenuDTO `json:"children,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
 }
 
 // CreateMenuResultDTO 创建菜单结果 DTO
@@ -10,7 +10,7 @@ func ToMenuDTO(menu *menu.Menu) *MenuDTO {
 		return nil
 	}
 
-	return &MenuDTO{
+	dto := &MenuDTO{
 		ID:        menu.ID,
 		Title:     menu.Title,
 		Path:      menu.Path,
@@ -20,5 +20,21 @@ func ToMenuDTO(menu *menu.Menu) *MenuDTO {
 		Visible:   menu.Visible,
 		CreatedAt: menu.CreatedAt,
 		UpdatedAt: menu.UpdatedAt,
+
+		Permission: menu.Permission,
+	}
+	if menu.HasChildren() {
+		dto.Children = ToMenuDTOs(menu.Children)
+	}
+
+	return dto
+}
+
+// ToMenuDTOs 将菜单树转换为 DTO 树
+func ToMenuDTOs(menus []*menu.Menu) []*MenuDTO {
+	dtos := make([]*MenuDTO, 0, len(menus))
+	for _, m := range menus {
+		dtos = append(dtos, ToMenuDTO(m))
 	}
+	return dtos
 }
@@ -117,3 +117,34 @@ func ptrInt(v int) *int {
 func ptrBool(v bool) *bool {
 	return &v
 }
+
+// ============================================================
+// MockMenuTreeCache
+// ============================================================
+
+type MockMenuTreeCache struct {
+	mock.Mock
+}
+
+func (m *MockMenuTreeCache) Get(ctx context.Context, permissionSetKey string) ([]*domainMenu.Menu, bool) {
+	args := m.Called(ctx, permissionSetKey)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1)
+	}
+	return args.Get(0).([]*domainMenu.Menu), args.Bool(1)
+}
+
+func (m *MockMenuTreeCache) Set(ctx context.Context, permissionSetKey string, menus []*domainMenu.Menu) {
+	m.Called(ctx, permissionSetKey, menus)
+}
+
+func (m *MockMenuTreeCache) Invalidate(ctx context.Context) {
+	m.Called(ctx)
+}
+
+// newMockTreeCache 创建允许任意失效调用的菜单树缓存 Mock
+func newMockTreeCache() *MockMenuTreeCache {
+	treeCache := new(MockMenuTreeCache)
+	treeCache.On("Invalidate", mock.Anything).Maybe()
+	return treeCache
+}
@@ -111,7 +111,7 @@ func TestReorderMenusHandler_Handle_Success(t *testing.T) {
 	mockQryRepo.On("FindByID", mock.Anything, uint(2)).Return(newTestMenu(2, "Menu2"), nil)
 	mockCmdRepo.On("UpdateOrder", mock.Anything, mock.AnythingOfType("[]struct { ID uint; Order int; ParentID *uint }")).Return(nil)
 
-	handler := NewReorderMenusHandler(mockCmdRepo, mockQryRepo)
+	handler := NewReorderMenusHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 
 	err := handler.Handle(context.Background(), ReorderMenusCommand{
 		Menus: []MenuItemCommand{
@@ -171,7 +171,7 @@ func TestReorderMenusHandler_Handle_Error(t *testing.T) {
 			mockQryRepo := new(MockMenuQueryRepository)
 			tt.setupMocks(mockCmdRepo, mockQryRepo)
 
-			handler := NewReorderMenusHandler(mockCmdRepo, mockQryRepo)
+			handler := NewReorderMenusHandler(mockCmdRepo, mockQryRepo, newMockTreeCache())
 			err := handler.Handle(context.Background(), tt.cmd)
 
 			require.Error(t, err)
@@ -179,3 +179,80 @@ func TestReorderMenusHandler_Handle_Error(t *testing.T) {
 		})
 	}
 }
+
+// ============================================================
+// ListUserMenusHandler Tests
+// ============================================================
+
+func TestListUserMenusHandler_Handle(t *testing.T) {
+	newTree := func() []*domainMenu.Menu {
+		system := newTestMenu(1, "System")
+		users := newTestMenuWithParent(2, "Users", 1)
+		users.Permission = "admin:users:read"
+		roles := newTestMenuWithParent(3, "Roles", 1)
+		roles.Permission = "admin:roles:*"
+		system.Children = []*domainMenu.Menu{users, roles}
+		return []*domainMenu.Menu{system, newTestMenu(4, "Profile")}
+	}
+
+	t.Run("缓存未命中时裁剪并写入缓存", func(t *testing.T) {
+		mockQryRepo := new(MockMenuQueryRepository)
+		mockCache := new(MockMenuTreeCache)
+		permissions := []string{"admin:users:read"}
+
+		mockCache.On("Get", mock.Anything, permissionSetKey(permissions)).Return(nil, false)
+		mockQryRepo.On("FindAll", mock.Anything).Return(newTree(), nil)
+		mockCache.On("Set", mock.Anything, permissionSetKey(permissions), mock.MatchedBy(func(menus []*domainMenu.Menu) bool {
+			return len(menus) == 2 && len(menus[0].Children) == 1
+		})).Return()
+
+		handler := NewListUserMenusHandler(mockQryRepo, mockCache)
+		result, err := handler.Handle(context.Background(), ListUserMenusQuery{Permissions: permissions})
+
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Len(t, result[0].Children, 1)
+		assert.Equal(t, "Users", result[0].Children[0].Title)
+		assert.Equal(t, "Profile", result[1].Title)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("缓存命中时不查询仓储", func(t *testing.T) {
+		mockQryRepo := new(MockMenuQueryRepository)
+		mockCache := new(MockMenuTreeCache)
+		permissions := []string{"admin:roles:read", "admin:roles:read"}
+
+		mockCache.On("Get", mock.Anything, permissionSetKey([]string{"admin:roles:read"})).
+			Return([]*domainMenu.Menu{newTestMenu(4, "Profile")}, true)
+
+		handler := NewListUserMenusHandler(mockQryRepo, mockCache)
+		result, err := handler.Handle(context.Background(), ListUserMenusQuery{Permissions: permissions})
+
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+		mockQryRepo.AssertNotCalled(t, "FindAll", mock.Anything)
+	})
+
+	t.Run("仓储错误", func(t *testing.T) {
+		mockQryRepo := new(MockMenuQueryRepository)
+		mockCache := new(MockMenuTreeCache)
+
+		mockCache.On("Get", mock.Anything, mock.Anything).Return(nil, false)
+		mockQryRepo.On("FindAll", mock.Anything).Return(nil, errors.New("database error"))
+
+		handler := NewListUserMenusHandler(mockQryRepo, mockCache)
+		result, err := handler.Handle(context.Background(), ListUserMenusQuery{})
+
+		assert.Nil(t, result)
+		require.Error(t, err)
+		mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPermissionSetKey(t *testing.T) {
+	assert.Equal(t,
+		permissionSetKey([]string{"a:b:c", "x:y:z"}),
+		permissionSetKey([]string{"x:y:z", "a:b:c", "x:y:z"}),
+	)
+	assert.NotEqual(t, permissionSetKey([]string{"a:b:c"}), permissionSetKey([]string{"a:b:d"}))
+}
@@ -26,11 +26,6 @@ func (h *ListMenusHandler) Handle(ctx context.Context, query ListMenusQuery) ([]
 		return nil, fmt.Errorf("failed to fetch menus: %w", err)
 	}
 
-	// 转换为 DTO
-	menuResponses := make([]*MenuDTO, 0, len(menus))
-	for _, m := range menus {
-		menuResponses = append(menuResponses, ToMenuDTO(m))
-	}
-
-	return menuResponses, nil
+	// 转换为 DTO（保留树形结构）
+	return ToMenuDTOs(menus), nil
 }
@@ -0,0 +1,7 @@
+package menu
+
+// ListUserMenusQuery 获取当前用户可访问的菜单树查询
+type ListUserMenusQuery struct {
+	// Permissions 调用者持有的权限代码（租户上下文中为成员权限）
+	Permissions []string
+}
@@ -0,0 +1,58 @@
+package menu
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+)
+
+// ListUserMenusHandler 获取用户菜单树查询处理器
+// 按调用者权限裁剪菜单树，并按权限集合缓存裁剪结果
+type ListUserMenusHandler struct {
+	menuQueryRepo menu.QueryRepository
+	treeCache     menu.TreeCache
+}
+
+// NewListUserMenusHandler 创建 ListUserMenusHandler 实例
+func NewListUserMenusHandler(menuQueryRepo menu.QueryRepository, treeCache menu.TreeCache) *ListUserMenusHandler {
+	return &ListUserMenusHandler{
+		menuQueryRepo: menuQueryRepo,
+		treeCache:     treeCache,
+	}
+}
+
+// Handle 处理获取用户菜单树查询
+func (h *ListUserMenusHandler) Handle(ctx context.Context, query ListUserMenusQuery) ([]*MenuDTO, error) {
+	key := permissionSetKey(query.Permissions)
+
+	// 1. 相同权限集合共享缓存
+	if menus, ok := h.treeCache.Get(ctx, key); ok {
+		return ToMenuDTOs(menus), nil
+	}
+
+	// 2. 加载完整菜单树并按权限裁剪
+	menus, err := h.menuQueryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch menus: %w", err)
+	}
+	pruned := menu.PruneTree(menus, query.Permissions)
+
+	h.treeCache.Set(ctx, key, pruned)
+
+	return ToMenuDTOs(pruned), nil
+}
+
+// permissionSetKey 计算权限集合的缓存键（与顺序、重复项无关）
+func permissionSetKey(permissions []string) string {
+	set := slices.Clone(permissions)
+	slices.Sort(set)
+	set = slices.Compact(set)
+
+	sum := sha256.Sum256([]byte(strings.Join(set, "\n")))
+	return hex.EncodeToString(sum[:16])
+}
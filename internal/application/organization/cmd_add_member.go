@@ -0,0 +1,8 @@
+package organization
+
+// AddMemberCommand 添加组织成员命令
+type AddMemberCommand struct {
+	OrganizationID uint
+	UserID         uint
+	RoleIDs        []uint
+}
@@ -0,0 +1,87 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// AddMemberHandler 添加组织成员命令处理器
+type AddMemberHandler struct {
+	orgCommandRepo organization.CommandRepository
+	orgQueryRepo   organization.QueryRepository
+	userQueryRepo  user.QueryRepository
+	roleQueryRepo  role.QueryRepository
+	eventBus       event.EventBus
+}
+
+// NewAddMemberHandler 创建添加组织成员命令处理器
+func NewAddMemberHandler(
+	orgCommandRepo organization.CommandRepository,
+	orgQueryRepo organization.QueryRepository,
+	userQueryRepo user.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	eventBus event.EventBus,
+) *AddMemberHandler {
+	return &AddMemberHandler{
+		orgCommandRepo: orgCommandRepo,
+		orgQueryRepo:   orgQueryRepo,
+		userQueryRepo:  userQueryRepo,
+		roleQueryRepo:  roleQueryRepo,
+		eventBus:       eventBus,
+	}
+}
+
+// Handle 处理添加组织成员命令
+func (h *AddMemberHandler) Handle(ctx context.Context, cmd AddMemberCommand) (*MemberDTO, error) {
+	// 1. 校验组织和用户
+	if _, err := h.orgQueryRepo.GetByID(ctx, cmd.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	exists, err := h.userQueryRepo.Exists(ctx, cmd.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	}
+	if !exists {
+		return nil, user.ErrUserNotFound
+	}
+
+	_, err = h.orgQueryRepo.GetMember(ctx, cmd.OrganizationID, cmd.UserID)
+	switch {
+	case err == nil:
+		return nil, organization.ErrMemberAlreadyExists
+	case !errors.Is(err, organization.ErrNotMember):
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+
+	// 2. 校验角色可在该组织内分配
+	member := &organization.Member{OrganizationID: cmd.OrganizationID, UserID: cmd.UserID}
+	roles, err := loadAssignableRoles(ctx, h.roleQueryRepo, member, cmd.RoleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 添加成员并分配角色
+	if err := h.orgCommandRepo.AddMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to add member: %w", err)
+	}
+	if len(cmd.RoleIDs) > 0 {
+		if err := h.orgCommandRepo.SetMemberRoles(ctx, cmd.OrganizationID, cmd.UserID, cmd.RoleIDs); err != nil {
+			return nil, fmt.Errorf("failed to set member roles: %w", err)
+		}
+	}
+	member.Roles = roles
+
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewOrganizationMemberChangedEvent(cmd.OrganizationID, cmd.UserID))
+	}
+
+	return ToMemberDTO(member), nil
+}
@@ -0,0 +1,8 @@
+package organization
+
+// CreateOrganizationCommand 创建组织命令
+type CreateOrganizationCommand struct {
+	Name        string
+	Slug        string
+	Description string
+}
@@ -0,0 +1,56 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// CreateOrganizationHandler 创建组织命令处理器
+type CreateOrganizationHandler struct {
+	orgCommandRepo organization.CommandRepository
+	orgQueryRepo   organization.QueryRepository
+}
+
+// NewCreateOrganizationHandler 创建组织命令处理器
+func NewCreateOrganizationHandler(
+	orgCommandRepo organization.CommandRepository,
+	orgQueryRepo organization.QueryRepository,
+) *CreateOrganizationHandler {
+	return &CreateOrganizationHandler{
+		orgCommandRepo: orgCommandRepo,
+		orgQueryRepo:   orgQueryRepo,
+	}
+}
+
+// Handle 处理创建组织命令
+func (h *CreateOrganizationHandler) Handle(ctx context.Context, cmd CreateOrganizationCommand) (*OrganizationDTO, error) {
+	// 1. 校验组织标识（统一小写，需可用作子域名）
+	slug := strings.ToLower(strings.TrimSpace(cmd.Slug))
+	if err := organization.ValidateSlug(slug); err != nil {
+		return nil, err
+	}
+
+	exists, err := h.orgQueryRepo.ExistsBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check organization slug: %w", err)
+	}
+	if exists {
+		return nil, organization.ErrSlugAlreadyExists
+	}
+
+	// 2. 创建组织
+	org := &organization.Organization{
+		Name:        cmd.Name,
+		Slug:        slug,
+		Description: cmd.Description,
+		Status:      organization.StatusActive,
+	}
+	if err := h.orgCommandRepo.Create(ctx, org); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return ToOrganizationDTO(org), nil
+}
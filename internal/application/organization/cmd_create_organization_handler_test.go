@@ -0,0 +1,74 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainOrg "github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+func TestCreateOrganizationHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name       string
+		cmd        CreateOrganizationCommand
+		setupMocks func(*MockOrganizationCommandRepository, *MockOrganizationQueryRepository)
+		wantErr    error
+		wantSlug   string
+	}{
+		{
+			name: "成功创建组织",
+			cmd:  CreateOrganizationCommand{Name: "Acme", Slug: " Acme-Corp "},
+			setupMocks: func(cmdRepo *MockOrganizationCommandRepository, qryRepo *MockOrganizationQueryRepository) {
+				qryRepo.On("ExistsBySlug", mock.Anything, "acme-corp").Return(false, nil)
+				cmdRepo.On("Create", mock.Anything, mock.MatchedBy(func(o *domainOrg.Organization) bool {
+					return o.Slug == "acme-corp" && o.Status == domainOrg.StatusActive
+				})).Return(nil)
+			},
+			wantSlug: "acme-corp",
+		},
+		{
+			name:       "组织标识格式无效",
+			cmd:        CreateOrganizationCommand{Name: "Acme", Slug: "acme.corp"},
+			setupMocks: func(_ *MockOrganizationCommandRepository, _ *MockOrganizationQueryRepository) {},
+			wantErr:    domainOrg.ErrInvalidSlug,
+		},
+		{
+			name: "组织标识已存在",
+			cmd:  CreateOrganizationCommand{Name: "Acme", Slug: "acme"},
+			setupMocks: func(_ *MockOrganizationCommandRepository, qryRepo *MockOrganizationQueryRepository) {
+				qryRepo.On("ExistsBySlug", mock.Anything, "acme").Return(true, nil)
+			},
+			wantErr: domainOrg.ErrSlugAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cmdRepo := new(MockOrganizationCommandRepository)
+			qryRepo := new(MockOrganizationQueryRepository)
+			tt.setupMocks(cmdRepo, qryRepo)
+
+			handler := NewCreateOrganizationHandler(cmdRepo, qryRepo)
+
+			// Act
+			result, err := handler.Handle(context.Background(), tt.cmd)
+
+			// Assert
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				cmdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSlug, result.Slug)
+			assert.NotZero(t, result.ID)
+			cmdRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package organization
+
+// DeleteOrganizationCommand 删除组织命令
+type DeleteOrganizationCommand struct {
+	OrganizationID uint
+}
@@ -0,0 +1,38 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// DeleteOrganizationHandler 删除组织命令处理器
+type DeleteOrganizationHandler struct {
+	orgCommandRepo organization.CommandRepository
+	orgQueryRepo   organization.QueryRepository
+}
+
+// NewDeleteOrganizationHandler 创建删除组织命令处理器
+func NewDeleteOrganizationHandler(
+	orgCommandRepo organization.CommandRepository,
+	orgQueryRepo organization.QueryRepository,
+) *DeleteOrganizationHandler {
+	return &DeleteOrganizationHandler{
+		orgCommandRepo: orgCommandRepo,
+		orgQueryRepo:   orgQueryRepo,
+	}
+}
+
+// Handle 处理删除组织命令
+// 成员关系随组织一并删除，成员的组织内权限缓存在 TTL 到期后失效；组织删除后租户解析即失败
+func (h *DeleteOrganizationHandler) Handle(ctx context.Context, cmd DeleteOrganizationCommand) error {
+	if _, err := h.orgQueryRepo.GetByID(ctx, cmd.OrganizationID); err != nil {
+		return err
+	}
+
+	if err := h.orgCommandRepo.Delete(ctx, cmd.OrganizationID); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package organization
+
+// RemoveMemberCommand 移除组织成员命令
+type RemoveMemberCommand struct {
+	OrganizationID uint
+	UserID         uint
+}
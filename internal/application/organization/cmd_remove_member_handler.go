@@ -0,0 +1,35 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// RemoveMemberHandler 移除组织成员命令处理器
+type RemoveMemberHandler struct {
+	orgCommandRepo organization.CommandRepository
+	eventBus       event.EventBus
+}
+
+// NewRemoveMemberHandler 创建移除组织成员命令处理器
+func NewRemoveMemberHandler(orgCommandRepo organization.CommandRepository, eventBus event.EventBus) *RemoveMemberHandler {
+	return &RemoveMemberHandler{
+		orgCommandRepo: orgCommandRepo,
+		eventBus:       eventBus,
+	}
+}
+
+// Handle 处理移除组织成员命令，非成员时返回 ErrNotMember
+func (h *RemoveMemberHandler) Handle(ctx context.Context, cmd RemoveMemberCommand) error {
+	if err := h.orgCommandRepo.RemoveMember(ctx, cmd.OrganizationID, cmd.UserID); err != nil {
+		return err
+	}
+
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewOrganizationMemberChangedEvent(cmd.OrganizationID, cmd.UserID))
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package organization
+
+// SetMemberRolesCommand 设置成员组织内角色命令
+type SetMemberRolesCommand struct {
+	OrganizationID uint
+	UserID         uint
+	RoleIDs        []uint
+}
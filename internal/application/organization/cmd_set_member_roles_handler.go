@@ -0,0 +1,81 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// SetMemberRolesHandler 设置成员组织内角色命令处理器
+type SetMemberRolesHandler struct {
+	orgCommandRepo organization.CommandRepository
+	orgQueryRepo   organization.QueryRepository
+	roleQueryRepo  role.QueryRepository
+	eventBus       event.EventBus
+}
+
+// NewSetMemberRolesHandler 创建设置成员组织内角色命令处理器
+func NewSetMemberRolesHandler(
+	orgCommandRepo organization.CommandRepository,
+	orgQueryRepo organization.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	eventBus event.EventBus,
+) *SetMemberRolesHandler {
+	return &SetMemberRolesHandler{
+		orgCommandRepo: orgCommandRepo,
+		orgQueryRepo:   orgQueryRepo,
+		roleQueryRepo:  roleQueryRepo,
+		eventBus:       eventBus,
+	}
+}
+
+// Handle 处理设置成员组织内角色命令（替换现有角色）
+func (h *SetMemberRolesHandler) Handle(ctx context.Context, cmd SetMemberRolesCommand) (*MemberDTO, error) {
+	member, err := h.orgQueryRepo.GetMember(ctx, cmd.OrganizationID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := loadAssignableRoles(ctx, h.roleQueryRepo, member, cmd.RoleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.orgCommandRepo.SetMemberRoles(ctx, cmd.OrganizationID, cmd.UserID, cmd.RoleIDs); err != nil {
+		return nil, fmt.Errorf("failed to set member roles: %w", err)
+	}
+	member.Roles = roles
+
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewOrganizationMemberChangedEvent(cmd.OrganizationID, cmd.UserID))
+	}
+
+	return ToMemberDTO(member), nil
+}
+
+// loadAssignableRoles 加载并校验角色：角色必须存在，且为全局角色或归属成员所在组织
+func loadAssignableRoles(ctx context.Context, roleQueryRepo role.QueryRepository, member *organization.Member, roleIDs []uint) ([]role.Role, error) {
+	roles := make([]role.Role, 0, len(roleIDs))
+	for _, id := range roleIDs {
+		r, err := roleQueryRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find role: %w", err)
+		}
+		if r == nil {
+			return nil, fmt.Errorf("%w: %d", role.ErrRoleNotFound, id)
+		}
+		// 租户内只能分配本组织角色，全局角色仅平台管理员可分配，避免组织管理员越权
+		if _, scoped := organization.TenantFromContext(ctx); scoped && r.IsGlobal() {
+			return nil, fmt.Errorf("%w: %s", organization.ErrRoleNotInOrganization, r.Name)
+		}
+		if !member.CanHoldRole(r) {
+			return nil, fmt.Errorf("%w: %s", organization.ErrRoleNotInOrganization, r.Name)
+		}
+		roles = append(roles, *r)
+	}
+	return roles, nil
+}
@@ -0,0 +1,166 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainOrg "github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+func TestSetMemberRolesHandler_Handle(t *testing.T) {
+	orgID, otherOrgID := uint(1), uint(2)
+	globalRole := &domainRole.Role{ID: 10, Name: "viewer"}
+	orgRole := &domainRole.Role{ID: 11, Name: "org-admin", OrganizationID: &orgID}
+	otherOrgRole := &domainRole.Role{ID: 12, Name: "other-admin", OrganizationID: &otherOrgID}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		cmd        SetMemberRolesCommand
+		setupMocks func(*MockOrganizationCommandRepository, *MockOrganizationQueryRepository, *MockRoleQueryRepository)
+		wantErr    error
+		wantRoles  int
+	}{
+		{
+			name: "分配全局角色和本组织角色",
+			cmd:  SetMemberRolesCommand{OrganizationID: orgID, UserID: 5, RoleIDs: []uint{10, 11}},
+			setupMocks: func(cmdRepo *MockOrganizationCommandRepository, qryRepo *MockOrganizationQueryRepository, roleRepo *MockRoleQueryRepository) {
+				qryRepo.On("GetMember", mock.Anything, orgID, uint(5)).Return(&domainOrg.Member{OrganizationID: orgID, UserID: 5}, nil)
+				roleRepo.On("FindByID", mock.Anything, uint(10)).Return(globalRole, nil)
+				roleRepo.On("FindByID", mock.Anything, uint(11)).Return(orgRole, nil)
+				cmdRepo.On("SetMemberRoles", mock.Anything, orgID, uint(5), []uint{10, 11}).Return(nil)
+			},
+			wantRoles: 2,
+		},
+		{
+			name: "不能分配其他组织的角色",
+			cmd:  SetMemberRolesCommand{OrganizationID: orgID, UserID: 5, RoleIDs: []uint{12}},
+			setupMocks: func(_ *MockOrganizationCommandRepository, qryRepo *MockOrganizationQueryRepository, roleRepo *MockRoleQueryRepository) {
+				qryRepo.On("GetMember", mock.Anything, orgID, uint(5)).Return(&domainOrg.Member{OrganizationID: orgID, UserID: 5}, nil)
+				roleRepo.On("FindByID", mock.Anything, uint(12)).Return(otherOrgRole, nil)
+			},
+			wantErr: domainOrg.ErrRoleNotInOrganization,
+		},
+		{
+			name: "租户内不能分配全局角色",
+			ctx:  domainOrg.WithTenant(context.Background(), orgID),
+			cmd:  SetMemberRolesCommand{OrganizationID: orgID, UserID: 5, RoleIDs: []uint{10}},
+			setupMocks: func(_ *MockOrganizationCommandRepository, qryRepo *MockOrganizationQueryRepository, roleRepo *MockRoleQueryRepository) {
+				qryRepo.On("GetMember", mock.Anything, orgID, uint(5)).Return(&domainOrg.Member{OrganizationID: orgID, UserID: 5}, nil)
+				roleRepo.On("FindByID", mock.Anything, uint(10)).Return(globalRole, nil)
+			},
+			wantErr: domainOrg.ErrRoleNotInOrganization,
+		},
+		{
+			name: "角色不存在",
+			cmd:  SetMemberRolesCommand{OrganizationID: orgID, UserID: 5, RoleIDs: []uint{99}},
+			setupMocks: func(_ *MockOrganizationCommandRepository, qryRepo *MockOrganizationQueryRepository, roleRepo *MockRoleQueryRepository) {
+				qryRepo.On("GetMember", mock.Anything, orgID, uint(5)).Return(&domainOrg.Member{OrganizationID: orgID, UserID: 5}, nil)
+				roleRepo.On("FindByID", mock.Anything, uint(99)).Return(nil, nil)
+			},
+			wantErr: domainRole.ErrRoleNotFound,
+		},
+		{
+			name: "用户不是组织成员",
+			cmd:  SetMemberRolesCommand{OrganizationID: orgID, UserID: 6, RoleIDs: []uint{10}},
+			setupMocks: func(_ *MockOrganizationCommandRepository, qryRepo *MockOrganizationQueryRepository, _ *MockRoleQueryRepository) {
+				qryRepo.On("GetMember", mock.Anything, orgID, uint(6)).Return(nil, domainOrg.ErrNotMember)
+			},
+			wantErr: domainOrg.ErrNotMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cmdRepo := new(MockOrganizationCommandRepository)
+			qryRepo := new(MockOrganizationQueryRepository)
+			roleRepo := new(MockRoleQueryRepository)
+			eventBus := new(MockEventBus)
+			eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+			tt.setupMocks(cmdRepo, qryRepo, roleRepo)
+
+			handler := NewSetMemberRolesHandler(cmdRepo, qryRepo, roleRepo, eventBus)
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			// Act
+			result, err := handler.Handle(ctx, tt.cmd)
+
+			// Assert
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				cmdRepo.AssertNotCalled(t, "SetMemberRoles", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, result.Roles, tt.wantRoles)
+			cmdRepo.AssertExpectations(t)
+			eventBus.AssertCalled(t, "Publish", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAddMemberHandler_Handle(t *testing.T) {
+	org := &domainOrg.Organization{ID: 1, Slug: "acme", Status: domainOrg.StatusActive}
+
+	t.Run("成功添加成员", func(t *testing.T) {
+		cmdRepo := new(MockOrganizationCommandRepository)
+		qryRepo := new(MockOrganizationQueryRepository)
+		userRepo := new(MockUserQueryRepository)
+		roleRepo := new(MockRoleQueryRepository)
+		eventBus := new(MockEventBus)
+
+		qryRepo.On("GetByID", mock.Anything, uint(1)).Return(org, nil)
+		userRepo.On("Exists", mock.Anything, uint(5)).Return(true, nil)
+		qryRepo.On("GetMember", mock.Anything, uint(1), uint(5)).Return(nil, domainOrg.ErrNotMember)
+		roleRepo.On("FindByID", mock.Anything, uint(10)).Return(&domainRole.Role{ID: 10, Name: "viewer"}, nil)
+		cmdRepo.On("AddMember", mock.Anything, mock.Anything).Return(nil)
+		cmdRepo.On("SetMemberRoles", mock.Anything, uint(1), uint(5), []uint{10}).Return(nil)
+		eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+		handler := NewAddMemberHandler(cmdRepo, qryRepo, userRepo, roleRepo, eventBus)
+		result, err := handler.Handle(context.Background(), AddMemberCommand{OrganizationID: 1, UserID: 5, RoleIDs: []uint{10}})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(5), result.UserID)
+		require.Len(t, result.Roles, 1)
+		assert.Equal(t, "viewer", result.Roles[0].Name)
+		cmdRepo.AssertExpectations(t)
+		eventBus.AssertExpectations(t)
+	})
+
+	t.Run("用户已是成员", func(t *testing.T) {
+		cmdRepo := new(MockOrganizationCommandRepository)
+		qryRepo := new(MockOrganizationQueryRepository)
+		userRepo := new(MockUserQueryRepository)
+
+		qryRepo.On("GetByID", mock.Anything, uint(1)).Return(org, nil)
+		userRepo.On("Exists", mock.Anything, uint(5)).Return(true, nil)
+		qryRepo.On("GetMember", mock.Anything, uint(1), uint(5)).Return(&domainOrg.Member{OrganizationID: 1, UserID: 5}, nil)
+
+		handler := NewAddMemberHandler(cmdRepo, qryRepo, userRepo, new(MockRoleQueryRepository), nil)
+		_, err := handler.Handle(context.Background(), AddMemberCommand{OrganizationID: 1, UserID: 5})
+
+		require.ErrorIs(t, err, domainOrg.ErrMemberAlreadyExists)
+		cmdRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("组织不存在", func(t *testing.T) {
+		qryRepo := new(MockOrganizationQueryRepository)
+		qryRepo.On("GetByID", mock.Anything, uint(9)).Return(nil, domainOrg.ErrOrganizationNotFound)
+
+		handler := NewAddMemberHandler(new(MockOrganizationCommandRepository), qryRepo, new(MockUserQueryRepository), new(MockRoleQueryRepository), nil)
+		_, err := handler.Handle(context.Background(), AddMemberCommand{OrganizationID: 9, UserID: 5})
+
+		require.ErrorIs(t, err, domainOrg.ErrOrganizationNotFound)
+	})
+}
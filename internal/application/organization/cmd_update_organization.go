@@ -0,0 +1,9 @@
+package organization
+
+// UpdateOrganizationCommand 更新组织命令
+type UpdateOrganizationCommand struct {
+	OrganizationID uint
+	Name           *string
+	Description    *string
+	Status         *string
+}
@@ -0,0 +1,52 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// UpdateOrganizationHandler 更新组织命令处理器
+type UpdateOrganizationHandler struct {
+	orgCommandRepo organization.CommandRepository
+	orgQueryRepo   organization.QueryRepository
+}
+
+// NewUpdateOrganizationHandler 创建更新组织命令处理器
+func NewUpdateOrganizationHandler(
+	orgCommandRepo organization.CommandRepository,
+	orgQueryRepo organization.QueryRepository,
+) *UpdateOrganizationHandler {
+	return &UpdateOrganizationHandler{
+		orgCommandRepo: orgCommandRepo,
+		orgQueryRepo:   orgQueryRepo,
+	}
+}
+
+// Handle 处理更新组织命令
+func (h *UpdateOrganizationHandler) Handle(ctx context.Context, cmd UpdateOrganizationCommand) (*OrganizationDTO, error) {
+	org, err := h.orgQueryRepo.GetByID(ctx, cmd.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Name != nil {
+		org.Name = *cmd.Name
+	}
+	if cmd.Description != nil {
+		org.Description = *cmd.Description
+	}
+	if cmd.Status != nil {
+		if !organization.IsValidStatus(*cmd.Status) {
+			return nil, fmt.Errorf("invalid organization status: %s", *cmd.Status)
+		}
+		org.Status = *cmd.Status
+	}
+
+	if err := h.orgCommandRepo.Update(ctx, org); err != nil {
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return ToOrganizationDTO(org), nil
+}
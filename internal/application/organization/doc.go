@@ -0,0 +1,23 @@
+// Package organization 实现组织（多租户）管理的应用层用例。
+//
+// 本包提供 CQRS 模式的 Command 和 Query Handler：
+//
+// # Command（写操作）
+//
+//   - [CreateOrganizationHandler]: 创建组织
+//   - [UpdateOrganizationHandler]: 更新组织信息与状态
+//   - [DeleteOrganizationHandler]: 删除组织
+//   - [AddMemberHandler]: 添加组织成员
+//   - [RemoveMemberHandler]: 移除组织成员
+//   - [SetMemberRolesHandler]: 设置成员在组织内的角色
+//
+// # Query（读操作）
+//
+//   - [GetOrganizationHandler]: 获取组织详情
+//   - [ListOrganizationsHandler]: 组织分页列表
+//   - [ListMembersHandler]: 组织成员分页列表
+//   - [ListUserOrganizationsHandler]: 用户加入的组织
+//   - [ResolveTenantHandler]: 根据请求头或子域名中的组织标识解析租户
+//
+// 成员变更会发布 OrganizationMemberChangedEvent，由缓存失效处理器清除成员在该组织内的权限缓存。
+package organization
@@ -0,0 +1,100 @@
+package organization
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
+var (
+	ErrOrganizationNotFound  = organization.ErrOrganizationNotFound
+	ErrSlugAlreadyExists     = organization.ErrSlugAlreadyExists
+	ErrInvalidSlug           = organization.ErrInvalidSlug
+	ErrOrganizationSuspended = organization.ErrOrganizationSuspended
+	ErrMemberAlreadyExists   = organization.ErrMemberAlreadyExists
+	ErrNotMember             = organization.ErrNotMember
+	ErrRoleNotInOrganization = organization.ErrRoleNotInOrganization
+
+	ErrUserNotFound = user.ErrUserNotFound
+	ErrRoleNotFound = role.ErrRoleNotFound
+)
+
+// 重新导出租户上下文函数供 Adapters 层中间件使用
+var (
+	WithTenant        = organization.WithTenant
+	TenantFromContext = organization.TenantFromContext
+)
+
+// CreateOrganizationDTO 创建组织 DTO
+type CreateOrganizationDTO struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Slug        string `json:"slug" binding:"required,max=63"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+// UpdateOrganizationDTO 更新组织 DTO（组织标识用作子域名，创建后不可修改）
+type UpdateOrganizationDTO struct {
+	Name        *string `json:"name" binding:"omitempty,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=255"`
+	Status      *string `json:"status" binding:"omitempty,oneof=active suspended"`
+}
+
+// AddMemberDTO 添加组织成员 DTO
+type AddMemberDTO struct {
+	UserID  uint   `json:"user_id" binding:"required,gt=0"`
+	RoleIDs []uint `json:"role_ids" binding:"omitempty,dive,gt=0"`
+}
+
+// SetMemberRolesDTO 设置成员组织内角色 DTO
+type SetMemberRolesDTO struct {
+	RoleIDs []uint `json:"role_ids" binding:"omitempty,dive,gt=0"`
+}
+
+// OrganizationDTO 组织响应 DTO
+type OrganizationDTO struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// OrganizationListDTO 组织列表响应 DTO
+type OrganizationListDTO struct {
+	Organizations []*OrganizationDTO `json:"organizations"`
+	Total         int64              `json:"total"`
+}
+
+// MemberRoleDTO 成员在组织内持有的角色
+type MemberRoleDTO struct {
+	ID             uint   `json:"id"`
+	Name           string `json:"name"`
+	DisplayName    string `json:"display_name"`
+	OrganizationID *uint  `json:"organization_id,omitempty"`
+}
+
+// MemberDTO 组织成员响应 DTO
+type MemberDTO struct {
+	OrganizationID uint             `json:"organization_id"`
+	UserID         uint             `json:"user_id"`
+	Roles          []*MemberRoleDTO `json:"roles"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+// MemberListDTO 组织成员列表响应 DTO
+type MemberListDTO struct {
+	Members []*MemberDTO `json:"members"`
+	Total   int64        `json:"total"`
+}
+
+// TenantDTO 解析出的租户
+type TenantDTO struct {
+	OrganizationID uint   `json:"organization_id"`
+	Slug           string `json:"slug"`
+	Name           string `json:"name"`
+}
@@ -0,0 +1,59 @@
+package organization
+
+import (
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// ToOrganizationDTO 将组织实体转换为 DTO
+func ToOrganizationDTO(org *organization.Organization) *OrganizationDTO {
+	if org == nil {
+		return nil
+	}
+
+	return &OrganizationDTO{
+		ID:          org.ID,
+		Name:        org.Name,
+		Slug:        org.Slug,
+		Description: org.Description,
+		Status:      org.Status,
+		CreatedAt:   org.CreatedAt,
+		UpdatedAt:   org.UpdatedAt,
+	}
+}
+
+// ToMemberDTO 将成员实体转换为 DTO
+func ToMemberDTO(member *organization.Member) *MemberDTO {
+	if member == nil {
+		return nil
+	}
+
+	roles := make([]*MemberRoleDTO, 0, len(member.Roles))
+	for _, r := range member.Roles {
+		roles = append(roles, &MemberRoleDTO{
+			ID:             r.ID,
+			Name:           r.Name,
+			DisplayName:    r.DisplayName,
+			OrganizationID: r.OrganizationID,
+		})
+	}
+
+	return &MemberDTO{
+		OrganizationID: member.OrganizationID,
+		UserID:         member.UserID,
+		Roles:          roles,
+		CreatedAt:      member.CreatedAt,
+	}
+}
+
+// ToTenantDTO 将组织实体转换为租户 DTO
+func ToTenantDTO(org *organization.Organization) *TenantDTO {
+	if org == nil {
+		return nil
+	}
+
+	return &TenantDTO{
+		OrganizationID: org.ID,
+		Slug:           org.Slug,
+		Name:           org.Name,
+	}
+}
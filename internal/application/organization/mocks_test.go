@@ -0,0 +1,342 @@
+//nolint:forcetypeassert // Mock 返回值类型在测试中总是已知的
+package organization
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	domainEvent "github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	domainOrg "github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// MockOrganizationCommandRepository 组织写仓储 Mock
+type MockOrganizationCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationCommandRepository) Create(ctx context.Context, org *domainOrg.Organization) error {
+	args := m.Called(ctx, org)
+	if args.Error(0) == nil {
+		org.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *MockOrganizationCommandRepository) Update(ctx context.Context, org *domainOrg.Organization) error {
+	args := m.Called(ctx, org)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationCommandRepository) AddMember(ctx context.Context, member *domainOrg.Member) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationCommandRepository) RemoveMember(ctx context.Context, organizationID, userID uint) error {
+	args := m.Called(ctx, organizationID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationCommandRepository) SetMemberRoles(ctx context.Context, organizationID, userID uint, roleIDs []uint) error {
+	args := m.Called(ctx, organizationID, userID, roleIDs)
+	return args.Error(0)
+}
+
+// MockOrganizationQueryRepository 组织读仓储 Mock
+type MockOrganizationQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationQueryRepository) GetByID(ctx context.Context, id uint) (*domainOrg.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainOrg.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationQueryRepository) GetBySlug(ctx context.Context, slug string) (*domainOrg.Organization, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainOrg.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationQueryRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	args := m.Called(ctx, slug)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOrganizationQueryRepository) List(ctx context.Context, offset, limit int) ([]*domainOrg.Organization, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domainOrg.Organization), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOrganizationQueryRepository) ListByUser(ctx context.Context, userID uint) ([]*domainOrg.Organization, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainOrg.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationQueryRepository) GetMember(ctx context.Context, organizationID, userID uint) (*domainOrg.Member, error) {
+	args := m.Called(ctx, organizationID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainOrg.Member), args.Error(1)
+}
+
+func (m *MockOrganizationQueryRepository) ListMembers(ctx context.Context, organizationID uint, offset, limit int) ([]*domainOrg.Member, int64, error) {
+	args := m.Called(ctx, organizationID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domainOrg.Member), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOrganizationQueryRepository) GetUserIDsByRole(ctx context.Context, roleID uint) ([]uint, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+// MockUserQueryRepository 用户读仓储 Mock
+type MockUserQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserQueryRepository) GetByID(ctx context.Context, id uint) (*domainUser.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByUsername(ctx context.Context, username string) (*domainUser.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByEmail(ctx context.Context, email string) (*domainUser.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByUsernameWithRoles(ctx context.Context, username string) (*domainUser.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByEmailWithRoles(ctx context.Context, email string) (*domainUser.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetByIDWithRoles(ctx context.Context, id uint) (*domainUser.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	args := m.Called(ctx, username)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) List(ctx context.Context, offset, limit int) ([]*domainUser.User, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) Search(ctx context.Context, keyword string, offset, limit int) ([]*domainUser.User, error) {
+	args := m.Called(ctx, keyword, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) CountBySearch(ctx context.Context, keyword string) (int64, error) {
+	args := m.Called(ctx, keyword)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) ListByCriteria(ctx context.Context, criteria domainUser.ListCriteria) ([]*domainUser.User, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) CountByCriteria(ctx context.Context, criteria domainUser.ListCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetRoles(ctx context.Context, userID uint) ([]uint, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) GetUserIDsByRole(ctx context.Context, roleID uint) ([]uint, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+// MockRoleQueryRepository 角色读仓储 Mock
+type MockRoleQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleQueryRepository) FindByID(ctx context.Context, id uint) (*domainRole.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByName(ctx context.Context, name string) (*domainRole.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByIDWithPermissions(ctx context.Context, id uint) (*domainRole.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) List(ctx context.Context, page, limit int) ([]domainRole.Role, int64, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domainRole.Role), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRoleQueryRepository) GetPermissions(ctx context.Context, roleID uint) ([]domainRole.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetHierarchy(ctx context.Context) (domainRole.Hierarchy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domainRole.Hierarchy), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetEffectivePermissions(ctx context.Context, roleID uint) ([]domainRole.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetGrants(ctx context.Context, roleIDs []uint) ([]domainRole.Grant, error) {
+	args := m.Called(ctx, roleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Grant), args.Error(1)
+}
+
+// MockEventBus 事件总线 Mock
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, events ...domainEvent.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Subscribe(eventName string, handler domainEvent.EventHandler) {
+	m.Called(eventName, handler)
+}
+
+func (m *MockEventBus) Unsubscribe(eventName string, handler domainEvent.EventHandler) {
+	m.Called(eventName, handler)
+}
+
+func (m *MockEventBus) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
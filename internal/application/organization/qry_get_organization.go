@@ -0,0 +1,6 @@
+package organization
+
+// GetOrganizationQuery 获取组织详情查询
+type GetOrganizationQuery struct {
+	OrganizationID uint
+}
@@ -0,0 +1,28 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// GetOrganizationHandler 获取组织详情查询处理器
+type GetOrganizationHandler struct {
+	orgQueryRepo organization.QueryRepository
+}
+
+// NewGetOrganizationHandler 创建获取组织详情查询处理器
+func NewGetOrganizationHandler(orgQueryRepo organization.QueryRepository) *GetOrganizationHandler {
+	return &GetOrganizationHandler{
+		orgQueryRepo: orgQueryRepo,
+	}
+}
+
+// Handle 处理获取组织详情查询
+func (h *GetOrganizationHandler) Handle(ctx context.Context, query GetOrganizationQuery) (*OrganizationDTO, error) {
+	org, err := h.orgQueryRepo.GetByID(ctx, query.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	return ToOrganizationDTO(org), nil
+}
@@ -0,0 +1,14 @@
+package organization
+
+// ListMembersQuery 组织成员列表查询
+type ListMembersQuery struct {
+	OrganizationID uint
+	Page           int
+	Limit          int
+}
+
+// GetOffset 计算数据库查询偏移量
+func (q ListMembersQuery) GetOffset() int {
+	page := max(q.Page, 1)
+	return (page - 1) * q.Limit
+}
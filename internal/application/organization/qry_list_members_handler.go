@@ -0,0 +1,42 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// ListMembersHandler 组织成员列表查询处理器
+type ListMembersHandler struct {
+	orgQueryRepo organization.QueryRepository
+}
+
+// NewListMembersHandler 创建组织成员列表查询处理器
+func NewListMembersHandler(orgQueryRepo organization.QueryRepository) *ListMembersHandler {
+	return &ListMembersHandler{
+		orgQueryRepo: orgQueryRepo,
+	}
+}
+
+// Handle 处理组织成员列表查询
+func (h *ListMembersHandler) Handle(ctx context.Context, query ListMembersQuery) (*MemberListDTO, error) {
+	if _, err := h.orgQueryRepo.GetByID(ctx, query.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	members, total, err := h.orgQueryRepo.ListMembers(ctx, query.OrganizationID, query.GetOffset(), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	result := make([]*MemberDTO, 0, len(members))
+	for _, m := range members {
+		result = append(result, ToMemberDTO(m))
+	}
+
+	return &MemberListDTO{
+		Members: result,
+		Total:   total,
+	}, nil
+}
@@ -0,0 +1,13 @@
+package organization
+
+// ListOrganizationsQuery 组织列表查询
+type ListOrganizationsQuery struct {
+	Page  int
+	Limit int
+}
+
+// GetOffset 计算数据库查询偏移量
+func (q ListOrganizationsQuery) GetOffset() int {
+	page := max(q.Page, 1)
+	return (page - 1) * q.Limit
+}
@@ -0,0 +1,38 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// ListOrganizationsHandler 组织列表查询处理器
+type ListOrganizationsHandler struct {
+	orgQueryRepo organization.QueryRepository
+}
+
+// NewListOrganizationsHandler 创建组织列表查询处理器
+func NewListOrganizationsHandler(orgQueryRepo organization.QueryRepository) *ListOrganizationsHandler {
+	return &ListOrganizationsHandler{
+		orgQueryRepo: orgQueryRepo,
+	}
+}
+
+// Handle 处理组织列表查询
+func (h *ListOrganizationsHandler) Handle(ctx context.Context, query ListOrganizationsQuery) (*OrganizationListDTO, error) {
+	orgs, total, err := h.orgQueryRepo.List(ctx, query.GetOffset(), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	result := make([]*OrganizationDTO, 0, len(orgs))
+	for _, org := range orgs {
+		result = append(result, ToOrganizationDTO(org))
+	}
+
+	return &OrganizationListDTO{
+		Organizations: result,
+		Total:         total,
+	}, nil
+}
@@ -0,0 +1,6 @@
+package organization
+
+// ListUserOrganizationsQuery 获取用户加入的组织查询
+type ListUserOrganizationsQuery struct {
+	UserID uint
+}
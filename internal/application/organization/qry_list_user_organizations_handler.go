@@ -0,0 +1,34 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// ListUserOrganizationsHandler 获取用户加入的组织查询处理器
+type ListUserOrganizationsHandler struct {
+	orgQueryRepo organization.QueryRepository
+}
+
+// NewListUserOrganizationsHandler 创建获取用户加入的组织查询处理器
+func NewListUserOrganizationsHandler(orgQueryRepo organization.QueryRepository) *ListUserOrganizationsHandler {
+	return &ListUserOrganizationsHandler{
+		orgQueryRepo: orgQueryRepo,
+	}
+}
+
+// Handle 处理获取用户加入的组织查询
+func (h *ListUserOrganizationsHandler) Handle(ctx context.Context, query ListUserOrganizationsQuery) ([]*OrganizationDTO, error) {
+	orgs, err := h.orgQueryRepo.ListByUser(ctx, query.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user organizations: %w", err)
+	}
+
+	result := make([]*OrganizationDTO, 0, len(orgs))
+	for _, org := range orgs {
+		result = append(result, ToOrganizationDTO(org))
+	}
+	return result, nil
+}
@@ -0,0 +1,7 @@
+package organization
+
+// ResolveTenantQuery 解析租户查询
+// Identifier 来自请求头或子域名，可以是组织 ID 或组织标识（slug）
+type ResolveTenantQuery struct {
+	Identifier string
+}
@@ -0,0 +1,48 @@
+package organization
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+// ResolveTenantHandler 解析租户查询处理器
+type ResolveTenantHandler struct {
+	orgQueryRepo organization.QueryRepository
+}
+
+// NewResolveTenantHandler 创建解析租户查询处理器
+func NewResolveTenantHandler(orgQueryRepo organization.QueryRepository) *ResolveTenantHandler {
+	return &ResolveTenantHandler{
+		orgQueryRepo: orgQueryRepo,
+	}
+}
+
+// Handle 处理解析租户查询
+// 组织不存在返回 ErrOrganizationNotFound，组织已停用返回 ErrOrganizationSuspended
+func (h *ResolveTenantHandler) Handle(ctx context.Context, query ResolveTenantQuery) (*TenantDTO, error) {
+	identifier := strings.ToLower(strings.TrimSpace(query.Identifier))
+	if identifier == "" {
+		return nil, organization.ErrOrganizationNotFound
+	}
+
+	var (
+		org *organization.Organization
+		err error
+	)
+	if id, parseErr := strconv.ParseUint(identifier, 10, 32); parseErr == nil {
+		org, err = h.orgQueryRepo.GetByID(ctx, uint(id))
+	} else {
+		org, err = h.orgQueryRepo.GetBySlug(ctx, identifier)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !org.IsActive() {
+		return nil, organization.ErrOrganizationSuspended
+	}
+	return ToTenantDTO(org), nil
+}
@@ -0,0 +1,81 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainOrg "github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+)
+
+func TestResolveTenantHandler_Handle(t *testing.T) {
+	active := &domainOrg.Organization{ID: 3, Name: "Acme", Slug: "acme", Status: domainOrg.StatusActive}
+	suspended := &domainOrg.Organization{ID: 4, Name: "Globex", Slug: "globex", Status: domainOrg.StatusSuspended}
+
+	tests := []struct {
+		name       string
+		identifier string
+		setupMocks func(*MockOrganizationQueryRepository)
+		wantErr    error
+		wantID     uint
+	}{
+		{
+			name:       "按组织标识解析",
+			identifier: "ACME",
+			setupMocks: func(repo *MockOrganizationQueryRepository) {
+				repo.On("GetBySlug", mock.Anything, "acme").Return(active, nil)
+			},
+			wantID: 3,
+		},
+		{
+			name:       "按组织 ID 解析",
+			identifier: "3",
+			setupMocks: func(repo *MockOrganizationQueryRepository) {
+				repo.On("GetByID", mock.Anything, uint(3)).Return(active, nil)
+			},
+			wantID: 3,
+		},
+		{
+			name:       "组织已停用",
+			identifier: "globex",
+			setupMocks: func(repo *MockOrganizationQueryRepository) {
+				repo.On("GetBySlug", mock.Anything, "globex").Return(suspended, nil)
+			},
+			wantErr: domainOrg.ErrOrganizationSuspended,
+		},
+		{
+			name:       "组织不存在",
+			identifier: "initech",
+			setupMocks: func(repo *MockOrganizationQueryRepository) {
+				repo.On("GetBySlug", mock.Anything, "initech").Return(nil, domainOrg.ErrOrganizationNotFound)
+			},
+			wantErr: domainOrg.ErrOrganizationNotFound,
+		},
+		{
+			name:       "空标识",
+			identifier: "  ",
+			setupMocks: func(_ *MockOrganizationQueryRepository) {},
+			wantErr:    domainOrg.ErrOrganizationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockOrganizationQueryRepository)
+			tt.setupMocks(repo)
+
+			result, err := NewResolveTenantHandler(repo).Handle(context.Background(), ResolveTenantQuery{Identifier: tt.identifier})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, result.OrganizationID)
+		})
+	}
+}
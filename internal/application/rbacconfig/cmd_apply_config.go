@@ -0,0 +1,8 @@
+package rbacconfig
+
+// ApplyConfigCommand 将数据库收敛到配置状态的命令
+type ApplyConfigCommand struct {
+	Document *Document
+	// Prune 是否删除配置中未声明的角色与菜单
+	Prune bool
+}
@@ -0,0 +1,68 @@
+package rbacconfig
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+)
+
+// ApplyConfigHandler 应用配置命令处理器
+type ApplyConfigHandler struct {
+	unitOfWork UnitOfWork
+	treeCache  menu.TreeCache
+	eventBus   event.EventBus
+}
+
+// NewApplyConfigHandler 创建 ApplyConfigHandler 实例
+func NewApplyConfigHandler(unitOfWork UnitOfWork, treeCache menu.TreeCache, eventBus event.EventBus) *ApplyConfigHandler {
+	return &ApplyConfigHandler{
+		unitOfWork: unitOfWork,
+		treeCache:  treeCache,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle 处理应用配置命令，返回已执行的变更
+//
+// 差异在事务内重新计算，保证执行的正是事务所见状态的差异；任一步骤失败整体回滚。
+func (h *ApplyConfigHandler) Handle(ctx context.Context, cmd ApplyConfigCommand) (*PlanDTO, error) {
+	var (
+		applied      *plan
+		affectedRole []uint
+	)
+
+	err := h.unitOfWork(ctx, func(repos Repositories) error {
+		s, err := loadState(ctx, repos)
+		if err != nil {
+			return err
+		}
+		p, err := buildPlan(cmd.Document, s, cmd.Prune)
+		if err != nil {
+			return err
+		}
+		if affectedRole, err = p.execute(ctx, repos, s); err != nil {
+			return err
+		}
+		applied = p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 事务提交后再失效缓存，避免并发请求读到旧数据后重新写入缓存
+	if applied.touchesMenus() {
+		h.treeCache.Invalidate(ctx)
+	}
+	if h.eventBus != nil && len(affectedRole) > 0 {
+		evts := make([]event.Event, 0, len(affectedRole))
+		for _, roleID := range affectedRole {
+			evts = append(evts, events.NewRolePermissionsChangedEvent(roleID, nil))
+		}
+		_ = h.eventBus.Publish(ctx, evts...) // 缓存失效失败不阻塞业务
+	}
+
+	return &applied.dto, nil
+}
@@ -0,0 +1,137 @@
+package rbacconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	domainMenu "github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+type applyMocks struct {
+	roleCmd   *MockRoleCommandRepository
+	roleQry   *MockRoleQueryRepository
+	permCmd   *MockPermissionCommandRepository
+	permQry   *MockPermissionQueryRepository
+	menuCmd   *MockMenuCommandRepository
+	menuQry   *MockMenuQueryRepository
+	treeCache *MockMenuTreeCache
+	eventBus  *MockEventBus
+}
+
+func newApplyMocks() *applyMocks {
+	m := &applyMocks{
+		roleCmd:   new(MockRoleCommandRepository),
+		roleQry:   new(MockRoleQueryRepository),
+		permCmd:   new(MockPermissionCommandRepository),
+		permQry:   new(MockPermissionQueryRepository),
+		menuCmd:   new(MockMenuCommandRepository),
+		menuQry:   new(MockMenuQueryRepository),
+		treeCache: new(MockMenuTreeCache),
+		eventBus:  new(MockEventBus),
+	}
+
+	m.permQry.On("ListAll", mock.Anything).Return([]role.Permission{
+		{ID: 10, Code: "admin:users:read", Description: "Read users"},
+	}, nil)
+	m.roleQry.On("List", mock.Anything, 1, rolePageSize).Return([]role.Role{
+		{ID: 1, Name: "admin", IsSystem: true},
+	}, int64(1), nil)
+	m.roleQry.On("GetGrants", mock.Anything, []uint{1}).Return([]role.Grant{
+		{RoleID: 1, PermissionID: 10, PermissionCode: "admin:users:read"},
+	}, nil)
+	m.menuQry.On("FindAll", mock.Anything).Return([]*domainMenu.Menu{}, nil)
+	return m
+}
+
+// handler 使用直接调用 fn 的工作单元；committed 记录事务是否成功提交
+func (m *applyMocks) handler(committed *bool) *ApplyConfigHandler {
+	unitOfWork := func(ctx context.Context, fn func(repos Repositories) error) error {
+		err := fn(Repositories{
+			RoleCommand:       m.roleCmd,
+			RoleQuery:         m.roleQry,
+			PermissionCommand: m.permCmd,
+			PermissionQuery:   m.permQry,
+			MenuCommand:       m.menuCmd,
+			MenuQuery:         m.menuQry,
+		})
+		*committed = err == nil
+		return err
+	}
+	return NewApplyConfigHandler(unitOfWork, m.treeCache, m.eventBus)
+}
+
+func newApplyDocument() *Document {
+	return &Document{
+		Roles: []RoleSpec{
+			{Name: "admin", System: true, Permissions: []string{"admin:users:read"}},
+			{Name: "viewer", DisplayName: "Viewer", Permissions: []string{"admin:users:read"}},
+		},
+		Menus: []MenuSpec{{Title: "Users", Path: "/users", Permission: "admin:users:read"}},
+	}
+}
+
+func TestApplyConfigHandler_Handle_Success(t *testing.T) {
+	m := newApplyMocks()
+	m.roleCmd.On("Create", mock.Anything, mock.MatchedBy(func(r *role.Role) bool {
+		return r.Name == "viewer" && r.DisplayName == "Viewer"
+	})).Return(nil)
+	m.roleCmd.On("SetPermissions", mock.Anything, uint(1), []uint{10}).Return(nil)
+	m.menuCmd.On("Create", mock.Anything, mock.MatchedBy(func(menu *domainMenu.Menu) bool {
+		return menu.Path == "/users" && menu.Visible && menu.ParentID == nil
+	})).Return(nil)
+	m.treeCache.On("Invalidate", mock.Anything).Return()
+	m.eventBus.On("Publish", mock.Anything, mock.MatchedBy(func(evts []event.Event) bool {
+		if len(evts) != 1 {
+			return false
+		}
+		evt, ok := evts[0].(*events.RolePermissionsChangedEvent)
+		return ok && evt.RoleID == 1
+	})).Return(nil)
+
+	var committed bool
+	result, err := m.handler(&committed).Handle(context.Background(), ApplyConfigCommand{Document: newApplyDocument()})
+
+	require.NoError(t, err)
+	assert.True(t, committed)
+	assert.Len(t, result.Changes, 3)
+	m.roleCmd.AssertExpectations(t)
+	m.menuCmd.AssertExpectations(t)
+	m.treeCache.AssertExpectations(t)
+	m.eventBus.AssertExpectations(t)
+}
+
+func TestApplyConfigHandler_Handle_RollbackOnError(t *testing.T) {
+	m := newApplyMocks()
+	m.roleCmd.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.roleCmd.On("SetPermissions", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+	var committed bool
+	result, err := m.handler(&committed).Handle(context.Background(), ApplyConfigCommand{Document: newApplyDocument()})
+
+	assert.Nil(t, result)
+	require.Error(t, err)
+	assert.False(t, committed)
+	m.menuCmd.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	m.treeCache.AssertNotCalled(t, "Invalidate", mock.Anything)
+	m.eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestApplyConfigHandler_Handle_InvalidConfig(t *testing.T) {
+	m := newApplyMocks()
+	doc := newApplyDocument()
+	doc.Roles[1].Permissions = []string{"admin:unknown:read"}
+
+	var committed bool
+	_, err := m.handler(&committed).Handle(context.Background(), ApplyConfigCommand{Document: doc})
+
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	m.roleCmd.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
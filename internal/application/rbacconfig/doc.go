@@ -0,0 +1,26 @@
+// Package rbacconfig 实现 RBAC 声明式配置（配置即代码）的应用层用例。
+//
+// 角色、权限、角色授权（含策略条件）与菜单可以保存在 git 中的 YAML 文件里，
+// 由 `rbac` 命令与数据库对比和收敛：
+//
+//   - [PlanConfigHandler]: 计算配置与数据库的差异（rbac plan）
+//   - [ApplyConfigHandler]: 在单个事务中收敛数据库到配置状态（rbac apply）
+//   - [ExportConfigHandler]: 从数据库导出配置文件（rbac export）
+//
+// # 配置语义
+//
+//   - 权限：只创建缺失的权限、更新说明，不删除（路由声明的权限由 permissions sync 管理）
+//   - 角色：按名称匹配，仅管理全局角色；授权列表为完整集合，未列出的授权会被移除
+//   - 菜单：按路径匹配，层级由 YAML 嵌套决定
+//   - 未在配置中声明的角色与菜单默认只提示，指定 Prune 时才删除
+//
+// # 系统角色
+//
+// [role.Role.IsSystemRole] 为真的角色受保护：不会被创建、删除或修改，
+// 配置与数据库不一致时仅输出警告。配置中标记 system 的角色必须已存在且为系统角色。
+//
+// # 事务与事件
+//
+// Apply 通过 [UnitOfWork] 在单个事务内重新计算差异并执行写入，
+// 提交成功后为受影响的角色发布 RolePermissionsChangedEvent 并失效菜单树缓存。
+package rbacconfig
@@ -0,0 +1,84 @@
+package rbacconfig
+
+import "errors"
+
+// ErrInvalidConfig 配置文件内容无效
+var ErrInvalidConfig = errors.New("invalid rbac config")
+
+// 变更动作
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// 变更对象类型
+const (
+	KindPermission = "permission"
+	KindRole       = "role"
+	KindGrant      = "grant"
+	KindMenu       = "menu"
+)
+
+// Document RBAC 声明式配置文档
+type Document struct {
+	Permissions []PermissionSpec `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	Roles       []RoleSpec       `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Menus       []MenuSpec       `json:"menus,omitempty" yaml:"menus,omitempty"`
+}
+
+// PermissionSpec 权限声明
+type PermissionSpec struct {
+	Code        string `json:"code" yaml:"code"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// RoleSpec 角色声明
+type RoleSpec struct {
+	Name        string `json:"name" yaml:"name"`
+	DisplayName string `json:"display_name,omitempty" yaml:"display_name,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Parent 父角色名称，为空表示不继承
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// System 标记系统角色（只读，仅用于导出往返）
+	System bool `json:"system,omitempty" yaml:"system,omitempty"`
+	// Permissions 授予的权限代码（完整集合）
+	Permissions []string `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	// Conditions 授权附加的策略条件，键为权限代码
+	Conditions map[string]string `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+}
+
+// MenuSpec 菜单声明，子菜单通过嵌套表达
+type MenuSpec struct {
+	Title      string     `json:"title" yaml:"title"`
+	Path       string     `json:"path" yaml:"path"`
+	Icon       string     `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Order      int        `json:"order,omitempty" yaml:"order,omitempty"`
+	Visible    *bool      `json:"visible,omitempty" yaml:"visible,omitempty"`
+	Permission string     `json:"permission,omitempty" yaml:"permission,omitempty"`
+	Children   []MenuSpec `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// IsVisible 返回菜单是否可见（未声明时默认可见）
+func (s MenuSpec) IsVisible() bool {
+	return s.Visible == nil || *s.Visible
+}
+
+// ChangeDTO 单项变更
+type ChangeDTO struct {
+	Action  string   `json:"action"`
+	Kind    string   `json:"kind"`
+	Key     string   `json:"key"`
+	Details []string `json:"details,omitempty"`
+}
+
+// PlanDTO 配置与数据库的差异
+type PlanDTO struct {
+	Changes  []ChangeDTO `json:"changes"`
+	Warnings []string    `json:"warnings,omitempty"`
+}
+
+// HasChanges 检查是否存在需要执行的变更
+func (p *PlanDTO) HasChanges() bool {
+	return len(p.Changes) > 0
+}
@@ -0,0 +1,143 @@
+package rbacconfig
+
+import (
+	"context"
+	"fmt"
+)
+
+// execute 按依赖顺序执行计划，返回需要失效权限缓存的角色 ID
+//
+// 顺序：权限 → 新角色 → 角色属性与继承 → 授权 → 菜单（创建、更新、删除）→ 删除角色
+func (p *plan) execute(ctx context.Context, repos Repositories, s *state) ([]uint, error) {
+	permissionIDs := make(map[string]uint, len(s.permissions))
+	for code, perm := range s.permissions {
+		permissionIDs[code] = perm.ID
+	}
+	roleIDs := make(map[string]uint, len(s.roles))
+	for name, r := range s.roles {
+		roleIDs[name] = r.ID
+	}
+	affected := newIDSet()
+
+	for _, def := range p.permissionCreates {
+		perm := def.NewPermission()
+		if err := repos.PermissionCommand.Create(ctx, perm); err != nil {
+			return nil, fmt.Errorf("failed to create permission %s: %w", def.Code, err)
+		}
+		permissionIDs[perm.Code] = perm.ID
+	}
+	for _, perm := range p.permissionUpdates {
+		if err := repos.PermissionCommand.Update(ctx, perm); err != nil {
+			return nil, fmt.Errorf("failed to update permission %s: %w", perm.Code, err)
+		}
+	}
+
+	for _, r := range p.roleCreates {
+		if err := repos.RoleCommand.Create(ctx, r); err != nil {
+			return nil, fmt.Errorf("failed to create role %s: %w", r.Name, err)
+		}
+		roleIDs[r.Name] = r.ID
+	}
+	for _, u := range p.roleUpdates {
+		u.role.ParentID = nil
+		if u.parent != "" {
+			parentID := roleIDs[u.parent]
+			u.role.ParentID = &parentID
+		}
+		if err := repos.RoleCommand.Update(ctx, u.role); err != nil {
+			return nil, fmt.Errorf("failed to update role %s: %w", u.role.Name, err)
+		}
+		affected.add(u.role.ID)
+	}
+
+	for _, g := range p.grantUpdates {
+		roleID := roleIDs[g.role]
+		if g.replace {
+			ids := make([]uint, 0, len(g.codes))
+			for _, code := range g.codes {
+				ids = append(ids, permissionIDs[code])
+			}
+			if err := repos.RoleCommand.SetPermissions(ctx, roleID, ids); err != nil {
+				return nil, fmt.Errorf("failed to set permissions of role %s: %w", g.role, err)
+			}
+		}
+		for code, condition := range g.conditions {
+			if err := repos.RoleCommand.SetPermissionCondition(ctx, roleID, permissionIDs[code], condition); err != nil {
+				return nil, fmt.Errorf("failed to set condition of role %s on %s: %w", g.role, code, err)
+			}
+		}
+		affected.add(roleID)
+	}
+
+	if err := p.executeMenus(ctx, repos, s); err != nil {
+		return nil, err
+	}
+
+	for _, r := range p.roleDeletes {
+		if err := repos.RoleCommand.Delete(ctx, r.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete role %s: %w", r.Name, err)
+		}
+		// 删除角色会解除子角色的继承，子角色的有效权限随之变化
+		affected.add(r.ID)
+		for _, child := range s.roles {
+			if child.HasParent() && *child.ParentID == r.ID {
+				affected.add(child.ID)
+			}
+		}
+	}
+
+	return affected.ids, nil
+}
+
+// executeMenus 执行菜单变更：先自上而下创建，再更新，最后自下而上删除
+func (p *plan) executeMenus(ctx context.Context, repos Repositories, s *state) error {
+	menuIDs := make(map[string]uint, len(s.menus))
+	for _, n := range s.menus {
+		menuIDs[n.menu.Path] = n.menu.ID
+	}
+	parentOf := func(parentPath string) *uint {
+		if parentPath == "" {
+			return nil
+		}
+		id := menuIDs[parentPath]
+		return &id
+	}
+
+	for _, w := range p.menuCreates {
+		w.menu.ParentID = parentOf(w.parentPath)
+		if err := repos.MenuCommand.Create(ctx, w.menu); err != nil {
+			return fmt.Errorf("failed to create menu %s: %w", w.menu.Path, err)
+		}
+		menuIDs[w.menu.Path] = w.menu.ID
+	}
+	for _, w := range p.menuUpdates {
+		w.menu.ParentID = parentOf(w.parentPath)
+		if err := repos.MenuCommand.Update(ctx, w.menu); err != nil {
+			return fmt.Errorf("failed to update menu %s: %w", w.menu.Path, err)
+		}
+	}
+	for _, m := range p.menuDeletes {
+		if err := repos.MenuCommand.Delete(ctx, m.ID); err != nil {
+			return fmt.Errorf("failed to delete menu %s: %w", m.Path, err)
+		}
+	}
+	return nil
+}
+
+// idSet 保持插入顺序的去重 ID 集合
+type idSet struct {
+	seen map[uint]struct{}
+	ids  []uint
+}
+
+func newIDSet() *idSet {
+	return &idSet{seen: make(map[uint]struct{})}
+}
+
+func (s *idSet) add(id uint) {
+	if _, ok := s.seen[id]; ok {
+		return
+	}
+	s.seen[id] = struct{}{}
+	s.ids = append(s.ids, id)
+}
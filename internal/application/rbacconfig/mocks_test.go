@@ -0,0 +1,337 @@
+//nolint:forcetypeassert // Mock 返回值类型在测试中总是已知的
+package rbacconfig
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	domainMenu "github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// MockRoleCommandRepository 角色写仓储 Mock
+type MockRoleCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleCommandRepository) Create(ctx context.Context, role *role.Role) error {
+	args := m.Called(ctx, role)
+	// 模拟数据库生成 ID
+	if args.Error(0) == nil && role.ID == 0 {
+		role.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *MockRoleCommandRepository) Update(ctx context.Context, role *role.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *MockRoleCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRoleCommandRepository) SetPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	args := m.Called(ctx, roleID, permissionIDs)
+	return args.Error(0)
+}
+
+func (m *MockRoleCommandRepository) AddPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	args := m.Called(ctx, roleID, permissionIDs)
+	return args.Error(0)
+}
+
+func (m *MockRoleCommandRepository) RemovePermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	args := m.Called(ctx, roleID, permissionIDs)
+	return args.Error(0)
+}
+
+func (m *MockRoleCommandRepository) SetPermissionCondition(ctx context.Context, roleID, permissionID uint, condition string) error {
+	args := m.Called(ctx, roleID, permissionID, condition)
+	return args.Error(0)
+}
+
+// MockRoleQueryRepository 角色读仓储 Mock
+type MockRoleQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleQueryRepository) FindByID(ctx context.Context, id uint) (*role.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*role.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByName(ctx context.Context, name string) (*role.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*role.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByIDWithPermissions(ctx context.Context, id uint) (*role.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*role.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) List(ctx context.Context, page, limit int) ([]role.Role, int64, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]role.Role), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRoleQueryRepository) GetPermissions(ctx context.Context, roleID uint) ([]role.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetHierarchy(ctx context.Context) (role.Hierarchy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(role.Hierarchy), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetEffectivePermissions(ctx context.Context, roleID uint) ([]role.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetGrants(ctx context.Context, roleIDs []uint) ([]role.Grant, error) {
+	args := m.Called(ctx, roleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Grant), args.Error(1)
+}
+
+// MockPermissionCommandRepository 权限写仓储 Mock
+type MockPermissionCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockPermissionCommandRepository) Create(ctx context.Context, permission *role.Permission) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionCommandRepository) Update(ctx context.Context, permission *role.Permission) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockPermissionQueryRepository 权限读仓储 Mock
+type MockPermissionQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockPermissionQueryRepository) FindByID(ctx context.Context, id uint) (*role.Permission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*role.Permission), args.Error(1)
+}
+
+func (m *MockPermissionQueryRepository) FindByCode(ctx context.Context, code string) (*role.Permission, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*role.Permission), args.Error(1)
+}
+
+func (m *MockPermissionQueryRepository) FindByIDs(ctx context.Context, ids []uint) ([]role.Permission, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Permission), args.Error(1)
+}
+
+func (m *MockPermissionQueryRepository) List(ctx context.Context, page, limit int) ([]role.Permission, int64, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]role.Permission), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPermissionQueryRepository) ListAll(ctx context.Context) ([]role.Permission, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Permission), args.Error(1)
+}
+
+func (m *MockPermissionQueryRepository) ListByResource(ctx context.Context, resource string) ([]role.Permission, error) {
+	args := m.Called(ctx, resource)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Permission), args.Error(1)
+}
+
+func (m *MockPermissionQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPermissionQueryRepository) ExistsByCode(ctx context.Context, code string) (bool, error) {
+	args := m.Called(ctx, code)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockEventBus 事件总线 Mock
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, events ...event.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Subscribe(eventName string, handler event.EventHandler) {
+	m.Called(eventName, handler)
+}
+
+func (m *MockEventBus) Unsubscribe(eventName string, handler event.EventHandler) {
+	m.Called(eventName, handler)
+}
+
+func (m *MockEventBus) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// ============================================================
+// MockMenuCommandRepository
+// ============================================================
+
+type MockMenuCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockMenuCommandRepository) Create(ctx context.Context, menu *domainMenu.Menu) error {
+	args := m.Called(ctx, menu)
+	// 模拟数据库分配 ID
+	if menu.ID == 0 {
+		menu.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *MockMenuCommandRepository) Update(ctx context.Context, menu *domainMenu.Menu) error {
+	args := m.Called(ctx, menu)
+	return args.Error(0)
+}
+
+func (m *MockMenuCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMenuCommandRepository) UpdateOrder(ctx context.Context, menus []struct {
+	ID       uint
+	Order    int
+	ParentID *uint
+}) error {
+	args := m.Called(ctx, menus)
+	return args.Error(0)
+}
+
+// ============================================================
+// MockMenuQueryRepository
+// ============================================================
+
+type MockMenuQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockMenuQueryRepository) FindByID(ctx context.Context, id uint) (*domainMenu.Menu, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainMenu.Menu), args.Error(1)
+}
+
+func (m *MockMenuQueryRepository) FindAll(ctx context.Context) ([]*domainMenu.Menu, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainMenu.Menu), args.Error(1)
+}
+
+func (m *MockMenuQueryRepository) FindByParentID(ctx context.Context, parentID *uint) ([]*domainMenu.Menu, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainMenu.Menu), args.Error(1)
+}
+
+// ============================================================
+// 测试辅助函数
+// ============================================================
+
+// ============================================================
+// MockMenuTreeCache
+// ============================================================
+
+type MockMenuTreeCache struct {
+	mock.Mock
+}
+
+func (m *MockMenuTreeCache) Get(ctx context.Context, permissionSetKey string) ([]*domainMenu.Menu, bool) {
+	args := m.Called(ctx, permissionSetKey)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1)
+	}
+	return args.Get(0).([]*domainMenu.Menu), args.Bool(1)
+}
+
+func (m *MockMenuTreeCache) Set(ctx context.Context, permissionSetKey string, menus []*domainMenu.Menu) {
+	m.Called(ctx, permissionSetKey, menus)
+}
+
+func (m *MockMenuTreeCache) Invalidate(ctx context.Context) {
+	m.Called(ctx)
+}
@@ -0,0 +1,466 @@
+package rbacconfig
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// plan 差异计算结果：dto 用于展示，其余字段供 Apply 按顺序执行
+type plan struct {
+	dto PlanDTO
+
+	permissionCreates []role.PermissionDefinition
+	permissionUpdates []*role.Permission
+	roleCreates       []*role.Role
+	roleUpdates       []roleUpdate
+	grantUpdates      []grantUpdate
+	menuCreates       []menuWrite
+	menuUpdates       []menuWrite
+	menuDeletes       []*menu.Menu // 子菜单在前
+	roleDeletes       []*role.Role
+}
+
+// roleUpdate 角色属性或父角色变更（父角色按名称在执行时解析，可能是本次新建的角色）
+type roleUpdate struct {
+	role   *role.Role
+	parent string
+}
+
+// grantUpdate 角色授权变更
+type grantUpdate struct {
+	role       string
+	codes      []string          // 期望的完整授权集合
+	replace    bool              // 授权集合发生变化
+	conditions map[string]string // 需要写入的策略条件（空字符串表示移除）
+}
+
+// menuWrite 菜单创建或更新（父菜单按路径在执行时解析）
+type menuWrite struct {
+	menu       *menu.Menu
+	parentPath string
+}
+
+// touchesMenus 检查计划是否修改菜单
+func (p *plan) touchesMenus() bool {
+	return len(p.menuCreates)+len(p.menuUpdates)+len(p.menuDeletes) > 0
+}
+
+func (p *plan) addChange(action, kind, key string, details ...string) {
+	p.dto.Changes = append(p.dto.Changes, ChangeDTO{Action: action, Kind: kind, Key: key, Details: details})
+}
+
+func (p *plan) warn(format string, args ...any) {
+	p.dto.Warnings = append(p.dto.Warnings, fmt.Sprintf(format, args...))
+}
+
+// buildPlan 计算配置文档与当前状态的差异
+func buildPlan(doc *Document, s *state, prune bool) (*plan, error) {
+	if err := validateDocument(doc); err != nil {
+		return nil, err
+	}
+
+	p := &plan{dto: PlanDTO{Changes: []ChangeDTO{}}}
+	known := p.planPermissions(doc, s)
+	if err := p.planRoles(doc, s, known, prune); err != nil {
+		return nil, err
+	}
+	p.planMenus(doc, s, prune)
+	return p, nil
+}
+
+// planPermissions 计算权限差异，返回配置可引用的全部权限代码
+func (p *plan) planPermissions(doc *Document, s *state) map[string]bool {
+	known := make(map[string]bool, len(s.permissions)+len(doc.Permissions))
+	for code := range s.permissions {
+		known[code] = true
+	}
+
+	for _, spec := range doc.Permissions {
+		known[spec.Code] = true
+		current, ok := s.permissions[spec.Code]
+		switch {
+		case !ok:
+			p.permissionCreates = append(p.permissionCreates, role.PermissionDefinition{Code: spec.Code, Description: spec.Description})
+			p.addChange(ActionCreate, KindPermission, spec.Code)
+		case current.Description != spec.Description:
+			updated := *current
+			updated.Description = spec.Description
+			p.permissionUpdates = append(p.permissionUpdates, &updated)
+			p.addChange(ActionUpdate, KindPermission, spec.Code, fieldChange("description", current.Description, spec.Description))
+		}
+	}
+	return known
+}
+
+// planRoles 计算角色、继承关系与授权差异
+func (p *plan) planRoles(doc *Document, s *state, known map[string]bool, prune bool) error {
+	declared := make(map[string]RoleSpec, len(doc.Roles))
+	for _, spec := range doc.Roles {
+		declared[spec.Name] = spec
+	}
+
+	desiredParents := make(map[string]string, len(s.roles)+len(doc.Roles))
+	for name, r := range s.roles {
+		desiredParents[name] = s.parentName(r)
+	}
+
+	for _, spec := range doc.Roles {
+		if err := checkRoleReferences(spec, s, declared, known, prune); err != nil {
+			return err
+		}
+
+		current, exists := s.roles[spec.Name]
+		if spec.System && (!exists || !current.IsSystemRole()) {
+			return fmt.Errorf("%w: role %q is declared as system role but no such system role exists", ErrInvalidConfig, spec.Name)
+		}
+
+		if !exists {
+			p.planNewRole(spec)
+			desiredParents[spec.Name] = spec.Parent
+			continue
+		}
+
+		details := roleDetails(current, s.parentName(current), spec)
+		grants := diffGrants(s.grants[current.ID], spec)
+		if current.IsSystemRole() {
+			if len(details) > 0 || grants != nil {
+				p.warn("system role %q differs from config; system roles are not modified", spec.Name)
+			}
+			continue
+		}
+
+		desiredParents[spec.Name] = spec.Parent
+		if len(details) > 0 {
+			updated := *current
+			updated.Permissions = nil
+			updated.DisplayName = spec.DisplayName
+			updated.Description = spec.Description
+			p.roleUpdates = append(p.roleUpdates, roleUpdate{role: &updated, parent: spec.Parent})
+			p.addChange(ActionUpdate, KindRole, spec.Name, details...)
+		}
+		if grants != nil {
+			p.grantUpdates = append(p.grantUpdates, grants.update)
+			p.addChange(ActionUpdate, KindGrant, spec.Name, grants.details...)
+		}
+	}
+
+	if name, ok := findCycle(desiredParents); ok {
+		return fmt.Errorf("%w: role %q: %w", ErrInvalidConfig, name, role.ErrRoleHierarchyCycle)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(s.roles)) {
+		r := s.roles[name]
+		if _, ok := declared[name]; ok || r.IsSystemRole() {
+			continue
+		}
+		if !prune {
+			p.warn("role %q is not declared in config (prune to delete)", name)
+			continue
+		}
+		p.roleDeletes = append(p.roleDeletes, r)
+		p.addChange(ActionDelete, KindRole, name)
+	}
+	return nil
+}
+
+// planNewRole 计划创建角色及其授权
+func (p *plan) planNewRole(spec RoleSpec) {
+	created := &role.Role{Name: spec.Name, DisplayName: spec.DisplayName, Description: spec.Description}
+	p.roleCreates = append(p.roleCreates, created)
+
+	var details []string
+	if spec.DisplayName != "" {
+		details = append(details, "display_name: "+spec.DisplayName)
+	}
+	if spec.Parent != "" {
+		details = append(details, "parent: "+spec.Parent)
+		p.roleUpdates = append(p.roleUpdates, roleUpdate{role: created, parent: spec.Parent})
+	}
+	p.addChange(ActionCreate, KindRole, spec.Name, details...)
+
+	if grants := diffGrants(nil, spec); grants != nil {
+		p.grantUpdates = append(p.grantUpdates, grants.update)
+		p.addChange(ActionCreate, KindGrant, spec.Name, grants.details...)
+	}
+}
+
+// checkRoleReferences 检查角色引用的权限与父角色是否存在
+func checkRoleReferences(spec RoleSpec, s *state, declared map[string]RoleSpec, known map[string]bool, prune bool) error {
+	for _, code := range spec.Permissions {
+		if !known[code] {
+			return fmt.Errorf("%w: role %q references unknown permission %q", ErrInvalidConfig, spec.Name, code)
+		}
+	}
+
+	if spec.Parent == "" {
+		return nil
+	}
+	if _, ok := declared[spec.Parent]; ok {
+		return nil
+	}
+	parent, ok := s.roles[spec.Parent]
+	if !ok {
+		return fmt.Errorf("%w: role %q references unknown parent %q", ErrInvalidConfig, spec.Name, spec.Parent)
+	}
+	if prune && !parent.IsSystemRole() {
+		return fmt.Errorf("%w: parent %q of role %q is not declared and would be pruned", ErrInvalidConfig, spec.Parent, spec.Name)
+	}
+	return nil
+}
+
+// roleDetails 比较角色属性与父角色
+func roleDetails(current *role.Role, currentParent string, spec RoleSpec) []string {
+	var details []string
+	if current.DisplayName != spec.DisplayName {
+		details = append(details, fieldChange("display_name", current.DisplayName, spec.DisplayName))
+	}
+	if current.Description != spec.Description {
+		details = append(details, fieldChange("description", current.Description, spec.Description))
+	}
+	if currentParent != spec.Parent {
+		details = append(details, fieldChange("parent", currentParent, spec.Parent))
+	}
+	return details
+}
+
+// grantDiff 授权差异
+type grantDiff struct {
+	update  grantUpdate
+	details []string
+}
+
+// diffGrants 比较当前授权（code -> condition）与声明，无差异时返回 nil
+func diffGrants(current map[string]string, spec RoleSpec) *grantDiff {
+	desired := make(map[string]string, len(spec.Permissions))
+	for _, code := range spec.Permissions {
+		desired[code] = strings.TrimSpace(spec.Conditions[code])
+	}
+
+	diff := &grantDiff{update: grantUpdate{role: spec.Name, conditions: make(map[string]string)}}
+	for _, code := range slices.Sorted(maps.Keys(desired)) {
+		condition := desired[code]
+		existing, granted := current[code]
+		switch {
+		case !granted:
+			diff.update.replace = true
+			if condition != "" {
+				diff.update.conditions[code] = condition
+				diff.details = append(diff.details, fmt.Sprintf("+%s [%s]", code, condition))
+			} else {
+				diff.details = append(diff.details, "+"+code)
+			}
+		case existing != condition:
+			diff.update.conditions[code] = condition
+			diff.details = append(diff.details, fmt.Sprintf("~%s condition: %q -> %q", code, existing, condition))
+		}
+	}
+	for _, code := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := desired[code]; !ok {
+			diff.update.replace = true
+			diff.details = append(diff.details, "-"+code)
+		}
+	}
+
+	if len(diff.details) == 0 {
+		return nil
+	}
+	diff.update.codes = slices.Sorted(maps.Keys(desired))
+	return diff
+}
+
+// findCycle 检查角色名称到父角色名称的映射中是否存在环，返回环上的一个角色
+func findCycle(parents map[string]string) (string, bool) {
+	for _, start := range slices.Sorted(maps.Keys(parents)) {
+		visited := map[string]struct{}{start: {}}
+		for current := parents[start]; current != ""; current = parents[current] {
+			if current == start {
+				return start, true
+			}
+			if _, seen := visited[current]; seen {
+				break
+			}
+			visited[current] = struct{}{}
+		}
+	}
+	return "", false
+}
+
+// planMenus 计算菜单差异
+func (p *plan) planMenus(doc *Document, s *state, prune bool) {
+	declared := make(map[string]struct{})
+	walkMenuSpecs(doc.Menus, "", func(spec MenuSpec, parentPath string) {
+		declared[spec.Path] = struct{}{}
+
+		desired := &menu.Menu{
+			Title:      spec.Title,
+			Path:       spec.Path,
+			Icon:       spec.Icon,
+			Order:      spec.Order,
+			Visible:    spec.IsVisible(),
+			Permission: spec.Permission,
+		}
+
+		current, ok := s.menuByPath(spec.Path)
+		if !ok {
+			p.menuCreates = append(p.menuCreates, menuWrite{menu: desired, parentPath: parentPath})
+			p.addChange(ActionCreate, KindMenu, spec.Path, menuCreateDetails(desired, parentPath)...)
+			return
+		}
+
+		details := menuDetails(current, desired, parentPath)
+		if len(details) == 0 {
+			return
+		}
+		updated := *current.menu
+		updated.Children = nil
+		updated.Title, updated.Icon, updated.Order = desired.Title, desired.Icon, desired.Order
+		updated.Visible, updated.Permission = desired.Visible, desired.Permission
+		p.menuUpdates = append(p.menuUpdates, menuWrite{menu: &updated, parentPath: parentPath})
+		p.addChange(ActionUpdate, KindMenu, spec.Path, details...)
+	})
+
+	for i := len(s.menus) - 1; i >= 0; i-- {
+		m := s.menus[i].menu
+		if _, ok := declared[m.Path]; ok {
+			continue
+		}
+		if !prune {
+			p.warn("menu %q is not declared in config (prune to delete)", m.Path)
+			continue
+		}
+		p.menuDeletes = append(p.menuDeletes, m)
+		p.addChange(ActionDelete, KindMenu, m.Path)
+	}
+}
+
+// walkMenuSpecs 深度优先遍历菜单声明（父菜单先于子菜单）
+func walkMenuSpecs(specs []MenuSpec, parentPath string, fn func(spec MenuSpec, parentPath string)) {
+	for _, spec := range specs {
+		fn(spec, parentPath)
+		walkMenuSpecs(spec.Children, spec.Path, fn)
+	}
+}
+
+func menuCreateDetails(m *menu.Menu, parentPath string) []string {
+	details := []string{"title: " + m.Title}
+	if parentPath != "" {
+		details = append(details, "parent: "+parentPath)
+	}
+	if m.Permission != "" {
+		details = append(details, "permission: "+m.Permission)
+	}
+	if !m.Visible {
+		details = append(details, "visible: false")
+	}
+	return details
+}
+
+func menuDetails(current menuNode, desired *menu.Menu, parentPath string) []string {
+	var details []string
+	m := current.menu
+	if m.Title != desired.Title {
+		details = append(details, fieldChange("title", m.Title, desired.Title))
+	}
+	if m.Icon != desired.Icon {
+		details = append(details, fieldChange("icon", m.Icon, desired.Icon))
+	}
+	if m.Order != desired.Order {
+		details = append(details, fmt.Sprintf("order: %d -> %d", m.Order, desired.Order))
+	}
+	if m.Visible != desired.Visible {
+		details = append(details, fmt.Sprintf("visible: %t -> %t", m.Visible, desired.Visible))
+	}
+	if m.Permission != desired.Permission {
+		details = append(details, fieldChange("permission", m.Permission, desired.Permission))
+	}
+	if current.parentPath != parentPath {
+		details = append(details, fieldChange("parent", current.parentPath, parentPath))
+	}
+	return details
+}
+
+func fieldChange(field, from, to string) string {
+	return fmt.Sprintf("%s: %q -> %q", field, from, to)
+}
+
+// validateDocument 检查配置文档自身的一致性
+func validateDocument(doc *Document) error {
+	seenPermissions := make(map[string]struct{}, len(doc.Permissions))
+	for _, spec := range doc.Permissions {
+		if err := (role.PermissionDefinition{Code: spec.Code}).Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+		if _, dup := seenPermissions[spec.Code]; dup {
+			return fmt.Errorf("%w: duplicate permission %q", ErrInvalidConfig, spec.Code)
+		}
+		seenPermissions[spec.Code] = struct{}{}
+	}
+
+	seenRoles := make(map[string]struct{}, len(doc.Roles))
+	for _, spec := range doc.Roles {
+		if err := validateRoleSpec(spec); err != nil {
+			return err
+		}
+		if _, dup := seenRoles[spec.Name]; dup {
+			return fmt.Errorf("%w: duplicate role %q", ErrInvalidConfig, spec.Name)
+		}
+		seenRoles[spec.Name] = struct{}{}
+	}
+
+	var err error
+	seenMenus := make(map[string]struct{})
+	walkMenuSpecs(doc.Menus, "", func(spec MenuSpec, _ string) {
+		if err != nil {
+			return
+		}
+		switch {
+		case spec.Path == "" || spec.Title == "":
+			err = fmt.Errorf("%w: menu %q requires title and path", ErrInvalidConfig, spec.Path)
+		case (&menu.Menu{Permission: spec.Permission}).ValidatePermission() != nil:
+			err = fmt.Errorf("%w: menu %q has invalid permission %q", ErrInvalidConfig, spec.Path, spec.Permission)
+		default:
+			if _, dup := seenMenus[spec.Path]; dup {
+				err = fmt.Errorf("%w: duplicate menu path %q", ErrInvalidConfig, spec.Path)
+			}
+			seenMenus[spec.Path] = struct{}{}
+		}
+	})
+	return err
+}
+
+// validateRoleSpec 检查单个角色声明
+func validateRoleSpec(spec RoleSpec) error {
+	if spec.Name == "" {
+		return fmt.Errorf("%w: role name is required", ErrInvalidConfig)
+	}
+	if spec.Parent == spec.Name {
+		return fmt.Errorf("%w: role %q: %w", ErrInvalidConfig, spec.Name, role.ErrRoleHierarchyCycle)
+	}
+
+	granted := make(map[string]struct{}, len(spec.Permissions))
+	for _, code := range spec.Permissions {
+		if _, dup := granted[code]; dup {
+			return fmt.Errorf("%w: role %q grants %q twice", ErrInvalidConfig, spec.Name, code)
+		}
+		granted[code] = struct{}{}
+	}
+
+	for code, condition := range spec.Conditions {
+		if _, ok := granted[code]; !ok {
+			return fmt.Errorf("%w: role %q has a condition on ungranted permission %q", ErrInvalidConfig, spec.Name, code)
+		}
+		if strings.TrimSpace(condition) == "" {
+			continue
+		}
+		if _, err := policy.Parse(condition); err != nil {
+			return fmt.Errorf("%w: role %q condition on %q: %w", ErrInvalidConfig, spec.Name, code, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package rbacconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// newTestState 构建测试快照：admin 为系统角色，editor 持有 admin:users:read
+func newTestState() *state {
+	users := &menu.Menu{ID: 1, Title: "Users", Path: "/users", Visible: true, Permission: "admin:users:read"}
+	legacy := &menu.Menu{ID: 2, Title: "Legacy", Path: "/legacy", Visible: true}
+
+	s := &state{
+		permissions: map[string]*role.Permission{
+			"admin:users:read":   {ID: 10, Code: "admin:users:read", Description: "Read users"},
+			"admin:users:update": {ID: 11, Code: "admin:users:update", Description: "Update users"},
+		},
+		roles: map[string]*role.Role{
+			"admin":  {ID: 1, Name: "admin", DisplayName: "Admin", IsSystem: true},
+			"editor": {ID: 2, Name: "editor", DisplayName: "Editor"},
+		},
+		roleNames: map[uint]string{1: "admin", 2: "editor"},
+		grants: map[uint]map[string]string{
+			1: {"admin:users:read": "", "admin:users:update": ""},
+			2: {"admin:users:read": ""},
+		},
+		menuTree: []*menu.Menu{users, legacy},
+	}
+	s.menus = flattenMenus(s.menuTree, "", nil)
+	return s
+}
+
+// newMatchingDocument 返回与 newTestState 完全一致的配置
+func newMatchingDocument() *Document {
+	return &Document{
+		Roles: []RoleSpec{
+			{Name: "admin", DisplayName: "Admin", System: true, Permissions: []string{"admin:users:read", "admin:users:update"}},
+			{Name: "editor", DisplayName: "Editor", Permissions: []string{"admin:users:read"}},
+		},
+		Menus: []MenuSpec{
+			{Title: "Users", Path: "/users", Permission: "admin:users:read"},
+			{Title: "Legacy", Path: "/legacy"},
+		},
+	}
+}
+
+func TestBuildPlan_NoChanges(t *testing.T) {
+	p, err := buildPlan(newMatchingDocument(), newTestState(), true)
+
+	require.NoError(t, err)
+	assert.False(t, p.dto.HasChanges())
+	assert.Empty(t, p.dto.Warnings)
+}
+
+func TestBuildPlan_CreateAndUpdate(t *testing.T) {
+	doc := newMatchingDocument()
+	doc.Permissions = []PermissionSpec{
+		{Code: "admin:reports:read", Description: "Read reports"},
+		{Code: "admin:users:read", Description: "List users"},
+	}
+	doc.Roles[1].Permissions = []string{"admin:users:read", "admin:users:update"}
+	doc.Roles[1].Conditions = map[string]string{"admin:users:update": "resource.department == subject.department"}
+	doc.Roles = append(doc.Roles, RoleSpec{Name: "reporter", Parent: "editor", Permissions: []string{"admin:reports:read"}})
+	doc.Menus[0].Children = []MenuSpec{{Title: "Reports", Path: "/reports", Permission: "admin:reports:*"}}
+
+	p, err := buildPlan(doc, newTestState(), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, []ChangeDTO{
+		{Action: ActionCreate, Kind: KindPermission, Key: "admin:reports:read"},
+		{Action: ActionUpdate, Kind: KindPermission, Key: "admin:users:read", Details: []string{`description: "Read users" -> "List users"`}},
+		{Action: ActionUpdate, Kind: KindGrant, Key: "editor", Details: []string{"+admin:users:update [resource.department == subject.department]"}},
+		{Action: ActionCreate, Kind: KindRole, Key: "reporter", Details: []string{"parent: editor"}},
+		{Action: ActionCreate, Kind: KindGrant, Key: "reporter", Details: []string{"+admin:reports:read"}},
+		{Action: ActionCreate, Kind: KindMenu, Key: "/reports", Details: []string{"title: Reports", "parent: /users", "permission: admin:reports:*"}},
+	}, p.dto.Changes)
+
+	require.Len(t, p.grantUpdates, 2)
+	assert.True(t, p.grantUpdates[0].replace)
+	assert.Equal(t, []string{"admin:users:read", "admin:users:update"}, p.grantUpdates[0].codes)
+	assert.Equal(t, map[string]string{"admin:users:update": "resource.department == subject.department"}, p.grantUpdates[0].conditions)
+	require.Len(t, p.roleUpdates, 1)
+	assert.Equal(t, "editor", p.roleUpdates[0].parent)
+	assert.True(t, p.touchesMenus())
+}
+
+func TestBuildPlan_ConditionOnlyChange(t *testing.T) {
+	s := newTestState()
+	s.grants[2]["admin:users:read"] = "resource.id == subject.id"
+
+	p, err := buildPlan(newMatchingDocument(), s, false)
+
+	require.NoError(t, err)
+	require.Len(t, p.grantUpdates, 1)
+	assert.False(t, p.grantUpdates[0].replace, "仅条件变化时不重设授权集合")
+	assert.Equal(t, map[string]string{"admin:users:read": ""}, p.grantUpdates[0].conditions)
+}
+
+func TestBuildPlan_SystemRole(t *testing.T) {
+	t.Run("系统角色不一致时只警告", func(t *testing.T) {
+		doc := newMatchingDocument()
+		doc.Roles[0].DisplayName = "Root"
+		doc.Roles[0].Permissions = []string{"admin:users:read"}
+
+		p, err := buildPlan(doc, newTestState(), true)
+
+		require.NoError(t, err)
+		assert.False(t, p.dto.HasChanges())
+		require.Len(t, p.dto.Warnings, 1)
+		assert.Contains(t, p.dto.Warnings[0], `system role "admin"`)
+	})
+
+	t.Run("系统角色不会被清理", func(t *testing.T) {
+		doc := newMatchingDocument()
+		doc.Roles = doc.Roles[1:]
+
+		p, err := buildPlan(doc, newTestState(), true)
+
+		require.NoError(t, err)
+		assert.Empty(t, p.roleDeletes)
+	})
+
+	t.Run("声明不存在的系统角色", func(t *testing.T) {
+		doc := newMatchingDocument()
+		doc.Roles[1].System = true
+
+		_, err := buildPlan(doc, newTestState(), false)
+
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestBuildPlan_Prune(t *testing.T) {
+	doc := newMatchingDocument()
+	doc.Roles = doc.Roles[:1]
+	doc.Menus = doc.Menus[:1]
+
+	t.Run("未指定清理时只警告", func(t *testing.T) {
+		p, err := buildPlan(doc, newTestState(), false)
+
+		require.NoError(t, err)
+		assert.False(t, p.dto.HasChanges())
+		assert.Len(t, p.dto.Warnings, 2)
+	})
+
+	t.Run("指定清理时删除未声明的角色与菜单", func(t *testing.T) {
+		p, err := buildPlan(doc, newTestState(), true)
+
+		require.NoError(t, err)
+		assert.Equal(t, []ChangeDTO{
+			{Action: ActionDelete, Kind: KindRole, Key: "editor"},
+			{Action: ActionDelete, Kind: KindMenu, Key: "/legacy"},
+		}, p.dto.Changes)
+	})
+}
+
+func TestBuildPlan_InvalidDocument(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(doc *Document)
+		target error
+	}{
+		{
+			name:   "引用未知权限",
+			mutate: func(doc *Document) { doc.Roles[1].Permissions = []string{"admin:unknown:read"} },
+			target: ErrInvalidConfig,
+		},
+		{
+			name:   "引用未知父角色",
+			mutate: func(doc *Document) { doc.Roles[1].Parent = "ghost" },
+			target: ErrInvalidConfig,
+		},
+		{
+			name: "继承形成环",
+			mutate: func(doc *Document) {
+				doc.Roles[1].Parent = "viewer"
+				doc.Roles = append(doc.Roles, RoleSpec{Name: "viewer", Parent: "editor"})
+			},
+			target: role.ErrRoleHierarchyCycle,
+		},
+		{
+			name: "条件语法错误",
+			mutate: func(doc *Document) {
+				doc.Roles[1].Conditions = map[string]string{"admin:users:read": "resource.id >= 1"}
+			},
+			target: ErrInvalidConfig,
+		},
+		{
+			name: "条件附加在未授予的权限上",
+			mutate: func(doc *Document) {
+				doc.Roles[1].Conditions = map[string]string{"admin:users:update": "resource.id == 1"}
+			},
+			target: ErrInvalidConfig,
+		},
+		{
+			name:   "菜单路径重复",
+			mutate: func(doc *Document) { doc.Menus[1].Path = "/users" },
+			target: ErrInvalidConfig,
+		},
+		{
+			name:   "权限代码无效",
+			mutate: func(doc *Document) { doc.Permissions = []PermissionSpec{{Code: "admin:*:read"}} },
+			target: ErrInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := newMatchingDocument()
+			tt.mutate(doc)
+
+			_, err := buildPlan(doc, newTestState(), false)
+
+			require.ErrorIs(t, err, tt.target)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+package rbacconfig
+
+// ExportConfigQuery 从数据库导出配置文档的查询
+type ExportConfigQuery struct{}
@@ -0,0 +1,84 @@
+package rbacconfig
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+)
+
+// ExportConfigHandler 导出配置查询处理器
+type ExportConfigHandler struct {
+	repos Repositories
+}
+
+// NewExportConfigHandler 创建 ExportConfigHandler 实例
+func NewExportConfigHandler(repos Repositories) *ExportConfigHandler {
+	return &ExportConfigHandler{repos: repos}
+}
+
+// Handle 处理导出配置查询
+// 导出结果按代码/名称排序，对同一数据库重复导出得到相同文件，便于在 git 中审阅
+func (h *ExportConfigHandler) Handle(ctx context.Context, _ ExportConfigQuery) (*Document, error) {
+	s, err := loadState(ctx, h.repos)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{}
+	for _, code := range slices.Sorted(maps.Keys(s.permissions)) {
+		doc.Permissions = append(doc.Permissions, PermissionSpec{Code: code, Description: s.permissions[code].Description})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(s.roles)) {
+		r := s.roles[name]
+		spec := RoleSpec{
+			Name:        r.Name,
+			DisplayName: r.DisplayName,
+			Description: r.Description,
+			Parent:      s.parentName(r),
+			System:      r.IsSystemRole(),
+		}
+		grants := s.grants[r.ID]
+		for _, code := range slices.Sorted(maps.Keys(grants)) {
+			spec.Permissions = append(spec.Permissions, code)
+			if condition := strings.TrimSpace(grants[code]); condition != "" {
+				if spec.Conditions == nil {
+					spec.Conditions = make(map[string]string)
+				}
+				spec.Conditions[code] = condition
+			}
+		}
+		doc.Roles = append(doc.Roles, spec)
+	}
+
+	doc.Menus = toMenuSpecs(s.menuTree)
+
+	return doc, nil
+}
+
+// toMenuSpecs 将菜单树转换为配置声明
+func toMenuSpecs(menus []*menu.Menu) []MenuSpec {
+	specs := make([]MenuSpec, 0, len(menus))
+	for _, m := range menus {
+		spec := MenuSpec{
+			Title:      m.Title,
+			Path:       m.Path,
+			Icon:       m.Icon,
+			Order:      m.Order,
+			Permission: m.Permission,
+			Children:   toMenuSpecs(m.Children),
+		}
+		if !m.Visible {
+			hidden := false
+			spec.Visible = &hidden
+		}
+		if len(spec.Children) == 0 {
+			spec.Children = nil
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
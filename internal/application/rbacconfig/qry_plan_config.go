@@ -0,0 +1,8 @@
+package rbacconfig
+
+// PlanConfigQuery 计算配置与数据库差异的查询
+type PlanConfigQuery struct {
+	Document *Document
+	// Prune 是否删除配置中未声明的角色与菜单
+	Prune bool
+}
@@ -0,0 +1,27 @@
+package rbacconfig
+
+import "context"
+
+// PlanConfigHandler 计算配置差异查询处理器
+type PlanConfigHandler struct {
+	repos Repositories
+}
+
+// NewPlanConfigHandler 创建 PlanConfigHandler 实例
+func NewPlanConfigHandler(repos Repositories) *PlanConfigHandler {
+	return &PlanConfigHandler{repos: repos}
+}
+
+// Handle 处理计算配置差异查询（只读，不修改数据库）
+func (h *PlanConfigHandler) Handle(ctx context.Context, query PlanConfigQuery) (*PlanDTO, error) {
+	s, err := loadState(ctx, h.repos)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := buildPlan(query.Document, s, query.Prune)
+	if err != nil {
+		return nil, err
+	}
+	return &p.dto, nil
+}
@@ -0,0 +1,123 @@
+package rbacconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// rolePageSize 加载角色时的分页大小
+const rolePageSize = 100
+
+// state 数据库中 RBAC 数据的当前快照
+type state struct {
+	permissions map[string]*role.Permission // code -> permission
+	roles       map[string]*role.Role       // name -> 全局角色
+	roleNames   map[uint]string             // role id -> name
+	grants      map[uint]map[string]string  // role id -> permission code -> condition
+	menuTree    []*menu.Menu
+	menus       []menuNode // 深度优先顺序（父菜单在前）
+}
+
+// menuNode 扁平化后的菜单节点
+type menuNode struct {
+	menu       *menu.Menu
+	parentPath string
+}
+
+// parentName 返回角色父角色的名称，父角色不是全局角色时返回空
+func (s *state) parentName(r *role.Role) string {
+	if !r.HasParent() {
+		return ""
+	}
+	return s.roleNames[*r.ParentID]
+}
+
+// menuByPath 按路径查找菜单节点
+func (s *state) menuByPath(path string) (menuNode, bool) {
+	for _, n := range s.menus {
+		if n.menu.Path == path {
+			return n, true
+		}
+	}
+	return menuNode{}, false
+}
+
+// loadState 从仓储加载当前 RBAC 快照
+func loadState(ctx context.Context, repos Repositories) (*state, error) {
+	s := &state{
+		permissions: make(map[string]*role.Permission),
+		roles:       make(map[string]*role.Role),
+		roleNames:   make(map[uint]string),
+		grants:      make(map[uint]map[string]string),
+	}
+
+	permissions, err := repos.PermissionQuery.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+	for i := range permissions {
+		s.permissions[permissions[i].Code] = &permissions[i]
+	}
+
+	roles, err := listGlobalRoles(ctx, repos.RoleQuery)
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]uint, 0, len(roles))
+	for _, r := range roles {
+		s.roles[r.Name] = r
+		s.roleNames[r.ID] = r.Name
+		s.grants[r.ID] = make(map[string]string)
+		roleIDs = append(roleIDs, r.ID)
+	}
+
+	grants, err := repos.RoleQuery.GetGrants(ctx, roleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grants: %w", err)
+	}
+	for _, g := range grants {
+		if byCode, ok := s.grants[g.RoleID]; ok {
+			byCode[g.PermissionCode] = g.Condition
+		}
+	}
+
+	tree, err := repos.MenuQuery.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch menus: %w", err)
+	}
+	s.menuTree = tree
+	s.menus = flattenMenus(tree, "", nil)
+
+	return s, nil
+}
+
+// listGlobalRoles 分页加载所有全局角色（组织角色不受配置文件管理）
+func listGlobalRoles(ctx context.Context, roleQueryRepo role.QueryRepository) ([]*role.Role, error) {
+	var result []*role.Role
+	for page := 1; ; page++ {
+		roles, total, err := roleQueryRepo.List(ctx, page, rolePageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+		for i := range roles {
+			if roles[i].IsGlobal() {
+				result = append(result, &roles[i])
+			}
+		}
+		if len(roles) < rolePageSize || int64(page*rolePageSize) >= total {
+			return result, nil
+		}
+	}
+}
+
+// flattenMenus 将菜单树按深度优先顺序展开
+func flattenMenus(menus []*menu.Menu, parentPath string, nodes []menuNode) []menuNode {
+	for _, m := range menus {
+		nodes = append(nodes, menuNode{menu: m, parentPath: parentPath})
+		nodes = flattenMenus(m.Children, m.Path, nodes)
+	}
+	return nodes
+}
@@ -0,0 +1,23 @@
+package rbacconfig
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// Repositories 声明式配置读写涉及的仓储集合
+type Repositories struct {
+	RoleCommand       role.CommandRepository
+	RoleQuery         role.QueryRepository
+	PermissionCommand role.PermissionCommandRepository
+	PermissionQuery   role.PermissionQueryRepository
+	MenuCommand       menu.CommandRepository
+	MenuQuery         menu.QueryRepository
+}
+
+// UnitOfWork 在单个数据库事务中执行 fn
+//
+// fn 收到的仓储绑定到同一事务；fn 返回错误时整个事务回滚。
+type UnitOfWork func(ctx context.Context, fn func(repos Repositories) error) error
@@ -5,4 +5,5 @@ type CreateRoleCommand struct {
 	Name        string // 角色名称（唯一）
 	DisplayName string // 显示名称
 	Description string // 描述
+	ParentID    *uint  // 可选：父角色 ID
 }
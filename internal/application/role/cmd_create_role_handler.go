@@ -35,15 +35,26 @@ func (h *CreateRoleHandler) Handle(ctx context.Context, cmd CreateRoleCommand) (
 		return nil, fmt.Errorf("role name already exists: %s", cmd.Name)
 	}
 
-	// 2. 创建角色实体
+	// 2. 验证父角色（新角色没有后代，不会形成环）
+	if cmd.ParentID != nil && *cmd.ParentID != 0 {
+		if err := checkParent(ctx, h.roleQueryRepo, *cmd.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 创建角色实体
 	newRole := &role.Role{
 		Name:        cmd.Name,
 		DisplayName: cmd.DisplayName,
 		Description: cmd.Description,
 		IsSystem:    false, // 用户创建的角色不是系统角色
+		ParentID:    cmd.ParentID,
+	}
+	if !newRole.HasParent() {
+		newRole.ParentID = nil
 	}
 
-	// 3. 保存角色
+	// 4. 保存角色
 	if err := h.roleCommandRepo.Create(ctx, newRole); err != nil {
 		return nil, fmt.Errorf("failed to create role: %w", err)
 	}
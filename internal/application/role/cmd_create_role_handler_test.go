@@ -114,6 +114,19 @@ func TestCreateRoleHandler_Handle_Error(t *testing.T) {
 			},
 			wantErr: "failed to create role",
 		},
+		{
+			name: "父角色不存在",
+			cmd: CreateRoleCommand{
+				Name:        "child",
+				DisplayName: "子角色",
+				ParentID:    func() *uint { id := uint(99); return &id }(),
+			},
+			setupMocks: func(cmdRepo *MockRoleCommandRepository, qryRepo *MockRoleQueryRepository) {
+				qryRepo.On("ExistsByName", mock.Anything, "child").Return(false, nil)
+				qryRepo.On("FindByID", mock.Anything, uint(99)).Return(nil, nil)
+			},
+			wantErr: "parent role not found",
+		},
 	}
 
 	for _, tt := range tests {
@@ -160,3 +173,28 @@ func TestCreateRoleHandler_NotSystemRole(t *testing.T) {
 	assert.NotNil(t, capturedRole)
 	assert.False(t, capturedRole.IsSystem, "用户创建的角色不应是系统角色")
 }
+
+func TestCreateRoleHandler_WithParent(t *testing.T) {
+	mockCmdRepo := new(MockRoleCommandRepository)
+	mockQryRepo := new(MockRoleQueryRepository)
+	parentID := uint(2)
+
+	var capturedRole *role.Role
+	mockQryRepo.On("ExistsByName", mock.Anything, "editor").Return(false, nil)
+	mockQryRepo.On("FindByID", mock.Anything, parentID).Return(&role.Role{ID: parentID, Name: "viewer"}, nil)
+	mockCmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*role.Role")).
+		Run(func(args mock.Arguments) {
+			capturedRole = args.Get(1).(*role.Role)
+		}).Return(nil)
+
+	handler := NewCreateRoleHandler(mockCmdRepo, mockQryRepo)
+	_, err := handler.Handle(context.Background(), CreateRoleCommand{
+		Name:        "editor",
+		DisplayName: "编辑者",
+		ParentID:    &parentID,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, capturedRole.ParentID)
+	assert.Equal(t, parentID, *capturedRole.ParentID)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 )
 
@@ -12,16 +14,19 @@ import (
 type DeleteRoleHandler struct {
 	roleCommandRepo role.CommandRepository
 	roleQueryRepo   role.QueryRepository
+	eventBus        event.EventBus
 }
 
 // NewDeleteRoleHandler 创建删除角色命令处理器
 func NewDeleteRoleHandler(
 	roleCommandRepo role.CommandRepository,
 	roleQueryRepo role.QueryRepository,
+	eventBus event.EventBus,
 ) *DeleteRoleHandler {
 	return &DeleteRoleHandler{
 		roleCommandRepo: roleCommandRepo,
 		roleQueryRepo:   roleQueryRepo,
+		eventBus:        eventBus,
 	}
 }
 
@@ -41,10 +46,27 @@ func (h *DeleteRoleHandler) Handle(ctx context.Context, cmd DeleteRoleCommand) e
 		return errors.New("cannot delete system role")
 	}
 
-	// 3. 删除角色
+	// 3. 记录将被解除继承的子角色
+	hierarchy, err := h.roleQueryRepo.GetHierarchy(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get role hierarchy: %w", err)
+	}
+	children := hierarchy.Children(cmd.RoleID)
+
+	// 4. 删除角色
 	if err := h.roleCommandRepo.Delete(ctx, cmd.RoleID); err != nil {
 		return fmt.Errorf("failed to delete role: %w", err)
 	}
 
+	// 5. 持有该角色的用户以及子角色（失去继承的权限）的有效权限均已变化，发布事件触发缓存失效
+	if h.eventBus != nil {
+		evts := make([]event.Event, 0, len(children)+1)
+		evts = append(evts, events.NewRolePermissionsChangedEvent(cmd.RoleID, nil))
+		for _, childID := range children {
+			evts = append(evts, events.NewRolePermissionsChangedEvent(childID, nil))
+		}
+		_ = h.eventBus.Publish(ctx, evts...) // 缓存失效失败不阻塞业务
+	}
+
 	return nil
 }
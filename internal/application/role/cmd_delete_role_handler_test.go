@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 )
 
@@ -17,6 +19,8 @@ func TestDeleteRoleHandler_Handle_Success(t *testing.T) {
 		name         string
 		cmd          DeleteRoleCommand
 		existingRole *role.Role
+		hierarchy    role.Hierarchy
+		wantRoleIDs  []uint
 	}{
 		{
 			name: "成功删除普通角色",
@@ -26,6 +30,8 @@ func TestDeleteRoleHandler_Handle_Success(t *testing.T) {
 				Name:     "developer",
 				IsSystem: false,
 			},
+			hierarchy:   role.Hierarchy{},
+			wantRoleIDs: []uint{1},
 		},
 		{
 			name: "删除自定义角色",
@@ -35,6 +41,8 @@ func TestDeleteRoleHandler_Handle_Success(t *testing.T) {
 				Name:     "custom",
 				IsSystem: false,
 			},
+			hierarchy:   role.Hierarchy{2: 1, 101: 100, 102: 100, 103: 101},
+			wantRoleIDs: []uint{100, 101, 102},
 		},
 	}
 
@@ -43,11 +51,24 @@ func TestDeleteRoleHandler_Handle_Success(t *testing.T) {
 			// Arrange
 			mockCmdRepo := new(MockRoleCommandRepository)
 			mockQryRepo := new(MockRoleQueryRepository)
+			mockEventBus := new(MockEventBus)
 
 			mockQryRepo.On("FindByID", mock.Anything, tt.cmd.RoleID).Return(tt.existingRole, nil)
+			mockQryRepo.On("GetHierarchy", mock.Anything).Return(tt.hierarchy, nil)
 			mockCmdRepo.On("Delete", mock.Anything, tt.cmd.RoleID).Return(nil)
+			mockEventBus.On("Publish", mock.Anything, mock.MatchedBy(func(evts []event.Event) bool {
+				roleIDs := make([]uint, 0, len(evts))
+				for _, evt := range evts {
+					changed, ok := evt.(*events.RolePermissionsChangedEvent)
+					if !ok {
+						return false
+					}
+					roleIDs = append(roleIDs, changed.RoleID)
+				}
+				return assert.ObjectsAreEqual(tt.wantRoleIDs, roleIDs)
+			})).Return(nil)
 
-			handler := NewDeleteRoleHandler(mockCmdRepo, mockQryRepo)
+			handler := NewDeleteRoleHandler(mockCmdRepo, mockQryRepo, mockEventBus)
 
 			// Act
 			err := handler.Handle(context.Background(), tt.cmd)
@@ -56,6 +77,7 @@ func TestDeleteRoleHandler_Handle_Success(t *testing.T) {
 			require.NoError(t, err)
 			mockCmdRepo.AssertExpectations(t)
 			mockQryRepo.AssertExpectations(t)
+			mockEventBus.AssertExpectations(t)
 		})
 	}
 }
@@ -95,6 +117,19 @@ func TestDeleteRoleHandler_Handle_Error(t *testing.T) {
 			},
 			wantErr: "failed to find role",
 		},
+		{
+			name: "查询角色继承关系失败",
+			cmd:  DeleteRoleCommand{RoleID: 1},
+			setupMocks: func(cmdRepo *MockRoleCommandRepository, qryRepo *MockRoleQueryRepository) {
+				qryRepo.On("FindByID", mock.Anything, uint(1)).Return(&role.Role{
+					ID:       1,
+					Name:     "custom",
+					IsSystem: false,
+				}, nil)
+				qryRepo.On("GetHierarchy", mock.Anything).Return(nil, errors.New("database error"))
+			},
+			wantErr: "failed to get role hierarchy",
+		},
 		{
 			name: "删除角色时数据库错误",
 			cmd:  DeleteRoleCommand{RoleID: 1},
@@ -104,6 +139,7 @@ func TestDeleteRoleHandler_Handle_Error(t *testing.T) {
 					Name:     "custom",
 					IsSystem: false,
 				}, nil)
+				qryRepo.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{2: 1}, nil)
 				cmdRepo.On("Delete", mock.Anything, uint(1)).Return(errors.New("delete failed"))
 			},
 			wantErr: "failed to delete role",
@@ -115,9 +151,10 @@ func TestDeleteRoleHandler_Handle_Error(t *testing.T) {
 			// Arrange
 			mockCmdRepo := new(MockRoleCommandRepository)
 			mockQryRepo := new(MockRoleQueryRepository)
+			mockEventBus := new(MockEventBus)
 			tt.setupMocks(mockCmdRepo, mockQryRepo)
 
-			handler := NewDeleteRoleHandler(mockCmdRepo, mockQryRepo)
+			handler := NewDeleteRoleHandler(mockCmdRepo, mockQryRepo, mockEventBus)
 
 			// Act
 			err := handler.Handle(context.Background(), tt.cmd)
@@ -127,6 +164,7 @@ func TestDeleteRoleHandler_Handle_Error(t *testing.T) {
 			assert.Contains(t, err.Error(), tt.wantErr)
 			mockCmdRepo.AssertExpectations(t)
 			mockQryRepo.AssertExpectations(t)
+			mockEventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
 		})
 	}
 }
@@ -0,0 +1,8 @@
+package role
+
+// SetPermissionConditionCommand 设置角色权限授予的策略条件命令
+type SetPermissionConditionCommand struct {
+	RoleID       uint
+	PermissionID uint
+	Condition    string // 为空时移除条件，授予变为无条件
+}
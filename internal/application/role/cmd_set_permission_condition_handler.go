@@ -0,0 +1,70 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// SetPermissionConditionHandler 设置权限授予策略条件命令处理器
+type SetPermissionConditionHandler struct {
+	roleCommandRepo role.CommandRepository
+	roleQueryRepo   role.QueryRepository
+}
+
+// NewSetPermissionConditionHandler 创建设置权限授予策略条件命令处理器
+func NewSetPermissionConditionHandler(
+	roleCommandRepo role.CommandRepository,
+	roleQueryRepo role.QueryRepository,
+) *SetPermissionConditionHandler {
+	return &SetPermissionConditionHandler{
+		roleCommandRepo: roleCommandRepo,
+		roleQueryRepo:   roleQueryRepo,
+	}
+}
+
+// Handle 处理设置权限授予策略条件命令
+func (h *SetPermissionConditionHandler) Handle(ctx context.Context, cmd SetPermissionConditionCommand) (*GrantDTO, error) {
+	// 1. 验证角色存在且已被授予该权限
+	grants, err := h.roleQueryRepo.GetGrants(ctx, []uint{cmd.RoleID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grants: %w", err)
+	}
+
+	var grant *role.Grant
+	for i := range grants {
+		if grants[i].PermissionID == cmd.PermissionID {
+			grant = &grants[i]
+			break
+		}
+	}
+	if grant == nil {
+		exists, err := h.roleQueryRepo.Exists(ctx, cmd.RoleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check role existence: %w", err)
+		}
+		if !exists {
+			return nil, role.ErrRoleNotFound
+		}
+		return nil, role.ErrPermissionNotGranted
+	}
+
+	// 2. 校验条件表达式
+	condition := strings.TrimSpace(cmd.Condition)
+	if condition != "" {
+		if _, err := policy.Parse(condition); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 保存条件
+	if err := h.roleCommandRepo.SetPermissionCondition(ctx, cmd.RoleID, cmd.PermissionID, condition); err != nil {
+		return nil, fmt.Errorf("failed to set permission condition: %w", err)
+	}
+
+	grant.Condition = condition
+	return ToGrantDTO(grant), nil
+}
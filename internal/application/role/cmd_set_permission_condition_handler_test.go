@@ -0,0 +1,124 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+func TestSetPermissionConditionHandler_Handle(t *testing.T) {
+	grants := []role.Grant{
+		{RoleID: 1, PermissionID: 10, PermissionCode: "admin:users:update"},
+		{RoleID: 1, PermissionID: 11, PermissionCode: "admin:audit_logs:read", Condition: "resource.user_id == subject.id"},
+	}
+
+	tests := []struct {
+		name       string
+		cmd        SetPermissionConditionCommand
+		setupMocks func(*MockRoleCommandRepository, *MockRoleQueryRepository)
+		wantErr    error
+		want       string
+	}{
+		{
+			name: "附加条件",
+			cmd:  SetPermissionConditionCommand{RoleID: 1, PermissionID: 10, Condition: " resource.department == subject.department "},
+			setupMocks: func(cmdRepo *MockRoleCommandRepository, _ *MockRoleQueryRepository) {
+				cmdRepo.On("SetPermissionCondition", mock.Anything, uint(1), uint(10), "resource.department == subject.department").Return(nil)
+			},
+			want: "resource.department == subject.department",
+		},
+		{
+			name: "移除条件",
+			cmd:  SetPermissionConditionCommand{RoleID: 1, PermissionID: 11},
+			setupMocks: func(cmdRepo *MockRoleCommandRepository, _ *MockRoleQueryRepository) {
+				cmdRepo.On("SetPermissionCondition", mock.Anything, uint(1), uint(11), "").Return(nil)
+			},
+		},
+		{
+			name:       "条件语法错误",
+			cmd:        SetPermissionConditionCommand{RoleID: 1, PermissionID: 10, Condition: "resource.department >= 1"},
+			setupMocks: func(_ *MockRoleCommandRepository, _ *MockRoleQueryRepository) {},
+			wantErr:    policy.ErrInvalidExpression,
+		},
+		{
+			name: "角色未被授予该权限",
+			cmd:  SetPermissionConditionCommand{RoleID: 1, PermissionID: 99, Condition: "resource.id == 1"},
+			setupMocks: func(_ *MockRoleCommandRepository, qryRepo *MockRoleQueryRepository) {
+				qryRepo.On("Exists", mock.Anything, uint(1)).Return(true, nil)
+			},
+			wantErr: role.ErrPermissionNotGranted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockCmdRepo := new(MockRoleCommandRepository)
+			mockQryRepo := new(MockRoleQueryRepository)
+			mockQryRepo.On("GetGrants", mock.Anything, []uint{1}).Return(grants, nil)
+			tt.setupMocks(mockCmdRepo, mockQryRepo)
+
+			handler := NewSetPermissionConditionHandler(mockCmdRepo, mockQryRepo)
+
+			// Act
+			result, err := handler.Handle(context.Background(), tt.cmd)
+
+			// Assert
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				mockCmdRepo.AssertNotCalled(t, "SetPermissionCondition", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.Condition)
+			mockCmdRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetPermissionConditionHandler_Handle_RoleNotFound(t *testing.T) {
+	mockCmdRepo := new(MockRoleCommandRepository)
+	mockQryRepo := new(MockRoleQueryRepository)
+	mockQryRepo.On("GetGrants", mock.Anything, []uint{999}).Return([]role.Grant{}, nil)
+	mockQryRepo.On("Exists", mock.Anything, uint(999)).Return(false, nil)
+
+	handler := NewSetPermissionConditionHandler(mockCmdRepo, mockQryRepo)
+
+	_, err := handler.Handle(context.Background(), SetPermissionConditionCommand{RoleID: 999, PermissionID: 1})
+
+	require.ErrorIs(t, err, role.ErrRoleNotFound)
+}
+
+func TestListGrantsHandler_Handle(t *testing.T) {
+	t.Run("返回授予及条件", func(t *testing.T) {
+		mockQryRepo := new(MockRoleQueryRepository)
+		mockQryRepo.On("Exists", mock.Anything, uint(1)).Return(true, nil)
+		mockQryRepo.On("GetGrants", mock.Anything, []uint{1}).Return([]role.Grant{
+			{RoleID: 1, PermissionID: 10, PermissionCode: "admin:users:update", Condition: "resource.department == subject.department"},
+		}, nil)
+
+		result, err := NewListGrantsHandler(mockQryRepo).Handle(context.Background(), ListGrantsQuery{RoleID: 1})
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "resource.department == subject.department", result[0].Condition)
+	})
+
+	t.Run("查询授予失败", func(t *testing.T) {
+		mockQryRepo := new(MockRoleQueryRepository)
+		mockQryRepo.On("Exists", mock.Anything, uint(1)).Return(true, nil)
+		mockQryRepo.On("GetGrants", mock.Anything, []uint{1}).Return(nil, errors.New("database error"))
+
+		_, err := NewListGrantsHandler(mockQryRepo).Handle(context.Background(), ListGrantsQuery{RoleID: 1})
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,10 @@
+package role
+
+import "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+
+// SyncPermissionsCommand 同步权限目录命令
+// Definitions 来自路由声明的权限注册表；DryRun 时仅比较差异，不写入数据库
+type SyncPermissionsCommand struct {
+	Definitions []role.PermissionDefinition
+	DryRun      bool
+}
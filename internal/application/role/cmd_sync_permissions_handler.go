@@ -0,0 +1,73 @@
+package role
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// SyncPermissionsHandler 同步权限目录命令处理器
+// 以路由声明的权限为准创建缺失权限、更新说明，并标记无路由引用的孤儿权限（不自动删除）
+type SyncPermissionsHandler struct {
+	permissionCommandRepo role.PermissionCommandRepository
+	permissionQueryRepo   role.PermissionQueryRepository
+}
+
+// NewSyncPermissionsHandler 创建同步权限目录命令处理器
+func NewSyncPermissionsHandler(
+	permissionCommandRepo role.PermissionCommandRepository,
+	permissionQueryRepo role.PermissionQueryRepository,
+) *SyncPermissionsHandler {
+	return &SyncPermissionsHandler{
+		permissionCommandRepo: permissionCommandRepo,
+		permissionQueryRepo:   permissionQueryRepo,
+	}
+}
+
+// Handle 处理同步权限目录命令
+func (h *SyncPermissionsHandler) Handle(ctx context.Context, cmd SyncPermissionsCommand) (*SyncPermissionsResultDTO, error) {
+	existing, err := h.permissionQueryRepo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	byCode := make(map[string]*role.Permission, len(existing))
+	for i := range existing {
+		byCode[existing[i].Code] = &existing[i]
+	}
+
+	result := &SyncPermissionsResultDTO{DryRun: cmd.DryRun}
+	declared := make(map[string]bool, len(cmd.Definitions))
+
+	for _, def := range cmd.Definitions {
+		declared[def.Code] = true
+
+		current, ok := byCode[def.Code]
+		switch {
+		case !ok:
+			result.Missing = append(result.Missing, def.Code)
+			if !cmd.DryRun {
+				if err = h.permissionCommandRepo.Create(ctx, def.NewPermission()); err != nil {
+					return nil, fmt.Errorf("failed to create permission %s: %w", def.Code, err)
+				}
+			}
+		case current.Description != def.Description:
+			result.Updated = append(result.Updated, def.Code)
+			if !cmd.DryRun {
+				current.Description = def.Description
+				if err = h.permissionCommandRepo.Update(ctx, current); err != nil {
+					return nil, fmt.Errorf("failed to update permission %s: %w", def.Code, err)
+				}
+			}
+		}
+	}
+
+	for _, p := range existing {
+		if !declared[p.Code] {
+			result.Orphaned = append(result.Orphaned, p.Code)
+		}
+	}
+
+	return result, nil
+}
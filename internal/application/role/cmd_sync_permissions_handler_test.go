@@ -0,0 +1,78 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+func TestSyncPermissionsHandler_Handle(t *testing.T) {
+	definitions := []role.PermissionDefinition{
+		{Code: "admin:users:read", Description: "Read all users"},
+		{Code: "admin:users:create", Description: "Create users"},
+		{Code: "user:profile:read", Description: "Read own profile"},
+	}
+	existing := []role.Permission{
+		{ID: 1, Code: "admin:users:read", Description: "Read all users"},
+		{ID: 2, Code: "user:profile:read", Description: "Read profile"},
+		{ID: 3, Code: "api:cache:read", Description: "Read cache data"},
+	}
+
+	t.Run("创建缺失权限、更新说明并标记孤儿权限", func(t *testing.T) {
+		mockCmdRepo := new(MockPermissionCommandRepository)
+		mockQryRepo := new(MockPermissionQueryRepository)
+
+		mockQryRepo.On("ListAll", mock.Anything).Return(append([]role.Permission{}, existing...), nil)
+		mockCmdRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *role.Permission) bool {
+			return p.Code == "admin:users:create" && p.Domain == "admin" && p.Resource == "users" && p.Action == "create"
+		})).Return(nil)
+		mockCmdRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *role.Permission) bool {
+			return p.ID == 2 && p.Description == "Read own profile"
+		})).Return(nil)
+
+		handler := NewSyncPermissionsHandler(mockCmdRepo, mockQryRepo)
+
+		result, err := handler.Handle(context.Background(), SyncPermissionsCommand{Definitions: definitions})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin:users:create"}, result.Missing)
+		assert.Equal(t, []string{"user:profile:read"}, result.Updated)
+		assert.Equal(t, []string{"api:cache:read"}, result.Orphaned)
+		mockCmdRepo.AssertExpectations(t)
+		mockCmdRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DryRun 不写入数据库", func(t *testing.T) {
+		mockCmdRepo := new(MockPermissionCommandRepository)
+		mockQryRepo := new(MockPermissionQueryRepository)
+
+		mockQryRepo.On("ListAll", mock.Anything).Return(append([]role.Permission{}, existing...), nil)
+
+		handler := NewSyncPermissionsHandler(mockCmdRepo, mockQryRepo)
+
+		result, err := handler.Handle(context.Background(), SyncPermissionsCommand{Definitions: definitions, DryRun: true})
+
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, []string{"admin:users:create"}, result.Missing)
+		mockCmdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mockCmdRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("查询失败", func(t *testing.T) {
+		mockQryRepo := new(MockPermissionQueryRepository)
+		mockQryRepo.On("ListAll", mock.Anything).Return(nil, errors.New("db error"))
+
+		handler := NewSyncPermissionsHandler(new(MockPermissionCommandRepository), mockQryRepo)
+
+		_, err := handler.Handle(context.Background(), SyncPermissionsCommand{Definitions: definitions})
+
+		require.Error(t, err)
+	})
+}
@@ -5,4 +5,5 @@ type UpdateRoleCommand struct {
 	RoleID      uint
 	DisplayName *string // 可选：显示名称
 	Description *string // 可选：描述
+	ParentID    *uint   // 可选：父角色 ID，0 表示移除父角色
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 )
 
@@ -12,16 +14,19 @@ import (
 type UpdateRoleHandler struct {
 	roleCommandRepo role.CommandRepository
 	roleQueryRepo   role.QueryRepository
+	eventBus        event.EventBus
 }
 
 // NewUpdateRoleHandler 创建更新角色命令处理器
 func NewUpdateRoleHandler(
 	roleCommandRepo role.CommandRepository,
 	roleQueryRepo role.QueryRepository,
+	eventBus event.EventBus,
 ) *UpdateRoleHandler {
 	return &UpdateRoleHandler{
 		roleCommandRepo: roleCommandRepo,
 		roleQueryRepo:   roleQueryRepo,
+		eventBus:        eventBus,
 	}
 }
 
@@ -49,10 +54,58 @@ func (h *UpdateRoleHandler) Handle(ctx context.Context, cmd UpdateRoleCommand) (
 		existingRole.Description = *cmd.Description
 	}
 
-	// 4. 保存更新
+	// 4. 变更父角色（需检查父角色存在且不形成环）
+	parentChanged, err := h.applyParent(ctx, existingRole, cmd.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. 保存更新
 	if err := h.roleCommandRepo.Update(ctx, existingRole); err != nil {
 		return nil, fmt.Errorf("failed to update role: %w", err)
 	}
 
+	// 6. 父角色变更会影响该角色及其后代的有效权限，发布事件触发缓存失效
+	if parentChanged && h.eventBus != nil {
+		evt := events.NewRolePermissionsChangedEvent(existingRole.ID, nil)
+		_ = h.eventBus.Publish(ctx, evt) // 缓存失效失败不阻塞业务
+	}
+
 	return ToRoleDTO(existingRole), nil
 }
+
+// applyParent 校验并设置父角色，返回父角色是否发生变化
+func (h *UpdateRoleHandler) applyParent(ctx context.Context, r *role.Role, parentID *uint) (bool, error) {
+	if parentID == nil {
+		return false, nil
+	}
+
+	// 0 表示移除父角色
+	if *parentID == 0 {
+		if !r.HasParent() {
+			return false, nil
+		}
+		r.ParentID = nil
+		return true, nil
+	}
+
+	if r.HasParent() && *r.ParentID == *parentID {
+		return false, nil
+	}
+
+	if err := checkParent(ctx, h.roleQueryRepo, *parentID); err != nil {
+		return false, err
+	}
+
+	hierarchy, err := h.roleQueryRepo.GetHierarchy(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get role hierarchy: %w", err)
+	}
+	if hierarchy.CreatesCycle(r.ID, *parentID) {
+		return false, role.ErrRoleHierarchyCycle
+	}
+
+	id := *parentID
+	r.ParentID = &id
+	return true, nil
+}
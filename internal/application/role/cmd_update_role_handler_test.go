@@ -79,7 +79,7 @@ func TestUpdateRoleHandler_Handle_Success(t *testing.T) {
 			mockQryRepo.On("FindByID", mock.Anything, tt.cmd.RoleID).Return(tt.existingRole, nil)
 			mockCmdRepo.On("Update", mock.Anything, mock.AnythingOfType("*role.Role")).Return(nil)
 
-			handler := NewUpdateRoleHandler(mockCmdRepo, mockQryRepo)
+			handler := NewUpdateRoleHandler(mockCmdRepo, mockQryRepo, nil)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -154,7 +154,7 @@ func TestUpdateRoleHandler_Handle_Error(t *testing.T) {
 			mockQryRepo := new(MockRoleQueryRepository)
 			tt.setupMocks(mockCmdRepo, mockQryRepo)
 
-			handler := NewUpdateRoleHandler(mockCmdRepo, mockQryRepo)
+			handler := NewUpdateRoleHandler(mockCmdRepo, mockQryRepo, nil)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -168,3 +168,101 @@ func TestUpdateRoleHandler_Handle_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateRoleHandler_Handle_Parent(t *testing.T) {
+	parentID := uint(2)
+	descendantID := uint(3)
+	noParent := uint(0)
+
+	tests := []struct {
+		name       string
+		cmd        UpdateRoleCommand
+		current    *uint
+		setupMocks func(*MockRoleCommandRepository, *MockRoleQueryRepository)
+		wantErr    error
+		wantParent *uint
+		wantEvent  bool
+	}{
+		{
+			name: "设置父角色",
+			cmd:  UpdateRoleCommand{RoleID: 1, ParentID: &parentID},
+			setupMocks: func(cmdRepo *MockRoleCommandRepository, qryRepo *MockRoleQueryRepository) {
+				qryRepo.On("FindByID", mock.Anything, parentID).Return(&role.Role{ID: parentID, Name: "viewer"}, nil)
+				qryRepo.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{}, nil)
+				cmdRepo.On("Update", mock.Anything, mock.AnythingOfType("*role.Role")).Return(nil)
+			},
+			wantParent: &parentID,
+			wantEvent:  true,
+		},
+		{
+			name:    "移除父角色",
+			cmd:     UpdateRoleCommand{RoleID: 1, ParentID: &noParent},
+			current: &parentID,
+			setupMocks: func(cmdRepo *MockRoleCommandRepository, _ *MockRoleQueryRepository) {
+				cmdRepo.On("Update", mock.Anything, mock.AnythingOfType("*role.Role")).Return(nil)
+			},
+			wantEvent: true,
+		},
+		{
+			name: "父角色为后代时拒绝形成环",
+			cmd:  UpdateRoleCommand{RoleID: 1, ParentID: &descendantID},
+			setupMocks: func(_ *MockRoleCommandRepository, qryRepo *MockRoleQueryRepository) {
+				qryRepo.On("FindByID", mock.Anything, descendantID).Return(&role.Role{ID: descendantID, Name: "intern"}, nil)
+				qryRepo.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{2: 1, 3: 2}, nil)
+			},
+			wantErr: role.ErrRoleHierarchyCycle,
+		},
+		{
+			name: "父角色为自身",
+			cmd:  UpdateRoleCommand{RoleID: 1, ParentID: func() *uint { id := uint(1); return &id }()},
+			setupMocks: func(_ *MockRoleCommandRepository, qryRepo *MockRoleQueryRepository) {
+				qryRepo.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{}, nil)
+			},
+			wantErr: role.ErrRoleHierarchyCycle,
+		},
+		{
+			name: "父角色不存在",
+			cmd:  UpdateRoleCommand{RoleID: 1, ParentID: &parentID},
+			setupMocks: func(_ *MockRoleCommandRepository, qryRepo *MockRoleQueryRepository) {
+				qryRepo.On("FindByID", mock.Anything, parentID).Return(nil, nil)
+			},
+			wantErr: role.ErrParentRoleNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockCmdRepo := new(MockRoleCommandRepository)
+			mockQryRepo := new(MockRoleQueryRepository)
+			mockEventBus := new(MockEventBus)
+
+			mockQryRepo.On("FindByID", mock.Anything, uint(1)).Return(&role.Role{
+				ID:       1,
+				Name:     "custom",
+				ParentID: tt.current,
+			}, nil)
+			tt.setupMocks(mockCmdRepo, mockQryRepo)
+			if tt.wantEvent {
+				mockEventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			handler := NewUpdateRoleHandler(mockCmdRepo, mockQryRepo, mockEventBus)
+
+			// Act
+			result, err := handler.Handle(context.Background(), tt.cmd)
+
+			// Assert
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				mockCmdRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantParent, result.ParentID)
+			mockCmdRepo.AssertExpectations(t)
+			mockEventBus.AssertExpectations(t)
+		})
+	}
+}
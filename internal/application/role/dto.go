@@ -1,18 +1,51 @@
 package role
 
-import "time"
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
+var (
+	ErrRoleNotFound       = role.ErrRoleNotFound
+	ErrParentRoleNotFound = role.ErrParentRoleNotFound
+	ErrRoleHierarchyCycle = role.ErrRoleHierarchyCycle
+
+	ErrPermissionNotGranted = role.ErrPermissionNotGranted
+	ErrInvalidCondition     = policy.ErrInvalidExpression
+
+	ErrTenantMismatch = organization.ErrTenantMismatch
+)
+
+// 重新导出权限注册表供 Adapters 层使用（遵循 DDD 依赖方向）
+type (
+	PermissionDefinition = role.PermissionDefinition
+	PermissionRegistry   = role.PermissionRegistry
+	RouteBinding         = role.RouteBinding
+)
+
+// NewPermissionRegistry 创建空的权限注册表
+var NewPermissionRegistry = role.NewPermissionRegistry
+
+// MatchPermission 检查权限模式是否覆盖权限代码（支持通配符），供 Adapters 层权限中间件使用
+var MatchPermission = role.MatchCode
 
 // CreateRoleDTO 创建角色请求 DTO
 type CreateRoleDTO struct {
 	Name        string `json:"name" binding:"required,min=2,max=50" example:"developer"`
 	DisplayName string `json:"display_name" binding:"required,max=100" example:"开发者"`
 	Description string `json:"description" binding:"max=255" example:"系统开发人员角色"`
+	ParentID    *uint  `json:"parent_id,omitempty" example:"2"`
 }
 
 // UpdateRoleDTO 更新角色请求 DTO
 type UpdateRoleDTO struct {
 	DisplayName *string `json:"display_name,omitempty" binding:"omitempty,max=100"`
 	Description *string `json:"description,omitempty" binding:"omitempty,max=255"`
+	ParentID    *uint   `json:"parent_id,omitempty"` // 0 表示移除父角色
 }
 
 // SetPermissionsDTO 设置角色权限请求 DTO
@@ -20,6 +53,11 @@ type SetPermissionsDTO struct {
 	PermissionIDs []uint `json:"permission_ids" binding:"required"`
 }
 
+// SetPermissionConditionDTO 设置权限授予策略条件请求 DTO
+type SetPermissionConditionDTO struct {
+	Condition string `json:"condition" binding:"max=1000" example:"resource.department == subject.department"`
+}
+
 // CreateRoleResultDTO 创建角色响应 DTO
 type CreateRoleResultDTO struct {
 	RoleID      uint   `json:"role_id"`
@@ -29,14 +67,23 @@ type CreateRoleResultDTO struct {
 
 // RoleDTO 角色响应 DTO
 type RoleDTO struct {
-	ID          uint             `json:"id"`
-	Name        string           `json:"name"`
-	DisplayName string           `json:"display_name"`
-	Description string           `json:"description"`
-	IsSystem    bool             `json:"is_system"`
-	Permissions []*PermissionDTO `json:"permissions,omitempty"`
-	CreatedAt   time.Time        `json:"created_at"`
-	UpdatedAt   time.Time        `json:"updated_at"`
+	ID             uint             `json:"id"`
+	Name           string           `json:"name"`
+	DisplayName    string           `json:"display_name"`
+	Description    string           `json:"description"`
+	IsSystem       bool             `json:"is_system"`
+	ParentID       *uint            `json:"parent_id,omitempty"`
+	OrganizationID *uint            `json:"organization_id,omitempty"` // 为空表示全局角色
+	Permissions    []*PermissionDTO `json:"permissions,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// EffectivePermissionsDTO 角色有效权限响应 DTO
+type EffectivePermissionsDTO struct {
+	RoleID      uint             `json:"role_id"`
+	AncestorIDs []uint           `json:"ancestor_ids"` // 继承链上的祖先角色（由近及远）
+	Permissions []*PermissionDTO `json:"permissions"`
 }
 
 // PermissionDTO 权限响应 DTO
@@ -51,6 +98,14 @@ type PermissionDTO struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// GrantDTO 角色权限授予响应 DTO
+type GrantDTO struct {
+	RoleID         uint   `json:"role_id"`
+	PermissionID   uint   `json:"permission_id"`
+	PermissionCode string `json:"permission_code"`
+	Condition      string `json:"condition,omitempty"` // 为空表示无条件授予
+}
+
 // ListRolesDTO 角色列表响应 DTO
 type ListRolesDTO struct {
 	Roles []*RoleDTO `json:"roles"`
@@ -66,3 +121,11 @@ type ListPermissionsDTO struct {
 	Page        int              `json:"page"`
 	Limit       int              `json:"limit"`
 }
+
+// SyncPermissionsResultDTO 权限目录同步结果
+type SyncPermissionsResultDTO struct {
+	Missing  []string `json:"missing"`  // 路由引用但数据库中不存在的权限（非 DryRun 时已创建）
+	Updated  []string `json:"updated"`  // 说明发生变化的权限（非 DryRun 时已更新）
+	Orphaned []string `json:"orphaned"` // 数据库中存在但没有任何路由引用的权限
+	DryRun   bool     `json:"dry_run"`
+}
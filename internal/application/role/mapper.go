@@ -11,13 +11,15 @@ func ToRoleDTO(role *role.Role) *RoleDTO {
 	}
 
 	response := &RoleDTO{
-		ID:          role.ID,
-		Name:        role.Name,
-		DisplayName: role.DisplayName,
-		Description: role.Description,
-		IsSystem:    role.IsSystem,
-		CreatedAt:   role.CreatedAt,
-		UpdatedAt:   role.UpdatedAt,
+		ID:             role.ID,
+		Name:           role.Name,
+		DisplayName:    role.DisplayName,
+		Description:    role.Description,
+		IsSystem:       role.IsSystem,
+		ParentID:       role.ParentID,
+		OrganizationID: role.OrganizationID,
+		CreatedAt:      role.CreatedAt,
+		UpdatedAt:      role.UpdatedAt,
 	}
 
 	// 转换权限列表
@@ -48,3 +50,17 @@ func ToPermissionDTO(permission *role.Permission) *PermissionDTO {
 		UpdatedAt:   permission.UpdatedAt,
 	}
 }
+
+// ToGrantDTO 将权限授予转换为 DTO
+func ToGrantDTO(grant *role.Grant) *GrantDTO {
+	if grant == nil {
+		return nil
+	}
+
+	return &GrantDTO{
+		RoleID:         grant.RoleID,
+		PermissionID:   grant.PermissionID,
+		PermissionCode: grant.PermissionCode,
+		Condition:      grant.Condition,
+	}
+}
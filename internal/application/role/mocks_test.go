@@ -49,6 +49,11 @@ func (m *MockRoleCommandRepository) RemovePermissions(ctx context.Context, roleI
 	return args.Error(0)
 }
 
+func (m *MockRoleCommandRepository) SetPermissionCondition(ctx context.Context, roleID, permissionID uint, condition string) error {
+	args := m.Called(ctx, roleID, permissionID, condition)
+	return args.Error(0)
+}
+
 // MockRoleQueryRepository 角色读仓储 Mock
 type MockRoleQueryRepository struct {
 	mock.Mock
@@ -104,6 +109,50 @@ func (m *MockRoleQueryRepository) ExistsByName(ctx context.Context, name string)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockRoleQueryRepository) GetHierarchy(ctx context.Context) (role.Hierarchy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(role.Hierarchy), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetEffectivePermissions(ctx context.Context, roleID uint) ([]role.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetGrants(ctx context.Context, roleIDs []uint) ([]role.Grant, error) {
+	args := m.Called(ctx, roleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Grant), args.Error(1)
+}
+
+// MockPermissionCommandRepository 权限写仓储 Mock
+type MockPermissionCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockPermissionCommandRepository) Create(ctx context.Context, permission *role.Permission) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionCommandRepository) Update(ctx context.Context, permission *role.Permission) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 // MockPermissionQueryRepository 权限读仓储 Mock
 type MockPermissionQueryRepository struct {
 	mock.Mock
@@ -141,6 +190,14 @@ func (m *MockPermissionQueryRepository) List(ctx context.Context, page, limit in
 	return args.Get(0).([]role.Permission), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockPermissionQueryRepository) ListAll(ctx context.Context) ([]role.Permission, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Permission), args.Error(1)
+}
+
 func (m *MockPermissionQueryRepository) ListByResource(ctx context.Context, resource string) ([]role.Permission, error) {
 	args := m.Called(ctx, resource)
 	if args.Get(0) == nil {
@@ -0,0 +1,30 @@
+package role
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// checkParent 校验父角色存在
+//
+// 租户上下文中全局角色对租户可读，但继承全局或系统角色会让租户管理员把平台权限
+// 授予本组织角色，因此父角色必须属于当前组织。
+func checkParent(ctx context.Context, roleQueryRepo role.QueryRepository, parentID uint) error {
+	parent, err := roleQueryRepo.FindByID(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to find parent role: %w", err)
+	}
+	if parent == nil {
+		return role.ErrParentRoleNotFound
+	}
+
+	if tenantID, ok := organization.TenantFromContext(ctx); ok {
+		if parent.IsSystem || parent.IsGlobal() || *parent.OrganizationID != tenantID {
+			return organization.ErrTenantMismatch
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+func TestCheckParent(t *testing.T) {
+	orgID, otherOrgID := uint(10), uint(20)
+	roles := map[uint]*role.Role{
+		1: {ID: 1, Name: "user", IsSystem: true},
+		2: {ID: 2, Name: "auditor"},
+		3: {ID: 3, Name: "org-editor", OrganizationID: &orgID},
+		4: {ID: 4, Name: "other-editor", OrganizationID: &otherOrgID},
+	}
+	tenantCtx := organization.WithTenant(context.Background(), orgID)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		parentID uint
+		wantErr  error
+	}{
+		{name: "平台级可继承全局角色", ctx: context.Background(), parentID: 2},
+		{name: "租户内继承本组织角色", ctx: tenantCtx, parentID: 3},
+		{name: "租户内不能继承系统角色", ctx: tenantCtx, parentID: 1, wantErr: organization.ErrTenantMismatch},
+		{name: "租户内不能继承全局角色", ctx: tenantCtx, parentID: 2, wantErr: organization.ErrTenantMismatch},
+		{name: "租户内不能继承其他组织角色", ctx: tenantCtx, parentID: 4, wantErr: organization.ErrTenantMismatch},
+		{name: "父角色不存在", ctx: tenantCtx, parentID: 99, wantErr: role.ErrParentRoleNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQryRepo := new(MockRoleQueryRepository)
+			if r, ok := roles[tt.parentID]; ok {
+				mockQryRepo.On("FindByID", mock.Anything, tt.parentID).Return(r, nil)
+			} else {
+				mockQryRepo.On("FindByID", mock.Anything, tt.parentID).Return(nil, nil)
+			}
+
+			err := checkParent(tt.ctx, mockQryRepo, tt.parentID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
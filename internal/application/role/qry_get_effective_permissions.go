@@ -0,0 +1,6 @@
+package role
+
+// GetEffectivePermissionsQuery 获取角色有效权限查询
+type GetEffectivePermissionsQuery struct {
+	RoleID uint
+}
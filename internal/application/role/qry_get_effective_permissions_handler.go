@@ -0,0 +1,55 @@
+package role
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// GetEffectivePermissionsHandler 获取角色有效权限查询处理器
+type GetEffectivePermissionsHandler struct {
+	roleQueryRepo role.QueryRepository
+}
+
+// NewGetEffectivePermissionsHandler 创建获取角色有效权限查询处理器
+func NewGetEffectivePermissionsHandler(roleQueryRepo role.QueryRepository) *GetEffectivePermissionsHandler {
+	return &GetEffectivePermissionsHandler{
+		roleQueryRepo: roleQueryRepo,
+	}
+}
+
+// Handle 处理获取角色有效权限查询
+// 有效权限为角色自身权限与继承链上所有祖先角色权限的并集
+func (h *GetEffectivePermissionsHandler) Handle(ctx context.Context, query GetEffectivePermissionsQuery) (*EffectivePermissionsDTO, error) {
+	exists, err := h.roleQueryRepo.Exists(ctx, query.RoleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check role existence: %w", err)
+	}
+	if !exists {
+		return nil, role.ErrRoleNotFound
+	}
+
+	hierarchy, err := h.roleQueryRepo.GetHierarchy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role hierarchy: %w", err)
+	}
+
+	permissions, err := h.roleQueryRepo.GetEffectivePermissions(ctx, query.RoleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get effective permissions: %w", err)
+	}
+
+	result := &EffectivePermissionsDTO{
+		RoleID:      query.RoleID,
+		AncestorIDs: hierarchy.Ancestors(query.RoleID),
+		Permissions: make([]*PermissionDTO, 0, len(permissions)),
+	}
+	if result.AncestorIDs == nil {
+		result.AncestorIDs = []uint{}
+	}
+	for i := range permissions {
+		result.Permissions = append(result.Permissions, ToPermissionDTO(&permissions[i]))
+	}
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+func TestGetEffectivePermissionsHandler_Handle(t *testing.T) {
+	t.Run("返回继承链与权限并集", func(t *testing.T) {
+		mockQryRepo := new(MockRoleQueryRepository)
+		mockQryRepo.On("Exists", mock.Anything, uint(3)).Return(true, nil)
+		mockQryRepo.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{2: 1, 3: 2}, nil)
+		mockQryRepo.On("GetEffectivePermissions", mock.Anything, uint(3)).Return([]role.Permission{
+			{ID: 1, Code: "user:profile:read"},
+			{ID: 2, Code: "admin:users:read"},
+		}, nil)
+
+		handler := NewGetEffectivePermissionsHandler(mockQryRepo)
+
+		result, err := handler.Handle(context.Background(), GetEffectivePermissionsQuery{RoleID: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), result.RoleID)
+		assert.Equal(t, []uint{2, 1}, result.AncestorIDs)
+		require.Len(t, result.Permissions, 2)
+		assert.Equal(t, "user:profile:read", result.Permissions[0].Code)
+		mockQryRepo.AssertExpectations(t)
+	})
+
+	t.Run("无父角色时祖先为空列表", func(t *testing.T) {
+		mockQryRepo := new(MockRoleQueryRepository)
+		mockQryRepo.On("Exists", mock.Anything, uint(1)).Return(true, nil)
+		mockQryRepo.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{}, nil)
+		mockQryRepo.On("GetEffectivePermissions", mock.Anything, uint(1)).Return([]role.Permission{}, nil)
+
+		handler := NewGetEffectivePermissionsHandler(mockQryRepo)
+
+		result, err := handler.Handle(context.Background(), GetEffectivePermissionsQuery{RoleID: 1})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.AncestorIDs)
+		assert.NotNil(t, result.AncestorIDs)
+		assert.Empty(t, result.Permissions)
+	})
+
+	t.Run("角色不存在", func(t *testing.T) {
+		mockQryRepo := new(MockRoleQueryRepository)
+		mockQryRepo.On("Exists", mock.Anything, uint(999)).Return(false, nil)
+
+		handler := NewGetEffectivePermissionsHandler(mockQryRepo)
+
+		result, err := handler.Handle(context.Background(), GetEffectivePermissionsQuery{RoleID: 999})
+
+		require.ErrorIs(t, err, role.ErrRoleNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("查询有效权限时数据库错误", func(t *testing.T) {
+		mockQryRepo := new(MockRoleQueryRepository)
+		mockQryRepo.On("Exists", mock.Anything, uint(1)).Return(true, nil)
+		mockQryRepo.On("GetHierarchy", mock.Anything).Return(role.Hierarchy{}, nil)
+		mockQryRepo.On("GetEffectivePermissions", mock.Anything, uint(1)).Return(nil, errors.New("database error"))
+
+		handler := NewGetEffectivePermissionsHandler(mockQryRepo)
+
+		result, err := handler.Handle(context.Background(), GetEffectivePermissionsQuery{RoleID: 1})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get effective permissions")
+		assert.Nil(t, result)
+	})
+}
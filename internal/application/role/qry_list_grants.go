@@ -0,0 +1,6 @@
+package role
+
+// ListGrantsQuery 获取角色权限授予（含策略条件）查询
+type ListGrantsQuery struct {
+	RoleID uint
+}
@@ -0,0 +1,42 @@
+package role
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// ListGrantsHandler 获取角色权限授予查询处理器
+type ListGrantsHandler struct {
+	roleQueryRepo role.QueryRepository
+}
+
+// NewListGrantsHandler 创建获取角色权限授予查询处理器
+func NewListGrantsHandler(roleQueryRepo role.QueryRepository) *ListGrantsHandler {
+	return &ListGrantsHandler{
+		roleQueryRepo: roleQueryRepo,
+	}
+}
+
+// Handle 处理获取角色权限授予查询
+func (h *ListGrantsHandler) Handle(ctx context.Context, query ListGrantsQuery) ([]*GrantDTO, error) {
+	exists, err := h.roleQueryRepo.Exists(ctx, query.RoleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check role existence: %w", err)
+	}
+	if !exists {
+		return nil, role.ErrRoleNotFound
+	}
+
+	grants, err := h.roleQueryRepo.GetGrants(ctx, []uint{query.RoleID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grants: %w", err)
+	}
+
+	result := make([]*GrantDTO, 0, len(grants))
+	for i := range grants {
+		result = append(result, ToGrantDTO(&grants[i]))
+	}
+	return result, nil
+}
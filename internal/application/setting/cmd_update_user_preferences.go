@@ -0,0 +1,8 @@
+package setting
+
+// UpdateUserPreferencesCommand 更新用户偏好命令
+type UpdateUserPreferencesCommand struct {
+	UserID uint
+	// Values 偏好键到新值的映射，值为 nil 时删除覆盖值恢复默认
+	Values map[string]any
+}
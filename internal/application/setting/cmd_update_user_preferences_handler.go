@@ -0,0 +1,78 @@
+package setting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+)
+
+// UpdateUserPreferencesHandler 更新用户偏好命令处理器
+type UpdateUserPreferencesHandler struct {
+	userSettingCommandRepo setting.UserSettingCommandRepository
+	settingQueryRepo       setting.QueryRepository
+	preferences            setting.PreferenceResolver
+}
+
+// NewUpdateUserPreferencesHandler 创建 UpdateUserPreferencesHandler 实例
+func NewUpdateUserPreferencesHandler(
+	userSettingCommandRepo setting.UserSettingCommandRepository,
+	settingQueryRepo setting.QueryRepository,
+	preferences setting.PreferenceResolver,
+) *UpdateUserPreferencesHandler {
+	return &UpdateUserPreferencesHandler{
+		userSettingCommandRepo: userSettingCommandRepo,
+		settingQueryRepo:       settingQueryRepo,
+		preferences:            preferences,
+	}
+}
+
+// Handle 处理更新用户偏好命令，返回更新后的生效偏好
+func (h *UpdateUserPreferencesHandler) Handle(ctx context.Context, cmd UpdateUserPreferencesCommand) ([]*PreferenceDTO, error) {
+	if len(cmd.Values) > 0 {
+		defaults, err := h.settingQueryRepo.FindByCategory(ctx, setting.CategoryPreference)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch preference defaults: %w", err)
+		}
+		defaultMap := make(map[string]*setting.Setting, len(defaults))
+		for _, d := range defaults {
+			defaultMap[d.Key] = d
+		}
+
+		// 1. 先校验全部键值，任一不合法则整体拒绝
+		upserts := make([]*setting.UserSetting, 0, len(cmd.Values))
+		var resets []string
+		for key, raw := range cmd.Values {
+			def, ok := defaultMap[key]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", setting.ErrUnknownPreference, key)
+			}
+			if raw == nil {
+				resets = append(resets, key)
+				continue
+			}
+			value, err := setting.NormalizeValue(def.ValueType, raw)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %w", setting.ErrInvalidPreferenceValue, key, err)
+			}
+			if err := setting.ValidatePreferenceValue(def, value); err != nil {
+				return nil, err
+			}
+			upserts = append(upserts, &setting.UserSetting{UserID: cmd.UserID, Key: key, Value: value})
+		}
+
+		// 2. 写入覆盖值并删除恢复默认的键
+		if err := h.userSettingCommandRepo.Upsert(ctx, upserts); err != nil {
+			return nil, fmt.Errorf("failed to save user preferences: %w", err)
+		}
+		if err := h.userSettingCommandRepo.DeleteByKeys(ctx, cmd.UserID, resets); err != nil {
+			return nil, fmt.Errorf("failed to reset user preferences: %w", err)
+		}
+	}
+
+	prefs, err := h.preferences.Resolve(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return ToPreferenceDTOs(prefs), nil
+}
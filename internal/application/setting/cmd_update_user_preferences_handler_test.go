@@ -0,0 +1,83 @@
+package setting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+)
+
+func TestUpdateUserPreferencesHandler_Handle_Success(t *testing.T) {
+	mockCmdRepo := new(MockUserSettingCommandRepository)
+	mockSettingRepo := new(MockSettingQueryRepository)
+	mockUserRepo := new(MockUserSettingQueryRepository)
+
+	mockSettingRepo.On("FindByCategory", mock.Anything, setting.CategoryPreference).Return(testPreferenceDefaults(), nil)
+	mockCmdRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(items []*setting.UserSetting) bool {
+		if len(items) != 2 {
+			return false
+		}
+		values := map[string]string{}
+		for _, item := range items {
+			if item.UserID != 7 {
+				return false
+			}
+			values[item.Key] = item.Value
+		}
+		return values[setting.KeyPreferenceLocale] == "en" && values[setting.KeyPreferenceNotifyProductEmail] == "true"
+	})).Return(nil)
+	mockCmdRepo.On("DeleteByKeys", mock.Anything, uint(7), []string{setting.KeyPreferenceTimezone}).Return(nil)
+	mockUserRepo.On("FindByUserID", mock.Anything, uint(7)).Return([]*setting.UserSetting{
+		{UserID: 7, Key: setting.KeyPreferenceLocale, Value: "en"},
+		{UserID: 7, Key: setting.KeyPreferenceNotifyProductEmail, Value: "true"},
+	}, nil)
+
+	handler := NewUpdateUserPreferencesHandler(mockCmdRepo, mockSettingRepo, NewPreferenceService(mockSettingRepo, mockUserRepo))
+	result, err := handler.Handle(context.Background(), UpdateUserPreferencesCommand{
+		UserID: 7,
+		Values: map[string]any{
+			setting.KeyPreferenceLocale:             "en",
+			setting.KeyPreferenceNotifyProductEmail: true,
+			setting.KeyPreferenceTimezone:           nil,
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	assert.Equal(t, "en", result[0].Value)
+	mockCmdRepo.AssertExpectations(t)
+}
+
+func TestUpdateUserPreferencesHandler_Handle_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]any
+		wantErr error
+	}{
+		{"未知偏好项", map[string]any{"preference.unknown": "x"}, setting.ErrUnknownPreference},
+		{"全局配置不可覆盖", map[string]any{"general.site_name": "x"}, setting.ErrUnknownPreference},
+		{"类型不匹配", map[string]any{setting.KeyPreferenceNotifyProductEmail: "maybe"}, setting.ErrInvalidPreferenceValue},
+		{"非法时区", map[string]any{setting.KeyPreferenceTimezone: "Mars/Olympus"}, setting.ErrInvalidPreferenceValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCmdRepo := new(MockUserSettingCommandRepository)
+			mockSettingRepo := new(MockSettingQueryRepository)
+			mockSettingRepo.On("FindByCategory", mock.Anything, setting.CategoryPreference).Return(testPreferenceDefaults(), nil)
+
+			handler := NewUpdateUserPreferencesHandler(
+				mockCmdRepo, mockSettingRepo, NewPreferenceService(mockSettingRepo, new(MockUserSettingQueryRepository)),
+			)
+			result, err := handler.Handle(context.Background(), UpdateUserPreferencesCommand{UserID: 7, Values: tt.values})
+
+			assert.Nil(t, result)
+			require.ErrorIs(t, err, tt.wantErr)
+			mockCmdRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
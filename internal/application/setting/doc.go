@@ -8,11 +8,18 @@
 //   - [command.UpdateSettingHandler]: 更新设置值
 //   - [command.DeleteSettingHandler]: 删除设置项
 //   - [command.BatchUpdateSettingsHandler]: 批量更新设置
+//   - [UpdateUserPreferencesHandler]: 更新当前用户的偏好覆盖值
 //
 // # Query（读操作）
 //
 //   - [query.GetSettingHandler]: 获取设置详情
 //   - [query.ListSettingsHandler]: 设置列表查询（支持分类筛选）
+//   - [GetUserPreferencesHandler]: 获取当前用户的生效偏好
+//
+// # 用户偏好
+//
+// [PreferenceService] 合并 preference 分类的默认值与用户覆盖值，
+// 实现 [setting.PreferenceResolver]，供邮件语言、审计时间展示等服务端功能使用。
 //
 // # DTO 与映射
 //
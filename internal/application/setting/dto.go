@@ -1,6 +1,10 @@
 package setting
 
-import "time"
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+)
 
 // SettingDTO 设置响应 DTO
 type SettingDTO struct {
@@ -18,3 +22,19 @@ type SettingDTO struct {
 type CreateSettingResultDTO struct {
 	ID uint `json:"id"`
 }
+
+// 偏好相关领域错误（供适配层判断，无需直接依赖领域层）
+var (
+	ErrUnknownPreference      = setting.ErrUnknownPreference
+	ErrInvalidPreferenceValue = setting.ErrInvalidPreferenceValue
+)
+
+// PreferenceDTO 用户偏好项响应 DTO
+type PreferenceDTO struct {
+	Key        string `json:"key"`
+	Label      string `json:"label"`
+	ValueType  string `json:"value_type"`
+	Value      any    `json:"value"`      // 生效值（按 ValueType 解析）
+	Default    any    `json:"default"`    // 管理员定义的默认值
+	Overridden bool   `json:"overridden"` // 是否为个人覆盖值
+}
@@ -21,3 +21,29 @@ func ToSettingDTO(setting *setting.Setting) *SettingDTO {
 		UpdatedAt: setting.UpdatedAt,
 	}
 }
+
+// ToPreferenceDTOs 将生效偏好集合转换为按键排序的 DTO 列表
+func ToPreferenceDTOs(prefs *setting.Preferences) []*PreferenceDTO {
+	keys := prefs.Keys()
+	items := make([]*PreferenceDTO, 0, len(keys))
+	for _, key := range keys {
+		p := prefs.Get(key)
+		value, err := p.Typed()
+		if err != nil {
+			value = p.Value
+		}
+		def, err := p.Default.TypedValue()
+		if err != nil {
+			def = p.Default.Value
+		}
+		items = append(items, &PreferenceDTO{
+			Key:        key,
+			Label:      p.Default.Label,
+			ValueType:  p.Default.ValueType,
+			Value:      value,
+			Default:    def,
+			Overridden: p.Overridden,
+		})
+	}
+	return items
+}
@@ -81,3 +81,40 @@ func (m *MockSettingQueryRepository) FindAll(ctx context.Context) ([]*setting.Se
 	}
 	return args.Get(0).([]*setting.Setting), args.Error(1)
 }
+
+// MockUserSettingCommandRepository 用户偏好写仓储 Mock
+type MockUserSettingCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserSettingCommandRepository) Upsert(ctx context.Context, settings []*setting.UserSetting) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
+func (m *MockUserSettingCommandRepository) DeleteByKeys(ctx context.Context, userID uint, keys []string) error {
+	args := m.Called(ctx, userID, keys)
+	return args.Error(0)
+}
+
+// MockUserSettingQueryRepository 用户偏好读仓储 Mock
+type MockUserSettingQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserSettingQueryRepository) FindByUserID(ctx context.Context, userID uint) ([]*setting.UserSetting, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*setting.UserSetting), args.Error(1)
+}
+
+// testPreferenceDefaults 返回 preference 分类下的测试默认项
+func testPreferenceDefaults() []*setting.Setting {
+	return []*setting.Setting{
+		{ID: 1, Key: setting.KeyPreferenceLocale, Value: "zh-CN", Category: setting.CategoryPreference, ValueType: setting.ValueTypeString, Label: "语言"},
+		{ID: 2, Key: setting.KeyPreferenceNotifyProductEmail, Value: "false", Category: setting.CategoryPreference, ValueType: setting.ValueTypeBoolean, Label: "接收产品动态邮件"},
+		{ID: 3, Key: setting.KeyPreferenceTimezone, Value: "Asia/Shanghai", Category: setting.CategoryPreference, ValueType: setting.ValueTypeString, Label: "时区"},
+	}
+}
@@ -0,0 +1,45 @@
+package setting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+)
+
+// PreferenceService 合并 preference 分类默认值与用户覆盖值，解析用户生效偏好
+type PreferenceService struct {
+	settingQueryRepo     setting.QueryRepository
+	userSettingQueryRepo setting.UserSettingQueryRepository
+}
+
+var _ setting.PreferenceResolver = (*PreferenceService)(nil)
+
+// NewPreferenceService 创建 PreferenceService 实例
+func NewPreferenceService(
+	settingQueryRepo setting.QueryRepository,
+	userSettingQueryRepo setting.UserSettingQueryRepository,
+) *PreferenceService {
+	return &PreferenceService{
+		settingQueryRepo:     settingQueryRepo,
+		userSettingQueryRepo: userSettingQueryRepo,
+	}
+}
+
+// Resolve 解析用户生效偏好，userID 为 0 时仅返回默认值
+func (s *PreferenceService) Resolve(ctx context.Context, userID uint) (*setting.Preferences, error) {
+	defaults, err := s.settingQueryRepo.FindByCategory(ctx, setting.CategoryPreference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch preference defaults: %w", err)
+	}
+
+	var overrides []*setting.UserSetting
+	if userID != 0 {
+		overrides, err = s.userSettingQueryRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch user preferences: %w", err)
+		}
+	}
+
+	return setting.ResolvePreferences(defaults, overrides), nil
+}
@@ -0,0 +1,6 @@
+package setting
+
+// GetUserPreferencesQuery 获取用户生效偏好查询
+type GetUserPreferencesQuery struct {
+	UserID uint
+}
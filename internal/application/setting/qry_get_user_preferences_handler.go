@@ -0,0 +1,28 @@
+package setting
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+)
+
+// GetUserPreferencesHandler 获取用户生效偏好查询处理器
+type GetUserPreferencesHandler struct {
+	preferences setting.PreferenceResolver
+}
+
+// NewGetUserPreferencesHandler 创建 GetUserPreferencesHandler 实例
+func NewGetUserPreferencesHandler(preferences setting.PreferenceResolver) *GetUserPreferencesHandler {
+	return &GetUserPreferencesHandler{
+		preferences: preferences,
+	}
+}
+
+// Handle 处理获取用户生效偏好查询
+func (h *GetUserPreferencesHandler) Handle(ctx context.Context, query GetUserPreferencesQuery) ([]*PreferenceDTO, error) {
+	prefs, err := h.preferences.Resolve(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return ToPreferenceDTOs(prefs), nil
+}
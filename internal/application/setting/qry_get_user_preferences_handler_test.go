@@ -0,0 +1,48 @@
+package setting
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+)
+
+func TestGetUserPreferencesHandler_Handle(t *testing.T) {
+	t.Run("合并默认值与个人覆盖值", func(t *testing.T) {
+		mockSettingRepo := new(MockSettingQueryRepository)
+		mockUserRepo := new(MockUserSettingQueryRepository)
+		mockSettingRepo.On("FindByCategory", mock.Anything, setting.CategoryPreference).Return(testPreferenceDefaults(), nil)
+		mockUserRepo.On("FindByUserID", mock.Anything, uint(7)).Return([]*setting.UserSetting{
+			{UserID: 7, Key: setting.KeyPreferenceNotifyProductEmail, Value: "true"},
+		}, nil)
+
+		handler := NewGetUserPreferencesHandler(NewPreferenceService(mockSettingRepo, mockUserRepo))
+		result, err := handler.Handle(context.Background(), GetUserPreferencesQuery{UserID: 7})
+
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		assert.Equal(t, setting.KeyPreferenceLocale, result[0].Key)
+		assert.Equal(t, "zh-CN", result[0].Value)
+		assert.False(t, result[0].Overridden)
+		assert.Equal(t, true, result[1].Value)
+		assert.Equal(t, false, result[1].Default)
+		assert.True(t, result[1].Overridden)
+	})
+
+	t.Run("读取默认值失败", func(t *testing.T) {
+		mockSettingRepo := new(MockSettingQueryRepository)
+		mockSettingRepo.On("FindByCategory", mock.Anything, setting.CategoryPreference).Return(nil, errors.New("db error"))
+
+		handler := NewGetUserPreferencesHandler(NewPreferenceService(mockSettingRepo, new(MockUserSettingQueryRepository)))
+		result, err := handler.Handle(context.Background(), GetUserPreferencesQuery{UserID: 7})
+
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to fetch preference defaults")
+	})
+}
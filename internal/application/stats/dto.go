@@ -4,14 +4,18 @@ import "time"
 
 // StatsDTO 系统统计响应 DTO
 type StatsDTO struct {
-	TotalUsers       int64                `json:"total_users"`
-	ActiveUsers      int64                `json:"active_users"`
-	InactiveUsers    int64                `json:"inactive_users"`
-	BannedUsers      int64                `json:"banned_users"`
-	TotalRoles       int64                `json:"total_roles"`
-	TotalPermissions int64                `json:"total_permissions"`
-	TotalMenus       int64                `json:"total_menus"`
-	RecentAuditLogs  []AuditLogSummaryDTO `json:"recent_audit_logs,omitempty"`
+	TotalUsers       int64 `json:"total_users"`
+	ActiveUsers      int64 `json:"active_users"`
+	InactiveUsers    int64 `json:"inactive_users"`
+	BannedUsers      int64 `json:"banned_users"`
+	TotalRoles       int64 `json:"total_roles"`
+	TotalPermissions int64 `json:"total_permissions"`
+	TotalMenus       int64 `json:"total_menus"`
+
+	TemporaryRoleGrants int64 `json:"temporary_role_grants"` // 生效中的限时角色授权
+	ScheduledRoleGrants int64 `json:"scheduled_role_grants"` // 尚未开始的计划角色授权
+
+	RecentAuditLogs []AuditLogSummaryDTO `json:"recent_audit_logs,omitempty"`
 }
 
 // AuditLogSummaryDTO 审计日志摘要 DTO
@@ -55,6 +55,18 @@ func (m *MockStatsQueryRepository) GetTotalMenus() (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
+// GetTemporaryRoleGrants 模拟获取生效中的限时角色授权数量
+func (m *MockStatsQueryRepository) GetTemporaryRoleGrants() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetScheduledRoleGrants 模拟获取计划角色授权数量
+func (m *MockStatsQueryRepository) GetScheduledRoleGrants() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // GetRecentAuditLogs 模拟获取最近的审计日志
 func (m *MockStatsQueryRepository) GetRecentAuditLogs(limit int) ([]stats.AuditLogSummary, error) {
 	args := m.Called(limit)
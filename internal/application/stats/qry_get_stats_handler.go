@@ -43,6 +43,9 @@ func toStatsDTO(s *stats.SystemStats) *StatsDTO {
 		TotalRoles:       s.TotalRoles,
 		TotalPermissions: s.TotalPermissions,
 		TotalMenus:       s.TotalMenus,
+
+		TemporaryRoleGrants: s.TemporaryRoleGrants,
+		ScheduledRoleGrants: s.ScheduledRoleGrants,
 	}
 
 	if len(s.RecentAuditLogs) > 0 {
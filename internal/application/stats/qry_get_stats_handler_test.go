@@ -26,6 +26,9 @@ func TestGetStatsHandler_Handle_Success(t *testing.T) {
 		TotalRoles:       10,
 		TotalPermissions: 50,
 		TotalMenus:       20,
+
+		TemporaryRoleGrants: 3,
+		ScheduledRoleGrants: 2,
 		RecentAuditLogs: []stats.AuditLogSummary{
 			{
 				ID:        1,
@@ -67,6 +70,8 @@ func TestGetStatsHandler_Handle_Success(t *testing.T) {
 	assert.Equal(t, int64(10), result.TotalRoles)
 	assert.Equal(t, int64(50), result.TotalPermissions)
 	assert.Equal(t, int64(20), result.TotalMenus)
+	assert.Equal(t, int64(3), result.TemporaryRoleGrants)
+	assert.Equal(t, int64(2), result.ScheduledRoleGrants)
 	assert.Len(t, result.RecentAuditLogs, 2)
 	assert.Equal(t, uint(1), result.RecentAuditLogs[0].ID)
 	assert.Equal(t, "admin", result.RecentAuditLogs[0].Username)
@@ -0,0 +1,26 @@
+package trash
+
+import (
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+)
+
+// bins 按资源类型索引的回收站集合
+type bins map[string]trash.Bin
+
+// newBins 按资源类型索引回收站，同一资源重复注册时后者覆盖前者
+func newBins(list []trash.Bin) bins {
+	m := make(bins, len(list))
+	for _, b := range list {
+		m[b.Resource()] = b
+	}
+	return m
+}
+
+// get 返回资源对应的回收站，资源不支持回收站时返回 ErrUnsupportedResource
+func (m bins) get(resource string) (trash.Bin, error) {
+	b, ok := m[resource]
+	if !ok {
+		return nil, trash.ErrUnsupportedResource
+	}
+	return b, nil
+}
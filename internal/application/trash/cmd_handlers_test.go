@@ -0,0 +1,121 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainSetting "github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	domainTrash "github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+)
+
+func TestRestoreItemHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("恢复菜单后失效菜单树缓存", func(t *testing.T) {
+		bin := newMockBin(domainTrash.ResourceMenus)
+		bin.On("Restore", ctx, uint(3)).Return(nil)
+		treeCache := new(MockMenuTreeCache)
+		treeCache.On("Invalidate", ctx).Once()
+
+		handler := NewRestoreItemHandler([]domainTrash.Bin{bin}, treeCache)
+		require.NoError(t, handler.Handle(ctx, RestoreItemCommand{Resource: domainTrash.ResourceMenus, ID: 3}))
+
+		bin.AssertExpectations(t)
+		treeCache.AssertExpectations(t)
+	})
+
+	t.Run("恢复冲突", func(t *testing.T) {
+		bin := newMockBin(domainTrash.ResourceUsers)
+		bin.On("Restore", ctx, uint(5)).Return(domainTrash.ErrRestoreConflict)
+		treeCache := new(MockMenuTreeCache)
+
+		handler := NewRestoreItemHandler([]domainTrash.Bin{bin}, treeCache)
+		err := handler.Handle(ctx, RestoreItemCommand{Resource: domainTrash.ResourceUsers, ID: 5})
+
+		require.ErrorIs(t, err, ErrRestoreConflict)
+		treeCache.AssertNotCalled(t, "Invalidate", mock.Anything)
+	})
+
+	t.Run("不支持的资源", func(t *testing.T) {
+		handler := NewRestoreItemHandler(nil, nil)
+		err := handler.Handle(ctx, RestoreItemCommand{Resource: "roles", ID: 1})
+
+		require.ErrorIs(t, err, ErrUnsupportedResource)
+	})
+}
+
+func TestPurgeItemHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	bin := newMockBin(domainTrash.ResourceTokens)
+	bin.On("Purge", ctx, uint(7)).Return(nil)
+	bin.On("Purge", ctx, uint(8)).Return(domainTrash.ErrItemNotFound)
+
+	handler := NewPurgeItemHandler([]domainTrash.Bin{bin})
+
+	require.NoError(t, handler.Handle(ctx, PurgeItemCommand{Resource: domainTrash.ResourceTokens, ID: 7}))
+	require.ErrorIs(t, handler.Handle(ctx, PurgeItemCommand{Resource: domainTrash.ResourceTokens, ID: 8}), ErrItemNotFound)
+	bin.AssertExpectations(t)
+}
+
+func TestPurgeExpiredHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	t.Run("按配置的保留期清理，单条失败不影响其他记录", func(t *testing.T) {
+		settingRepo := new(MockSettingQueryRepository)
+		settingRepo.On("FindByKey", ctx, domainSetting.KeyTrashRetentionDays).
+			Return(&domainSetting.Setting{Key: domainSetting.KeyTrashRetentionDays, Value: "7", ValueType: domainSetting.ValueTypeNumber}, nil)
+		cutoff := now.AddDate(0, 0, -7)
+
+		users := newMockBin(domainTrash.ResourceUsers)
+		users.On("ExpiredIDs", ctx, cutoff, domainTrash.DefaultPurgeBatchSize).Return([]uint{1, 2}, nil)
+		users.On("Purge", ctx, uint(1)).Return(nil)
+		users.On("Purge", ctx, uint(2)).Return(errors.New("db error"))
+		menus := newMockBin(domainTrash.ResourceMenus)
+		menus.On("ExpiredIDs", ctx, cutoff, domainTrash.DefaultPurgeBatchSize).Return([]uint{4}, nil)
+		menus.On("Purge", ctx, uint(4)).Return(nil)
+
+		handler := NewPurgeExpiredHandler([]domainTrash.Bin{users, menus}, settingRepo)
+		result, err := handler.Handle(ctx, PurgeExpiredCommand{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Purged)
+		assert.Equal(t, 1, result.Failed)
+		users.AssertExpectations(t)
+		menus.AssertExpectations(t)
+	})
+
+	t.Run("保留期为 0 时不清理", func(t *testing.T) {
+		settingRepo := new(MockSettingQueryRepository)
+		settingRepo.On("FindByKey", ctx, domainSetting.KeyTrashRetentionDays).
+			Return(&domainSetting.Setting{Key: domainSetting.KeyTrashRetentionDays, Value: "0", ValueType: domainSetting.ValueTypeNumber}, nil)
+		users := newMockBin(domainTrash.ResourceUsers)
+
+		handler := NewPurgeExpiredHandler([]domainTrash.Bin{users}, settingRepo)
+		result, err := handler.Handle(ctx, PurgeExpiredCommand{Now: now})
+
+		require.NoError(t, err)
+		assert.Zero(t, result.Purged)
+		users.AssertNotCalled(t, "ExpiredIDs", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("未配置时使用默认保留期", func(t *testing.T) {
+		settingRepo := new(MockSettingQueryRepository)
+		settingRepo.On("FindByKey", ctx, domainSetting.KeyTrashRetentionDays).Return(nil, errors.New("not found"))
+		users := newMockBin(domainTrash.ResourceUsers)
+		users.On("ExpiredIDs", ctx, now.AddDate(0, 0, -DefaultRetentionDays), 10).Return([]uint{}, nil)
+
+		handler := NewPurgeExpiredHandler([]domainTrash.Bin{users}, settingRepo)
+		_, err := handler.Handle(ctx, PurgeExpiredCommand{Now: now, BatchSize: 10})
+
+		require.NoError(t, err)
+		users.AssertExpectations(t)
+	})
+}
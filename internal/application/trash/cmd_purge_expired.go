@@ -0,0 +1,9 @@
+package trash
+
+import "time"
+
+// PurgeExpiredCommand 永久删除超过保留期的回收站记录命令（由后台任务定期执行）
+type PurgeExpiredCommand struct {
+	Now       time.Time
+	BatchSize int // 每个资源单轮最多删除的记录数，为空时使用 trash.DefaultPurgeBatchSize
+}
@@ -0,0 +1,79 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+)
+
+// DefaultRetentionDays 未配置回收站保留期时的默认天数
+const DefaultRetentionDays = 30
+
+// PurgeExpiredHandler 保留期清理命令处理器
+type PurgeExpiredHandler struct {
+	bins             []trash.Bin
+	settingQueryRepo setting.QueryRepository
+}
+
+// NewPurgeExpiredHandler 创建保留期清理命令处理器
+func NewPurgeExpiredHandler(trashBins []trash.Bin, settingQueryRepo setting.QueryRepository) *PurgeExpiredHandler {
+	return &PurgeExpiredHandler{
+		bins:             trashBins,
+		settingQueryRepo: settingQueryRepo,
+	}
+}
+
+// Handle 永久删除各资源中删除时间超过保留期的记录
+// 单条记录失败（如菜单仍有子菜单）不影响其他记录，在下一轮重试
+func (h *PurgeExpiredHandler) Handle(ctx context.Context, cmd PurgeExpiredCommand) (*PurgeExpiredResultDTO, error) {
+	result := &PurgeExpiredResultDTO{}
+
+	cutoff, ok := trash.RetentionCutoff(cmd.Now, h.retentionDays(ctx))
+	if !ok {
+		return result, nil
+	}
+
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = trash.DefaultPurgeBatchSize
+	}
+
+	for _, bin := range h.bins {
+		ids, err := bin.ExpiredIDs(ctx, cutoff, batchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to list expired %s: %w", bin.Resource(), err)
+		}
+		for _, id := range ids {
+			if err := bin.Purge(ctx, id); err != nil {
+				slog.Error("Failed to purge expired trash item", "resource", bin.Resource(), "id", id, "error", err)
+				result.Failed++
+				continue
+			}
+			result.Purged++
+		}
+	}
+
+	return result, nil
+}
+
+// retentionDays 从系统配置读取回收站保留天数
+// 未配置或读取失败时使用 DefaultRetentionDays，配置为 0 时不自动清理
+func (h *PurgeExpiredHandler) retentionDays(ctx context.Context) int {
+	if h.settingQueryRepo == nil {
+		return DefaultRetentionDays
+	}
+
+	s, err := h.settingQueryRepo.FindByKey(ctx, setting.KeyTrashRetentionDays)
+	if err != nil || s == nil {
+		return DefaultRetentionDays
+	}
+
+	days, err := s.ParseInt()
+	if err != nil || days < 0 {
+		return DefaultRetentionDays
+	}
+	return days
+}
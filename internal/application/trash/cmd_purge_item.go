@@ -0,0 +1,7 @@
+package trash
+
+// PurgeItemCommand 永久删除回收站记录命令
+type PurgeItemCommand struct {
+	Resource string
+	ID       uint
+}
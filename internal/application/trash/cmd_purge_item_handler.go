@@ -0,0 +1,28 @@
+package trash
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+)
+
+// PurgeItemHandler 永久删除回收站记录命令处理器
+type PurgeItemHandler struct {
+	bins bins
+}
+
+// NewPurgeItemHandler 创建永久删除回收站记录命令处理器
+func NewPurgeItemHandler(trashBins []trash.Bin) *PurgeItemHandler {
+	return &PurgeItemHandler{
+		bins: newBins(trashBins),
+	}
+}
+
+// Handle 处理永久删除回收站记录命令
+func (h *PurgeItemHandler) Handle(ctx context.Context, cmd PurgeItemCommand) error {
+	bin, err := h.bins.get(cmd.Resource)
+	if err != nil {
+		return err
+	}
+	return bin.Purge(ctx, cmd.ID)
+}
@@ -0,0 +1,7 @@
+package trash
+
+// RestoreItemCommand 恢复回收站记录命令
+type RestoreItemCommand struct {
+	Resource string
+	ID       uint
+}
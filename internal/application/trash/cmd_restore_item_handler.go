@@ -0,0 +1,40 @@
+package trash
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+)
+
+// RestoreItemHandler 恢复回收站记录命令处理器
+type RestoreItemHandler struct {
+	bins          bins
+	menuTreeCache menu.TreeCache
+}
+
+// NewRestoreItemHandler 创建恢复回收站记录命令处理器
+func NewRestoreItemHandler(trashBins []trash.Bin, menuTreeCache menu.TreeCache) *RestoreItemHandler {
+	return &RestoreItemHandler{
+		bins:          newBins(trashBins),
+		menuTreeCache: menuTreeCache,
+	}
+}
+
+// Handle 处理恢复回收站记录命令
+func (h *RestoreItemHandler) Handle(ctx context.Context, cmd RestoreItemCommand) error {
+	bin, err := h.bins.get(cmd.Resource)
+	if err != nil {
+		return err
+	}
+
+	if err := bin.Restore(ctx, cmd.ID); err != nil {
+		return err
+	}
+
+	// 恢复的菜单重新出现在菜单树中
+	if cmd.Resource == trash.ResourceMenus && h.menuTreeCache != nil {
+		h.menuTreeCache.Invalidate(ctx)
+	}
+	return nil
+}
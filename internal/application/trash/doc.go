@@ -0,0 +1,15 @@
+// Package trash 实现回收站（软删除记录的列出、恢复与永久删除）的应用层用例。
+//
+// 各资源的回收站由 Infrastructure 层实现 [trash.Bin]，在 bootstrap 中注册后按资源类型分发。
+//
+// # Command（写操作）
+//
+//   - [RestoreItemHandler]: 恢复已删除记录（唯一键被占用或上级记录已删除时返回冲突）
+//   - [PurgeItemHandler]: 永久删除已删除记录
+//   - [PurgeExpiredHandler]: 永久删除超过保留期的记录（由 worker 周期执行，
+//     保留天数读取系统配置 general.trash_retention_days）
+//
+// # Query（读操作）
+//
+//   - [ListDeletedHandler]: 按资源类型分页列出已删除记录
+package trash
@@ -0,0 +1,50 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+)
+
+// 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
+var (
+	ErrUnsupportedResource = trash.ErrUnsupportedResource
+	ErrItemNotFound        = trash.ErrItemNotFound
+	ErrRestoreConflict     = trash.ErrRestoreConflict
+	ErrPurgeConflict       = trash.ErrPurgeConflict
+)
+
+// TrashItemDTO 回收站条目 DTO
+type TrashItemDTO struct {
+	ID        uint      `json:"id"`
+	Resource  string    `json:"resource"`
+	Label     string    `json:"label"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// ListDeletedDTO 回收站列表 DTO
+type ListDeletedDTO struct {
+	Items []*TrashItemDTO `json:"items"`
+	Total int64           `json:"total"`
+	Page  int             `json:"page"`
+	Limit int             `json:"limit"`
+}
+
+// PurgeExpiredResultDTO 保留期清理结果 DTO
+type PurgeExpiredResultDTO struct {
+	Purged int `json:"purged"`
+	Failed int `json:"failed"`
+}
+
+// ToTrashItemDTO 将回收站条目转换为 DTO
+func ToTrashItemDTO(item *trash.Item) *TrashItemDTO {
+	if item == nil {
+		return nil
+	}
+	return &TrashItemDTO{
+		ID:        item.ID,
+		Resource:  item.Resource,
+		Label:     item.Label,
+		DeletedAt: item.DeletedAt,
+	}
+}
@@ -0,0 +1,129 @@
+//nolint:forcetypeassert // Mock 返回值类型在测试中总是已知的
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	domainMenu "github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	domainSetting "github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	domainTrash "github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+)
+
+// ============================================================
+// MockBin
+// ============================================================
+
+type MockBin struct {
+	mock.Mock
+
+	resource string
+}
+
+func newMockBin(resource string) *MockBin {
+	return &MockBin{resource: resource}
+}
+
+func (m *MockBin) Resource() string {
+	return m.resource
+}
+
+func (m *MockBin) List(ctx context.Context, offset, limit int) ([]*domainTrash.Item, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domainTrash.Item), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBin) Restore(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockBin) Purge(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockBin) ExpiredIDs(ctx context.Context, cutoff time.Time, limit int) ([]uint, error) {
+	args := m.Called(ctx, cutoff, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+// ============================================================
+// MockMenuTreeCache
+// ============================================================
+
+type MockMenuTreeCache struct {
+	mock.Mock
+}
+
+func (m *MockMenuTreeCache) Get(ctx context.Context, permissionSetKey string) ([]*domainMenu.Menu, bool) {
+	args := m.Called(ctx, permissionSetKey)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1)
+	}
+	return args.Get(0).([]*domainMenu.Menu), args.Bool(1)
+}
+
+func (m *MockMenuTreeCache) Set(ctx context.Context, permissionSetKey string, menus []*domainMenu.Menu) {
+	m.Called(ctx, permissionSetKey, menus)
+}
+
+func (m *MockMenuTreeCache) Invalidate(ctx context.Context) {
+	m.Called(ctx)
+}
+
+// ============================================================
+// MockSettingQueryRepository
+// ============================================================
+
+type MockSettingQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettingQueryRepository) FindByID(ctx context.Context, id uint) (*domainSetting.Setting, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindByKey(ctx context.Context, key string) (*domainSetting.Setting, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindByKeys(ctx context.Context, keys []string) ([]*domainSetting.Setting, error) {
+	args := m.Called(ctx, keys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindByCategory(ctx context.Context, category string) ([]*domainSetting.Setting, error) {
+	args := m.Called(ctx, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindAll(ctx context.Context) ([]*domainSetting.Setting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainSetting.Setting), args.Error(1)
+}
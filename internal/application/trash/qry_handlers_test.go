@@ -0,0 +1,35 @@
+package trash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainTrash "github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+)
+
+func TestListDeletedHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Now()
+
+	bin := newMockBin(domainTrash.ResourceUsers)
+	bin.On("List", ctx, 20, 20).Return([]*domainTrash.Item{
+		{ID: 9, Resource: domainTrash.ResourceUsers, Label: "alice <alice@example.com>", DeletedAt: deletedAt},
+	}, int64(21), nil)
+
+	handler := NewListDeletedHandler([]domainTrash.Bin{bin})
+	result, err := handler.Handle(ctx, ListDeletedQuery{Resource: domainTrash.ResourceUsers, Page: 2, Limit: 20})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(21), result.Total)
+	assert.Equal(t, 2, result.Page)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, uint(9), result.Items[0].ID)
+	assert.Equal(t, deletedAt, result.Items[0].DeletedAt)
+
+	_, err = handler.Handle(ctx, ListDeletedQuery{Resource: "roles", Page: 1, Limit: 20})
+	require.ErrorIs(t, err, ErrUnsupportedResource)
+}
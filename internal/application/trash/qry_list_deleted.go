@@ -0,0 +1,14 @@
+package trash
+
+// ListDeletedQuery 回收站列表查询
+type ListDeletedQuery struct {
+	Resource string
+	Page     int
+	Limit    int
+}
+
+// GetOffset 计算数据库查询偏移量
+func (q ListDeletedQuery) GetOffset() int {
+	page := max(q.Page, 1)
+	return (page - 1) * q.Limit
+}
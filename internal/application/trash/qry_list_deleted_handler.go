@@ -0,0 +1,45 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+)
+
+// ListDeletedHandler 回收站列表查询处理器
+type ListDeletedHandler struct {
+	bins bins
+}
+
+// NewListDeletedHandler 创建回收站列表查询处理器
+func NewListDeletedHandler(trashBins []trash.Bin) *ListDeletedHandler {
+	return &ListDeletedHandler{
+		bins: newBins(trashBins),
+	}
+}
+
+// Handle 处理回收站列表查询
+func (h *ListDeletedHandler) Handle(ctx context.Context, query ListDeletedQuery) (*ListDeletedDTO, error) {
+	bin, err := h.bins.get(query.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := bin.List(ctx, query.GetOffset(), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted %s: %w", query.Resource, err)
+	}
+
+	result := make([]*TrashItemDTO, 0, len(items))
+	for _, item := range items {
+		result = append(result, ToTrashItemDTO(item))
+	}
+
+	return &ListDeletedDTO{
+		Items: result,
+		Total: total,
+		Page:  query.Page,
+		Limit: query.Limit,
+	}, nil
+}
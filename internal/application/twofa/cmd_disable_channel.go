@@ -0,0 +1,7 @@
+package twofa
+
+// DisableChannelCommand 解绑验证通道命令
+type DisableChannelCommand struct {
+	UserID uint
+	Method string
+}
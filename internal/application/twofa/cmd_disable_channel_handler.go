@@ -0,0 +1,29 @@
+package twofa
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+// DisableChannelHandler 解绑验证通道命令处理器
+type DisableChannelHandler struct {
+	otpService twofa.OTPService
+}
+
+// NewDisableChannelHandler 创建解绑验证通道命令处理器
+func NewDisableChannelHandler(otpService twofa.OTPService) *DisableChannelHandler {
+	return &DisableChannelHandler{
+		otpService: otpService,
+	}
+}
+
+// Handle 处理解绑验证通道命令
+func (h *DisableChannelHandler) Handle(ctx context.Context, cmd DisableChannelCommand) error {
+	method, err := twofa.ParseMethod(cmd.Method)
+	if err != nil {
+		return err
+	}
+
+	return h.otpService.DisableChannel(ctx, cmd.UserID, method)
+}
@@ -0,0 +1,34 @@
+package twofa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+func TestDisableChannelHandler_Handle(t *testing.T) {
+	t.Run("解绑成功", func(t *testing.T) {
+		mockService := new(MockOTPService)
+		mockService.On("DisableChannel", mock.Anything, uint(1), twofa.MethodSMS).Return(nil)
+
+		handler := NewDisableChannelHandler(mockService)
+		err := handler.Handle(context.Background(), DisableChannelCommand{UserID: 1, Method: "sms"})
+
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("通道不存在", func(t *testing.T) {
+		mockService := new(MockOTPService)
+		mockService.On("DisableChannel", mock.Anything, uint(1), twofa.MethodSMS).Return(twofa.ErrChannelNotFound)
+
+		handler := NewDisableChannelHandler(mockService)
+		err := handler.Handle(context.Background(), DisableChannelCommand{UserID: 1, Method: "sms"})
+
+		require.ErrorIs(t, err, twofa.ErrChannelNotFound)
+	})
+}
@@ -0,0 +1,8 @@
+package twofa
+
+// SetupChannelCommand 绑定邮件/短信验证通道命令
+type SetupChannelCommand struct {
+	UserID      uint
+	Method      string // email / sms
+	Destination string // 邮箱或手机号（邮件通道为空时使用账户邮箱）
+}
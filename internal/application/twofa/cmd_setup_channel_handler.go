@@ -0,0 +1,37 @@
+package twofa
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+// SetupChannelHandler 绑定验证通道命令处理器
+type SetupChannelHandler struct {
+	otpService twofa.OTPService
+}
+
+// NewSetupChannelHandler 创建绑定验证通道命令处理器
+func NewSetupChannelHandler(otpService twofa.OTPService) *SetupChannelHandler {
+	return &SetupChannelHandler{
+		otpService: otpService,
+	}
+}
+
+// Handle 处理绑定验证通道命令（发送确认码）
+func (h *SetupChannelHandler) Handle(ctx context.Context, cmd SetupChannelCommand) (*SetupChannelResultDTO, error) {
+	method, err := twofa.ParseMethod(cmd.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.otpService.SetupChannel(ctx, cmd.UserID, method, cmd.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetupChannelResultDTO{
+		Method:      result.Method.String(),
+		Destination: result.Destination,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package twofa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+func TestSetupChannelHandler_Handle(t *testing.T) {
+	t.Run("成功绑定短信通道", func(t *testing.T) {
+		mockService := new(MockOTPService)
+		mockService.On("SetupChannel", mock.Anything, uint(1), twofa.MethodSMS, "+8613812345678").
+			Return(&twofa.ChannelSetupResult{Method: twofa.MethodSMS, Destination: "****5678"}, nil)
+
+		handler := NewSetupChannelHandler(mockService)
+		result, err := handler.Handle(context.Background(), SetupChannelCommand{
+			UserID:      1,
+			Method:      "sms",
+			Destination: "+8613812345678",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "sms", result.Method)
+		assert.Equal(t, "****5678", result.Destination)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("不支持的验证方式", func(t *testing.T) {
+		mockService := new(MockOTPService)
+		handler := NewSetupChannelHandler(mockService)
+
+		_, err := handler.Handle(context.Background(), SetupChannelCommand{UserID: 1, Method: "fax"})
+
+		require.ErrorIs(t, err, twofa.ErrUnsupportedMethod)
+		mockService.AssertNotCalled(t, "SetupChannel")
+	})
+
+	t.Run("发送过于频繁", func(t *testing.T) {
+		mockService := new(MockOTPService)
+		mockService.On("SetupChannel", mock.Anything, uint(1), twofa.MethodEmail, "").
+			Return(nil, twofa.ErrOTPThrottled)
+
+		handler := NewSetupChannelHandler(mockService)
+		_, err := handler.Handle(context.Background(), SetupChannelCommand{UserID: 1, Method: "email"})
+
+		require.ErrorIs(t, err, twofa.ErrOTPThrottled)
+	})
+}
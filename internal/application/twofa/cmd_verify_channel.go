@@ -0,0 +1,8 @@
+package twofa
+
+// VerifyChannelCommand 确认并启用验证通道命令
+type VerifyChannelCommand struct {
+	UserID uint
+	Method string
+	Code   string
+}
@@ -0,0 +1,29 @@
+package twofa
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+// VerifyChannelHandler 确认并启用验证通道命令处理器
+type VerifyChannelHandler struct {
+	otpService twofa.OTPService
+}
+
+// NewVerifyChannelHandler 创建确认并启用验证通道命令处理器
+func NewVerifyChannelHandler(otpService twofa.OTPService) *VerifyChannelHandler {
+	return &VerifyChannelHandler{
+		otpService: otpService,
+	}
+}
+
+// Handle 处理确认并启用验证通道命令
+func (h *VerifyChannelHandler) Handle(ctx context.Context, cmd VerifyChannelCommand) error {
+	method, err := twofa.ParseMethod(cmd.Method)
+	if err != nil {
+		return err
+	}
+
+	return h.otpService.VerifyAndEnableChannel(ctx, cmd.UserID, method, cmd.Code)
+}
@@ -0,0 +1,35 @@
+package twofa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+func TestVerifyChannelHandler_Handle(t *testing.T) {
+	t.Run("确认成功", func(t *testing.T) {
+		mockService := new(MockOTPService)
+		mockService.On("VerifyAndEnableChannel", mock.Anything, uint(1), twofa.MethodEmail, "123456").Return(nil)
+
+		handler := NewVerifyChannelHandler(mockService)
+		err := handler.Handle(context.Background(), VerifyChannelCommand{UserID: 1, Method: "email", Code: "123456"})
+
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("确认码错误", func(t *testing.T) {
+		mockService := new(MockOTPService)
+		mockService.On("VerifyAndEnableChannel", mock.Anything, uint(1), twofa.MethodEmail, "000000").
+			Return(twofa.ErrInvalidOTPCode)
+
+		handler := NewVerifyChannelHandler(mockService)
+		err := handler.Handle(context.Background(), VerifyChannelCommand{UserID: 1, Method: "email", Code: "000000"})
+
+		require.ErrorIs(t, err, twofa.ErrInvalidOTPCode)
+	})
+}
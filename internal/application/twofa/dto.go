@@ -1,6 +1,13 @@
 // Package twofa 提供两步验证应用层 DTO。
 package twofa
 
+import "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+
+// 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
+var (
+	ErrOTPThrottled = twofa.ErrOTPThrottled
+)
+
 // SetupDTO 2FA 设置响应 DTO。
 type SetupDTO struct {
 	Secret    string `json:"secret"`     // TOTP 密钥（用户可手动输入）
@@ -42,3 +49,37 @@ type GetStatusResultDTO struct {
 	Enabled            bool
 	RecoveryCodesCount int
 }
+
+// SetupChannelDTO 绑定邮件/短信验证通道请求 DTO。
+type SetupChannelDTO struct {
+	Method      string `json:"method" binding:"required,oneof=email sms" example:"email"` // 验证方式
+	Destination string `json:"destination" example:"john@example.com"`                    // 邮箱或手机号（邮件可省略，默认账户邮箱）
+}
+
+// VerifyChannelDTO 确认验证通道请求 DTO。
+type VerifyChannelDTO struct {
+	Method string `json:"method" binding:"required,oneof=email sms" example:"email"` // 验证方式
+	Code   string `json:"code" binding:"required" example:"123456"`                  // 收到的确认码
+}
+
+// DisableChannelDTO 解绑验证通道请求 DTO。
+type DisableChannelDTO struct {
+	Method string `json:"method" binding:"required,oneof=email sms" example:"sms"` // 验证方式
+}
+
+// ChannelSetupDTO 绑定验证通道响应 DTO。
+type ChannelSetupDTO struct {
+	Method      string `json:"method"`      // 验证方式
+	Destination string `json:"destination"` // 脱敏后的投递地址
+}
+
+// MethodsDTO 已启用的邮件/短信验证方式响应 DTO。
+type MethodsDTO struct {
+	Methods []string `json:"methods"` // 已启用的验证方式
+}
+
+// SetupChannelResultDTO 绑定验证通道结果 DTO（Handler 返回类型）
+type SetupChannelResultDTO struct {
+	Method      string
+	Destination string
+}
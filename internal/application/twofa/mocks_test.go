@@ -49,3 +49,50 @@ func (m *MockTwoFAService) GetStatus(ctx context.Context, userID uint) (bool, in
 	args := m.Called(ctx, userID)
 	return args.Bool(0), args.Int(1), args.Error(2)
 }
+
+// MockOTPService 邮件/短信验证码服务 Mock
+type MockOTPService struct {
+	mock.Mock
+}
+
+// SetupChannel Mock 实现
+func (m *MockOTPService) SetupChannel(ctx context.Context, userID uint, method twofa.Method, destination string) (*twofa.ChannelSetupResult, error) {
+	args := m.Called(ctx, userID, method, destination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*twofa.ChannelSetupResult), args.Error(1)
+}
+
+// VerifyAndEnableChannel Mock 实现
+func (m *MockOTPService) VerifyAndEnableChannel(ctx context.Context, userID uint, method twofa.Method, code string) error {
+	args := m.Called(ctx, userID, method, code)
+	return args.Error(0)
+}
+
+// DisableChannel Mock 实现
+func (m *MockOTPService) DisableChannel(ctx context.Context, userID uint, method twofa.Method) error {
+	args := m.Called(ctx, userID, method)
+	return args.Error(0)
+}
+
+// SendLoginCode Mock 实现
+func (m *MockOTPService) SendLoginCode(ctx context.Context, userID uint, method twofa.Method) (string, error) {
+	args := m.Called(ctx, userID, method)
+	return args.String(0), args.Error(1)
+}
+
+// VerifyLoginCode Mock 实现
+func (m *MockOTPService) VerifyLoginCode(ctx context.Context, userID uint, method twofa.Method, code string) (bool, error) {
+	args := m.Called(ctx, userID, method, code)
+	return args.Bool(0), args.Error(1)
+}
+
+// EnabledMethods Mock 实现
+func (m *MockOTPService) EnabledMethods(ctx context.Context, userID uint) ([]twofa.Method, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]twofa.Method), args.Error(1)
+}
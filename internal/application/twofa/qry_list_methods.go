@@ -0,0 +1,6 @@
+package twofa
+
+// ListMethodsQuery 获取已启用的邮件/短信验证方式查询
+type ListMethodsQuery struct {
+	UserID uint
+}
@@ -0,0 +1,33 @@
+package twofa
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+// ListMethodsHandler 获取已启用验证方式查询处理器
+type ListMethodsHandler struct {
+	otpService twofa.OTPService
+}
+
+// NewListMethodsHandler 创建获取已启用验证方式查询处理器
+func NewListMethodsHandler(otpService twofa.OTPService) *ListMethodsHandler {
+	return &ListMethodsHandler{
+		otpService: otpService,
+	}
+}
+
+// Handle 处理获取已启用验证方式查询
+func (h *ListMethodsHandler) Handle(ctx context.Context, query ListMethodsQuery) ([]string, error) {
+	methods, err := h.otpService.EnabledMethods(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(methods))
+	for _, m := range methods {
+		result = append(result, m.String())
+	}
+	return result, nil
+}
@@ -0,0 +1,25 @@
+package twofa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+func TestListMethodsHandler_Handle(t *testing.T) {
+	mockService := new(MockOTPService)
+	mockService.On("EnabledMethods", mock.Anything, uint(1)).
+		Return([]twofa.Method{twofa.MethodEmail, twofa.MethodSMS}, nil)
+
+	handler := NewListMethodsHandler(mockService)
+	result, err := handler.Handle(context.Background(), ListMethodsQuery{UserID: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"email", "sms"}, result)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,17 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// loadAttributeSchema 加载全部自定义属性定义
+func loadAttributeSchema(ctx context.Context, repo user.AttributeDefinitionQueryRepository) (user.AttributeSchema, error) {
+	defs, err := repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attribute definitions: %w", err)
+	}
+	return user.AttributeSchema(defs), nil
+}
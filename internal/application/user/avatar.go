@@ -0,0 +1,39 @@
+package user
+
+import (
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// DefaultAvatarSize 未指定尺寸时返回的头像边长
+const DefaultAvatarSize = 128
+
+// avatarURL 已上传头像的访问地址，版本参数使地址随内容变化，便于客户端长期缓存
+func avatarURL(userID uint, version string) string {
+	return fmt.Sprintf("/api/avatars/%d?v=%s", userID, version)
+}
+
+// avatarKey 头像在存储中的对象键
+func avatarKey(userID uint, version string, size int) string {
+	return fmt.Sprintf("avatars/%d/%s/%d.png", userID, version, size)
+}
+
+// identiconSeed 默认头像的种子，按用户 ID 生成，改名后保持不变
+func identiconSeed(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// avatarSize 返回不小于请求尺寸的最小已保存尺寸，超出时返回最大尺寸
+func avatarSize(requested int) int {
+	if requested <= 0 {
+		requested = DefaultAvatarSize
+	}
+	best := user.AvatarSizes[0]
+	for _, size := range user.AvatarSizes {
+		if size >= requested && size < best {
+			best = size
+		}
+	}
+	return best
+}
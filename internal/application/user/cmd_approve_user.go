@@ -0,0 +1,8 @@
+package user
+
+// ApproveUserCommand 审批注册用户命令
+type ApproveUserCommand struct {
+	UserID uint
+
+	ActorID uint // 发起操作的管理员，用于解析 ABAC 策略
+}
@@ -0,0 +1,47 @@
+package user
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ApproveUserHandler 审批注册用户命令处理器
+// 审批模式下自助注册的用户为 inactive，管理员审批后激活
+type ApproveUserHandler struct {
+	userCommandRepo user.CommandRepository
+	userQueryRepo   user.QueryRepository
+	policyResolver  policy.Resolver
+}
+
+// NewApproveUserHandler 创建审批注册用户命令处理器
+func NewApproveUserHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	policyResolver policy.Resolver,
+) *ApproveUserHandler {
+	return &ApproveUserHandler{
+		userCommandRepo: userCommandRepo,
+		userQueryRepo:   userQueryRepo,
+		policyResolver:  policyResolver,
+	}
+}
+
+// Handle 处理审批注册用户命令
+func (h *ApproveUserHandler) Handle(ctx context.Context, cmd ApproveUserCommand) error {
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if err = authorize(ctx, h.policyResolver, cmd.ActorID, PermissionUpdate, u); err != nil {
+		return err
+	}
+
+	if !u.IsInactive() {
+		return user.ErrUserNotPending
+	}
+
+	u.Activate()
+	return h.userCommandRepo.UpdateStatus(ctx, u.ID, u.Status)
+}
@@ -0,0 +1,38 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestApproveUserHandler_Handle(t *testing.T) {
+	t.Run("审批待激活用户", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+
+		mockQryRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1, Status: "inactive"}, nil)
+		mockCmdRepo.On("UpdateStatus", mock.Anything, uint(1), "active").Return(nil)
+
+		handler := NewApproveUserHandler(mockCmdRepo, mockQryRepo, nil)
+
+		require.NoError(t, handler.Handle(context.Background(), ApproveUserCommand{UserID: 1}))
+		mockCmdRepo.AssertExpectations(t)
+	})
+
+	t.Run("非待审批用户", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+
+		mockQryRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1, Status: "banned"}, nil)
+
+		handler := NewApproveUserHandler(mockCmdRepo, mockQryRepo, nil)
+
+		require.ErrorIs(t, handler.Handle(context.Background(), ApproveUserCommand{UserID: 1}), user.ErrUserNotPending)
+		mockCmdRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
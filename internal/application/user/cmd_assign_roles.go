@@ -4,4 +4,6 @@ package user
 type AssignRolesCommand struct {
 	UserID  uint
 	RoleIDs []uint
+
+	ActorID uint // 发起操作的管理员，用于解析 ABAC 策略
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
@@ -12,6 +13,7 @@ import (
 type AssignRolesHandler struct {
 	userCommandRepo user.CommandRepository
 	userQueryRepo   user.QueryRepository
+	policyResolver  policy.Resolver
 	eventBus        event.EventBus
 }
 
@@ -19,18 +21,24 @@ type AssignRolesHandler struct {
 func NewAssignRolesHandler(
 	userCommandRepo user.CommandRepository,
 	userQueryRepo user.QueryRepository,
+	policyResolver policy.Resolver,
 	eventBus event.EventBus,
 ) *AssignRolesHandler {
 	return &AssignRolesHandler{
 		userCommandRepo: userCommandRepo,
 		userQueryRepo:   userQueryRepo,
+		policyResolver:  policyResolver,
 		eventBus:        eventBus,
 	}
 }
 
 // Handle 处理分配角色命令
 func (h *AssignRolesHandler) Handle(ctx context.Context, cmd AssignRolesCommand) error {
-	if _, err := h.userQueryRepo.GetByID(ctx, cmd.UserID); err != nil {
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if err = authorize(ctx, h.policyResolver, cmd.ActorID, PermissionUpdate, u); err != nil {
 		return err
 	}
 
@@ -38,7 +38,7 @@ func TestAssignRolesHandler_Handle_Success(t *testing.T) {
 			mockCmdRepo.On("AssignRoles", mock.Anything, tt.cmd.UserID, tt.cmd.RoleIDs).Return(nil)
 			mockEventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
 
-			handler := NewAssignRolesHandler(mockCmdRepo, mockQryRepo, mockEventBus)
+			handler := NewAssignRolesHandler(mockCmdRepo, mockQryRepo, nil, mockEventBus)
 
 			// Act
 			err := handler.Handle(context.Background(), tt.cmd)
@@ -85,7 +85,7 @@ func TestAssignRolesHandler_Handle_Error(t *testing.T) {
 			mockEventBus := new(MockEventBus)
 			tt.setupMocks(mockCmdRepo, mockQryRepo, mockEventBus)
 
-			handler := NewAssignRolesHandler(mockCmdRepo, mockQryRepo, mockEventBus)
+			handler := NewAssignRolesHandler(mockCmdRepo, mockQryRepo, nil, mockEventBus)
 
 			// Act
 			err := handler.Handle(context.Background(), tt.cmd)
@@ -104,7 +104,7 @@ func TestAssignRolesHandler_Handle_NilEventBus(t *testing.T) {
 	mockQryRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1}, nil)
 	mockCmdRepo.On("AssignRoles", mock.Anything, uint(1), []uint{1}).Return(nil)
 
-	handler := NewAssignRolesHandler(mockCmdRepo, mockQryRepo, nil)
+	handler := NewAssignRolesHandler(mockCmdRepo, mockQryRepo, nil, nil)
 	err := handler.Handle(context.Background(), AssignRolesCommand{UserID: 1, RoleIDs: []uint{1}})
 
 	require.NoError(t, err)
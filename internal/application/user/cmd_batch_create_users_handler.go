@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
@@ -15,6 +16,7 @@ import (
 type BatchCreateUsersHandler struct {
 	userCommandRepo user.CommandRepository
 	userQueryRepo   user.QueryRepository
+	roleQueryRepo   role.QueryRepository
 	authService     auth.Service
 }
 
@@ -22,11 +24,13 @@ type BatchCreateUsersHandler struct {
 func NewBatchCreateUsersHandler(
 	userCommandRepo user.CommandRepository,
 	userQueryRepo user.QueryRepository,
+	roleQueryRepo role.QueryRepository,
 	authService auth.Service,
 ) *BatchCreateUsersHandler {
 	return &BatchCreateUsersHandler{
 		userCommandRepo: userCommandRepo,
 		userQueryRepo:   userQueryRepo,
+		roleQueryRepo:   roleQueryRepo,
 		authService:     authService,
 	}
 }
@@ -87,6 +91,11 @@ func (h *BatchCreateUsersHandler) createSingleUser(ctx context.Context, item Bat
 		return errors.New("邮箱已存在")
 	}
 
+	// 4.1 校验初始角色，管理角色须经角色分配接口授予
+	if err := checkInitialRoles(ctx, h.roleQueryRepo, item.RoleIDs); err != nil {
+		return fmt.Errorf("角色无效: %w", err)
+	}
+
 	// 5. 生成密码哈希
 	hashedPassword, err := h.authService.GeneratePasswordHash(ctx, item.Password)
 	if err != nil {
@@ -110,6 +119,7 @@ func (h *BatchCreateUsersHandler) createSingleUser(ctx context.Context, item Bat
 		FullName: item.FullName,
 		Status:   status,
 	}
+	newUser.RequirePasswordChange()
 
 	// 8. 保存用户
 	if err := h.userCommandRepo.Create(ctx, newUser); err != nil {
@@ -29,7 +29,7 @@ func TestBatchCreateUsersHandler_Handle_AllSuccess(t *testing.T) {
 		mockCmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.User")).Return(nil).Once()
 	}
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	// Act
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
@@ -65,7 +65,7 @@ func TestBatchCreateUsersHandler_Handle_PartialFailure(t *testing.T) {
 	mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "password2").Return(nil).Once()
 	mockQryRepo.On("ExistsByUsername", mock.Anything, "existing").Return(true, nil).Once()
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	// Act
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
@@ -92,7 +92,7 @@ func TestBatchCreateUsersHandler_Handle_ValidationError(t *testing.T) {
 		{Username: "ab", Email: "invalid", Password: ""},
 	}
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	// Act
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
@@ -123,7 +123,7 @@ func TestBatchCreateUsersHandler_Handle_WithRoles(t *testing.T) {
 	mockCmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.User")).Return(nil)
 	mockCmdRepo.On("AssignRoles", mock.Anything, uint(1), []uint{1, 2}).Return(nil)
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	// Act
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
@@ -142,7 +142,7 @@ func TestBatchCreateUsersHandler_Handle_RoleAssignmentFailure(t *testing.T) {
 	mockAuthService := new(MockAuthService)
 
 	users := []BatchUserItemDTO{
-		{Username: "user1", Email: "user1@example.com", Password: "password1", RoleIDs: []uint{999}},
+		{Username: "user1", Email: "user1@example.com", Password: "password1", RoleIDs: []uint{1}},
 	}
 
 	mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "password1").Return(nil)
@@ -150,9 +150,9 @@ func TestBatchCreateUsersHandler_Handle_RoleAssignmentFailure(t *testing.T) {
 	mockQryRepo.On("ExistsByEmail", mock.Anything, "user1@example.com").Return(false, nil)
 	mockAuthService.On("GeneratePasswordHash", mock.Anything, "password1").Return("hashed", nil)
 	mockCmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.User")).Return(nil)
-	mockCmdRepo.On("AssignRoles", mock.Anything, uint(1), []uint{999}).Return(errors.New("role not found"))
+	mockCmdRepo.On("AssignRoles", mock.Anything, uint(1), []uint{1}).Return(errors.New("db error"))
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	// Act
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
@@ -206,7 +206,7 @@ func TestBatchCreateUsersHandler_Handle_EmailValidationError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+			handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 			result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: tt.users})
 
@@ -228,7 +228,7 @@ func TestBatchCreateUsersHandler_Handle_PasswordPolicyError(t *testing.T) {
 
 	mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "weak").Return(errors.New("密码太弱"))
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
 
@@ -249,7 +249,7 @@ func TestBatchCreateUsersHandler_Handle_CheckUsernameError(t *testing.T) {
 	mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "password").Return(nil)
 	mockQryRepo.On("ExistsByUsername", mock.Anything, "validuser").Return(false, errors.New("db error"))
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
 
@@ -271,7 +271,7 @@ func TestBatchCreateUsersHandler_Handle_CheckEmailError(t *testing.T) {
 	mockQryRepo.On("ExistsByUsername", mock.Anything, "validuser").Return(false, nil)
 	mockQryRepo.On("ExistsByEmail", mock.Anything, "test@example.com").Return(false, errors.New("db error"))
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
 
@@ -293,7 +293,7 @@ func TestBatchCreateUsersHandler_Handle_EmailExists(t *testing.T) {
 	mockQryRepo.On("ExistsByUsername", mock.Anything, "validuser").Return(false, nil)
 	mockQryRepo.On("ExistsByEmail", mock.Anything, "existing@example.com").Return(true, nil)
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
 
@@ -316,7 +316,7 @@ func TestBatchCreateUsersHandler_Handle_HashPasswordError(t *testing.T) {
 	mockQryRepo.On("ExistsByEmail", mock.Anything, "test@example.com").Return(false, nil)
 	mockAuthService.On("GeneratePasswordHash", mock.Anything, "password").Return("", errors.New("hash error"))
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
 
@@ -339,7 +339,7 @@ func TestBatchCreateUsersHandler_Handle_InvalidStatus(t *testing.T) {
 	mockQryRepo.On("ExistsByEmail", mock.Anything, "test@example.com").Return(false, nil)
 	mockAuthService.On("GeneratePasswordHash", mock.Anything, "password").Return("hashed", nil)
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
 
@@ -363,7 +363,7 @@ func TestBatchCreateUsersHandler_Handle_CreateUserError(t *testing.T) {
 	mockAuthService.On("GeneratePasswordHash", mock.Anything, "password").Return("hashed", nil)
 	mockCmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.User")).Return(errors.New("db error"))
 
-	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewBatchCreateUsersHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockAuthService)
 
 	result, err := handler.Handle(context.Background(), BatchCreateUsersCommand{Users: users})
 
@@ -36,6 +36,11 @@ func (h *ChangePasswordHandler) Handle(ctx context.Context, cmd ChangePasswordCo
 		return err
 	}
 
+	// 目录用户的密码由外部身份源管理
+	if u.IsExternallyManaged() {
+		return user.ErrPasswordManagedExternally
+	}
+
 	// 验证旧密码
 	if err = h.authService.VerifyPassword(ctx, u.Password, cmd.OldPassword); err != nil {
 		return user.ErrInvalidPassword
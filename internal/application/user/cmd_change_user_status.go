@@ -0,0 +1,12 @@
+package user
+
+import "time"
+
+// ChangeUserStatusCommand 变更用户状态命令
+type ChangeUserStatusCommand struct {
+	UserID      uint
+	OperatorID  uint // 操作者，不能变更自己的状态，并据此解析 ABAC 策略
+	Status      string
+	Reason      string
+	BannedUntil *time.Time // 仅封禁可设置，为空表示永久封禁
+}
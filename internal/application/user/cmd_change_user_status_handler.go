@@ -0,0 +1,92 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ChangeUserStatusHandler 变更用户状态命令处理器
+//
+// 封禁时吊销用户已签发的会话并撤销全部个人访问令牌；每次变更记录状态历史，
+// 并发布 UserStatusChangedEvent 供缓存失效与审计处理器响应。
+type ChangeUserStatusHandler struct {
+	userCommandRepo   user.CommandRepository
+	userQueryRepo     user.QueryRepository
+	statusHistoryRepo user.StatusHistoryCommandRepository
+	patCommandRepo    pat.CommandRepository
+	policyResolver    policy.Resolver
+	eventBus          event.EventBus
+}
+
+// NewChangeUserStatusHandler 创建变更用户状态命令处理器
+func NewChangeUserStatusHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	statusHistoryRepo user.StatusHistoryCommandRepository,
+	patCommandRepo pat.CommandRepository,
+	policyResolver policy.Resolver,
+	eventBus event.EventBus,
+) *ChangeUserStatusHandler {
+	return &ChangeUserStatusHandler{
+		userCommandRepo:   userCommandRepo,
+		userQueryRepo:     userQueryRepo,
+		statusHistoryRepo: statusHistoryRepo,
+		patCommandRepo:    patCommandRepo,
+		policyResolver:    policyResolver,
+		eventBus:          eventBus,
+	}
+}
+
+// Handle 处理变更用户状态命令
+func (h *ChangeUserStatusHandler) Handle(ctx context.Context, cmd ChangeUserStatusCommand) (*UserStatusDTO, error) {
+	if cmd.OperatorID != 0 && cmd.OperatorID == cmd.UserID {
+		return nil, user.ErrCannotChangeOwnStatus
+	}
+
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(ctx, h.policyResolver, cmd.OperatorID, PermissionUpdate, u); err != nil {
+		return nil, err
+	}
+
+	if err := h.apply(ctx, u, cmd, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return ToUserStatusDTO(u), nil
+}
+
+// apply 对已加载的用户执行状态变更并处理副作用
+func (h *ChangeUserStatusHandler) apply(ctx context.Context, u *user.User, cmd ChangeUserStatusCommand, now time.Time) error {
+	from := u.Status
+	if err := u.ChangeStatus(cmd.Status, cmd.Reason, cmd.BannedUntil, now); err != nil {
+		return err
+	}
+
+	if err := h.userCommandRepo.SaveStatus(ctx, u); err != nil {
+		return err
+	}
+	if u.IsBanned() {
+		if err := h.patCommandRepo.DeleteByUserID(ctx, u.ID); err != nil {
+			return fmt.Errorf("failed to revoke tokens: %w", err)
+		}
+	}
+	if err := h.statusHistoryRepo.Create(ctx, user.NewStatusChange(u, from, cmd.Reason, cmd.OperatorID)); err != nil {
+		return err
+	}
+
+	// 发布状态变更事件，触发缓存清理（使会话吊销立即生效）与审计记录
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewUserStatusChangedEvent(u.ID, from, u.Status, cmd.Reason, cmd.OperatorID))
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestChangeUserStatusHandler_Handle_Ban(t *testing.T) {
+	// Arrange
+	until := time.Now().Add(24 * time.Hour)
+	existing := &user.User{ID: 2, Username: "member", Status: user.StatusActive}
+
+	mockCmdRepo := new(MockUserCommandRepository)
+	mockQryRepo := new(MockUserQueryRepository)
+	mockHistoryRepo := new(MockStatusHistoryCommandRepository)
+	mockPATRepo := new(MockPATCommandRepository)
+	mockEventBus := new(MockEventBus)
+
+	mockQryRepo.On("GetByID", mock.Anything, uint(2)).Return(existing, nil)
+	mockCmdRepo.On("SaveStatus", mock.Anything, existing).Return(nil)
+	mockPATRepo.On("DeleteByUserID", mock.Anything, uint(2)).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *user.StatusChange) bool {
+		return c.UserID == 2 && c.FromStatus == user.StatusActive && c.ToStatus == user.StatusBanned &&
+			c.Reason == "spam" && c.OperatorID == 1 && c.BannedUntil != nil
+	})).Return(nil)
+	mockEventBus.On("Publish", mock.Anything, mock.MatchedBy(func(evts []event.Event) bool {
+		e, ok := evts[0].(*events.UserStatusChangedEvent)
+		return ok && e.OldStatus == user.StatusActive && e.NewStatus == user.StatusBanned && e.OperatorID == 1
+	})).Return(nil)
+
+	handler := NewChangeUserStatusHandler(mockCmdRepo, mockQryRepo, mockHistoryRepo, mockPATRepo, nil, mockEventBus)
+
+	// Act
+	result, err := handler.Handle(context.Background(), ChangeUserStatusCommand{
+		UserID:      2,
+		OperatorID:  1,
+		Status:      user.StatusBanned,
+		Reason:      "spam",
+		BannedUntil: &until,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, user.StatusBanned, result.Status)
+	assert.Equal(t, "spam", result.BanReason)
+	assert.True(t, existing.IsSessionRevoked(time.Now().Add(-time.Minute)))
+	mockCmdRepo.AssertExpectations(t)
+	mockPATRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+	mockEventBus.AssertExpectations(t)
+}
+
+func TestChangeUserStatusHandler_Handle_Activate(t *testing.T) {
+	// Arrange
+	existing := &user.User{ID: 2, Status: user.StatusBanned, BanReason: "spam"}
+
+	mockCmdRepo := new(MockUserCommandRepository)
+	mockQryRepo := new(MockUserQueryRepository)
+	mockHistoryRepo := new(MockStatusHistoryCommandRepository)
+	mockPATRepo := new(MockPATCommandRepository)
+
+	mockQryRepo.On("GetByID", mock.Anything, uint(2)).Return(existing, nil)
+	mockCmdRepo.On("SaveStatus", mock.Anything, existing).Return(nil)
+	mockHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.StatusChange")).Return(nil)
+
+	handler := NewChangeUserStatusHandler(mockCmdRepo, mockQryRepo, mockHistoryRepo, mockPATRepo, nil, nil)
+
+	// Act
+	result, err := handler.Handle(context.Background(), ChangeUserStatusCommand{
+		UserID:     2,
+		OperatorID: 1,
+		Status:     user.StatusActive,
+		Reason:     "appeal accepted",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, user.StatusActive, result.Status)
+	assert.Empty(t, result.BanReason)
+	mockPATRepo.AssertNotCalled(t, "DeleteByUserID", mock.Anything, mock.Anything)
+}
+
+func TestChangeUserStatusHandler_Handle_Errors(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		cmd        ChangeUserStatusCommand
+		setupMocks func(*MockUserCommandRepository, *MockUserQueryRepository, *MockPATCommandRepository)
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name:       "不能变更自己的状态",
+			cmd:        ChangeUserStatusCommand{UserID: 1, OperatorID: 1, Status: user.StatusBanned},
+			setupMocks: func(*MockUserCommandRepository, *MockUserQueryRepository, *MockPATCommandRepository) {},
+			wantErr:    user.ErrCannotChangeOwnStatus,
+		},
+		{
+			name: "用户不存在",
+			cmd:  ChangeUserStatusCommand{UserID: 2, OperatorID: 1, Status: user.StatusBanned},
+			setupMocks: func(_ *MockUserCommandRepository, qryRepo *MockUserQueryRepository, _ *MockPATCommandRepository) {
+				qryRepo.On("GetByID", mock.Anything, uint(2)).Return(nil, user.ErrUserNotFound)
+			},
+			wantErr: user.ErrUserNotFound,
+		},
+		{
+			name: "状态未变化",
+			cmd:  ChangeUserStatusCommand{UserID: 2, OperatorID: 1, Status: user.StatusActive},
+			setupMocks: func(_ *MockUserCommandRepository, qryRepo *MockUserQueryRepository, _ *MockPATCommandRepository) {
+				qryRepo.On("GetByID", mock.Anything, uint(2)).Return(&user.User{ID: 2, Status: user.StatusActive}, nil)
+			},
+			wantErr: user.ErrUserStatusUnchanged,
+		},
+		{
+			name: "封禁期限已过",
+			cmd:  ChangeUserStatusCommand{UserID: 2, OperatorID: 1, Status: user.StatusBanned, BannedUntil: &past},
+			setupMocks: func(_ *MockUserCommandRepository, qryRepo *MockUserQueryRepository, _ *MockPATCommandRepository) {
+				qryRepo.On("GetByID", mock.Anything, uint(2)).Return(&user.User{ID: 2, Status: user.StatusActive}, nil)
+			},
+			wantErr: user.ErrInvalidBanExpiry,
+		},
+		{
+			name: "撤销令牌失败",
+			cmd:  ChangeUserStatusCommand{UserID: 2, OperatorID: 1, Status: user.StatusBanned},
+			setupMocks: func(cmdRepo *MockUserCommandRepository, qryRepo *MockUserQueryRepository, patRepo *MockPATCommandRepository) {
+				qryRepo.On("GetByID", mock.Anything, uint(2)).Return(&user.User{ID: 2, Status: user.StatusActive}, nil)
+				cmdRepo.On("SaveStatus", mock.Anything, mock.AnythingOfType("*user.User")).Return(nil)
+				patRepo.On("DeleteByUserID", mock.Anything, uint(2)).Return(errors.New("db error"))
+			},
+			wantErrMsg: "failed to revoke tokens",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockCmdRepo := new(MockUserCommandRepository)
+			mockQryRepo := new(MockUserQueryRepository)
+			mockHistoryRepo := new(MockStatusHistoryCommandRepository)
+			mockPATRepo := new(MockPATCommandRepository)
+			tt.setupMocks(mockCmdRepo, mockQryRepo, mockPATRepo)
+
+			handler := NewChangeUserStatusHandler(mockCmdRepo, mockQryRepo, mockHistoryRepo, mockPATRepo, nil, nil)
+
+			// Act
+			result, err := handler.Handle(context.Background(), tt.cmd)
+
+			// Assert
+			assert.Nil(t, result)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+			}
+			mockHistoryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
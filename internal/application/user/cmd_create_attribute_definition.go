@@ -0,0 +1,15 @@
+package user
+
+// CreateAttributeDefinitionCommand 创建自定义属性定义命令
+type CreateAttributeDefinitionCommand struct {
+	Key          string
+	Label        string
+	Description  string
+	Type         string
+	Required     bool
+	Pattern      string
+	Options      []string
+	Visibility   string // 为空时默认 user
+	UserEditable bool
+	SortOrder    int
+}
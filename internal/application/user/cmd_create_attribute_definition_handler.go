@@ -0,0 +1,61 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// CreateAttributeDefinitionHandler 创建自定义属性定义命令处理器
+type CreateAttributeDefinitionHandler struct {
+	commandRepo user.AttributeDefinitionCommandRepository
+	queryRepo   user.AttributeDefinitionQueryRepository
+}
+
+// NewCreateAttributeDefinitionHandler 创建自定义属性定义命令处理器
+func NewCreateAttributeDefinitionHandler(
+	commandRepo user.AttributeDefinitionCommandRepository,
+	queryRepo user.AttributeDefinitionQueryRepository,
+) *CreateAttributeDefinitionHandler {
+	return &CreateAttributeDefinitionHandler{
+		commandRepo: commandRepo,
+		queryRepo:   queryRepo,
+	}
+}
+
+// Handle 处理创建自定义属性定义命令
+func (h *CreateAttributeDefinitionHandler) Handle(ctx context.Context, cmd CreateAttributeDefinitionCommand) (*AttributeDefinitionDTO, error) {
+	def := &user.AttributeDefinition{
+		Key:          cmd.Key,
+		Label:        cmd.Label,
+		Description:  cmd.Description,
+		Type:         cmd.Type,
+		Required:     cmd.Required,
+		Pattern:      cmd.Pattern,
+		Options:      cmd.Options,
+		Visibility:   cmd.Visibility,
+		UserEditable: cmd.UserEditable,
+		SortOrder:    cmd.SortOrder,
+	}
+	if def.Visibility == "" {
+		def.Visibility = user.AttributeVisibilityUser
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	exists, err := h.queryRepo.ExistsByKey(ctx, def.Key)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, user.ErrAttributeKeyExists
+	}
+
+	if err := h.commandRepo.Create(ctx, def); err != nil {
+		return nil, fmt.Errorf("failed to create attribute definition: %w", err)
+	}
+
+	return ToAttributeDefinitionDTO(def), nil
+}
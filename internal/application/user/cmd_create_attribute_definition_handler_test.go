@@ -0,0 +1,78 @@
+//nolint:forcetypeassert // 测试中的类型断言是可控的
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestCreateAttributeDefinitionHandler_Handle_Success(t *testing.T) {
+	cmdRepo := new(MockAttributeDefinitionCommandRepository)
+	qryRepo := new(MockAttributeDefinitionQueryRepository)
+
+	qryRepo.On("ExistsByKey", mock.Anything, "department").Return(false, nil)
+	cmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.AttributeDefinition")).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*domainUser.AttributeDefinition).ID = 7
+		}).Return(nil)
+
+	handler := NewCreateAttributeDefinitionHandler(cmdRepo, qryRepo)
+	result, err := handler.Handle(context.Background(), CreateAttributeDefinitionCommand{
+		Key:     "department",
+		Label:   "部门",
+		Type:    domainUser.AttributeTypeEnum,
+		Options: []string{"sales", "rd"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), result.ID)
+	assert.Equal(t, domainUser.AttributeVisibilityUser, result.Visibility)
+	cmdRepo.AssertExpectations(t)
+}
+
+func TestCreateAttributeDefinitionHandler_Handle_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     CreateAttributeDefinitionCommand
+		exists  bool
+		wantErr error
+	}{
+		{
+			name:    "键已存在",
+			cmd:     CreateAttributeDefinitionCommand{Key: "department", Label: "部门", Type: domainUser.AttributeTypeString},
+			exists:  true,
+			wantErr: domainUser.ErrAttributeKeyExists,
+		},
+		{
+			name:    "枚举缺少选项",
+			cmd:     CreateAttributeDefinitionCommand{Key: "level", Label: "级别", Type: domainUser.AttributeTypeEnum},
+			wantErr: domainUser.ErrInvalidAttributeDefinition,
+		},
+		{
+			name:    "未知类型",
+			cmd:     CreateAttributeDefinitionCommand{Key: "level", Label: "级别", Type: "json"},
+			wantErr: domainUser.ErrInvalidAttributeDefinition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmdRepo := new(MockAttributeDefinitionCommandRepository)
+			qryRepo := new(MockAttributeDefinitionQueryRepository)
+			qryRepo.On("ExistsByKey", mock.Anything, tt.cmd.Key).Return(tt.exists, nil).Maybe()
+
+			handler := NewCreateAttributeDefinitionHandler(cmdRepo, qryRepo)
+			result, err := handler.Handle(context.Background(), tt.cmd)
+
+			assert.Nil(t, result)
+			require.ErrorIs(t, err, tt.wantErr)
+			cmdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -8,4 +8,6 @@ type CreateUserCommand struct {
 	FullName string
 	Status   *string // 可选：初始状态，默认 "active"
 	RoleIDs  []uint  // 可选：创建时分配角色
+
+	Attributes map[string]any // 可选：自定义属性值（校验必填属性）
 }
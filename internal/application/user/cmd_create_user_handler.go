@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
@@ -12,6 +13,8 @@ import (
 type CreateUserHandler struct {
 	userCommandRepo user.CommandRepository
 	userQueryRepo   user.QueryRepository
+	roleQueryRepo   role.QueryRepository
+	attributeRepo   user.AttributeDefinitionQueryRepository
 	authService     auth.Service
 }
 
@@ -19,11 +22,15 @@ type CreateUserHandler struct {
 func NewCreateUserHandler(
 	userCommandRepo user.CommandRepository,
 	userQueryRepo user.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	attributeRepo user.AttributeDefinitionQueryRepository,
 	authService auth.Service,
 ) *CreateUserHandler {
 	return &CreateUserHandler{
 		userCommandRepo: userCommandRepo,
 		userQueryRepo:   userQueryRepo,
+		roleQueryRepo:   roleQueryRepo,
+		attributeRepo:   attributeRepo,
 		authService:     authService,
 	}
 }
@@ -53,13 +60,28 @@ func (h *CreateUserHandler) Handle(ctx context.Context, cmd CreateUserCommand) (
 		return nil, user.ErrEmailAlreadyExists
 	}
 
-	// 4. 生成密码哈希
+	// 4. 校验自定义属性（含必填属性）
+	schema, err := loadAttributeSchema(ctx, h.attributeRepo)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := schema.Apply(nil, cmd.Attributes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4.1 校验初始角色，管理角色须经角色分配接口授予
+	if err = checkInitialRoles(ctx, h.roleQueryRepo, cmd.RoleIDs); err != nil {
+		return nil, err
+	}
+
+	// 5. 生成密码哈希
 	hashedPassword, err := h.authService.GeneratePasswordHash(ctx, cmd.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	// 5. 创建用户实体
+	// 6. 创建用户实体
 	status := "active" // 默认状态
 	if cmd.Status != nil && *cmd.Status != "" {
 		status = *cmd.Status
@@ -72,13 +94,22 @@ func (h *CreateUserHandler) Handle(ctx context.Context, cmd CreateUserCommand) (
 		FullName: cmd.FullName,
 		Status:   status,
 	}
+	// 管理员设置的初始密码需用户首次登录后修改
+	newUser.RequirePasswordChange()
 
-	// 6. 保存用户
+	// 7. 保存用户
 	if err := h.userCommandRepo.Create(ctx, newUser); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// 7. 分配角色（如果提供）
+	// 8. 保存自定义属性
+	if len(attributes) > 0 {
+		if err := h.userCommandRepo.SaveAttributes(ctx, newUser.ID, attributes); err != nil {
+			return nil, fmt.Errorf("failed to save attributes: %w", err)
+		}
+	}
+
+	// 9. 分配角色（如果提供）
 	if len(cmd.RoleIDs) > 0 {
 		if err := h.userCommandRepo.AssignRoles(ctx, newUser.ID, cmd.RoleIDs); err != nil {
 			return nil, fmt.Errorf("failed to assign roles: %w", err)
@@ -67,7 +67,7 @@ func TestCreateUserHandler_Handle_Success(t *testing.T) {
 				mockCmdRepo.On("AssignRoles", mock.Anything, uint(1), tt.cmd.RoleIDs).Return(nil)
 			}
 
-			handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+			handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(), mockAuthService)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -128,7 +128,7 @@ func TestCreateUserHandler_Handle_Error(t *testing.T) {
 			mockAuthService := new(MockAuthService)
 			tt.setupMocks(mockCmdRepo, mockQryRepo, mockAuthService)
 
-			handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+			handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(), mockAuthService)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -157,7 +157,7 @@ func TestCreateUserHandler_Handle_DefaultStatus(t *testing.T) {
 			capturedUser = args.Get(1).(*user.User)
 		}).Return(nil)
 
-	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(), mockAuthService)
 	_, err := handler.Handle(context.Background(), CreateUserCommand{
 		Username: "test",
 		Email:    "test@example.com",
@@ -181,7 +181,7 @@ func TestCreateUserHandler_Handle_CheckUsernameError(t *testing.T) {
 	mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "password123").Return(nil)
 	mockQryRepo.On("ExistsByUsername", mock.Anything, "test").Return(false, errors.New("db error"))
 
-	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(), mockAuthService)
 	result, err := handler.Handle(context.Background(), CreateUserCommand{
 		Username: "test",
 		Email:    "test@example.com",
@@ -202,7 +202,7 @@ func TestCreateUserHandler_Handle_CheckEmailError(t *testing.T) {
 	mockQryRepo.On("ExistsByUsername", mock.Anything, "test").Return(false, nil)
 	mockQryRepo.On("ExistsByEmail", mock.Anything, "test@example.com").Return(false, errors.New("db error"))
 
-	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(), mockAuthService)
 	result, err := handler.Handle(context.Background(), CreateUserCommand{
 		Username: "test",
 		Email:    "test@example.com",
@@ -224,7 +224,7 @@ func TestCreateUserHandler_Handle_HashPasswordError(t *testing.T) {
 	mockQryRepo.On("ExistsByEmail", mock.Anything, "test@example.com").Return(false, nil)
 	mockAuthService.On("GeneratePasswordHash", mock.Anything, "password123").Return("", errors.New("hash error"))
 
-	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(), mockAuthService)
 	result, err := handler.Handle(context.Background(), CreateUserCommand{
 		Username: "test",
 		Email:    "test@example.com",
@@ -247,7 +247,7 @@ func TestCreateUserHandler_Handle_CreateUserError(t *testing.T) {
 	mockAuthService.On("GeneratePasswordHash", mock.Anything, "password123").Return("hashed", nil)
 	mockCmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.User")).Return(errors.New("db error"))
 
-	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(), mockAuthService)
 	result, err := handler.Handle(context.Background(), CreateUserCommand{
 		Username: "test",
 		Email:    "test@example.com",
@@ -271,7 +271,7 @@ func TestCreateUserHandler_Handle_AssignRolesError(t *testing.T) {
 	mockCmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.User")).Return(nil)
 	mockCmdRepo.On("AssignRoles", mock.Anything, uint(1), []uint{1, 2}).Return(errors.New("role error"))
 
-	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(), mockAuthService)
 	result, err := handler.Handle(context.Background(), CreateUserCommand{
 		Username: "test",
 		Email:    "test@example.com",
@@ -301,7 +301,7 @@ func TestCreateUserHandler_Handle_WithStatus(t *testing.T) {
 			capturedUser = args.Get(1).(*user.User)
 		}).Return(nil)
 
-	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, mockAuthService)
+	handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(), mockAuthService)
 	_, err := handler.Handle(context.Background(), CreateUserCommand{
 		Username: "test",
 		Email:    "test@example.com",
@@ -313,3 +313,55 @@ func TestCreateUserHandler_Handle_WithStatus(t *testing.T) {
 	assert.NotNil(t, capturedUser)
 	assert.Equal(t, "inactive", capturedUser.Status)
 }
+
+func TestCreateUserHandler_Handle_Attributes(t *testing.T) {
+	required := &user.AttributeDefinition{
+		Key: "department", Label: "部门", Type: user.AttributeTypeString, Required: true,
+	}
+
+	t.Run("缺少必填属性", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+		mockAuthService := new(MockAuthService)
+		mockAuthService.On("ValidatePasswordPolicy", mock.Anything, mock.Anything).Return(nil)
+		mockQryRepo.On("ExistsByUsername", mock.Anything, mock.Anything).Return(false, nil)
+		mockQryRepo.On("ExistsByEmail", mock.Anything, mock.Anything).Return(false, nil)
+
+		handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(required), mockAuthService)
+		result, err := handler.Handle(context.Background(), CreateUserCommand{
+			Username: "test",
+			Email:    "test@example.com",
+			Password: "password123",
+		})
+
+		assert.Nil(t, result)
+		require.ErrorIs(t, err, user.ErrAttributeRequired)
+		mockCmdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("保存属性值", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+		mockAuthService := new(MockAuthService)
+		mockAuthService.On("ValidatePasswordPolicy", mock.Anything, mock.Anything).Return(nil)
+		mockQryRepo.On("ExistsByUsername", mock.Anything, mock.Anything).Return(false, nil)
+		mockQryRepo.On("ExistsByEmail", mock.Anything, mock.Anything).Return(false, nil)
+		mockAuthService.On("GeneratePasswordHash", mock.Anything, mock.Anything).Return("hashed", nil)
+		mockCmdRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.User")).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*user.User).ID = 3
+			}).Return(nil)
+		mockCmdRepo.On("SaveAttributes", mock.Anything, uint(3), map[string]string{"department": "sales"}).Return(nil)
+
+		handler := NewCreateUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), newTestAttributeRepo(required), mockAuthService)
+		_, err := handler.Handle(context.Background(), CreateUserCommand{
+			Username:   "test",
+			Email:      "test@example.com",
+			Password:   "password123",
+			Attributes: map[string]any{"department": " sales "},
+		})
+
+		require.NoError(t, err)
+		mockCmdRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,17 @@
+package user
+
+import "time"
+
+// DefaultDeactivateInactiveBatchSize 单次最多停用的不活跃账号数
+const DefaultDeactivateInactiveBatchSize = 100
+
+// InactivityDeactivationReason 不活跃账号自动停用时记录的原因
+const InactivityDeactivationReason = "inactive for too long"
+
+// DeactivateInactiveUsersCommand 停用长期未登录账号命令（由后台任务定期执行）
+//
+// 不活跃天数读取系统配置 security.inactive_account_days，为 0 时不停用任何账号。
+type DeactivateInactiveUsersCommand struct {
+	Now       time.Time
+	BatchSize int // 为空时使用 DefaultDeactivateInactiveBatchSize
+}
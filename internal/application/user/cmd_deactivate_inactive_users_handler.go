@@ -0,0 +1,113 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// DeactivateInactiveUsersHandler 停用长期未登录账号命令处理器
+//
+// 最近登录（从未登录时为创建时间）早于阈值的 active 用户改为 inactive，
+// 以系统身份（操作者为 0）记录状态历史并发布事件。管理员账号不会被停用，避免系统失去管理入口。
+type DeactivateInactiveUsersHandler struct {
+	userQueryRepo    user.QueryRepository
+	settingQueryRepo setting.QueryRepository
+	statusHandler    *ChangeUserStatusHandler
+}
+
+// NewDeactivateInactiveUsersHandler 创建停用长期未登录账号命令处理器
+func NewDeactivateInactiveUsersHandler(
+	userQueryRepo user.QueryRepository,
+	settingQueryRepo setting.QueryRepository,
+	statusHandler *ChangeUserStatusHandler,
+) *DeactivateInactiveUsersHandler {
+	return &DeactivateInactiveUsersHandler{
+		userQueryRepo:    userQueryRepo,
+		settingQueryRepo: settingQueryRepo,
+		statusHandler:    statusHandler,
+	}
+}
+
+// Handle 处理一批不活跃账号
+// 单个用户失败不影响其他用户，失败的用户在下一轮重试
+func (h *DeactivateInactiveUsersHandler) Handle(ctx context.Context, cmd DeactivateInactiveUsersCommand) (*DeactivateInactiveUsersResultDTO, error) {
+	result := &DeactivateInactiveUsersResultDTO{}
+	days := h.inactiveDays(ctx)
+	if days <= 0 {
+		return result, nil
+	}
+
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultDeactivateInactiveBatchSize
+	}
+
+	cutoff := cmd.Now.AddDate(0, 0, -days)
+	idle, err := h.userQueryRepo.ListByCriteria(ctx, user.ListCriteria{
+		Statuses:   []string{user.StatusActive},
+		IdleBefore: &cutoff,
+		Limit:      batchSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inactive users: %w", err)
+	}
+
+	for _, u := range idle {
+		deactivated, err := h.deactivate(ctx, u.ID, cutoff, cmd.Now)
+		if err != nil {
+			slog.Error("Failed to deactivate inactive user", "user_id", u.ID, "error", err)
+			result.Failed++
+			continue
+		}
+		if deactivated {
+			result.Deactivated++
+		}
+	}
+
+	return result, nil
+}
+
+// deactivate 停用单个不活跃用户，期间已登录、状态已变更或为管理员时返回 false
+func (h *DeactivateInactiveUsersHandler) deactivate(ctx context.Context, userID uint, cutoff, now time.Time) (bool, error) {
+	// 重新读取，避免覆盖列出之后的登录或状态调整
+	u, err := h.userQueryRepo.GetByIDWithRoles(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if u.Status != user.StatusActive || !u.IsIdleSince(cutoff) || u.IsAdmin() {
+		return false, nil
+	}
+
+	err = h.statusHandler.apply(ctx, u, ChangeUserStatusCommand{
+		UserID: u.ID,
+		Status: user.StatusInactive,
+		Reason: InactivityDeactivationReason,
+	}, now)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// inactiveDays 读取不活跃天数配置，未配置或无效时返回 0（不停用）
+func (h *DeactivateInactiveUsersHandler) inactiveDays(ctx context.Context) int {
+	if h.settingQueryRepo == nil {
+		return 0
+	}
+
+	s, err := h.settingQueryRepo.FindByKey(ctx, setting.KeyInactiveAccountDays)
+	if err != nil || s == nil {
+		return 0
+	}
+
+	days, err := s.ParseInt()
+	if err != nil || days < 0 {
+		return 0
+	}
+	return days
+}
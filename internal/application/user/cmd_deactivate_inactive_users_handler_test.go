@@ -0,0 +1,85 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestDeactivateInactiveUsersHandler_Handle(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -90)
+	idleAt := now.AddDate(0, 0, -100)
+	recentAt := now.Add(-time.Hour)
+
+	idle := &user.User{ID: 1, Status: user.StatusActive, LastLoginAt: &idleAt}
+	// 列出后又登录过的用户
+	returned := &user.User{ID: 2, Status: user.StatusActive, LastLoginAt: &recentAt}
+	admin := &user.User{ID: 3, Status: user.StatusActive, LastLoginAt: &idleAt, Roles: []role.Role{{Name: "admin"}}}
+	broken := &user.User{ID: 4, Status: user.StatusActive, CreatedAt: idleAt}
+
+	mockCmdRepo := new(MockUserCommandRepository)
+	mockQryRepo := new(MockUserQueryRepository)
+	mockHistoryRepo := new(MockStatusHistoryCommandRepository)
+	mockSettingRepo := new(MockSettingQueryRepository)
+
+	mockSettingRepo.On("FindByKey", mock.Anything, setting.KeyInactiveAccountDays).
+		Return(&setting.Setting{Key: setting.KeyInactiveAccountDays, Value: "90", ValueType: setting.ValueTypeNumber}, nil)
+	mockQryRepo.On("ListByCriteria", mock.Anything, mock.MatchedBy(func(c user.ListCriteria) bool {
+		return c.IdleBefore != nil && c.IdleBefore.Equal(cutoff) &&
+			assert.ObjectsAreEqual([]string{user.StatusActive}, c.Statuses) && c.Limit == DefaultDeactivateInactiveBatchSize
+	})).Return([]*user.User{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}, nil)
+	mockQryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(idle, nil)
+	mockQryRepo.On("GetByIDWithRoles", mock.Anything, uint(2)).Return(returned, nil)
+	mockQryRepo.On("GetByIDWithRoles", mock.Anything, uint(3)).Return(admin, nil)
+	mockQryRepo.On("GetByIDWithRoles", mock.Anything, uint(4)).Return(broken, nil)
+	mockCmdRepo.On("SaveStatus", mock.Anything, idle).Return(nil)
+	mockCmdRepo.On("SaveStatus", mock.Anything, broken).Return(errors.New("db error"))
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *user.StatusChange) bool {
+		return c.UserID == 1 && c.ToStatus == user.StatusInactive && c.OperatorID == 0 && c.Reason == InactivityDeactivationReason
+	})).Return(nil)
+
+	statusHandler := NewChangeUserStatusHandler(mockCmdRepo, mockQryRepo, mockHistoryRepo, new(MockPATCommandRepository), nil, nil)
+	handler := NewDeactivateInactiveUsersHandler(mockQryRepo, mockSettingRepo, statusHandler)
+
+	// Act
+	result, err := handler.Handle(context.Background(), DeactivateInactiveUsersCommand{Now: now})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Deactivated)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, user.StatusInactive, idle.Status)
+	assert.Equal(t, user.StatusActive, returned.Status)
+	assert.Equal(t, user.StatusActive, admin.Status)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestDeactivateInactiveUsersHandler_Handle_Disabled(t *testing.T) {
+	for _, value := range []string{"0", "invalid"} {
+		t.Run(value, func(t *testing.T) {
+			mockQryRepo := new(MockUserQueryRepository)
+			mockSettingRepo := new(MockSettingQueryRepository)
+			mockSettingRepo.On("FindByKey", mock.Anything, setting.KeyInactiveAccountDays).
+				Return(&setting.Setting{Key: setting.KeyInactiveAccountDays, Value: value, ValueType: setting.ValueTypeNumber}, nil)
+
+			handler := NewDeactivateInactiveUsersHandler(mockQryRepo, mockSettingRepo, nil)
+
+			result, err := handler.Handle(context.Background(), DeactivateInactiveUsersCommand{Now: time.Now()})
+
+			require.NoError(t, err)
+			assert.Zero(t, result.Deactivated)
+			mockQryRepo.AssertNotCalled(t, "ListByCriteria", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package user
+
+// DeleteAttributeDefinitionCommand 删除自定义属性定义命令
+type DeleteAttributeDefinitionCommand struct {
+	ID uint
+}
@@ -0,0 +1,25 @@
+package user
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// DeleteAttributeDefinitionHandler 删除自定义属性定义命令处理器
+// 同时删除所有用户在该属性上的值
+type DeleteAttributeDefinitionHandler struct {
+	commandRepo user.AttributeDefinitionCommandRepository
+}
+
+// NewDeleteAttributeDefinitionHandler 创建删除自定义属性定义命令处理器
+func NewDeleteAttributeDefinitionHandler(commandRepo user.AttributeDefinitionCommandRepository) *DeleteAttributeDefinitionHandler {
+	return &DeleteAttributeDefinitionHandler{
+		commandRepo: commandRepo,
+	}
+}
+
+// Handle 处理删除自定义属性定义命令
+func (h *DeleteAttributeDefinitionHandler) Handle(ctx context.Context, cmd DeleteAttributeDefinitionCommand) error {
+	return h.commandRepo.Delete(ctx, cmd.ID)
+}
@@ -0,0 +1,6 @@
+package user
+
+// DeleteAvatarCommand 删除头像命令（恢复为默认头像）
+type DeleteAvatarCommand struct {
+	UserID uint
+}
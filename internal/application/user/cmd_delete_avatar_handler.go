@@ -0,0 +1,47 @@
+package user
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/storage"
+)
+
+// DeleteAvatarHandler 删除头像命令处理器
+type DeleteAvatarHandler struct {
+	userCommandRepo user.CommandRepository
+	userQueryRepo   user.QueryRepository
+	store           storage.Storage
+}
+
+// NewDeleteAvatarHandler 创建删除头像命令处理器
+func NewDeleteAvatarHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	store storage.Storage,
+) *DeleteAvatarHandler {
+	return &DeleteAvatarHandler{
+		userCommandRepo: userCommandRepo,
+		userQueryRepo:   userQueryRepo,
+		store:           store,
+	}
+}
+
+// Handle 清除头像（包括外部头像地址）并删除已上传的文件
+func (h *DeleteAvatarHandler) Handle(ctx context.Context, cmd DeleteAvatarCommand) error {
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	previous := u.AvatarVersion
+	u.ClearAvatar()
+	if err := h.userCommandRepo.UpdateAvatar(ctx, u.ID, u.Avatar, u.AvatarVersion); err != nil {
+		return err
+	}
+
+	if previous != "" {
+		removeAvatarFiles(ctx, h.store, u.ID, previous)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package user
+
+import "time"
+
+// DefaultAccountDeletionBatchSize 单次最多完成删除的账号数
+const DefaultAccountDeletionBatchSize = 100
+
+// FinalizeAccountDeletionsCommand 完成宽限期已结束的账号删除命令（由后台任务定期执行）
+type FinalizeAccountDeletionsCommand struct {
+	Now       time.Time
+	BatchSize int // 为空时使用 DefaultAccountDeletionBatchSize
+}
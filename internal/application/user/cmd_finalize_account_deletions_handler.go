@@ -0,0 +1,122 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// FinalizeAccountDeletionsHandler 完成账号删除命令处理器
+//
+// 对每个宽限期已结束的账号：匿名化其审计日志、撤销个人访问令牌、
+// 删除 2FA 配置与验证通道和数据导出归档，最后删除账号并发布删除事件。
+type FinalizeAccountDeletionsHandler struct {
+	userCommandRepo   user.CommandRepository
+	userQueryRepo     user.QueryRepository
+	auditLogCmdRepo   auditlog.CommandRepository
+	patCommandRepo    pat.CommandRepository
+	twofaCommandRepo  twofa.CommandRepository
+	channelCmdRepo    twofa.ChannelCommandRepository
+	dataExportCmdRepo user.DataExportCommandRepository
+	eventBus          event.EventBus
+}
+
+// NewFinalizeAccountDeletionsHandler 创建完成账号删除命令处理器
+func NewFinalizeAccountDeletionsHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	auditLogCmdRepo auditlog.CommandRepository,
+	patCommandRepo pat.CommandRepository,
+	twofaCommandRepo twofa.CommandRepository,
+	channelCmdRepo twofa.ChannelCommandRepository,
+	dataExportCmdRepo user.DataExportCommandRepository,
+	eventBus event.EventBus,
+) *FinalizeAccountDeletionsHandler {
+	return &FinalizeAccountDeletionsHandler{
+		userCommandRepo:   userCommandRepo,
+		userQueryRepo:     userQueryRepo,
+		auditLogCmdRepo:   auditLogCmdRepo,
+		patCommandRepo:    patCommandRepo,
+		twofaCommandRepo:  twofaCommandRepo,
+		channelCmdRepo:    channelCmdRepo,
+		dataExportCmdRepo: dataExportCmdRepo,
+		eventBus:          eventBus,
+	}
+}
+
+// Handle 处理一批到期的账号删除
+// 单个账号失败不影响其他账号，失败的账号保留删除计划，在下一轮重试
+func (h *FinalizeAccountDeletionsHandler) Handle(ctx context.Context, cmd FinalizeAccountDeletionsCommand) (*FinalizeAccountDeletionsResultDTO, error) {
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultAccountDeletionBatchSize
+	}
+
+	due, err := h.userQueryRepo.ListByCriteria(ctx, user.ListCriteria{DeletionDueBefore: &cmd.Now, Limit: batchSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts due for deletion: %w", err)
+	}
+
+	result := &FinalizeAccountDeletionsResultDTO{}
+	for _, u := range due {
+		deleted, err := h.finalize(ctx, u.ID, cmd)
+		if err != nil {
+			slog.Error("Failed to finalize account deletion", "user_id", u.ID, "error", err)
+			result.Failed++
+			continue
+		}
+		if deleted {
+			result.Deleted++
+		}
+	}
+
+	return result, nil
+}
+
+// finalize 完成单个账号的删除，删除计划已取消时返回 false
+func (h *FinalizeAccountDeletionsHandler) finalize(ctx context.Context, userID uint, cmd FinalizeAccountDeletionsCommand) (bool, error) {
+	// 重新读取，避免删除在列出之后通过登录取消的账号
+	u, err := h.userQueryRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if !u.IsDeletionDue(cmd.Now) {
+		return false, nil
+	}
+
+	if err := h.auditLogCmdRepo.AnonymizeUser(ctx, u.ID, deletedUserPseudonym(u.ID)); err != nil {
+		return false, err
+	}
+	if err := h.patCommandRepo.DeleteByUserID(ctx, u.ID); err != nil {
+		return false, fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	if err := h.twofaCommandRepo.Delete(ctx, u.ID); err != nil {
+		return false, err
+	}
+	if err := h.channelCmdRepo.DeleteByUserID(ctx, u.ID); err != nil {
+		return false, err
+	}
+	if err := h.dataExportCmdRepo.DeleteByUserID(ctx, u.ID); err != nil {
+		return false, err
+	}
+	if err := h.userCommandRepo.Delete(ctx, u.ID); err != nil {
+		return false, fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewUserDeletedEvent(u.ID)) // 缓存清理失败不阻塞业务
+	}
+	return true, nil
+}
+
+// deletedUserPseudonym 已删除用户在审计日志中的化名
+func deletedUserPseudonym(userID uint) string {
+	return fmt.Sprintf("deleted-user-%d", userID)
+}
@@ -0,0 +1,112 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// finalizeDeletionMocks 完成账号删除处理器依赖的 Mock
+type finalizeDeletionMocks struct {
+	userCmd    *MockUserCommandRepository
+	userQry    *MockUserQueryRepository
+	auditCmd   *MockAuditLogCommandRepository
+	patCmd     *MockPATCommandRepository
+	twofaCmd   *MockTwoFACommandRepository
+	channelCmd *MockTwoFAChannelCommandRepository
+	exportCmd  *MockDataExportCommandRepository
+	eventBus   *MockEventBus
+}
+
+func newFinalizeDeletionMocks() *finalizeDeletionMocks {
+	return &finalizeDeletionMocks{
+		userCmd:    new(MockUserCommandRepository),
+		userQry:    new(MockUserQueryRepository),
+		auditCmd:   new(MockAuditLogCommandRepository),
+		patCmd:     new(MockPATCommandRepository),
+		twofaCmd:   new(MockTwoFACommandRepository),
+		channelCmd: new(MockTwoFAChannelCommandRepository),
+		exportCmd:  new(MockDataExportCommandRepository),
+		eventBus:   new(MockEventBus),
+	}
+}
+
+func (m *finalizeDeletionMocks) handler() *FinalizeAccountDeletionsHandler {
+	return NewFinalizeAccountDeletionsHandler(m.userCmd, m.userQry, m.auditCmd, m.patCmd, m.twofaCmd, m.channelCmd, m.exportCmd, m.eventBus)
+}
+
+func TestFinalizeAccountDeletionsHandler_Handle(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	scheduledAt := now.Add(-time.Hour)
+	due := func(id uint) *domainUser.User {
+		return &domainUser.User{ID: id, Username: "alice", DeletionRequestedAt: &scheduledAt, DeletionScheduledAt: &scheduledAt}
+	}
+
+	t.Run("匿名化日志、吊销令牌、删除 2FA 后删除账号", func(t *testing.T) {
+		m := newFinalizeDeletionMocks()
+		m.userQry.On("ListByCriteria", mock.Anything, mock.MatchedBy(func(c domainUser.ListCriteria) bool {
+			return c.DeletionDueBefore != nil && c.DeletionDueBefore.Equal(now) && c.Limit == DefaultAccountDeletionBatchSize
+		})).Return([]*domainUser.User{due(7)}, nil)
+		m.userQry.On("GetByID", mock.Anything, uint(7)).Return(due(7), nil)
+		m.auditCmd.On("AnonymizeUser", mock.Anything, uint(7), "deleted-user-7").Return(nil)
+		m.patCmd.On("DeleteByUserID", mock.Anything, uint(7)).Return(nil)
+		m.twofaCmd.On("Delete", mock.Anything, uint(7)).Return(nil)
+		m.channelCmd.On("DeleteByUserID", mock.Anything, uint(7)).Return(nil)
+		m.exportCmd.On("DeleteByUserID", mock.Anything, uint(7)).Return(nil)
+		m.userCmd.On("Delete", mock.Anything, uint(7)).Return(nil)
+		m.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := m.handler().Handle(context.Background(), FinalizeAccountDeletionsCommand{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Deleted)
+		assert.Equal(t, 0, result.Failed)
+		m.auditCmd.AssertExpectations(t)
+		m.patCmd.AssertExpectations(t)
+		m.twofaCmd.AssertExpectations(t)
+		m.channelCmd.AssertExpectations(t)
+		m.userCmd.AssertExpectations(t)
+		m.eventBus.AssertExpectations(t)
+	})
+
+	t.Run("已通过登录取消的账号不删除", func(t *testing.T) {
+		m := newFinalizeDeletionMocks()
+		m.userQry.On("ListByCriteria", mock.Anything, mock.Anything).Return([]*domainUser.User{due(7)}, nil)
+		m.userQry.On("GetByID", mock.Anything, uint(7)).Return(&domainUser.User{ID: 7}, nil)
+
+		result, err := m.handler().Handle(context.Background(), FinalizeAccountDeletionsCommand{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Deleted)
+		m.userCmd.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("单个账号失败不影响其他账号", func(t *testing.T) {
+		m := newFinalizeDeletionMocks()
+		m.userQry.On("ListByCriteria", mock.Anything, mock.Anything).Return([]*domainUser.User{due(7), due(8)}, nil)
+		m.userQry.On("GetByID", mock.Anything, uint(7)).Return(due(7), nil)
+		m.userQry.On("GetByID", mock.Anything, uint(8)).Return(due(8), nil)
+		m.auditCmd.On("AnonymizeUser", mock.Anything, uint(7), mock.Anything).Return(errors.New("db down"))
+		m.auditCmd.On("AnonymizeUser", mock.Anything, uint(8), mock.Anything).Return(nil)
+		m.patCmd.On("DeleteByUserID", mock.Anything, uint(8)).Return(nil)
+		m.twofaCmd.On("Delete", mock.Anything, uint(8)).Return(nil)
+		m.channelCmd.On("DeleteByUserID", mock.Anything, uint(8)).Return(nil)
+		m.exportCmd.On("DeleteByUserID", mock.Anything, uint(8)).Return(nil)
+		m.userCmd.On("Delete", mock.Anything, uint(8)).Return(nil)
+		m.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := m.handler().Handle(context.Background(), FinalizeAccountDeletionsCommand{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Deleted)
+		assert.Equal(t, 1, result.Failed)
+		m.userCmd.AssertNotCalled(t, "Delete", mock.Anything, uint(7))
+	})
+}
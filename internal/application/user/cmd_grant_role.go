@@ -0,0 +1,14 @@
+package user
+
+import "time"
+
+// GrantRoleCommand 限时角色授权命令
+// StartsAt 为空表示立即生效，ExpiresAt 为空表示永不过期
+type GrantRoleCommand struct {
+	UserID    uint
+	RoleID    uint
+	StartsAt  *time.Time
+	ExpiresAt *time.Time
+
+	ActorID uint // 发起操作的管理员，用于解析 ABAC 策略
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// GrantRoleHandler 负责为用户授予（限时）角色
+// 与 AssignRolesHandler 的整体替换不同，这里仅创建或覆盖单个角色的授权
+type GrantRoleHandler struct {
+	userQueryRepo     user.QueryRepository
+	roleQueryRepo     role.QueryRepository
+	assignmentCmdRepo user.RoleAssignmentCommandRepository
+	policyResolver    policy.Resolver
+	eventBus          event.EventBus
+}
+
+// NewGrantRoleHandler 创建限时角色授权处理器
+func NewGrantRoleHandler(
+	userQueryRepo user.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	assignmentCmdRepo user.RoleAssignmentCommandRepository,
+	policyResolver policy.Resolver,
+	eventBus event.EventBus,
+) *GrantRoleHandler {
+	return &GrantRoleHandler{
+		userQueryRepo:     userQueryRepo,
+		roleQueryRepo:     roleQueryRepo,
+		assignmentCmdRepo: assignmentCmdRepo,
+		policyResolver:    policyResolver,
+		eventBus:          eventBus,
+	}
+}
+
+// Handle 处理限时角色授权命令
+// 立即生效的授权发布角色分配事件；计划授权由后台任务在开始时间到达后激活
+func (h *GrantRoleHandler) Handle(ctx context.Context, cmd GrantRoleCommand) (*RoleAssignmentDTO, error) {
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(ctx, h.policyResolver, cmd.ActorID, PermissionUpdate, u); err != nil {
+		return nil, err
+	}
+
+	exists, err := h.roleQueryRepo.Exists(ctx, cmd.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, user.ErrRoleNotFound
+	}
+
+	now := time.Now()
+	assignment, err := user.NewRoleAssignment(cmd.UserID, cmd.RoleID, cmd.StartsAt, cmd.ExpiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.assignmentCmdRepo.Grant(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	// 覆盖已有授权也可能改变当前权限，统一失效缓存
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewUserRoleAssignedEvent(cmd.UserID, []uint{cmd.RoleID})) // 缓存失效失败不阻塞业务
+	}
+
+	return ToRoleAssignmentDTO(assignment, now), nil
+}
@@ -0,0 +1,104 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestGrantRoleHandler_Handle(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		cmd        GrantRoleCommand
+		setupMocks func(*MockUserQueryRepository, *MockRoleQueryRepository, *MockRoleAssignmentCommandRepository, *MockEventBus)
+		wantErr    error
+		wantStatus string
+	}{
+		{
+			name: "立即生效的限时授权",
+			cmd:  GrantRoleCommand{UserID: 1, RoleID: 2, ExpiresAt: &future},
+			setupMocks: func(userRepo *MockUserQueryRepository, roleRepo *MockRoleQueryRepository, assignRepo *MockRoleAssignmentCommandRepository, eventBus *MockEventBus) {
+				userRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1}, nil)
+				roleRepo.On("Exists", mock.Anything, uint(2)).Return(true, nil)
+				assignRepo.On("Grant", mock.Anything, mock.MatchedBy(func(a *user.RoleAssignment) bool {
+					return a.UserID == 1 && a.RoleID == 2 && a.ActivatedAt != nil
+				})).Return(nil)
+				eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantStatus: user.AssignmentStatusActive,
+		},
+		{
+			name: "计划授权",
+			cmd:  GrantRoleCommand{UserID: 1, RoleID: 2, StartsAt: &future},
+			setupMocks: func(userRepo *MockUserQueryRepository, roleRepo *MockRoleQueryRepository, assignRepo *MockRoleAssignmentCommandRepository, eventBus *MockEventBus) {
+				userRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1}, nil)
+				roleRepo.On("Exists", mock.Anything, uint(2)).Return(true, nil)
+				assignRepo.On("Grant", mock.Anything, mock.MatchedBy(func(a *user.RoleAssignment) bool {
+					return a.ActivatedAt == nil
+				})).Return(nil)
+				eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantStatus: user.AssignmentStatusScheduled,
+		},
+		{
+			name: "用户不存在",
+			cmd:  GrantRoleCommand{UserID: 999, RoleID: 2},
+			setupMocks: func(userRepo *MockUserQueryRepository, roleRepo *MockRoleQueryRepository, assignRepo *MockRoleAssignmentCommandRepository, eventBus *MockEventBus) {
+				userRepo.On("GetByID", mock.Anything, uint(999)).Return(nil, user.ErrUserNotFound)
+			},
+			wantErr: user.ErrUserNotFound,
+		},
+		{
+			name: "角色不存在",
+			cmd:  GrantRoleCommand{UserID: 1, RoleID: 999},
+			setupMocks: func(userRepo *MockUserQueryRepository, roleRepo *MockRoleQueryRepository, assignRepo *MockRoleAssignmentCommandRepository, eventBus *MockEventBus) {
+				userRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1}, nil)
+				roleRepo.On("Exists", mock.Anything, uint(999)).Return(false, nil)
+			},
+			wantErr: user.ErrRoleNotFound,
+		},
+		{
+			name: "到期时间早于当前时间",
+			cmd:  GrantRoleCommand{UserID: 1, RoleID: 2, ExpiresAt: &past},
+			setupMocks: func(userRepo *MockUserQueryRepository, roleRepo *MockRoleQueryRepository, assignRepo *MockRoleAssignmentCommandRepository, eventBus *MockEventBus) {
+				userRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1}, nil)
+				roleRepo.On("Exists", mock.Anything, uint(2)).Return(true, nil)
+			},
+			wantErr: user.ErrInvalidAssignmentWindow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(MockUserQueryRepository)
+			roleRepo := new(MockRoleQueryRepository)
+			assignRepo := new(MockRoleAssignmentCommandRepository)
+			eventBus := new(MockEventBus)
+			tt.setupMocks(userRepo, roleRepo, assignRepo, eventBus)
+
+			handler := NewGrantRoleHandler(userRepo, roleRepo, assignRepo, nil, eventBus)
+			result, err := handler.Handle(context.Background(), tt.cmd)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assignRepo.AssertNotCalled(t, "Grant", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assignRepo.AssertExpectations(t)
+		})
+	}
+}
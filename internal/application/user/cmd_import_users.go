@@ -0,0 +1,11 @@
+package user
+
+// ImportUsersCommand 从 CSV/XLSX 文件导入用户命令
+type ImportUsersCommand struct {
+	CreatedBy uint
+	FileName  string
+	Format    string            // csv / xlsx，为空时按文件扩展名识别
+	Content   []byte            // 文件内容
+	Mapping   map[string]string // 字段名 -> 表头列名，未映射字段按同名列匹配
+	DryRun    bool              // 仅校验不写入
+}
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// InlineImportRows 不超过该行数的文件在上传请求中同步处理，更大的文件交由后台任务处理
+const InlineImportRows = 100
+
+// ImportUsersHandler 导入用户命令处理器
+type ImportUsersHandler struct {
+	importer *userImporter
+}
+
+// NewImportUsersHandler 创建导入用户命令处理器
+func NewImportUsersHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	importJobCmdRepo user.ImportJobCommandRepository,
+	authService auth.Service,
+) *ImportUsersHandler {
+	return &ImportUsersHandler{
+		importer: &userImporter{
+			userCommandRepo:  userCommandRepo,
+			userQueryRepo:    userQueryRepo,
+			roleQueryRepo:    roleQueryRepo,
+			importJobCmdRepo: importJobCmdRepo,
+			authService:      authService,
+		},
+	}
+}
+
+// Handle 解析上传文件并创建导入任务
+//
+// 小文件在请求内处理完毕后返回完成的任务；大文件创建待处理任务，
+// 由后台任务处理，客户端通过任务 ID 轮询进度。
+func (h *ImportUsersHandler) Handle(ctx context.Context, cmd ImportUsersCommand) (*ImportJobDTO, error) {
+	format := cmd.Format
+	if format == "" {
+		format = DetectImportFormat(cmd.FileName)
+	}
+
+	rows, err := parseImportRows(format, cmd.Content, cmd.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	// 明文密码不随任务保存
+	if err := h.importer.protectPasswords(ctx, rows, cmd.DryRun); err != nil {
+		return nil, err
+	}
+
+	job := user.NewImportJob(cmd.CreatedBy, cmd.FileName, format, cmd.DryRun, rows)
+	inline := len(rows) <= InlineImportRows
+	if inline {
+		// 以处理中状态创建，避免后台任务同时认领
+		job.Start(time.Now())
+	}
+	if err := h.importer.importJobCmdRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+	if !inline {
+		return ToImportJobDTO(job, false), nil
+	}
+
+	if err := h.importer.run(ctx, job); err != nil {
+		if saveErr := h.importer.fail(ctx, job, err); saveErr != nil {
+			return nil, saveErr
+		}
+		return ToImportJobDTO(job, true), nil
+	}
+	if err := h.importer.importJobCmdRepo.Save(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to save import job: %w", err)
+	}
+
+	return ToImportJobDTO(job, true), nil
+}
@@ -0,0 +1,176 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+type importUsersMocks struct {
+	userCmd *MockUserCommandRepository
+	userQry *MockUserQueryRepository
+	roleQry *MockRoleQueryRepository
+	jobCmd  *MockImportJobCommandRepository
+	authSvc *MockAuthService
+	handler *ImportUsersHandler
+}
+
+func newImportUsersMocks() *importUsersMocks {
+	m := &importUsersMocks{
+		userCmd: new(MockUserCommandRepository),
+		userQry: new(MockUserQueryRepository),
+		roleQry: new(MockRoleQueryRepository),
+		jobCmd:  new(MockImportJobCommandRepository),
+		authSvc: new(MockAuthService),
+	}
+	m.handler = NewImportUsersHandler(m.userCmd, m.userQry, m.roleQry, m.jobCmd, m.authSvc)
+	return m
+}
+
+func resultsByLine(dto *ImportJobDTO) map[int]ImportRowResultDTO {
+	out := make(map[int]ImportRowResultDTO, len(dto.Results))
+	for _, r := range dto.Results {
+		out[r.Line] = r
+	}
+	return out
+}
+
+func TestImportUsersHandler_Handle_DryRun(t *testing.T) {
+	m := newImportUsersMocks()
+	content := "username,email,password,roles\n" +
+		"alice,alice@example.com,Secret123!,viewer\n" + // 2 通过
+		"taken,taken@example.com,Secret123!,\n" + // 3 用户名已存在
+		"alice,other@example.com,Secret123!,\n" + // 4 文件内重复
+		"carol,carol@example.com,weak,\n" + // 5 密码策略
+		"dave,dave@example.com,Secret123!,ghost\n" + // 6 未知角色
+		"eve,not-an-email,Secret123!,\n" + // 7 邮箱格式
+		"frank,frank@example.com,Secret123!,admin\n" + // 8 特权角色
+		"gina,gina@example.com,Secret123!,ops\n" // 9 继承管理权限的角色
+
+	m.authSvc.On("ValidatePasswordPolicy", mock.Anything, "weak").Return(errors.New("too short"))
+	m.authSvc.On("ValidatePasswordPolicy", mock.Anything, "Secret123!").Return(nil)
+	m.userQry.On("ExistsByUsername", mock.Anything, "taken").Return(true, nil)
+	m.userQry.On("ExistsByUsername", mock.Anything, mock.Anything).Return(false, nil)
+	m.userQry.On("ExistsByEmail", mock.Anything, mock.Anything).Return(false, nil)
+	m.roleQry.On("FindByName", mock.Anything, "viewer").Return(&domainRole.Role{ID: 2, Name: "viewer"}, nil).Once()
+	m.roleQry.On("FindByName", mock.Anything, "ghost").Return(nil, nil).Once()
+	m.roleQry.On("FindByName", mock.Anything, "admin").Return(&domainRole.Role{ID: 1, Name: "admin"}, nil).Once()
+	m.roleQry.On("FindByName", mock.Anything, "ops").Return(&domainRole.Role{ID: 4, Name: "ops"}, nil).Once()
+	m.roleQry.On("GetEffectivePermissions", mock.Anything, uint(4)).
+		Return([]domainRole.Permission{{ID: 7, Code: "admin:users:update"}}, nil)
+	m.roleQry.On("GetEffectivePermissions", mock.Anything, mock.Anything).Return(nil, nil)
+	m.jobCmd.On("Create", mock.Anything, mock.MatchedBy(func(j *domainUser.ImportJob) bool {
+		for _, row := range j.Rows {
+			if row.Password != "" || row.PasswordHash != "" {
+				return false
+			}
+		}
+		return true
+	})).Return(nil)
+	m.jobCmd.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := m.handler.Handle(context.Background(), ImportUsersCommand{
+		CreatedBy: 1, FileName: "users.csv", Content: []byte(content), DryRun: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "completed", result.Status)
+	assert.Equal(t, 8, result.Total)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 7, result.Failed)
+	assert.Equal(t, 100, result.Progress)
+
+	byLine := resultsByLine(result)
+	assert.Equal(t, domainUser.ImportRowValid, byLine[2].Status)
+	assert.Equal(t, "用户名已存在", byLine[3].Error)
+	assert.Equal(t, "用户名在文件中重复", byLine[4].Error)
+	assert.Contains(t, byLine[5].Error, "密码不符合策略")
+	assert.Equal(t, "角色不存在: ghost", byLine[6].Error)
+	assert.Equal(t, "邮箱格式无效", byLine[7].Error)
+	assert.Equal(t, "角色含管理权限，不能通过导入分配: admin", byLine[8].Error)
+	assert.Equal(t, "角色含管理权限，不能通过导入分配: ops", byLine[9].Error)
+	m.jobCmd.AssertExpectations(t)
+	// 预检不计算密码哈希
+	m.authSvc.AssertNotCalled(t, "GeneratePasswordHash", mock.Anything, mock.Anything)
+
+	// 预检不写入任何用户
+	m.userCmd.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	m.userCmd.AssertNotCalled(t, "AssignRoles", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportUsersHandler_Handle_CreatesUsers(t *testing.T) {
+	m := newImportUsersMocks()
+	content := "username,email,password,roles,status\n" +
+		"alice,alice@example.com,Secret123!,viewer;editor,inactive\n"
+
+	m.authSvc.On("ValidatePasswordPolicy", mock.Anything, "Secret123!").Return(nil)
+	m.authSvc.On("GeneratePasswordHash", mock.Anything, "Secret123!").Return("hashed", nil)
+	m.userQry.On("ExistsByUsername", mock.Anything, "alice").Return(false, nil)
+	m.userQry.On("ExistsByEmail", mock.Anything, "alice@example.com").Return(false, nil)
+	m.roleQry.On("FindByName", mock.Anything, "viewer").Return(&domainRole.Role{ID: 2, Name: "viewer"}, nil)
+	m.roleQry.On("FindByName", mock.Anything, "editor").Return(&domainRole.Role{ID: 3, Name: "editor"}, nil)
+	m.roleQry.On("GetEffectivePermissions", mock.Anything, mock.Anything).Return(nil, nil)
+	m.userCmd.On("Create", mock.Anything, mock.MatchedBy(func(u *domainUser.User) bool {
+		return u.Username == "alice" && u.Password == "hashed" && u.Status == "inactive" && u.MustChangePassword
+	})).Return(nil)
+	m.userCmd.On("AssignRoles", mock.Anything, uint(1), []uint{2, 3}).Return(nil)
+	// 任务保存时只含密码哈希
+	m.jobCmd.On("Create", mock.Anything, mock.MatchedBy(func(j *domainUser.ImportJob) bool {
+		return j.Rows[0].Password == "" && j.Rows[0].PasswordHash == "hashed"
+	})).Return(nil)
+	m.jobCmd.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := m.handler.Handle(context.Background(), ImportUsersCommand{
+		CreatedBy: 1, FileName: "users.csv", Content: []byte(content),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, domainUser.ImportRowCreated, result.Results[0].Status)
+	m.userCmd.AssertExpectations(t)
+}
+
+func TestImportUsersHandler_Handle_LargeFileQueued(t *testing.T) {
+	m := newImportUsersMocks()
+	var b strings.Builder
+	b.WriteString("username,email,password\n")
+	for i := range InlineImportRows + 1 {
+		fmt.Fprintf(&b, "user%d,user%d@example.com,Secret123!\n", i, i)
+	}
+	m.authSvc.On("ValidatePasswordPolicy", mock.Anything, "Secret123!").Return(nil)
+	m.authSvc.On("GeneratePasswordHash", mock.Anything, "Secret123!").Return("hashed", nil)
+	m.jobCmd.On("Create", mock.Anything, mock.MatchedBy(func(j *domainUser.ImportJob) bool {
+		return j.Status == domainUser.ImportJobPending && j.Total == InlineImportRows+1 &&
+			j.Rows[0].Password == "" && j.Rows[0].PasswordHash == "hashed"
+	})).Return(nil)
+
+	result, err := m.handler.Handle(context.Background(), ImportUsersCommand{
+		CreatedBy: 1, FileName: "users.csv", Content: []byte(b.String()),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "pending", result.Status)
+	assert.Equal(t, 0, result.Progress)
+	m.jobCmd.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	m.userQry.AssertNotCalled(t, "ExistsByUsername", mock.Anything, mock.Anything)
+}
+
+func TestImportUsersHandler_Handle_InvalidFile(t *testing.T) {
+	m := newImportUsersMocks()
+
+	_, err := m.handler.Handle(context.Background(), ImportUsersCommand{
+		FileName: "users.txt", Content: []byte("username,email,password\n"),
+	})
+
+	require.ErrorIs(t, err, domainUser.ErrUnsupportedImportFormat)
+	m.jobCmd.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
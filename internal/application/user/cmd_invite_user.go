@@ -0,0 +1,10 @@
+package user
+
+// InviteUserCommand 邀请用户命令
+type InviteUserCommand struct {
+	Username  string
+	Email     string
+	FullName  string
+	RoleIDs   []uint // 可选：邀请时分配角色
+	InvitedBy uint   // 发起邀请的管理员 ID
+}
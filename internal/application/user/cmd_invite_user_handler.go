@@ -0,0 +1,117 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/mail"
+)
+
+// InviteUserHandler 邀请用户命令处理器
+// 创建 inactive 状态的待激活用户，并通过邮件发送设置密码的链接
+type InviteUserHandler struct {
+	invitationIssuer
+
+	userCommandRepo user.CommandRepository
+	userQueryRepo   user.QueryRepository
+	roleQueryRepo   role.QueryRepository
+	authService     auth.Service
+}
+
+// NewInviteUserHandler 创建邀请用户命令处理器
+func NewInviteUserHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	invitationCommandRepo user.InvitationCommandRepository,
+	settingQueryRepo setting.QueryRepository,
+	preferences setting.PreferenceResolver,
+	authService auth.Service,
+	mailer mail.Mailer,
+) *InviteUserHandler {
+	return &InviteUserHandler{
+		invitationIssuer: invitationIssuer{
+			invitationCommandRepo: invitationCommandRepo,
+			settingQueryRepo:      settingQueryRepo,
+			preferences:           preferences,
+			mailer:                mailer,
+		},
+		userCommandRepo: userCommandRepo,
+		userQueryRepo:   userQueryRepo,
+		roleQueryRepo:   roleQueryRepo,
+		authService:     authService,
+	}
+}
+
+// Handle 处理邀请用户命令
+func (h *InviteUserHandler) Handle(ctx context.Context, cmd InviteUserCommand) (*InvitationResultDTO, error) {
+	// 1. 检查用户名是否已存在
+	exists, err := h.userQueryRepo.ExistsByUsername(ctx, cmd.Username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check username existence: %w", err)
+	}
+	if exists {
+		return nil, user.ErrUsernameAlreadyExists
+	}
+
+	// 2. 检查邮箱是否已存在
+	exists, err = h.userQueryRepo.ExistsByEmail(ctx, cmd.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if exists {
+		return nil, user.ErrEmailAlreadyExists
+	}
+
+	// 2.1 校验初始角色，管理角色须经角色分配接口授予
+	if err = checkInitialRoles(ctx, h.roleQueryRepo, cmd.RoleIDs); err != nil {
+		return nil, err
+	}
+
+	// 3. 生成不可用的随机密码占位，受邀用户接受邀请时设置真实密码
+	placeholder, err := randomPassword()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := h.authService.GeneratePasswordHash(ctx, placeholder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// 4. 创建待激活用户
+	newUser := &user.User{
+		Username: cmd.Username,
+		Email:    cmd.Email,
+		Password: hashedPassword,
+		FullName: cmd.FullName,
+		Status:   "inactive",
+	}
+	if err = h.userCommandRepo.Create(ctx, newUser); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	// 5. 分配角色（如果提供）
+	if len(cmd.RoleIDs) > 0 {
+		if err = h.userCommandRepo.AssignRoles(ctx, newUser.ID, cmd.RoleIDs); err != nil {
+			return nil, fmt.Errorf("failed to assign roles: %w", err)
+		}
+	}
+
+	// 6. 生成邀请并发送邮件
+	return h.issue(ctx, newUser, cmd.InvitedBy)
+}
+
+// randomPassword 生成随机占位密码
+func randomPassword() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate placeholder password: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,136 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestInviteUserHandler_Handle(t *testing.T) {
+	cmd := InviteUserCommand{
+		Username:  "invitee",
+		Email:     "invitee@example.com",
+		RoleIDs:   []uint{2},
+		InvitedBy: 1,
+	}
+
+	t.Run("成功邀请用户并发送邮件", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+		mockInvRepo := new(MockInvitationCommandRepository)
+		mockAuthService := new(MockAuthService)
+		mockMailer := new(MockMailer)
+
+		mockQryRepo.On("ExistsByUsername", mock.Anything, cmd.Username).Return(false, nil)
+		mockQryRepo.On("ExistsByEmail", mock.Anything, cmd.Email).Return(false, nil)
+		mockAuthService.On("GeneratePasswordHash", mock.Anything, mock.AnythingOfType("string")).Return("hashed_placeholder", nil)
+		mockCmdRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *user.User) bool {
+			return u.Status == "inactive"
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*user.User).ID = 5
+		}).Return(nil)
+		mockCmdRepo.On("AssignRoles", mock.Anything, uint(5), []uint{2}).Return(nil)
+		mockInvRepo.On("DeleteByUserID", mock.Anything, uint(5)).Return(nil)
+		mockInvRepo.On("Create", mock.Anything, mock.MatchedBy(func(inv *user.Invitation) bool {
+			return inv.UserID == 5 && inv.InvitedBy == 1
+		})).Return(nil)
+		mockMailer.On("Send", mock.Anything, cmd.Email, mock.Anything, mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, "/accept-invitation?token=")
+		})).Return(nil)
+
+		handler := NewInviteUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockInvRepo, nil, nil, mockAuthService, mockMailer)
+
+		result, err := handler.Handle(context.Background(), cmd)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(5), result.UserID)
+		assert.False(t, result.ExpiresAt.IsZero())
+		mockCmdRepo.AssertExpectations(t)
+		mockInvRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("用户名已存在", func(t *testing.T) {
+		mockQryRepo := new(MockUserQueryRepository)
+		mockQryRepo.On("ExistsByUsername", mock.Anything, cmd.Username).Return(true, nil)
+
+		handler := NewInviteUserHandler(new(MockUserCommandRepository), mockQryRepo, newTestRoleRepo(), new(MockInvitationCommandRepository), nil, nil, new(MockAuthService), new(MockMailer))
+
+		result, err := handler.Handle(context.Background(), cmd)
+
+		assert.Nil(t, result)
+		require.ErrorIs(t, err, user.ErrUsernameAlreadyExists)
+	})
+
+	t.Run("邮件发送失败", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+		mockInvRepo := new(MockInvitationCommandRepository)
+		mockAuthService := new(MockAuthService)
+		mockMailer := new(MockMailer)
+
+		mockQryRepo.On("ExistsByUsername", mock.Anything, cmd.Username).Return(false, nil)
+		mockQryRepo.On("ExistsByEmail", mock.Anything, cmd.Email).Return(false, nil)
+		mockAuthService.On("GeneratePasswordHash", mock.Anything, mock.AnythingOfType("string")).Return("hashed_placeholder", nil)
+		mockCmdRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockCmdRepo.On("AssignRoles", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockInvRepo.On("DeleteByUserID", mock.Anything, mock.Anything).Return(nil)
+		mockInvRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockMailer.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+
+		handler := NewInviteUserHandler(mockCmdRepo, mockQryRepo, newTestRoleRepo(editorRoles...), mockInvRepo, nil, nil, mockAuthService, mockMailer)
+
+		_, err := handler.Handle(context.Background(), cmd)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to send invitation email")
+	})
+}
+
+func TestResendInvitationHandler_Handle_LocalizedEmail(t *testing.T) {
+	mockQryRepo := new(MockUserQueryRepository)
+	mockInvRepo := new(MockInvitationCommandRepository)
+	mockMailer := new(MockMailer)
+	mockPrefs := new(MockPreferenceResolver)
+
+	prefs := setting.ResolvePreferences([]*setting.Setting{
+		{Key: setting.KeyPreferenceLocale, Value: "zh-CN", Category: setting.CategoryPreference, ValueType: setting.ValueTypeString},
+		{Key: setting.KeyPreferenceTimezone, Value: "Asia/Shanghai", Category: setting.CategoryPreference, ValueType: setting.ValueTypeString},
+	}, nil)
+
+	mockQryRepo.On("GetByID", mock.Anything, uint(5)).Return(&user.User{ID: 5, Username: "alice", Email: "alice@example.com", Status: "inactive"}, nil)
+	mockInvRepo.On("DeleteByUserID", mock.Anything, uint(5)).Return(nil)
+	mockInvRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockPrefs.On("Resolve", mock.Anything, uint(5)).Return(prefs, nil)
+	mockMailer.On("Send", mock.Anything, "alice@example.com", "您收到了一份邀请", mock.MatchedBy(func(body string) bool {
+		return strings.Contains(body, "alice，您好") && strings.Contains(body, "+08:00")
+	})).Return(nil)
+
+	handler := NewResendInvitationHandler(mockQryRepo, mockInvRepo, nil, mockPrefs, mockMailer)
+
+	_, err := handler.Handle(context.Background(), ResendInvitationCommand{UserID: 5})
+
+	require.NoError(t, err)
+	mockMailer.AssertExpectations(t)
+}
+
+func TestResendInvitationHandler_Handle(t *testing.T) {
+	t.Run("已激活用户不能重发邀请", func(t *testing.T) {
+		mockQryRepo := new(MockUserQueryRepository)
+		mockQryRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1, Status: "active"}, nil)
+
+		handler := NewResendInvitationHandler(mockQryRepo, new(MockInvitationCommandRepository), nil, nil, new(MockMailer))
+
+		_, err := handler.Handle(context.Background(), ResendInvitationCommand{UserID: 1})
+
+		require.ErrorIs(t, err, user.ErrUserNotPending)
+	})
+}
@@ -0,0 +1,15 @@
+package user
+
+import "time"
+
+// DefaultLiftExpiredBansBatchSize 单次最多解除的到期封禁数
+const DefaultLiftExpiredBansBatchSize = 100
+
+// BanExpiredReason 封禁到期自动解封时记录的原因
+const BanExpiredReason = "ban expired"
+
+// LiftExpiredBansCommand 解除到期封禁命令（由后台任务定期执行）
+type LiftExpiredBansCommand struct {
+	Now       time.Time
+	BatchSize int // 为空时使用 DefaultLiftExpiredBansBatchSize
+}
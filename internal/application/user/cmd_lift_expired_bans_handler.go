@@ -0,0 +1,76 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// LiftExpiredBansHandler 解除到期封禁命令处理器
+//
+// 限时封禁到期的用户恢复为 active，以系统身份（操作者为 0）记录状态历史并发布事件。
+type LiftExpiredBansHandler struct {
+	userQueryRepo user.QueryRepository
+	statusHandler *ChangeUserStatusHandler
+}
+
+// NewLiftExpiredBansHandler 创建解除到期封禁命令处理器
+func NewLiftExpiredBansHandler(userQueryRepo user.QueryRepository, statusHandler *ChangeUserStatusHandler) *LiftExpiredBansHandler {
+	return &LiftExpiredBansHandler{
+		userQueryRepo: userQueryRepo,
+		statusHandler: statusHandler,
+	}
+}
+
+// Handle 处理一批到期的封禁
+// 单个用户失败不影响其他用户，失败的用户保持封禁，在下一轮重试
+func (h *LiftExpiredBansHandler) Handle(ctx context.Context, cmd LiftExpiredBansCommand) (*LiftExpiredBansResultDTO, error) {
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultLiftExpiredBansBatchSize
+	}
+
+	expired, err := h.userQueryRepo.ListByCriteria(ctx, user.ListCriteria{BanExpiredBefore: &cmd.Now, Limit: batchSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired bans: %w", err)
+	}
+
+	result := &LiftExpiredBansResultDTO{}
+	for _, u := range expired {
+		lifted, err := h.lift(ctx, u.ID, cmd)
+		if err != nil {
+			slog.Error("Failed to lift expired ban", "user_id", u.ID, "error", err)
+			result.Failed++
+			continue
+		}
+		if lifted {
+			result.Lifted++
+		}
+	}
+
+	return result, nil
+}
+
+// lift 解除单个用户的到期封禁，封禁已被解除或延期时返回 false
+func (h *LiftExpiredBansHandler) lift(ctx context.Context, userID uint, cmd LiftExpiredBansCommand) (bool, error) {
+	// 重新读取，避免覆盖列出之后由管理员调整的封禁
+	u, err := h.userQueryRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if !u.IsBanExpired(cmd.Now) {
+		return false, nil
+	}
+
+	err = h.statusHandler.apply(ctx, u, ChangeUserStatusCommand{
+		UserID: u.ID,
+		Status: user.StatusActive,
+		Reason: BanExpiredReason,
+	}, cmd.Now)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
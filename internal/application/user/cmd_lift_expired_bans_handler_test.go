@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestLiftExpiredBansHandler_Handle(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	expiredAt := now.Add(-time.Minute)
+	extendedTo := now.Add(time.Hour)
+
+	expired := &user.User{ID: 1, Status: user.StatusBanned, BanReason: "spam", BannedUntil: &expiredAt}
+	// 列出后被管理员延期的封禁
+	extended := &user.User{ID: 2, Status: user.StatusBanned, BannedUntil: &extendedTo}
+	broken := &user.User{ID: 3, Status: user.StatusBanned, BannedUntil: &expiredAt}
+
+	mockCmdRepo := new(MockUserCommandRepository)
+	mockQryRepo := new(MockUserQueryRepository)
+	mockHistoryRepo := new(MockStatusHistoryCommandRepository)
+
+	mockQryRepo.On("ListByCriteria", mock.Anything, mock.MatchedBy(func(c user.ListCriteria) bool {
+		return c.BanExpiredBefore != nil && c.BanExpiredBefore.Equal(now) && c.Limit == DefaultLiftExpiredBansBatchSize
+	})).Return([]*user.User{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+	mockQryRepo.On("GetByID", mock.Anything, uint(1)).Return(expired, nil)
+	mockQryRepo.On("GetByID", mock.Anything, uint(2)).Return(extended, nil)
+	mockQryRepo.On("GetByID", mock.Anything, uint(3)).Return(broken, nil)
+	mockCmdRepo.On("SaveStatus", mock.Anything, expired).Return(nil)
+	mockCmdRepo.On("SaveStatus", mock.Anything, broken).Return(errors.New("db error"))
+	mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *user.StatusChange) bool {
+		return c.UserID == 1 && c.ToStatus == user.StatusActive && c.OperatorID == 0 && c.Reason == BanExpiredReason
+	})).Return(nil)
+
+	statusHandler := NewChangeUserStatusHandler(mockCmdRepo, mockQryRepo, mockHistoryRepo, new(MockPATCommandRepository), nil, nil)
+	handler := NewLiftExpiredBansHandler(mockQryRepo, statusHandler)
+
+	// Act
+	result, err := handler.Handle(context.Background(), LiftExpiredBansCommand{Now: now})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Lifted)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, user.StatusActive, expired.Status)
+	assert.Nil(t, expired.BannedUntil)
+	assert.Equal(t, user.StatusBanned, extended.Status)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestLiftExpiredBansHandler_Handle_ListError(t *testing.T) {
+	mockQryRepo := new(MockUserQueryRepository)
+	mockQryRepo.On("ListByCriteria", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+	handler := NewLiftExpiredBansHandler(mockQryRepo, nil)
+
+	result, err := handler.Handle(context.Background(), LiftExpiredBansCommand{Now: time.Now()})
+
+	assert.Nil(t, result)
+	require.ErrorContains(t, err, "failed to list expired bans")
+}
@@ -0,0 +1,16 @@
+package user
+
+import "time"
+
+// DataExportRetention 数据导出归档的下载有效期
+const DataExportRetention = 7 * 24 * time.Hour
+
+// DefaultDataExportStaleAfter 生成中任务超过该时长未完成即视为中断，可被重新认领
+const DefaultDataExportStaleAfter = 10 * time.Minute
+
+// ProcessDataExportsCommand 生成待处理的数据导出归档命令（由后台任务定期执行）
+type ProcessDataExportsCommand struct {
+	Now        time.Time
+	StaleAfter time.Duration // 为空时使用 DefaultDataExportStaleAfter
+	MaxExports int           // 单次最多生成的归档数，为空时处理到没有待处理任务为止
+}
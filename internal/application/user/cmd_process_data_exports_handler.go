@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ProcessDataExportsHandler 后台生成个人数据导出归档
+type ProcessDataExportsHandler struct {
+	dataExportCmdRepo user.DataExportCommandRepository
+	archiver          *dataExportArchiver
+}
+
+// NewProcessDataExportsHandler 创建数据导出归档处理器
+func NewProcessDataExportsHandler(
+	dataExportCmdRepo user.DataExportCommandRepository,
+	userQueryRepo user.QueryRepository,
+	patQueryRepo pat.QueryRepository,
+	auditLogQueryRepo auditlog.QueryRepository,
+) *ProcessDataExportsHandler {
+	return &ProcessDataExportsHandler{
+		dataExportCmdRepo: dataExportCmdRepo,
+		archiver: &dataExportArchiver{
+			userQueryRepo:     userQueryRepo,
+			patQueryRepo:      patQueryRepo,
+			auditLogQueryRepo: auditLogQueryRepo,
+		},
+	}
+}
+
+// Handle 依次认领并生成待处理的导出归档，然后清除已过期的归档
+func (h *ProcessDataExportsHandler) Handle(ctx context.Context, cmd ProcessDataExportsCommand) (*ProcessDataExportsResultDTO, error) {
+	staleAfter := cmd.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultDataExportStaleAfter
+	}
+
+	result := &ProcessDataExportsResultDTO{}
+	for cmd.MaxExports <= 0 || result.Completed+result.Failed < cmd.MaxExports {
+		export, err := h.dataExportCmdRepo.ClaimNext(ctx, cmd.Now.Add(-staleAfter))
+		if err != nil {
+			return nil, err
+		}
+		if export == nil {
+			break
+		}
+		export.Start(time.Now())
+
+		fileName, archive, buildErr := h.archiver.build(ctx, export.UserID, time.Now())
+		if buildErr != nil {
+			export.Fail(buildErr.Error(), time.Now())
+			result.Failed++
+		} else {
+			export.Complete(fileName, archive, time.Now(), DataExportRetention)
+			result.Completed++
+		}
+		if err := h.dataExportCmdRepo.Save(ctx, export); err != nil {
+			return nil, err
+		}
+	}
+
+	purged, err := h.dataExportCmdRepo.PurgeExpired(ctx, cmd.Now)
+	if err != nil {
+		return nil, err
+	}
+	result.Purged = purged
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	domainPAT "github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestProcessDataExportsHandler_Handle(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("生成包含个人数据的 ZIP 归档", func(t *testing.T) {
+		exportCmd := new(MockDataExportCommandRepository)
+		userQry := new(MockUserQueryRepository)
+		patQry := new(MockPATQueryRepository)
+		auditQry := new(MockAuditLogQueryRepository)
+
+		export := domainUser.NewDataExport(1)
+		export.ID = 3
+		exportCmd.On("ClaimNext", mock.Anything, now.Add(-DefaultDataExportStaleAfter)).Return(export, nil).Once()
+		exportCmd.On("ClaimNext", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		exportCmd.On("Save", mock.Anything, export).Return(nil).Once()
+		exportCmd.On("PurgeExpired", mock.Anything, now).Return(int64(2), nil)
+
+		userQry.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(&domainUser.User{ID: 1, Username: "alice"}, nil)
+		patQry.On("ListByUser", mock.Anything, uint(1)).Return([]*domainPAT.PersonalAccessToken{
+			{ID: 5, Name: "ci", Token: "secret-hash", TokenPrefix: "pat_abc"},
+		}, nil)
+		auditQry.On("ListByUser", mock.Anything, uint(1), 1, dataExportActivityPageSize).Return([]domainAuditLog.AuditLog{
+			{Action: domainAuditLog.ActionLogin, Status: domainAuditLog.StatusSuccess, IPAddress: "10.0.0.1"},
+			{Action: domainAuditLog.ActionLogin, Status: "failure"},
+			{Action: "update", Resource: "user"},
+		}, int64(3), nil)
+
+		handler := NewProcessDataExportsHandler(exportCmd, userQry, patQry, auditQry)
+		result, err := handler.Handle(context.Background(), ProcessDataExportsCommand{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Completed)
+		assert.Equal(t, int64(2), result.Purged)
+		assert.Equal(t, domainUser.DataExportCompleted, export.Status)
+		require.NotNil(t, export.ExpiresAt)
+		assert.Contains(t, export.FileName, "alice-data-export-")
+
+		zr, err := zip.NewReader(bytes.NewReader(export.Archive), export.Size)
+		require.NoError(t, err)
+		contents := make(map[string]string)
+		for _, f := range zr.File {
+			rc, openErr := f.Open()
+			require.NoError(t, openErr)
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(rc)
+			_ = rc.Close()
+			contents[f.Name] = buf.String()
+		}
+		assert.Len(t, contents, 5)
+		assert.Contains(t, contents["profile.json"], `"username": "alice"`)
+		assert.Contains(t, contents["tokens.json"], `"token_prefix": "pat_abc"`)
+		assert.NotContains(t, contents["tokens.json"], "secret-hash", "不导出令牌哈希")
+		assert.Contains(t, contents["sessions.json"], "10.0.0.1")
+		assert.Equal(t, 1, bytes.Count([]byte(contents["sessions.json"]), []byte("login_at")), "仅成功登录计为会话")
+		assert.Equal(t, 3, bytes.Count([]byte(contents["activity.json"]), []byte(`"action"`)))
+	})
+
+	t.Run("读取数据失败时标记任务失败", func(t *testing.T) {
+		exportCmd := new(MockDataExportCommandRepository)
+		userQry := new(MockUserQueryRepository)
+
+		export := domainUser.NewDataExport(1)
+		exportCmd.On("ClaimNext", mock.Anything, mock.Anything).Return(export, nil).Once()
+		exportCmd.On("Save", mock.Anything, export).Return(nil).Once()
+		exportCmd.On("PurgeExpired", mock.Anything, now).Return(int64(0), nil)
+		userQry.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(nil, errors.New("db down"))
+
+		handler := NewProcessDataExportsHandler(exportCmd, userQry, new(MockPATQueryRepository), new(MockAuditLogQueryRepository))
+		result, err := handler.Handle(context.Background(), ProcessDataExportsCommand{Now: now, MaxExports: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, domainUser.DataExportFailed, export.Status)
+		assert.Contains(t, export.Error, "db down")
+	})
+}
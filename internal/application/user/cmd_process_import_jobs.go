@@ -0,0 +1,13 @@
+package user
+
+import "time"
+
+// DefaultImportJobStaleAfter 处理中任务超过该时长未更新进度即视为中断，可被重新认领
+const DefaultImportJobStaleAfter = 5 * time.Minute
+
+// ProcessImportJobsCommand 处理待处理的导入任务命令（由后台任务定期执行）
+type ProcessImportJobsCommand struct {
+	Now        time.Time
+	StaleAfter time.Duration // 为空时使用 DefaultImportJobStaleAfter
+	MaxJobs    int           // 单次最多处理的任务数，为空时处理到没有待处理任务为止
+}
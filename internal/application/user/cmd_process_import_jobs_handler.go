@@ -0,0 +1,70 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ProcessImportJobsHandler 后台处理导入任务
+type ProcessImportJobsHandler struct {
+	importer *userImporter
+}
+
+// NewProcessImportJobsHandler 创建导入任务处理器
+func NewProcessImportJobsHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	importJobCmdRepo user.ImportJobCommandRepository,
+	authService auth.Service,
+) *ProcessImportJobsHandler {
+	return &ProcessImportJobsHandler{
+		importer: &userImporter{
+			userCommandRepo:  userCommandRepo,
+			userQueryRepo:    userQueryRepo,
+			roleQueryRepo:    roleQueryRepo,
+			importJobCmdRepo: importJobCmdRepo,
+			authService:      authService,
+		},
+	}
+}
+
+// Handle 依次认领并处理待处理的导入任务
+func (h *ProcessImportJobsHandler) Handle(ctx context.Context, cmd ProcessImportJobsCommand) (*ProcessImportJobsResultDTO, error) {
+	staleAfter := cmd.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultImportJobStaleAfter
+	}
+
+	result := &ProcessImportJobsResultDTO{}
+	for cmd.MaxJobs <= 0 || result.Completed+result.Failed < cmd.MaxJobs {
+		job, err := h.importer.importJobCmdRepo.ClaimNext(ctx, cmd.Now.Add(-staleAfter))
+		if err != nil {
+			return nil, err
+		}
+		if job == nil {
+			break
+		}
+		if job.StartedAt == nil {
+			job.Start(time.Now())
+		}
+
+		if err := h.importer.run(ctx, job); err != nil {
+			if saveErr := h.importer.fail(ctx, job, err); saveErr != nil {
+				return nil, saveErr
+			}
+			result.Failed++
+			continue
+		}
+		if err := h.importer.importJobCmdRepo.Save(ctx, job); err != nil {
+			return nil, err
+		}
+		result.Completed++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestProcessImportJobsHandler_Handle(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("从断点继续处理并完成任务", func(t *testing.T) {
+		userCmd := new(MockUserCommandRepository)
+		userQry := new(MockUserQueryRepository)
+		roleQry := new(MockRoleQueryRepository)
+		jobCmd := new(MockImportJobCommandRepository)
+		authSvc := new(MockAuthService)
+
+		job := domainUser.NewImportJob(1, "users.csv", ImportFormatCSV, false, []domainUser.ImportRow{
+			{Line: 2, Username: "alice", Email: "alice@example.com", PasswordHash: "hashed"},
+			{Line: 3, Username: "alice", Email: "alice2@example.com", PasswordHash: "hashed"},
+		})
+		job.ID = 9
+		job.Status = domainUser.ImportJobRunning
+		// 第一行已在中断前处理
+		job.Record(domainUser.ImportRowResult{Line: 2, Username: "alice", Email: "alice@example.com", Status: domainUser.ImportRowCreated})
+
+		jobCmd.On("ClaimNext", mock.Anything, now.Add(-DefaultImportJobStaleAfter)).Return(job, nil).Once()
+		jobCmd.On("ClaimNext", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		jobCmd.On("Save", mock.Anything, job).Return(nil).Once()
+
+		handler := NewProcessImportJobsHandler(userCmd, userQry, roleQry, jobCmd, authSvc)
+		result, err := handler.Handle(context.Background(), ProcessImportJobsCommand{Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Completed)
+		assert.Equal(t, domainUser.ImportJobCompleted, job.Status)
+		assert.Equal(t, 2, job.Processed)
+		assert.Equal(t, "用户名在文件中重复", job.Results[1].Error)
+		assert.Nil(t, job.Rows, "完成后清除含密码的原始数据")
+		userCmd.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("查询失败时标记任务失败", func(t *testing.T) {
+		userQry := new(MockUserQueryRepository)
+		jobCmd := new(MockImportJobCommandRepository)
+		authSvc := new(MockAuthService)
+
+		job := domainUser.NewImportJob(1, "users.csv", ImportFormatCSV, true, []domainUser.ImportRow{
+			{Line: 2, Username: "alice", Email: "alice@example.com", PasswordHash: "hashed"},
+		})
+		userQry.On("ExistsByUsername", mock.Anything, "alice").Return(false, errors.New("db down"))
+		jobCmd.On("ClaimNext", mock.Anything, mock.Anything).Return(job, nil).Once()
+		jobCmd.On("Save", mock.Anything, job).Return(nil).Once()
+
+		handler := NewProcessImportJobsHandler(new(MockUserCommandRepository), userQry, new(MockRoleQueryRepository), jobCmd, authSvc)
+		result, err := handler.Handle(context.Background(), ProcessImportJobsCommand{Now: now, MaxJobs: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, domainUser.ImportJobFailed, job.Status)
+		assert.Contains(t, job.Error, "db down")
+		assert.NotNil(t, job.StartedAt)
+	})
+}
@@ -0,0 +1,10 @@
+package user
+
+import "time"
+
+// ProcessRoleAssignmentsCommand 处理限时角色授权命令（由后台任务定期执行）
+// 激活已到开始时间的计划授权，移除已到期的授权
+type ProcessRoleAssignmentsCommand struct {
+	Now       time.Time
+	BatchSize int
+}
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+const defaultRoleAssignmentBatchSize = 100
+
+// ProcessRoleAssignmentsHandler 激活和到期限时角色授权
+//
+// 权限查询本身按生效时间窗口过滤，本处理器负责在授权生效和失效时
+// 发布 UserRoleAssignedEvent 使权限缓存失效，并清理已到期的授权。
+type ProcessRoleAssignmentsHandler struct {
+	assignmentCmdRepo   user.RoleAssignmentCommandRepository
+	assignmentQueryRepo user.RoleAssignmentQueryRepository
+	eventBus            event.EventBus
+}
+
+// NewProcessRoleAssignmentsHandler 创建限时授权处理器
+func NewProcessRoleAssignmentsHandler(
+	assignmentCmdRepo user.RoleAssignmentCommandRepository,
+	assignmentQueryRepo user.RoleAssignmentQueryRepository,
+	eventBus event.EventBus,
+) *ProcessRoleAssignmentsHandler {
+	return &ProcessRoleAssignmentsHandler{
+		assignmentCmdRepo:   assignmentCmdRepo,
+		assignmentQueryRepo: assignmentQueryRepo,
+		eventBus:            eventBus,
+	}
+}
+
+// Handle 处理限时角色授权命令
+func (h *ProcessRoleAssignmentsHandler) Handle(ctx context.Context, cmd ProcessRoleAssignmentsCommand) (*ProcessRoleAssignmentsResultDTO, error) {
+	batchSize := cmd.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultRoleAssignmentBatchSize
+	}
+
+	result := &ProcessRoleAssignmentsResultDTO{}
+	changed := map[uint][]uint{}
+
+	// 激活计划授权：标记后不再出现在待激活列表中
+	for {
+		due, err := h.assignmentQueryRepo.ListDueActivations(ctx, cmd.Now, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range due {
+			if err := h.assignmentCmdRepo.MarkActivated(ctx, a.UserID, a.RoleID, cmd.Now); err != nil {
+				return nil, fmt.Errorf("failed to activate role %d for user %d: %w", a.RoleID, a.UserID, err)
+			}
+			changed[a.UserID] = append(changed[a.UserID], a.RoleID)
+			result.Activated++
+		}
+		if len(due) < batchSize {
+			break
+		}
+	}
+
+	// 移除到期授权：删除后不再出现在到期列表中
+	for {
+		expired, err := h.assignmentQueryRepo.ListExpired(ctx, cmd.Now, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		removed := 0
+		for _, a := range expired {
+			ok, err := h.assignmentCmdRepo.Expire(ctx, a.UserID, a.RoleID, cmd.Now)
+			if err != nil {
+				return nil, fmt.Errorf("failed to expire role %d for user %d: %w", a.RoleID, a.UserID, err)
+			}
+			if ok {
+				changed[a.UserID] = append(changed[a.UserID], a.RoleID)
+				removed++
+			}
+		}
+		result.Expired += removed
+		if len(expired) < batchSize || removed == 0 {
+			break
+		}
+	}
+
+	if h.eventBus != nil {
+		for userID, roleIDs := range changed {
+			_ = h.eventBus.Publish(ctx, events.NewUserRoleAssignedEvent(userID, roleIDs)) // 缓存失效失败不阻塞处理
+		}
+	}
+
+	return result, nil
+}
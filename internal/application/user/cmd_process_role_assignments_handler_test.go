@@ -0,0 +1,83 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestProcessRoleAssignmentsHandler_Handle(t *testing.T) {
+	now := time.Now()
+
+	t.Run("激活计划授权并移除到期授权", func(t *testing.T) {
+		cmdRepo := new(MockRoleAssignmentCommandRepository)
+		qryRepo := new(MockRoleAssignmentQueryRepository)
+		eventBus := new(MockEventBus)
+
+		qryRepo.On("ListDueActivations", mock.Anything, now, 10).Return([]*user.RoleAssignment{
+			{UserID: 1, RoleID: 2},
+		}, nil)
+		qryRepo.On("ListExpired", mock.Anything, now, 10).Return([]*user.RoleAssignment{
+			{UserID: 1, RoleID: 3},
+			{UserID: 4, RoleID: 2},
+		}, nil)
+		cmdRepo.On("MarkActivated", mock.Anything, uint(1), uint(2), now).Return(nil)
+		cmdRepo.On("Expire", mock.Anything, uint(1), uint(3), now).Return(true, nil)
+		cmdRepo.On("Expire", mock.Anything, uint(4), uint(2), now).Return(true, nil)
+		eventBus.On("Publish", mock.Anything, mock.MatchedBy(func(evts []event.Event) bool {
+			e, ok := evts[0].(*events.UserRoleAssignedEvent)
+			return ok && e.UserID == 1 && len(e.RoleIDs) == 2
+		})).Return(nil).Once()
+		eventBus.On("Publish", mock.Anything, mock.MatchedBy(func(evts []event.Event) bool {
+			e, ok := evts[0].(*events.UserRoleAssignedEvent)
+			return ok && e.UserID == 4
+		})).Return(nil).Once()
+
+		handler := NewProcessRoleAssignmentsHandler(cmdRepo, qryRepo, eventBus)
+		result, err := handler.Handle(context.Background(), ProcessRoleAssignmentsCommand{Now: now, BatchSize: 10})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Activated)
+		assert.Equal(t, 2, result.Expired)
+		cmdRepo.AssertExpectations(t)
+		eventBus.AssertExpectations(t)
+	})
+
+	t.Run("无待处理授权时不发布事件", func(t *testing.T) {
+		cmdRepo := new(MockRoleAssignmentCommandRepository)
+		qryRepo := new(MockRoleAssignmentQueryRepository)
+		eventBus := new(MockEventBus)
+
+		qryRepo.On("ListDueActivations", mock.Anything, now, defaultRoleAssignmentBatchSize).Return([]*user.RoleAssignment{}, nil)
+		qryRepo.On("ListExpired", mock.Anything, now, defaultRoleAssignmentBatchSize).Return([]*user.RoleAssignment{}, nil)
+
+		handler := NewProcessRoleAssignmentsHandler(cmdRepo, qryRepo, eventBus)
+		result, err := handler.Handle(context.Background(), ProcessRoleAssignmentsCommand{Now: now})
+
+		require.NoError(t, err)
+		assert.Zero(t, result.Activated)
+		assert.Zero(t, result.Expired)
+		eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+
+	t.Run("查询失败", func(t *testing.T) {
+		cmdRepo := new(MockRoleAssignmentCommandRepository)
+		qryRepo := new(MockRoleAssignmentQueryRepository)
+
+		qryRepo.On("ListDueActivations", mock.Anything, now, defaultRoleAssignmentBatchSize).Return(nil, errors.New("db error"))
+
+		handler := NewProcessRoleAssignmentsHandler(cmdRepo, qryRepo, nil)
+		_, err := handler.Handle(context.Background(), ProcessRoleAssignmentsCommand{Now: now})
+
+		require.Error(t, err)
+	})
+}
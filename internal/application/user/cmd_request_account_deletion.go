@@ -0,0 +1,11 @@
+package user
+
+import "time"
+
+// DefaultAccountDeletionGrace 未配置宽限期时的默认值
+const DefaultAccountDeletionGrace = 14 * 24 * time.Hour
+
+// RequestAccountDeletionCommand 用户请求删除本人账号命令
+type RequestAccountDeletionCommand struct {
+	UserID uint
+}
@@ -0,0 +1,72 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// RequestAccountDeletionHandler 请求删除账号命令处理器
+//
+// 账号不会立即删除：记录删除计划后进入宽限期，宽限期内登录即取消，
+// 到期后由 FinalizeAccountDeletionsHandler 完成删除。
+type RequestAccountDeletionHandler struct {
+	userCommandRepo  user.CommandRepository
+	userQueryRepo    user.QueryRepository
+	settingQueryRepo setting.QueryRepository
+}
+
+// NewRequestAccountDeletionHandler 创建请求删除账号命令处理器
+func NewRequestAccountDeletionHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	settingQueryRepo setting.QueryRepository,
+) *RequestAccountDeletionHandler {
+	return &RequestAccountDeletionHandler{
+		userCommandRepo:  userCommandRepo,
+		userQueryRepo:    userQueryRepo,
+		settingQueryRepo: settingQueryRepo,
+	}
+}
+
+// Handle 处理请求删除账号命令，已在宽限期内时返回原计划
+func (h *RequestAccountDeletionHandler) Handle(ctx context.Context, cmd RequestAccountDeletionCommand) (*AccountDeletionDTO, error) {
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.IsDeletionPending() {
+		u.ScheduleDeletion(time.Now(), h.gracePeriod(ctx))
+		if err := h.userCommandRepo.UpdateDeletionSchedule(ctx, u.ID, u.DeletionRequestedAt, u.DeletionScheduledAt); err != nil {
+			return nil, fmt.Errorf("failed to schedule account deletion: %w", err)
+		}
+	}
+
+	return &AccountDeletionDTO{
+		RequestedAt: *u.DeletionRequestedAt,
+		ScheduledAt: *u.DeletionScheduledAt,
+	}, nil
+}
+
+// gracePeriod 从系统配置读取删除宽限期
+// 未配置或读取失败时使用 DefaultAccountDeletionGrace，配置为 0 时在下一次后台任务中删除
+func (h *RequestAccountDeletionHandler) gracePeriod(ctx context.Context) time.Duration {
+	if h.settingQueryRepo == nil {
+		return DefaultAccountDeletionGrace
+	}
+
+	s, err := h.settingQueryRepo.FindByKey(ctx, setting.KeyAccountDeletionGraceDays)
+	if err != nil || s == nil {
+		return DefaultAccountDeletionGrace
+	}
+
+	days, err := s.ParseInt()
+	if err != nil || days < 0 {
+		return DefaultAccountDeletionGrace
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
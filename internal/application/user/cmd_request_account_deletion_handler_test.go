@@ -0,0 +1,59 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestRequestAccountDeletionHandler_Handle(t *testing.T) {
+	t.Run("进入默认宽限期", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+
+		mockQryRepo.On("GetByID", mock.Anything, uint(1)).Return(&domainUser.User{ID: 1}, nil)
+		mockCmdRepo.On("UpdateDeletionSchedule", mock.Anything, uint(1), mock.Anything, mock.Anything).Return(nil)
+
+		handler := NewRequestAccountDeletionHandler(mockCmdRepo, mockQryRepo, nil)
+		result, err := handler.Handle(context.Background(), RequestAccountDeletionCommand{UserID: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultAccountDeletionGrace, result.ScheduledAt.Sub(result.RequestedAt))
+		mockCmdRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		mockCmdRepo.AssertExpectations(t)
+	})
+
+	t.Run("已在宽限期内时返回原计划", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+
+		requestedAt := time.Now().Add(-time.Hour)
+		scheduledAt := requestedAt.Add(DefaultAccountDeletionGrace)
+		mockQryRepo.On("GetByID", mock.Anything, uint(1)).Return(&domainUser.User{
+			ID: 1, DeletionRequestedAt: &requestedAt, DeletionScheduledAt: &scheduledAt,
+		}, nil)
+
+		handler := NewRequestAccountDeletionHandler(mockCmdRepo, mockQryRepo, nil)
+		result, err := handler.Handle(context.Background(), RequestAccountDeletionCommand{UserID: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, scheduledAt, result.ScheduledAt)
+		mockCmdRepo.AssertNotCalled(t, "UpdateDeletionSchedule", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("用户不存在", func(t *testing.T) {
+		mockQryRepo := new(MockUserQueryRepository)
+		mockQryRepo.On("GetByID", mock.Anything, uint(9)).Return(nil, domainUser.ErrUserNotFound)
+
+		handler := NewRequestAccountDeletionHandler(new(MockUserCommandRepository), mockQryRepo, nil)
+		_, err := handler.Handle(context.Background(), RequestAccountDeletionCommand{UserID: 9})
+
+		require.ErrorIs(t, err, domainUser.ErrUserNotFound)
+	})
+}
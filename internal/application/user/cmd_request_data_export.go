@@ -0,0 +1,6 @@
+package user
+
+// RequestDataExportCommand 用户请求导出本人数据命令
+type RequestDataExportCommand struct {
+	UserID uint
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// RequestDataExportHandler 请求导出个人数据命令处理器
+type RequestDataExportHandler struct {
+	dataExportCmdRepo   user.DataExportCommandRepository
+	dataExportQueryRepo user.DataExportQueryRepository
+}
+
+// NewRequestDataExportHandler 创建请求导出个人数据命令处理器
+func NewRequestDataExportHandler(
+	dataExportCmdRepo user.DataExportCommandRepository,
+	dataExportQueryRepo user.DataExportQueryRepository,
+) *RequestDataExportHandler {
+	return &RequestDataExportHandler{
+		dataExportCmdRepo:   dataExportCmdRepo,
+		dataExportQueryRepo: dataExportQueryRepo,
+	}
+}
+
+// Handle 创建待处理的导出任务，已有排队或生成中的任务时直接返回该任务
+func (h *RequestDataExportHandler) Handle(ctx context.Context, cmd RequestDataExportCommand) (*DataExportDTO, error) {
+	active, err := h.dataExportQueryRepo.FindActiveByUserID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return ToDataExportDTO(active, time.Now()), nil
+	}
+
+	export := user.NewDataExport(cmd.UserID)
+	if err := h.dataExportCmdRepo.Create(ctx, export); err != nil {
+		return nil, err
+	}
+	return ToDataExportDTO(export, time.Now()), nil
+}
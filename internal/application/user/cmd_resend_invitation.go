@@ -0,0 +1,7 @@
+package user
+
+// ResendInvitationCommand 重发邀请命令
+type ResendInvitationCommand struct {
+	UserID    uint
+	InvitedBy uint
+}
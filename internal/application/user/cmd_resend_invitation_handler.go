@@ -0,0 +1,49 @@
+package user
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/mail"
+)
+
+// ResendInvitationHandler 重发邀请命令处理器
+// 旧邀请链接立即失效，仅对尚未激活的用户有效
+type ResendInvitationHandler struct {
+	invitationIssuer
+
+	userQueryRepo user.QueryRepository
+}
+
+// NewResendInvitationHandler 创建重发邀请命令处理器
+func NewResendInvitationHandler(
+	userQueryRepo user.QueryRepository,
+	invitationCommandRepo user.InvitationCommandRepository,
+	settingQueryRepo setting.QueryRepository,
+	preferences setting.PreferenceResolver,
+	mailer mail.Mailer,
+) *ResendInvitationHandler {
+	return &ResendInvitationHandler{
+		invitationIssuer: invitationIssuer{
+			invitationCommandRepo: invitationCommandRepo,
+			settingQueryRepo:      settingQueryRepo,
+			preferences:           preferences,
+			mailer:                mailer,
+		},
+		userQueryRepo: userQueryRepo,
+	}
+}
+
+// Handle 处理重发邀请命令
+func (h *ResendInvitationHandler) Handle(ctx context.Context, cmd ResendInvitationCommand) (*InvitationResultDTO, error) {
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsInactive() {
+		return nil, user.ErrUserNotPending
+	}
+
+	return h.issue(ctx, u, cmd.InvitedBy)
+}
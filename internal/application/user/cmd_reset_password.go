@@ -0,0 +1,9 @@
+package user
+
+// ResetPasswordCommand 管理员重置用户密码命令
+type ResetPasswordCommand struct {
+	UserID      uint
+	NewPassword string
+
+	ActorID uint // 发起操作的管理员，用于解析 ABAC 策略
+}
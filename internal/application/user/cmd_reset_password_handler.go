@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ResetPasswordHandler 管理员重置密码命令处理器
+// 重置后用户下次登录只能获得修改密码的受限令牌
+type ResetPasswordHandler struct {
+	userCommandRepo user.CommandRepository
+	userQueryRepo   user.QueryRepository
+	policyResolver  policy.Resolver
+	authService     auth.Service
+}
+
+// NewResetPasswordHandler 创建重置密码命令处理器
+func NewResetPasswordHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	policyResolver policy.Resolver,
+	authService auth.Service,
+) *ResetPasswordHandler {
+	return &ResetPasswordHandler{
+		userCommandRepo: userCommandRepo,
+		userQueryRepo:   userQueryRepo,
+		policyResolver:  policyResolver,
+		authService:     authService,
+	}
+}
+
+// Handle 处理重置密码命令
+func (h *ResetPasswordHandler) Handle(ctx context.Context, cmd ResetPasswordCommand) error {
+	// 1. 检查用户是否存在、操作者的 ABAC 策略是否允许，目录用户的密码由外部身份源管理
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if err = authorize(ctx, h.policyResolver, cmd.ActorID, PermissionUpdate, u); err != nil {
+		return err
+	}
+	if u.IsExternallyManaged() {
+		return user.ErrPasswordManagedExternally
+	}
+
+	// 2. 验证密码策略
+	if err = h.authService.ValidatePasswordPolicy(ctx, cmd.NewPassword); err != nil {
+		return err
+	}
+
+	// 3. 生成密码哈希
+	hashedPassword, err := h.authService.GeneratePasswordHash(ctx, cmd.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// 4. 重置密码并设置强制修改标记
+	if err := h.userCommandRepo.ResetPassword(ctx, cmd.UserID, hashedPassword); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestResetPasswordHandler_Handle(t *testing.T) {
+	t.Run("成功重置密码", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+		mockAuthService := new(MockAuthService)
+
+		mockQryRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1}, nil)
+		mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "temp123456").Return(nil)
+		mockAuthService.On("GeneratePasswordHash", mock.Anything, "temp123456").Return("hashed_temp", nil)
+		mockCmdRepo.On("ResetPassword", mock.Anything, uint(1), "hashed_temp").Return(nil)
+
+		handler := NewResetPasswordHandler(mockCmdRepo, mockQryRepo, nil, mockAuthService)
+		err := handler.Handle(context.Background(), ResetPasswordCommand{UserID: 1, NewPassword: "temp123456"})
+
+		require.NoError(t, err)
+		mockCmdRepo.AssertExpectations(t)
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("用户不存在", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+		mockAuthService := new(MockAuthService)
+
+		mockQryRepo.On("GetByID", mock.Anything, uint(999)).Return(nil, user.ErrUserNotFound)
+
+		handler := NewResetPasswordHandler(mockCmdRepo, mockQryRepo, nil, mockAuthService)
+		err := handler.Handle(context.Background(), ResetPasswordCommand{UserID: 999, NewPassword: "temp123456"})
+
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+		mockCmdRepo.AssertNotCalled(t, "ResetPassword")
+	})
+
+	t.Run("密码不符合策略", func(t *testing.T) {
+		mockCmdRepo := new(MockUserCommandRepository)
+		mockQryRepo := new(MockUserQueryRepository)
+		mockAuthService := new(MockAuthService)
+
+		mockQryRepo.On("GetByID", mock.Anything, uint(1)).Return(&user.User{ID: 1}, nil)
+		mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "123").Return(ErrWeakPassword)
+
+		handler := NewResetPasswordHandler(mockCmdRepo, mockQryRepo, nil, mockAuthService)
+		err := handler.Handle(context.Background(), ResetPasswordCommand{UserID: 1, NewPassword: "123"})
+
+		require.ErrorIs(t, err, ErrWeakPassword)
+		mockCmdRepo.AssertNotCalled(t, "ResetPassword")
+	})
+}
@@ -0,0 +1,9 @@
+package user
+
+// RevokeRoleCommand 撤销用户角色授权命令（永久授权和限时授权均适用）
+type RevokeRoleCommand struct {
+	UserID uint
+	RoleID uint
+
+	ActorID uint // 发起操作的管理员，用于解析 ABAC 策略
+}
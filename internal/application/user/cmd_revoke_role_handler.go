@@ -0,0 +1,54 @@
+package user
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event/events"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// RevokeRoleHandler 负责撤销用户的单个角色授权
+type RevokeRoleHandler struct {
+	userCommandRepo user.CommandRepository
+	userQueryRepo   user.QueryRepository
+	policyResolver  policy.Resolver
+	eventBus        event.EventBus
+}
+
+// NewRevokeRoleHandler 创建撤销角色授权处理器
+func NewRevokeRoleHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	policyResolver policy.Resolver,
+	eventBus event.EventBus,
+) *RevokeRoleHandler {
+	return &RevokeRoleHandler{
+		userCommandRepo: userCommandRepo,
+		userQueryRepo:   userQueryRepo,
+		policyResolver:  policyResolver,
+		eventBus:        eventBus,
+	}
+}
+
+// Handle 处理撤销角色授权命令
+func (h *RevokeRoleHandler) Handle(ctx context.Context, cmd RevokeRoleCommand) error {
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return err
+	}
+	if err = authorize(ctx, h.policyResolver, cmd.ActorID, PermissionUpdate, u); err != nil {
+		return err
+	}
+
+	if err := h.userCommandRepo.RemoveRoles(ctx, cmd.UserID, []uint{cmd.RoleID}); err != nil {
+		return err
+	}
+
+	if h.eventBus != nil {
+		_ = h.eventBus.Publish(ctx, events.NewUserRoleAssignedEvent(cmd.UserID, []uint{cmd.RoleID})) // 缓存失效失败不阻塞业务
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package user
+
+// UpdateAttributeDefinitionCommand 更新自定义属性定义命令
+// Key 与 Type 不可修改；nil 字段保持不变，Options 非 nil 时整体替换
+type UpdateAttributeDefinitionCommand struct {
+	ID           uint
+	Label        *string
+	Description  *string
+	Required     *bool
+	Pattern      *string
+	Options      []string
+	Visibility   *string
+	UserEditable *bool
+	SortOrder    *int
+}
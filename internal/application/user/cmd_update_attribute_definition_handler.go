@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// UpdateAttributeDefinitionHandler 更新自定义属性定义命令处理器
+//
+// 规则变更（必填、校验正则、可选值）不回溯校验已保存的值，在用户下次编辑属性时生效。
+type UpdateAttributeDefinitionHandler struct {
+	commandRepo user.AttributeDefinitionCommandRepository
+	queryRepo   user.AttributeDefinitionQueryRepository
+}
+
+// NewUpdateAttributeDefinitionHandler 创建更新自定义属性定义命令处理器
+func NewUpdateAttributeDefinitionHandler(
+	commandRepo user.AttributeDefinitionCommandRepository,
+	queryRepo user.AttributeDefinitionQueryRepository,
+) *UpdateAttributeDefinitionHandler {
+	return &UpdateAttributeDefinitionHandler{
+		commandRepo: commandRepo,
+		queryRepo:   queryRepo,
+	}
+}
+
+// Handle 处理更新自定义属性定义命令
+func (h *UpdateAttributeDefinitionHandler) Handle(ctx context.Context, cmd UpdateAttributeDefinitionCommand) (*AttributeDefinitionDTO, error) {
+	def, err := h.queryRepo.GetByID(ctx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Label != nil {
+		def.Label = *cmd.Label
+	}
+	if cmd.Description != nil {
+		def.Description = *cmd.Description
+	}
+	if cmd.Required != nil {
+		def.Required = *cmd.Required
+	}
+	if cmd.Pattern != nil {
+		def.Pattern = *cmd.Pattern
+	}
+	if cmd.Options != nil {
+		def.Options = cmd.Options
+	}
+	if cmd.Visibility != nil {
+		def.Visibility = *cmd.Visibility
+	}
+	if cmd.UserEditable != nil {
+		def.UserEditable = *cmd.UserEditable
+	}
+	if cmd.SortOrder != nil {
+		def.SortOrder = *cmd.SortOrder
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := h.commandRepo.Update(ctx, def); err != nil {
+		return nil, fmt.Errorf("failed to update attribute definition: %w", err)
+	}
+
+	return ToAttributeDefinitionDTO(def), nil
+}
@@ -2,11 +2,19 @@ package user
 
 // UpdateUserCommand 更新用户命令
 type UpdateUserCommand struct {
-	UserID   uint
-	Username *string
-	Email    *string
-	FullName *string
-	Avatar   *string
-	Bio      *string
-	Status   *string
+	UserID     uint
+	Username   *string
+	Email      *string
+	FullName   *string
+	Avatar     *string
+	Bio        *string
+	Department *string
+	Status     *string
+
+	// Attributes 自定义属性变更，值为 nil 表示清除；为 nil 时不修改属性
+	Attributes map[string]any
+	// SelfService 用户编辑自己的资料，只能修改用户可编辑的属性
+	SelfService bool
+
+	ActorID uint // 发起更新的管理员，用于解析 ABAC 策略
 }
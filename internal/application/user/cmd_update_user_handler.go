@@ -3,24 +3,37 @@ package user
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
 // UpdateUserHandler 更新用户命令处理器
+//
+// 状态变更委托给 ChangeUserStatusHandler，与显式状态接口一样吊销会话、记录历史并发布事件。
 type UpdateUserHandler struct {
 	userCommandRepo user.CommandRepository
 	userQueryRepo   user.QueryRepository
+	attributeRepo   user.AttributeDefinitionQueryRepository
+	policyResolver  policy.Resolver
+	statusHandler   *ChangeUserStatusHandler
 }
 
 // NewUpdateUserHandler 创建更新用户命令处理器
 func NewUpdateUserHandler(
 	userCommandRepo user.CommandRepository,
 	userQueryRepo user.QueryRepository,
+	attributeRepo user.AttributeDefinitionQueryRepository,
+	policyResolver policy.Resolver,
+	statusHandler *ChangeUserStatusHandler,
 ) *UpdateUserHandler {
 	return &UpdateUserHandler{
 		userCommandRepo: userCommandRepo,
 		userQueryRepo:   userQueryRepo,
+		attributeRepo:   attributeRepo,
+		policyResolver:  policyResolver,
+		statusHandler:   statusHandler,
 	}
 }
 
@@ -32,7 +45,16 @@ func (h *UpdateUserHandler) Handle(ctx context.Context, cmd UpdateUserCommand) (
 		return nil, user.ErrUserNotFound
 	}
 
-	// 2. 更新用户属性
+	// 2. 检查操作者的 ABAC 策略是否允许更新该用户
+	decision, err := policy.Resolve(ctx, h.policyResolver, cmd.ActorID, PermissionUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve policy: %w", err)
+	}
+	if !decision.Allows(u.PolicyAttributes()) {
+		return nil, ErrAccessDenied
+	}
+
+	// 3. 更新用户属性
 	if cmd.Username != nil && *cmd.Username != u.Username {
 		// 检查用户名是否已存在
 		exists, err := h.userQueryRepo.ExistsByUsername(ctx, *cmd.Username)
@@ -59,29 +81,62 @@ func (h *UpdateUserHandler) Handle(ctx context.Context, cmd UpdateUserCommand) (
 		u.FullName = *cmd.FullName
 	}
 	if cmd.Avatar != nil {
-		u.Avatar = *cmd.Avatar
+		u.SetAvatarURL(*cmd.Avatar)
 	}
 	if cmd.Bio != nil {
 		u.Bio = *cmd.Bio
 	}
+	if cmd.Department != nil {
+		u.Department = *cmd.Department
+	}
+	statusChanged := false
 	if cmd.Status != nil {
-		// 使用领域模型方法
-		switch *cmd.Status {
-		case "active":
-			u.Activate()
-		case "inactive":
-			u.Deactivate()
-		case "banned":
-			u.Ban()
-		default:
+		if !user.IsValidStatus(*cmd.Status) {
 			return nil, user.ErrInvalidUserStatus
 		}
+		statusChanged = *cmd.Status != u.Status
+	}
+	var attributes map[string]string
+	if cmd.Attributes != nil {
+		schema, err := loadAttributeSchema(ctx, h.attributeRepo)
+		if err != nil {
+			return nil, err
+		}
+		if attributes, err = schema.Apply(u.Attributes, cmd.Attributes, cmd.SelfService); err != nil {
+			return nil, err
+		}
 	}
 
-	// 3. 保存更新
+	// 4. 更新后的用户同样需满足策略（如不能将用户移出自己可管理的部门）
+	attrs := u.PolicyAttributes()
+	if statusChanged {
+		attrs["status"] = *cmd.Status
+	}
+	if !decision.Allows(attrs) {
+		return nil, ErrAccessDenied
+	}
+
+	// 5. 保存更新
 	if err := h.userCommandRepo.Update(ctx, u); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	if cmd.Attributes != nil {
+		if err := h.userCommandRepo.SaveAttributes(ctx, u.ID, attributes); err != nil {
+			return nil, fmt.Errorf("failed to save attributes: %w", err)
+		}
+	}
+
+	// 6. 状态变更（封禁时吊销会话与令牌、记录历史并发布事件）
+	if statusChanged {
+		err := h.statusHandler.apply(ctx, u, ChangeUserStatusCommand{
+			UserID:     u.ID,
+			OperatorID: cmd.ActorID,
+			Status:     *cmd.Status,
+		}, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to change user status: %w", err)
+		}
+	}
 
 	return &UpdateUserResultDTO{
 		UserID: u.ID,
@@ -10,9 +10,20 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
+// newTestStatusHandler 构造状态变更处理器，副作用仓储均返回成功
+func newTestStatusHandler(cmdRepo *MockUserCommandRepository, qryRepo *MockUserQueryRepository) *ChangeUserStatusHandler {
+	cmdRepo.On("SaveStatus", mock.Anything, mock.AnythingOfType("*user.User")).Return(nil).Maybe()
+	historyRepo := new(MockStatusHistoryCommandRepository)
+	historyRepo.On("Create", mock.Anything, mock.AnythingOfType("*user.StatusChange")).Return(nil).Maybe()
+	patRepo := new(MockPATCommandRepository)
+	patRepo.On("DeleteByUserID", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return NewChangeUserStatusHandler(cmdRepo, qryRepo, historyRepo, patRepo, nil, nil)
+}
+
 func TestUpdateUserHandler_Handle_Success(t *testing.T) {
 	now := time.Now()
 	username := "newusername"
@@ -75,7 +86,7 @@ func TestUpdateUserHandler_Handle_Success(t *testing.T) {
 			}
 			mockCmdRepo.On("Update", mock.Anything, mock.AnythingOfType("*user.User")).Return(nil)
 
-			handler := NewUpdateUserHandler(mockCmdRepo, mockQryRepo)
+			handler := NewUpdateUserHandler(mockCmdRepo, mockQryRepo, newTestAttributeRepo(), nil, newTestStatusHandler(mockCmdRepo, mockQryRepo))
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -189,7 +200,7 @@ func TestUpdateUserHandler_Handle_Error(t *testing.T) {
 			mockQryRepo := new(MockUserQueryRepository)
 			tt.setupMocks(mockCmdRepo, mockQryRepo)
 
-			handler := NewUpdateUserHandler(mockCmdRepo, mockQryRepo)
+			handler := NewUpdateUserHandler(mockCmdRepo, mockQryRepo, newTestAttributeRepo(), nil, nil)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.cmd)
@@ -265,7 +276,7 @@ func TestUpdateUserHandler_Handle_UpdateAllFields(t *testing.T) {
 			mockQryRepo.On("GetByID", mock.Anything, tt.cmd.UserID).Return(tt.existingUser, nil)
 			mockCmdRepo.On("Update", mock.Anything, mock.AnythingOfType("*user.User")).Return(nil)
 
-			handler := NewUpdateUserHandler(mockCmdRepo, mockQryRepo)
+			handler := NewUpdateUserHandler(mockCmdRepo, mockQryRepo, newTestAttributeRepo(), nil, newTestStatusHandler(mockCmdRepo, mockQryRepo))
 
 			result, err := handler.Handle(context.Background(), tt.cmd)
 
@@ -281,3 +292,79 @@ func TestUpdateUserHandler_Handle_UpdateAllFields(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateUserHandler_Handle_Policy(t *testing.T) {
+	sameDepartment, err := policy.Parse("resource.department == subject.department")
+	require.NoError(t, err)
+	sales := "sales"
+	hr := "hr"
+	fullName := "New Name"
+
+	tests := []struct {
+		name       string
+		department string
+		cmd        UpdateUserCommand
+		wantErr    error
+	}{
+		{
+			name:       "更新本部门用户",
+			department: "sales",
+			cmd:        UpdateUserCommand{UserID: 2, FullName: &fullName, ActorID: 1},
+		},
+		{
+			name:       "更新其他部门用户",
+			department: "hr",
+			cmd:        UpdateUserCommand{UserID: 2, FullName: &fullName, ActorID: 1},
+			wantErr:    ErrAccessDenied,
+		},
+		{
+			name:       "将用户移出本部门",
+			department: "sales",
+			cmd:        UpdateUserCommand{UserID: 2, Department: &hr, ActorID: 1},
+			wantErr:    ErrAccessDenied,
+		},
+		{
+			name:       "保持本部门",
+			department: "sales",
+			cmd:        UpdateUserCommand{UserID: 2, Department: &sales, ActorID: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockCmdRepo := new(MockUserCommandRepository)
+			mockQryRepo := new(MockUserQueryRepository)
+			mockResolver := new(MockPolicyResolver)
+
+			mockQryRepo.On("GetByID", mock.Anything, uint(2)).Return(&user.User{
+				ID:         2,
+				Username:   "member",
+				Department: tt.department,
+				Status:     "active",
+			}, nil)
+			mockResolver.On("Resolve", mock.Anything, uint(1), PermissionUpdate).Return(&policy.Decision{
+				Subject:  policy.Attributes{"id": uint(1), "department": "sales"},
+				Policies: []*policy.Policy{sameDepartment},
+			}, nil)
+			if tt.wantErr == nil {
+				mockCmdRepo.On("Update", mock.Anything, mock.AnythingOfType("*user.User")).Return(nil)
+			}
+
+			handler := NewUpdateUserHandler(mockCmdRepo, mockQryRepo, newTestAttributeRepo(), mockResolver, nil)
+
+			// Act
+			result, err := handler.Handle(context.Background(), tt.cmd)
+
+			// Assert
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				mockCmdRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			mockCmdRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package user
+
+// DefaultAvatarMaxSize 头像文件默认大小上限
+const DefaultAvatarMaxSize int64 = 5 << 20
+
+// UploadAvatarCommand 上传头像命令
+type UploadAvatarCommand struct {
+	UserID  uint
+	Content []byte
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/imaging"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/storage"
+)
+
+// avatarVersionLength 头像版本（内容摘要）的十六进制长度
+const avatarVersionLength = 16
+
+// UploadAvatarHandler 上传头像命令处理器
+//
+// 图片经类型与尺寸校验后按中心裁剪为正方形，缩放为 user.AvatarSizes 中的各尺寸写入存储，
+// 以内容摘要作为版本；成功后删除旧版本文件。
+type UploadAvatarHandler struct {
+	userCommandRepo user.CommandRepository
+	userQueryRepo   user.QueryRepository
+	store           storage.Storage
+	maxSize         int64
+}
+
+// NewUploadAvatarHandler 创建上传头像命令处理器，maxSize <= 0 时使用 DefaultAvatarMaxSize
+func NewUploadAvatarHandler(
+	userCommandRepo user.CommandRepository,
+	userQueryRepo user.QueryRepository,
+	store storage.Storage,
+	maxSize int64,
+) *UploadAvatarHandler {
+	if maxSize <= 0 {
+		maxSize = DefaultAvatarMaxSize
+	}
+	return &UploadAvatarHandler{
+		userCommandRepo: userCommandRepo,
+		userQueryRepo:   userQueryRepo,
+		store:           store,
+		maxSize:         maxSize,
+	}
+}
+
+// MaxSize 头像文件大小上限（字节）
+func (h *UploadAvatarHandler) MaxSize() int64 {
+	return h.maxSize
+}
+
+// Handle 处理上传头像命令
+func (h *UploadAvatarHandler) Handle(ctx context.Context, cmd UploadAvatarCommand) (*AvatarDTO, error) {
+	if int64(len(cmd.Content)) > h.maxSize {
+		return nil, user.ErrAvatarTooLarge
+	}
+
+	u, err := h.userQueryRepo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := imaging.Decode(cmd.Content)
+	switch {
+	case errors.Is(err, imaging.ErrUnsupportedType):
+		return nil, user.ErrUnsupportedAvatarType
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", user.ErrInvalidAvatar, err)
+	}
+
+	images, err := imaging.SquareSizes(img, user.AvatarSizes)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(images[user.AvatarSizes[0]])
+	version := hex.EncodeToString(sum[:])[:avatarVersionLength]
+
+	for _, size := range user.AvatarSizes {
+		if err := h.store.Put(ctx, avatarKey(u.ID, version, size), images[size], imaging.ContentTypePNG); err != nil {
+			return nil, fmt.Errorf("failed to store avatar: %w", err)
+		}
+	}
+
+	previous := u.AvatarVersion
+	u.SetUploadedAvatar(avatarURL(u.ID, version), version)
+	if err := h.userCommandRepo.UpdateAvatar(ctx, u.ID, u.Avatar, u.AvatarVersion); err != nil {
+		return nil, err
+	}
+
+	if previous != "" && previous != version {
+		removeAvatarFiles(ctx, h.store, u.ID, previous)
+	}
+
+	return &AvatarDTO{URL: u.Avatar, Sizes: user.AvatarSizes}, nil
+}
+
+// removeAvatarFiles 删除指定版本的头像文件，失败仅记录日志（不影响已生效的头像）
+func removeAvatarFiles(ctx context.Context, store storage.Storage, userID uint, version string) {
+	for _, size := range user.AvatarSizes {
+		if err := store.Delete(ctx, avatarKey(userID, version, size)); err != nil {
+			slog.Warn("Failed to delete avatar file", "user_id", userID, "version", version, "size", size, "error", err)
+		}
+	}
+}
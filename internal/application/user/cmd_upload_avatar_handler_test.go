@@ -0,0 +1,96 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/storage"
+)
+
+// testAvatarPNG 生成指定尺寸的纯色 PNG
+func testAvatarPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{R: 200, G: 40, B: 40, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestUploadAvatarHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("裁剪缩放后写入各尺寸并删除旧版本", func(t *testing.T) {
+		store, err := storage.NewLocalStorage(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, store.Put(ctx, avatarKey(1, "oldversion", 128), []byte("old"), "image/png"))
+
+		userCmd := new(MockUserCommandRepository)
+		userQry := new(MockUserQueryRepository)
+		u := &domainUser.User{ID: 1, Avatar: avatarURL(1, "oldversion"), AvatarVersion: "oldversion"}
+		userQry.On("GetByID", mock.Anything, uint(1)).Return(u, nil)
+		userCmd.On("UpdateAvatar", mock.Anything, uint(1), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+
+		handler := NewUploadAvatarHandler(userCmd, userQry, store, 0)
+		result, err := handler.Handle(ctx, UploadAvatarCommand{UserID: 1, Content: testAvatarPNG(t, 300, 200)})
+
+		require.NoError(t, err)
+		assert.Equal(t, domainUser.AvatarSizes, result.Sizes)
+		assert.Equal(t, avatarURL(1, u.AvatarVersion), result.URL)
+		assert.NotEqual(t, "oldversion", u.AvatarVersion)
+		userCmd.AssertCalled(t, "UpdateAvatar", mock.Anything, uint(1), result.URL, u.AvatarVersion)
+
+		for _, size := range domainUser.AvatarSizes {
+			data, getErr := store.Get(ctx, avatarKey(1, u.AvatarVersion, size))
+			require.NoError(t, getErr)
+			img, decodeErr := png.Decode(bytes.NewReader(data))
+			require.NoError(t, decodeErr)
+			assert.Equal(t, size, img.Bounds().Dx())
+			assert.Equal(t, size, img.Bounds().Dy())
+		}
+		_, err = store.Get(ctx, avatarKey(1, "oldversion", 128))
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("超出大小上限", func(t *testing.T) {
+		handler := NewUploadAvatarHandler(new(MockUserCommandRepository), new(MockUserQueryRepository), nil, 10)
+
+		_, err := handler.Handle(ctx, UploadAvatarCommand{UserID: 1, Content: make([]byte, 11)})
+
+		assert.ErrorIs(t, err, ErrAvatarTooLarge)
+	})
+
+	t.Run("不支持的文件类型", func(t *testing.T) {
+		userQry := new(MockUserQueryRepository)
+		userQry.On("GetByID", mock.Anything, uint(1)).Return(&domainUser.User{ID: 1}, nil)
+		handler := NewUploadAvatarHandler(new(MockUserCommandRepository), userQry, nil, 0)
+
+		_, err := handler.Handle(ctx, UploadAvatarCommand{UserID: 1, Content: []byte("%PDF-1.4 not an image")})
+
+		assert.ErrorIs(t, err, ErrUnsupportedAvatarType)
+	})
+
+	t.Run("损坏的图片", func(t *testing.T) {
+		userQry := new(MockUserQueryRepository)
+		userQry.On("GetByID", mock.Anything, uint(1)).Return(&domainUser.User{ID: 1}, nil)
+		handler := NewUploadAvatarHandler(new(MockUserCommandRepository), userQry, nil, 0)
+
+		content := testAvatarPNG(t, 10, 10)
+		_, err := handler.Handle(ctx, UploadAvatarCommand{UserID: 1, Content: content[:len(content)/2]})
+
+		assert.ErrorIs(t, err, ErrInvalidAvatar)
+	})
+}
@@ -0,0 +1,79 @@
+package user
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// listCursor 游标的序列化形式：排序表达式与上一页最后一个用户的排序值
+type listCursor struct {
+	Sort   string `json:"s"`
+	Values []any  `json:"v"`
+}
+
+// encodeCursor 将游标编码为不透明字符串（base64url JSON）
+func encodeCursor(sort string, c *user.Cursor) string {
+	values := make([]any, len(c.Values))
+	for i, v := range c.Values {
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC().Format(time.RFC3339Nano)
+		}
+		values[i] = v
+	}
+	data, _ := json.Marshal(listCursor{Sort: sort, Values: values})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor 解析游标并按排序字段类型还原值；游标必须与当前排序一致
+func decodeCursor(raw, sort string, order []user.SortField) (*user.Cursor, error) {
+	invalid := fmt.Errorf("%w: invalid cursor", user.ErrInvalidListCriteria)
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, invalid
+	}
+	var c listCursor
+	if err := json.Unmarshal(data, &c); err != nil || len(c.Values) != len(order) {
+		return nil, invalid
+	}
+	if c.Sort != sort {
+		return nil, fmt.Errorf("%w: cursor was issued for a different sort order", user.ErrInvalidListCriteria)
+	}
+
+	values := make([]any, len(order))
+	for i, s := range order {
+		switch {
+		case user.IsNumericSortField(s.Field):
+			n, ok := c.Values[i].(float64)
+			if !ok || n < 0 {
+				return nil, invalid
+			}
+			if s.Field == user.SortByID {
+				values[i] = uint(n)
+			} else {
+				values[i] = int64(n)
+			}
+		case user.IsTimeSortField(s.Field):
+			str, ok := c.Values[i].(string)
+			if !ok {
+				return nil, invalid
+			}
+			t, err := time.Parse(time.RFC3339Nano, str)
+			if err != nil {
+				return nil, invalid
+			}
+			values[i] = t
+		default:
+			str, ok := c.Values[i].(string)
+			if !ok {
+				return nil, invalid
+			}
+			values[i] = str
+		}
+	}
+	return &user.Cursor{Values: values}, nil
+}
@@ -0,0 +1,178 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// dataExportActivityPageSize 读取审计日志时的每页数量
+const dataExportActivityPageSize = 500
+
+// 归档中的文件
+const (
+	dataExportManifestFile = "manifest.json"
+	dataExportProfileFile  = "profile.json"
+	dataExportTokensFile   = "tokens.json"
+	dataExportSessionsFile = "sessions.json"
+	dataExportActivityFile = "activity.json"
+)
+
+// dataExportManifest 归档说明
+type dataExportManifest struct {
+	UserID      uint      `json:"user_id"`
+	Username    string    `json:"username"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Files       []string  `json:"files"`
+}
+
+// dataExportToken 个人访问令牌元数据（不含令牌哈希）
+type dataExportToken struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Permissions []string   `json:"permissions"`
+	Status      string     `json:"status"`
+	IPWhitelist []string   `json:"ip_whitelist,omitempty"`
+	Description string     `json:"description,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+}
+
+// dataExportSession 一次登录会话
+type dataExportSession struct {
+	LoginAt   time.Time `json:"login_at"`
+	IPAddress string    `json:"ip_address,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	Status    string    `json:"status"`
+}
+
+// dataExportActivity 一条操作记录
+type dataExportActivity struct {
+	At         time.Time `json:"at"`
+	Action     string    `json:"action"`
+	Resource   string    `json:"resource"`
+	ResourceID string    `json:"resource_id,omitempty"`
+	Status     string    `json:"status"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	Details    string    `json:"details,omitempty"`
+}
+
+// dataExportArchiver 收集用户数据并打包为 ZIP 归档
+type dataExportArchiver struct {
+	userQueryRepo     user.QueryRepository
+	patQueryRepo      pat.QueryRepository
+	auditLogQueryRepo auditlog.QueryRepository
+}
+
+// build 生成用户的数据归档，返回文件名与内容
+func (a *dataExportArchiver) build(ctx context.Context, userID uint, now time.Time) (string, []byte, error) {
+	u, err := a.userQueryRepo.GetByIDWithRoles(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tokens, err := a.patQueryRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+
+	logs, err := a.activity(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var sessions []dataExportSession
+	activity := make([]dataExportActivity, 0, len(logs))
+	for _, l := range logs {
+		if l.Action == auditlog.ActionLogin && l.IsSuccess() {
+			sessions = append(sessions, dataExportSession{
+				LoginAt: l.CreatedAt, IPAddress: l.IPAddress, UserAgent: l.UserAgent, Status: l.Status,
+			})
+		}
+		activity = append(activity, dataExportActivity{
+			At: l.CreatedAt, Action: l.Action, Resource: l.Resource, ResourceID: l.ResourceID,
+			Status: l.Status, IPAddress: l.IPAddress, UserAgent: l.UserAgent, Details: l.Details,
+		})
+	}
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{dataExportManifestFile, dataExportManifest{
+			UserID:      u.ID,
+			Username:    u.Username,
+			GeneratedAt: now,
+			Files:       []string{dataExportProfileFile, dataExportTokensFile, dataExportSessionsFile, dataExportActivityFile},
+		}},
+		{dataExportProfileFile, ToUserWithRolesDTO(u)},
+		{dataExportTokensFile, toDataExportTokens(tokens)},
+		{dataExportSessionsFile, sessions},
+		{dataExportActivityFile, activity},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create %s: %w", f.name, err)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return "", nil, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return "", nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	fileName := fmt.Sprintf("%s-data-export-%s.zip", u.Username, now.UTC().Format("20060102T150405Z"))
+	return fileName, buf.Bytes(), nil
+}
+
+// activity 分页读取用户的全部审计日志（按时间倒序）
+func (a *dataExportArchiver) activity(ctx context.Context, userID uint) ([]auditlog.AuditLog, error) {
+	var all []auditlog.AuditLog
+	for page := 1; ; page++ {
+		logs, total, err := a.auditLogQueryRepo.ListByUser(ctx, userID, page, dataExportActivityPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list activity: %w", err)
+		}
+		all = append(all, logs...)
+		if len(logs) < dataExportActivityPageSize || int64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
+
+// toDataExportTokens 转换令牌元数据
+func toDataExportTokens(tokens []*pat.PersonalAccessToken) []dataExportToken {
+	result := make([]dataExportToken, 0, len(tokens))
+	for _, t := range tokens {
+		result = append(result, dataExportToken{
+			ID:          t.ID,
+			Name:        t.Name,
+			TokenPrefix: t.TokenPrefix,
+			Permissions: t.Permissions,
+			Status:      t.Status,
+			IPWhitelist: t.IPWhitelist,
+			Description: t.Description,
+			CreatedAt:   t.CreatedAt,
+			ExpiresAt:   t.ExpiresAt,
+			LastUsedAt:  t.LastUsedAt,
+		})
+	}
+	return result
+}
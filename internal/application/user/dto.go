@@ -1,6 +1,47 @@
 package user
 
-import "time"
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// 重新导出领域错误供 Adapters 层使用（遵循 DDD 依赖方向）
+var (
+	ErrUserNotFound              = user.ErrUserNotFound
+	ErrUserNotPending            = user.ErrUserNotPending
+	ErrUsernameAlreadyExists     = user.ErrUsernameAlreadyExists
+	ErrEmailAlreadyExists        = user.ErrEmailAlreadyExists
+	ErrPasswordManagedExternally = user.ErrPasswordManagedExternally
+	ErrRoleNotFound              = user.ErrRoleNotFound
+	ErrPrivilegedRoleAssignment  = user.ErrPrivilegedRoleAssignment
+	ErrInvalidAssignmentWindow   = user.ErrInvalidAssignmentWindow
+	ErrImportJobNotFound         = user.ErrImportJobNotFound
+	ErrUnsupportedImportFormat   = user.ErrUnsupportedImportFormat
+	ErrInvalidImportFile         = user.ErrInvalidImportFile
+	ErrTooManyImportRows         = user.ErrTooManyImportRows
+	ErrInvalidListCriteria       = user.ErrInvalidListCriteria
+	ErrDataExportNotFound        = user.ErrDataExportNotFound
+	ErrDataExportNotReady        = user.ErrDataExportNotReady
+	ErrDataExportExpired         = user.ErrDataExportExpired
+	ErrUnsupportedAvatarType     = user.ErrUnsupportedAvatarType
+	ErrAvatarTooLarge            = user.ErrAvatarTooLarge
+	ErrInvalidAvatar             = user.ErrInvalidAvatar
+	ErrInvalidUserStatus         = user.ErrInvalidUserStatus
+	ErrUserStatusUnchanged       = user.ErrUserStatusUnchanged
+	ErrInvalidBanExpiry          = user.ErrInvalidBanExpiry
+	ErrCannotChangeOwnStatus     = user.ErrCannotChangeOwnStatus
+	ErrWeakPassword              = auth.ErrWeakPassword
+
+	ErrAttributeDefinitionNotFound = user.ErrAttributeDefinitionNotFound
+	ErrAttributeKeyExists          = user.ErrAttributeKeyExists
+	ErrInvalidAttributeDefinition  = user.ErrInvalidAttributeDefinition
+	ErrUnknownAttribute            = user.ErrUnknownAttribute
+	ErrInvalidAttributeValue       = user.ErrInvalidAttributeValue
+	ErrAttributeRequired           = user.ErrAttributeRequired
+	ErrAttributeNotEditable        = user.ErrAttributeNotEditable
+)
 
 // CreateUserDTO 创建用户 DTO
 type CreateUserDTO struct {
@@ -10,6 +51,8 @@ type CreateUserDTO struct {
 	FullName string  `json:"full_name" binding:"max=100"`
 	Status   *string `json:"status" binding:"omitempty,oneof=active inactive"`
 	RoleIDs  []uint  `json:"role_ids" binding:"omitempty,dive,gt=0"`
+
+	Attributes map[string]any `json:"attributes"` // 自定义属性值，键为属性键
 }
 
 // UpdateUserDTO 更新用户 DTO
@@ -19,7 +62,11 @@ type UpdateUserDTO struct {
 	FullName *string `json:"full_name" binding:"omitempty,max=100"`
 	Avatar   *string `json:"avatar" binding:"omitempty,max=255"`
 	Bio      *string `json:"bio"`
-	Status   *string `json:"status" binding:"omitempty,oneof=active inactive banned"`
+	Status   *string `json:"status" binding:"omitempty,oneof=active inactive banned"` // 需要记录原因或设置封禁期限时使用 POST /api/admin/users/:id/status
+
+	Department *string `json:"department" binding:"omitempty,max=100"`
+
+	Attributes map[string]any `json:"attributes"` // 自定义属性变更，值为 null 表示清除；未提供的属性保持不变
 }
 
 // ChangePasswordDTO 修改密码 DTO
@@ -28,36 +75,115 @@ type ChangePasswordDTO struct {
 	NewPassword string `json:"new_password" binding:"required,min=6"`
 }
 
+// ResetPasswordDTO 管理员重置密码 DTO
+type ResetPasswordDTO struct {
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+// InviteUserDTO 邀请用户 DTO
+type InviteUserDTO struct {
+	Username string `json:"username" binding:"required,min=3,max=50"`
+	Email    string `json:"email" binding:"required,email"`
+	FullName string `json:"full_name" binding:"max=100"`
+	RoleIDs  []uint `json:"role_ids" binding:"omitempty,dive,gt=0"`
+}
+
+// InvitationResultDTO 邀请结果 DTO
+type InvitationResultDTO struct {
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // AssignRolesDTO 分配角色 DTO
 type AssignRolesDTO struct {
 	RoleIDs []uint `json:"role_ids" binding:"required"`
 }
 
+// GrantRoleDTO 限时角色授权 DTO
+// starts_at 为空表示立即生效，expires_at 为空表示永不过期
+type GrantRoleDTO struct {
+	RoleID    uint       `json:"role_id" binding:"required,gt=0"`
+	StartsAt  *time.Time `json:"starts_at" binding:"omitempty"`
+	ExpiresAt *time.Time `json:"expires_at" binding:"omitempty"`
+}
+
+// RoleAssignmentDTO 角色授权响应 DTO
+type RoleAssignmentDTO struct {
+	UserID      uint       `json:"user_id"`
+	RoleID      uint       `json:"role_id"`
+	RoleName    string     `json:"role_name,omitempty"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+	Status      string     `json:"status"` // active | scheduled | expired
+	Temporary   bool       `json:"temporary"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// RoleAssignmentListDTO 角色授权列表响应 DTO
+type RoleAssignmentListDTO struct {
+	Assignments []*RoleAssignmentDTO `json:"assignments"`
+	Total       int64                `json:"total"`
+}
+
+// ProcessRoleAssignmentsResultDTO 限时授权处理结果
+type ProcessRoleAssignmentsResultDTO struct {
+	Activated int `json:"activated"`
+	Expired   int `json:"expired"`
+}
+
 // UserDTO 用户响应 DTO (不包含敏感信息)
 type UserDTO struct {
-	ID        uint      `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	FullName  string    `json:"full_name"`
-	Avatar    string    `json:"avatar"`
-	Bio       string    `json:"bio"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	Avatar   string `json:"avatar"`
+	Bio      string `json:"bio"`
+	Status   string `json:"status"`
+
+	Department string    `json:"department"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"` // 为空表示从未登录
+	LastLoginIP string     `json:"last_login_ip,omitempty"`
+	LoginCount  int64      `json:"login_count"`
+
+	Attributes map[string]any `json:"attributes,omitempty"`
 }
 
 // UserWithRolesDTO 用户响应 DTO（包含角色信息）
 type UserWithRolesDTO struct {
-	ID        uint      `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	FullName  string    `json:"full_name"`
-	Avatar    string    `json:"avatar"`
-	Bio       string    `json:"bio"`
-	Status    string    `json:"status"`
-	Roles     []RoleDTO `json:"roles"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	Avatar   string `json:"avatar"`
+	Bio      string `json:"bio"`
+	Status   string `json:"status"`
+
+	Department string    `json:"department"`
+	Roles      []RoleDTO `json:"roles"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	MustChangePassword bool       `json:"must_change_password"`
+	PasswordChangedAt  *time.Time `json:"password_changed_at,omitempty"`
+	AuthSource         string     `json:"auth_source"` // local | ldap，目录用户不能在本地修改密码
+
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"` // 账号将在该时间删除，期间登录即取消
+
+	BanReason   string     `json:"ban_reason,omitempty"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"` // 为空表示永久封禁
+
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"` // 为空表示从未登录
+	LastLoginIP string     `json:"last_login_ip,omitempty"`
+	LoginCount  int64      `json:"login_count"`
+
+	Attributes map[string]any `json:"attributes,omitempty"` // 自定义属性值（按可见性过滤）
 }
 
 // RoleDTO 角色 DTO（嵌套在用户响应中）
@@ -70,8 +196,10 @@ type RoleDTO struct {
 
 // UserListDTO 用户列表响应 DTO
 type UserListDTO struct {
-	Users []*UserDTO `json:"users"`
-	Total int64      `json:"total"`
+	Users      []*UserDTO `json:"users"`
+	Total      int64      `json:"total"`                 // 仅在 WithTotal 时统计
+	HasMore    bool       `json:"has_more"`              // 是否还有下一页
+	NextCursor string     `json:"next_cursor,omitempty"` // 下一页游标
 }
 
 // BatchCreateUserDTO 批量创建用户请求 DTO
@@ -116,3 +244,196 @@ type CreateUserResultDTO struct {
 type UpdateUserResultDTO struct {
 	UserID uint
 }
+
+// ImportJobDTO 用户导入任务 DTO
+type ImportJobDTO struct {
+	ID         uint                 `json:"id"`
+	FileName   string               `json:"file_name"`
+	Format     string               `json:"format"`
+	DryRun     bool                 `json:"dry_run"`
+	Status     string               `json:"status"`
+	Total      int                  `json:"total"`
+	Processed  int                  `json:"processed"`
+	Succeeded  int                  `json:"succeeded"`
+	Failed     int                  `json:"failed"`
+	Progress   int                  `json:"progress"` // 处理进度百分比
+	Error      string               `json:"error,omitempty"`
+	CreatedBy  uint                 `json:"created_by"`
+	CreatedAt  time.Time            `json:"created_at"`
+	StartedAt  *time.Time           `json:"started_at,omitempty"`
+	FinishedAt *time.Time           `json:"finished_at,omitempty"`
+	Results    []ImportRowResultDTO `json:"results,omitempty"`
+}
+
+// ImportRowResultDTO 导入报告中的单行结果 DTO
+type ImportRowResultDTO struct {
+	Line     int    `json:"line"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Status   string `json:"status"` // created / valid / failed
+	Error    string `json:"error,omitempty"`
+}
+
+// ProcessImportJobsResultDTO 后台处理导入任务结果 DTO
+type ProcessImportJobsResultDTO struct {
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+// UserExportDTO 导出的单个用户 DTO
+type UserExportDTO struct {
+	ID         uint      `json:"id"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	FullName   string    `json:"full_name"`
+	Status     string    `json:"status"`
+	Department string    `json:"department"`
+	Roles      []string  `json:"roles"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ExportUsersResultDTO 导出结果 DTO
+type ExportUsersResultDTO struct {
+	Count int `json:"count"`
+}
+
+// AccountDeletionDTO 账号删除计划 DTO
+type AccountDeletionDTO struct {
+	RequestedAt time.Time `json:"requested_at"`
+	ScheduledAt time.Time `json:"scheduled_at"` // 宽限期结束时间，此前登录即取消删除
+}
+
+// FinalizeAccountDeletionsResultDTO 后台完成账号删除结果 DTO
+type FinalizeAccountDeletionsResultDTO struct {
+	Deleted int `json:"deleted"`
+	Failed  int `json:"failed"`
+}
+
+// AvatarDTO 上传头像结果 DTO
+type AvatarDTO struct {
+	URL   string `json:"url"`   // 头像地址（带版本参数，可长期缓存）
+	Sizes []int  `json:"sizes"` // 可通过 size 参数获取的边长
+}
+
+// AvatarImageDTO 头像图片 DTO
+type AvatarImageDTO struct {
+	Content     []byte
+	ContentType string
+	ETag        string
+	Version     string // 已上传头像的版本，默认头像为空
+	Generated   bool   // 是否为生成的默认头像
+}
+
+// DataExportDTO 个人数据导出任务 DTO
+type DataExportDTO struct {
+	ID         uint       `json:"id"`
+	Status     string     `json:"status"` // pending / running / completed / failed
+	FileName   string     `json:"file_name,omitempty"`
+	Size       int64      `json:"size"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Expired    bool       `json:"expired"`
+}
+
+// DataExportArchiveDTO 可下载的数据导出归档
+type DataExportArchiveDTO struct {
+	FileName string
+	Content  []byte
+}
+
+// ProcessDataExportsResultDTO 后台生成数据导出归档结果 DTO
+type ProcessDataExportsResultDTO struct {
+	Completed int   `json:"completed"`
+	Failed    int   `json:"failed"`
+	Purged    int64 `json:"purged"`
+}
+
+// ChangeUserStatusDTO 变更用户状态请求 DTO
+type ChangeUserStatusDTO struct {
+	Status      string     `json:"status" binding:"required,oneof=active inactive banned"`
+	Reason      string     `json:"reason" binding:"required,max=500"`
+	BannedUntil *time.Time `json:"banned_until" binding:"omitempty"` // 仅封禁可设置，为空表示永久封禁
+}
+
+// UserStatusDTO 用户状态响应 DTO
+type UserStatusDTO struct {
+	UserID      uint       `json:"user_id"`
+	Status      string     `json:"status"`
+	BanReason   string     `json:"ban_reason,omitempty"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+}
+
+// StatusChangeDTO 用户状态变更记录 DTO
+type StatusChangeDTO struct {
+	ID          uint       `json:"id"`
+	FromStatus  string     `json:"from_status"`
+	ToStatus    string     `json:"to_status"`
+	Reason      string     `json:"reason,omitempty"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+	OperatorID  uint       `json:"operator_id"` // 0 表示系统（封禁到期自动解封）
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// StatusHistoryDTO 用户状态变更历史响应 DTO
+type StatusHistoryDTO struct {
+	Changes []*StatusChangeDTO `json:"changes"`
+	Total   int64              `json:"total"`
+}
+
+// LiftExpiredBansResultDTO 到期封禁处理结果
+type LiftExpiredBansResultDTO struct {
+	Lifted int `json:"lifted"`
+	Failed int `json:"failed"`
+}
+
+// DeactivateInactiveUsersResultDTO 不活跃账号停用结果
+type DeactivateInactiveUsersResultDTO struct {
+	Deactivated int `json:"deactivated"`
+	Failed      int `json:"failed"`
+}
+
+// AttributeDefinitionDTO 自定义属性定义响应 DTO
+type AttributeDefinitionDTO struct {
+	ID           uint      `json:"id"`
+	Key          string    `json:"key"`
+	Label        string    `json:"label"`
+	Description  string    `json:"description,omitempty"`
+	Type         string    `json:"type"`
+	Required     bool      `json:"required"`
+	Pattern      string    `json:"pattern,omitempty"`
+	Options      []string  `json:"options,omitempty"`
+	Visibility   string    `json:"visibility"`
+	UserEditable bool      `json:"user_editable"`
+	SortOrder    int       `json:"sort_order"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// CreateAttributeDefinitionDTO 创建自定义属性定义 DTO
+type CreateAttributeDefinitionDTO struct {
+	Key          string   `json:"key" binding:"required,max=50" example:"employee_id"`
+	Label        string   `json:"label" binding:"required,max=100" example:"工号"`
+	Description  string   `json:"description" binding:"max=500"`
+	Type         string   `json:"type" binding:"required,oneof=string number boolean date enum"`
+	Required     bool     `json:"required"`
+	Pattern      string   `json:"pattern" binding:"max=500" example:"E[0-9]{6}"`    // 正则校验（string/number），须完整匹配
+	Options      []string `json:"options" binding:"omitempty,max=100,dive,max=500"` // enum 类型的可选值
+	Visibility   string   `json:"visibility" binding:"omitempty,oneof=user admin"`  // 默认 user
+	UserEditable bool     `json:"user_editable"`
+	SortOrder    int      `json:"sort_order"`
+}
+
+// UpdateAttributeDefinitionDTO 更新自定义属性定义 DTO（key 与 type 不可修改）
+type UpdateAttributeDefinitionDTO struct {
+	Label        *string  `json:"label" binding:"omitempty,max=100"`
+	Description  *string  `json:"description" binding:"omitempty,max=500"`
+	Required     *bool    `json:"required"`
+	Pattern      *string  `json:"pattern" binding:"omitempty,max=500"`
+	Options      []string `json:"options" binding:"omitempty,max=100,dive,max=500"` // 提供时整体替换
+	Visibility   *string  `json:"visibility" binding:"omitempty,oneof=user admin"`
+	UserEditable *bool    `json:"user_editable"`
+	SortOrder    *int     `json:"sort_order"`
+}
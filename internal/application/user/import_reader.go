@@ -0,0 +1,207 @@
+package user
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// 导入文件格式
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatXLSX = "xlsx"
+)
+
+// MaxImportRows 单个导入文件允许的最大数据行数
+const MaxImportRows = 10000
+
+// 导入字段名（列映射的目标字段）
+const (
+	importFieldUsername = "username"
+	importFieldEmail    = "email"
+	importFieldPassword = "password"
+	importFieldFullName = "full_name"
+	importFieldStatus   = "status"
+	importFieldRoles    = "roles"
+)
+
+// importFields 支持的导入字段
+var importFields = []string{
+	importFieldUsername, importFieldEmail, importFieldPassword,
+	importFieldFullName, importFieldStatus, importFieldRoles,
+}
+
+// importRequiredFields 必须存在对应列的字段
+var importRequiredFields = []string{importFieldUsername, importFieldEmail, importFieldPassword}
+
+// importRecord 源文件中的一行原始数据
+type importRecord struct {
+	line  int
+	cells []string
+}
+
+// DetectImportFormat 根据文件扩展名识别导入格式，无法识别时返回空字符串
+func DetectImportFormat(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return ImportFormatCSV
+	case ".xlsx":
+		return ImportFormatXLSX
+	default:
+		return ""
+	}
+}
+
+// parseImportRows 解析导入文件为待导入行
+//
+// 第一行为表头。mapping 为"字段名 -> 表头列名"，未映射的字段按同名列（忽略大小写）匹配；
+// 空白行被跳过，角色列以分号或逗号分隔多个角色名称。
+func parseImportRows(format string, content []byte, mapping map[string]string) ([]user.ImportRow, error) {
+	var (
+		records []importRecord
+		err     error
+	)
+	switch format {
+	case ImportFormatCSV:
+		records, err = readCSVRecords(content)
+	case ImportFormatXLSX:
+		records, err = readXLSXRecords(content)
+	default:
+		return nil, fmt.Errorf("%w: %q", user.ErrUnsupportedImportFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: missing header row", user.ErrInvalidImportFile)
+	}
+
+	columns, err := resolveImportColumns(records[0].cells, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]user.ImportRow, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		if isBlankRecord(rec.cells) {
+			continue
+		}
+		if len(rows) == MaxImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows allowed", user.ErrTooManyImportRows, MaxImportRows)
+		}
+
+		cell := func(field string) string {
+			idx, ok := columns[field]
+			if !ok || idx >= len(rec.cells) {
+				return ""
+			}
+			return strings.TrimSpace(rec.cells[idx])
+		}
+		rows = append(rows, user.ImportRow{
+			Line:     rec.line,
+			Username: cell(importFieldUsername),
+			Email:    cell(importFieldEmail),
+			Password: cell(importFieldPassword),
+			FullName: cell(importFieldFullName),
+			Status:   cell(importFieldStatus),
+			Roles:    splitRoleNames(cell(importFieldRoles)),
+		})
+	}
+
+	return rows, nil
+}
+
+// resolveImportColumns 根据表头和列映射确定每个字段所在的列序号
+func resolveImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, dup := index[key]; !dup && key != "" {
+			index[key] = i
+		}
+	}
+
+	for field := range mapping {
+		if !slices.Contains(importFields, field) {
+			return nil, fmt.Errorf("%w: unknown field %q in column mapping", user.ErrInvalidImportFile, field)
+		}
+	}
+
+	columns := make(map[string]int, len(importFields))
+	for _, field := range importFields {
+		column, mapped := mapping[field]
+		if !mapped || strings.TrimSpace(column) == "" {
+			column = field
+		}
+		idx, ok := index[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			if mapped {
+				return nil, fmt.Errorf("%w: column %q mapped to %s not found", user.ErrInvalidImportFile, column, field)
+			}
+			continue
+		}
+		columns[field] = idx
+	}
+
+	for _, field := range importRequiredFields {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("%w: missing column for %s", user.ErrInvalidImportFile, field)
+		}
+	}
+
+	return columns, nil
+}
+
+// readCSVRecords 读取 CSV 内容（兼容 UTF-8 BOM 和多行字段）
+func readCSVRecords(content []byte) ([]importRecord, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var records []importRecord
+	for {
+		cells, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", user.ErrInvalidImportFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, importRecord{line: line, cells: cells})
+		if len(records) > MaxImportRows+1 {
+			return nil, fmt.Errorf("%w: at most %d rows allowed", user.ErrTooManyImportRows, MaxImportRows)
+		}
+	}
+	return records, nil
+}
+
+// splitRoleNames 拆分角色列（分号或逗号分隔）
+func splitRoleNames(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var names []string
+	for name := range strings.FieldsFuncSeq(value, func(r rune) bool { return r == ';' || r == ',' }) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func isBlankRecord(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// buildXLSX 构造仅包含共享字符串和内联字符串的最小 XLSX 文件
+func buildXLSX(t *testing.T, sheet string) []byte {
+	t.Helper()
+
+	parts := map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Users" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/users.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Login</t></si><si><t>email</t></si><si><t>password</t></si><si><r><t>ali</t></r><r><t>ce</t></r></si></sst>`,
+		"xl/worksheets/users.xml": sheet,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestParseImportRows_CSV(t *testing.T) {
+	t.Run("按同名列解析并拆分角色", func(t *testing.T) {
+		content := "\xef\xbb\xbfUsername,Email,Password,Roles\n" +
+			"alice,alice@example.com,Secret123!,viewer; editor\n" +
+			",,,\n" +
+			"bob,bob@example.com,Secret123!,\n"
+
+		rows, err := parseImportRows(ImportFormatCSV, []byte(content), nil)
+
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, domainUser.ImportRow{
+			Line: 2, Username: "alice", Email: "alice@example.com", Password: "Secret123!",
+			Roles: []string{"viewer", "editor"},
+		}, rows[0])
+		assert.Equal(t, 4, rows[1].Line)
+		assert.Nil(t, rows[1].Roles)
+	})
+
+	t.Run("使用列映射", func(t *testing.T) {
+		content := "Login,Mail,Pwd,Name\nalice,alice@example.com,Secret123!,Alice\n"
+		mapping := map[string]string{"username": "Login", "email": "Mail", "password": "Pwd", "full_name": "name"}
+
+		rows, err := parseImportRows(ImportFormatCSV, []byte(content), mapping)
+
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "alice", rows[0].Username)
+		assert.Equal(t, "Alice", rows[0].FullName)
+	})
+
+	t.Run("缺少必需列", func(t *testing.T) {
+		_, err := parseImportRows(ImportFormatCSV, []byte("username,email\nalice,a@example.com\n"), nil)
+		require.ErrorIs(t, err, domainUser.ErrInvalidImportFile)
+	})
+
+	t.Run("映射到不存在的列", func(t *testing.T) {
+		_, err := parseImportRows(ImportFormatCSV, []byte("username,email,password\n"), map[string]string{"email": "Mail"})
+		require.ErrorIs(t, err, domainUser.ErrInvalidImportFile)
+	})
+
+	t.Run("映射未知字段", func(t *testing.T) {
+		_, err := parseImportRows(ImportFormatCSV, []byte("username,email,password\n"), map[string]string{"salary": "Pay"})
+		require.ErrorIs(t, err, domainUser.ErrInvalidImportFile)
+	})
+
+	t.Run("空文件", func(t *testing.T) {
+		_, err := parseImportRows(ImportFormatCSV, nil, nil)
+		require.ErrorIs(t, err, domainUser.ErrInvalidImportFile)
+	})
+
+	t.Run("不支持的格式", func(t *testing.T) {
+		_, err := parseImportRows("xls", []byte("x"), nil)
+		require.ErrorIs(t, err, domainUser.ErrUnsupportedImportFormat)
+	})
+}
+
+func TestParseImportRows_XLSX(t *testing.T) {
+	t.Run("解析共享字符串、内联字符串和稀疏单元格", func(t *testing.T) {
+		sheet := `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="s"><v>2</v></c></row>
+<row r="3"><c r="A3" t="s"><v>3</v></c><c r="B3" t="inlineStr"><is><t>alice@example.com</t></is></c><c r="D3"><v>12345678</v></c></row>
+</sheetData></worksheet>`
+		content := buildXLSX(t, sheet)
+
+		rows, err := parseImportRows(ImportFormatXLSX, content, map[string]string{"username": "login"})
+
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, 3, rows[0].Line)
+		assert.Equal(t, "alice", rows[0].Username)
+		assert.Equal(t, "alice@example.com", rows[0].Email)
+		assert.Equal(t, "12345678", rows[0].Password)
+	})
+
+	t.Run("非 zip 文件", func(t *testing.T) {
+		_, err := parseImportRows(ImportFormatXLSX, []byte("username,email"), nil)
+		require.ErrorIs(t, err, domainUser.ErrInvalidImportFile)
+	})
+}
+
+func TestDetectImportFormat(t *testing.T) {
+	assert.Equal(t, ImportFormatCSV, DetectImportFormat("users.CSV"))
+	assert.Equal(t, ImportFormatXLSX, DetectImportFormat("users.xlsx"))
+	assert.Empty(t, DetectImportFormat("users.xls"))
+}
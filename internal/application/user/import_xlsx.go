@@ -0,0 +1,214 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// maxXLSXPartSize 单个 XLSX 部件解压后的最大字节数（防止压缩炸弹）
+const maxXLSXPartSize = 64 << 20
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText 富文本或纯文本字符串（<t> 或多个 <r><t>）
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSXRecords 读取 XLSX 工作簿第一个工作表的单元格文本
+//
+// 仅实现导入所需的最小子集：共享字符串、内联字符串、数字与布尔值，
+// 不计算公式（使用缓存值），日期按序列号原样返回。
+func readXLSXRecords(content []byte) ([]importRecord, error) {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a valid xlsx archive", user.ErrInvalidImportFile)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+
+	sheetPath, err := xlsxFirstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("%w: worksheet %s not found", user.ErrInvalidImportFile, sheetPath)
+	}
+	var sheet xlsxSheet
+	if err := decodeXLSXPart(f, &sheet); err != nil {
+		return nil, err
+	}
+	if len(sheet.Rows) > MaxImportRows+1 {
+		return nil, fmt.Errorf("%w: at most %d rows allowed", user.ErrTooManyImportRows, MaxImportRows)
+	}
+
+	records := make([]importRecord, 0, len(sheet.Rows))
+	for i, row := range sheet.Rows {
+		line := row.R
+		if line == 0 {
+			line = i + 1
+		}
+		var cells []string
+		for j, c := range row.Cells {
+			col := j
+			if c.Ref != "" {
+				if col, err = xlsxColumnIndex(c.Ref); err != nil {
+					return nil, err
+				}
+			}
+			value, err := xlsxCellValue(c.Type, c.Value, c.Inline, shared.Items)
+			if err != nil {
+				return nil, err
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+			cells[col] = value
+		}
+		records = append(records, importRecord{line: line, cells: cells})
+	}
+	return records, nil
+}
+
+// xlsxFirstSheetPath 通过 workbook.xml 及其关系定位第一个工作表
+func xlsxFirstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wbFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("%w: workbook.xml not found", user.ErrInvalidImportFile)
+	}
+	var wb xlsxWorkbook
+	if err := decodeXLSXPart(wbFile, &wb); err != nil {
+		return "", err
+	}
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if len(wb.Sheets) == 0 || !ok {
+		return fallback, nil
+	}
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(relsFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+func decodeXLSXPart(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %w", user.ErrInvalidImportFile, err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, maxXLSXPartSize)).Decode(v); err != nil {
+		return fmt.Errorf("%w: malformed %s: %w", user.ErrInvalidImportFile, f.Name, err)
+	}
+	return nil
+}
+
+func xlsxCellValue(cellType, value string, inline xlsxText, shared []xlsxText) (string, error) {
+	switch cellType {
+	case "s":
+		idx, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || idx < 0 || idx >= len(shared) {
+			return "", fmt.Errorf("%w: invalid shared string index %q", user.ErrInvalidImportFile, value)
+		}
+		return shared[idx].String(), nil
+	case "inlineStr":
+		return inline.String(), nil
+	case "b":
+		if value == "1" {
+			return "true", nil
+		}
+		return "false", nil
+	default: // n, str, e 及未声明类型均使用缓存值
+		return value, nil
+	}
+}
+
+// xlsxColumnIndex 将单元格引用（如 "AB12"）转换为从 0 开始的列序号
+func xlsxColumnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 || n > 3 {
+		return 0, fmt.Errorf("%w: invalid cell reference %q", user.ErrInvalidImportFile, ref)
+	}
+	return col - 1, nil
+}
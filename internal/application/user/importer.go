@@ -0,0 +1,267 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// importProgressInterval 后台处理时每处理多少行保存一次进度
+const importProgressInterval = 50
+
+var importEmailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// userImporter 逐行校验并创建导入用户（上传时同步处理与后台任务共用）
+type userImporter struct {
+	userCommandRepo  user.CommandRepository
+	userQueryRepo    user.QueryRepository
+	roleQueryRepo    role.QueryRepository
+	importJobCmdRepo user.ImportJobCommandRepository
+	authService      auth.Service
+}
+
+// importState 单个任务处理过程中的状态
+type importState struct {
+	usernames map[string]bool       // 文件内已出现的用户名（小写）
+	emails    map[string]bool       // 文件内已出现的邮箱（小写）
+	roles     map[string]*role.Role // 角色名称查询缓存，nil 表示不存在
+}
+
+// run 处理任务中尚未处理的行并将任务标记为完成
+//
+// 每 importProgressInterval 行保存一次进度，任务中断后可从断点继续；
+// 查询失败等基础设施错误会中止处理并返回错误，由调用方将任务标记为失败。
+func (im *userImporter) run(ctx context.Context, job *user.ImportJob) error {
+	state := newImportState(job.Results)
+
+	for i, row := range job.PendingRows() {
+		result, err := im.importRow(ctx, job.DryRun, row, state)
+		if err != nil {
+			return err
+		}
+		job.Record(result)
+
+		if (i+1)%importProgressInterval == 0 {
+			if err := im.importJobCmdRepo.Save(ctx, job); err != nil {
+				return err
+			}
+		}
+	}
+
+	job.Complete(time.Now())
+	return nil
+}
+
+// fail 将任务标记为失败并保存
+func (im *userImporter) fail(ctx context.Context, job *user.ImportJob, cause error) error {
+	job.Fail(cause.Error(), time.Now())
+	return im.importJobCmdRepo.Save(ctx, job)
+}
+
+// protectPasswords 校验密码策略并以哈希替换明文密码，须在任务保存前调用
+//
+// 校验失败的原因记录在行中，由逐行处理时报告；预检任务不创建用户，不生成哈希。
+// 哈希计算开销较大，按 CPU 数并发处理。
+func (im *userImporter) protectPasswords(ctx context.Context, rows []user.ImportRow, dryRun bool) error {
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+
+	for i := range rows {
+		row := &rows[i]
+		password := row.Password
+		row.Password = ""
+
+		if password == "" {
+			row.PasswordError = "密码不能为空"
+			continue
+		}
+		if err := im.authService.ValidatePasswordPolicy(ctx, password); err != nil {
+			row.PasswordError = fmt.Sprintf("密码不符合策略: %v", err)
+			continue
+		}
+		if dryRun {
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			hash, err := im.authService.GeneratePasswordHash(ctx, password)
+			if err != nil {
+				once.Do(func() { firstErr = fmt.Errorf("failed to hash password: %w", err) })
+				return
+			}
+			row.PasswordHash = hash
+		})
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// newImportState 基于已处理结果恢复文件内去重状态
+func newImportState(results []user.ImportRowResult) *importState {
+	state := &importState{
+		usernames: make(map[string]bool),
+		emails:    make(map[string]bool),
+		roles:     make(map[string]*role.Role),
+	}
+	for _, r := range results {
+		if r.Status != user.ImportRowFailed {
+			state.remember(r.Username, r.Email)
+		}
+	}
+	return state
+}
+
+func (s *importState) remember(username, email string) {
+	s.usernames[strings.ToLower(username)] = true
+	s.emails[strings.ToLower(email)] = true
+}
+
+// importRow 校验并（非预检时）创建单行用户
+func (im *userImporter) importRow(ctx context.Context, dryRun bool, row user.ImportRow, state *importState) (user.ImportRowResult, error) {
+	result := user.ImportRowResult{Line: row.Line, Username: row.Username, Email: row.Email}
+	reject := func(msg string) (user.ImportRowResult, error) {
+		result.Status = user.ImportRowFailed
+		result.Error = msg
+		return result, nil
+	}
+
+	// 1. 基本字段校验
+	if msg := validateImportRow(row); msg != "" {
+		return reject(msg)
+	}
+	status := row.Status
+	if status == "" {
+		status = "active"
+	}
+
+	// 2. 文件内重复
+	if state.usernames[strings.ToLower(row.Username)] {
+		return reject("用户名在文件中重复")
+	}
+	if state.emails[strings.ToLower(row.Email)] {
+		return reject("邮箱在文件中重复")
+	}
+
+	// 3. 密码策略（创建任务前已校验）
+	if row.PasswordError != "" {
+		return reject(row.PasswordError)
+	}
+
+	// 4. 与已有用户重复
+	exists, err := im.userQueryRepo.ExistsByUsername(ctx, row.Username)
+	if err != nil {
+		return result, fmt.Errorf("failed to check username existence: %w", err)
+	}
+	if exists {
+		return reject("用户名已存在")
+	}
+	exists, err = im.userQueryRepo.ExistsByEmail(ctx, row.Email)
+	if err != nil {
+		return result, fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if exists {
+		return reject("邮箱已存在")
+	}
+
+	// 5. 角色解析
+	roleIDs := make([]uint, 0, len(row.Roles))
+	for _, name := range row.Roles {
+		r, err := im.findRole(ctx, name, state)
+		if err != nil {
+			return result, err
+		}
+		if r == nil || !r.IsGlobal() {
+			return reject(fmt.Sprintf("角色不存在: %s", name))
+		}
+		// 管理权限须经角色分配接口授予（受双人审批保护），不能通过导入绕过
+		if r.IsPrivileged() {
+			return reject(fmt.Sprintf("角色含管理权限，不能通过导入分配: %s", name))
+		}
+		roleIDs = append(roleIDs, r.ID)
+	}
+
+	// 预检通过的行同样参与文件内去重
+	state.remember(row.Username, row.Email)
+	if dryRun {
+		result.Status = user.ImportRowValid
+		return result, nil
+	}
+
+	// 6. 创建用户并分配角色
+	if row.PasswordHash == "" {
+		return reject("密码不能为空")
+	}
+	newUser := &user.User{
+		Username: row.Username,
+		Email:    row.Email,
+		Password: row.PasswordHash,
+		FullName: row.FullName,
+		Status:   status,
+	}
+	newUser.RequirePasswordChange()
+
+	if err := im.userCommandRepo.Create(ctx, newUser); err != nil {
+		return reject(fmt.Sprintf("创建用户失败: %v", err))
+	}
+	if len(roleIDs) > 0 {
+		if err := im.userCommandRepo.AssignRoles(ctx, newUser.ID, roleIDs); err != nil {
+			return reject(fmt.Sprintf("用户已创建但角色分配失败: %v", err))
+		}
+	}
+
+	result.Status = user.ImportRowCreated
+	return result, nil
+}
+
+// findRole 按名称查询角色及其有效权限（每个任务内缓存）
+func (im *userImporter) findRole(ctx context.Context, name string, state *importState) (*role.Role, error) {
+	if r, ok := state.roles[name]; ok {
+		return r, nil
+	}
+	r, err := im.roleQueryRepo.FindByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find role %q: %w", name, err)
+	}
+	if r != nil {
+		// 加载含继承在内的有效权限，用于判断是否为特权角色
+		if r.InheritedPermissions, err = im.roleQueryRepo.GetEffectivePermissions(ctx, r.ID); err != nil {
+			return nil, fmt.Errorf("failed to get permissions of role %q: %w", name, err)
+		}
+	}
+	state.roles[name] = r
+	return r, nil
+}
+
+// validateImportRow 校验单行必填字段与格式，返回错误信息（通过时为空）
+func validateImportRow(row user.ImportRow) string {
+	switch {
+	case row.Username == "":
+		return "用户名不能为空"
+	case len(row.Username) < 3 || len(row.Username) > 50:
+		return "用户名长度必须在 3-50 个字符之间"
+	case row.Email == "":
+		return "邮箱不能为空"
+	case !importEmailRegex.MatchString(row.Email):
+		return "邮箱格式无效"
+	case len(row.FullName) > 100:
+		return "姓名长度不能超过 100 个字符"
+	case row.Status != "" && row.Status != "active" && row.Status != "inactive":
+		return fmt.Sprintf("无效的状态值: %s", row.Status)
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/mail"
+)
+
+// invitationAcceptPath 前端接受邀请页面路径
+const invitationAcceptPath = "/accept-invitation"
+
+// invitationIssuer 生成邀请令牌并发送邀请邮件（邀请与重发邀请共用）
+type invitationIssuer struct {
+	invitationCommandRepo user.InvitationCommandRepository
+	settingQueryRepo      setting.QueryRepository
+	preferences           setting.PreferenceResolver // 可选，决定邮件语言与时区
+	mailer                mail.Mailer
+}
+
+// invitationEmail 邀请邮件模板，按语言区分
+type invitationEmail struct {
+	subject string
+	body    string // 参数依次为用户名、链接、过期时间
+}
+
+// invitationEmails 已支持的邀请邮件语言，按语言主标签索引
+var invitationEmails = map[string]invitationEmail{
+	"en": {
+		subject: "You have been invited",
+		body:    "Hello %s,\n\nYou have been invited to join. Open the link below to set your password and activate your account:\n\n%s\n\nThe link expires at %s.",
+	},
+	"zh": {
+		subject: "您收到了一份邀请",
+		body:    "%s，您好：\n\n您已受邀加入。请打开以下链接设置密码并激活账号：\n\n%s\n\n链接有效期至 %s。",
+	},
+}
+
+// invitationEmailFor 返回语言对应的邀请邮件模板，未支持的语言回退到英文
+func invitationEmailFor(locale string) invitationEmail {
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	if tpl, ok := invitationEmails[lang]; ok {
+		return tpl
+	}
+	return invitationEmails["en"]
+}
+
+// issue 使旧邀请失效，生成新邀请并发送邮件
+func (i *invitationIssuer) issue(ctx context.Context, u *user.User, invitedBy uint) (*InvitationResultDTO, error) {
+	if err := i.invitationCommandRepo.DeleteByUserID(ctx, u.ID); err != nil {
+		return nil, err
+	}
+
+	invitation, token, err := user.NewInvitation(u.ID, invitedBy, user.DefaultInvitationTTL, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err = i.invitationCommandRepo.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	// 邮件语言与过期时间时区取被邀请用户的生效偏好（通常为管理员配置的默认值）
+	prefs := setting.ResolveFor(ctx, i.preferences, u.ID)
+	tpl := invitationEmailFor(prefs.Locale())
+	link := i.acceptLink(ctx, token)
+	body := fmt.Sprintf(tpl.body, u.Username, link, prefs.FormatTime(invitation.ExpiresAt))
+	if err = i.mailer.Send(ctx, u.Email, tpl.subject, body); err != nil {
+		return nil, fmt.Errorf("failed to send invitation email: %w", err)
+	}
+
+	return &InvitationResultDTO{
+		UserID:    u.ID,
+		Username:  u.Username,
+		Email:     u.Email,
+		ExpiresAt: invitation.ExpiresAt,
+	}, nil
+}
+
+// acceptLink 基于站点 URL 生成接受邀请链接，未配置站点 URL 时返回相对路径
+func (i *invitationIssuer) acceptLink(ctx context.Context, token string) string {
+	base := ""
+	if i.settingQueryRepo != nil {
+		if s, err := i.settingQueryRepo.FindByKey(ctx, setting.KeySiteURL); err == nil && s != nil {
+			base = strings.TrimRight(s.Value, "/")
+		}
+	}
+	return base + invitationAcceptPath + "?token=" + url.QueryEscape(token)
+}
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"time"
+
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
@@ -11,15 +13,20 @@ func ToUserDTO(u *user.User) *UserDTO {
 	}
 
 	return &UserDTO{
-		ID:        u.ID,
-		Username:  u.Username,
-		Email:     u.Email,
-		FullName:  u.FullName,
-		Avatar:    u.Avatar,
-		Bio:       u.Bio,
-		Status:    u.Status,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:         u.ID,
+		Username:   u.Username,
+		Email:      u.Email,
+		FullName:   u.FullName,
+		Avatar:     u.Avatar,
+		Bio:        u.Bio,
+		Status:     u.Status,
+		Department: u.Department,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+
+		LastLoginAt: u.LastLoginAt,
+		LastLoginIP: u.LastLoginIP,
+		LoginCount:  u.LoginCount,
 	}
 }
 
@@ -44,15 +51,180 @@ func ToUserWithRolesDTO(u *user.User) *UserWithRolesDTO {
 	}
 
 	return &UserWithRolesDTO{
-		ID:        u.ID,
-		Username:  u.Username,
-		Email:     u.Email,
-		FullName:  u.FullName,
-		Avatar:    u.Avatar,
-		Bio:       u.Bio,
-		Status:    u.Status,
-		Roles:     roles,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:         u.ID,
+		Username:   u.Username,
+		Email:      u.Email,
+		FullName:   u.FullName,
+		Avatar:     u.Avatar,
+		Bio:        u.Bio,
+		Status:     u.Status,
+		Department: u.Department,
+		Roles:      roles,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+
+		MustChangePassword: u.MustChangePassword,
+		PasswordChangedAt:  u.PasswordChangedAt,
+		AuthSource:         u.AuthSource,
+
+		DeletionScheduledAt: u.DeletionScheduledAt,
+
+		BanReason:   u.BanReason,
+		BannedUntil: u.BannedUntil,
+
+		LastLoginAt: u.LastLoginAt,
+		LastLoginIP: u.LastLoginIP,
+		LoginCount:  u.LoginCount,
+	}
+}
+
+// ToUserStatusDTO 将用户当前状态转换为 DTO
+func ToUserStatusDTO(u *user.User) *UserStatusDTO {
+	return &UserStatusDTO{
+		UserID:      u.ID,
+		Status:      u.Status,
+		BanReason:   u.BanReason,
+		BannedUntil: u.BannedUntil,
+	}
+}
+
+// ToStatusChangeDTOs 将状态变更记录转换为 DTO 列表
+func ToStatusChangeDTOs(changes []*user.StatusChange) []*StatusChangeDTO {
+	dtos := make([]*StatusChangeDTO, 0, len(changes))
+	for _, c := range changes {
+		dtos = append(dtos, &StatusChangeDTO{
+			ID:          c.ID,
+			FromStatus:  c.FromStatus,
+			ToStatus:    c.ToStatus,
+			Reason:      c.Reason,
+			BannedUntil: c.BannedUntil,
+			OperatorID:  c.OperatorID,
+			CreatedAt:   c.CreatedAt,
+		})
+	}
+	return dtos
+}
+
+// ToRoleAssignmentDTO 将领域模型 RoleAssignment 转换为 DTO，状态按 now 计算
+func ToRoleAssignmentDTO(a *user.RoleAssignment, now time.Time) *RoleAssignmentDTO {
+	if a == nil {
+		return nil
+	}
+
+	return &RoleAssignmentDTO{
+		UserID:      a.UserID,
+		RoleID:      a.RoleID,
+		RoleName:    a.RoleName,
+		StartsAt:    a.StartsAt,
+		ExpiresAt:   a.ExpiresAt,
+		ActivatedAt: a.ActivatedAt,
+		Status:      a.StatusAt(now),
+		Temporary:   a.IsTemporary(),
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// ToRoleAssignmentDTOs 批量转换角色授权
+func ToRoleAssignmentDTOs(assignments []*user.RoleAssignment, now time.Time) []*RoleAssignmentDTO {
+	result := make([]*RoleAssignmentDTO, 0, len(assignments))
+	for _, a := range assignments {
+		result = append(result, ToRoleAssignmentDTO(a, now))
+	}
+	return result
+}
+
+// ToImportJobDTO 将导入任务转换为 DTO，withResults 为 true 时包含逐行结果
+func ToImportJobDTO(j *user.ImportJob, withResults bool) *ImportJobDTO {
+	if j == nil {
+		return nil
+	}
+
+	dto := &ImportJobDTO{
+		ID:         j.ID,
+		FileName:   j.FileName,
+		Format:     j.Format,
+		DryRun:     j.DryRun,
+		Status:     string(j.Status),
+		Total:      j.Total,
+		Processed:  j.Processed,
+		Succeeded:  j.Succeeded,
+		Failed:     j.Failed,
+		Progress:   j.Progress(),
+		Error:      j.Error,
+		CreatedBy:  j.CreatedBy,
+		CreatedAt:  j.CreatedAt,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+	}
+	if withResults {
+		dto.Results = make([]ImportRowResultDTO, 0, len(j.Results))
+		for _, r := range j.Results {
+			dto.Results = append(dto.Results, ImportRowResultDTO{
+				Line:     r.Line,
+				Username: r.Username,
+				Email:    r.Email,
+				Status:   r.Status,
+				Error:    r.Error,
+			})
+		}
+	}
+	return dto
+}
+
+// ToUserExportDTO 将用户转换为导出 DTO（角色名称取直接分配的角色）
+func ToUserExportDTO(u *user.User) *UserExportDTO {
+	roles := make([]string, 0, len(u.Roles))
+	for _, r := range u.Roles {
+		roles = append(roles, r.Name)
+	}
+
+	return &UserExportDTO{
+		ID:         u.ID,
+		Username:   u.Username,
+		Email:      u.Email,
+		FullName:   u.FullName,
+		Status:     u.Status,
+		Department: u.Department,
+		Roles:      roles,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+	}
+}
+
+// ToDataExportDTO 将领域模型 DataExport 转换为 DTO，过期状态按 now 计算
+func ToDataExportDTO(e *user.DataExport, now time.Time) *DataExportDTO {
+	if e == nil {
+		return nil
+	}
+
+	return &DataExportDTO{
+		ID:         e.ID,
+		Status:     string(e.Status),
+		FileName:   e.FileName,
+		Size:       e.Size,
+		Error:      e.Error,
+		CreatedAt:  e.CreatedAt,
+		FinishedAt: e.FinishedAt,
+		ExpiresAt:  e.ExpiresAt,
+		Expired:    e.IsExpired(now),
+	}
+}
+
+// ToAttributeDefinitionDTO 将属性定义转换为 DTO
+func ToAttributeDefinitionDTO(d *user.AttributeDefinition) *AttributeDefinitionDTO {
+	return &AttributeDefinitionDTO{
+		ID:           d.ID,
+		Key:          d.Key,
+		Label:        d.Label,
+		Description:  d.Description,
+		Type:         d.Type,
+		Required:     d.Required,
+		Pattern:      d.Pattern,
+		Options:      d.Options,
+		Visibility:   d.Visibility,
+		UserEditable: d.UserEditable,
+		SortOrder:    d.SortOrder,
+		CreatedAt:    d.CreatedAt,
+		UpdatedAt:    d.UpdatedAt,
 	}
 }
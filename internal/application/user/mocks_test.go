@@ -7,8 +7,14 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
 	domainAuth "github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
 	domainEvent "github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	domainPAT "github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	domainPolicy "github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	domainSetting "github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
 	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
@@ -35,6 +41,31 @@ func (m *MockUserCommandRepository) Delete(ctx context.Context, id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockUserCommandRepository) RecordLogins(ctx context.Context, summaries []domainUser.LoginSummary) error {
+	args := m.Called(ctx, summaries)
+	return args.Error(0)
+}
+
+func (m *MockUserCommandRepository) SaveStatus(ctx context.Context, u *domainUser.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserCommandRepository) SaveAttributes(ctx context.Context, userID uint, values map[string]string) error {
+	args := m.Called(ctx, userID, values)
+	return args.Error(0)
+}
+
+func (m *MockUserCommandRepository) UpdateAvatar(ctx context.Context, userID uint, avatar, version string) error {
+	args := m.Called(ctx, userID, avatar, version)
+	return args.Error(0)
+}
+
+func (m *MockUserCommandRepository) UpdateDeletionSchedule(ctx context.Context, userID uint, requestedAt, scheduledAt *time.Time) error {
+	args := m.Called(ctx, userID, requestedAt, scheduledAt)
+	return args.Error(0)
+}
+
 func (m *MockUserCommandRepository) AssignRoles(ctx context.Context, userID uint, roleIDs []uint) error {
 	args := m.Called(ctx, userID, roleIDs)
 	return args.Error(0)
@@ -50,6 +81,11 @@ func (m *MockUserCommandRepository) UpdatePassword(ctx context.Context, userID u
 	return args.Error(0)
 }
 
+func (m *MockUserCommandRepository) ResetPassword(ctx context.Context, userID uint, hashedPassword string) error {
+	args := m.Called(ctx, userID, hashedPassword)
+	return args.Error(0)
+}
+
 func (m *MockUserCommandRepository) UpdateStatus(ctx context.Context, userID uint, status string) error {
 	args := m.Called(ctx, userID, status)
 	return args.Error(0)
@@ -149,6 +185,19 @@ func (m *MockUserQueryRepository) CountBySearch(ctx context.Context, keyword str
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockUserQueryRepository) ListByCriteria(ctx context.Context, criteria domainUser.ListCriteria) ([]*domainUser.User, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.User), args.Error(1)
+}
+
+func (m *MockUserQueryRepository) CountByCriteria(ctx context.Context, criteria domainUser.ListCriteria) (int64, error) {
+	args := m.Called(ctx, criteria)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockUserQueryRepository) GetRoles(ctx context.Context, userID uint) ([]uint, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -190,6 +239,11 @@ func (m *MockAuthService) GenerateAccessToken(ctx context.Context, userID uint,
 	return args.String(0), args.Get(1).(time.Time), args.Error(2)
 }
 
+func (m *MockAuthService) GenerateScopedAccessToken(ctx context.Context, userID uint, username, scope string) (string, time.Time, error) {
+	args := m.Called(ctx, userID, username, scope)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
 func (m *MockAuthService) GenerateRefreshToken(ctx context.Context, userID uint) (string, time.Time, error) {
 	args := m.Called(ctx, userID)
 	return args.String(0), args.Get(1).(time.Time), args.Error(2)
@@ -203,9 +257,9 @@ func (m *MockAuthService) ValidateAccessToken(ctx context.Context, token string)
 	return args.Get(0).(*domainAuth.TokenClaims), args.Error(1)
 }
 
-func (m *MockAuthService) ValidateRefreshToken(ctx context.Context, token string) (uint, error) {
+func (m *MockAuthService) ValidateRefreshToken(ctx context.Context, token string) (uint, time.Time, error) {
 	args := m.Called(ctx, token)
-	return args.Get(0).(uint), args.Error(1)
+	return args.Get(0).(uint), args.Get(1).(time.Time), args.Error(2)
 }
 
 func (m *MockAuthService) GeneratePATToken(ctx context.Context) (string, error) {
@@ -240,3 +294,597 @@ func (m *MockEventBus) Close() error {
 	args := m.Called()
 	return args.Error(0)
 }
+
+// MockInvitationCommandRepository 邀请写仓储 Mock
+type MockInvitationCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvitationCommandRepository) Create(ctx context.Context, invitation *domainUser.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationCommandRepository) MarkAccepted(ctx context.Context, invitation *domainUser.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationCommandRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// MockMailer 邮件发送 Mock
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, to, subject, body string) error {
+	args := m.Called(ctx, to, subject, body)
+	return args.Error(0)
+}
+
+// MockPreferenceResolver 用户偏好解析 Mock
+type MockPreferenceResolver struct {
+	mock.Mock
+}
+
+func (m *MockPreferenceResolver) Resolve(ctx context.Context, userID uint) (*domainSetting.Preferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainSetting.Preferences), args.Error(1)
+}
+
+// MockPolicyResolver ABAC 策略解析 Mock
+type MockPolicyResolver struct {
+	mock.Mock
+}
+
+func (m *MockPolicyResolver) Resolve(ctx context.Context, userID uint, permission string) (*domainPolicy.Decision, error) {
+	args := m.Called(ctx, userID, permission)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainPolicy.Decision), args.Error(1)
+}
+
+// MockRoleQueryRepository 角色读仓储 Mock
+type MockRoleQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleQueryRepository) FindByID(ctx context.Context, id uint) (*domainRole.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByName(ctx context.Context, name string) (*domainRole.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) FindByIDWithPermissions(ctx context.Context, id uint) (*domainRole.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainRole.Role), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) List(ctx context.Context, page, limit int) ([]domainRole.Role, int64, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]domainRole.Role), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRoleQueryRepository) GetPermissions(ctx context.Context, roleID uint) ([]domainRole.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetHierarchy(ctx context.Context) (domainRole.Hierarchy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domainRole.Hierarchy), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetEffectivePermissions(ctx context.Context, roleID uint) ([]domainRole.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Permission), args.Error(1)
+}
+
+func (m *MockRoleQueryRepository) GetGrants(ctx context.Context, roleIDs []uint) ([]domainRole.Grant, error) {
+	args := m.Called(ctx, roleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domainRole.Grant), args.Error(1)
+}
+
+// MockRoleAssignmentCommandRepository 角色授权写仓储 Mock
+type MockRoleAssignmentCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleAssignmentCommandRepository) Grant(ctx context.Context, assignment *domainUser.RoleAssignment) error {
+	args := m.Called(ctx, assignment)
+	return args.Error(0)
+}
+
+func (m *MockRoleAssignmentCommandRepository) MarkActivated(ctx context.Context, userID, roleID uint, at time.Time) error {
+	args := m.Called(ctx, userID, roleID, at)
+	return args.Error(0)
+}
+
+func (m *MockRoleAssignmentCommandRepository) Expire(ctx context.Context, userID, roleID uint, now time.Time) (bool, error) {
+	args := m.Called(ctx, userID, roleID, now)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockRoleAssignmentQueryRepository 角色授权读仓储 Mock
+type MockRoleAssignmentQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleAssignmentQueryRepository) ListByUser(ctx context.Context, userID uint) ([]*domainUser.RoleAssignment, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.RoleAssignment), args.Error(1)
+}
+
+func (m *MockRoleAssignmentQueryRepository) ListDueActivations(ctx context.Context, now time.Time, limit int) ([]*domainUser.RoleAssignment, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.RoleAssignment), args.Error(1)
+}
+
+func (m *MockRoleAssignmentQueryRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domainUser.RoleAssignment, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.RoleAssignment), args.Error(1)
+}
+
+func (m *MockRoleAssignmentQueryRepository) ListExpiring(ctx context.Context, now, until time.Time, offset, limit int) ([]*domainUser.RoleAssignment, int64, error) {
+	args := m.Called(ctx, now, until, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domainUser.RoleAssignment), args.Get(1).(int64), args.Error(2)
+}
+
+// MockImportJobCommandRepository 导入任务写仓储 Mock
+type MockImportJobCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportJobCommandRepository) Create(ctx context.Context, job *domainUser.ImportJob) error {
+	args := m.Called(ctx, job)
+	if args.Error(0) == nil && job.ID == 0 {
+		job.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *MockImportJobCommandRepository) Save(ctx context.Context, job *domainUser.ImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockImportJobCommandRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domainUser.ImportJob, error) {
+	args := m.Called(ctx, staleBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.ImportJob), args.Error(1)
+}
+
+// MockAuditLogCommandRepository 审计日志写仓储 Mock
+type MockAuditLogCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogCommandRepository) Create(ctx context.Context, log *domainAuditLog.AuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogCommandRepository) DeleteOlderThan(ctx context.Context, days int) error {
+	args := m.Called(ctx, days)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogCommandRepository) AnonymizeUser(ctx context.Context, userID uint, pseudonym string) error {
+	args := m.Called(ctx, userID, pseudonym)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogCommandRepository) BatchCreate(ctx context.Context, logs []*domainAuditLog.AuditLog) error {
+	args := m.Called(ctx, logs)
+	return args.Error(0)
+}
+
+// MockAuditLogQueryRepository 审计日志读仓储 Mock
+type MockAuditLogQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogQueryRepository) FindByID(ctx context.Context, id uint) (*domainAuditLog.AuditLog, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainAuditLog.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditLogQueryRepository) List(ctx context.Context, filter domainAuditLog.FilterOptions) ([]domainAuditLog.AuditLog, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domainAuditLog.AuditLog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAuditLogQueryRepository) ListByUser(ctx context.Context, userID uint, page, limit int) ([]domainAuditLog.AuditLog, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	return args.Get(0).([]domainAuditLog.AuditLog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAuditLogQueryRepository) ListByResource(ctx context.Context, resource string, page, limit int) ([]domainAuditLog.AuditLog, int64, error) {
+	args := m.Called(ctx, resource, page, limit)
+	return args.Get(0).([]domainAuditLog.AuditLog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAuditLogQueryRepository) ListByAction(ctx context.Context, action string, page, limit int) ([]domainAuditLog.AuditLog, int64, error) {
+	args := m.Called(ctx, action, page, limit)
+	return args.Get(0).([]domainAuditLog.AuditLog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAuditLogQueryRepository) Count(ctx context.Context, filter domainAuditLog.FilterOptions) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAuditLogQueryRepository) Search(ctx context.Context, keyword string, page, limit int) ([]domainAuditLog.AuditLog, int64, error) {
+	args := m.Called(ctx, keyword, page, limit)
+	return args.Get(0).([]domainAuditLog.AuditLog), args.Get(1).(int64), args.Error(2)
+}
+
+// MockDataExportCommandRepository 个人数据导出写仓储 Mock
+type MockDataExportCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockDataExportCommandRepository) Create(ctx context.Context, export *domainUser.DataExport) error {
+	args := m.Called(ctx, export)
+	if args.Error(0) == nil && export.ID == 0 {
+		export.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *MockDataExportCommandRepository) Save(ctx context.Context, export *domainUser.DataExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockDataExportCommandRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domainUser.DataExport, error) {
+	args := m.Called(ctx, staleBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportCommandRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDataExportCommandRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// MockDataExportQueryRepository 个人数据导出读仓储 Mock
+type MockDataExportQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockDataExportQueryRepository) GetByID(ctx context.Context, id uint) (*domainUser.DataExport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportQueryRepository) FindActiveByUserID(ctx context.Context, userID uint) (*domainUser.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.DataExport), args.Error(1)
+}
+
+// MockPATCommandRepository 个人访问令牌写仓储 Mock
+type MockPATCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockPATCommandRepository) Create(ctx context.Context, pat *domainPAT.PersonalAccessToken) error {
+	args := m.Called(ctx, pat)
+	return args.Error(0)
+}
+
+func (m *MockPATCommandRepository) Update(ctx context.Context, pat *domainPAT.PersonalAccessToken) error {
+	args := m.Called(ctx, pat)
+	return args.Error(0)
+}
+
+func (m *MockPATCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPATCommandRepository) Disable(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPATCommandRepository) Enable(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPATCommandRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockPATCommandRepository) CleanupExpired(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// MockPATQueryRepository 个人访问令牌读仓储 Mock
+type MockPATQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockPATQueryRepository) FindByToken(ctx context.Context, tokenHash string) (*domainPAT.PersonalAccessToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainPAT.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockPATQueryRepository) FindByID(ctx context.Context, id uint) (*domainPAT.PersonalAccessToken, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainPAT.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockPATQueryRepository) FindByPrefix(ctx context.Context, prefix string) (*domainPAT.PersonalAccessToken, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainPAT.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockPATQueryRepository) ListByUser(ctx context.Context, userID uint) ([]*domainPAT.PersonalAccessToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainPAT.PersonalAccessToken), args.Error(1)
+}
+
+// MockTwoFACommandRepository 2FA 配置写仓储 Mock
+type MockTwoFACommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFACommandRepository) CreateOrUpdate(ctx context.Context, twoFA *domainTwoFA.TwoFA) error {
+	args := m.Called(ctx, twoFA)
+	return args.Error(0)
+}
+
+func (m *MockTwoFACommandRepository) Delete(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// MockTwoFAChannelCommandRepository 2FA 验证通道写仓储 Mock
+type MockTwoFAChannelCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFAChannelCommandRepository) Save(ctx context.Context, channel *domainTwoFA.Channel) error {
+	args := m.Called(ctx, channel)
+	return args.Error(0)
+}
+
+func (m *MockTwoFAChannelCommandRepository) Delete(ctx context.Context, userID uint, method domainTwoFA.Method) error {
+	args := m.Called(ctx, userID, method)
+	return args.Error(0)
+}
+
+func (m *MockTwoFAChannelCommandRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// MockStatusHistoryCommandRepository 用户状态历史写仓储 Mock
+type MockStatusHistoryCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatusHistoryCommandRepository) Create(ctx context.Context, change *domainUser.StatusChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+// MockStatusHistoryQueryRepository 用户状态历史读仓储 Mock
+type MockStatusHistoryQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatusHistoryQueryRepository) ListByUser(ctx context.Context, userID uint, offset, limit int) ([]*domainUser.StatusChange, int64, error) {
+	args := m.Called(ctx, userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domainUser.StatusChange), args.Get(1).(int64), args.Error(2)
+}
+
+// MockAttributeDefinitionCommandRepository 自定义属性定义写仓储 Mock
+type MockAttributeDefinitionCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttributeDefinitionCommandRepository) Create(ctx context.Context, def *domainUser.AttributeDefinition) error {
+	args := m.Called(ctx, def)
+	return args.Error(0)
+}
+
+func (m *MockAttributeDefinitionCommandRepository) Update(ctx context.Context, def *domainUser.AttributeDefinition) error {
+	args := m.Called(ctx, def)
+	return args.Error(0)
+}
+
+func (m *MockAttributeDefinitionCommandRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockAttributeDefinitionQueryRepository 自定义属性定义读仓储 Mock
+type MockAttributeDefinitionQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttributeDefinitionQueryRepository) GetByID(ctx context.Context, id uint) (*domainUser.AttributeDefinition, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainUser.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockAttributeDefinitionQueryRepository) ExistsByKey(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAttributeDefinitionQueryRepository) List(ctx context.Context) ([]*domainUser.AttributeDefinition, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainUser.AttributeDefinition), args.Error(1)
+}
+
+// newTestAttributeRepo 构造返回指定属性定义的读仓储 Mock
+func newTestAttributeRepo(defs ...*domainUser.AttributeDefinition) *MockAttributeDefinitionQueryRepository {
+	repo := new(MockAttributeDefinitionQueryRepository)
+	repo.On("List", mock.Anything).Return(defs, nil).Maybe()
+	return repo
+}
+
+// newTestRoleRepo 构造包含指定角色的读仓储 Mock，有效权限即角色自身权限
+func newTestRoleRepo(roles ...*domainRole.Role) *MockRoleQueryRepository {
+	repo := new(MockRoleQueryRepository)
+	for _, r := range roles {
+		repo.On("FindByID", mock.Anything, r.ID).Return(r, nil).Maybe()
+		repo.On("GetEffectivePermissions", mock.Anything, r.ID).Return(r.Permissions, nil).Maybe()
+	}
+	return repo
+}
+
+// ============================================================
+// MockSettingQueryRepository
+// ============================================================
+
+type MockSettingQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettingQueryRepository) FindByID(ctx context.Context, id uint) (*domainSetting.Setting, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindByKey(ctx context.Context, key string) (*domainSetting.Setting, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindByKeys(ctx context.Context, keys []string) ([]*domainSetting.Setting, error) {
+	args := m.Called(ctx, keys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindByCategory(ctx context.Context, category string) ([]*domainSetting.Setting, error) {
+	args := m.Called(ctx, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainSetting.Setting), args.Error(1)
+}
+
+func (m *MockSettingQueryRepository) FindAll(ctx context.Context) ([]*domainSetting.Setting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domainSetting.Setting), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// 据此解析 ABAC 策略的用户管理权限
+const (
+	PermissionRead   = "admin:users:read"   // 查看用户列表
+	PermissionUpdate = "admin:users:update" // 更新用户、重置密码、变更状态、审批与分配角色
+	PermissionExport = "admin:users:export" // 导出用户
+)
+
+// ErrAccessDenied 重新导出策略拒绝错误供 Adapters 层使用
+var ErrAccessDenied = policy.ErrAccessDenied
+
+// authorize 检查操作者的 ABAC 策略是否允许对目标用户执行 permission
+func authorize(ctx context.Context, resolver policy.Resolver, actorID uint, permission string, target *user.User) error {
+	decision, err := policy.Resolve(ctx, resolver, actorID, permission)
+	if err != nil {
+		return fmt.Errorf("failed to resolve policy: %w", err)
+	}
+	if !decision.Allows(target.PolicyAttributes()) {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+// policyFilter 将操作者的 ABAC 策略转换为用户列表过滤条件
+func policyFilter(ctx context.Context, resolver policy.Resolver, actorID uint, permission string) (*policy.Filter, error) {
+	decision, err := policy.Resolve(ctx, resolver, actorID, permission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve policy: %w", err)
+	}
+	filter, err := decision.Filter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build policy filter: %w", err)
+	}
+	return &filter, nil
+}
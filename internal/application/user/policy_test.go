@@ -0,0 +1,118 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// newDepartmentDecision 返回仅允许操作本部门（sales）用户的策略决策
+func newDepartmentDecision(t *testing.T) *policy.Decision {
+	t.Helper()
+	sameDepartment, err := policy.Parse("resource.department == subject.department")
+	require.NoError(t, err)
+	return &policy.Decision{
+		Subject:  policy.Attributes{"id": uint(1), "department": "sales"},
+		Policies: []*policy.Policy{sameDepartment},
+	}
+}
+
+func TestUserCommands_PolicyDenied(t *testing.T) {
+	tests := []struct {
+		name   string
+		handle func(*MockUserCommandRepository, *MockUserQueryRepository, policy.Resolver) error
+	}{
+		{
+			name: "重置密码",
+			handle: func(cmdRepo *MockUserCommandRepository, qryRepo *MockUserQueryRepository, resolver policy.Resolver) error {
+				h := NewResetPasswordHandler(cmdRepo, qryRepo, resolver, new(MockAuthService))
+				return h.Handle(context.Background(), ResetPasswordCommand{UserID: 2, NewPassword: "Temp123456", ActorID: 1})
+			},
+		},
+		{
+			name: "分配角色",
+			handle: func(cmdRepo *MockUserCommandRepository, qryRepo *MockUserQueryRepository, resolver policy.Resolver) error {
+				h := NewAssignRolesHandler(cmdRepo, qryRepo, resolver, nil)
+				return h.Handle(context.Background(), AssignRolesCommand{UserID: 2, RoleIDs: []uint{1}, ActorID: 1})
+			},
+		},
+		{
+			name: "变更状态",
+			handle: func(cmdRepo *MockUserCommandRepository, qryRepo *MockUserQueryRepository, resolver policy.Resolver) error {
+				h := NewChangeUserStatusHandler(cmdRepo, qryRepo, new(MockStatusHistoryCommandRepository), new(MockPATCommandRepository), resolver, nil)
+				_, err := h.Handle(context.Background(), ChangeUserStatusCommand{UserID: 2, OperatorID: 1, Status: user.StatusBanned})
+				return err
+			},
+		},
+		{
+			name: "审批注册",
+			handle: func(cmdRepo *MockUserCommandRepository, qryRepo *MockUserQueryRepository, resolver policy.Resolver) error {
+				h := NewApproveUserHandler(cmdRepo, qryRepo, resolver)
+				return h.Handle(context.Background(), ApproveUserCommand{UserID: 2, ActorID: 1})
+			},
+		},
+		{
+			name: "授予角色",
+			handle: func(_ *MockUserCommandRepository, qryRepo *MockUserQueryRepository, resolver policy.Resolver) error {
+				h := NewGrantRoleHandler(qryRepo, new(MockRoleQueryRepository), new(MockRoleAssignmentCommandRepository), resolver, nil)
+				_, err := h.Handle(context.Background(), GrantRoleCommand{UserID: 2, RoleID: 1, ActorID: 1})
+				return err
+			},
+		},
+		{
+			name: "撤销角色",
+			handle: func(cmdRepo *MockUserCommandRepository, qryRepo *MockUserQueryRepository, resolver policy.Resolver) error {
+				h := NewRevokeRoleHandler(cmdRepo, qryRepo, resolver, nil)
+				return h.Handle(context.Background(), RevokeRoleCommand{UserID: 2, RoleID: 1, ActorID: 1})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: 目标用户属于其他部门，仓储写方法未设置期望，被调用即失败
+			mockCmdRepo := new(MockUserCommandRepository)
+			mockQryRepo := new(MockUserQueryRepository)
+			mockResolver := new(MockPolicyResolver)
+			mockQryRepo.On("GetByID", mock.Anything, uint(2)).Return(&user.User{
+				ID: 2, Username: "member", Department: "hr", Status: user.StatusInactive,
+			}, nil)
+			mockResolver.On("Resolve", mock.Anything, uint(1), PermissionUpdate).Return(newDepartmentDecision(t), nil)
+
+			// Act
+			err := tt.handle(mockCmdRepo, mockQryRepo, mockResolver)
+
+			// Assert
+			require.ErrorIs(t, err, ErrAccessDenied)
+			mockResolver.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListUsersHandler_Handle_Policy(t *testing.T) {
+	// Arrange
+	mockQryRepo := new(MockUserQueryRepository)
+	mockResolver := new(MockPolicyResolver)
+	mockResolver.On("Resolve", mock.Anything, uint(1), PermissionRead).Return(newDepartmentDecision(t), nil)
+
+	var captured user.ListCriteria
+	mockQryRepo.On("ListByCriteria", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { captured = args.Get(1).(user.ListCriteria) }).
+		Return([]*user.User{}, nil)
+
+	handler := NewListUsersHandler(mockQryRepo, newTestAttributeRepo(), mockResolver)
+
+	// Act
+	_, err := handler.Handle(context.Background(), ListUsersQuery{Page: 1, Limit: 10, ActorID: 1})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, captured.Policy)
+	assert.Equal(t, [][]policy.Predicate{{{Field: "department", Op: policy.OpEqual, Value: "sales"}}}, captured.Policy.Alternatives)
+}
@@ -0,0 +1,26 @@
+package user
+
+// 导出文件格式
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatXLSX   = "xlsx"
+)
+
+// DefaultExportChunkSize 导出时每批从数据库读取的用户数
+const DefaultExportChunkSize = 500
+
+// ExportUsersQuery 导出用户查询
+//
+// Filter 复用 ListUsersQuery 的过滤条件，其中的分页、排序、游标与统计字段被忽略，
+// 导出始终按 ID 键集分页；操作者信息用于写入 ActionExport 审计日志。
+type ExportUsersQuery struct {
+	Filter    ListUsersQuery
+	Format    string // 导出格式，仅记录到审计日志
+	ChunkSize int    // 每批读取数量，为空时使用 DefaultExportChunkSize
+
+	ActorID   uint
+	ActorName string
+	IPAddress string
+	UserAgent string
+}
@@ -0,0 +1,144 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ExportUsersHandler 导出用户查询处理器
+//
+// 以键集分页按 ID 顺序分批读取用户并逐行交给调用方写出，内存占用与总用户数无关。
+type ExportUsersHandler struct {
+	userQueryRepo   user.QueryRepository
+	attributeRepo   user.AttributeDefinitionQueryRepository
+	policyResolver  policy.Resolver
+	auditLogHandler *auditlog.CreateLogHandler
+}
+
+// NewExportUsersHandler 创建导出用户查询处理器
+func NewExportUsersHandler(
+	userQueryRepo user.QueryRepository,
+	attributeRepo user.AttributeDefinitionQueryRepository,
+	policyResolver policy.Resolver,
+	auditLogHandler *auditlog.CreateLogHandler,
+) *ExportUsersHandler {
+	return &ExportUsersHandler{
+		userQueryRepo:   userQueryRepo,
+		attributeRepo:   attributeRepo,
+		policyResolver:  policyResolver,
+		auditLogHandler: auditLogHandler,
+	}
+}
+
+// Handle 分批读取用户并对每个用户调用 emit，emit 返回错误时中止导出
+// 只导出操作者 ABAC 策略允许导出的用户
+// 过滤条件无效时返回 ErrInvalidListCriteria 且不写审计日志；
+// 开始读取后无论成功与否都会写入一条 ActionExport 审计日志
+func (h *ExportUsersHandler) Handle(ctx context.Context, query ExportUsersQuery, emit func(*UserExportDTO) error) (*ExportUsersResultDTO, error) {
+	schema, err := loadAttributeSchema(ctx, h.attributeRepo)
+	if err != nil {
+		return nil, err
+	}
+	criteria, err := filterCriteria(query.Filter, schema)
+	if err != nil {
+		return nil, err
+	}
+	if criteria.Policy, err = policyFilter(ctx, h.policyResolver, query.ActorID, PermissionExport); err != nil {
+		return nil, err
+	}
+	criteria.Limit = query.ChunkSize
+	if criteria.Limit <= 0 {
+		criteria.Limit = DefaultExportChunkSize
+	}
+
+	result := &ExportUsersResultDTO{}
+	err = h.stream(ctx, criteria, func(dto *UserExportDTO) error {
+		if err := emit(dto); err != nil {
+			return err
+		}
+		result.Count++
+		return nil
+	})
+	h.audit(ctx, query, result.Count, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// stream 按 ID 键集分页遍历匹配的用户（criteria 不含排序字段，按 ID 升序）
+func (h *ExportUsersHandler) stream(ctx context.Context, criteria user.ListCriteria, emit func(*UserExportDTO) error) error {
+	chunkSize := criteria.Limit
+	for {
+		users, err := h.userQueryRepo.ListByCriteria(ctx, criteria)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			if err := emit(ToUserExportDTO(u)); err != nil {
+				return err
+			}
+		}
+		if len(users) < chunkSize {
+			return nil
+		}
+		criteria.After = criteria.CursorFor(users[len(users)-1])
+	}
+}
+
+// audit 记录导出审计日志，写入失败不影响导出结果
+func (h *ExportUsersHandler) audit(ctx context.Context, query ExportUsersQuery, count int, exportErr error) {
+	if h.auditLogHandler == nil {
+		return
+	}
+
+	status := domainAuditLog.StatusSuccess
+	details := map[string]any{"format": query.Format, "filter": exportFilterDetails(query.Filter), "count": count}
+	if exportErr != nil {
+		status = domainAuditLog.StatusFailed
+		details["error"] = exportErr.Error()
+	}
+	detailsJSON, _ := json.Marshal(details)
+
+	_ = h.auditLogHandler.Handle(context.WithoutCancel(ctx), auditlog.CreateLogCommand{
+		UserID:    query.ActorID,
+		Username:  query.ActorName,
+		Action:    domainAuditLog.ActionExport,
+		Resource:  "users",
+		IPAddress: query.IPAddress,
+		UserAgent: query.UserAgent,
+		Details:   string(detailsJSON),
+		Status:    status,
+	})
+}
+
+// exportFilterDetails 提取导出使用的过滤条件（仅非空项），记录到审计日志
+func exportFilterDetails(f ListUsersQuery) map[string]any {
+	details := map[string]any{}
+	set := func(key string, value any, present bool) {
+		if present {
+			details[key] = value
+		}
+	}
+	set("search", f.Search, f.Search != "")
+	set("statuses", f.Statuses, len(f.Statuses) > 0)
+	set("role_ids", f.RoleIDs, len(f.RoleIDs) > 0)
+	set("created_from", f.CreatedFrom, f.CreatedFrom != nil)
+	set("created_to", f.CreatedTo, f.CreatedTo != nil)
+	set("updated_from", f.UpdatedFrom, f.UpdatedFrom != nil)
+	set("updated_to", f.UpdatedTo, f.UpdatedTo != nil)
+	set("twofa_enabled", f.TwoFAEnabled, f.TwoFAEnabled != nil)
+	set("last_login_from", f.LastLoginFrom, f.LastLoginFrom != nil)
+	set("last_login_to", f.LastLoginTo, f.LastLoginTo != nil)
+	set("never_logged_in", f.NeverLoggedIn, f.NeverLoggedIn)
+	set("login_count_min", f.LoginCountMin, f.LoginCountMin != nil)
+	set("login_count_max", f.LoginCountMax, f.LoginCountMax != nil)
+	set("attributes", f.Attributes, len(f.Attributes) > 0)
+	return details
+}
@@ -0,0 +1,127 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
+	domainAuditLog "github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	domainRole "github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// afterID 匹配按 ID 键集分页、位于指定 ID 之后且携带列表过滤条件的查询条件（0 表示第一批）
+func afterID(id uint) any {
+	return mock.MatchedBy(func(c domainUser.ListCriteria) bool {
+		if c.Keyword != "ex" || c.Limit != 2 || len(c.Sort) != 0 || c.Offset != 0 {
+			return false
+		}
+		if !assert.ObjectsAreEqual([]string{"active"}, c.Statuses) || !assert.ObjectsAreEqual([]uint{4}, c.RoleIDs) {
+			return false
+		}
+		if id == 0 {
+			return c.After == nil
+		}
+		return c.After != nil && len(c.After.Values) == 1 && c.After.Values[0] == id
+	})
+}
+
+func TestExportUsersHandler_Handle(t *testing.T) {
+	query := ExportUsersQuery{
+		// 分页与排序字段被忽略，导出始终按 ID 键集分页
+		Filter:    ListUsersQuery{Search: "ex", Statuses: []string{"active"}, RoleIDs: []uint{4}, Page: 3, Sort: "-username"},
+		Format:    ExportFormatCSV,
+		ChunkSize: 2,
+		ActorID:   1,
+		ActorName: "admin",
+	}
+
+	t.Run("按键集分页分批读取并记录审计日志", func(t *testing.T) {
+		userQry := new(MockUserQueryRepository)
+		auditRepo := new(MockAuditLogCommandRepository)
+
+		userQry.On("ListByCriteria", mock.Anything, afterID(0)).Return([]*domainUser.User{
+			{ID: 3, Username: "alice", Roles: []domainRole.Role{{Name: "viewer"}, {Name: "editor"}}},
+			{ID: 7, Username: "bob"},
+		}, nil).Once()
+		userQry.On("ListByCriteria", mock.Anything, afterID(7)).Return([]*domainUser.User{
+			{ID: 9, Username: "carol"},
+		}, nil).Once()
+
+		var logged *domainAuditLog.AuditLog
+		auditRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			logged = args.Get(1).(*domainAuditLog.AuditLog)
+		}).Return(nil).Once()
+
+		handler := NewExportUsersHandler(userQry, newTestAttributeRepo(), nil, auditlog.NewCreateLogHandler(auditRepo))
+
+		var exported []*UserExportDTO
+		result, err := handler.Handle(context.Background(), query, func(dto *UserExportDTO) error {
+			exported = append(exported, dto)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Count)
+		require.Len(t, exported, 3)
+		assert.Equal(t, []string{"viewer", "editor"}, exported[0].Roles)
+		assert.Empty(t, exported[1].Roles)
+		userQry.AssertExpectations(t)
+
+		require.NotNil(t, logged)
+		assert.Equal(t, domainAuditLog.ActionExport, logged.Action)
+		assert.Equal(t, "users", logged.Resource)
+		assert.Equal(t, domainAuditLog.StatusSuccess, logged.Status)
+		var details map[string]any
+		require.NoError(t, json.Unmarshal([]byte(logged.Details), &details))
+		assert.InDelta(t, 3, details["count"], 0)
+		assert.Equal(t, "csv", details["format"])
+		assert.Equal(t, map[string]any{"search": "ex", "statuses": []any{"active"}, "role_ids": []any{float64(4)}}, details["filter"])
+	})
+
+	t.Run("写出失败时中止并记录失败审计", func(t *testing.T) {
+		userQry := new(MockUserQueryRepository)
+		auditRepo := new(MockAuditLogCommandRepository)
+
+		userQry.On("ListByCriteria", mock.Anything, afterID(0)).Return([]*domainUser.User{
+			{ID: 3, Username: "alice"}, {ID: 7, Username: "bob"},
+		}, nil).Once()
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *domainAuditLog.AuditLog) bool {
+			return l.Status == domainAuditLog.StatusFailed
+		})).Return(nil).Once()
+
+		handler := NewExportUsersHandler(userQry, newTestAttributeRepo(), nil, auditlog.NewCreateLogHandler(auditRepo))
+		writeErr := errors.New("client disconnected")
+
+		_, err := handler.Handle(context.Background(), query, func(dto *UserExportDTO) error {
+			if dto.ID == 7 {
+				return writeErr
+			}
+			return nil
+		})
+
+		require.ErrorIs(t, err, writeErr)
+		auditRepo.AssertExpectations(t)
+		userQry.AssertNumberOfCalls(t, "ListByCriteria", 1)
+	})
+
+	t.Run("过滤条件无效时不读取也不记录审计", func(t *testing.T) {
+		userQry := new(MockUserQueryRepository)
+		auditRepo := new(MockAuditLogCommandRepository)
+		handler := NewExportUsersHandler(userQry, newTestAttributeRepo(), nil, auditlog.NewCreateLogHandler(auditRepo))
+
+		invalid := query
+		invalid.Filter.Attributes = map[string]string{"unknown": "x"}
+		_, err := handler.Handle(context.Background(), invalid, func(*UserExportDTO) error { return nil })
+
+		require.ErrorIs(t, err, domainUser.ErrInvalidListCriteria)
+		userQry.AssertNotCalled(t, "ListByCriteria", mock.Anything, mock.Anything)
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,7 @@
+package user
+
+// GetAvatarQuery 获取用户头像图片查询
+type GetAvatarQuery struct {
+	UserID uint
+	Size   int // 期望边长，返回不小于该值的最小已保存尺寸；为空时使用 DefaultAvatarSize
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/imaging"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/storage"
+)
+
+// GetAvatarHandler 获取用户头像图片查询处理器
+type GetAvatarHandler struct {
+	userQueryRepo user.QueryRepository
+	store         storage.Storage
+}
+
+// NewGetAvatarHandler 创建获取用户头像图片查询处理器
+func NewGetAvatarHandler(userQueryRepo user.QueryRepository, store storage.Storage) *GetAvatarHandler {
+	return &GetAvatarHandler{
+		userQueryRepo: userQueryRepo,
+		store:         store,
+	}
+}
+
+// Handle 返回已上传的头像；未上传头像（含外部地址）时生成 identicon，不跳转到用户提供的地址
+func (h *GetAvatarHandler) Handle(ctx context.Context, query GetAvatarQuery) (*AvatarImageDTO, error) {
+	u, err := h.userQueryRepo.GetByID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+	size := avatarSize(query.Size)
+
+	if u.HasUploadedAvatar() {
+		content, err := h.store.Get(ctx, avatarKey(u.ID, u.AvatarVersion, size))
+		if err == nil {
+			return &AvatarImageDTO{
+				Content:     content,
+				ContentType: imaging.ContentTypePNG,
+				ETag:        fmt.Sprintf(`"%s-%d"`, u.AvatarVersion, size),
+				Version:     u.AvatarVersion,
+			}, nil
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("failed to read avatar: %w", err)
+		}
+		// 文件丢失时回退为默认头像
+		slog.Warn("Avatar file missing, falling back to identicon", "user_id", u.ID, "version", u.AvatarVersion)
+	}
+
+	content, err := imaging.Identicon(identiconSeed(u.ID), size)
+	if err != nil {
+		return nil, err
+	}
+	return &AvatarImageDTO{
+		Content:     content,
+		ContentType: imaging.ContentTypePNG,
+		ETag:        fmt.Sprintf(`"identicon-%d-%d"`, u.ID, size),
+		Generated:   true,
+	}, nil
+}
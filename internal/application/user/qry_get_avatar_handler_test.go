@@ -0,0 +1,72 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/storage"
+)
+
+func TestGetAvatarHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("返回已上传的头像", func(t *testing.T) {
+		store, err := storage.NewLocalStorage(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, store.Put(ctx, avatarKey(1, "abc", 64), []byte("png-64"), "image/png"))
+
+		userQry := new(MockUserQueryRepository)
+		userQry.On("GetByID", mock.Anything, uint(1)).Return(&domainUser.User{ID: 1, Avatar: avatarURL(1, "abc"), AvatarVersion: "abc"}, nil)
+
+		result, err := NewGetAvatarHandler(userQry, store).Handle(ctx, GetAvatarQuery{UserID: 1, Size: 50})
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("png-64"), result.Content)
+		assert.Equal(t, "abc", result.Version)
+		assert.Equal(t, `"abc-64"`, result.ETag)
+		assert.False(t, result.Generated)
+	})
+
+	t.Run("外部头像地址不跳转，返回 identicon", func(t *testing.T) {
+		userQry := new(MockUserQueryRepository)
+		userQry.On("GetByID", mock.Anything, uint(2)).Return(&domainUser.User{ID: 2, Avatar: "https://evil.example.com/"}, nil)
+
+		result, err := NewGetAvatarHandler(userQry, nil).Handle(ctx, GetAvatarQuery{UserID: 2})
+
+		require.NoError(t, err)
+		assert.True(t, result.Generated)
+		assert.Equal(t, `"identicon-2-128"`, result.ETag)
+	})
+
+	t.Run("未设置头像时生成确定性的 identicon", func(t *testing.T) {
+		userQry := new(MockUserQueryRepository)
+		userQry.On("GetByID", mock.Anything, uint(3)).Return(&domainUser.User{ID: 3}, nil)
+		handler := NewGetAvatarHandler(userQry, nil)
+
+		first, err := handler.Handle(ctx, GetAvatarQuery{UserID: 3})
+		require.NoError(t, err)
+		second, err := handler.Handle(ctx, GetAvatarQuery{UserID: 3})
+		require.NoError(t, err)
+
+		assert.True(t, first.Generated)
+		assert.Equal(t, first.Content, second.Content)
+		assert.Equal(t, `"identicon-3-128"`, first.ETag)
+	})
+
+	t.Run("文件丢失时回退为 identicon", func(t *testing.T) {
+		store, err := storage.NewLocalStorage(t.TempDir())
+		require.NoError(t, err)
+		userQry := new(MockUserQueryRepository)
+		userQry.On("GetByID", mock.Anything, uint(4)).Return(&domainUser.User{ID: 4, Avatar: avatarURL(4, "gone"), AvatarVersion: "gone"}, nil)
+
+		result, err := NewGetAvatarHandler(userQry, store).Handle(ctx, GetAvatarQuery{UserID: 4})
+
+		require.NoError(t, err)
+		assert.True(t, result.Generated)
+	})
+}
@@ -0,0 +1,7 @@
+package user
+
+// GetDataExportQuery 获取本人数据导出任务查询
+type GetDataExportQuery struct {
+	ID     uint
+	UserID uint // 当前用户，只能访问本人的导出任务
+}
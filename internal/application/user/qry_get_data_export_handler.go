@@ -0,0 +1,57 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// GetDataExportHandler 获取数据导出任务查询处理器
+type GetDataExportHandler struct {
+	dataExportQueryRepo user.DataExportQueryRepository
+}
+
+// NewGetDataExportHandler 创建获取数据导出任务查询处理器
+func NewGetDataExportHandler(dataExportQueryRepo user.DataExportQueryRepository) *GetDataExportHandler {
+	return &GetDataExportHandler{dataExportQueryRepo: dataExportQueryRepo}
+}
+
+// Handle 获取导出任务状态
+func (h *GetDataExportHandler) Handle(ctx context.Context, query GetDataExportQuery) (*DataExportDTO, error) {
+	export, err := h.find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return ToDataExportDTO(export, time.Now()), nil
+}
+
+// Download 获取可下载的归档，未生成时返回 ErrDataExportNotReady，过期时返回 ErrDataExportExpired
+func (h *GetDataExportHandler) Download(ctx context.Context, query GetDataExportQuery) (*DataExportArchiveDTO, error) {
+	export, err := h.find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if export.Status != user.DataExportCompleted {
+		return nil, user.ErrDataExportNotReady
+	}
+	if !export.IsDownloadable(now) {
+		return nil, user.ErrDataExportExpired
+	}
+
+	return &DataExportArchiveDTO{FileName: export.FileName, Content: export.Archive}, nil
+}
+
+// find 获取导出任务，他人的任务按不存在处理
+func (h *GetDataExportHandler) find(ctx context.Context, query GetDataExportQuery) (*user.DataExport, error) {
+	export, err := h.dataExportQueryRepo.GetByID(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	if export.UserID != query.UserID {
+		return nil, user.ErrDataExportNotFound
+	}
+	return export, nil
+}
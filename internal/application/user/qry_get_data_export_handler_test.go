@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainUser "github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+func TestGetDataExportHandler_Download(t *testing.T) {
+	completed := func(userID uint, finishedAt time.Time) *domainUser.DataExport {
+		e := domainUser.NewDataExport(userID)
+		e.ID = 3
+		e.Complete("alice.zip", []byte("zip"), finishedAt, DataExportRetention)
+		return e
+	}
+
+	tests := []struct {
+		name    string
+		export  *domainUser.DataExport
+		wantErr error
+	}{
+		{name: "下载归档", export: completed(1, time.Now())},
+		{name: "他人的导出按不存在处理", export: completed(2, time.Now()), wantErr: domainUser.ErrDataExportNotFound},
+		{name: "尚未生成", export: domainUser.NewDataExport(1), wantErr: domainUser.ErrDataExportNotReady},
+		{name: "已过期", export: completed(1, time.Now().Add(-DataExportRetention-time.Minute)), wantErr: domainUser.ErrDataExportExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQryRepo := new(MockDataExportQueryRepository)
+			mockQryRepo.On("GetByID", mock.Anything, uint(3)).Return(tt.export, nil)
+
+			handler := NewGetDataExportHandler(mockQryRepo)
+			archive, err := handler.Download(context.Background(), GetDataExportQuery{ID: 3, UserID: 1})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "alice.zip", archive.FileName)
+			assert.Equal(t, []byte("zip"), archive.Content)
+		})
+	}
+}
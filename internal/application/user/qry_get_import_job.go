@@ -0,0 +1,7 @@
+package user
+
+// GetImportJobQuery 获取导入任务查询
+type GetImportJobQuery struct {
+	ID             uint
+	IncludeResults bool // 是否包含逐行结果（下载报告时使用）
+}
@@ -0,0 +1,26 @@
+package user
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// GetImportJobHandler 获取导入任务查询处理器
+type GetImportJobHandler struct {
+	importJobQueryRepo user.ImportJobQueryRepository
+}
+
+// NewGetImportJobHandler 创建获取导入任务查询处理器
+func NewGetImportJobHandler(importJobQueryRepo user.ImportJobQueryRepository) *GetImportJobHandler {
+	return &GetImportJobHandler{importJobQueryRepo: importJobQueryRepo}
+}
+
+// Handle 处理获取导入任务查询
+func (h *GetImportJobHandler) Handle(ctx context.Context, query GetImportJobQuery) (*ImportJobDTO, error) {
+	job, err := h.importJobQueryRepo.GetByID(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	return ToImportJobDTO(job, query.IncludeResults), nil
+}
@@ -4,4 +4,5 @@ package user
 type GetUserQuery struct {
 	UserID    uint
 	WithRoles bool // 是否包含角色信息
+	SelfView  bool // 用户查看自己的资料，隐藏仅管理员可见的自定义属性
 }
@@ -9,12 +9,14 @@ import (
 // GetUserHandler 获取用户查询处理器
 type GetUserHandler struct {
 	userQueryRepo user.QueryRepository
+	attributeRepo user.AttributeDefinitionQueryRepository
 }
 
 // NewGetUserHandler 创建获取用户查询处理器
-func NewGetUserHandler(userQueryRepo user.QueryRepository) *GetUserHandler {
+func NewGetUserHandler(userQueryRepo user.QueryRepository, attributeRepo user.AttributeDefinitionQueryRepository) *GetUserHandler {
 	return &GetUserHandler{
 		userQueryRepo: userQueryRepo,
+		attributeRepo: attributeRepo,
 	}
 }
 
@@ -35,15 +37,16 @@ func (h *GetUserHandler) Handle(ctx context.Context, query GetUserQuery) (*UserW
 
 	// 转换为 DTO
 	response := &UserWithRolesDTO{
-		ID:        u.ID,
-		Username:  u.Username,
-		Email:     u.Email,
-		FullName:  u.FullName,
-		Avatar:    u.Avatar,
-		Bio:       u.Bio,
-		Status:    u.Status,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:         u.ID,
+		Username:   u.Username,
+		Email:      u.Email,
+		FullName:   u.FullName,
+		Avatar:     u.Avatar,
+		Bio:        u.Bio,
+		Status:     u.Status,
+		Department: u.Department,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
 	}
 
 	if query.WithRoles {
@@ -59,5 +62,13 @@ func (h *GetUserHandler) Handle(ctx context.Context, query GetUserQuery) (*UserW
 		response.Roles = roles
 	}
 
+	if len(u.Attributes) > 0 {
+		schema, err := loadAttributeSchema(ctx, h.attributeRepo)
+		if err != nil {
+			return nil, err
+		}
+		response.Attributes = schema.Values(u.Attributes, !query.SelfView)
+	}
+
 	return response, nil
 }
@@ -64,7 +64,7 @@ func TestGetUserHandler_Handle_Success(t *testing.T) {
 				mockQryRepo.On("GetByID", mock.Anything, tt.query.UserID).Return(tt.user, nil)
 			}
 
-			handler := NewGetUserHandler(mockQryRepo)
+			handler := NewGetUserHandler(mockQryRepo, newTestAttributeRepo())
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.query)
@@ -116,7 +116,7 @@ func TestGetUserHandler_Handle_Error(t *testing.T) {
 			mockQryRepo := new(MockUserQueryRepository)
 			tt.setupMocks(mockQryRepo)
 
-			handler := NewGetUserHandler(mockQryRepo)
+			handler := NewGetUserHandler(mockQryRepo, newTestAttributeRepo())
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.query)
@@ -154,7 +154,7 @@ func TestGetUserHandler_Handle_RoleConversion(t *testing.T) {
 
 	mockQryRepo.On("GetByIDWithRoles", mock.Anything, uint(1)).Return(user, nil)
 
-	handler := NewGetUserHandler(mockQryRepo)
+	handler := NewGetUserHandler(mockQryRepo, newTestAttributeRepo())
 	result, err := handler.Handle(context.Background(), GetUserQuery{UserID: 1, WithRoles: true})
 
 	require.NoError(t, err)
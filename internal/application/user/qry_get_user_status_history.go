@@ -0,0 +1,17 @@
+package user
+
+// DefaultStatusHistoryLimit 未指定每页数量时的默认值
+const DefaultStatusHistoryLimit = 20
+
+// GetUserStatusHistoryQuery 用户状态变更历史查询
+type GetUserStatusHistoryQuery struct {
+	UserID uint
+	Page   int
+	Limit  int
+}
+
+// GetOffset 计算数据库查询偏移量
+func (q GetUserStatusHistoryQuery) GetOffset() int {
+	page := max(q.Page, 1)
+	return (page - 1) * q.Limit
+}
@@ -0,0 +1,38 @@
+package user
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// GetUserStatusHistoryHandler 用户状态变更历史查询处理器
+type GetUserStatusHistoryHandler struct {
+	userQueryRepo    user.QueryRepository
+	historyQueryRepo user.StatusHistoryQueryRepository
+}
+
+// NewGetUserStatusHistoryHandler 创建用户状态变更历史查询处理器
+func NewGetUserStatusHistoryHandler(userQueryRepo user.QueryRepository, historyQueryRepo user.StatusHistoryQueryRepository) *GetUserStatusHistoryHandler {
+	return &GetUserStatusHistoryHandler{
+		userQueryRepo:    userQueryRepo,
+		historyQueryRepo: historyQueryRepo,
+	}
+}
+
+// Handle 处理用户状态变更历史查询（按时间倒序）
+func (h *GetUserStatusHistoryHandler) Handle(ctx context.Context, query GetUserStatusHistoryQuery) (*StatusHistoryDTO, error) {
+	if _, err := h.userQueryRepo.GetByID(ctx, query.UserID); err != nil {
+		return nil, err
+	}
+	if query.Limit <= 0 {
+		query.Limit = DefaultStatusHistoryLimit
+	}
+
+	changes, total, err := h.historyQueryRepo.ListByUser(ctx, query.UserID, query.GetOffset(), query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatusHistoryDTO{Changes: ToStatusChangeDTOs(changes), Total: total}, nil
+}
@@ -0,0 +1,6 @@
+package user
+
+// ListAttributeDefinitionsQuery 获取自定义属性定义列表查询
+type ListAttributeDefinitionsQuery struct {
+	UserVisibleOnly bool // 仅返回用户本人可见的属性（供个人资料页使用）
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"context"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ListAttributeDefinitionsHandler 获取自定义属性定义列表查询处理器
+type ListAttributeDefinitionsHandler struct {
+	queryRepo user.AttributeDefinitionQueryRepository
+}
+
+// NewListAttributeDefinitionsHandler 创建获取自定义属性定义列表查询处理器
+func NewListAttributeDefinitionsHandler(queryRepo user.AttributeDefinitionQueryRepository) *ListAttributeDefinitionsHandler {
+	return &ListAttributeDefinitionsHandler{
+		queryRepo: queryRepo,
+	}
+}
+
+// Handle 处理获取自定义属性定义列表查询（按排序值排列）
+func (h *ListAttributeDefinitionsHandler) Handle(ctx context.Context, query ListAttributeDefinitionsQuery) ([]*AttributeDefinitionDTO, error) {
+	defs, err := h.queryRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*AttributeDefinitionDTO, 0, len(defs))
+	for _, d := range defs {
+		if query.UserVisibleOnly && !d.VisibleToUser() {
+			continue
+		}
+		result = append(result, ToAttributeDefinitionDTO(d))
+	}
+	return result, nil
+}
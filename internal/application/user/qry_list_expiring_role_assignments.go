@@ -0,0 +1,19 @@
+package user
+
+import "time"
+
+// DefaultExpiringWindow 即将到期授权的默认查询窗口
+const DefaultExpiringWindow = 7 * 24 * time.Hour
+
+// ListExpiringRoleAssignmentsQuery 即将到期的角色授权查询
+type ListExpiringRoleAssignmentsQuery struct {
+	Within time.Duration // 查询窗口，为空时使用 DefaultExpiringWindow
+	Page   int
+	Limit  int
+}
+
+// GetOffset 计算分页偏移量
+func (q ListExpiringRoleAssignmentsQuery) GetOffset() int {
+	page := max(q.Page, 1)
+	return (page - 1) * q.Limit
+}
@@ -0,0 +1,39 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ListExpiringRoleAssignmentsHandler 即将到期的角色授权查询处理器
+type ListExpiringRoleAssignmentsHandler struct {
+	assignmentQueryRepo user.RoleAssignmentQueryRepository
+}
+
+// NewListExpiringRoleAssignmentsHandler 创建即将到期的角色授权查询处理器
+func NewListExpiringRoleAssignmentsHandler(assignmentQueryRepo user.RoleAssignmentQueryRepository) *ListExpiringRoleAssignmentsHandler {
+	return &ListExpiringRoleAssignmentsHandler{
+		assignmentQueryRepo: assignmentQueryRepo,
+	}
+}
+
+// Handle 处理即将到期的角色授权查询，按到期时间升序
+func (h *ListExpiringRoleAssignmentsHandler) Handle(ctx context.Context, query ListExpiringRoleAssignmentsQuery) (*RoleAssignmentListDTO, error) {
+	within := query.Within
+	if within <= 0 {
+		within = DefaultExpiringWindow
+	}
+
+	now := time.Now()
+	assignments, total, err := h.assignmentQueryRepo.ListExpiring(ctx, now, now.Add(within), query.GetOffset(), query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RoleAssignmentListDTO{
+		Assignments: ToRoleAssignmentDTOs(assignments, now),
+		Total:       total,
+	}, nil
+}
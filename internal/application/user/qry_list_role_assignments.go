@@ -0,0 +1,6 @@
+package user
+
+// ListRoleAssignmentsQuery 用户角色授权列表查询（包含计划中和已过期未清理的授权）
+type ListRoleAssignmentsQuery struct {
+	UserID uint
+}
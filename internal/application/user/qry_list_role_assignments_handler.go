@@ -0,0 +1,36 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ListRoleAssignmentsHandler 用户角色授权列表查询处理器
+type ListRoleAssignmentsHandler struct {
+	userQueryRepo       user.QueryRepository
+	assignmentQueryRepo user.RoleAssignmentQueryRepository
+}
+
+// NewListRoleAssignmentsHandler 创建用户角色授权列表查询处理器
+func NewListRoleAssignmentsHandler(userQueryRepo user.QueryRepository, assignmentQueryRepo user.RoleAssignmentQueryRepository) *ListRoleAssignmentsHandler {
+	return &ListRoleAssignmentsHandler{
+		userQueryRepo:       userQueryRepo,
+		assignmentQueryRepo: assignmentQueryRepo,
+	}
+}
+
+// Handle 处理用户角色授权列表查询
+func (h *ListRoleAssignmentsHandler) Handle(ctx context.Context, query ListRoleAssignmentsQuery) ([]*RoleAssignmentDTO, error) {
+	if _, err := h.userQueryRepo.GetByID(ctx, query.UserID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := h.assignmentQueryRepo.ListByUser(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToRoleAssignmentDTOs(assignments, time.Now()), nil
+}
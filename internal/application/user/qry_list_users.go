@@ -1,10 +1,42 @@
 package user
 
+import "time"
+
+// DefaultListUsersLimit 未指定每页数量时的默认值
+const DefaultListUsersLimit = 20
+
 // ListUsersQuery 获取用户列表查询
+//
+// 过滤条件之间为 AND 关系。设置 Cursor 时按游标继续翻页（忽略 Page），
+// Cursor 由上一次查询结果的 NextCursor 提供，且必须使用相同的 Sort。
 type ListUsersQuery struct {
 	Page   int
 	Limit  int
-	Search string // 搜索关键词（用户名或邮箱）
+	Search string // 搜索关键词（用户名、邮箱或全名）
+
+	Statuses []string // 状态（任一匹配）
+	RoleIDs  []uint   // 拥有任一角色（含用户组角色）
+
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+
+	TwoFAEnabled *bool // 是否启用了第二因素
+
+	LastLoginFrom *time.Time
+	LastLoginTo   *time.Time
+	NeverLoggedIn bool
+	LoginCountMin *int64 // 登录次数下限（含）
+	LoginCountMax *int64 // 登录次数上限（含）
+
+	Attributes map[string]string // 自定义属性精确匹配，键为属性键
+
+	Sort      string // 排序表达式，如 "status,-last_login_at"（"-" 表示降序）
+	Cursor    string // 不透明游标
+	WithTotal bool   // 是否统计总数（大表上统计代价较高）
+
+	ActorID uint // 查询者，用于解析 ABAC 策略
 }
 
 // GetOffset 计算数据库查询偏移量
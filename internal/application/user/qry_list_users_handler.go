@@ -3,82 +3,126 @@ package user
 import (
 	"context"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
 // ListUsersHandler 获取用户列表查询处理器
 type ListUsersHandler struct {
-	userQueryRepo user.QueryRepository
+	userQueryRepo  user.QueryRepository
+	attributeRepo  user.AttributeDefinitionQueryRepository
+	policyResolver policy.Resolver
 }
 
 // NewListUsersHandler 创建获取用户列表查询处理器
-func NewListUsersHandler(userQueryRepo user.QueryRepository) *ListUsersHandler {
+func NewListUsersHandler(
+	userQueryRepo user.QueryRepository,
+	attributeRepo user.AttributeDefinitionQueryRepository,
+	policyResolver policy.Resolver,
+) *ListUsersHandler {
 	return &ListUsersHandler{
-		userQueryRepo: userQueryRepo,
+		userQueryRepo:  userQueryRepo,
+		attributeRepo:  attributeRepo,
+		policyResolver: policyResolver,
 	}
 }
 
 // Handle 处理获取用户列表查询
+//
+// 多查询一条记录用于判断是否还有下一页，避免为此统计总数；
+// 仅在 WithTotal 时统计总数。只返回查询者 ABAC 策略允许查看的用户。
 func (h *ListUsersHandler) Handle(ctx context.Context, query ListUsersQuery) (*UserListDTO, error) {
-	// 根据是否有搜索关键词选择不同的查询方法
-	users, total, err := h.fetchUsers(ctx, query)
+	schema, err := loadAttributeSchema(ctx, h.attributeRepo)
 	if err != nil {
 		return nil, err
 	}
+	criteria, err := h.buildCriteria(query, schema)
+	if err != nil {
+		return nil, err
+	}
+	if criteria.Policy, err = policyFilter(ctx, h.policyResolver, query.ActorID, PermissionRead); err != nil {
+		return nil, err
+	}
 
-	// 转换为 DTO
-	userResponses := make([]*UserDTO, 0, len(users))
-	for _, u := range users {
-		userResponses = append(userResponses, &UserDTO{
-			ID:        u.ID,
-			Username:  u.Username,
-			Email:     u.Email,
-			FullName:  u.FullName,
-			Avatar:    u.Avatar,
-			Bio:       u.Bio,
-			Status:    u.Status,
-			CreatedAt: u.CreatedAt,
-			UpdatedAt: u.UpdatedAt,
-		})
+	limit := criteria.Limit
+	criteria.Limit = limit + 1
+	users, err := h.userQueryRepo.ListByCriteria(ctx, criteria)
+	if err != nil {
+		return nil, err
 	}
+	criteria.Limit = limit
 
-	return &UserListDTO{
-		Users: userResponses,
-		Total: total,
-	}, nil
-}
+	result := &UserListDTO{Users: make([]*UserDTO, 0, min(len(users), limit))}
+	if len(users) > limit {
+		users = users[:limit]
+		result.HasMore = true
+		result.NextCursor = encodeCursor(query.Sort, criteria.CursorFor(users[len(users)-1]))
+	}
+	for _, u := range users {
+		dto := ToUserDTO(u)
+		dto.Attributes = schema.Values(u.Attributes, true)
+		result.Users = append(result.Users, dto)
+	}
 
-// fetchUsers 根据查询条件获取用户列表和总数
-func (h *ListUsersHandler) fetchUsers(ctx context.Context, query ListUsersQuery) ([]*user.User, int64, error) {
-	offset := query.GetOffset()
-	if query.Search != "" {
-		return h.searchUsers(ctx, query.Search, offset, query.Limit)
+	if query.WithTotal {
+		total, err := h.userQueryRepo.CountByCriteria(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+		result.Total = total
 	}
-	return h.listAllUsers(ctx, offset, query.Limit)
+
+	return result, nil
 }
 
-// searchUsers 搜索用户
-func (h *ListUsersHandler) searchUsers(ctx context.Context, keyword string, offset, limit int) ([]*user.User, int64, error) {
-	users, err := h.userQueryRepo.Search(ctx, keyword, offset, limit)
+// buildCriteria 将查询转换为仓储查询条件，解析排序表达式、游标与属性过滤
+func (h *ListUsersHandler) buildCriteria(query ListUsersQuery, schema user.AttributeSchema) (user.ListCriteria, error) {
+	criteria, err := filterCriteria(query, schema)
 	if err != nil {
-		return nil, 0, err
+		return user.ListCriteria{}, err
 	}
-	total, err := h.userQueryRepo.CountBySearch(ctx, keyword)
-	if err != nil {
-		return nil, 0, err
+	if criteria.Sort, err = user.ParseSort(query.Sort); err != nil {
+		return user.ListCriteria{}, err
 	}
-	return users, total, nil
-}
 
-// listAllUsers 获取所有用户列表
-func (h *ListUsersHandler) listAllUsers(ctx context.Context, offset, limit int) ([]*user.User, int64, error) {
-	users, err := h.userQueryRepo.List(ctx, offset, limit)
-	if err != nil {
-		return nil, 0, err
+	criteria.Limit = query.Limit
+	if criteria.Limit <= 0 {
+		criteria.Limit = DefaultListUsersLimit
+	}
+
+	if query.Cursor != "" {
+		criteria.After, err = decodeCursor(query.Cursor, query.Sort, criteria.OrderBy())
+		if err != nil {
+			return user.ListCriteria{}, err
+		}
+	} else {
+		criteria.Offset = (max(query.Page, 1) - 1) * criteria.Limit
 	}
-	total, err := h.userQueryRepo.Count(ctx)
+
+	return criteria, criteria.Validate()
+}
+
+// filterCriteria 将查询的过滤条件转换为仓储查询条件（不含排序与分页），列表与导出共用
+func filterCriteria(query ListUsersQuery, schema user.AttributeSchema) (user.ListCriteria, error) {
+	attributes, err := schema.NormalizeFilters(query.Attributes)
 	if err != nil {
-		return nil, 0, err
+		return user.ListCriteria{}, err
 	}
-	return users, total, nil
+
+	return user.ListCriteria{
+		Keyword:       query.Search,
+		Statuses:      query.Statuses,
+		RoleIDs:       query.RoleIDs,
+		CreatedFrom:   query.CreatedFrom,
+		CreatedTo:     query.CreatedTo,
+		UpdatedFrom:   query.UpdatedFrom,
+		UpdatedTo:     query.UpdatedTo,
+		TwoFAEnabled:  query.TwoFAEnabled,
+		LastLoginFrom: query.LastLoginFrom,
+		LastLoginTo:   query.LastLoginTo,
+		NeverLoggedIn: query.NeverLoggedIn,
+		LoginCountMin: query.LoginCountMin,
+		LoginCountMax: query.LoginCountMax,
+		Attributes:    attributes,
+	}, nil
 }
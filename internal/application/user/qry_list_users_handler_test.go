@@ -17,14 +17,17 @@ func TestListUsersHandler_Handle_Success(t *testing.T) {
 	now := time.Now()
 
 	tests := []struct {
-		name  string
-		query ListUsersQuery
-		users []*domainUser.User
-		total int64
+		name        string
+		query       ListUsersQuery
+		offset      int
+		users       []*domainUser.User
+		total       int64
+		wantHasMore bool
 	}{
 		{
-			name:  "列出第一页用户",
-			query: ListUsersQuery{Page: 1, Limit: 10},
+			name:   "列出第一页用户",
+			query:  ListUsersQuery{Page: 1, Limit: 10, WithTotal: true},
+			offset: 0,
 			users: []*domainUser.User{
 				{ID: 1, Username: "user1", Email: "user1@example.com", Status: "active", CreatedAt: now, UpdatedAt: now},
 				{ID: 2, Username: "user2", Email: "user2@example.com", Status: "active", CreatedAt: now, UpdatedAt: now},
@@ -32,18 +35,22 @@ func TestListUsersHandler_Handle_Success(t *testing.T) {
 			total: 2,
 		},
 		{
-			name:  "空用户列表",
-			query: ListUsersQuery{Page: 1, Limit: 10},
-			users: []*domainUser.User{},
-			total: 0,
+			name:   "空用户列表",
+			query:  ListUsersQuery{Page: 1, Limit: 10, WithTotal: true},
+			offset: 0,
+			users:  []*domainUser.User{},
+			total:  0,
 		},
 		{
-			name:  "分页查询",
-			query: ListUsersQuery{Page: 2, Limit: 5},
+			name:   "分页查询",
+			query:  ListUsersQuery{Page: 2, Limit: 1, WithTotal: true},
+			offset: 1,
 			users: []*domainUser.User{
-				{ID: 6, Username: "user6", Email: "user6@example.com", Status: "active", CreatedAt: now, UpdatedAt: now},
+				{ID: 2, Username: "user2", Email: "user2@example.com", Status: "active", CreatedAt: now, UpdatedAt: now},
+				{ID: 3, Username: "user3", Email: "user3@example.com", Status: "active", CreatedAt: now, UpdatedAt: now},
 			},
-			total: 6,
+			total:       3,
+			wantHasMore: true,
 		},
 	}
 
@@ -51,12 +58,13 @@ func TestListUsersHandler_Handle_Success(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
 			mockQryRepo := new(MockUserQueryRepository)
-			offset := (tt.query.Page - 1) * tt.query.Limit
 
-			mockQryRepo.On("List", mock.Anything, offset, tt.query.Limit).Return(tt.users, nil)
-			mockQryRepo.On("Count", mock.Anything).Return(tt.total, nil)
+			mockQryRepo.On("ListByCriteria", mock.Anything, mock.MatchedBy(func(c domainUser.ListCriteria) bool {
+				return c.Offset == tt.offset && c.Limit == tt.query.Limit+1 && c.After == nil
+			})).Return(tt.users, nil)
+			mockQryRepo.On("CountByCriteria", mock.Anything, mock.Anything).Return(tt.total, nil)
 
-			handler := NewListUsersHandler(mockQryRepo)
+			handler := NewListUsersHandler(mockQryRepo, newTestAttributeRepo(), nil)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.query)
@@ -65,14 +73,17 @@ func TestListUsersHandler_Handle_Success(t *testing.T) {
 			require.NoError(t, err)
 			assert.NotNil(t, result)
 			assert.Equal(t, tt.total, result.Total)
-			assert.Len(t, result.Users, len(tt.users))
+			assert.Equal(t, tt.wantHasMore, result.HasMore)
+			assert.Len(t, result.Users, min(len(tt.users), tt.query.Limit))
 			mockQryRepo.AssertExpectations(t)
 		})
 	}
 }
 
-func TestListUsersHandler_Handle_WithSearch(t *testing.T) {
+func TestListUsersHandler_Handle_WithFilters(t *testing.T) {
 	now := time.Now()
+	enabled := true
+	createdFrom := now.Add(-24 * time.Hour)
 
 	// Arrange
 	mockQryRepo := new(MockUserQueryRepository)
@@ -81,25 +92,84 @@ func TestListUsersHandler_Handle_WithSearch(t *testing.T) {
 		{ID: 1, Username: "john", Email: "john@example.com", Status: "active", CreatedAt: now, UpdatedAt: now},
 	}
 
-	mockQryRepo.On("Search", mock.Anything, "john", 0, 10).Return(users, nil)
-	mockQryRepo.On("CountBySearch", mock.Anything, "john").Return(int64(1), nil)
+	mockQryRepo.On("ListByCriteria", mock.Anything, mock.MatchedBy(func(c domainUser.ListCriteria) bool {
+		return c.Keyword == "john" &&
+			assert.ObjectsAreEqual([]string{"active", "pending"}, c.Statuses) &&
+			assert.ObjectsAreEqual([]uint{2}, c.RoleIDs) &&
+			c.CreatedFrom == &createdFrom && c.TwoFAEnabled == &enabled &&
+			assert.ObjectsAreEqual([]domainUser.SortField{{Field: "status"}, {Field: "created_at", Desc: true}}, c.Sort)
+	})).Return(users, nil)
 
-	handler := NewListUsersHandler(mockQryRepo)
+	handler := NewListUsersHandler(mockQryRepo, newTestAttributeRepo(), nil)
 
 	// Act
 	result, err := handler.Handle(context.Background(), ListUsersQuery{
-		Page:   1,
-		Limit:  10,
-		Search: "john",
+		Page:         1,
+		Limit:        10,
+		Search:       "john",
+		Statuses:     []string{"active", "pending"},
+		RoleIDs:      []uint{2},
+		CreatedFrom:  &createdFrom,
+		TwoFAEnabled: &enabled,
+		Sort:         "status,-created_at",
 	})
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, int64(1), result.Total)
+	assert.Equal(t, int64(0), result.Total)
+	assert.False(t, result.HasMore)
+	assert.Empty(t, result.NextCursor)
 	assert.Len(t, result.Users, 1)
 	assert.Equal(t, "john", result.Users[0].Username)
 	mockQryRepo.AssertExpectations(t)
+	mockQryRepo.AssertNotCalled(t, "CountByCriteria", mock.Anything, mock.Anything)
+}
+
+func TestListUsersHandler_Handle_Cursor(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	mockQryRepo := new(MockUserQueryRepository)
+
+	mockQryRepo.On("ListByCriteria", mock.Anything, mock.MatchedBy(func(c domainUser.ListCriteria) bool {
+		return c.After == nil
+	})).Return([]*domainUser.User{
+		{ID: 5, Username: "a", CreatedAt: created.Add(time.Hour)},
+		{ID: 4, Username: "b", CreatedAt: created},
+		{ID: 3, Username: "c", CreatedAt: created},
+	}, nil).Once()
+
+	mockQryRepo.On("ListByCriteria", mock.Anything, mock.MatchedBy(func(c domainUser.ListCriteria) bool {
+		return c.After != nil && c.Offset == 0 &&
+			assert.ObjectsAreEqual([]any{created, uint(4)}, c.After.Values)
+	})).Return([]*domainUser.User{
+		{ID: 3, Username: "c", CreatedAt: created},
+	}, nil).Once()
+
+	handler := NewListUsersHandler(mockQryRepo, newTestAttributeRepo(), nil)
+
+	first, err := handler.Handle(context.Background(), ListUsersQuery{Limit: 2, Sort: "-created_at"})
+	require.NoError(t, err)
+	assert.True(t, first.HasMore)
+	require.NotEmpty(t, first.NextCursor)
+	assert.Len(t, first.Users, 2)
+
+	// 游标优先于页码
+	second, err := handler.Handle(context.Background(), ListUsersQuery{Page: 9, Limit: 2, Sort: "-created_at", Cursor: first.NextCursor})
+	require.NoError(t, err)
+	assert.False(t, second.HasMore)
+	require.Len(t, second.Users, 1)
+	assert.Equal(t, uint(3), second.Users[0].ID)
+	mockQryRepo.AssertExpectations(t)
+
+	t.Run("游标与排序不一致", func(t *testing.T) {
+		_, err := handler.Handle(context.Background(), ListUsersQuery{Limit: 2, Sort: "username", Cursor: first.NextCursor})
+		require.ErrorIs(t, err, domainUser.ErrInvalidListCriteria)
+	})
+
+	t.Run("无效游标", func(t *testing.T) {
+		_, err := handler.Handle(context.Background(), ListUsersQuery{Limit: 2, Sort: "-created_at", Cursor: "not-a-cursor"})
+		require.ErrorIs(t, err, domainUser.ErrInvalidListCriteria)
+	})
 }
 
 func TestListUsersHandler_Handle_Error(t *testing.T) {
@@ -113,35 +183,30 @@ func TestListUsersHandler_Handle_Error(t *testing.T) {
 			name:  "列表查询数据库错误",
 			query: ListUsersQuery{Page: 1, Limit: 10},
 			setupMocks: func(qryRepo *MockUserQueryRepository) {
-				qryRepo.On("List", mock.Anything, 0, 10).Return(nil, errors.New("database error"))
+				qryRepo.On("ListByCriteria", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
 			},
 			wantErr: "database error",
 		},
 		{
 			name:  "计数数据库错误",
-			query: ListUsersQuery{Page: 1, Limit: 10},
+			query: ListUsersQuery{Page: 1, Limit: 10, WithTotal: true},
 			setupMocks: func(qryRepo *MockUserQueryRepository) {
-				qryRepo.On("List", mock.Anything, 0, 10).Return([]*domainUser.User{}, nil)
-				qryRepo.On("Count", mock.Anything).Return(int64(0), errors.New("count error"))
+				qryRepo.On("ListByCriteria", mock.Anything, mock.Anything).Return([]*domainUser.User{}, nil)
+				qryRepo.On("CountByCriteria", mock.Anything, mock.Anything).Return(int64(0), errors.New("count error"))
 			},
 			wantErr: "count error",
 		},
 		{
-			name:  "搜索数据库错误",
-			query: ListUsersQuery{Page: 1, Limit: 10, Search: "test"},
-			setupMocks: func(qryRepo *MockUserQueryRepository) {
-				qryRepo.On("Search", mock.Anything, "test", 0, 10).Return(nil, errors.New("search error"))
-			},
-			wantErr: "search error",
+			name:       "不支持的排序字段",
+			query:      ListUsersQuery{Page: 1, Limit: 10, Sort: "password"},
+			setupMocks: func(*MockUserQueryRepository) {},
+			wantErr:    "unsupported sort field",
 		},
 		{
-			name:  "搜索计数错误",
-			query: ListUsersQuery{Page: 1, Limit: 10, Search: "john"},
-			setupMocks: func(qryRepo *MockUserQueryRepository) {
-				qryRepo.On("Search", mock.Anything, "john", 0, 10).Return([]*domainUser.User{}, nil)
-				qryRepo.On("CountBySearch", mock.Anything, "john").Return(int64(0), errors.New("count search error"))
-			},
-			wantErr: "count search error",
+			name:       "重复的排序字段",
+			query:      ListUsersQuery{Page: 1, Limit: 10, Sort: "email,-email"},
+			setupMocks: func(*MockUserQueryRepository) {},
+			wantErr:    "duplicate sort field",
 		},
 	}
 
@@ -151,7 +216,7 @@ func TestListUsersHandler_Handle_Error(t *testing.T) {
 			mockQryRepo := new(MockUserQueryRepository)
 			tt.setupMocks(mockQryRepo)
 
-			handler := NewListUsersHandler(mockQryRepo)
+			handler := NewListUsersHandler(mockQryRepo, newTestAttributeRepo(), nil)
 
 			// Act
 			result, err := handler.Handle(context.Background(), tt.query)
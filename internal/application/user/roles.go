@@ -0,0 +1,32 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// checkInitialRoles 校验创建或邀请用户时直接分配的角色
+//
+// 角色须存在；含管理权限（按含继承在内的有效权限判断）的角色须经角色分配接口授予，
+// 不能在创建用户时绕过双人审批。
+func checkInitialRoles(ctx context.Context, roleQueryRepo role.QueryRepository, roleIDs []uint) error {
+	for _, id := range roleIDs {
+		r, err := roleQueryRepo.FindByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to find role %d: %w", id, err)
+		}
+		if r == nil {
+			return fmt.Errorf("%w: %d", user.ErrRoleNotFound, id)
+		}
+		if r.InheritedPermissions, err = roleQueryRepo.GetEffectivePermissions(ctx, id); err != nil {
+			return fmt.Errorf("failed to get permissions of role %d: %w", id, err)
+		}
+		if r.IsPrivileged() {
+			return fmt.Errorf("%w: %s", user.ErrPrivilegedRoleAssignment, r.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// editorRoles 不含管理权限、可在创建用户时直接分配的角色
+var editorRoles = []*role.Role{
+	{ID: 1, Name: "editor", Permissions: []role.Permission{{Code: "user:profile:read"}}},
+	{ID: 2, Name: "viewer"},
+}
+
+func TestCheckInitialRoles(t *testing.T) {
+	roles := append([]*role.Role{
+		{ID: 3, Name: role.AdminRoleName},
+		{ID: 4, Name: "user-manager", Permissions: []role.Permission{{Code: "admin:users:update"}}},
+	}, editorRoles...)
+
+	tests := []struct {
+		name    string
+		roleIDs []uint
+		wantErr error
+	}{
+		{name: "普通角色", roleIDs: []uint{1, 2}},
+		{name: "未分配角色", roleIDs: nil},
+		{name: "管理员角色", roleIDs: []uint{1, 3}, wantErr: user.ErrPrivilegedRoleAssignment},
+		{name: "含管理权限的角色", roleIDs: []uint{4}, wantErr: user.ErrPrivilegedRoleAssignment},
+		{name: "角色不存在", roleIDs: []uint{99}, wantErr: user.ErrRoleNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRoleRepo(roles...)
+			repo.On("FindByID", mock.Anything, uint(99)).Return(nil, nil).Maybe()
+
+			err := checkInitialRoles(context.Background(), repo, tt.roleIDs)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCreateUserHandler_Handle_PrivilegedRole(t *testing.T) {
+	// Arrange: 角色校验在创建用户之前，被拒绝时不写入任何数据
+	mockCmdRepo := new(MockUserCommandRepository)
+	mockQryRepo := new(MockUserQueryRepository)
+	mockAuthService := new(MockAuthService)
+	mockAuthService.On("ValidatePasswordPolicy", mock.Anything, "password123").Return(nil)
+	mockQryRepo.On("ExistsByUsername", mock.Anything, "newuser").Return(false, nil)
+	mockQryRepo.On("ExistsByEmail", mock.Anything, "new@example.com").Return(false, nil)
+
+	handler := NewCreateUserHandler(
+		mockCmdRepo, mockQryRepo, newTestRoleRepo(&role.Role{ID: 3, Name: role.AdminRoleName}), newTestAttributeRepo(), mockAuthService,
+	)
+
+	// Act
+	result, err := handler.Handle(context.Background(), CreateUserCommand{
+		Username: "newuser",
+		Email:    "new@example.com",
+		Password: "password123",
+		RoleIDs:  []uint{3},
+	})
+
+	// Assert
+	require.ErrorIs(t, err, ErrPrivilegedRoleAssignment)
+	assert.Nil(t, result)
+	mockCmdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -24,8 +24,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
 	"github.com/lwmacct/251117-go-ddd-template/internal/config"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/persistence"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/scheduler"
 )
 
 // ContainerOptions 容器初始化选项
@@ -55,6 +57,12 @@ type Container struct {
 	Handlers *HandlersModule       // HTTP Handlers：所有 HTTP Handlers
 
 	Router *gin.Engine
+
+	// ScheduledJobs 周期性后台任务，由 worker 命令通过 scheduler 运行
+	ScheduledJobs []scheduler.Job
+
+	// PermissionRegistry 路由声明的权限注册表，在路由初始化时填充
+	PermissionRegistry *role.PermissionRegistry
 }
 
 // NewContainer 创建并初始化模块化依赖注入容器
@@ -65,8 +73,9 @@ type Container struct {
 //  3. Services（依赖 Repos, Redis）
 //  4. UseCases（依赖 Repos, Services, EventBus）
 //  5. EventHandlers（依赖 EventBus, Repos, Services）
-//  6. Handlers（依赖 UseCases, Services）
-//  7. Router（依赖 Handlers, Services）
+//  6. ScheduledJobs（依赖 UseCases）
+//  7. Handlers（依赖 UseCases, Services）
+//  8. Router（依赖 Handlers, Services）
 func NewContainer(ctx context.Context, cfg *config.Config, opts *ContainerOptions) (*Container, error) {
 	if opts == nil {
 		opts = DefaultOptions()
@@ -93,17 +102,25 @@ func NewContainer(ctx context.Context, cfg *config.Config, opts *ContainerOption
 	// 5. 事件处理器
 	initEventHandlers(c.Infra.EventBus, c.Repos, c.Services)
 
-	// 6. HTTP Handlers
-	c.Handlers = newHandlersModule(cfg, c.Infra, c.UseCases)
+	// 6. 后台任务
+	c.ScheduledJobs = newScheduledJobs(c.UseCases)
 
-	// 7. 路由
-	c.Router = newRouter(cfg, c.Infra, c.Services, c.UseCases, c.Handlers)
+	// 7. HTTP Handlers（授权解释需读取路由注册表，注册表在路由初始化时填充）
+	c.PermissionRegistry = role.NewPermissionRegistry()
+	c.Handlers = newHandlersModule(cfg, c.Infra, c.UseCases, c.PermissionRegistry)
+
+	// 8. 路由
+	c.Router = newRouter(cfg, c.Infra, c.Services, c.UseCases, c.Handlers, c.PermissionRegistry)
 
 	return c, nil
 }
 
 // Close 关闭容器中的所有资源
+// 先刷出待写入的登录活动，再关闭数据库等基础设施
 func (c *Container) Close() error {
+	if c.Services != nil && c.Services.LoginRecorder != nil {
+		c.Services.LoginRecorder.Close()
+	}
 	return c.Infra.Close()
 }
 
@@ -112,12 +129,27 @@ func (c *Container) Close() error {
 func GetAllModels() []any {
 	return []any{
 		&persistence.UserModel{},
+		&persistence.UserRoleModel{},
 		&persistence.RoleModel{},
 		&persistence.PermissionModel{},
+		&persistence.RolePermissionPolicyModel{},
 		&persistence.PersonalAccessTokenModel{},
 		&persistence.AuditLogModel{},
 		&persistence.TwoFAModel{},
+		&persistence.TwoFAChannelModel{},
+		&persistence.InvitationModel{},
+		&persistence.UserImportJobModel{},
+		&persistence.UserDataExportModel{},
+		&persistence.UserStatusChangeModel{},
+		&persistence.UserAttributeDefinitionModel{},
+		&persistence.UserAttributeValueModel{},
 		&persistence.MenuModel{},
 		&persistence.SettingModel{},
+		&persistence.UserSettingModel{},
+		&persistence.OrganizationModel{},
+		&persistence.OrganizationMemberModel{},
+		&persistence.UserGroupModel{},
+		&persistence.UserGroupMemberModel{},
+		&persistence.ChangeRequestModel{},
 	}
 }
@@ -22,14 +22,18 @@ func initEventHandlers(eventBus event.EventBus, repos *RepositoriesModule, servi
 	// 订阅缓存失效事件
 	eventBus.Subscribe("user.role_assigned", cacheHandler)
 	eventBus.Subscribe("user.deleted", cacheHandler)
+	eventBus.Subscribe("user.status_changed", cacheHandler)
 	eventBus.Subscribe("role.permissions_changed", cacheHandler)
+	eventBus.Subscribe("organization.member_changed", cacheHandler)
+	eventBus.Subscribe("group.membership_changed", cacheHandler)
+	eventBus.Subscribe("group.roles_changed", cacheHandler)
 
 	// 订阅审计日志事件（使用通配符订阅所有事件）
 	eventBus.Subscribe("*", auditHandler)
 
 	slog.Info("Event handlers initialized",
 		"handlers", []string{"CacheInvalidationHandler", "AuditLogHandler"},
-		"cache_subscriptions", []string{"user.role_assigned", "user.deleted", "role.permissions_changed"},
+		"cache_subscriptions", []string{"user.role_assigned", "user.deleted", "user.status_changed", "role.permissions_changed", "organization.member_changed", "group.membership_changed", "group.roles_changed"},
 		"audit_subscriptions", []string{"*"},
 	)
 }
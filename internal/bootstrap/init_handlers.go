@@ -2,13 +2,14 @@ package bootstrap
 
 import (
 	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http/handler"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
 	"github.com/lwmacct/251117-go-ddd-template/internal/config"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/health"
 )
 
 // newHandlersModule 初始化 HTTP Handler 模块
 // 依赖：UseCasesModule, InfrastructureModule, config.Config
-func newHandlersModule(cfg *config.Config, infra *InfrastructureModule, useCases *UseCasesModule) *HandlersModule {
+func newHandlersModule(cfg *config.Config, infra *InfrastructureModule, useCases *UseCasesModule, registry *role.PermissionRegistry) *HandlersModule {
 	m := &HandlersModule{}
 
 	// Health Handler
@@ -19,8 +20,10 @@ func newHandlersModule(cfg *config.Config, infra *InfrastructureModule, useCases
 	m.Auth = handler.NewAuthHandler(
 		useCases.Auth.Login,
 		useCases.Auth.Login2FA,
+		useCases.Auth.Send2FACode,
 		useCases.Auth.Register,
 		useCases.Auth.RefreshToken,
+		useCases.Auth.AcceptInvitation,
 	)
 
 	// Captcha Handler
@@ -33,8 +36,13 @@ func newHandlersModule(cfg *config.Config, infra *InfrastructureModule, useCases
 		useCases.User.Delete,
 		useCases.User.AssignRoles,
 		useCases.User.BatchCreate,
+		useCases.User.ResetPassword,
+		useCases.User.Invite,
+		useCases.User.ResendInvite,
+		useCases.User.Approve,
 		useCases.User.Get,
 		useCases.User.List,
+		useCases.Approval.Submit,
 	)
 
 	// User Profile Handler
@@ -42,7 +50,7 @@ func newHandlersModule(cfg *config.Config, infra *InfrastructureModule, useCases
 		useCases.User.Get,
 		useCases.User.Update,
 		useCases.User.ChangePassword,
-		useCases.User.Delete,
+		useCases.User.RequestDeletion,
 	)
 
 	// Role Handler
@@ -54,8 +62,87 @@ func newHandlersModule(cfg *config.Config, infra *InfrastructureModule, useCases
 		useCases.Role.Get,
 		useCases.Role.List,
 		useCases.Role.ListPermissions,
+		useCases.Role.EffectivePermissions,
+		useCases.Role.SetCondition,
+		useCases.Role.ListGrants,
+		useCases.Approval.Submit,
+	)
+
+	// Role Assignment Handler
+	m.RoleAssignment = handler.NewRoleAssignmentHandler(
+		useCases.User.GrantRole,
+		useCases.User.RevokeRole,
+		useCases.User.ListRoleAssignments,
+		useCases.User.ListExpiringAssignments,
+		useCases.Approval.Submit,
+	)
+
+	// User Import Handler
+	m.UserImport = handler.NewUserImportHandler(useCases.User.Import, useCases.User.GetImportJob)
+
+	// User Export Handler
+	m.UserExport = handler.NewUserExportHandler(useCases.User.Export)
+
+	// User Data Export Handler
+	m.UserDataExport = handler.NewUserDataExportHandler(useCases.User.RequestDataExport, useCases.User.GetDataExport)
+
+	// User Avatar Handler
+	m.UserAvatar = handler.NewUserAvatarHandler(useCases.User.UploadAvatar, useCases.User.DeleteAvatar, useCases.User.GetAvatar)
+
+	// User Status Handler
+	m.UserStatus = handler.NewUserStatusHandler(useCases.User.ChangeStatus, useCases.User.StatusHistory)
+
+	// User Attribute Handler
+	m.UserAttribute = handler.NewUserAttributeHandler(
+		useCases.User.CreateAttribute, useCases.User.UpdateAttribute, useCases.User.DeleteAttribute, useCases.User.ListAttributes,
+	)
+
+	// User Preference Handler
+	m.UserPreference = handler.NewUserPreferenceHandler(useCases.Setting.GetPreferences, useCases.Setting.UpdatePreferences)
+
+	// Organization Handler
+	m.Organization = handler.NewOrganizationHandler(
+		useCases.Organization.Create,
+		useCases.Organization.Update,
+		useCases.Organization.Delete,
+		useCases.Organization.AddMember,
+		useCases.Organization.RemoveMember,
+		useCases.Organization.SetMemberRoles,
+		useCases.Organization.Get,
+		useCases.Organization.List,
+		useCases.Organization.ListMembers,
+		useCases.Organization.ListUserOrgs,
+	)
+
+	// Group Handler
+	m.Group = handler.NewGroupHandler(
+		useCases.Group.Create,
+		useCases.Group.Update,
+		useCases.Group.Delete,
+		useCases.Group.AddMembers,
+		useCases.Group.RemoveMembers,
+		useCases.Group.SetRoles,
+		useCases.Group.Import,
+		useCases.Group.Get,
+		useCases.Group.List,
+		useCases.Group.ListMembers,
+		useCases.Approval.Submit,
 	)
 
+	// Change Request Handler
+	m.ChangeRequest = handler.NewChangeRequestHandler(
+		useCases.Approval.Approve,
+		useCases.Approval.Reject,
+		useCases.Approval.Get,
+		useCases.Approval.List,
+	)
+
+	// Trash Handler
+	m.Trash = handler.NewTrashHandler(useCases.Trash.List, useCases.Trash.Restore, useCases.Trash.Purge)
+
+	// Authz Handler
+	m.Authz = handler.NewAuthzHandler(useCases.Authz.Explain, registry)
+
 	// Menu Handler
 	m.Menu = handler.NewMenuHandler(
 		useCases.Menu.Create,
@@ -64,6 +151,7 @@ func newHandlersModule(cfg *config.Config, infra *InfrastructureModule, useCases
 		useCases.Menu.Reorder,
 		useCases.Menu.Get,
 		useCases.Menu.List,
+		useCases.Menu.ListMine,
 	)
 
 	// Setting Handler
@@ -101,6 +189,10 @@ func newHandlersModule(cfg *config.Config, infra *InfrastructureModule, useCases
 		useCases.TwoFA.VerifyEnable,
 		useCases.TwoFA.Disable,
 		useCases.TwoFA.GetStatus,
+		useCases.TwoFA.SetupChannel,
+		useCases.TwoFA.VerifyChannel,
+		useCases.TwoFA.DisableChannel,
+		useCases.TwoFA.ListMethods,
 	)
 
 	// Cache Handler (for demo)
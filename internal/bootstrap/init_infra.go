@@ -7,6 +7,7 @@ import (
 	"github.com/lwmacct/251117-go-ddd-template/internal/config"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/database"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/eventbus"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/persistence"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/redis"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/telemetry"
 )
@@ -45,6 +46,16 @@ func newInfrastructureModule(ctx context.Context, cfg *config.Config, opts *Cont
 	}
 	m.DB = db
 
+	// 注册租户作用域回调：上下文携带组织时自动隔离组织内数据
+	if err = persistence.RegisterTenantScope(db); err != nil {
+		return nil, err
+	}
+
+	// 注册自定义中间表：用户角色关联携带限时授权字段
+	if err = persistence.RegisterJoinTables(db); err != nil {
+		return nil, err
+	}
+
 	// 2. 条件性执行自动迁移
 	if opts.AutoMigrate {
 		slog.Info("Auto-migration enabled, migrating database...")
@@ -0,0 +1,155 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/approval"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/trash"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/scheduler"
+)
+
+// roleAssignmentJobInterval 限时角色授权的检查间隔，决定授权生效/到期的最大延迟
+const roleAssignmentJobInterval = time.Minute
+
+// changeRequestJobInterval 变更请求过期检查间隔
+const changeRequestJobInterval = time.Minute
+
+// userImportJobInterval 用户导入任务检查间隔
+const userImportJobInterval = 10 * time.Second
+
+// accountDeletionJobInterval 到期账号删除的检查间隔
+const accountDeletionJobInterval = time.Minute
+
+// dataExportJobInterval 个人数据导出任务检查间隔
+const dataExportJobInterval = 10 * time.Second
+
+// banExpiryJobInterval 限时封禁的到期检查间隔，决定自动解封的最大延迟
+const banExpiryJobInterval = time.Minute
+
+// trashRetentionJobInterval 回收站保留期清理的检查间隔
+const trashRetentionJobInterval = time.Hour
+
+// inactiveAccountJobInterval 不活跃账号自动停用的检查间隔
+const inactiveAccountJobInterval = time.Hour
+
+// newScheduledJobs 初始化周期性后台任务（由 worker 命令运行）
+// 依赖：UseCasesModule
+func newScheduledJobs(useCases *UseCasesModule) []scheduler.Job {
+	return []scheduler.Job{
+		{
+			Name:     "role_assignments",
+			Interval: roleAssignmentJobInterval,
+			Run: func(ctx context.Context) error {
+				result, err := useCases.User.ProcessRoleAssignments.Handle(ctx, user.ProcessRoleAssignmentsCommand{Now: time.Now()})
+				if err != nil {
+					return err
+				}
+				if result.Activated > 0 || result.Expired > 0 {
+					slog.Info("Processed role assignments", "activated", result.Activated, "expired", result.Expired)
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "change_requests",
+			Interval: changeRequestJobInterval,
+			Run: func(ctx context.Context) error {
+				result, err := useCases.Approval.Expire.Handle(ctx, approval.ExpireChangeRequestsCommand{Now: time.Now()})
+				if err != nil {
+					return err
+				}
+				if result.Expired > 0 {
+					slog.Info("Expired change requests", "expired", result.Expired)
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "user_imports",
+			Interval: userImportJobInterval,
+			Run: func(ctx context.Context) error {
+				result, err := useCases.User.ProcessImportJobs.Handle(ctx, user.ProcessImportJobsCommand{Now: time.Now()})
+				if err != nil {
+					return err
+				}
+				if result.Completed > 0 || result.Failed > 0 {
+					slog.Info("Processed user import jobs", "completed", result.Completed, "failed", result.Failed)
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "account_deletions",
+			Interval: accountDeletionJobInterval,
+			Run: func(ctx context.Context) error {
+				result, err := useCases.User.FinalizeDeletions.Handle(ctx, user.FinalizeAccountDeletionsCommand{Now: time.Now()})
+				if err != nil {
+					return err
+				}
+				if result.Deleted > 0 || result.Failed > 0 {
+					slog.Info("Finalized account deletions", "deleted", result.Deleted, "failed", result.Failed)
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "data_exports",
+			Interval: dataExportJobInterval,
+			Run: func(ctx context.Context) error {
+				result, err := useCases.User.ProcessDataExports.Handle(ctx, user.ProcessDataExportsCommand{Now: time.Now()})
+				if err != nil {
+					return err
+				}
+				if result.Completed > 0 || result.Failed > 0 || result.Purged > 0 {
+					slog.Info("Processed data exports", "completed", result.Completed, "failed", result.Failed, "purged", result.Purged)
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "ban_expiry",
+			Interval: banExpiryJobInterval,
+			Run: func(ctx context.Context) error {
+				result, err := useCases.User.LiftExpiredBans.Handle(ctx, user.LiftExpiredBansCommand{Now: time.Now()})
+				if err != nil {
+					return err
+				}
+				if result.Lifted > 0 || result.Failed > 0 {
+					slog.Info("Lifted expired bans", "lifted", result.Lifted, "failed", result.Failed)
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "trash_retention",
+			Interval: trashRetentionJobInterval,
+			Run: func(ctx context.Context) error {
+				result, err := useCases.Trash.PurgeExpired.Handle(ctx, trash.PurgeExpiredCommand{Now: time.Now()})
+				if err != nil {
+					return err
+				}
+				if result.Purged > 0 || result.Failed > 0 {
+					slog.Info("Purged expired trash items", "purged", result.Purged, "failed", result.Failed)
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "inactive_accounts",
+			Interval: inactiveAccountJobInterval,
+			Run: func(ctx context.Context) error {
+				result, err := useCases.User.DeactivateInactive.Handle(ctx, user.DeactivateInactiveUsersCommand{Now: time.Now()})
+				if err != nil {
+					return err
+				}
+				if result.Deactivated > 0 || result.Failed > 0 {
+					slog.Info("Deactivated inactive users", "deactivated", result.Deactivated, "failed", result.Failed)
+				}
+				return nil
+			},
+		},
+	}
+}
@@ -23,9 +23,16 @@ func newRepositoriesModule(db *gorm.DB) *RepositoriesModule {
 		Setting:    persistence.NewSettingRepositories(db),
 		TwoFA:      persistence.NewTwoFARepositories(db),
 
+		Organization: persistence.NewOrganizationRepositories(db),
+		Group:        persistence.NewGroupRepositories(db),
+		Approval:     persistence.NewApprovalRepositories(db),
+
+		Trash: persistence.NewTrashBins(db),
+
 		// 特殊仓储（内存实现）
 		CaptchaCommand: captchaRepo,
 		CaptchaQuery:   captchaRepo,
+		OTPChallenge:   persistence.NewOTPChallengeMemoryRepository(),
 
 		// 只读仓储
 		StatsQuery: persistence.NewStatsQueryRepository(db),
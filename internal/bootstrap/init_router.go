@@ -4,18 +4,21 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/adapters/http"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
 	"github.com/lwmacct/251117-go-ddd-template/internal/config"
 )
 
 // newRouter 初始化路由
 // 使用 RouterDependencies 参数对象模式，简化依赖传递
-func newRouter(cfg *config.Config, infra *InfrastructureModule, services *ServicesModule, usecases *UseCasesModule, handlers *HandlersModule) *gin.Engine {
+func newRouter(cfg *config.Config, infra *InfrastructureModule, services *ServicesModule, usecases *UseCasesModule, handlers *HandlersModule, registry *role.PermissionRegistry) *gin.Engine {
 	deps := &http.RouterDependencies{
 		Config:                 cfg,
 		RedisClient:            infra.RedisClient,
 		CreateLogHandler:       usecases.AuditLog.CreateLog,
+		ResolveTenantHandler:   usecases.Organization.ResolveTenant,
 		JWTManager:             services.JWT,
 		PATService:             services.PAT,
+		ClientCertService:      services.ClientCert,
 		PermissionCacheService: services.PermissionCache,
 		HealthHandler:          handlers.Health,
 		AuthHandler:            handlers.Auth,
@@ -30,6 +33,20 @@ func newRouter(cfg *config.Config, infra *InfrastructureModule, services *Servic
 		OverviewHandler:        handlers.Overview,
 		TwoFAHandler:           handlers.TwoFA,
 		CacheHandler:           handlers.Cache,
+		OrganizationHandler:    handlers.Organization,
+		GroupHandler:           handlers.Group,
+		ChangeRequestHandler:   handlers.ChangeRequest,
+		RoleAssignmentHandler:  handlers.RoleAssignment,
+		UserImportHandler:      handlers.UserImport,
+		UserExportHandler:      handlers.UserExport,
+		UserDataExportHandler:  handlers.UserDataExport,
+		UserAvatarHandler:      handlers.UserAvatar,
+		UserStatusHandler:      handlers.UserStatus,
+		UserAttributeHandler:   handlers.UserAttribute,
+		UserPreferenceHandler:  handlers.UserPreference,
+		AuthzHandler:           handlers.Authz,
+		TrashHandler:           handlers.Trash,
+		PermissionRegistry:     registry,
 	}
 
 	return http.SetupRouterWithDeps(deps)
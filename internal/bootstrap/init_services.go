@@ -1,11 +1,20 @@
 package bootstrap
 
 import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/lwmacct/251117-go-ddd-template/internal/config"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
 
 	authInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/captcha"
+	ldapInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/ldap"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/mail"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/storage"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/twofa"
 )
 
@@ -19,7 +28,8 @@ func newServicesModule(cfg *config.Config, infra *InfrastructureModule, repos *R
 	tokenGenerator := authInfra.NewTokenGenerator()
 	m.TokenGenerator = tokenGenerator
 	m.LoginSession = authInfra.NewLoginSessionService()
-	m.PermissionCache = authInfra.NewPermissionCacheService(infra.RedisClient, repos.User.Query, cfg.Data.RedisKeyPrefix)
+	m.PermissionCache = authInfra.NewPermissionCacheService(infra.RedisClient, repos.User.Query, repos.Role.Query, repos.Organization.Query, repos.Group.Query, cfg.Data.RedisKeyPrefix)
+	m.PolicyResolver = authInfra.NewPolicyResolver(repos.User.Query, repos.Role.Query, repos.Organization.Query)
 
 	// Domain Services
 	passwordPolicy := auth.DefaultPasswordPolicy()
@@ -28,11 +38,140 @@ func newServicesModule(cfg *config.Config, infra *InfrastructureModule, repos *R
 	// Captcha Service
 	m.Captcha = captcha.NewService()
 
+	// 登录活动记录器（异步批量写入最近登录时间、IP 与登录次数）
+	m.LoginRecorder = authInfra.NewLoginActivityRecorder(repos.User.Command, 0)
+
 	// PAT Service（需要仓储）
-	m.PAT = authInfra.NewPATService(repos.PAT.Command, repos.PAT.Query, repos.User.Query, tokenGenerator)
+	m.PAT = authInfra.NewPATService(repos.PAT.Command, repos.PAT.Query, repos.User.Query, repos.Setting.Query, tokenGenerator, m.LoginRecorder)
+
+	// Client Certificate Service（mTLS 服务账号映射）
+	m.ClientCert = newClientCertService(cfg, repos)
+
+	// 外部身份提供者（LDAP 等）
+	m.IdentityProviders = newIdentityProviders(cfg)
 
 	// TwoFA Service（需要仓储）
 	m.TwoFA = twofa.NewService(repos.TwoFA.Command, repos.TwoFA.Query, repos.User.Query, cfg.Auth.TwoFAIssuer)
 
+	// Mailer（未配置 SMTP 时仅输出日志）
+	m.Mailer = mail.New(mail.Config{
+		Host:     cfg.Mail.SMTPHost,
+		Port:     cfg.Mail.SMTPPort,
+		Username: cfg.Mail.SMTPUsername,
+		Password: cfg.Mail.SMTPPassword,
+		From:     cfg.Mail.From,
+	})
+
+	// OTP Service（邮件/短信二次认证）
+	m.OTP = newOTPService(cfg, repos, m.Mailer)
+
+	// 文件存储（用户头像等）
+	m.Storage = newStorage(cfg)
+
 	return m
 }
+
+// newStorage 初始化文件存储
+// 配置无效时回退到临时目录下的本地存储，避免上传写入错误的位置
+func newStorage(cfg *config.Config) storage.Storage {
+	store, err := storage.New(storage.Config{
+		Driver:      cfg.Storage.Driver,
+		LocalDir:    cfg.Storage.LocalDir,
+		S3Endpoint:  cfg.Storage.S3Endpoint,
+		S3Region:    cfg.Storage.S3Region,
+		S3Bucket:    cfg.Storage.S3Bucket,
+		S3AccessKey: cfg.Storage.S3AccessKey,
+		S3SecretKey: cfg.Storage.S3SecretKey,
+		S3PathStyle: cfg.Storage.S3PathStyle,
+	})
+	if err == nil {
+		return store
+	}
+
+	fallbackDir := filepath.Join(os.TempDir(), "go-ddd-template-uploads")
+	slog.Error("Invalid storage configuration, falling back to local storage", "error", err, "dir", fallbackDir)
+	fallback, fallbackErr := storage.NewLocalStorage(fallbackDir)
+	if fallbackErr != nil {
+		slog.Error("Failed to initialize fallback storage", "error", fallbackErr)
+		return nil
+	}
+	return fallback
+}
+
+// newClientCertService 初始化 mTLS 客户端证书认证服务
+// 映射配置无效时禁用证书认证，避免错误配置放行请求
+func newClientCertService(cfg *config.Config, repos *RepositoriesModule) *authInfra.ClientCertService {
+	svc, err := authInfra.NewClientCertService(repos.User.Query, cfg.Auth.ClientCertAccounts)
+	if err != nil {
+		slog.Error("Invalid client certificate account mapping, mTLS authentication disabled", "error", err)
+		svc, _ = authInfra.NewClientCertService(repos.User.Query, "")
+	}
+	return svc
+}
+
+// newIdentityProviders 初始化 auth.identity-providers 中启用的外部身份提供者
+func newIdentityProviders(cfg *config.Config) []auth.IdentityProvider {
+	var providers []auth.IdentityProvider
+	for _, name := range identityProviderOrder(cfg) {
+		if name != ldapInfra.ProviderName {
+			continue
+		}
+		groupRoles, err := ldapInfra.ParseGroupRoles(cfg.LDAP.GroupRoles)
+		if err != nil {
+			slog.Error("Invalid LDAP group role mapping, directory roles will not be synced", "error", err)
+		}
+		providers = append(providers, ldapInfra.NewProvider(ldapInfra.Config{
+			URL:                cfg.LDAP.URL,
+			BindDN:             cfg.LDAP.BindDN,
+			BindPassword:       cfg.LDAP.BindPassword,
+			BaseDN:             cfg.LDAP.BaseDN,
+			UserFilter:         cfg.LDAP.UserFilter,
+			UsernameAttribute:  cfg.LDAP.UsernameAttribute,
+			EmailAttribute:     cfg.LDAP.EmailAttribute,
+			NameAttribute:      cfg.LDAP.NameAttribute,
+			GroupAttribute:     cfg.LDAP.GroupAttribute,
+			GroupRoles:         groupRoles,
+			StartTLS:           cfg.LDAP.StartTLS,
+			InsecureSkipVerify: cfg.LDAP.InsecureSkipVerify,
+		}, nil))
+	}
+	return providers
+}
+
+// identityProviderOrder 解析登录身份提供者顺序
+func identityProviderOrder(cfg *config.Config) []string {
+	var order []string
+	for name := range strings.SplitSeq(cfg.Auth.IdentityProviders, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+// newOTPService 初始化邮件/短信一次性验证码服务
+// 邮件通道始终可用；短信通道仅在配置了服务商时启用
+func newOTPService(cfg *config.Config, repos *RepositoriesModule, mailer mail.Mailer) *twofa.OTPService {
+	policy := domainTwoFA.DefaultOTPPolicy()
+	senders := []domainTwoFA.OTPSender{
+		twofa.NewEmailSender(mailer, cfg.Auth.TwoFAIssuer, policy.TTL),
+	}
+
+	switch cfg.Auth.SMSProvider {
+	case "fake":
+		senders = append(senders, twofa.NewSMSSender(twofa.NewFakeSMSProvider(), cfg.Auth.TwoFAIssuer, policy.TTL))
+	case "":
+		// 未配置短信服务商，禁用短信通道
+	default:
+		slog.Warn("unknown sms provider, sms two-factor disabled", "provider", cfg.Auth.SMSProvider)
+	}
+
+	return twofa.NewOTPService(
+		repos.TwoFA.ChannelCommand,
+		repos.TwoFA.ChannelQuery,
+		repos.OTPChallenge,
+		repos.User.Query,
+		policy,
+		senders...,
+	)
+}
@@ -1,97 +1,342 @@
 package bootstrap
 
 import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
 	"github.com/lwmacct/251117-go-ddd-template/internal/config"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+	domainMenu "github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/approval"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/authz"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/cache"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/captcha"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/group"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/organization"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/rbacconfig"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/setting"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/stats"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/trash"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/twofa"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
 
 	authInfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/persistence"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/redis"
 )
 
 // newUseCasesModule 初始化用例模块
 // 依赖：RepositoriesModule, ServicesModule, InfrastructureModule, EventBus, Config
 func newUseCasesModule(cfg *config.Config, infra *InfrastructureModule, repos *RepositoriesModule, services *ServicesModule, eventBus event.EventBus) *UseCasesModule {
+	// 用户偏好解析：邮件语言、审计时间展示等按用户偏好输出
+	preferences := setting.NewPreferenceService(repos.Setting.Query, repos.Setting.UserQuery)
+
 	// 先创建 AuditLog（Auth 依赖它记录登录日志）
-	auditLogUseCases := newAuditLogUseCases(repos)
+	auditLogUseCases := newAuditLogUseCases(repos, services, preferences)
+
+	// 菜单树缓存：按权限集合缓存裁剪结果，菜单管理与 RBAC 配置应用时整体失效
+	menuTreeCache := redis.NewMenuTreeCache(infra.RedisClient, cfg.Data.RedisKeyPrefix)
+
+	// 审批通过后执行用户、角色与用户组命令，需先创建这些用例
+	userUseCases := newUserUseCases(cfg, repos, services, preferences, eventBus, auditLogUseCases.CreateLog)
+	roleUseCases := newRoleUseCases(repos, eventBus)
+	groupUseCases := newGroupUseCases(repos, eventBus)
 
 	return &UseCasesModule{
-		Auth:     newAuthUseCases(repos, services, auditLogUseCases.CreateLog),
-		User:     newUserUseCases(repos, services, eventBus),
-		Role:     newRoleUseCases(repos, eventBus),
-		Menu:     newMenuUseCases(repos),
-		Setting:  newSettingUseCases(repos),
+		Auth:     newAuthUseCases(cfg, repos, services, eventBus, auditLogUseCases.CreateLog),
+		User:     userUseCases,
+		Role:     roleUseCases,
+		Menu:     newMenuUseCases(repos, menuTreeCache),
+		Setting:  newSettingUseCases(repos, preferences),
 		PAT:      newPATUseCases(repos, services),
 		AuditLog: auditLogUseCases,
 		Stats:    newStatsUseCases(repos),
 		Captcha:  newCaptchaUseCases(repos, services),
 		TwoFA:    newTwoFAUseCases(services),
 		Cache:    newCacheUseCases(infra, cfg),
+
+		Organization: newOrganizationUseCases(repos, eventBus),
+		Group:        groupUseCases,
+		Authz:        newAuthzUseCases(repos, services),
+		RBACConfig:   newRBACConfigUseCases(infra, repos, menuTreeCache, eventBus),
+		Approval:     newApprovalUseCases(cfg, repos, auditLogUseCases.CreateLog, userUseCases, roleUseCases, groupUseCases),
+		Trash:        newTrashUseCases(repos, menuTreeCache),
+	}
+}
+
+// newApprovalUseCases 初始化双人审批用例
+// 受保护操作批准后通过 Executors 调用原有命令处理器执行
+func newApprovalUseCases(
+	cfg *config.Config,
+	repos *RepositoriesModule,
+	auditLog *auditlog.CreateLogHandler,
+	userUseCases *UserUseCases,
+	roleUseCases *RoleUseCases,
+	groupUseCases *GroupUseCases,
+) *ApprovalUseCases {
+	policy := approval.NewPolicy(approvalPermissions(cfg), cfg.Approval.Timeout)
+	executors := approval.Executors{
+		approval.OperationSetRolePermissions: approval.Bind(roleUseCases.SetPermissions.Handle),
+		approval.OperationSetRoleParent:      approval.BindResult(roleUseCases.Update.Handle),
+		approval.OperationSetGrantCondition:  approval.BindResult(roleUseCases.SetCondition.Handle),
+		approval.OperationAssignUserRoles:    approval.Bind(userUseCases.AssignRoles.Handle),
+		approval.OperationDeleteUser:         approval.Bind(userUseCases.Delete.Handle),
+		approval.OperationGrantUserRole:      approval.BindResult(userUseCases.GrantRole.Handle),
+		approval.OperationSetGroupRoles:      approval.BindResult(groupUseCases.SetRoles.Handle),
+		approval.OperationAddGroupMembers:    approval.Bind(groupUseCases.AddMembers.Handle),
+	}
+
+	return &ApprovalUseCases{
+		Submit:  approval.NewSubmitChangeRequestHandler(repos.Approval.Command, policy, auditLog),
+		Approve: approval.NewApproveChangeRequestHandler(repos.Approval.Command, repos.Approval.Query, repos.User.Query, executors, auditLog),
+		Reject:  approval.NewRejectChangeRequestHandler(repos.Approval.Command, repos.Approval.Query, repos.User.Query, auditLog),
+		Expire:  approval.NewExpireChangeRequestsHandler(repos.Approval.Command, repos.Approval.Query, auditLog),
+		Get:     approval.NewGetChangeRequestHandler(repos.Approval.Query),
+		List:    approval.NewListChangeRequestsHandler(repos.Approval.Query),
+	}
+}
+
+// approvalPermissions 解析 approval.permissions 中需要审批的权限列表
+func approvalPermissions(cfg *config.Config) []string {
+	var permissions []string
+	for code := range strings.SplitSeq(cfg.Approval.Permissions, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			permissions = append(permissions, code)
+		}
+	}
+	return permissions
+}
+
+// newRBACConfigUseCases 初始化 RBAC 声明式配置用例
+func newRBACConfigUseCases(infra *InfrastructureModule, repos *RepositoriesModule, treeCache domainMenu.TreeCache, eventBus event.EventBus) *RBACConfigUseCases {
+	rbacRepos := rbacconfig.Repositories{
+		RoleCommand:       repos.Role.Command,
+		RoleQuery:         repos.Role.Query,
+		PermissionCommand: repos.Permission.Command,
+		PermissionQuery:   repos.Permission.Query,
+		MenuCommand:       repos.Menu.Command,
+		MenuQuery:         repos.Menu.Query,
+	}
+
+	return &RBACConfigUseCases{
+		Plan:   rbacconfig.NewPlanConfigHandler(rbacRepos),
+		Apply:  rbacconfig.NewApplyConfigHandler(newRBACConfigUnitOfWork(infra.DB), treeCache, eventBus),
+		Export: rbacconfig.NewExportConfigHandler(rbacRepos),
+	}
+}
+
+// newRBACConfigUnitOfWork 创建基于数据库事务的工作单元，事务内的仓储绑定到同一个 tx
+func newRBACConfigUnitOfWork(db *gorm.DB) rbacconfig.UnitOfWork {
+	return func(ctx context.Context, fn func(repos rbacconfig.Repositories) error) error {
+		return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			roleRepos := persistence.NewRoleRepositories(tx)
+			permissionRepos := persistence.NewPermissionRepositories(tx)
+			menuRepos := persistence.NewMenuRepositories(tx)
+
+			return fn(rbacconfig.Repositories{
+				RoleCommand:       roleRepos.Command,
+				RoleQuery:         roleRepos.Query,
+				PermissionCommand: permissionRepos.Command,
+				PermissionQuery:   permissionRepos.Query,
+				MenuCommand:       menuRepos.Command,
+				MenuQuery:         menuRepos.Query,
+			})
+		})
+	}
+}
+
+// newAuthzUseCases 初始化授权解释用例
+func newAuthzUseCases(repos *RepositoriesModule, services *ServicesModule) *AuthzUseCases {
+	return &AuthzUseCases{
+		Explain: authz.NewExplainPermissionHandler(repos.User.Query, repos.Role.Query, repos.Organization.Query, repos.PAT.Query, services.PermissionCache),
+	}
+}
+
+// newGroupUseCases 初始化用户组管理用例
+func newGroupUseCases(repos *RepositoriesModule, eventBus event.EventBus) *GroupUseCases {
+	return &GroupUseCases{
+		Create:        group.NewCreateGroupHandler(repos.Group.Command, repos.Group.Query, repos.Role.Query),
+		Update:        group.NewUpdateGroupHandler(repos.Group.Command, repos.Group.Query),
+		Delete:        group.NewDeleteGroupHandler(repos.Group.Command, repos.Group.Query, eventBus),
+		AddMembers:    group.NewAddGroupMembersHandler(repos.Group.Command, repos.Group.Query, repos.User.Query, eventBus),
+		RemoveMembers: group.NewRemoveGroupMembersHandler(repos.Group.Command, repos.Group.Query, eventBus),
+		SetRoles:      group.NewSetGroupRolesHandler(repos.Group.Command, repos.Group.Query, repos.Role.Query, eventBus),
+		Import:        group.NewImportGroupsHandler(repos.Group.Command, repos.Group.Query, repos.Role.Query, repos.User.Query, eventBus),
+		Get:           group.NewGetGroupHandler(repos.Group.Query),
+		List:          group.NewListGroupsHandler(repos.Group.Query),
+		ListMembers:   group.NewListGroupMembersHandler(repos.Group.Query),
+	}
+}
+
+// newOrganizationUseCases 初始化组织管理用例
+func newOrganizationUseCases(repos *RepositoriesModule, eventBus event.EventBus) *OrganizationUseCases {
+	return &OrganizationUseCases{
+		Create:         organization.NewCreateOrganizationHandler(repos.Organization.Command, repos.Organization.Query),
+		Update:         organization.NewUpdateOrganizationHandler(repos.Organization.Command, repos.Organization.Query),
+		Delete:         organization.NewDeleteOrganizationHandler(repos.Organization.Command, repos.Organization.Query),
+		AddMember:      organization.NewAddMemberHandler(repos.Organization.Command, repos.Organization.Query, repos.User.Query, repos.Role.Query, eventBus),
+		RemoveMember:   organization.NewRemoveMemberHandler(repos.Organization.Command, eventBus),
+		SetMemberRoles: organization.NewSetMemberRolesHandler(repos.Organization.Command, repos.Organization.Query, repos.Role.Query, eventBus),
+		Get:            organization.NewGetOrganizationHandler(repos.Organization.Query),
+		List:           organization.NewListOrganizationsHandler(repos.Organization.Query),
+		ListMembers:    organization.NewListMembersHandler(repos.Organization.Query),
+		ListUserOrgs:   organization.NewListUserOrganizationsHandler(repos.Organization.Query),
+		ResolveTenant:  organization.NewResolveTenantHandler(repos.Organization.Query),
 	}
 }
 
 // newAuthUseCases 初始化认证用例
-func newAuthUseCases(repos *RepositoriesModule, services *ServicesModule, auditLogHandler *auditlog.CreateLogHandler) *AuthUseCases {
+func newAuthUseCases(cfg *config.Config, repos *RepositoriesModule, services *ServicesModule, eventBus event.EventBus, auditLogHandler *auditlog.CreateLogHandler) *AuthUseCases {
+	// 登录身份提供者链：按配置顺序尝试本地密码库和外部目录
+	identityProviders := auth.NewIdentityProviderChain(
+		identityProviderOrder(cfg), services.IdentityProviders,
+		repos.User.Command, repos.User.Query, repos.User.RoleAssignmentQuery, repos.Role.Query, services.Auth, eventBus,
+	)
+
 	return &AuthUseCases{
-		Login:        auth.NewLoginHandler(repos.User.Query, repos.CaptchaCommand, repos.TwoFA.Query, services.Auth, services.LoginSession, auditLogHandler),
-		Login2FA:     auth.NewLogin2FAHandler(repos.User.Query, services.Auth, services.LoginSession, services.TwoFA, auditLogHandler),
-		Register:     auth.NewRegisterHandler(repos.User.Command, repos.User.Query, services.Auth),
-		RefreshToken: auth.NewRefreshTokenHandler(repos.User.Query, services.Auth),
+		Login:        auth.NewLoginHandler(repos.User.Query, repos.User.Command, repos.CaptchaCommand, repos.TwoFA.Query, repos.Setting.Query, services.OTP, services.Auth, services.LoginSession, identityProviders, auditLogHandler, services.LoginRecorder),
+		Login2FA:     auth.NewLogin2FAHandler(repos.User.Query, repos.User.Command, repos.Setting.Query, services.Auth, services.LoginSession, services.TwoFA, services.OTP, auditLogHandler, services.LoginRecorder),
+		Send2FACode:  auth.NewSend2FACodeHandler(services.LoginSession, services.OTP),
+		Register:     auth.NewRegisterHandler(repos.User.Command, repos.User.Query, repos.Setting.Query, services.Auth),
+		RefreshToken: auth.NewRefreshTokenHandler(repos.User.Query, repos.Setting.Query, services.Auth),
+
+		AcceptInvitation: auth.NewAcceptInvitationHandler(
+			repos.User.Command, repos.User.Query, repos.User.InvitationCommand, repos.User.InvitationQuery, services.Auth,
+		),
 	}
 }
 
 // newUserUseCases 初始化用户管理用例
-func newUserUseCases(repos *RepositoriesModule, services *ServicesModule, eventBus event.EventBus) *UserUseCases {
+func newUserUseCases(
+	cfg *config.Config,
+	repos *RepositoriesModule,
+	services *ServicesModule,
+	preferences *setting.PreferenceService,
+	eventBus event.EventBus,
+	auditLogHandler *auditlog.CreateLogHandler,
+) *UserUseCases {
+	changeStatus := user.NewChangeUserStatusHandler(
+		repos.User.Command, repos.User.Query, repos.User.StatusHistoryCommand, repos.PAT.Command,
+		services.PolicyResolver, eventBus,
+	)
+
 	return &UserUseCases{
-		Create:         user.NewCreateUserHandler(repos.User.Command, repos.User.Query, services.Auth),
-		Update:         user.NewUpdateUserHandler(repos.User.Command, repos.User.Query),
+		Create: user.NewCreateUserHandler(
+			repos.User.Command, repos.User.Query, repos.Role.Query, repos.User.AttributeDefinitionQuery, services.Auth,
+		),
+		Update: user.NewUpdateUserHandler(
+			repos.User.Command, repos.User.Query, repos.User.AttributeDefinitionQuery, services.PolicyResolver, changeStatus,
+		),
 		Delete:         user.NewDeleteUserHandler(repos.User.Command, repos.User.Query, eventBus),
-		AssignRoles:    user.NewAssignRolesHandler(repos.User.Command, repos.User.Query, eventBus),
+		AssignRoles:    user.NewAssignRolesHandler(repos.User.Command, repos.User.Query, services.PolicyResolver, eventBus),
 		ChangePassword: user.NewChangePasswordHandler(repos.User.Command, repos.User.Query, services.Auth),
-		BatchCreate:    user.NewBatchCreateUsersHandler(repos.User.Command, repos.User.Query, services.Auth),
-		Get:            user.NewGetUserHandler(repos.User.Query),
-		List:           user.NewListUsersHandler(repos.User.Query),
+		BatchCreate:    user.NewBatchCreateUsersHandler(repos.User.Command, repos.User.Query, repos.Role.Query, services.Auth),
+		ResetPassword:  user.NewResetPasswordHandler(repos.User.Command, repos.User.Query, services.PolicyResolver, services.Auth),
+		Invite: user.NewInviteUserHandler(
+			repos.User.Command, repos.User.Query, repos.Role.Query, repos.User.InvitationCommand, repos.Setting.Query,
+			preferences, services.Auth, services.Mailer,
+		),
+		ResendInvite: user.NewResendInvitationHandler(
+			repos.User.Query, repos.User.InvitationCommand, repos.Setting.Query, preferences, services.Mailer,
+		),
+		Approve: user.NewApproveUserHandler(repos.User.Command, repos.User.Query, services.PolicyResolver),
+		GrantRole: user.NewGrantRoleHandler(
+			repos.User.Query, repos.Role.Query, repos.User.RoleAssignmentCommand, services.PolicyResolver, eventBus,
+		),
+		RevokeRole: user.NewRevokeRoleHandler(repos.User.Command, repos.User.Query, services.PolicyResolver, eventBus),
+		Import: user.NewImportUsersHandler(
+			repos.User.Command, repos.User.Query, repos.Role.Query, repos.User.ImportJobCommand, services.Auth,
+		),
+		RequestDeletion:   user.NewRequestAccountDeletionHandler(repos.User.Command, repos.User.Query, repos.Setting.Query),
+		RequestDataExport: user.NewRequestDataExportHandler(repos.User.DataExportCommand, repos.User.DataExportQuery),
+		UploadAvatar:      user.NewUploadAvatarHandler(repos.User.Command, repos.User.Query, services.Storage, cfg.Storage.AvatarMaxSize),
+		DeleteAvatar:      user.NewDeleteAvatarHandler(repos.User.Command, repos.User.Query, services.Storage),
+		ChangeStatus:      changeStatus,
+		Get:               user.NewGetUserHandler(repos.User.Query, repos.User.AttributeDefinitionQuery),
+		List:              user.NewListUsersHandler(repos.User.Query, repos.User.AttributeDefinitionQuery, services.PolicyResolver),
+
+		CreateAttribute: user.NewCreateAttributeDefinitionHandler(repos.User.AttributeDefinitionCommand, repos.User.AttributeDefinitionQuery),
+		UpdateAttribute: user.NewUpdateAttributeDefinitionHandler(repos.User.AttributeDefinitionCommand, repos.User.AttributeDefinitionQuery),
+		DeleteAttribute: user.NewDeleteAttributeDefinitionHandler(repos.User.AttributeDefinitionCommand),
+		ListAttributes:  user.NewListAttributeDefinitionsHandler(repos.User.AttributeDefinitionQuery),
+
+		ListRoleAssignments:     user.NewListRoleAssignmentsHandler(repos.User.Query, repos.User.RoleAssignmentQuery),
+		ListExpiringAssignments: user.NewListExpiringRoleAssignmentsHandler(repos.User.RoleAssignmentQuery),
+		ProcessRoleAssignments:  user.NewProcessRoleAssignmentsHandler(repos.User.RoleAssignmentCommand, repos.User.RoleAssignmentQuery, eventBus),
+
+		GetImportJob: user.NewGetImportJobHandler(repos.User.ImportJobQuery),
+		Export: user.NewExportUsersHandler(
+			repos.User.Query, repos.User.AttributeDefinitionQuery, services.PolicyResolver, auditLogHandler,
+		),
+		ProcessImportJobs: user.NewProcessImportJobsHandler(
+			repos.User.Command, repos.User.Query, repos.Role.Query, repos.User.ImportJobCommand, services.Auth,
+		),
+
+		GetDataExport: user.NewGetDataExportHandler(repos.User.DataExportQuery),
+		GetAvatar:     user.NewGetAvatarHandler(repos.User.Query, services.Storage),
+		StatusHistory: user.NewGetUserStatusHistoryHandler(repos.User.Query, repos.User.StatusHistoryQuery),
+		FinalizeDeletions: user.NewFinalizeAccountDeletionsHandler(
+			repos.User.Command, repos.User.Query, repos.AuditLog.Command, repos.PAT.Command,
+			repos.TwoFA.Command, repos.TwoFA.ChannelCommand, repos.User.DataExportCommand, eventBus,
+		),
+		ProcessDataExports: user.NewProcessDataExportsHandler(
+			repos.User.DataExportCommand, repos.User.Query, repos.PAT.Query, repos.AuditLog.Query,
+		),
+		LiftExpiredBans:    user.NewLiftExpiredBansHandler(repos.User.Query, changeStatus),
+		DeactivateInactive: user.NewDeactivateInactiveUsersHandler(repos.User.Query, repos.Setting.Query, changeStatus),
 	}
 }
 
 // newRoleUseCases 初始化角色管理用例
 func newRoleUseCases(repos *RepositoriesModule, eventBus event.EventBus) *RoleUseCases {
 	return &RoleUseCases{
-		Create:          role.NewCreateRoleHandler(repos.Role.Command, repos.Role.Query),
-		Update:          role.NewUpdateRoleHandler(repos.Role.Command, repos.Role.Query),
-		Delete:          role.NewDeleteRoleHandler(repos.Role.Command, repos.Role.Query),
-		SetPermissions:  role.NewSetPermissionsHandler(repos.Role.Command, repos.Role.Query, repos.Permission.Query, eventBus),
-		Get:             role.NewGetRoleHandler(repos.Role.Query),
-		List:            role.NewListRolesHandler(repos.Role.Query),
-		ListPermissions: role.NewListPermissionsHandler(repos.Permission.Query),
+		Create:               role.NewCreateRoleHandler(repos.Role.Command, repos.Role.Query),
+		Update:               role.NewUpdateRoleHandler(repos.Role.Command, repos.Role.Query, eventBus),
+		Delete:               role.NewDeleteRoleHandler(repos.Role.Command, repos.Role.Query, eventBus),
+		SetPermissions:       role.NewSetPermissionsHandler(repos.Role.Command, repos.Role.Query, repos.Permission.Query, eventBus),
+		SyncPermissions:      role.NewSyncPermissionsHandler(repos.Permission.Command, repos.Permission.Query),
+		SetCondition:         role.NewSetPermissionConditionHandler(repos.Role.Command, repos.Role.Query),
+		Get:                  role.NewGetRoleHandler(repos.Role.Query),
+		List:                 role.NewListRolesHandler(repos.Role.Query),
+		ListPermissions:      role.NewListPermissionsHandler(repos.Permission.Query),
+		EffectivePermissions: role.NewGetEffectivePermissionsHandler(repos.Role.Query),
+		ListGrants:           role.NewListGrantsHandler(repos.Role.Query),
 	}
 }
 
 // newMenuUseCases 初始化菜单管理用例
-func newMenuUseCases(repos *RepositoriesModule) *MenuUseCases {
+func newMenuUseCases(repos *RepositoriesModule, treeCache domainMenu.TreeCache) *MenuUseCases {
 	return &MenuUseCases{
-		Create:  menu.NewCreateMenuHandler(repos.Menu.Command, repos.Menu.Query),
-		Update:  menu.NewUpdateMenuHandler(repos.Menu.Command, repos.Menu.Query),
-		Delete:  menu.NewDeleteMenuHandler(repos.Menu.Command, repos.Menu.Query),
-		Reorder: menu.NewReorderMenusHandler(repos.Menu.Command, repos.Menu.Query),
-		Get:     menu.NewGetMenuHandler(repos.Menu.Query),
-		List:    menu.NewListMenusHandler(repos.Menu.Query),
+		Create:   menu.NewCreateMenuHandler(repos.Menu.Command, repos.Menu.Query, treeCache),
+		Update:   menu.NewUpdateMenuHandler(repos.Menu.Command, repos.Menu.Query, treeCache),
+		Delete:   menu.NewDeleteMenuHandler(repos.Menu.Command, repos.Menu.Query, treeCache),
+		Reorder:  menu.NewReorderMenusHandler(repos.Menu.Command, repos.Menu.Query, treeCache),
+		Get:      menu.NewGetMenuHandler(repos.Menu.Query),
+		List:     menu.NewListMenusHandler(repos.Menu.Query),
+		ListMine: menu.NewListUserMenusHandler(repos.Menu.Query, treeCache),
+	}
+}
+
+// newTrashUseCases 初始化回收站用例
+func newTrashUseCases(repos *RepositoriesModule, treeCache domainMenu.TreeCache) *TrashUseCases {
+	return &TrashUseCases{
+		Restore:      trash.NewRestoreItemHandler(repos.Trash, treeCache),
+		Purge:        trash.NewPurgeItemHandler(repos.Trash),
+		PurgeExpired: trash.NewPurgeExpiredHandler(repos.Trash, repos.Setting.Query),
+		List:         trash.NewListDeletedHandler(repos.Trash),
 	}
 }
 
 // newSettingUseCases 初始化系统配置用例
-func newSettingUseCases(repos *RepositoriesModule) *SettingUseCases {
+func newSettingUseCases(repos *RepositoriesModule, preferences *setting.PreferenceService) *SettingUseCases {
 	return &SettingUseCases{
 		Create:      setting.NewCreateSettingHandler(repos.Setting.Command, repos.Setting.Query),
 		Update:      setting.NewUpdateSettingHandler(repos.Setting.Command, repos.Setting.Query),
@@ -99,6 +344,9 @@ func newSettingUseCases(repos *RepositoriesModule) *SettingUseCases {
 		BatchUpdate: setting.NewBatchUpdateSettingsHandler(repos.Setting.Command, repos.Setting.Query),
 		Get:         setting.NewGetSettingHandler(repos.Setting.Query),
 		List:        setting.NewListSettingsHandler(repos.Setting.Query),
+
+		GetPreferences:    setting.NewGetUserPreferencesHandler(preferences),
+		UpdatePreferences: setting.NewUpdateUserPreferencesHandler(repos.Setting.UserCommand, repos.Setting.Query, preferences),
 	}
 }
 
@@ -122,11 +370,11 @@ func newPATUseCases(repos *RepositoriesModule, services *ServicesModule) *PATUse
 }
 
 // newAuditLogUseCases 初始化审计日志用例
-func newAuditLogUseCases(repos *RepositoriesModule) *AuditLogUseCases {
+func newAuditLogUseCases(repos *RepositoriesModule, services *ServicesModule, preferences *setting.PreferenceService) *AuditLogUseCases {
 	return &AuditLogUseCases{
 		CreateLog: auditlog.NewCreateLogHandler(repos.AuditLog.Command),
-		Get:       auditlog.NewGetLogHandler(repos.AuditLog.Query),
-		List:      auditlog.NewListLogsHandler(repos.AuditLog.Query),
+		Get:       auditlog.NewGetLogHandler(repos.AuditLog.Query, services.PolicyResolver, preferences),
+		List:      auditlog.NewListLogsHandler(repos.AuditLog.Query, services.PolicyResolver, preferences),
 	}
 }
 
@@ -151,6 +399,11 @@ func newTwoFAUseCases(services *ServicesModule) *TwoFAUseCases {
 		VerifyEnable: twofa.NewVerifyEnableHandler(services.TwoFA),
 		Disable:      twofa.NewDisableHandler(services.TwoFA),
 		GetStatus:    twofa.NewGetStatusHandler(services.TwoFA),
+
+		SetupChannel:   twofa.NewSetupChannelHandler(services.OTP),
+		VerifyChannel:  twofa.NewVerifyChannelHandler(services.OTP),
+		DisableChannel: twofa.NewDisableChannelHandler(services.OTP),
+		ListMethods:    twofa.NewListMethodsHandler(services.OTP),
 	}
 }
 
@@ -10,10 +10,14 @@ import (
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/captcha"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/stats"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
 
 	_auth "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/auth"
 	_captcha "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/captcha"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/mail"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/persistence"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/storage"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/telemetry"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/twofa"
 )
@@ -39,9 +43,17 @@ type RepositoriesModule struct {
 	Setting    persistence.SettingRepositories
 	TwoFA      persistence.TwoFARepositories
 
+	Organization persistence.OrganizationRepositories
+	Group        persistence.GroupRepositories
+	Approval     persistence.ApprovalRepositories
+
+	// 回收站（按资源类型分发的软删除记录仓储）
+	Trash []trash.Bin
+
 	// 特殊仓储（内存实现）
 	CaptchaCommand captcha.CommandRepository
 	CaptchaQuery   captcha.QueryRepository
+	OTPChallenge   domainTwoFA.ChallengeRepository
 
 	// 只读仓储
 	StatsQuery stats.QueryRepository
@@ -58,9 +70,19 @@ type ServicesModule struct {
 	TokenGenerator  auth.TokenGenerator
 	LoginSession    *_auth.LoginSessionService
 	PermissionCache *_auth.PermissionCacheService
+	PolicyResolver  *_auth.PolicyResolver
 	PAT             *_auth.PATService
-	Captcha         *_captcha.Service
-	TwoFA           *twofa.Service
+	ClientCert      *_auth.ClientCertService
+	// LoginRecorder 登录活动异步批量写入，容器关闭时刷出剩余记录
+	LoginRecorder *_auth.LoginActivityRecorder
+	// IdentityProviders 外部身份提供者（LDAP 等），按 auth.identity-providers 启用
+	IdentityProviders []auth.IdentityProvider
+	Captcha           *_captcha.Service
+	TwoFA             *twofa.Service
+	OTP               *twofa.OTPService
+	Mailer            mail.Mailer
+	// Storage 用户上传文件（头像等）存储
+	Storage storage.Storage
 }
 
 // HandlersModule HTTP Handler 模块
@@ -79,6 +101,20 @@ type HandlersModule struct {
 	Overview    *handler.OverviewHandler
 	TwoFA       *handler.TwoFAHandler
 	Cache       *handler.CacheHandler
+
+	Organization   *handler.OrganizationHandler
+	Group          *handler.GroupHandler
+	ChangeRequest  *handler.ChangeRequestHandler
+	RoleAssignment *handler.RoleAssignmentHandler
+	UserImport     *handler.UserImportHandler
+	UserExport     *handler.UserExportHandler
+	UserDataExport *handler.UserDataExportHandler
+	UserAvatar     *handler.UserAvatarHandler
+	UserStatus     *handler.UserStatusHandler
+	UserAttribute  *handler.UserAttributeHandler
+	UserPreference *handler.UserPreferenceHandler
+	Authz          *handler.AuthzHandler
+	Trash          *handler.TrashHandler
 }
 
 // RouterModule 路由模块
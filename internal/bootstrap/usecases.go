@@ -1,15 +1,21 @@
 package bootstrap
 
 import (
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/approval"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/auditlog"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/authz"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/cache"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/captcha"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/group"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/organization"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/rbacconfig"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/setting"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/stats"
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/trash"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/twofa"
 	"github.com/lwmacct/251117-go-ddd-template/internal/application/user"
 )
@@ -28,14 +34,83 @@ type UseCasesModule struct {
 	Captcha  *CaptchaUseCases
 	TwoFA    *TwoFAUseCases
 	Cache    *CacheUseCases
+
+	Organization *OrganizationUseCases
+	Group        *GroupUseCases
+	Authz        *AuthzUseCases
+	RBACConfig   *RBACConfigUseCases
+	Approval     *ApprovalUseCases
+	Trash        *TrashUseCases
 }
 
 // AuthUseCases 认证相关用例
 type AuthUseCases struct {
 	Login        *auth.LoginHandler
 	Login2FA     *auth.Login2FAHandler
+	Send2FACode  *auth.Send2FACodeHandler
 	Register     *auth.RegisterHandler
 	RefreshToken *auth.RefreshTokenHandler
+
+	AcceptInvitation *auth.AcceptInvitationHandler
+}
+
+// OrganizationUseCases 组织（租户）管理用例
+type OrganizationUseCases struct {
+	// Commands
+	Create         *organization.CreateOrganizationHandler
+	Update         *organization.UpdateOrganizationHandler
+	Delete         *organization.DeleteOrganizationHandler
+	AddMember      *organization.AddMemberHandler
+	RemoveMember   *organization.RemoveMemberHandler
+	SetMemberRoles *organization.SetMemberRolesHandler
+
+	// Queries
+	Get           *organization.GetOrganizationHandler
+	List          *organization.ListOrganizationsHandler
+	ListMembers   *organization.ListMembersHandler
+	ListUserOrgs  *organization.ListUserOrganizationsHandler
+	ResolveTenant *organization.ResolveTenantHandler
+}
+
+// GroupUseCases 用户组管理用例
+type GroupUseCases struct {
+	// Commands
+	Create        *group.CreateGroupHandler
+	Update        *group.UpdateGroupHandler
+	Delete        *group.DeleteGroupHandler
+	AddMembers    *group.AddGroupMembersHandler
+	RemoveMembers *group.RemoveGroupMembersHandler
+	SetRoles      *group.SetGroupRolesHandler
+	Import        *group.ImportGroupsHandler
+
+	// Queries
+	Get         *group.GetGroupHandler
+	List        *group.ListGroupsHandler
+	ListMembers *group.ListGroupMembersHandler
+}
+
+// ApprovalUseCases 敏感变更双人审批用例
+type ApprovalUseCases struct {
+	// Commands
+	Submit  *approval.SubmitChangeRequestHandler
+	Approve *approval.ApproveChangeRequestHandler
+	Reject  *approval.RejectChangeRequestHandler
+	Expire  *approval.ExpireChangeRequestsHandler
+
+	// Queries
+	Get  *approval.GetChangeRequestHandler
+	List *approval.ListChangeRequestsHandler
+}
+
+// TrashUseCases 回收站用例
+type TrashUseCases struct {
+	// Commands
+	Restore      *trash.RestoreItemHandler
+	Purge        *trash.PurgeItemHandler
+	PurgeExpired *trash.PurgeExpiredHandler
+
+	// Queries
+	List *trash.ListDeletedHandler
 }
 
 // UserUseCases 用户管理用例
@@ -47,24 +122,61 @@ type UserUseCases struct {
 	AssignRoles    *user.AssignRolesHandler
 	ChangePassword *user.ChangePasswordHandler
 	BatchCreate    *user.BatchCreateUsersHandler
+	ResetPassword  *user.ResetPasswordHandler
+	Invite         *user.InviteUserHandler
+	ResendInvite   *user.ResendInvitationHandler
+	Approve        *user.ApproveUserHandler
+	GrantRole      *user.GrantRoleHandler
+	RevokeRole     *user.RevokeRoleHandler
+	Import         *user.ImportUsersHandler
+
+	RequestDeletion   *user.RequestAccountDeletionHandler
+	RequestDataExport *user.RequestDataExportHandler
+	UploadAvatar      *user.UploadAvatarHandler
+	DeleteAvatar      *user.DeleteAvatarHandler
+	ChangeStatus      *user.ChangeUserStatusHandler
+
+	CreateAttribute *user.CreateAttributeDefinitionHandler
+	UpdateAttribute *user.UpdateAttributeDefinitionHandler
+	DeleteAttribute *user.DeleteAttributeDefinitionHandler
 
 	// Queries
-	Get  *user.GetUserHandler
-	List *user.ListUsersHandler
+	Get                     *user.GetUserHandler
+	List                    *user.ListUsersHandler
+	ListRoleAssignments     *user.ListRoleAssignmentsHandler
+	ListExpiringAssignments *user.ListExpiringRoleAssignmentsHandler
+	GetImportJob            *user.GetImportJobHandler
+	Export                  *user.ExportUsersHandler
+	GetDataExport           *user.GetDataExportHandler
+	GetAvatar               *user.GetAvatarHandler
+	StatusHistory           *user.GetUserStatusHistoryHandler
+	ListAttributes          *user.ListAttributeDefinitionsHandler
+
+	// Jobs
+	ProcessRoleAssignments *user.ProcessRoleAssignmentsHandler
+	ProcessImportJobs      *user.ProcessImportJobsHandler
+	FinalizeDeletions      *user.FinalizeAccountDeletionsHandler
+	ProcessDataExports     *user.ProcessDataExportsHandler
+	LiftExpiredBans        *user.LiftExpiredBansHandler
+	DeactivateInactive     *user.DeactivateInactiveUsersHandler
 }
 
 // RoleUseCases 角色管理用例
 type RoleUseCases struct {
 	// Commands
-	Create         *role.CreateRoleHandler
-	Update         *role.UpdateRoleHandler
-	Delete         *role.DeleteRoleHandler
-	SetPermissions *role.SetPermissionsHandler
+	Create          *role.CreateRoleHandler
+	Update          *role.UpdateRoleHandler
+	Delete          *role.DeleteRoleHandler
+	SetPermissions  *role.SetPermissionsHandler
+	SyncPermissions *role.SyncPermissionsHandler
+	SetCondition    *role.SetPermissionConditionHandler
 
 	// Queries
-	Get             *role.GetRoleHandler
-	List            *role.ListRolesHandler
-	ListPermissions *role.ListPermissionsHandler
+	Get                  *role.GetRoleHandler
+	List                 *role.ListRolesHandler
+	ListPermissions      *role.ListPermissionsHandler
+	EffectivePermissions *role.GetEffectivePermissionsHandler
+	ListGrants           *role.ListGrantsHandler
 }
 
 // MenuUseCases 菜单管理用例
@@ -76,8 +188,9 @@ type MenuUseCases struct {
 	Reorder *menu.ReorderMenusHandler
 
 	// Queries
-	Get  *menu.GetMenuHandler
-	List *menu.ListMenusHandler
+	Get      *menu.GetMenuHandler
+	List     *menu.ListMenusHandler
+	ListMine *menu.ListUserMenusHandler
 }
 
 // SettingUseCases 系统配置用例
@@ -91,6 +204,10 @@ type SettingUseCases struct {
 	// Queries
 	Get  *setting.GetSettingHandler
 	List *setting.ListSettingsHandler
+
+	// 用户偏好
+	GetPreferences    *setting.GetUserPreferencesHandler
+	UpdatePreferences *setting.UpdateUserPreferencesHandler
 }
 
 // PATUseCases 个人访问令牌用例
@@ -133,8 +250,14 @@ type TwoFAUseCases struct {
 	VerifyEnable *twofa.VerifyEnableHandler
 	Disable      *twofa.DisableHandler
 
+	// Commands - 邮件/短信通道
+	SetupChannel   *twofa.SetupChannelHandler
+	VerifyChannel  *twofa.VerifyChannelHandler
+	DisableChannel *twofa.DisableChannelHandler
+
 	// Queries
-	GetStatus *twofa.GetStatusHandler
+	GetStatus   *twofa.GetStatusHandler
+	ListMethods *twofa.ListMethodsHandler
 }
 
 // CacheUseCases 缓存用例（演示用）
@@ -146,3 +269,19 @@ type CacheUseCases struct {
 	// Queries
 	Get *cache.GetCacheHandler
 }
+
+// AuthzUseCases 授权解释用例
+type AuthzUseCases struct {
+	// Queries
+	Explain *authz.ExplainPermissionHandler
+}
+
+// RBACConfigUseCases RBAC 声明式配置用例
+type RBACConfigUseCases struct {
+	// Commands
+	Apply *rbacconfig.ApplyConfigHandler
+
+	// Queries
+	Plan   *rbacconfig.PlanConfigHandler
+	Export *rbacconfig.ExportConfigHandler
+}
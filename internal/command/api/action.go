@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
 	"github.com/lwmacct/251117-go-ddd-template/internal/bootstrap"
 	"github.com/lwmacct/251117-go-ddd-template/internal/config"
 	"github.com/lwmacct/251207-go-pkg-cfgm/pkg/cfgm"
@@ -52,8 +54,16 @@ func action(ctx context.Context, cmd *cli.Command) error {
 		"server_env", cfg.Server.Env,
 	)
 
+	// 检查路由声明的权限是否已同步到数据库
+	if err := checkPermissionDrift(ctx, container, cfg); err != nil {
+		return err
+	}
+
 	// 创建并启动 HTTP 服务器
 	server := httpserver.NewServer(container.Router, cfg.Server.Addr)
+	if err := server.ConfigureTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile, cfg.Server.ClientCAFile); err != nil {
+		return fmt.Errorf("failed to configure TLS: %w", err)
+	}
 
 	// 启动服务器 (在goroutine中)
 	go func() {
@@ -88,3 +98,32 @@ func action(ctx context.Context, cmd *cli.Command) error {
 	slog.Info("API server exited")
 	return nil
 }
+
+// checkPermissionDrift 以 dry-run 方式比对路由声明的权限与数据库权限目录
+// 生产环境无法完成检查或存在缺失权限时拒绝启动，其他环境仅输出警告
+func checkPermissionDrift(ctx context.Context, container *bootstrap.Container, cfg *config.Config) error {
+	result, err := container.UseCases.Role.SyncPermissions.Handle(ctx, role.SyncPermissionsCommand{
+		Definitions: container.PermissionRegistry.Definitions(),
+		DryRun:      true,
+	})
+	if err != nil {
+		if cfg.Server.Env == "production" {
+			return fmt.Errorf("failed to check permission drift: %w", err)
+		}
+		slog.Warn("Failed to check permission drift", "error", err)
+		return nil
+	}
+
+	for _, code := range result.Orphaned {
+		slog.Warn("Orphaned permission: not referenced by any route", "code", code)
+	}
+	if len(result.Missing) == 0 {
+		return nil
+	}
+
+	if cfg.Server.Env == "production" {
+		return fmt.Errorf("permissions missing from database, run 'permissions sync' first: %v", result.Missing)
+	}
+	slog.Warn("Permissions missing from database, run 'permissions sync'", "codes", result.Missing)
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/lwmacct/251117-go-ddd-template/internal/bootstrap"
 	"github.com/lwmacct/251117-go-ddd-template/internal/config"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/database"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/persistence"
 	"github.com/lwmacct/251207-go-pkg-cfgm/pkg/cfgm"
 	"github.com/lwmacct/251207-go-pkg-version/pkg/version"
 	"github.com/urfave/cli/v3"
@@ -31,6 +32,11 @@ func actionUp(ctx context.Context, cmd *cli.Command) error {
 		}
 	}()
 
+	// 用户角色中间表携带限时授权字段，迁移前需注册自定义中间表
+	if err := persistence.RegisterJoinTables(db); err != nil {
+		return err
+	}
+
 	// 创建迁移管理器
 	manager := database.NewMigrationManager(db, bootstrap.GetAllModels())
 
@@ -131,6 +137,11 @@ func actionFresh(ctx context.Context, cmd *cli.Command) error {
 		}
 	}()
 
+	// 用户角色中间表携带限时授权字段，迁移前需注册自定义中间表
+	if err := persistence.RegisterJoinTables(db); err != nil {
+		return err
+	}
+
 	// 创建迁移管理器
 	manager := database.NewMigrationManager(db, bootstrap.GetAllModels())
 
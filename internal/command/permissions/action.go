@@ -0,0 +1,64 @@
+package permissions
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/config"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/database"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/persistence"
+	"github.com/lwmacct/251207-go-pkg-cfgm/pkg/cfgm"
+	"github.com/lwmacct/251207-go-pkg-version/pkg/version"
+	"github.com/urfave/cli/v3"
+
+	httpserver "github.com/lwmacct/251117-go-ddd-template/internal/adapters/http"
+)
+
+// actionSync 同步路由声明的权限到数据库
+func actionSync(ctx context.Context, cmd *cli.Command) error {
+	cfg := cfgm.MustLoadCmd(cmd, config.DefaultConfig(), version.AppRawName)
+
+	// 初始化数据库连接
+	dbConfig := database.DefaultConfig(cfg.Data.PgsqlURL)
+	db, err := database.NewConnection(ctx, dbConfig)
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		return err
+	}
+	defer func() {
+		if closeErr := database.Close(db); closeErr != nil {
+			slog.Error("Failed to close database connection", "error", closeErr)
+		}
+	}()
+
+	repos := persistence.NewPermissionRepositories(db)
+	handler := role.NewSyncPermissionsHandler(repos.Command, repos.Query)
+
+	result, err := handler.Handle(ctx, role.SyncPermissionsCommand{
+		Definitions: httpserver.CollectRoutePermissions(cfg).Definitions(),
+		DryRun:      cmd.Bool("dry-run"),
+	})
+	if err != nil {
+		slog.Error("Permission sync failed", "error", err)
+		return err
+	}
+
+	for _, code := range result.Missing {
+		slog.Info("Permission missing from catalog", "code", code, "created", !result.DryRun)
+	}
+	for _, code := range result.Updated {
+		slog.Info("Permission description changed", "code", code, "updated", !result.DryRun)
+	}
+	for _, code := range result.Orphaned {
+		slog.Warn("Orphaned permission: not referenced by any route", "code", code)
+	}
+
+	slog.Info("Permission sync completed",
+		"dry_run", result.DryRun,
+		"missing", len(result.Missing),
+		"updated", len(result.Updated),
+		"orphaned", len(result.Orphaned),
+	)
+	return nil
+}
@@ -0,0 +1,36 @@
+// Package permissions 提供权限目录管理命令
+package permissions
+
+import (
+	"github.com/lwmacct/251207-go-pkg-version/pkg/version"
+	"github.com/urfave/cli/v3"
+)
+
+// Command 定义权限目录命令
+var Command = &cli.Command{
+	Name:  "permissions",
+	Usage: "权限目录管理",
+	Description: `
+   管理权限目录。权限由路由声明（见 internal/adapters/http/permissions.go），
+   数据库中的权限表需要与路由声明保持一致。
+
+   子命令：
+   - sync   同步路由声明的权限到数据库，并列出无路由引用的孤儿权限
+	`,
+	Commands: []*cli.Command{
+		version.Command,
+		{
+			Name:  "sync",
+			Usage: "同步路由声明的权限到数据库",
+			Description: `创建路由引用但数据库中缺失的权限，更新说明已变化的权限，
+   并列出数据库中存在但没有任何路由引用的孤儿权限（孤儿权限不会被删除）。`,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "仅显示差异，不写入数据库",
+				},
+			},
+			Action: actionSync,
+		},
+	},
+}
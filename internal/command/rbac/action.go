@@ -0,0 +1,157 @@
+package rbac
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/lwmacct/251207-go-pkg-cfgm/pkg/cfgm"
+	"github.com/lwmacct/251207-go-pkg-version/pkg/version"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/application/rbacconfig"
+	"github.com/lwmacct/251117-go-ddd-template/internal/bootstrap"
+	"github.com/lwmacct/251117-go-ddd-template/internal/config"
+)
+
+// actionPlan 显示配置与数据库的差异
+func actionPlan(ctx context.Context, cmd *cli.Command) error {
+	doc, err := readDocument(cmd.String("file"))
+	if err != nil {
+		return err
+	}
+
+	return withContainer(ctx, cmd, func(container *bootstrap.Container) error {
+		plan, err := container.UseCases.RBACConfig.Plan.Handle(ctx, rbacconfig.PlanConfigQuery{
+			Document: doc,
+			Prune:    cmd.Bool("prune"),
+		})
+		if err != nil {
+			slog.Error("RBAC plan failed", "error", err)
+			return err
+		}
+
+		printPlan(os.Stdout, plan)
+		return nil
+	})
+}
+
+// actionApply 将数据库收敛到配置状态
+func actionApply(ctx context.Context, cmd *cli.Command) error {
+	doc, err := readDocument(cmd.String("file"))
+	if err != nil {
+		return err
+	}
+
+	return withContainer(ctx, cmd, func(container *bootstrap.Container) error {
+		applied, err := container.UseCases.RBACConfig.Apply.Handle(ctx, rbacconfig.ApplyConfigCommand{
+			Document: doc,
+			Prune:    cmd.Bool("prune"),
+		})
+		if err != nil {
+			slog.Error("RBAC apply failed, no changes were made", "error", err)
+			return err
+		}
+
+		printPlan(os.Stdout, applied)
+		slog.Info("RBAC apply completed", "changes", len(applied.Changes))
+		return nil
+	})
+}
+
+// actionExport 从数据库导出配置文件
+func actionExport(ctx context.Context, cmd *cli.Command) error {
+	return withContainer(ctx, cmd, func(container *bootstrap.Container) error {
+		doc, err := container.UseCases.RBACConfig.Export.Handle(ctx, rbacconfig.ExportConfigQuery{})
+		if err != nil {
+			slog.Error("RBAC export failed", "error", err)
+			return err
+		}
+
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return fmt.Errorf("failed to encode rbac config: %w", err)
+		}
+
+		output := cmd.String("output")
+		if output == "-" {
+			_, err = os.Stdout.Write(buf.Bytes())
+			return err
+		}
+		if err := os.WriteFile(output, buf.Bytes(), 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+		slog.Info("RBAC config exported", "file", output,
+			"permissions", len(doc.Permissions), "roles", len(doc.Roles), "menus", len(doc.Menus))
+		return nil
+	})
+}
+
+// withContainer 初始化依赖容器（apply 需要事件总线与 Redis 失效缓存）并在结束后关闭
+func withContainer(ctx context.Context, cmd *cli.Command, fn func(container *bootstrap.Container) error) error {
+	cfg := cfgm.MustLoadCmd(cmd, config.DefaultConfig(), version.AppRawName)
+
+	container, err := bootstrap.NewContainer(ctx, cfg, nil)
+	if err != nil {
+		slog.Error("Failed to initialize container", "error", err)
+		return err
+	}
+	defer func() {
+		if err := container.Close(); err != nil {
+			slog.Error("Failed to close container", "error", err)
+		}
+	}()
+
+	return fn(container)
+}
+
+// readDocument 读取并解析 YAML 配置文件，未知字段视为错误以尽早发现拼写问题
+func readDocument(path string) (*rbacconfig.Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var doc rbacconfig.Document
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &doc, nil
+}
+
+// printPlan 以 diff 风格输出变更（+ 创建，~ 更新，- 删除）
+func printPlan(w io.Writer, plan *rbacconfig.PlanDTO) {
+	symbols := map[string]string{
+		rbacconfig.ActionCreate: "+",
+		rbacconfig.ActionUpdate: "~",
+		rbacconfig.ActionDelete: "-",
+	}
+	counts := make(map[string]int, len(symbols))
+
+	for _, change := range plan.Changes {
+		counts[change.Action]++
+		_, _ = fmt.Fprintf(w, "%s %s %s\n", symbols[change.Action], change.Kind, change.Key)
+		for _, detail := range change.Details {
+			_, _ = fmt.Fprintf(w, "    %s\n", detail)
+		}
+	}
+	for _, warning := range plan.Warnings {
+		_, _ = fmt.Fprintf(w, "! %s\n", warning)
+	}
+
+	if !plan.HasChanges() {
+		_, _ = fmt.Fprintln(w, "No changes. Database matches the configuration.")
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to delete.\n",
+		counts[rbacconfig.ActionCreate], counts[rbacconfig.ActionUpdate], counts[rbacconfig.ActionDelete])
+}
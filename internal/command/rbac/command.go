@@ -0,0 +1,69 @@
+// Package rbac 提供 RBAC 声明式配置（配置即代码）命令
+package rbac
+
+import (
+	"github.com/lwmacct/251207-go-pkg-version/pkg/version"
+	"github.com/urfave/cli/v3"
+)
+
+// defaultConfigFile 默认的 RBAC 配置文件路径
+const defaultConfigFile = "rbac.yaml"
+
+// fileFlag 配置文件路径参数
+var fileFlag = &cli.StringFlag{
+	Name:    "file",
+	Aliases: []string{"f"},
+	Usage:   "RBAC 配置文件路径 (YAML)",
+	Value:   defaultConfigFile,
+}
+
+// pruneFlag 清理未声明对象参数
+var pruneFlag = &cli.BoolFlag{
+	Name:  "prune",
+	Usage: "删除配置中未声明的角色与菜单（系统角色永不删除）",
+}
+
+// Command 定义 RBAC 配置命令
+var Command = &cli.Command{
+	Name:  "rbac",
+	Usage: "RBAC 声明式配置管理",
+	Description: `
+   将角色、权限、角色授权（含策略条件）与菜单保存在 YAML 文件中，
+   与数据库对比并收敛。系统角色只读，配置不一致时仅输出警告。
+
+   子命令：
+   - plan    显示配置与数据库的差异
+   - apply   在单个事务中将数据库收敛到配置状态
+   - export  从数据库导出配置文件
+	`,
+	Commands: []*cli.Command{
+		version.Command,
+		{
+			Name:   "plan",
+			Usage:  "显示配置与数据库的差异",
+			Flags:  []cli.Flag{fileFlag, pruneFlag},
+			Action: actionPlan,
+		},
+		{
+			Name:  "apply",
+			Usage: "将数据库收敛到配置状态",
+			Description: `在单个事务中执行 plan 显示的全部变更，任一步骤失败则整体回滚。
+   提交后失效受影响角色的权限缓存与菜单树缓存。`,
+			Flags:  []cli.Flag{fileFlag, pruneFlag},
+			Action: actionApply,
+		},
+		{
+			Name:  "export",
+			Usage: "从数据库导出配置文件",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "输出文件路径，- 表示标准输出",
+					Value:   "-",
+				},
+			},
+			Action: actionExport,
+		},
+	},
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/lwmacct/251207-go-pkg-cfgm/pkg/cfgm"
 	"github.com/lwmacct/251207-go-pkg-version/pkg/version"
 	"github.com/urfave/cli/v3"
+
+	httpserver "github.com/lwmacct/251117-go-ddd-template/internal/adapters/http"
 )
 
 // action 执行种子数据填充
@@ -31,7 +33,9 @@ func action(ctx context.Context, cmd *cli.Command) error {
 	}()
 
 	// 创建种子管理器
-	manager := database.NewSeederManager(db, seeds.DefaultSeeders())
+	// 权限目录来自路由声明
+	permissions := httpserver.CollectRoutePermissions(cfg).Definitions()
+	manager := database.NewSeederManager(db, seeds.DefaultSeeders(permissions))
 
 	slog.Info("Running database seeders...")
 	if err := manager.Run(ctx); err != nil {
@@ -7,9 +7,11 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/bootstrap"
 	"github.com/lwmacct/251117-go-ddd-template/internal/config"
 	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/queue"
 	redisinfra "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/redis"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/scheduler"
 	"github.com/lwmacct/251207-go-pkg-cfgm/pkg/cfgm"
 	"github.com/lwmacct/251207-go-pkg-version/pkg/version"
 	"github.com/urfave/cli/v3"
@@ -21,10 +23,12 @@ func action(ctx context.Context, cmd *cli.Command) error {
 
 	queueName := cmd.String("queue")
 	concurrency := cmd.Int("concurrency")
+	withSchedule := cmd.Bool("schedule")
 
 	slog.Info("Starting worker",
 		"queue", queueName,
 		"concurrency", concurrency,
+		"schedule", withSchedule,
 	)
 
 	// 初始化 Redis 客户端（与 Telemetry 配置联动）
@@ -49,6 +53,27 @@ func action(ctx context.Context, cmd *cli.Command) error {
 	// 启动处理器 (在 goroutine 中)
 	go processor.Start(ctx)
 
+	// 启动周期性任务（限时角色授权等），需要完整的依赖容器
+	// 任务按名称在 Redis 中加锁，多个 worker 同时开启 --schedule 时每个间隔只有一个实例执行
+	var sched *scheduler.Scheduler
+	if withSchedule {
+		container, err := bootstrap.NewContainer(ctx, cfg, nil)
+		if err != nil {
+			slog.Error("Failed to initialize container", "error", err)
+			processor.Stop()
+			return err
+		}
+		defer func() {
+			if err := container.Close(); err != nil {
+				slog.Error("Failed to close container", "error", err)
+			}
+		}()
+
+		locker := redisinfra.NewSchedulerLocker(redisClient, cfg.Data.RedisKeyPrefix)
+		sched = scheduler.New(locker, container.ScheduledJobs...)
+		go sched.Start(ctx)
+	}
+
 	// 优雅关闭
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -56,6 +81,9 @@ func action(ctx context.Context, cmd *cli.Command) error {
 
 	slog.Info("Shutting down worker...")
 	processor.Stop()
+	if sched != nil {
+		sched.Stop()
+	}
 
 	slog.Info("Worker stopped")
 	return nil
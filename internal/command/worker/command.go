@@ -17,6 +17,10 @@ var Command = &cli.Command{
    示例：
    - 默认配置启动：worker
    - 指定队列和并发数：worker --queue jobs --concurrency 10
+   - 仅处理队列，不运行定时任务：worker --schedule=false
+
+   定时任务包括限时角色授权的激活与到期处理。
+   多个实例同时运行定时任务时，通过 Redis 锁保证每个任务在每个间隔内只执行一次。
 	`,
 	Action:   action,
 	Commands: []*cli.Command{version.Command},
@@ -33,5 +37,10 @@ var Command = &cli.Command{
 			Value:   5,
 			Usage:   "并发处理数",
 		},
+		&cli.BoolFlag{
+			Name:  "schedule",
+			Value: true,
+			Usage: "是否运行定时任务",
+		},
 	},
 }
@@ -11,6 +11,10 @@ type Server struct {
 	Env      string `koanf:"env" desc:"运行环境: development | production"`
 	WebDist  string `koanf:"web-dist" desc:"静态资源目录路径，用于提供前端文件服务 (如 SPA 应用)"`
 	DocsDist string `koanf:"docs-dist" desc:"文档目录路径，用于提供 VitePress 构建的文档服务，通过 /docs 路由访问"`
+
+	TLSCertFile  string `koanf:"tls-cert-file" desc:"TLS 证书文件路径，与 tls-key-file 同时配置时启用 HTTPS"`
+	TLSKeyFile   string `koanf:"tls-key-file" desc:"TLS 私钥文件路径"`
+	ClientCAFile string `koanf:"client-ca-file" desc:"客户端证书 CA 文件路径，配置后启用 mTLS 客户端证书认证 (需同时启用 HTTPS)"`
 }
 
 // Data 数据源配置
@@ -33,6 +37,63 @@ type Auth struct {
 	DevSecret       string `koanf:"dev-secret" desc:"开发模式密钥 (用于验证码开发模式) - ⚠️ 生产环境务必修改! 建议通过环境变量 APP_AUTH_DEV_SECRET 设置"`
 	TwoFAIssuer     string `koanf:"twofa-issuer" desc:"2FA TOTP 发行者名称，显示在用户的验证器应用中"`
 	CaptchaRequired bool   `koanf:"captcha-required" desc:"是否需要验证码 (可在生产环境强制开启以提升安全性)"`
+	SMSProvider     string `koanf:"sms-provider" desc:"短信验证码服务商: fake (仅记录日志，开发测试用) | 空 (禁用短信二次认证)"`
+
+	IdentityProviders string `koanf:"identity-providers" desc:"登录身份提供者列表，按顺序尝试: local (本地密码库) | ldap，多个用逗号分隔，例如 'local,ldap'"`
+
+	ClientCertAccounts string `koanf:"client-cert-accounts" desc:"mTLS 客户端证书 CN 到服务账号用户名的映射，格式: cn1=user1,cn2=user2 (为空时不启用证书认证)"`
+}
+
+// LDAP LDAP/Active Directory 身份提供者配置（auth.identity-providers 包含 ldap 时生效）
+type LDAP struct {
+	URL                string `koanf:"url" desc:"目录服务地址，例如 'ldaps://ldap.example.com:636' 或 'ldap://dc.example.com:389'"`
+	BindDN             string `koanf:"bind-dn" desc:"用于搜索用户的服务账号 DN，为空时匿名搜索"`
+	BindPassword       string `koanf:"bind-password" desc:"服务账号密码 - 建议通过环境变量 APP_LDAP_BIND_PASSWORD 设置"`
+	BaseDN             string `koanf:"base-dn" desc:"用户搜索根 DN，例如 'ou=people,dc=example,dc=com'"`
+	UserFilter         string `koanf:"user-filter" desc:"用户搜索过滤器，%s 为转义后的登录名；AD 可使用 '(sAMAccountName=%s)'"`
+	UsernameAttribute  string `koanf:"username-attribute" desc:"用户名属性 (AD 为 sAMAccountName)"`
+	EmailAttribute     string `koanf:"email-attribute" desc:"邮箱属性"`
+	NameAttribute      string `koanf:"name-attribute" desc:"显示名称属性"`
+	GroupAttribute     string `koanf:"group-attribute" desc:"用户所属组属性"`
+	GroupRoles         string `koanf:"group-roles" desc:"目录组到本地角色的映射，格式: group=role;group2=role2，组可以是 CN 或完整 DN，每次登录同步"`
+	StartTLS           bool   `koanf:"start-tls" desc:"是否在 ldap:// 连接上启用 StartTLS"`
+	InsecureSkipVerify bool   `koanf:"insecure-skip-verify" desc:"是否跳过服务端证书校验 (仅用于测试环境)"`
+}
+
+// Mail 邮件配置
+type Mail struct {
+	SMTPHost     string `koanf:"smtp-host" desc:"SMTP 服务器地址，为空时邮件仅输出到日志 (开发模式)"`
+	SMTPPort     int    `koanf:"smtp-port" desc:"SMTP 服务器端口"`
+	SMTPUsername string `koanf:"smtp-username" desc:"SMTP 用户名"`
+	SMTPPassword string `koanf:"smtp-password" desc:"SMTP 密码 - 建议通过环境变量 APP_MAIL_SMTP_PASSWORD 设置"`
+	From         string `koanf:"from" desc:"发件人地址"`
+}
+
+// Storage 文件存储配置（用户头像等上传文件）
+type Storage struct {
+	Driver   string `koanf:"driver" desc:"存储驱动: local (本地文件系统) | s3 (S3 兼容对象存储，如 AWS S3、MinIO)"`
+	LocalDir string `koanf:"local-dir" desc:"本地存储根目录 (driver 为 local 时使用)"`
+
+	S3Endpoint  string `koanf:"s3-endpoint" desc:"S3 服务地址，例如 'https://s3.amazonaws.com' 或 'http://minio:9000'"`
+	S3Region    string `koanf:"s3-region" desc:"S3 区域"`
+	S3Bucket    string `koanf:"s3-bucket" desc:"S3 存储桶"`
+	S3AccessKey string `koanf:"s3-access-key" desc:"S3 Access Key"`
+	S3SecretKey string `koanf:"s3-secret-key" desc:"S3 Secret Key - 建议通过环境变量 APP_STORAGE_S3_SECRET_KEY 设置"`
+	S3PathStyle bool   `koanf:"s3-path-style" desc:"是否使用路径风格地址 (endpoint/bucket/key)，MinIO 等自建服务通常需要开启"`
+
+	AvatarMaxSize int64 `koanf:"avatar-max-size" desc:"头像上传文件大小上限 (字节)"`
+}
+
+// Tenant 多租户配置
+type Tenant struct {
+	Header     string `koanf:"header" desc:"携带组织标识 (slug 或 ID) 的请求头名称"`
+	BaseDomain string `koanf:"base-domain" desc:"子域名解析的基础域名，例如 'example.com' 时 acme.example.com 解析为组织 acme (为空时不启用子域名解析)"`
+}
+
+// Approval 敏感变更双人审批配置
+type Approval struct {
+	Permissions string        `koanf:"permissions" desc:"需要双人审批的操作权限，支持通配符，多个用逗号分隔，例如 'admin:roles:update,admin:users:delete' (为空时不启用审批)"`
+	Timeout     time.Duration `koanf:"timeout" desc:"变更请求的审批期限，超时未审批自动过期 (格式: 1h, 24h 等)"`
 }
 
 // Telemetry OpenTelemetry 追踪配置
@@ -49,6 +110,11 @@ type Config struct {
 	Data      Data      `koanf:"data" desc:"数据源配置"`
 	JWT       JWT       `koanf:"jwt" desc:"JWT 认证配置"`
 	Auth      Auth      `koanf:"auth" desc:"认证配置"`
+	LDAP      LDAP      `koanf:"ldap" desc:"LDAP/Active Directory 身份提供者配置"`
+	Mail      Mail      `koanf:"mail" desc:"邮件配置"`
+	Storage   Storage   `koanf:"storage" desc:"文件存储配置"`
+	Tenant    Tenant    `koanf:"tenant" desc:"多租户 (组织) 配置"`
+	Approval  Approval  `koanf:"approval" desc:"敏感变更双人审批配置"`
 	Telemetry Telemetry `koanf:"telemetry" desc:"OpenTelemetry 追踪配置"`
 }
 
@@ -77,6 +143,36 @@ func DefaultConfig() Config {
 			DevSecret:       "dev-secret-change-me",
 			TwoFAIssuer:     "Go-DDD-Template",
 			CaptchaRequired: true, // 默认开启验证码
+			SMSProvider:     "",   // 默认禁用短信二次认证
+
+			IdentityProviders: "local", // 默认仅本地密码库
+		},
+		LDAP: LDAP{
+			UserFilter:        "(uid=%s)",
+			UsernameAttribute: "uid",
+			EmailAttribute:    "mail",
+			NameAttribute:     "cn",
+			GroupAttribute:    "memberOf",
+		},
+		Mail: Mail{
+			SMTPHost: "", // 默认仅输出到日志
+			SMTPPort: 587,
+			From:     "no-reply@example.com",
+		},
+		Storage: Storage{
+			Driver:        "local",
+			LocalDir:      "data/uploads",
+			S3Region:      "us-east-1",
+			S3PathStyle:   true,
+			AvatarMaxSize: 5 << 20, // 5 MiB
+		},
+		Tenant: Tenant{
+			Header:     "X-Organization",
+			BaseDomain: "", // 默认仅通过请求头解析
+		},
+		Approval: Approval{
+			Permissions: "", // 默认不启用审批
+			Timeout:     24 * time.Hour,
 		},
 		Telemetry: Telemetry{
 			Enabled:      false,  // 默认关闭，按需开启
@@ -0,0 +1,13 @@
+package approval
+
+import "context"
+
+// CommandRepository 定义变更请求写操作接口
+type CommandRepository interface {
+	// Create 创建变更请求
+	Create(ctx context.Context, request *ChangeRequest) error
+
+	// UpdateStatus 保存状态流转，仅当当前状态为 expected 时更新；
+	// 状态已被其他审批人改变时返回 ErrChangeRequestNotPending，防止同一请求被重复执行
+	UpdateStatus(ctx context.Context, request *ChangeRequest, expected Status) error
+}
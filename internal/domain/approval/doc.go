@@ -0,0 +1,14 @@
+// Package approval 定义敏感变更的双人审批（四眼原则）领域模型。
+//
+// 设置角色权限、分配用户角色、删除用户等操作一旦由单个管理员执行便立即生效。
+// 启用审批后，这些操作先生成待审批的变更请求，由另一名有权限的管理员批准后才执行。
+// 本包定义了：
+//   - [ChangeRequest]: 变更请求实体，Payload 保存原始命令的 JSON
+//   - [Status]: 变更请求状态（pending → approved/rejected/expired，执行失败时为 failed）
+//   - [CommandRepository]: 写仓储接口（状态流转使用条件更新，防止重复批准）
+//   - [QueryRepository]: 读仓储接口
+//   - 审批领域错误（见 errors.go）
+//
+// 依赖倒置：
+// 本包仅定义接口，实现位于 infrastructure/persistence 包。
+package approval
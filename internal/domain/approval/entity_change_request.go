@@ -0,0 +1,121 @@
+package approval
+
+import "time"
+
+// Status 变更请求状态
+type Status string
+
+// 变更请求状态常量
+const (
+	StatusPending  Status = "pending"  // 待审批
+	StatusApproved Status = "approved" // 已批准并执行成功
+	StatusRejected Status = "rejected" // 已拒绝（或申请人撤回）
+	StatusExpired  Status = "expired"  // 超时未审批
+	StatusFailed   Status = "failed"   // 已批准但执行失败
+)
+
+// ChangeRequest 变更请求实体
+type ChangeRequest struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Operation 受审批保护的操作标识，例如 "role.set_permissions"
+	Operation string `json:"operation"`
+	// ResourceID 操作目标资源 ID（角色 ID、用户 ID 等），便于检索
+	ResourceID string `json:"resource_id"`
+	// Payload 原始命令的 JSON，批准后据此执行
+	Payload string `json:"payload"`
+	Status  Status `json:"status"`
+
+	RequesterID   uint   `json:"requester_id"`
+	RequesterName string `json:"requester_name"`
+
+	ReviewerID    *uint      `json:"reviewer_id,omitempty"`
+	ReviewerName  string     `json:"reviewer_name,omitempty"`
+	ReviewComment string     `json:"review_comment,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+
+	// Error 执行失败原因（仅 StatusFailed）
+	Error string `json:"error,omitempty"`
+
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IsPending 检查是否待审批
+func (r *ChangeRequest) IsPending() bool {
+	return r.Status == StatusPending
+}
+
+// IsExpired 检查在 now 时刻是否已超过审批期限
+func (r *ChangeRequest) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Approve 批准变更请求，申请人不能批准自己的请求
+func (r *ChangeRequest) Approve(reviewerID uint, reviewerName, comment string, now time.Time) error {
+	if err := r.checkReviewable(now); err != nil {
+		return err
+	}
+	if reviewerID == r.RequesterID {
+		return ErrSelfApproval
+	}
+
+	r.Status = StatusApproved
+	r.review(reviewerID, reviewerName, comment, now)
+	return nil
+}
+
+// Reject 拒绝变更请求，申请人拒绝自己的请求即为撤回
+func (r *ChangeRequest) Reject(reviewerID uint, reviewerName, comment string, now time.Time) error {
+	if err := r.checkReviewable(now); err != nil {
+		return err
+	}
+
+	r.Status = StatusRejected
+	r.review(reviewerID, reviewerName, comment, now)
+	return nil
+}
+
+// Expire 将超过审批期限的待审批请求标记为过期
+func (r *ChangeRequest) Expire(now time.Time) error {
+	if !r.IsPending() {
+		return ErrChangeRequestNotPending
+	}
+	if !r.IsExpired(now) {
+		return nil
+	}
+
+	r.Status = StatusExpired
+	return nil
+}
+
+// MarkFailed 记录批准后执行失败
+func (r *ChangeRequest) MarkFailed(reason string) {
+	r.Status = StatusFailed
+	r.Error = reason
+}
+
+func (r *ChangeRequest) checkReviewable(now time.Time) error {
+	if !r.IsPending() {
+		return ErrChangeRequestNotPending
+	}
+	if r.IsExpired(now) {
+		return ErrChangeRequestExpired
+	}
+	return nil
+}
+
+func (r *ChangeRequest) review(reviewerID uint, reviewerName, comment string, now time.Time) {
+	r.ReviewerID = &reviewerID
+	r.ReviewerName = reviewerName
+	r.ReviewComment = comment
+	r.ReviewedAt = &now
+}
+
+// FilterOptions 变更请求过滤条件
+type FilterOptions struct {
+	Status      Status
+	Operation   string
+	RequesterID *uint
+}
@@ -0,0 +1,98 @@
+package approval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPendingRequest(now time.Time) *ChangeRequest {
+	return &ChangeRequest{
+		ID:          1,
+		Operation:   "user.delete",
+		Status:      StatusPending,
+		RequesterID: 10,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+}
+
+func TestChangeRequest_Approve(t *testing.T) {
+	now := time.Now()
+
+	t.Run("其他管理员批准", func(t *testing.T) {
+		r := newPendingRequest(now)
+
+		require.NoError(t, r.Approve(20, "reviewer", "ok", now))
+
+		assert.Equal(t, StatusApproved, r.Status)
+		require.NotNil(t, r.ReviewerID)
+		assert.Equal(t, uint(20), *r.ReviewerID)
+		assert.Equal(t, "reviewer", r.ReviewerName)
+		assert.Equal(t, "ok", r.ReviewComment)
+		require.NotNil(t, r.ReviewedAt)
+	})
+
+	t.Run("申请人不能批准自己的请求", func(t *testing.T) {
+		r := newPendingRequest(now)
+
+		require.ErrorIs(t, r.Approve(10, "requester", "", now), ErrSelfApproval)
+		assert.Equal(t, StatusPending, r.Status)
+	})
+
+	t.Run("已过期", func(t *testing.T) {
+		r := newPendingRequest(now)
+
+		require.ErrorIs(t, r.Approve(20, "reviewer", "", now.Add(time.Hour)), ErrChangeRequestExpired)
+	})
+
+	t.Run("已处理", func(t *testing.T) {
+		r := newPendingRequest(now)
+		r.Status = StatusRejected
+
+		require.ErrorIs(t, r.Approve(20, "reviewer", "", now), ErrChangeRequestNotPending)
+	})
+}
+
+func TestChangeRequest_Reject(t *testing.T) {
+	now := time.Now()
+
+	t.Run("申请人撤回", func(t *testing.T) {
+		r := newPendingRequest(now)
+
+		require.NoError(t, r.Reject(10, "requester", "withdrawn", now))
+		assert.Equal(t, StatusRejected, r.Status)
+	})
+
+	t.Run("已过期", func(t *testing.T) {
+		r := newPendingRequest(now)
+
+		require.ErrorIs(t, r.Reject(20, "reviewer", "", now.Add(2*time.Hour)), ErrChangeRequestExpired)
+	})
+}
+
+func TestChangeRequest_Expire(t *testing.T) {
+	now := time.Now()
+
+	t.Run("未到期时保持待审批", func(t *testing.T) {
+		r := newPendingRequest(now)
+
+		require.NoError(t, r.Expire(now))
+		assert.Equal(t, StatusPending, r.Status)
+	})
+
+	t.Run("到期后标记为过期", func(t *testing.T) {
+		r := newPendingRequest(now)
+
+		require.NoError(t, r.Expire(now.Add(time.Hour)))
+		assert.Equal(t, StatusExpired, r.Status)
+	})
+
+	t.Run("已处理的请求不能过期", func(t *testing.T) {
+		r := newPendingRequest(now)
+		r.Status = StatusApproved
+
+		require.ErrorIs(t, r.Expire(now.Add(time.Hour)), ErrChangeRequestNotPending)
+	})
+}
@@ -0,0 +1,23 @@
+package approval
+
+import "errors"
+
+var (
+	// ErrChangeRequestNotFound 变更请求不存在
+	ErrChangeRequestNotFound = errors.New("change request not found")
+
+	// ErrChangeRequestNotPending 变更请求已处理，不能再次审批
+	ErrChangeRequestNotPending = errors.New("change request is not pending")
+
+	// ErrChangeRequestExpired 变更请求已超过审批期限
+	ErrChangeRequestExpired = errors.New("change request has expired")
+
+	// ErrSelfApproval 申请人不能批准自己的变更请求
+	ErrSelfApproval = errors.New("requester cannot approve own change request")
+
+	// ErrReviewerNotAuthorized 审批人不具备执行该操作的权限
+	ErrReviewerNotAuthorized = errors.New("reviewer is not authorized for this operation")
+
+	// ErrUnknownOperation 操作不受审批流程支持
+	ErrUnknownOperation = errors.New("unknown change request operation")
+)
@@ -0,0 +1,18 @@
+package approval
+
+import (
+	"context"
+	"time"
+)
+
+// QueryRepository 定义变更请求读操作接口
+type QueryRepository interface {
+	// GetByID 根据 ID 获取变更请求，不存在时返回 ErrChangeRequestNotFound
+	GetByID(ctx context.Context, id uint) (*ChangeRequest, error)
+
+	// List 按过滤条件分页获取变更请求（按创建时间倒序）
+	List(ctx context.Context, filter FilterOptions, offset, limit int) ([]*ChangeRequest, int64, error)
+
+	// ListExpired 获取在 now 时刻已过期但仍为待审批状态的请求
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*ChangeRequest, error)
+}
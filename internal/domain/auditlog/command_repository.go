@@ -15,4 +15,7 @@ type CommandRepository interface {
 
 	// BatchCreate creates multiple audit log entries
 	BatchCreate(ctx context.Context, logs []*AuditLog) error
+
+	// AnonymizeUser replaces the username of the user's entries with pseudonym and clears IP address and user agent
+	AnonymizeUser(ctx context.Context, userID uint, pseudonym string) error
 }
@@ -1,6 +1,10 @@
 package auditlog
 
-import "time"
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+)
 
 // AuditLog 审计日志实体，用于追踪用户操作
 type AuditLog struct {
@@ -19,6 +23,19 @@ type AuditLog struct {
 	Status     string     `json:"status"`
 }
 
+// PolicyAttributes 返回用于 ABAC 策略判定的资源属性
+func (l *AuditLog) PolicyAttributes() policy.Attributes {
+	return policy.Attributes{
+		"id":          l.ID,
+		"user_id":     l.UserID,
+		"username":    l.Username,
+		"action":      l.Action,
+		"resource":    l.Resource,
+		"resource_id": l.ResourceID,
+		"status":      l.Status,
+	}
+}
+
 // FilterOptions 审计日志过滤条件
 type FilterOptions struct {
 	UserID    *uint
@@ -29,6 +46,9 @@ type FilterOptions struct {
 	EndDate   *time.Time
 	Page      int
 	Limit     int
+
+	// Policy ABAC 策略过滤条件，为 nil 时不做限制
+	Policy *policy.Filter
 }
 
 // 操作状态常量
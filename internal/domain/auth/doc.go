@@ -3,7 +3,9 @@
 // 本包是认证系统的领域层核心，定义了：
 //   - [Service]: 认证领域服务接口（密码管理、Token 生成与验证）
 //   - [PasswordPolicy]: 密码策略值对象
+//   - [RegistrationPolicy]: 注册策略值对象（注册模式 + 邮箱域名白名单）
 //   - [TokenClaims]: JWT Token 声明结构
+//   - [PermissionCacheInspector]: 权限缓存查看接口（授权解释使用）
 //   - 认证相关错误（见 errors.go）
 //
 // 认证模式：
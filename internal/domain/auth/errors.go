@@ -28,6 +28,9 @@ var (
 	// ErrTokenExpired Token 已过期
 	ErrTokenExpired = errors.New("token has expired")
 
+	// ErrTokenRevoked Token 已随用户会话一并吊销（如用户被封禁）
+	ErrTokenRevoked = errors.New("token has been revoked")
+
 	// ErrInvalidCaptcha 验证码无效
 	ErrInvalidCaptcha = errors.New("invalid captcha")
 
@@ -40,6 +43,18 @@ var (
 	// ErrSessionNotFound Session 不存在
 	ErrSessionNotFound = errors.New("session not found")
 
+	// ErrPasswordChangeRequired 需要先修改密码
+	ErrPasswordChangeRequired = errors.New("password change required")
+
+	// ErrRegistrationClosed 注册已关闭
+	ErrRegistrationClosed = errors.New("registration is closed")
+
+	// ErrInvitationRequired 仅允许受邀注册
+	ErrInvitationRequired = errors.New("registration requires an invitation")
+
+	// ErrEmailDomainNotAllowed 邮箱域名不在允许列表
+	ErrEmailDomainNotAllowed = errors.New("email domain is not allowed")
+
 	// ErrSessionExpired Session 已过期
 	ErrSessionExpired = errors.New("session has expired")
 )
@@ -0,0 +1,26 @@
+package auth
+
+import "context"
+
+// ProviderLocal 本地密码库身份提供者名称
+const ProviderLocal = "local"
+
+// ExternalIdentity 外部身份提供者认证成功后返回的用户信息
+type ExternalIdentity struct {
+	Provider   string   // 提供者名称，如 "ldap"
+	ExternalID string   // 目录中的唯一标识，如 DN
+	Username   string   // 规范化的用户名
+	Email      string   // 邮箱
+	FullName   string   // 显示名称
+	Groups     []string // 目录中的所属组
+	Roles      []string // 按组映射得到的本地角色名称
+}
+
+// IdentityProvider 外部身份提供者（如 LDAP/Active Directory）
+//
+// Authenticate 使用用户名和密码认证，凭证无效时返回 [ErrInvalidCredentials]，
+// 目录不可用等其他错误原样返回。
+type IdentityProvider interface {
+	Name() string
+	Authenticate(ctx context.Context, username, password string) (*ExternalIdentity, error)
+}
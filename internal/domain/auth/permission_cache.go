@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// PermissionCacheEntry 权限缓存条目快照
+type PermissionCacheEntry struct {
+	Cached      bool
+	Roles       []string
+	Permissions []string
+	TTL         time.Duration // 剩余有效期
+}
+
+// PermissionCacheInspector 权限缓存查看接口
+//
+// 仅读取缓存现状，不会在未命中时回填缓存；organizationID 为 0 时查看全局权限缓存。
+type PermissionCacheInspector interface {
+	InspectPermissions(ctx context.Context, organizationID, userID uint) (*PermissionCacheEntry, error)
+}
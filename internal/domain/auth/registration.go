@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"slices"
+	"strings"
+)
+
+// RegistrationMode 注册模式值对象。
+// 由系统配置 security.registration_mode 决定自助注册接口的行为。
+type RegistrationMode string
+
+// 注册模式常量。
+const (
+	RegistrationOpen       RegistrationMode = "open"        // 开放注册，注册后立即可登录
+	RegistrationClosed     RegistrationMode = "closed"      // 关闭注册，仅管理员可创建用户
+	RegistrationInviteOnly RegistrationMode = "invite_only" // 仅邀请，用户通过管理员邀请链接激活
+	RegistrationApproval   RegistrationMode = "approval"    // 需审批，注册后为 inactive，待管理员审批
+)
+
+// ParseRegistrationMode 解析注册模式，空值或无效值按开放注册处理
+func ParseRegistrationMode(s string) RegistrationMode {
+	switch m := RegistrationMode(strings.ToLower(strings.TrimSpace(s))); m {
+	case RegistrationClosed, RegistrationInviteOnly, RegistrationApproval:
+		return m
+	default:
+		return RegistrationOpen
+	}
+}
+
+// RegistrationPolicy 注册策略值对象。
+// 组合注册模式与邮箱域名白名单，白名单为空表示不限制域名。
+type RegistrationPolicy struct {
+	Mode           RegistrationMode
+	AllowedDomains []string
+}
+
+// Check 检查自助注册是否允许，返回对应的领域错误
+func (p *RegistrationPolicy) Check(email string) error {
+	switch p.Mode {
+	case RegistrationClosed:
+		return ErrRegistrationClosed
+	case RegistrationInviteOnly:
+		return ErrInvitationRequired
+	case RegistrationOpen, RegistrationApproval:
+	}
+	if !p.AllowsEmail(email) {
+		return ErrEmailDomainNotAllowed
+	}
+	return nil
+}
+
+// RequiresApproval 检查新注册用户是否需要管理员审批
+func (p *RegistrationPolicy) RequiresApproval() bool {
+	return p.Mode == RegistrationApproval
+}
+
+// AllowsEmail 检查邮箱域名是否在白名单内（忽略大小写，支持子域名）
+func (p *RegistrationPolicy) AllowsEmail(email string) bool {
+	if len(p.AllowedDomains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+
+	return slices.ContainsFunc(p.AllowedDomains, func(allowed string) bool {
+		allowed = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(allowed), "@"))
+		return allowed != "" && (domain == allowed || strings.HasSuffix(domain, "."+allowed))
+	})
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRegistrationMode(t *testing.T) {
+	assert.Equal(t, RegistrationOpen, ParseRegistrationMode(""))
+	assert.Equal(t, RegistrationOpen, ParseRegistrationMode("unknown"))
+	assert.Equal(t, RegistrationClosed, ParseRegistrationMode("closed"))
+	assert.Equal(t, RegistrationInviteOnly, ParseRegistrationMode(" Invite_Only "))
+	assert.Equal(t, RegistrationApproval, ParseRegistrationMode("approval"))
+}
+
+func TestRegistrationPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RegistrationPolicy
+		email   string
+		wantErr error
+	}{
+		{"开放注册", RegistrationPolicy{Mode: RegistrationOpen}, "a@example.com", nil},
+		{"关闭注册", RegistrationPolicy{Mode: RegistrationClosed}, "a@example.com", ErrRegistrationClosed},
+		{"仅邀请", RegistrationPolicy{Mode: RegistrationInviteOnly}, "a@example.com", ErrInvitationRequired},
+		{"需审批允许注册", RegistrationPolicy{Mode: RegistrationApproval}, "a@example.com", nil},
+		{"域名在白名单", RegistrationPolicy{Mode: RegistrationOpen, AllowedDomains: []string{"example.com"}}, "a@Example.COM", nil},
+		{"子域名在白名单", RegistrationPolicy{Mode: RegistrationOpen, AllowedDomains: []string{"@example.com"}}, "a@dev.example.com", nil},
+		{"域名不在白名单", RegistrationPolicy{Mode: RegistrationOpen, AllowedDomains: []string{"example.com"}}, "a@badexample.com", ErrEmailDomainNotAllowed},
+		{"无效邮箱", RegistrationPolicy{Mode: RegistrationOpen, AllowedDomains: []string{"example.com"}}, "invalid", ErrEmailDomainNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.policy.Check(tt.email), tt.wantErr)
+		})
+	}
+}
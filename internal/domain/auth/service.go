@@ -78,14 +78,18 @@ type Service interface {
 	// 新架构：Token 只包含 user_id/username，权限信息从缓存实时查询
 	GenerateAccessToken(ctx context.Context, userID uint, username string) (string, time.Time, error)
 
+	// GenerateScopedAccessToken 生成受限作用域的访问令牌
+	// 受限令牌仅能访问作用域允许的接口（如强制修改密码）
+	GenerateScopedAccessToken(ctx context.Context, userID uint, username, scope string) (string, time.Time, error)
+
 	// GenerateRefreshToken 生成刷新令牌
 	GenerateRefreshToken(ctx context.Context, userID uint) (string, time.Time, error)
 
 	// ValidateAccessToken 验证访问令牌
 	ValidateAccessToken(ctx context.Context, token string) (*TokenClaims, error)
 
-	// ValidateRefreshToken 验证刷新令牌
-	ValidateRefreshToken(ctx context.Context, token string) (uint, error)
+	// ValidateRefreshToken 验证刷新令牌，返回用户 ID 与签发时间
+	ValidateRefreshToken(ctx context.Context, token string) (uint, time.Time, error)
 
 	// GeneratePATToken 生成个人访问令牌
 	GeneratePATToken(ctx context.Context) (string, error)
@@ -94,6 +98,12 @@ type Service interface {
 	HashPATToken(ctx context.Context, token string) string
 }
 
+// 访问令牌作用域常量。
+// 空作用域表示完整令牌；受限令牌只能访问作用域允许的接口。
+const (
+	TokenScopePasswordChange = "password_change" // 仅允许修改密码
+)
+
 // TokenClaims Token 声明
 type TokenClaims struct {
 	UserID   uint     `json:"user_id"`
@@ -0,0 +1,45 @@
+package events
+
+import (
+	"strconv"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+)
+
+// ============================================================================
+// 用户组事件
+// ============================================================================
+
+// GroupMembershipChangedEvent 用户组成员变更事件（加入、移除、用户组删除）
+// 用于失效受影响用户的权限缓存
+type GroupMembershipChangedEvent struct {
+	event.BaseEvent
+
+	GroupID uint   `json:"group_id"`
+	UserIDs []uint `json:"user_ids"`
+}
+
+// NewGroupMembershipChangedEvent 创建用户组成员变更事件
+func NewGroupMembershipChangedEvent(groupID uint, userIDs []uint) *GroupMembershipChangedEvent {
+	return &GroupMembershipChangedEvent{
+		BaseEvent: event.NewBaseEvent("group.membership_changed", "group", strconv.FormatUint(uint64(groupID), 10)),
+		GroupID:   groupID,
+		UserIDs:   userIDs,
+	}
+}
+
+// GroupRolesChangedEvent 用户组角色变更事件
+// 用于失效该用户组全部成员的权限缓存
+type GroupRolesChangedEvent struct {
+	event.BaseEvent
+
+	GroupID uint `json:"group_id"`
+}
+
+// NewGroupRolesChangedEvent 创建用户组角色变更事件
+func NewGroupRolesChangedEvent(groupID uint) *GroupRolesChangedEvent {
+	return &GroupRolesChangedEvent{
+		BaseEvent: event.NewBaseEvent("group.roles_changed", "group", strconv.FormatUint(uint64(groupID), 10)),
+		GroupID:   groupID,
+	}
+}
@@ -0,0 +1,29 @@
+package events
+
+import (
+	"strconv"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/event"
+)
+
+// ============================================================================
+// 组织事件
+// ============================================================================
+
+// OrganizationMemberChangedEvent 组织成员变更事件（加入、移除、组织内角色变更）
+// 用于失效成员在该组织内的权限缓存
+type OrganizationMemberChangedEvent struct {
+	event.BaseEvent
+
+	OrganizationID uint `json:"organization_id"`
+	UserID         uint `json:"user_id"`
+}
+
+// NewOrganizationMemberChangedEvent 创建组织成员变更事件
+func NewOrganizationMemberChangedEvent(organizationID, userID uint) *OrganizationMemberChangedEvent {
+	return &OrganizationMemberChangedEvent{
+		BaseEvent:      event.NewBaseEvent("organization.member_changed", "organization", strconv.FormatUint(uint64(organizationID), 10)),
+		OrganizationID: organizationID,
+		UserID:         userID,
+	}
+}
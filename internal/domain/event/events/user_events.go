@@ -83,15 +83,21 @@ type UserStatusChangedEvent struct {
 	UserID    uint   `json:"user_id"`
 	OldStatus string `json:"old_status"`
 	NewStatus string `json:"new_status"`
+	Reason    string `json:"reason,omitempty"`
+
+	// OperatorID 操作者，0 表示系统（如封禁到期自动解封）
+	OperatorID uint `json:"operator_id"`
 }
 
 // NewUserStatusChangedEvent 创建用户状态变更事件
-func NewUserStatusChangedEvent(userID uint, oldStatus, newStatus string) *UserStatusChangedEvent {
+func NewUserStatusChangedEvent(userID uint, oldStatus, newStatus, reason string, operatorID uint) *UserStatusChangedEvent {
 	return &UserStatusChangedEvent{
-		BaseEvent: event.NewBaseEvent("user.status_changed", "user", strconv.FormatUint(uint64(userID), 10)),
-		UserID:    userID,
-		OldStatus: oldStatus,
-		NewStatus: newStatus,
+		BaseEvent:  event.NewBaseEvent("user.status_changed", "user", strconv.FormatUint(uint64(userID), 10)),
+		UserID:     userID,
+		OldStatus:  oldStatus,
+		NewStatus:  newStatus,
+		Reason:     reason,
+		OperatorID: operatorID,
 	}
 }
 
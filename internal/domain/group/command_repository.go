@@ -0,0 +1,24 @@
+package group
+
+import "context"
+
+// CommandRepository 定义用户组写操作接口
+type CommandRepository interface {
+	// Create 创建用户组（不含角色，角色通过 SetRoles 设置）
+	Create(ctx context.Context, group *Group) error
+
+	// Update 更新用户组基本信息
+	Update(ctx context.Context, group *Group) error
+
+	// Delete 删除用户组（同时移除成员关系与角色分配）
+	Delete(ctx context.Context, id uint) error
+
+	// AddMembers 添加成员，已是成员的用户忽略
+	AddMembers(ctx context.Context, groupID uint, userIDs []uint) error
+
+	// RemoveMembers 移除成员，非成员的用户忽略
+	RemoveMembers(ctx context.Context, groupID uint, userIDs []uint) error
+
+	// SetRoles 设置用户组的角色（替换现有角色）
+	SetRoles(ctx context.Context, groupID uint, roleIDs []uint) error
+}
@@ -0,0 +1,17 @@
+// Package group 定义用户组领域模型。
+//
+// 按部门等维度批量授权时，逐个用户分配角色难以维护。
+// 用户组聚合了成员关系与角色分配，本包定义了：
+//   - [Group]: 用户组实体，Roles 为组内成员共同获得的角色
+//   - [Member]: 用户组成员关系
+//   - [CommandRepository]: 写仓储接口（用户组、成员与角色管理）
+//   - [QueryRepository]: 读仓储接口
+//   - 用户组领域错误（见 errors.go）
+//
+// 有效角色：
+// 用户的有效角色为直接分配的角色与所在用户组角色的并集（见 user.User.EffectiveRoles）。
+// 用户组是平台级资源，只能持有全局角色，租户角色需通过组织成员关系分配。
+//
+// 依赖倒置：
+// 本包仅定义接口，实现位于 infrastructure/persistence 包。
+package group
@@ -0,0 +1,60 @@
+package group
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// namePattern 用户组名称格式：字母开头，允许字母、数字、下划线、连字符和点
+var namePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]{1,49}$`)
+
+// Group 用户组实体
+type Group struct {
+	ID          uint        `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Roles       []role.Role `json:"roles,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	DeletedAt   *time.Time  `json:"-"`
+}
+
+// RoleIDs 返回用户组持有的角色 ID
+func (g *Group) RoleIDs() []uint {
+	ids := make([]uint, 0, len(g.Roles))
+	for _, r := range g.Roles {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+// GetRoleNames 返回用户组持有的角色名称
+func (g *Group) GetRoleNames() []string {
+	names := make([]string, 0, len(g.Roles))
+	for _, r := range g.Roles {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// CanHoldRole 检查角色能否分配给用户组（用户组为平台级资源，仅可持有全局角色）
+func (g *Group) CanHoldRole(r *role.Role) bool {
+	return r.IsGlobal()
+}
+
+// Member 用户组成员关系
+type Member struct {
+	GroupID   uint      `json:"group_id"`
+	UserID    uint      `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ValidateName 校验用户组名称
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return ErrInvalidGroupName
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package group
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"字母", "engineering", false},
+		{"包含数字和分隔符", "dept-42_ops.cn", false},
+		{"大写字母", "Finance", false},
+		{"数字开头", "42dept", true},
+		{"单字符", "a", true},
+		{"包含空格", "sales team", true},
+		{"空字符串", "", true},
+		{"超过 50 字符", "a12345678901234567890123456789012345678901234567890", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateName(tt.input)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidGroupName)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGroup_Roles(t *testing.T) {
+	g := &Group{Roles: []role.Role{{ID: 1, Name: "viewer"}, {ID: 3, Name: "editor"}}}
+
+	assert.Equal(t, []uint{1, 3}, g.RoleIDs())
+	assert.Equal(t, []string{"viewer", "editor"}, g.GetRoleNames())
+}
+
+func TestGroup_CanHoldRole(t *testing.T) {
+	orgID := uint(7)
+	g := &Group{}
+
+	assert.True(t, g.CanHoldRole(&role.Role{Name: "viewer"}))
+	assert.False(t, g.CanHoldRole(&role.Role{Name: "acme-admin", OrganizationID: &orgID}))
+}
@@ -0,0 +1,17 @@
+package group
+
+import "errors"
+
+var (
+	// ErrGroupNotFound 用户组不存在
+	ErrGroupNotFound = errors.New("group not found")
+
+	// ErrGroupNameExists 用户组名称已存在
+	ErrGroupNameExists = errors.New("group name already exists")
+
+	// ErrInvalidGroupName 用户组名称格式无效
+	ErrInvalidGroupName = errors.New("invalid group name")
+
+	// ErrRoleNotGlobal 用户组只能持有全局角色
+	ErrRoleNotGlobal = errors.New("groups can only hold global roles")
+)
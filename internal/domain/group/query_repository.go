@@ -0,0 +1,30 @@
+package group
+
+import "context"
+
+// QueryRepository 定义用户组读操作接口
+type QueryRepository interface {
+	// GetByID 根据 ID 获取用户组（包含角色），不存在时返回 ErrGroupNotFound
+	GetByID(ctx context.Context, id uint) (*Group, error)
+
+	// GetByName 根据名称获取用户组（包含角色），不存在时返回 ErrGroupNotFound
+	GetByName(ctx context.Context, name string) (*Group, error)
+
+	// ExistsByName 检查用户组名称是否已存在
+	ExistsByName(ctx context.Context, name string) (bool, error)
+
+	// List 分页获取用户组列表（包含角色）
+	List(ctx context.Context, offset, limit int) ([]*Group, int64, error)
+
+	// ListByUser 获取用户所在的全部用户组（包含角色）
+	ListByUser(ctx context.Context, userID uint) ([]*Group, error)
+
+	// ListMembers 分页获取用户组成员
+	ListMembers(ctx context.Context, groupID uint, offset, limit int) ([]*Member, int64, error)
+
+	// GetMemberIDs 获取用户组全部成员的用户 ID（用于缓存失效）
+	GetMemberIDs(ctx context.Context, groupID uint) ([]uint, error)
+
+	// GetUserIDsByRole 获取经由用户组持有指定角色的用户 ID（用于缓存失效）
+	GetUserIDsByRole(ctx context.Context, roleID uint) ([]uint, error)
+}
@@ -4,6 +4,7 @@
 //   - [Menu]: 菜单实体，支持树形结构
 //   - [CommandRepository]: 写仓储接口（创建、更新、删除）
 //   - [QueryRepository]: 读仓储接口（查询、树形构建）
+//   - [TreeCache]: 按权限集合缓存的菜单树
 //   - 菜单领域错误（见 errors.go）
 //
 // 树形结构：
@@ -18,7 +19,8 @@
 //   - [Menu.Order]: 定义同级菜单的显示顺序
 //
 // RBAC 集成：
-// [Menu] 实体通常与权限系统配合使用，实现基于角色的菜单过滤。
+// [Menu.Permission] 声明访问菜单所需的权限（支持通配符），
+// [PruneTree] 按用户权限集合裁剪菜单树，[TreeCache] 按权限集合缓存裁剪结果。
 //
 // 依赖倒置：
 // 本包仅定义接口，实现位于 infrastructure/persistence 包。
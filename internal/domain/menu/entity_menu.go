@@ -1,22 +1,29 @@
 package menu
 
 import (
+	"cmp"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 )
 
 // Menu 菜单实体
 type Menu struct {
-	ID        uint       `json:"id"`
-	Title     string     `json:"title"`
-	Path      string     `json:"path"`
-	Icon      string     `json:"icon"`
-	ParentID  *uint      `json:"parent_id"`
-	Order     int        `json:"order"`
-	Visible   bool       `json:"visible"`
-	Children  []*Menu    `json:"children,omitempty"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"-"`
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Path     string `json:"path"`
+	Icon     string `json:"icon"`
+	ParentID *uint  `json:"parent_id"`
+	Order    int    `json:"order"`
+	Visible  bool   `json:"visible"`
+	// Permission 访问该菜单所需的权限代码（domain:resource:action，支持通配符），空表示登录即可访问
+	Permission string     `json:"permission,omitempty"`
+	Children   []*Menu    `json:"children,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"-"`
 }
 
 // IsRoot 检查是否为顶级菜单
@@ -97,3 +104,68 @@ func (m *Menu) FindChild(id uint) *Menu {
 	}
 	return nil
 }
+
+// RequiresPermission 检查菜单是否声明了访问权限
+func (m *Menu) RequiresPermission() bool {
+	return m.Permission != ""
+}
+
+// ValidatePermission 校验菜单声明的权限代码格式（三段式，允许通配符 *）
+func (m *Menu) ValidatePermission() error {
+	if !m.RequiresPermission() || m.Permission == "*" {
+		return nil
+	}
+	parts := strings.Split(m.Permission, ":")
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return ErrInvalidMenuPermission
+	}
+	return nil
+}
+
+// AccessibleBy 检查持有指定权限集合的用户能否访问该菜单（不考虑可见性）
+//
+// 匹配是双向的：用户的通配权限（如 "admin:*:*"）覆盖具体的菜单权限；
+// 菜单声明的通配权限（如 "admin:users:*"）则在用户持有其下任一权限时放行。
+func (m *Menu) AccessibleBy(permissions []string) bool {
+	if !m.RequiresPermission() {
+		return true
+	}
+	for _, p := range permissions {
+		if role.MatchCode(p, m.Permission) || role.MatchCode(m.Permission, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// PruneTree 按权限集合裁剪菜单树，返回新的树（不修改入参）
+//
+// 裁剪规则：
+//   - 隐藏菜单及其子树被移除
+//   - 无权访问的菜单及其子树被移除
+//   - 未声明权限的分组菜单若子菜单全部被移除，则一并移除
+//
+// 同级菜单按 Order、ID 升序排列。
+func PruneTree(menus []*Menu, permissions []string) []*Menu {
+	result := make([]*Menu, 0, len(menus))
+	for _, m := range menus {
+		if m == nil || !m.IsVisible() || !m.AccessibleBy(permissions) {
+			continue
+		}
+
+		pruned := *m
+		pruned.Children = nil
+		if m.HasChildren() {
+			pruned.Children = PruneTree(m.Children, permissions)
+			if len(pruned.Children) == 0 && !m.RequiresPermission() {
+				continue
+			}
+		}
+		result = append(result, &pruned)
+	}
+
+	slices.SortStableFunc(result, func(a, b *Menu) int {
+		return cmp.Or(cmp.Compare(a.Order, b.Order), cmp.Compare(a.ID, b.ID))
+	})
+	return result
+}
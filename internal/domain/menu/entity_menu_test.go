@@ -210,3 +210,69 @@ func TestMenu_FindChild(t *testing.T) {
 func ptrUint(v uint) *uint {
 	return &v
 }
+
+func TestMenu_AccessibleBy(t *testing.T) {
+	tests := []struct {
+		name        string
+		permission  string
+		permissions []string
+		want        bool
+	}{
+		{name: "未声明权限", permission: "", permissions: nil, want: true},
+		{name: "精确匹配", permission: "admin:users:read", permissions: []string{"admin:users:read"}, want: true},
+		{name: "用户持有通配权限", permission: "admin:users:read", permissions: []string{"admin:*:*"}, want: true},
+		{name: "菜单声明通配权限", permission: "admin:users:*", permissions: []string{"admin:users:update"}, want: true},
+		{name: "无匹配权限", permission: "admin:users:*", permissions: []string{"admin:roles:read"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Menu{Permission: tt.permission}
+			assert.Equal(t, tt.want, m.AccessibleBy(tt.permissions))
+		})
+	}
+}
+
+func TestMenu_ValidatePermission(t *testing.T) {
+	assert.NoError(t, (&Menu{}).ValidatePermission())
+	assert.NoError(t, (&Menu{Permission: "admin:users:*"}).ValidatePermission())
+	assert.ErrorIs(t, (&Menu{Permission: "admin:users"}).ValidatePermission(), ErrInvalidMenuPermission)
+	assert.ErrorIs(t, (&Menu{Permission: "admin::read"}).ValidatePermission(), ErrInvalidMenuPermission)
+}
+
+func TestPruneTree(t *testing.T) {
+	system := newTestMenu(1, "system", nil)
+	users := newTestMenu(2, "users", ptrUint(1))
+	users.Permission = "admin:users:read"
+	users.Order = 2
+	roles := newTestMenu(3, "roles", ptrUint(1))
+	roles.Permission = "admin:roles:read"
+	roles.Order = 1
+	hidden := newTestMenu(4, "hidden", ptrUint(1))
+	hidden.Visible = false
+	system.Children = []*Menu{users, roles, hidden}
+	dashboard := newTestMenu(5, "dashboard", nil)
+
+	t.Run("保留有权访问的菜单并按顺序排列", func(t *testing.T) {
+		tree := PruneTree([]*Menu{system, dashboard}, []string{"admin:*:read"})
+
+		assert.Len(t, tree, 2)
+		assert.Equal(t, uint(1), tree[0].ID)
+		assert.Len(t, tree[0].Children, 2)
+		assert.Equal(t, uint(3), tree[0].Children[0].ID)
+		assert.Equal(t, uint(2), tree[0].Children[1].ID)
+	})
+
+	t.Run("子菜单全部无权时移除分组", func(t *testing.T) {
+		tree := PruneTree([]*Menu{system, dashboard}, []string{"user:profile:read"})
+
+		assert.Len(t, tree, 1)
+		assert.Equal(t, uint(5), tree[0].ID)
+	})
+
+	t.Run("不修改原始菜单树", func(t *testing.T) {
+		PruneTree([]*Menu{system}, nil)
+
+		assert.Len(t, system.Children, 3)
+	})
+}
@@ -21,6 +21,9 @@ var (
 	// ErrInvalidMenuOrder 无效的菜单排序
 	ErrInvalidMenuOrder = errors.New("invalid menu order")
 
+	// ErrInvalidMenuPermission 无效的菜单权限代码
+	ErrInvalidMenuPermission = errors.New("invalid menu permission")
+
 	// ErrDuplicateMenuPath 菜单路径重复
 	ErrDuplicateMenuPath = errors.New("duplicate menu path")
 )
@@ -0,0 +1,18 @@
+package menu
+
+import "context"
+
+// TreeCache 按权限集合缓存裁剪后的菜单树
+//
+// 相同权限集合的用户共享同一份缓存；菜单发生任何变更时整体失效。
+// 缓存读写失败不应影响业务，实现方自行记录错误，读取失败时按未命中处理。
+type TreeCache interface {
+	// Get 获取权限集合对应的菜单树，未命中时第二个返回值为 false
+	Get(ctx context.Context, permissionSetKey string) ([]*Menu, bool)
+
+	// Set 缓存权限集合对应的菜单树
+	Set(ctx context.Context, permissionSetKey string, menus []*Menu)
+
+	// Invalidate 使所有已缓存的菜单树失效
+	Invalidate(ctx context.Context)
+}
@@ -0,0 +1,24 @@
+package organization
+
+import "context"
+
+// CommandRepository 定义组织写操作接口
+type CommandRepository interface {
+	// Create 创建组织
+	Create(ctx context.Context, org *Organization) error
+
+	// Update 更新组织
+	Update(ctx context.Context, org *Organization) error
+
+	// Delete 删除组织（同时移除所有成员关系）
+	Delete(ctx context.Context, id uint) error
+
+	// AddMember 添加组织成员
+	AddMember(ctx context.Context, member *Member) error
+
+	// RemoveMember 移除组织成员
+	RemoveMember(ctx context.Context, organizationID, userID uint) error
+
+	// SetMemberRoles 设置成员在组织内的角色（替换现有角色）
+	SetMemberRoles(ctx context.Context, organizationID, userID uint, roleIDs []uint) error
+}
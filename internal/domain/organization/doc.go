@@ -0,0 +1,22 @@
+// Package organization 定义组织（租户）领域模型。
+//
+// 同一部署服务多个客户组织，本包定义了：
+//   - [Organization]: 组织实体，Slug 同时用作子域名
+//   - [Member]: 组织成员，用户可以加入多个组织，并在每个组织中持有不同角色
+//   - [CommandRepository]: 写仓储接口（组织与成员管理）
+//   - [QueryRepository]: 读仓储接口
+//   - 组织领域错误（见 errors.go）
+//
+// 租户上下文：
+// [WithTenant] 将当前请求的组织写入 context，[TenantFromContext] 读取。
+// 持久化层据此自动为租户隔离的数据附加 organization_id 条件，
+// 避免查询跨租户泄漏数据；未携带租户的 context 表示平台级（全局）访问。
+//
+// 租户角色：
+// 角色的 OrganizationID 为空时为全局角色，所有组织可见；
+// 在租户上下文中创建的角色归属该组织，仅在该组织内可见和可分配。
+// 成员在组织内的有效权限为其全局角色与组织角色权限的并集。
+//
+// 依赖倒置：
+// 本包仅定义接口，实现位于 infrastructure/persistence 包。
+package organization
@@ -0,0 +1,57 @@
+package organization
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+// Member 组织成员实体
+// 用户可以加入多个组织，Roles 为其在该组织内持有的角色
+type Member struct {
+	ID             uint        `json:"id"`
+	OrganizationID uint        `json:"organization_id"`
+	UserID         uint        `json:"user_id"`
+	Roles          []role.Role `json:"roles,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// RoleIDs 返回成员在组织内持有的角色 ID
+func (m *Member) RoleIDs() []uint {
+	ids := make([]uint, 0, len(m.Roles))
+	for _, r := range m.Roles {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+// GetRoleNames 返回成员在组织内持有的角色名称
+func (m *Member) GetRoleNames() []string {
+	names := make([]string, 0, len(m.Roles))
+	for _, r := range m.Roles {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// GetPermissionCodes 返回成员在组织内的有效权限代码（含继承权限，已去重）
+func (m *Member) GetPermissionCodes() []string {
+	seen := make(map[string]struct{})
+	var codes []string
+	for i := range m.Roles {
+		for _, p := range m.Roles[i].EffectivePermissions() {
+			if _, ok := seen[p.Code]; ok {
+				continue
+			}
+			seen[p.Code] = struct{}{}
+			codes = append(codes, p.Code)
+		}
+	}
+	return codes
+}
+
+// CanHoldRole 检查角色能否在该组织内分配（全局角色或归属该组织的角色）
+func (m *Member) CanHoldRole(r *role.Role) bool {
+	return r.OrganizationID == nil || *r.OrganizationID == m.OrganizationID
+}
@@ -0,0 +1,58 @@
+package organization
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// 组织状态
+const (
+	StatusActive    = "active"
+	StatusSuspended = "suspended"
+)
+
+// slugPattern 组织标识格式：小写字母、数字和连字符，需可直接用作子域名
+var slugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Organization 组织（租户）实体
+type Organization struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"-"`
+}
+
+// IsActive 检查组织是否处于启用状态
+func (o *Organization) IsActive() bool {
+	return o.Status == StatusActive
+}
+
+// Suspend 停用组织，停用后成员无法进入该租户
+func (o *Organization) Suspend() {
+	o.Status = StatusSuspended
+}
+
+// Activate 启用组织
+func (o *Organization) Activate() {
+	o.Status = StatusActive
+}
+
+// ValidateSlug 校验组织标识（同时用作子域名）
+// 纯数字标识会与组织 ID 混淆，不允许使用
+func ValidateSlug(slug string) error {
+	if !slugPattern.MatchString(slug) || !strings.ContainsFunc(slug, unicode.IsLetter) {
+		return ErrInvalidSlug
+	}
+	return nil
+}
+
+// IsValidStatus 检查组织状态是否合法
+func IsValidStatus(status string) bool {
+	return status == StatusActive || status == StatusSuspended
+}
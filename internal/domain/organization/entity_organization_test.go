@@ -0,0 +1,99 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+)
+
+func TestValidateSlug(t *testing.T) {
+	tests := []struct {
+		name    string
+		slug    string
+		wantErr bool
+	}{
+		{"小写字母", "acme", false},
+		{"包含数字和连字符", "acme-2024", false},
+		{"单字符", "a", false},
+		{"大写字母", "Acme", true},
+		{"连字符开头", "-acme", true},
+		{"连字符结尾", "acme-", true},
+		{"包含点", "acme.corp", true},
+		{"空字符串", "", true},
+		{"纯数字", "2024", true},
+		{"超过 63 字符", "a123456789012345678901234567890123456789012345678901234567890123", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSlug(tt.slug)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidSlug)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOrganization_Status(t *testing.T) {
+	org := &Organization{Status: StatusActive}
+	assert.True(t, org.IsActive())
+
+	org.Suspend()
+	assert.False(t, org.IsActive())
+
+	org.Activate()
+	assert.True(t, org.IsActive())
+
+	assert.True(t, IsValidStatus(StatusSuspended))
+	assert.False(t, IsValidStatus("deleted"))
+}
+
+func TestMember_GetPermissionCodes(t *testing.T) {
+	member := &Member{
+		OrganizationID: 1,
+		Roles: []role.Role{
+			{
+				ID:          1,
+				Name:        "org-admin",
+				Permissions: []role.Permission{{ID: 1, Code: "org:members:read"}, {ID: 2, Code: "org:members:update"}},
+			},
+			{
+				ID:                   2,
+				Name:                 "org-viewer",
+				Permissions:          []role.Permission{{ID: 1, Code: "org:members:read"}},
+				InheritedPermissions: []role.Permission{{ID: 3, Code: "org:roles:read"}},
+			},
+		},
+	}
+
+	assert.Equal(t, []uint{1, 2}, member.RoleIDs())
+	assert.Equal(t, []string{"org-admin", "org-viewer"}, member.GetRoleNames())
+	assert.ElementsMatch(t, []string{"org:members:read", "org:members:update", "org:roles:read"}, member.GetPermissionCodes())
+}
+
+func TestMember_CanHoldRole(t *testing.T) {
+	orgID, otherOrgID := uint(1), uint(2)
+	member := &Member{OrganizationID: orgID}
+
+	assert.True(t, member.CanHoldRole(&role.Role{}), "全局角色可分配")
+	assert.True(t, member.CanHoldRole(&role.Role{OrganizationID: &orgID}), "本组织角色可分配")
+	assert.False(t, member.CanHoldRole(&role.Role{OrganizationID: &otherOrgID}), "其他组织角色不可分配")
+}
+
+func TestTenantContext(t *testing.T) {
+	_, ok := TenantFromContext(context.Background())
+	assert.False(t, ok)
+
+	id, ok := TenantFromContext(WithTenant(context.Background(), 7))
+	assert.True(t, ok)
+	assert.Equal(t, uint(7), id)
+
+	_, ok = TenantFromContext(WithTenant(context.Background(), 0))
+	assert.False(t, ok, "租户 ID 为 0 视为未设置")
+}
@@ -0,0 +1,29 @@
+package organization
+
+import "errors"
+
+var (
+	// ErrOrganizationNotFound 组织不存在
+	ErrOrganizationNotFound = errors.New("organization not found")
+
+	// ErrSlugAlreadyExists 组织标识已存在
+	ErrSlugAlreadyExists = errors.New("organization slug already exists")
+
+	// ErrInvalidSlug 组织标识格式无效
+	ErrInvalidSlug = errors.New("invalid organization slug")
+
+	// ErrOrganizationSuspended 组织已停用
+	ErrOrganizationSuspended = errors.New("organization is suspended")
+
+	// ErrMemberAlreadyExists 用户已是组织成员
+	ErrMemberAlreadyExists = errors.New("user is already a member of the organization")
+
+	// ErrNotMember 用户不是组织成员
+	ErrNotMember = errors.New("user is not a member of the organization")
+
+	// ErrRoleNotInOrganization 角色不属于该组织
+	ErrRoleNotInOrganization = errors.New("role does not belong to the organization")
+
+	// ErrTenantMismatch 资源属于其他租户（或为全局资源），当前租户无权修改
+	ErrTenantMismatch = errors.New("resource belongs to another tenant")
+)
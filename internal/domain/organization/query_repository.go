@@ -0,0 +1,30 @@
+package organization
+
+import "context"
+
+// QueryRepository 定义组织读操作接口
+type QueryRepository interface {
+	// GetByID 根据 ID 获取组织，不存在时返回 ErrOrganizationNotFound
+	GetByID(ctx context.Context, id uint) (*Organization, error)
+
+	// GetBySlug 根据标识获取组织，不存在时返回 ErrOrganizationNotFound
+	GetBySlug(ctx context.Context, slug string) (*Organization, error)
+
+	// ExistsBySlug 检查组织标识是否已存在
+	ExistsBySlug(ctx context.Context, slug string) (bool, error)
+
+	// List 分页获取组织列表
+	List(ctx context.Context, offset, limit int) ([]*Organization, int64, error)
+
+	// ListByUser 获取用户加入的所有组织
+	ListByUser(ctx context.Context, userID uint) ([]*Organization, error)
+
+	// GetMember 获取组织成员（包含角色及继承权限），非成员时返回 ErrNotMember
+	GetMember(ctx context.Context, organizationID, userID uint) (*Member, error)
+
+	// ListMembers 分页获取组织成员（包含角色）
+	ListMembers(ctx context.Context, organizationID uint, offset, limit int) ([]*Member, int64, error)
+
+	// GetUserIDsByRole 获取在任意组织内持有指定角色的用户 ID（用于缓存失效）
+	GetUserIDsByRole(ctx context.Context, roleID uint) ([]uint, error)
+}
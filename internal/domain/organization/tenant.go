@@ -0,0 +1,17 @@
+package organization
+
+import "context"
+
+// tenantKey 租户 context key
+type tenantKey struct{}
+
+// WithTenant 返回携带当前租户（组织 ID）的 context
+func WithTenant(ctx context.Context, organizationID uint) context.Context {
+	return context.WithValue(ctx, tenantKey{}, organizationID)
+}
+
+// TenantFromContext 读取 context 中的当前租户，未设置时返回 false（平台级访问）
+func TenantFromContext(ctx context.Context) (uint, bool) {
+	id, ok := ctx.Value(tenantKey{}).(uint)
+	return id, ok && id != 0
+}
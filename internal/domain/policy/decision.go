@@ -0,0 +1,88 @@
+package policy
+
+import "context"
+
+// Predicate 资源属性上的过滤谓词
+type Predicate struct {
+	Field string // 资源属性名
+	Op    string // OpEqual / OpNotEqual
+	Value any
+}
+
+// Filter 由策略转换得到的仓储过滤条件
+//
+// Alternatives 中每一组谓词为"且"关系，组之间为"或"关系。
+// Unrestricted 为 true 时不做任何限制；否则 Alternatives 为空表示没有任何资源可见。
+type Filter struct {
+	Unrestricted bool
+	Alternatives [][]Predicate
+}
+
+// Unrestricted 返回不做限制的过滤条件
+func Unrestricted() Filter {
+	return Filter{Unrestricted: true}
+}
+
+// MatchesNothing 检查过滤条件是否排除所有资源
+func (f Filter) MatchesNothing() bool {
+	return !f.Unrestricted && len(f.Alternatives) == 0
+}
+
+// Decision 主体在某权限上的授权策略
+type Decision struct {
+	Subject  Attributes
+	Policies []*Policy // 各授予上附加的条件
+	// Unrestricted 为 true 表示至少有一个授予未附加条件
+	Unrestricted bool
+}
+
+// Allows 判断主体能否访问指定资源
+func (d *Decision) Allows(resource Attributes) bool {
+	if d == nil || d.Unrestricted {
+		return true
+	}
+	for _, p := range d.Policies {
+		if p.Evaluate(d.Subject, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter 将策略转换为仓储过滤条件
+func (d *Decision) Filter() (Filter, error) {
+	if d == nil || d.Unrestricted {
+		return Unrestricted(), nil
+	}
+
+	var filter Filter
+	for _, p := range d.Policies {
+		predicates, satisfiable, err := p.bind(d.Subject)
+		if err != nil {
+			return Filter{}, err
+		}
+		if !satisfiable {
+			continue
+		}
+		if len(predicates) == 0 {
+			// 代入主体属性后恒成立
+			return Unrestricted(), nil
+		}
+		filter.Alternatives = append(filter.Alternatives, predicates)
+	}
+	return filter, nil
+}
+
+// Resolver 解析主体在指定权限上的授权策略
+type Resolver interface {
+	Resolve(ctx context.Context, userID uint, permission string) (*Decision, error)
+}
+
+// Resolve 使用可选的解析器解析策略
+// 未配置解析器或主体未知（如系统内部调用）时不做限制
+func Resolve(ctx context.Context, r Resolver, userID uint, permission string) (*Decision, error) {
+	if r == nil || userID == 0 {
+		return &Decision{Unrestricted: true}, nil
+	}
+	return r.Resolve(ctx, userID, permission)
+}
@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, expressions ...string) []*Policy {
+	t.Helper()
+	policies := make([]*Policy, 0, len(expressions))
+	for _, e := range expressions {
+		p, err := Parse(e)
+		require.NoError(t, err)
+		policies = append(policies, p)
+	}
+	return policies
+}
+
+func TestDecision_Allows(t *testing.T) {
+	subject := Attributes{"id": uint(1), "department": "sales"}
+
+	t.Run("无条件授予允许所有资源", func(t *testing.T) {
+		d := &Decision{Subject: subject, Unrestricted: true}
+		assert.True(t, d.Allows(Attributes{"department": "hr"}))
+	})
+
+	t.Run("任一条件满足即允许", func(t *testing.T) {
+		d := &Decision{Subject: subject, Policies: mustParse(t,
+			"resource.department == subject.department",
+			"resource.id == subject.id",
+		)}
+		assert.True(t, d.Allows(Attributes{"id": uint(1), "department": "hr"}))
+		assert.False(t, d.Allows(Attributes{"id": uint(2), "department": "hr"}))
+	})
+}
+
+func TestDecision_Filter(t *testing.T) {
+	subject := Attributes{"id": uint(1), "department": "sales", "admin": false}
+
+	t.Run("无条件授予不限制", func(t *testing.T) {
+		f, err := (&Decision{Unrestricted: true}).Filter()
+		require.NoError(t, err)
+		assert.True(t, f.Unrestricted)
+	})
+
+	t.Run("代入主体属性生成谓词", func(t *testing.T) {
+		d := &Decision{Subject: subject, Policies: mustParse(t,
+			"subject.department == resource.department && resource.status != 'banned'",
+			"resource.user_id == subject.id",
+		)}
+
+		f, err := d.Filter()
+
+		require.NoError(t, err)
+		assert.False(t, f.Unrestricted)
+		assert.Equal(t, [][]Predicate{
+			{{Field: "department", Op: OpEqual, Value: "sales"}, {Field: "status", Op: OpNotEqual, Value: "banned"}},
+			{{Field: "user_id", Op: OpEqual, Value: uint(1)}},
+		}, f.Alternatives)
+	})
+
+	t.Run("主体条件不满足时排除该分支", func(t *testing.T) {
+		d := &Decision{Subject: subject, Policies: mustParse(t, "subject.admin == true && resource.id == 1")}
+
+		f, err := d.Filter()
+
+		require.NoError(t, err)
+		assert.True(t, f.MatchesNothing())
+	})
+
+	t.Run("仅含主体条件且满足时不限制", func(t *testing.T) {
+		d := &Decision{Subject: subject, Policies: mustParse(t, "subject.department == 'sales'")}
+
+		f, err := d.Filter()
+
+		require.NoError(t, err)
+		assert.True(t, f.Unrestricted)
+	})
+
+	t.Run("比较两个资源属性无法转换", func(t *testing.T) {
+		d := &Decision{Subject: subject, Policies: mustParse(t, "resource.owner_id == resource.user_id")}
+
+		_, err := d.Filter()
+
+		require.ErrorIs(t, err, ErrUnsupportedFilter)
+	})
+}
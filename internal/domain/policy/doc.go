@@ -0,0 +1,34 @@
+// Package policy 定义基于属性的访问控制（ABAC）策略模型。
+//
+// RBAC 只能回答"用户能否对某类资源执行某操作"，本包在角色权限授予上附加条件，
+// 将授权细化到具体资源行，例如"只能更新本部门的用户"、"只能查看自己的审计日志"。
+//
+// 本包定义了：
+//   - [Policy]: 已解析的条件表达式
+//   - [Attributes]: 主体（subject）或资源（resource）属性集合
+//   - [Decision]: 某主体在某权限上的全部策略，用于单条资源判定与列表过滤
+//   - [Filter]、[Predicate]: 由策略转换得到的仓储过滤条件
+//   - [Resolver]: 解析主体策略的服务接口
+//   - 策略领域错误（见 errors.go）
+//
+// 策略语言：
+// 条件由若干比较子句以 && 连接，每个子句比较两个操作数：
+//
+//	resource.department == subject.department
+//	resource.user_id == subject.id && resource.action != 'delete'
+//
+// 操作数可以是 subject.<属性>、resource.<属性>、单引号或双引号字符串、整数以及 true/false，
+// 比较运算符支持 == 和 !=。
+//
+// 组合规则：
+// 同一权限可能经由多个角色授予，任一授予无条件时不做限制；
+// 否则各授予的条件之间为"或"关系，满足任意一条即允许；
+// 没有任何角色授予覆盖该权限时拒绝访问。
+//
+// 列表过滤：
+// [Decision.Filter] 将主体属性代入条件，生成仅涉及资源属性的 [Filter]，
+// 由仓储实现转换为数据库查询条件。
+//
+// 依赖倒置：
+// 本包仅定义接口，[Resolver] 实现位于 infrastructure/auth 包。
+package policy
@@ -0,0 +1,14 @@
+package policy
+
+import "errors"
+
+var (
+	// ErrInvalidExpression 策略表达式语法错误
+	ErrInvalidExpression = errors.New("invalid policy expression")
+
+	// ErrUnsupportedFilter 策略无法转换为仓储过滤条件
+	ErrUnsupportedFilter = errors.New("policy cannot be converted to a filter")
+
+	// ErrAccessDenied 资源不满足策略条件
+	ErrAccessDenied = errors.New("access denied by policy")
+)
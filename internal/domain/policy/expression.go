@@ -0,0 +1,245 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 操作数作用域
+const (
+	ScopeSubject  = "subject"
+	ScopeResource = "resource"
+)
+
+// 比较运算符
+const (
+	OpEqual    = "=="
+	OpNotEqual = "!="
+)
+
+// Attributes 主体或资源的属性集合
+type Attributes map[string]any
+
+// operand 比较子句的操作数：属性引用或字面量
+type operand struct {
+	scope string // subject / resource，字面量为空
+	name  string
+	value any
+}
+
+// resolve 在给定属性中求值操作数
+func (o operand) resolve(subject, resource Attributes) (any, bool) {
+	switch o.scope {
+	case ScopeSubject:
+		v, ok := subject[o.name]
+		return v, ok
+	case ScopeResource:
+		v, ok := resource[o.name]
+		return v, ok
+	default:
+		return o.value, true
+	}
+}
+
+func (o operand) String() string {
+	if o.scope != "" {
+		return o.scope + "." + o.name
+	}
+	if s, ok := o.value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(o.value)
+}
+
+// clause 比较子句
+type clause struct {
+	left  operand
+	op    string
+	right operand
+}
+
+// Policy 已解析的策略条件，所有子句均满足时成立
+type Policy struct {
+	expression string
+	clauses    []clause
+}
+
+// Parse 解析策略表达式
+func Parse(expression string) (*Policy, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, fmt.Errorf("%w: empty expression", ErrInvalidExpression)
+	}
+
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Policy{expression: expression}
+	for i := 0; i < len(tokens); {
+		if len(tokens)-i < 3 {
+			return nil, fmt.Errorf("%w: incomplete comparison near %q", ErrInvalidExpression, strings.Join(tokens[i:], " "))
+		}
+
+		left, err := parseOperand(tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		op := tokens[i+1]
+		if op != OpEqual && op != OpNotEqual {
+			return nil, fmt.Errorf("%w: unsupported operator %q", ErrInvalidExpression, op)
+		}
+		right, err := parseOperand(tokens[i+2])
+		if err != nil {
+			return nil, err
+		}
+		p.clauses = append(p.clauses, clause{left: left, op: op, right: right})
+		i += 3
+
+		if i < len(tokens) {
+			if tokens[i] != "&&" || i == len(tokens)-1 {
+				return nil, fmt.Errorf("%w: expected && near %q", ErrInvalidExpression, tokens[i])
+			}
+			i++
+		}
+	}
+	return p, nil
+}
+
+// String 返回原始表达式
+func (p *Policy) String() string {
+	return p.expression
+}
+
+// Evaluate 判断主体与资源属性是否满足策略
+// 缺失的属性视为不满足
+func (p *Policy) Evaluate(subject, resource Attributes) bool {
+	for _, c := range p.clauses {
+		left, ok := c.left.resolve(subject, resource)
+		if !ok {
+			return false
+		}
+		right, ok := c.right.resolve(subject, resource)
+		if !ok {
+			return false
+		}
+		if equalValues(left, right) != (c.op == OpEqual) {
+			return false
+		}
+	}
+	return true
+}
+
+// bind 代入主体属性，将策略转换为仅涉及资源属性的谓词
+// 返回 false 表示代入后策略恒不成立
+func (p *Policy) bind(subject Attributes) ([]Predicate, bool, error) {
+	predicates := make([]Predicate, 0, len(p.clauses))
+	for _, c := range p.clauses {
+		left, right := c.left, c.right
+		if right.scope == ScopeResource && left.scope != ScopeResource {
+			left, right = right, left
+		}
+
+		switch {
+		case left.scope == ScopeResource && right.scope == ScopeResource:
+			return nil, false, fmt.Errorf("%w: %s %s %s compares two resource attributes", ErrUnsupportedFilter, left, c.op, right)
+		case left.scope == ScopeResource:
+			value, ok := right.resolve(subject, nil)
+			if !ok {
+				return nil, false, nil
+			}
+			predicates = append(predicates, Predicate{Field: left.name, Op: c.op, Value: value})
+		default:
+			// 子句不涉及资源属性，可直接求值
+			l, lok := left.resolve(subject, nil)
+			r, rok := right.resolve(subject, nil)
+			if !lok || !rok || equalValues(l, r) != (c.op == OpEqual) {
+				return nil, false, nil
+			}
+		}
+	}
+	return predicates, true, nil
+}
+
+// tokenize 将表达式切分为操作数、运算符与连接符
+func tokenize(expression string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expression); {
+		ch := expression[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			i++
+		case ch == '\'' || ch == '"':
+			end := strings.IndexByte(expression[i+1:], ch)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated string literal", ErrInvalidExpression)
+			}
+			tokens = append(tokens, expression[i:i+end+2])
+			i += end + 2
+		case strings.HasPrefix(expression[i:], "=="), strings.HasPrefix(expression[i:], "!="), strings.HasPrefix(expression[i:], "&&"):
+			tokens = append(tokens, expression[i:i+2])
+			i += 2
+		default:
+			start := i
+			for i < len(expression) && !strings.ContainsRune(" \t\n'\"=!&", rune(expression[i])) {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("%w: unexpected character %q", ErrInvalidExpression, ch)
+			}
+			tokens = append(tokens, expression[start:i])
+		}
+	}
+	return tokens, nil
+}
+
+// parseOperand 解析单个操作数
+func parseOperand(token string) (operand, error) {
+	if len(token) >= 2 && (token[0] == '\'' || token[0] == '"') {
+		return operand{value: token[1 : len(token)-1]}, nil
+	}
+	if token == "true" || token == "false" {
+		return operand{value: token == "true"}, nil
+	}
+	if n, err := strconv.ParseInt(token, 10, 64); err == nil {
+		return operand{value: n}, nil
+	}
+
+	scope, name, ok := strings.Cut(token, ".")
+	if !ok || name == "" || (scope != ScopeSubject && scope != ScopeResource) {
+		return operand{}, fmt.Errorf("%w: unknown operand %q", ErrInvalidExpression, token)
+	}
+	return operand{scope: scope, name: name}, nil
+}
+
+// equalValues 比较两个属性值，数字统一按整数比较，其余按字符串形式比较
+func equalValues(a, b any) bool {
+	if ai, ok := toInt64(a); ok {
+		if bi, ok := toInt64(b); ok {
+			return ai == bi
+		}
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// toInt64 将整数类型的属性值转换为 int64
+func toInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case int32:
+		return int64(n), true
+	case uint:
+		return int64(n), true //nolint:gosec // ID 不会超过 int64 范围
+	case uint64:
+		return int64(n), true //nolint:gosec // ID 不会超过 int64 范围
+	case uint32:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{name: "主体与资源属性比较", expression: "resource.department == subject.department"},
+		{name: "多个子句", expression: "resource.user_id == subject.id && resource.action != 'delete'"},
+		{name: "字面量", expression: `resource.status == "active" && resource.level == 3 && subject.admin == true`},
+		{name: "空表达式", expression: "  ", wantErr: true},
+		{name: "未知作用域", expression: "user.id == 1", wantErr: true},
+		{name: "不支持的运算符", expression: "resource.id > 1", wantErr: true},
+		{name: "缺少操作数", expression: "resource.id ==", wantErr: true},
+		{name: "缺少连接符", expression: "resource.id == 1 resource.id == 2", wantErr: true},
+		{name: "末尾多余连接符", expression: "resource.id == 1 &&", wantErr: true},
+		{name: "未闭合字符串", expression: "resource.name == 'abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse(tt.expression)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidExpression)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expression, p.String())
+		})
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	subject := Attributes{"id": uint(7), "department": "sales"}
+
+	tests := []struct {
+		name       string
+		expression string
+		resource   Attributes
+		want       bool
+	}{
+		{
+			name:       "同部门",
+			expression: "resource.department == subject.department",
+			resource:   Attributes{"department": "sales"},
+			want:       true,
+		},
+		{
+			name:       "不同部门",
+			expression: "resource.department == subject.department",
+			resource:   Attributes{"department": "hr"},
+			want:       false,
+		},
+		{
+			name:       "数字类型统一比较",
+			expression: "resource.user_id == subject.id",
+			resource:   Attributes{"user_id": 7},
+			want:       true,
+		},
+		{
+			name:       "缺失资源属性视为不满足",
+			expression: "resource.user_id == subject.id",
+			resource:   Attributes{},
+			want:       false,
+		},
+		{
+			name:       "所有子句均需满足",
+			expression: "resource.user_id == subject.id && resource.action != 'delete'",
+			resource:   Attributes{"user_id": uint(7), "action": "delete"},
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p.Evaluate(subject, tt.resource))
+		})
+	}
+}
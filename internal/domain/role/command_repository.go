@@ -21,6 +21,9 @@ type CommandRepository interface {
 
 	// RemovePermissions removes permissions from a role
 	RemovePermissions(ctx context.Context, roleID uint, permissionIDs []uint) error
+
+	// SetPermissionCondition attaches a policy condition to a role-permission grant (empty removes it)
+	SetPermissionCondition(ctx context.Context, roleID, permissionID uint, condition string) error
 }
 
 // PermissionCommandRepository 权限命令仓储接口（写操作）
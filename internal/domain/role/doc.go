@@ -18,6 +18,19 @@
 //   - [Role.AddPermission]: 添加权限
 //   - [Role.RemovePermission]: 移除权限
 //
+// 角色继承：
+// 角色可通过 ParentID 指定父角色，有效权限为沿继承链向上所有角色权限的并集。
+// [Hierarchy] 描述角色间的父子关系，提供祖先/后代遍历与环检测
+// （[Hierarchy.CreatesCycle]），更新父角色时必须拒绝形成环的变更。
+//
+// 条件授予：
+// 角色权限授予（[Grant]）可附加 ABAC 策略条件（见 policy 包），
+// 使授予仅对满足条件的资源生效，例如"只能更新本部门的用户"。
+//
+// 权限注册表：
+// 路由声明所需权限时登记到 [PermissionRegistry]，注册表中的 [PermissionDefinition]
+// 是权限目录的唯一来源，由种子数据和 `permissions sync` 命令同步到数据库。
+//
 // 依赖倒置：
 // 本包仅定义接口，实现位于 infrastructure/persistence 包。
 package role
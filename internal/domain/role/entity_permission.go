@@ -54,6 +54,27 @@ func (p *Permission) Matches(pattern string) bool {
 		matchPart(parts[2], p.Action)
 }
 
+// MatchCode 检查权限代码 code 是否被授予的权限模式 pattern 覆盖（支持通配符 *）
+// 例如: MatchCode("admin:*:*", "admin:users:update") 返回 true
+func MatchCode(pattern, code string) bool {
+	if pattern == code || pattern == "*" || pattern == "*:*:*" {
+		return true
+	}
+
+	patternParts := splitPermissionCode(pattern)
+	codeParts := splitPermissionCode(code)
+	if len(patternParts) != 3 || len(codeParts) != 3 {
+		return false
+	}
+
+	for i := range 3 {
+		if !matchPart(patternParts[i], codeParts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // BuildCode 根据 Domain/Resource/Action 构建权限代码
 func (p *Permission) BuildCode() string {
 	return p.Domain + ":" + p.Resource + ":" + p.Action
@@ -1,18 +1,30 @@
 package role
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// AdminRoleName 管理员角色名称，持有者可访问管理接口
+const AdminRoleName = "admin"
 
 // Role 角色实体，RBAC 系统的核心组件
 type Role struct {
-	ID          uint         `json:"id"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
-	DeletedAt   *time.Time   `json:"-"`
-	Name        string       `json:"name"`
-	DisplayName string       `json:"display_name"`
-	Description string       `json:"description"`
-	IsSystem    bool         `json:"is_system"`
-	Permissions []Permission `json:"permissions,omitempty"`
+	ID          uint       `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"-"`
+	Name        string     `json:"name"`
+	DisplayName string     `json:"display_name"`
+	Description string     `json:"description"`
+	IsSystem    bool       `json:"is_system"`
+	ParentID    *uint      `json:"parent_id,omitempty"`
+	// OrganizationID 所属组织，为空表示全局角色
+	OrganizationID *uint        `json:"organization_id,omitempty"`
+	Permissions    []Permission `json:"permissions,omitempty"`
+
+	// InheritedPermissions 从祖先角色继承的权限（仅在加载用户权限时填充）
+	InheritedPermissions []Permission `json:"-"`
 }
 
 // IsSystemRole 检查是否为系统角色
@@ -30,6 +42,50 @@ func (r *Role) CanBeModified() bool {
 	return !r.IsSystem
 }
 
+// IsGlobal 检查是否为全局角色（不归属任何组织）
+func (r *Role) IsGlobal() bool {
+	return r.OrganizationID == nil
+}
+
+// HasParent 检查角色是否继承自父角色
+func (r *Role) HasParent() bool {
+	return r.ParentID != nil && *r.ParentID != 0
+}
+
+// EffectivePermissions 返回直接授予与继承权限的并集（按权限 ID 去重）
+func (r *Role) EffectivePermissions() []Permission {
+	if len(r.InheritedPermissions) == 0 {
+		return r.Permissions
+	}
+
+	seen := make(map[uint]struct{}, len(r.Permissions)+len(r.InheritedPermissions))
+	permissions := make([]Permission, 0, len(r.Permissions)+len(r.InheritedPermissions))
+	for _, list := range [][]Permission{r.Permissions, r.InheritedPermissions} {
+		for _, p := range list {
+			if _, ok := seen[p.ID]; ok {
+				continue
+			}
+			seen[p.ID] = struct{}{}
+			permissions = append(permissions, p)
+		}
+	}
+	return permissions
+}
+
+// IsPrivileged 检查角色是否授予管理权限：管理员角色本身，或有效权限中含 admin 域或通配符权限
+// 需先加载继承权限（InheritedPermissions），否则仅按直接授予的权限判断
+func (r *Role) IsPrivileged() bool {
+	if r.Name == AdminRoleName {
+		return true
+	}
+	for _, p := range r.EffectivePermissions() {
+		if strings.Contains(p.Code, "*") || strings.HasPrefix(p.Code, AdminRoleName+":") {
+			return true
+		}
+	}
+	return false
+}
+
 // HasPermission 检查角色是否拥有指定权限
 func (r *Role) HasPermission(code string) bool {
 	for _, p := range r.Permissions {
@@ -293,3 +293,23 @@ func TestPermission_BuildCode(t *testing.T) {
 	perm := Permission{Domain: "user", Resource: "profile", Action: "read"}
 	assert.Equal(t, "user:profile:read", perm.BuildCode())
 }
+
+func TestRole_IsPrivileged(t *testing.T) {
+	tests := []struct {
+		name string
+		role Role
+		want bool
+	}{
+		{"管理员角色", Role{Name: AdminRoleName}, true},
+		{"普通权限", Role{Name: "viewer", Permissions: []Permission{{ID: 1, Code: "user:profile:read"}}}, false},
+		{"管理域权限", Role{Name: "ops", Permissions: []Permission{{ID: 1, Code: "admin:users:update"}}}, true},
+		{"通配符权限", Role{Name: "ops", Permissions: []Permission{{ID: 1, Code: "user:*:*"}}}, true},
+		{"继承的管理权限", Role{Name: "child", InheritedPermissions: []Permission{{ID: 2, Code: "admin:roles:update"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.role.IsPrivileged())
+		})
+	}
+}
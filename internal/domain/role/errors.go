@@ -21,6 +21,12 @@ var (
 
 	// ErrRoleHasUsers 角色下有关联用户
 	ErrRoleHasUsers = errors.New("role has associated users")
+
+	// ErrParentRoleNotFound 父角色不存在
+	ErrParentRoleNotFound = errors.New("parent role not found")
+
+	// ErrRoleHierarchyCycle 角色继承关系形成环
+	ErrRoleHierarchyCycle = errors.New("role hierarchy would contain a cycle")
 )
 
 // 权限相关错误
@@ -36,4 +42,7 @@ var (
 
 	// ErrPermissionInUse 权限正在被使用
 	ErrPermissionInUse = errors.New("permission is in use by roles")
+
+	// ErrPermissionNotGranted 角色未被授予该权限
+	ErrPermissionNotGranted = errors.New("permission is not granted to role")
 )
@@ -0,0 +1,22 @@
+package role
+
+// Grant 角色权限授予，可附加 ABAC 策略条件
+//
+// Condition 为空表示无条件授予；否则为 policy 包可解析的条件表达式，
+// 仅当主体与资源属性满足条件时授予才生效。
+type Grant struct {
+	RoleID         uint
+	PermissionID   uint
+	PermissionCode string
+	Condition      string
+}
+
+// IsConditional 检查授予是否附加了策略条件
+func (g Grant) IsConditional() bool {
+	return g.Condition != ""
+}
+
+// Covers 检查授予是否覆盖指定权限代码（支持通配符）
+func (g Grant) Covers(code string) bool {
+	return MatchCode(g.PermissionCode, code)
+}
@@ -0,0 +1,88 @@
+package role
+
+import "slices"
+
+// Hierarchy 角色继承关系，键为角色 ID，值为其父角色 ID
+//
+// 没有父角色的角色不出现在映射中。
+type Hierarchy map[uint]uint
+
+// Parent 返回角色的父角色 ID
+func (h Hierarchy) Parent(roleID uint) (uint, bool) {
+	parentID, ok := h[roleID]
+	return parentID, ok && parentID != 0
+}
+
+// Ancestors 返回角色的所有祖先角色 ID（由近及远）
+//
+// 遇到环时停止遍历，不会包含角色自身。
+func (h Hierarchy) Ancestors(roleID uint) []uint {
+	var ancestors []uint
+	visited := map[uint]struct{}{roleID: {}}
+
+	current := roleID
+	for {
+		parentID, ok := h.Parent(current)
+		if !ok {
+			return ancestors
+		}
+		if _, seen := visited[parentID]; seen {
+			return ancestors
+		}
+		visited[parentID] = struct{}{}
+		ancestors = append(ancestors, parentID)
+		current = parentID
+	}
+}
+
+// Children 返回直接继承自该角色的子角色 ID（按 ID 升序）
+func (h Hierarchy) Children(roleID uint) []uint {
+	var children []uint
+	for child, parent := range h {
+		if parent == roleID && child != roleID {
+			children = append(children, child)
+		}
+	}
+	slices.Sort(children)
+	return children
+}
+
+// Descendants 返回直接或间接继承自该角色的所有角色 ID
+func (h Hierarchy) Descendants(roleID uint) []uint {
+	children := make(map[uint][]uint, len(h))
+	for child, parent := range h {
+		if parent != 0 {
+			children[parent] = append(children[parent], child)
+		}
+	}
+
+	var descendants []uint
+	visited := map[uint]struct{}{roleID: {}}
+	queue := []uint{roleID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range children[current] {
+			if _, seen := visited[child]; seen {
+				continue
+			}
+			visited[child] = struct{}{}
+			descendants = append(descendants, child)
+			queue = append(queue, child)
+		}
+	}
+	return descendants
+}
+
+// CreatesCycle 检查将 parentID 设为 roleID 的父角色是否会形成环
+func (h Hierarchy) CreatesCycle(roleID, parentID uint) bool {
+	if roleID == parentID {
+		return true
+	}
+	for _, ancestor := range h.Ancestors(parentID) {
+		if ancestor == roleID {
+			return true
+		}
+	}
+	return false
+}
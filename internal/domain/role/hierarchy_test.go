@@ -0,0 +1,93 @@
+package role
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 继承链：4 -> 3 -> 2 -> 1，5 -> 2
+func newTestHierarchy() Hierarchy {
+	return Hierarchy{
+		2: 1,
+		3: 2,
+		4: 3,
+		5: 2,
+	}
+}
+
+func TestHierarchy_Ancestors(t *testing.T) {
+	h := newTestHierarchy()
+
+	t.Run("由近及远返回祖先", func(t *testing.T) {
+		assert.Equal(t, []uint{3, 2, 1}, h.Ancestors(4))
+	})
+
+	t.Run("根角色没有祖先", func(t *testing.T) {
+		assert.Empty(t, h.Ancestors(1))
+	})
+
+	t.Run("存在环时停止遍历", func(t *testing.T) {
+		cyclic := Hierarchy{1: 2, 2: 1}
+		assert.Equal(t, []uint{2}, cyclic.Ancestors(1))
+	})
+}
+
+func TestHierarchy_Children(t *testing.T) {
+	h := newTestHierarchy()
+
+	t.Run("仅返回直接子角色", func(t *testing.T) {
+		assert.Equal(t, []uint{3, 5}, h.Children(2))
+	})
+
+	t.Run("叶子角色没有子角色", func(t *testing.T) {
+		assert.Empty(t, h.Children(4))
+	})
+}
+
+func TestHierarchy_Descendants(t *testing.T) {
+	h := newTestHierarchy()
+
+	t.Run("包含间接后代", func(t *testing.T) {
+		assert.ElementsMatch(t, []uint{2, 3, 4, 5}, h.Descendants(1))
+	})
+
+	t.Run("叶子角色没有后代", func(t *testing.T) {
+		assert.Empty(t, h.Descendants(4))
+	})
+}
+
+func TestHierarchy_CreatesCycle(t *testing.T) {
+	h := newTestHierarchy()
+
+	tests := []struct {
+		name     string
+		roleID   uint
+		parentID uint
+		want     bool
+	}{
+		{name: "父角色为自身", roleID: 2, parentID: 2, want: true},
+		{name: "父角色为后代", roleID: 1, parentID: 4, want: true},
+		{name: "父角色为兄弟分支", roleID: 5, parentID: 3, want: false},
+		{name: "父角色为无关角色", roleID: 4, parentID: 6, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, h.CreatesCycle(tt.roleID, tt.parentID))
+		})
+	}
+}
+
+func TestRole_EffectivePermissions(t *testing.T) {
+	r := Role{
+		Permissions:          []Permission{{ID: 1, Code: "user:read"}, {ID: 2, Code: "user:update"}},
+		InheritedPermissions: []Permission{{ID: 2, Code: "user:update"}, {ID: 3, Code: "user:delete"}},
+	}
+
+	codes := make([]string, 0)
+	for _, p := range r.EffectivePermissions() {
+		codes = append(codes, p.Code)
+	}
+	assert.Equal(t, []string{"user:read", "user:update", "user:delete"}, codes)
+}
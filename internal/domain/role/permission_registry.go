@@ -0,0 +1,180 @@
+package role
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// PermissionDefinition 权限定义（权限目录中的一项）
+type PermissionDefinition struct {
+	Code        string // 三段式权限代码 domain:resource:action
+	Description string
+}
+
+// Validate 检查权限代码是否为三段式且不含通配符
+func (d PermissionDefinition) Validate() error {
+	parts := splitPermissionCode(d.Code)
+	if len(parts) != 3 || slices.Contains(parts, "") || strings.Contains(d.Code, "*") {
+		return fmt.Errorf("%w: %q", ErrInvalidPermissionCode, d.Code)
+	}
+	return nil
+}
+
+// NewPermission 根据定义创建权限实体，Domain/Resource/Action 由代码拆分得到
+func (d PermissionDefinition) NewPermission() *Permission {
+	parts := splitPermissionCode(d.Code)
+	p := &Permission{Code: d.Code, Description: d.Description}
+	if len(parts) == 3 {
+		p.Domain, p.Resource, p.Action = parts[0], parts[1], parts[2]
+	}
+	return p
+}
+
+// RouteBinding 路由与其访问要求的绑定
+// Permission 为空表示路由只要求登录；Role 非空表示路由组额外要求该角色
+type RouteBinding struct {
+	Method     string
+	Path       string // 路由模板，如 /api/admin/users/:id
+	Permission string
+	Role       string
+}
+
+// PermissionRegistry 路由声明的权限注册表
+//
+// 路由注册时登记所需权限，注册表是权限目录的唯一来源：
+// 种子数据和 `permissions sync` 都从这里读取权限定义，避免权限代码在多处重复书写。
+// 注册表同时记录路由与权限的绑定，供授权解释按路由反查所需权限。
+type PermissionRegistry struct {
+	mu          sync.RWMutex
+	definitions map[string]PermissionDefinition
+	routes      map[string]RouteBinding // key: METHOD + " " + Path
+}
+
+// NewPermissionRegistry 创建空的权限注册表
+func NewPermissionRegistry() *PermissionRegistry {
+	return &PermissionRegistry{
+		definitions: make(map[string]PermissionDefinition),
+		routes:      make(map[string]RouteBinding),
+	}
+}
+
+// Register 登记权限定义
+// 同一代码可被多个路由重复登记，但说明必须一致；代码格式错误属于编程错误，直接 panic
+func (r *PermissionRegistry) Register(def PermissionDefinition) {
+	if err := def.Validate(); err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.definitions[def.Code]; ok && existing.Description != def.Description {
+		panic(fmt.Sprintf("permission %q registered with conflicting descriptions", def.Code))
+	}
+	r.definitions[def.Code] = def
+}
+
+// Has 检查权限代码是否已登记
+func (r *PermissionRegistry) Has(code string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.definitions[code]
+	return ok
+}
+
+// Definitions 返回按代码排序的全部权限定义
+func (r *PermissionRegistry) Definitions() []PermissionDefinition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make([]PermissionDefinition, 0, len(r.definitions))
+	for _, def := range r.definitions {
+		defs = append(defs, def)
+	}
+	slices.SortFunc(defs, func(a, b PermissionDefinition) int { return strings.Compare(a.Code, b.Code) })
+	return defs
+}
+
+// BindRoute 登记路由绑定，同一路由重复登记时以最后一次为准
+func (r *PermissionRegistry) BindRoute(binding RouteBinding) {
+	binding.Method = strings.ToUpper(binding.Method)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[binding.Method+" "+binding.Path] = binding
+}
+
+// LookupRoute 按请求方法和路径查找路由绑定
+// path 可以是路由模板，也可以是具体路径（如 /api/admin/users/5），模板中的 :param 和 *param 按段匹配
+func (r *PermissionRegistry) LookupRoute(method, path string) (RouteBinding, bool) {
+	method = strings.ToUpper(method)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if binding, ok := r.routes[method+" "+path]; ok {
+		return binding, true
+	}
+
+	// 静态段越多的模板越具体，优先匹配
+	var (
+		best      RouteBinding
+		bestScore = -1
+	)
+	for _, binding := range r.routes {
+		if binding.Method != method {
+			continue
+		}
+		if score, ok := matchRoute(binding.Path, path); ok && score > bestScore {
+			best, bestScore = binding, score
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// Routes 返回按路径和方法排序的全部路由绑定
+func (r *PermissionRegistry) Routes() []RouteBinding {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	routes := make([]RouteBinding, 0, len(r.routes))
+	for _, binding := range r.routes {
+		routes = append(routes, binding)
+	}
+	slices.SortFunc(routes, func(a, b RouteBinding) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+	return routes
+}
+
+// matchRoute 检查具体路径是否匹配路由模板，返回匹配的静态段数量
+func matchRoute(template, path string) (int, bool) {
+	templateParts := strings.Split(strings.Trim(template, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	score := 0
+	for i, part := range templateParts {
+		if strings.HasPrefix(part, "*") {
+			return score, true
+		}
+		if i >= len(pathParts) {
+			return 0, false
+		}
+		switch {
+		case strings.HasPrefix(part, ":"):
+			if pathParts[i] == "" {
+				return 0, false
+			}
+		case part == pathParts[i]:
+			score++
+		default:
+			return 0, false
+		}
+	}
+	return score, len(templateParts) == len(pathParts)
+}
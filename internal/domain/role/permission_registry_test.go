@@ -0,0 +1,110 @@
+package role
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{name: "三段式", code: "admin:users:read"},
+		{name: "两段", code: "admin:users", wantErr: true},
+		{name: "空段", code: "admin::read", wantErr: true},
+		{name: "通配符", code: "admin:users:*", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PermissionDefinition{Code: tt.code}.Validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidPermissionCode)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPermissionDefinition_NewPermission(t *testing.T) {
+	p := PermissionDefinition{Code: "user:tokens:disable", Description: "Disable own tokens"}.NewPermission()
+
+	assert.Equal(t, "user", p.Domain)
+	assert.Equal(t, "tokens", p.Resource)
+	assert.Equal(t, "disable", p.Action)
+	assert.Equal(t, "Disable own tokens", p.Description)
+}
+
+func TestPermissionRegistry(t *testing.T) {
+	t.Run("重复登记同一权限", func(t *testing.T) {
+		r := NewPermissionRegistry()
+		r.Register(PermissionDefinition{Code: "admin:users:read", Description: "Read all users"})
+		r.Register(PermissionDefinition{Code: "admin:users:read", Description: "Read all users"})
+		r.Register(PermissionDefinition{Code: "admin:roles:read", Description: "Read all roles"})
+
+		assert.True(t, r.Has("admin:users:read"))
+		assert.False(t, r.Has("admin:users:delete"))
+		defs := r.Definitions()
+		require.Len(t, defs, 2)
+		assert.Equal(t, "admin:roles:read", defs[0].Code, "按代码排序")
+	})
+
+	t.Run("说明冲突时 panic", func(t *testing.T) {
+		r := NewPermissionRegistry()
+		r.Register(PermissionDefinition{Code: "admin:users:read", Description: "Read all users"})
+
+		assert.Panics(t, func() {
+			r.Register(PermissionDefinition{Code: "admin:users:read", Description: "Other"})
+		})
+	})
+
+	t.Run("格式错误时 panic", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewPermissionRegistry().Register(PermissionDefinition{Code: "admin:users"})
+		})
+	})
+}
+
+func TestPermissionRegistry_LookupRoute(t *testing.T) {
+	r := NewPermissionRegistry()
+	r.BindRoute(RouteBinding{Method: "get", Path: "/api/admin/users/:id", Permission: "admin:users:read", Role: "admin"})
+	r.BindRoute(RouteBinding{Method: "GET", Path: "/api/admin/users/batch", Permission: "admin:users:create"})
+	r.BindRoute(RouteBinding{Method: "GET", Path: "/api/user/profile"})
+	r.BindRoute(RouteBinding{Method: "GET", Path: "/static/*filepath"})
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		wantFound bool
+		wantPath  string
+		wantPerm  string
+	}{
+		{name: "路由模板精确匹配", method: "GET", path: "/api/admin/users/:id", wantFound: true, wantPath: "/api/admin/users/:id", wantPerm: "admin:users:read"},
+		{name: "具体路径匹配参数段", method: "get", path: "/api/admin/users/5", wantFound: true, wantPath: "/api/admin/users/:id", wantPerm: "admin:users:read"},
+		{name: "静态段优先", method: "GET", path: "/api/admin/users/batch", wantFound: true, wantPath: "/api/admin/users/batch", wantPerm: "admin:users:create"},
+		{name: "仅要求登录的路由", method: "GET", path: "/api/user/profile", wantFound: true, wantPath: "/api/user/profile"},
+		{name: "通配段匹配剩余路径", method: "GET", path: "/static/js/app.js", wantFound: true, wantPath: "/static/*filepath"},
+		{name: "方法不匹配", method: "DELETE", path: "/api/admin/users/5"},
+		{name: "段数不匹配", method: "GET", path: "/api/admin/users/5/roles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binding, ok := r.LookupRoute(tt.method, tt.path)
+			require.Equal(t, tt.wantFound, ok)
+			if !tt.wantFound {
+				return
+			}
+			assert.Equal(t, tt.wantPath, binding.Path)
+			assert.Equal(t, tt.wantPerm, binding.Permission)
+		})
+	}
+
+	assert.Len(t, r.Routes(), 4)
+}
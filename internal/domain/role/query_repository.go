@@ -24,6 +24,15 @@ type QueryRepository interface {
 
 	// ExistsByName checks if a role exists by name
 	ExistsByName(ctx context.Context, name string) (bool, error)
+
+	// GetHierarchy returns the parent links of all roles
+	GetHierarchy(ctx context.Context) (Hierarchy, error)
+
+	// GetEffectivePermissions returns the union of permissions granted to a role and its ancestors
+	GetEffectivePermissions(ctx context.Context, roleID uint) ([]Permission, error)
+
+	// GetGrants returns the direct permission grants (with policy conditions) of the given roles
+	GetGrants(ctx context.Context, roleIDs []uint) ([]Grant, error)
 }
 
 // PermissionQueryRepository 权限查询仓储接口（读操作）
@@ -40,6 +49,9 @@ type PermissionQueryRepository interface {
 	// List returns all permissions with pagination
 	List(ctx context.Context, page, limit int) ([]Permission, int64, error)
 
+	// ListAll returns all permissions without pagination
+	ListAll(ctx context.Context) ([]Permission, error)
+
 	// ListByResource returns all permissions for a specific resource
 	ListByResource(ctx context.Context, resource string) ([]Permission, error)
 
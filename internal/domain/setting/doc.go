@@ -11,6 +11,7 @@
 //   - security: 安全相关配置
 //   - notification: 通知配置
 //   - backup: 备份配置
+//   - preference: 用户偏好默认值
 //
 // 值类型 (ValueType)：
 //   - string: 字符串
@@ -18,6 +19,11 @@
 //   - boolean: 布尔值
 //   - json: JSON 对象
 //
+// 用户偏好 (Preference)：
+// preference 分类下的配置项作为全站默认值，用户可通过 [UserSetting] 按键覆盖，
+// 覆盖值沿用默认项的 ValueType 解析。[ResolvePreferences] 合并两者得到 [Preferences]，
+// 供邮件语言、时间展示等服务端功能读取。
+//
 // 与配置文件的区别：
 // 配置文件适合静态配置（数据库连接、端口等），本模块适合需要运行时调整的动态配置。
 package setting
@@ -33,7 +33,7 @@ func (s *Setting) IsValidValueType() bool {
 // IsValidCategory 检查 Category 是否有效
 func (s *Setting) IsValidCategory() bool {
 	switch s.Category {
-	case CategoryGeneral, CategorySecurity, CategoryNotification, CategoryBackup:
+	case CategoryGeneral, CategorySecurity, CategoryNotification, CategoryBackup, CategoryPreference:
 		return true
 	default:
 		return false
@@ -91,6 +91,75 @@ func (s *Setting) ParseJSON(v any) error {
 	return nil
 }
 
+// TypedValue 按 ValueType 将 Value 解析为对应的 Go 类型
+// string 返回 string，number 返回 float64，boolean 返回 bool，json 返回解码后的任意值
+func (s *Setting) TypedValue() (any, error) {
+	switch s.ValueType {
+	case ValueTypeNumber:
+		return s.ParseFloat()
+	case ValueTypeBoolean:
+		return s.ParseBool()
+	case ValueTypeJSON:
+		var v any
+		if err := s.ParseJSON(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return s.Value, nil
+	}
+}
+
+// NormalizeValue 将任意输入值按 valueType 规范化为存储字符串
+// 数值和布尔值同时接受原生类型与可解析的字符串
+func NormalizeValue(valueType string, v any) (string, error) {
+	switch valueType {
+	case ValueTypeString:
+		str, ok := v.(string)
+		if !ok {
+			return "", ErrValueTypeMismatch
+		}
+		return str, nil
+	case ValueTypeNumber:
+		switch n := v.(type) {
+		case float64:
+			return strconv.FormatFloat(n, 'f', -1, 64), nil
+		case int:
+			return strconv.Itoa(n), nil
+		case json.Number:
+			return n.String(), nil
+		case string:
+			if _, err := strconv.ParseFloat(strings.TrimSpace(n), 64); err != nil {
+				return "", ErrInvalidNumberValue
+			}
+			return strings.TrimSpace(n), nil
+		default:
+			return "", ErrValueTypeMismatch
+		}
+	case ValueTypeBoolean:
+		switch b := v.(type) {
+		case bool:
+			return strconv.FormatBool(b), nil
+		case string:
+			parsed, err := (&Setting{ValueType: ValueTypeBoolean, Value: b}).ParseBool()
+			if err != nil {
+				return "", err
+			}
+			return strconv.FormatBool(parsed), nil
+		default:
+			return "", ErrValueTypeMismatch
+		}
+	case ValueTypeJSON:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", ErrInvalidJSONValue
+		}
+		return string(data), nil
+	default:
+		return "", ErrInvalidValueType
+	}
+}
+
 // SetBool 设置布尔值
 func (s *Setting) SetBool(val bool) {
 	s.ValueType = ValueTypeBoolean
@@ -130,6 +199,7 @@ const (
 	CategorySecurity     = "security"     // 安全配置（密码策略、登录限制等）
 	CategoryNotification = "notification" // 通知配置（邮件、短信等）
 	CategoryBackup       = "backup"       // 备份配置（备份周期、保留策略等）
+	CategoryPreference   = "preference"   // 用户偏好默认值（语言、时区等），可被用户个人覆盖
 )
 
 // 值类型常量。
@@ -140,3 +210,25 @@ const (
 	ValueTypeBoolean = "boolean" // 布尔类型，使用开关控件
 	ValueTypeJSON    = "json"    // JSON 类型，使用 JSON 编辑器
 )
+
+// 业务读取的配置键常量。
+// 对应 seeds 中的默认配置项，业务代码通过这些键读取运行时配置。
+const (
+	KeyPasswordMaxAgeDays       = "security.password_max_age_days"       // 密码最长有效期（天），0 表示不限制
+	KeyRegistrationMode         = "security.registration_mode"           // 注册模式：open / closed / invite_only / approval
+	KeyRegistrationEmailDomains = "security.registration_email_domains"  // 允许注册的邮箱域名（JSON 数组），空表示不限制
+	KeyAccountDeletionGraceDays = "security.account_deletion_grace_days" // 自助删除账号的宽限期（天），期间登录即取消删除
+	KeyInactiveAccountDays      = "security.inactive_account_days"       // 超过该天数未登录的账号自动停用，0 表示不自动停用
+	KeySiteURL                  = "general.site_url"                     // 站点 URL，用于生成邮件中的链接
+	KeyTrashRetentionDays       = "general.trash_retention_days"         // 回收站保留期（天），超期记录被永久删除，0 表示不自动清理
+)
+
+// 用户偏好配置键常量。
+// 管理员在 preference 分类下维护默认值，用户可按需覆盖。
+const (
+	KeyPreferenceLocale              = "preference.locale"                // 界面与邮件语言，如 zh-CN、en
+	KeyPreferenceTimezone            = "preference.timezone"              // IANA 时区，如 Asia/Shanghai
+	KeyPreferenceTheme               = "preference.theme"                 // 界面主题：light / dark / system
+	KeyPreferenceNotifySecurityEmail = "preference.notify_security_email" // 是否接收安全提醒邮件
+	KeyPreferenceNotifyProductEmail  = "preference.notify_product_email"  // 是否接收产品动态邮件
+)
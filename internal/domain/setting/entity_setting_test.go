@@ -39,6 +39,7 @@ func TestSetting_IsValidCategory(t *testing.T) {
 		{"security", CategorySecurity, true},
 		{"notification", CategoryNotification, true},
 		{"backup", CategoryBackup, true},
+		{"preference", CategoryPreference, true},
 		{"invalid", "invalid", false},
 		{"empty", "", false},
 	}
@@ -251,3 +252,64 @@ func TestSetting_IsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestSetting_TypedValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		valueType string
+		value     string
+		want      any
+		wantErr   error
+	}{
+		{"string", ValueTypeString, "hello", "hello", nil},
+		{"number", ValueTypeNumber, "2.5", 2.5, nil},
+		{"boolean", ValueTypeBoolean, "on", true, nil},
+		{"json", ValueTypeJSON, `["a"]`, []any{"a"}, nil},
+		{"invalid number", ValueTypeNumber, "abc", nil, ErrInvalidNumberValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Setting{ValueType: tt.valueType, Value: tt.value}
+			got, err := s.TypedValue()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		valueType string
+		input     any
+		want      string
+		wantErr   error
+	}{
+		{"string", ValueTypeString, "en", "en", nil},
+		{"string from number", ValueTypeString, 1.0, "", ErrValueTypeMismatch},
+		{"number", ValueTypeNumber, 20.0, "20", nil},
+		{"number from string", ValueTypeNumber, " 1.5 ", "1.5", nil},
+		{"invalid number string", ValueTypeNumber, "x", "", ErrInvalidNumberValue},
+		{"boolean", ValueTypeBoolean, true, "true", nil},
+		{"boolean from string", ValueTypeBoolean, "off", "false", nil},
+		{"json", ValueTypeJSON, map[string]any{"a": 1.0}, `{"a":1}`, nil},
+		{"unknown type", "xml", "x", "", ErrInvalidValueType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeValue(tt.valueType, tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
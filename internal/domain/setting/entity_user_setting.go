@@ -0,0 +1,185 @@
+package setting
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// 偏好兜底值，未配置默认项或解析失败时使用
+const (
+	DefaultPreferenceLocale   = "zh-CN"
+	DefaultPreferenceTimezone = "UTC"
+)
+
+// PreferenceTimeLayout 按用户时区展示时间的格式
+const PreferenceTimeLayout = "2006-01-02 15:04:05 -07:00"
+
+// 主题取值
+const (
+	ThemeLight  = "light"
+	ThemeDark   = "dark"
+	ThemeSystem = "system"
+)
+
+// localePattern 语言标签格式，如 en、zh-CN
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// UserSetting 用户对某个偏好项的个人覆盖值。
+// Value 按对应默认项的 ValueType 存储为字符串。
+type UserSetting struct {
+	ID        uint      `json:"id"`
+	UserID    uint      `json:"user_id"`
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Preference 合并默认值与个人覆盖后的单个偏好项
+type Preference struct {
+	Default    *Setting // 管理员定义的默认项
+	Value      string   // 生效值
+	Overridden bool     // 是否为用户个人覆盖值
+}
+
+// Typed 按默认项的 ValueType 解析生效值
+func (p *Preference) Typed() (any, error) {
+	return (&Setting{ValueType: p.Default.ValueType, Value: p.Value}).TypedValue()
+}
+
+// Preferences 用户生效的偏好集合，按键索引
+type Preferences struct {
+	items map[string]*Preference
+}
+
+// ResolvePreferences 合并 preference 分类下的默认项与用户覆盖值。
+// 覆盖值对应的默认项已删除或按默认项类型无法解析时忽略该覆盖。
+func ResolvePreferences(defaults []*Setting, overrides []*UserSetting) *Preferences {
+	p := &Preferences{items: make(map[string]*Preference, len(defaults))}
+	for _, d := range defaults {
+		if d == nil || d.Category != CategoryPreference {
+			continue
+		}
+		p.items[d.Key] = &Preference{Default: d, Value: d.Value}
+	}
+	for _, o := range overrides {
+		item, ok := p.items[o.Key]
+		if !ok {
+			continue
+		}
+		if err := ValidatePreferenceValue(item.Default, o.Value); err != nil {
+			continue
+		}
+		item.Value = o.Value
+		item.Overridden = true
+	}
+	return p
+}
+
+// Get 返回指定键的偏好项，不存在时返回 nil
+func (p *Preferences) Get(key string) *Preference {
+	if p == nil {
+		return nil
+	}
+	return p.items[key]
+}
+
+// Keys 返回按字母排序的偏好键
+func (p *Preferences) Keys() []string {
+	if p == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(p.items))
+	for k := range p.items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String 返回字符串偏好值，不存在时返回 fallback
+func (p *Preferences) String(key, fallback string) string {
+	if item := p.Get(key); item != nil && item.Value != "" {
+		return item.Value
+	}
+	return fallback
+}
+
+// Bool 返回布尔偏好值，不存在或无法解析时返回 fallback
+func (p *Preferences) Bool(key string, fallback bool) bool {
+	item := p.Get(key)
+	if item == nil {
+		return fallback
+	}
+	v, err := (&Setting{ValueType: ValueTypeBoolean, Value: item.Value}).ParseBool()
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+// Locale 返回生效的语言标签
+func (p *Preferences) Locale() string {
+	return p.String(KeyPreferenceLocale, DefaultPreferenceLocale)
+}
+
+// Location 返回生效的时区，无法加载时回退到 UTC
+func (p *Preferences) Location() *time.Location {
+	loc, err := time.LoadLocation(p.String(KeyPreferenceTimezone, DefaultPreferenceTimezone))
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// FormatTime 按用户时区格式化时间，用于审计记录等服务端展示
+func (p *Preferences) FormatTime(t time.Time) string {
+	return t.In(p.Location()).Format(PreferenceTimeLayout)
+}
+
+// ValidatePreferenceValue 校验覆盖值能否按默认项的 ValueType 解析，并对内置偏好键做取值校验
+func ValidatePreferenceValue(def *Setting, value string) error {
+	s := &Setting{ValueType: def.ValueType, Value: value}
+	if _, err := s.TypedValue(); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidPreferenceValue, def.Key, err)
+	}
+
+	switch def.Key {
+	case KeyPreferenceLocale:
+		if !localePattern.MatchString(value) {
+			return fmt.Errorf("%w: %s: malformed locale %q", ErrInvalidPreferenceValue, def.Key, value)
+		}
+	case KeyPreferenceTimezone:
+		if _, err := time.LoadLocation(value); err != nil || value == "" {
+			return fmt.Errorf("%w: %s: unknown timezone %q", ErrInvalidPreferenceValue, def.Key, value)
+		}
+	case KeyPreferenceTheme:
+		switch value {
+		case ThemeLight, ThemeDark, ThemeSystem:
+		default:
+			return fmt.Errorf("%w: %s: unsupported theme %q", ErrInvalidPreferenceValue, def.Key, value)
+		}
+	}
+	return nil
+}
+
+// PreferenceResolver 解析用户生效偏好，供邮件、审计等服务端功能使用
+type PreferenceResolver interface {
+	Resolve(ctx context.Context, userID uint) (*Preferences, error)
+}
+
+// ResolveFor 使用可选的解析器解析用户偏好
+// 未配置解析器或解析失败时返回空集合，调用方据此使用兜底值
+func ResolveFor(ctx context.Context, r PreferenceResolver, userID uint) *Preferences {
+	if r == nil {
+		return ResolvePreferences(nil, nil)
+	}
+	prefs, err := r.Resolve(ctx, userID)
+	if err != nil || prefs == nil {
+		return ResolvePreferences(nil, nil)
+	}
+	return prefs
+}
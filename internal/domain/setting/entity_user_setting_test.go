@@ -0,0 +1,83 @@
+package setting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPreferenceDefaults() []*Setting {
+	return []*Setting{
+		{Key: KeyPreferenceLocale, Value: "zh-CN", Category: CategoryPreference, ValueType: ValueTypeString},
+		{Key: KeyPreferenceTimezone, Value: "Asia/Shanghai", Category: CategoryPreference, ValueType: ValueTypeString},
+		{Key: KeyPreferenceNotifyProductEmail, Value: "false", Category: CategoryPreference, ValueType: ValueTypeBoolean},
+		{Key: "general.site_name", Value: "demo", Category: CategoryGeneral, ValueType: ValueTypeString},
+	}
+}
+
+func TestResolvePreferences(t *testing.T) {
+	prefs := ResolvePreferences(testPreferenceDefaults(), []*UserSetting{
+		{UserID: 1, Key: KeyPreferenceLocale, Value: "en"},
+		{UserID: 1, Key: KeyPreferenceNotifyProductEmail, Value: "true"},
+		{UserID: 1, Key: KeyPreferenceTimezone, Value: "Mars/Olympus"}, // 非法覆盖值被忽略
+		{UserID: 1, Key: "preference.removed", Value: "x"},             // 默认项已删除
+	})
+
+	assert.Equal(t, []string{KeyPreferenceLocale, KeyPreferenceNotifyProductEmail, KeyPreferenceTimezone}, prefs.Keys())
+	assert.Equal(t, "en", prefs.Locale())
+	assert.True(t, prefs.Get(KeyPreferenceLocale).Overridden)
+	assert.True(t, prefs.Bool(KeyPreferenceNotifyProductEmail, false))
+	assert.Equal(t, "Asia/Shanghai", prefs.Location().String())
+	assert.False(t, prefs.Get(KeyPreferenceTimezone).Overridden)
+	assert.Nil(t, prefs.Get("general.site_name"))
+}
+
+func TestPreferences_Fallbacks(t *testing.T) {
+	prefs := ResolvePreferences(nil, nil)
+
+	assert.Equal(t, DefaultPreferenceLocale, prefs.Locale())
+	assert.Equal(t, time.UTC, prefs.Location())
+	assert.True(t, prefs.Bool(KeyPreferenceNotifySecurityEmail, true))
+
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, "2026-01-02 03:04:05 +00:00", prefs.FormatTime(ts))
+}
+
+func TestPreferences_FormatTime(t *testing.T) {
+	prefs := ResolvePreferences(testPreferenceDefaults(), nil)
+
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, "2026-01-02 11:04:05 +08:00", prefs.FormatTime(ts))
+}
+
+func TestValidatePreferenceValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     *Setting
+		value   string
+		wantErr bool
+	}{
+		{"合法语言", &Setting{Key: KeyPreferenceLocale, ValueType: ValueTypeString}, "en-US", false},
+		{"非法语言", &Setting{Key: KeyPreferenceLocale, ValueType: ValueTypeString}, "english!", true},
+		{"合法时区", &Setting{Key: KeyPreferenceTimezone, ValueType: ValueTypeString}, "Europe/Berlin", false},
+		{"未知时区", &Setting{Key: KeyPreferenceTimezone, ValueType: ValueTypeString}, "Mars/Olympus", true},
+		{"空时区", &Setting{Key: KeyPreferenceTimezone, ValueType: ValueTypeString}, "", true},
+		{"合法主题", &Setting{Key: KeyPreferenceTheme, ValueType: ValueTypeString}, ThemeDark, false},
+		{"非法主题", &Setting{Key: KeyPreferenceTheme, ValueType: ValueTypeString}, "neon", true},
+		{"布尔类型", &Setting{Key: "preference.custom", ValueType: ValueTypeBoolean}, "maybe", true},
+		{"数值类型", &Setting{Key: "preference.page_size", ValueType: ValueTypeNumber}, "20", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePreferenceValue(tt.def, tt.value)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidPreferenceValue)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
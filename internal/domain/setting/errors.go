@@ -29,4 +29,8 @@ var (
 
 	// ErrInvalidNumberValue 无效的数值
 	ErrInvalidNumberValue = errors.New("invalid number value")
+	// ErrUnknownPreference 偏好项不存在（未在 preference 分类下定义默认值）
+	ErrUnknownPreference = errors.New("unknown preference")
+	// ErrInvalidPreferenceValue 偏好值不合法
+	ErrInvalidPreferenceValue = errors.New("invalid preference value")
 )
@@ -0,0 +1,25 @@
+package setting
+
+import (
+	"context"
+	"time"
+)
+
+// PasswordMaxAge 从系统配置读取密码最长有效期
+// 未配置、读取失败或值 <= 0 时返回 0（不限制）
+func PasswordMaxAge(ctx context.Context, queryRepo QueryRepository) time.Duration {
+	if queryRepo == nil {
+		return 0
+	}
+
+	s, err := queryRepo.FindByKey(ctx, KeyPasswordMaxAgeDays)
+	if err != nil || s == nil {
+		return 0
+	}
+
+	days, err := s.ParseInt()
+	if err != nil || days <= 0 {
+		return 0
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -0,0 +1,17 @@
+package setting
+
+import "context"
+
+// UserSettingCommandRepository 定义用户偏好覆盖值写操作接口
+type UserSettingCommandRepository interface {
+	// Upsert 按 (UserID, Key) 插入或更新覆盖值
+	Upsert(ctx context.Context, settings []*UserSetting) error
+	// DeleteByKeys 删除用户指定键的覆盖值（恢复默认）
+	DeleteByKeys(ctx context.Context, userID uint, keys []string) error
+}
+
+// UserSettingQueryRepository 定义用户偏好覆盖值读操作接口
+type UserSettingQueryRepository interface {
+	// FindByUserID 查找用户的全部覆盖值
+	FindByUserID(ctx context.Context, userID uint) ([]*UserSetting, error)
+}
@@ -10,6 +10,7 @@
 //   - 用户统计：总数、活跃、未激活、禁用
 //   - 角色统计：角色总数、权限总数
 //   - 菜单统计：菜单总数
+//   - 限时授权：生效中的限时角色授权数、计划授权数
 //   - 近期审计日志
 //
 // 设计说明：
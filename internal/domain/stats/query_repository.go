@@ -12,6 +12,10 @@ type SystemStats struct {
 	TotalPermissions int64
 	TotalMenus       int64
 	RecentAuditLogs  []AuditLogSummary
+
+	// 限时角色授权：生效中且设置了到期时间的授权数，以及尚未开始的计划授权数
+	TemporaryRoleGrants int64
+	ScheduledRoleGrants int64
 }
 
 // AuditLogSummary 审计日志摘要
@@ -45,6 +49,12 @@ type QueryRepository interface {
 	// GetTotalMenus 获取菜单总数
 	GetTotalMenus() (int64, error)
 
+	// GetTemporaryRoleGrants 获取生效中的限时角色授权数量
+	GetTemporaryRoleGrants() (int64, error)
+
+	// GetScheduledRoleGrants 获取尚未开始的计划角色授权数量
+	GetScheduledRoleGrants() (int64, error)
+
 	// GetRecentAuditLogs 获取最近的审计日志
 	GetRecentAuditLogs(limit int) ([]AuditLogSummary, error)
 }
@@ -0,0 +1,26 @@
+package trash
+
+import (
+	"context"
+	"time"
+)
+
+// Bin 单个资源的回收站仓储接口
+type Bin interface {
+	// Resource 返回资源类型（见 Resource* 常量）
+	Resource() string
+
+	// List 按删除时间倒序分页列出已删除记录及总数
+	List(ctx context.Context, offset, limit int) ([]*Item, int64, error)
+
+	// Restore 恢复已删除记录
+	// 记录不存在或未删除时返回 [ErrItemNotFound]，唯一键被占用或上级记录已删除时返回 [ErrRestoreConflict]
+	Restore(ctx context.Context, id uint) error
+
+	// Purge 永久删除已删除记录及其从属数据
+	// 记录不存在或未删除时返回 [ErrItemNotFound]，仍被其他记录引用时返回 [ErrPurgeConflict]
+	Purge(ctx context.Context, id uint) error
+
+	// ExpiredIDs 返回删除时间早于 cutoff 的记录 ID，最多 limit 条
+	ExpiredIDs(ctx context.Context, cutoff time.Time, limit int) ([]uint, error)
+}
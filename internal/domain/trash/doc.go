@@ -0,0 +1,15 @@
+// Package trash 定义回收站领域模型。
+//
+// 用户、菜单、个人访问令牌与审计日志采用软删除，删除后的记录保留在原表中。
+// 本包为这些资源提供统一的回收站能力：
+//   - [Item]: 回收站条目（资源类型、ID、展示名称、删除时间）
+//   - [Bin]: 单个资源的回收站仓储接口（列出、恢复、永久删除）
+//   - 回收站领域错误（见 errors.go）
+//
+// 恢复冲突：
+// 软删除期间原记录的唯一键可能已被新记录占用（如重新注册了相同用户名），
+// 或其依赖的上级记录已被删除（如父菜单、令牌所属用户），此时恢复返回 [ErrRestoreConflict]。
+//
+// 保留期：
+// 删除时间超过保留天数的记录由后台任务永久删除，保留天数为 0 时不自动清理。
+package trash
@@ -0,0 +1,31 @@
+package trash
+
+import "time"
+
+// 支持回收站的资源类型，与管理接口路径中的 resource 参数一致。
+const (
+	ResourceUsers     = "users"
+	ResourceMenus     = "menus"
+	ResourceTokens    = "tokens"
+	ResourceAuditLogs = "auditlogs"
+)
+
+// DefaultPurgeBatchSize 保留期清理任务每个资源单轮最多永久删除的记录数
+const DefaultPurgeBatchSize = 100
+
+// Item 回收站条目
+type Item struct {
+	ID        uint      `json:"id"`
+	Resource  string    `json:"resource"`
+	Label     string    `json:"label"` // 便于识别的展示名称，如用户名、菜单标题
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// RetentionCutoff 返回保留期截止时间，删除时间早于该时间的记录应被永久删除。
+// retentionDays <= 0 表示不自动清理，返回 false。
+func RetentionCutoff(now time.Time, retentionDays int) (time.Time, bool) {
+	if retentionDays <= 0 {
+		return time.Time{}, false
+	}
+	return now.AddDate(0, 0, -retentionDays), true
+}
@@ -0,0 +1,22 @@
+package trash
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionCutoff(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	cutoff, ok := RetentionCutoff(now, 30)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), cutoff)
+
+	_, ok = RetentionCutoff(now, 0)
+	assert.False(t, ok)
+
+	_, ok = RetentionCutoff(now, -1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,14 @@
+package trash
+
+import "errors"
+
+var (
+	// ErrUnsupportedResource 资源类型不支持回收站
+	ErrUnsupportedResource = errors.New("resource does not support trash")
+	// ErrItemNotFound 回收站中不存在该记录（记录不存在或未被删除）
+	ErrItemNotFound = errors.New("deleted item not found")
+	// ErrRestoreConflict 恢复会违反唯一约束或引用已删除的上级记录
+	ErrRestoreConflict = errors.New("restore conflicts with existing data")
+	// ErrPurgeConflict 记录仍被其他记录引用，不能永久删除
+	ErrPurgeConflict = errors.New("item is still referenced")
+)
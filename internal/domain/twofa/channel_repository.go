@@ -0,0 +1,32 @@
+package twofa
+
+import "context"
+
+// ChannelCommandRepository 定义验证通道写操作接口
+type ChannelCommandRepository interface {
+	// Save 创建或更新验证通道（按 UserID + Method 唯一）
+	Save(ctx context.Context, channel *Channel) error
+	// Delete 删除指定方式的验证通道
+	Delete(ctx context.Context, userID uint, method Method) error
+	// DeleteByUserID 删除用户的全部验证通道
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
+
+// ChannelQueryRepository 定义验证通道读操作接口
+type ChannelQueryRepository interface {
+	// FindByUserIDAndMethod 查找指定方式的验证通道，不存在时返回 nil
+	FindByUserIDAndMethod(ctx context.Context, userID uint, method Method) (*Channel, error)
+	// ListByUserID 列出用户的全部验证通道
+	ListByUserID(ctx context.Context, userID uint) ([]*Channel, error)
+}
+
+// ChallengeRepository 定义一次性验证码挑战的存取接口
+// 验证码具有时效性，通常由内存或 Redis 实现
+type ChallengeRepository interface {
+	// Update 原子地读取并修改挑战：fn 收到当前挑战（不存在时为 nil），
+	// 返回非 nil 的挑战时将其保存；fn 返回的错误原样返回。
+	// 同一挑战的并发 Update 必须串行执行，保证验证码只能被消费一次。
+	Update(ctx context.Context, userID uint, method Method, purpose OTPPurpose, fn func(*OTPChallenge) (*OTPChallenge, error)) error
+	// Delete 删除挑战
+	Delete(ctx context.Context, userID uint, method Method, purpose OTPPurpose) error
+}
@@ -1,10 +1,15 @@
 // Package twofa 定义双因素认证（Two-Factor Authentication）领域模型。
 //
-// 本包实现基于 TOTP（时间同步一次性密码）的双因素认证，定义了：
+// 本包实现基于 TOTP（时间同步一次性密码）的双因素认证，
+// 并支持邮件、短信一次性验证码作为备选第二因素，定义了：
 //   - [TwoFA]: 用户 2FA 配置实体
-//   - [RecoveryCodes]: 恢复码值对象（见 value_objects.go）
-//   - [CommandRepository]: 写仓储接口
-//   - [QueryRepository]: 读仓储接口
+//   - [Channel]: 邮件/短信验证通道实体
+//   - [OTPChallenge]: 一次性验证码挑战实体（过期、限频、一次性）
+//   - [RecoveryCodes]、[Method]、[OTPPolicy]: 值对象（见 value_objects.go）
+//   - [CommandRepository]、[ChannelCommandRepository]: 写仓储接口
+//   - [QueryRepository]、[ChannelQueryRepository]: 读仓储接口
+//   - [ChallengeRepository]: 验证码挑战存储接口
+//   - [OTPSender]、[SMSProvider]: 验证码投递接口
 //   - 2FA 领域错误（见 errors.go）
 //
 // 兼容的 Authenticator 应用：
@@ -16,11 +21,13 @@
 //   - TOTP 密钥管理：[TwoFA.Secret] 存储 Base32 编码的密钥
 //   - 恢复码：[TwoFA.RecoveryCodes] 用于设备丢失时的账户恢复
 //   - 状态管理：[TwoFA.Enable] / [TwoFA.Disable]
+//   - OTP 通道：邮件/短信通道绑定后可在登录二次认证时选择
 //
 // 安全设计：
 //   - Secret 字段不在 JSON 中暴露
 //   - 恢复码为一次性使用（[TwoFA.UseRecoveryCode]）
 //   - 恢复码格式：xxxx-xxxx（8 位数字）
+//   - OTP 验证码仅保存哈希，超过尝试次数或过期即失效
 //
 // 依赖倒置：
 // 本包仅定义接口，实现位于 infrastructure/persistence 和 infrastructure/twofa 包。
@@ -0,0 +1,69 @@
+package twofa
+
+import "time"
+
+// Channel 用户一次性验证码投递通道实体（邮件/短信）
+//
+// 与 [TwoFA]（TOTP）并列，每个用户每种 [Method] 最多一个通道。
+// 通道需经过验证码确认后才会启用。
+type Channel struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID      uint   `json:"user_id"`
+	Method      Method `json:"method"`      // 验证方式（email / sms）
+	Destination string `json:"destination"` // 投递地址（邮箱或手机号）
+	Enabled     bool   `json:"enabled"`     // 是否已启用
+
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`  // 完成确认的时间
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"` // 最后使用时间
+}
+
+// IsEnabled 检查通道是否已启用
+func (c *Channel) IsEnabled() bool {
+	return c.Enabled
+}
+
+// Enable 确认并启用通道
+func (c *Channel) Enable() {
+	c.Enabled = true
+	now := time.Now()
+	c.VerifiedAt = &now
+}
+
+// MarkUsed 标记最后使用时间
+func (c *Channel) MarkUsed() {
+	now := time.Now()
+	c.LastUsedAt = &now
+}
+
+// MaskedDestination 返回脱敏后的投递地址，用于提示用户验证码发往何处
+func (c *Channel) MaskedDestination() string {
+	return MaskDestination(c.Method, c.Destination)
+}
+
+// MaskDestination 对邮箱或手机号做脱敏处理
+//   - 邮箱：保留首字符和域名，如 j***@example.com
+//   - 手机号：保留末 4 位，如 ****5678
+func MaskDestination(method Method, destination string) string {
+	switch method {
+	case MethodEmail:
+		for i := range len(destination) {
+			if destination[i] == '@' {
+				if i == 0 {
+					return destination
+				}
+				return destination[:1] + "***" + destination[i:]
+			}
+		}
+		return destination
+	case MethodSMS:
+		if len(destination) <= 4 {
+			return destination
+		}
+		return "****" + destination[len(destination)-4:]
+	default:
+		return destination
+	}
+}
@@ -0,0 +1,119 @@
+package twofa
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// OTPChallenge 一次性验证码挑战实体
+//
+// 记录某个用户、某种验证方式、某种用途下最近一次下发的验证码。
+// 验证码仅保存哈希值，具备过期、限频和一次性使用特性：
+//   - 过期：超过 [OTPChallenge.ExpiresAt] 后失效
+//   - 限频：[OTPChallenge.CanSend] 检查重发间隔和窗口内发送次数
+//   - 一次性：验证成功后调用 [OTPChallenge.Consume] 使其失效
+//   - 防暴力：超过最大尝试次数后失效
+type OTPChallenge struct {
+	UserID  uint
+	Method  Method
+	Purpose OTPPurpose
+
+	CodeHash    string    // 验证码 SHA-256 哈希
+	Destination string    // 投递地址
+	ExpiresAt   time.Time // 过期时间
+	Attempts    int       // 已尝试次数
+
+	LastSentAt  time.Time // 最后发送时间
+	WindowStart time.Time // 当前统计窗口起始时间
+	SendCount   int       // 窗口内发送次数
+}
+
+// CanSend 检查当前是否允许再次发送验证码
+func (c *OTPChallenge) CanSend(now time.Time, policy *OTPPolicy) bool {
+	if c.LastSentAt.IsZero() {
+		return true
+	}
+	if now.Sub(c.LastSentAt) < policy.ResendInterval {
+		return false
+	}
+	if now.Sub(c.WindowStart) >= policy.SendWindow {
+		return true
+	}
+	return c.SendCount < policy.MaxSendsPerWindow
+}
+
+// Issue 下发新验证码，重置过期时间与尝试次数并累计发送次数
+func (c *OTPChallenge) Issue(code, destination string, now time.Time, policy *OTPPolicy) {
+	if c.WindowStart.IsZero() || now.Sub(c.WindowStart) >= policy.SendWindow {
+		c.WindowStart = now
+		c.SendCount = 0
+	}
+	c.CodeHash = HashOTPCode(code)
+	c.Destination = destination
+	c.ExpiresAt = now.Add(policy.TTL)
+	c.Attempts = 0
+	c.LastSentAt = now
+	c.SendCount++
+}
+
+// IsExpired 检查验证码是否过期
+func (c *OTPChallenge) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// Verify 校验验证码
+//
+// 每次调用都会累计尝试次数；返回 nil 表示校验通过，调用方应随即调用 Consume。
+func (c *OTPChallenge) Verify(code string, now time.Time, policy *OTPPolicy) error {
+	if c.CodeHash == "" {
+		return ErrOTPNotFound
+	}
+	if c.IsExpired(now) {
+		return ErrOTPExpired
+	}
+	if c.Attempts >= policy.MaxAttempts {
+		return ErrOTPTooManyAttempts
+	}
+	c.Attempts++
+	if subtle.ConstantTimeCompare([]byte(c.CodeHash), []byte(HashOTPCode(code))) != 1 {
+		return ErrInvalidOTPCode
+	}
+	return nil
+}
+
+// Consume 使验证码失效（保留限频信息）
+func (c *OTPChallenge) Consume() {
+	c.CodeHash = ""
+	c.Attempts = 0
+}
+
+// HashOTPCode 计算验证码哈希
+func HashOTPCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateOTPCode 生成指定位数的数字验证码
+// 丢弃 >= 250 的随机字节，避免取模偏差
+func GenerateOTPCode(length int, randReader func([]byte) (int, error)) (string, error) {
+	code := make([]byte, 0, length)
+	b := make([]byte, 1)
+	for len(code) < length {
+		if _, err := randReader(b); err != nil {
+			return "", err
+		}
+		if b[0] >= 250 {
+			continue
+		}
+		code = append(code, '0'+b[0]%10)
+	}
+	return string(code), nil
+}
+
+// OTPMessage 生成验证码通知正文
+func OTPMessage(code string, ttl time.Duration) string {
+	return fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(ttl.Minutes()))
+}
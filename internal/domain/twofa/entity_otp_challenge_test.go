@@ -0,0 +1,105 @@
+package twofa
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTPChallenge_CanSend(t *testing.T) {
+	policy := DefaultOTPPolicy()
+	now := time.Now()
+
+	t.Run("首次发送", func(t *testing.T) {
+		c := &OTPChallenge{}
+		assert.True(t, c.CanSend(now, policy))
+	})
+
+	t.Run("重发间隔内被限制", func(t *testing.T) {
+		c := &OTPChallenge{}
+		c.Issue("123456", "a@example.com", now, policy)
+		assert.False(t, c.CanSend(now.Add(30*time.Second), policy))
+		assert.True(t, c.CanSend(now.Add(policy.ResendInterval), policy))
+	})
+
+	t.Run("窗口内超过最大发送次数", func(t *testing.T) {
+		c := &OTPChallenge{}
+		sentAt := now
+		for range policy.MaxSendsPerWindow {
+			c.Issue("123456", "a@example.com", sentAt, policy)
+			sentAt = sentAt.Add(policy.ResendInterval)
+		}
+		assert.False(t, c.CanSend(sentAt, policy))
+		assert.True(t, c.CanSend(now.Add(policy.SendWindow), policy))
+	})
+}
+
+func TestOTPChallenge_Verify(t *testing.T) {
+	policy := DefaultOTPPolicy()
+	now := time.Now()
+
+	t.Run("验证成功后失效", func(t *testing.T) {
+		c := &OTPChallenge{}
+		c.Issue("123456", "a@example.com", now, policy)
+		require.NoError(t, c.Verify("123456", now, policy))
+		c.Consume()
+		assert.ErrorIs(t, c.Verify("123456", now, policy), ErrOTPNotFound)
+	})
+
+	t.Run("验证码过期", func(t *testing.T) {
+		c := &OTPChallenge{}
+		c.Issue("123456", "a@example.com", now, policy)
+		assert.ErrorIs(t, c.Verify("123456", now.Add(policy.TTL), policy), ErrOTPExpired)
+	})
+
+	t.Run("超过最大尝试次数", func(t *testing.T) {
+		c := &OTPChallenge{}
+		c.Issue("123456", "a@example.com", now, policy)
+		for range policy.MaxAttempts {
+			assert.ErrorIs(t, c.Verify("000000", now, policy), ErrInvalidOTPCode)
+		}
+		assert.ErrorIs(t, c.Verify("123456", now, policy), ErrOTPTooManyAttempts)
+	})
+}
+
+func TestGenerateOTPCode(t *testing.T) {
+	t.Run("生成指定位数", func(t *testing.T) {
+		seq := []byte{255, 3, 251, 17, 42, 9, 99, 250}
+		i := 0
+		reader := func(b []byte) (int, error) {
+			b[0] = seq[i%len(seq)]
+			i++
+			return 1, nil
+		}
+		code, err := GenerateOTPCode(6, reader)
+		require.NoError(t, err)
+		assert.Equal(t, "372993", code)
+	})
+
+	t.Run("随机源错误", func(t *testing.T) {
+		_, err := GenerateOTPCode(6, func([]byte) (int, error) { return 0, errors.New("boom") })
+		assert.Error(t, err)
+	})
+}
+
+func TestMaskDestination(t *testing.T) {
+	assert.Equal(t, "j***@example.com", MaskDestination(MethodEmail, "john@example.com"))
+	assert.Equal(t, "****5678", MaskDestination(MethodSMS, "+8613812345678"))
+	assert.Equal(t, "123", MaskDestination(MethodSMS, "123"))
+}
+
+func TestParseMethod(t *testing.T) {
+	m, err := ParseMethod("")
+	require.NoError(t, err)
+	assert.Equal(t, MethodTOTP, m)
+
+	m, err = ParseMethod("sms")
+	require.NoError(t, err)
+	assert.True(t, m.IsOTPChannel())
+
+	_, err = ParseMethod("fax")
+	assert.ErrorIs(t, err, ErrUnsupportedMethod)
+}
@@ -30,3 +30,26 @@ var (
 	// ErrRecoveryCodeAlreadyUsed 恢复码已被使用
 	ErrRecoveryCodeAlreadyUsed = errors.New("recovery code already used")
 )
+
+var (
+	// ErrUnsupportedMethod 不支持的验证方式
+	ErrUnsupportedMethod = errors.New("unsupported two-factor method")
+	// ErrChannelNotFound 验证通道不存在
+	ErrChannelNotFound = errors.New("two-factor channel not found")
+	// ErrChannelNotEnabled 验证通道未启用
+	ErrChannelNotEnabled = errors.New("two-factor channel not enabled")
+	// ErrChannelAlreadyEnabled 验证通道已启用
+	ErrChannelAlreadyEnabled = errors.New("two-factor channel already enabled")
+	// ErrDestinationRequired 缺少投递地址（邮箱或手机号）
+	ErrDestinationRequired = errors.New("delivery destination is required")
+	// ErrOTPThrottled 验证码发送过于频繁
+	ErrOTPThrottled = errors.New("verification code requested too frequently")
+	// ErrOTPNotFound 验证码不存在或已使用
+	ErrOTPNotFound = errors.New("verification code not found or already used")
+	// ErrOTPExpired 验证码已过期
+	ErrOTPExpired = errors.New("verification code expired")
+	// ErrInvalidOTPCode 验证码错误
+	ErrInvalidOTPCode = errors.New("invalid verification code")
+	// ErrOTPTooManyAttempts 验证码尝试次数过多
+	ErrOTPTooManyAttempts = errors.New("too many verification attempts")
+)
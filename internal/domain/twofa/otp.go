@@ -0,0 +1,42 @@
+package twofa
+
+import "context"
+
+// OTPSender 一次性验证码投递接口
+// 每种 OTP 通道（邮件、短信）提供一个实现
+type OTPSender interface {
+	// Method 返回该投递器对应的验证方式
+	Method() Method
+	// Send 将验证码投递到指定地址
+	Send(ctx context.Context, destination, code string) error
+}
+
+// SMSProvider 短信服务商接口
+// 对接具体的短信网关（阿里云、Twilio 等），测试中使用假实现
+type SMSProvider interface {
+	// SendSMS 发送短信
+	SendSMS(ctx context.Context, phone, message string) error
+}
+
+// ChannelSetupResult 通道绑定结果
+type ChannelSetupResult struct {
+	Method      Method // 验证方式
+	Destination string // 脱敏后的投递地址
+}
+
+// OTPService 邮件/短信一次性验证码领域服务接口
+type OTPService interface {
+	// SetupChannel 绑定通道并发送确认码
+	// destination 为空时，邮件通道默认使用用户邮箱
+	SetupChannel(ctx context.Context, userID uint, method Method, destination string) (*ChannelSetupResult, error)
+	// VerifyAndEnableChannel 校验确认码并启用通道
+	VerifyAndEnableChannel(ctx context.Context, userID uint, method Method, code string) error
+	// DisableChannel 解绑通道
+	DisableChannel(ctx context.Context, userID uint, method Method) error
+	// SendLoginCode 向已启用的通道发送登录验证码，返回脱敏后的投递地址
+	SendLoginCode(ctx context.Context, userID uint, method Method) (string, error)
+	// VerifyLoginCode 校验登录验证码（一次性使用）
+	VerifyLoginCode(ctx context.Context, userID uint, method Method, code string) (bool, error)
+	// EnabledMethods 返回用户已启用的 OTP 通道方式
+	EnabledMethods(ctx context.Context, userID uint) ([]Method, error)
+}
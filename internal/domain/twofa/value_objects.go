@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"time"
 )
 
 // RecoveryCodes 是 2FA 恢复码的值对象。
@@ -51,3 +52,86 @@ func (r RecoveryCodes) Value() (driver.Value, error) {
 	}
 	return json.Marshal(r)
 }
+
+// Method 是第二因素验证方式的值对象。
+//
+// 支持的验证方式：
+//   - [MethodTOTP]: 认证器应用生成的 TOTP 码（默认）
+//   - [MethodEmail]: 通过邮件发送的一次性验证码
+//   - [MethodSMS]: 通过短信发送的一次性验证码
+type Method string
+
+const (
+	// MethodTOTP 认证器应用（TOTP）
+	MethodTOTP Method = "totp"
+	// MethodEmail 邮件一次性验证码
+	MethodEmail Method = "email"
+	// MethodSMS 短信一次性验证码
+	MethodSMS Method = "sms"
+)
+
+// ParseMethod 解析验证方式，空字符串视为 TOTP（保持向后兼容）
+func ParseMethod(s string) (Method, error) {
+	if s == "" {
+		return MethodTOTP, nil
+	}
+	m := Method(s)
+	if !m.IsValid() {
+		return "", ErrUnsupportedMethod
+	}
+	return m, nil
+}
+
+// IsValid 检查验证方式是否受支持
+func (m Method) IsValid() bool {
+	switch m {
+	case MethodTOTP, MethodEmail, MethodSMS:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsOTPChannel 检查是否为需要投递验证码的通道（邮件/短信）
+func (m Method) IsOTPChannel() bool {
+	return m == MethodEmail || m == MethodSMS
+}
+
+// String 返回验证方式字符串
+func (m Method) String() string {
+	return string(m)
+}
+
+// OTPPurpose 一次性验证码用途
+type OTPPurpose string
+
+const (
+	// OTPPurposeEnroll 绑定通道时的确认码
+	OTPPurposeEnroll OTPPurpose = "enroll"
+	// OTPPurposeLogin 登录二次认证码
+	OTPPurposeLogin OTPPurpose = "login"
+)
+
+// OTPPolicy 一次性验证码策略值对象。
+//
+// 控制验证码的长度、有效期、发送频率和最大尝试次数。
+type OTPPolicy struct {
+	CodeLength        int           // 验证码位数
+	TTL               time.Duration // 验证码有效期
+	ResendInterval    time.Duration // 两次发送的最小间隔
+	SendWindow        time.Duration // 发送次数统计窗口
+	MaxSendsPerWindow int           // 窗口内最大发送次数
+	MaxAttempts       int           // 单个验证码最大尝试次数
+}
+
+// DefaultOTPPolicy 默认一次性验证码策略
+func DefaultOTPPolicy() *OTPPolicy {
+	return &OTPPolicy{
+		CodeLength:        6,
+		TTL:               5 * time.Minute,
+		ResendInterval:    time.Minute,
+		SendWindow:        time.Hour,
+		MaxSendsPerWindow: 5,
+		MaxAttempts:       5,
+	}
+}
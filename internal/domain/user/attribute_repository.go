@@ -0,0 +1,27 @@
+package user
+
+import "context"
+
+// AttributeDefinitionCommandRepository 自定义属性定义写仓储接口
+type AttributeDefinitionCommandRepository interface {
+	// Create 创建属性定义
+	Create(ctx context.Context, def *AttributeDefinition) error
+
+	// Update 更新属性定义
+	Update(ctx context.Context, def *AttributeDefinition) error
+
+	// Delete 删除属性定义及所有用户在该属性上的值
+	Delete(ctx context.Context, id uint) error
+}
+
+// AttributeDefinitionQueryRepository 自定义属性定义读仓储接口
+type AttributeDefinitionQueryRepository interface {
+	// GetByID 根据 ID 获取属性定义
+	GetByID(ctx context.Context, id uint) (*AttributeDefinition, error)
+
+	// ExistsByKey 检查属性键是否已存在
+	ExistsByKey(ctx context.Context, key string) (bool, error)
+
+	// List 按排序值与 ID 列出全部属性定义
+	List(ctx context.Context) ([]*AttributeDefinition, error)
+}
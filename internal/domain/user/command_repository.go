@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 )
 
 // CommandRepository 用户命令仓储接口
@@ -22,9 +23,27 @@ type CommandRepository interface {
 	// RemoveRoles 移除用户的角色
 	RemoveRoles(ctx context.Context, userID uint, roleIDs []uint) error
 
-	// UpdatePassword 更新用户密码
+	// UpdatePassword 更新用户密码（用户自行修改，清除强制修改标记并记录修改时间）
 	UpdatePassword(ctx context.Context, userID uint, hashedPassword string) error
 
+	// ResetPassword 管理员重置用户密码（设置强制修改标记）
+	ResetPassword(ctx context.Context, userID uint, hashedPassword string) error
+
 	// UpdateStatus 更新用户状态
 	UpdateStatus(ctx context.Context, userID uint, status string) error
+
+	// SaveStatus 保存状态变更：状态、封禁原因与期限、会话吊销时间
+	SaveStatus(ctx context.Context, user *User) error
+
+	// UpdateAvatar 更新头像地址与已上传头像的版本（版本为空表示未使用上传的头像）
+	UpdateAvatar(ctx context.Context, userID uint, avatar, version string) error
+
+	// SaveAttributes 替换用户的全部自定义属性值
+	SaveAttributes(ctx context.Context, userID uint, values map[string]string) error
+
+	// UpdateDeletionSchedule 更新账号删除计划，均为 nil 时表示取消
+	UpdateDeletionSchedule(ctx context.Context, userID uint, requestedAt, scheduledAt *time.Time) error
+
+	// RecordLogins 批量写入登录活动：刷新最近登录时间与 IP（不早于已记录的时间），累加登录次数
+	RecordLogins(ctx context.Context, summaries []LoginSummary) error
 }
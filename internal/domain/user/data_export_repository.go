@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+// DataExportCommandRepository 个人数据导出任务写仓储接口
+type DataExportCommandRepository interface {
+	// Create 创建导出任务
+	Create(ctx context.Context, export *DataExport) error
+
+	// Save 保存任务状态与归档
+	Save(ctx context.Context, export *DataExport) error
+
+	// ClaimNext 认领最早的待处理任务并标记为生成中，没有可认领任务时返回 nil；
+	// 在 staleBefore 之前开始且仍未完成的任务（worker 中断）也可被重新认领
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*DataExport, error)
+
+	// PurgeExpired 清除已过期任务的归档内容，返回清除的数量
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+
+	// DeleteByUserID 删除用户的全部导出任务
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
+
+// DataExportQueryRepository 个人数据导出任务读仓储接口
+type DataExportQueryRepository interface {
+	// GetByID 获取导出任务（包含归档），不存在时返回 ErrDataExportNotFound
+	GetByID(ctx context.Context, id uint) (*DataExport, error)
+
+	// FindActiveByUserID 获取用户排队或生成中的导出任务（不含归档），不存在时返回 nil
+	FindActiveByUserID(ctx context.Context, userID uint) (*DataExport, error)
+}
@@ -4,6 +4,12 @@
 //   - [User]: 用户实体（富领域模型，包含 RBAC 角色关联）
 //   - [CommandRepository]: 写仓储接口（创建、更新、删除、角色分配）
 //   - [QueryRepository]: 读仓储接口（查询、搜索、统计）
+//   - [Invitation]: 用户邀请实体（一次性令牌，受邀用户设置密码后激活）
+//   - [RoleAssignment]: 角色授权（支持计划生效和到期自动失效的限时授权）
+//   - [ImportJob]: 用户批量导入任务（CSV/XLSX 上传，支持预检与后台处理）
+//   - [DataExport]: 个人数据导出任务（后台生成 ZIP 归档，限时下载）
+//   - [StatusChange]: 用户状态变更记录（封禁原因、期限与操作者）
+//   - [AttributeDefinition]: 管理员定义的自定义用户属性（类型、必填、校验规则、可见性）
 //   - 用户领域错误（见 errors.go）
 //
 // 用户状态：
@@ -12,6 +18,25 @@
 //   - inactive: 未激活状态
 //   - banned: 禁用状态
 //
+// [User.ChangeStatus] 执行显式的状态变更：封禁可附带原因与到期时间（到期后由
+// 后台任务自动解封），并通过 [User.RevokeSessions] 使此前签发的令牌全部失效。
+//
+// 密码生命周期：
+// 管理员创建或重置密码后，[User.MustChangePassword] 置为 true；
+// [User.PasswordChangeReason] 结合最长有效期判断登录后是否必须修改密码。
+//
+// 账号删除：
+// 用户自助删除账号时 [User.ScheduleDeletion] 记录删除计划，宽限期内登录即
+// [User.CancelDeletion]；到期后由后台任务匿名化审计日志、撤销令牌并删除账号。
+//
+// 登录活动：
+// 成功登录与个人访问令牌认证以 [LoginActivity] 交给 [LoginRecorder] 异步批量写入
+// 最近登录时间、IP 与登录次数；[User.IsIdleSince] 用于按不活跃天数自动停用账号。
+//
+// 自定义属性：
+// [AttributeSchema.Apply] 按属性定义校验并合并属性值，用户自助编辑时只能修改
+// UserEditable 的属性；[AttributeSchema.Values] 按可见性输出属性值。
+//
 // RBAC 集成：
 // [User] 实体通过 Roles 字段关联 [role.Role]，提供：
 //   - [User.HasRole]: 检查用户是否拥有指定角色
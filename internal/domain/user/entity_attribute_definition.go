@@ -0,0 +1,303 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 自定义属性值类型。
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeDate    = "date" // YYYY-MM-DD
+	AttributeTypeEnum    = "enum"
+)
+
+// 自定义属性可见性。
+const (
+	AttributeVisibilityUser  = "user"  // 用户本人与管理员可见
+	AttributeVisibilityAdmin = "admin" // 仅管理员可见
+)
+
+// MaxAttributeValueLength 属性值的最大长度（字符）
+const MaxAttributeValueLength = 500
+
+// attributeDateLayout 日期类型属性的格式
+const attributeDateLayout = time.DateOnly
+
+// attributeKeyPattern 属性键格式：小写字母开头，由小写字母、数字和下划线组成
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// AttributeDefinition 自定义用户属性定义
+//
+// 管理员定义属性的类型与校验规则，属性值按用户保存（见 [User.Attributes]）。
+// Key 与 Type 创建后不可修改，避免已保存的值与定义不一致。
+type AttributeDefinition struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Key         string `json:"key"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+
+	Required bool     `json:"required"`
+	Pattern  string   `json:"pattern,omitempty"` // 正则校验（string/number 类型），须完整匹配
+	Options  []string `json:"options,omitempty"` // enum 类型的可选值
+
+	Visibility   string `json:"visibility"`
+	UserEditable bool   `json:"user_editable"` // 用户可在个人资料中编辑（要求 Visibility 为 user）
+	SortOrder    int    `json:"sort_order"`
+}
+
+// Validate 校验属性定义
+func (d *AttributeDefinition) Validate() error {
+	if !attributeKeyPattern.MatchString(d.Key) {
+		return fmt.Errorf("%w: key must match %s", ErrInvalidAttributeDefinition, attributeKeyPattern)
+	}
+
+	switch d.Type {
+	case AttributeTypeString, AttributeTypeNumber:
+	case AttributeTypeBoolean, AttributeTypeDate:
+		if d.Pattern != "" {
+			return fmt.Errorf("%w: pattern is not supported for %s attributes", ErrInvalidAttributeDefinition, d.Type)
+		}
+	case AttributeTypeEnum:
+		if len(d.Options) == 0 {
+			return fmt.Errorf("%w: enum attributes require options", ErrInvalidAttributeDefinition)
+		}
+		if d.Pattern != "" {
+			return fmt.Errorf("%w: pattern is not supported for enum attributes", ErrInvalidAttributeDefinition)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported type %q", ErrInvalidAttributeDefinition, d.Type)
+	}
+	if d.Type != AttributeTypeEnum && len(d.Options) > 0 {
+		return fmt.Errorf("%w: options are only supported for enum attributes", ErrInvalidAttributeDefinition)
+	}
+	for i, opt := range d.Options {
+		if opt == "" || slices.Contains(d.Options[:i], opt) {
+			return fmt.Errorf("%w: options must be non-empty and unique", ErrInvalidAttributeDefinition)
+		}
+	}
+
+	if d.Pattern != "" {
+		if _, err := d.compilePattern(); err != nil {
+			return fmt.Errorf("%w: invalid pattern: %w", ErrInvalidAttributeDefinition, err)
+		}
+	}
+
+	switch d.Visibility {
+	case AttributeVisibilityUser:
+	case AttributeVisibilityAdmin:
+		if d.UserEditable {
+			return fmt.Errorf("%w: admin-only attributes cannot be user editable", ErrInvalidAttributeDefinition)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported visibility %q", ErrInvalidAttributeDefinition, d.Visibility)
+	}
+	return nil
+}
+
+// compilePattern 编译校验正则，要求完整匹配
+func (d *AttributeDefinition) compilePattern() (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + d.Pattern + `)$`)
+}
+
+// VisibleToUser 检查属性是否对用户本人可见
+func (d *AttributeDefinition) VisibleToUser() bool {
+	return d.Visibility == AttributeVisibilityUser
+}
+
+// Normalize 校验属性值并转换为保存格式
+//
+// 接受 JSON 解码后的值：string 类型接受字符串；number 类型接受数字或数字字符串；
+// boolean 类型接受布尔值或 "true"/"false"；date 与 enum 类型接受字符串。
+func (d *AttributeDefinition) Normalize(value any) (string, error) {
+	s, err := d.normalize(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrInvalidAttributeValue, d.Key, err)
+	}
+	return s, nil
+}
+
+func (d *AttributeDefinition) normalize(value any) (string, error) {
+	var s string
+	switch d.Type {
+	case AttributeTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			s = strconv.FormatFloat(v, 'f', -1, 64)
+		case int:
+			s = strconv.Itoa(v)
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return "", errors.New("expected a number")
+			}
+			s = strconv.FormatFloat(f, 'f', -1, 64)
+		default:
+			return "", errors.New("expected a number")
+		}
+	case AttributeTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			s = strconv.FormatBool(v)
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return "", errors.New("expected a boolean")
+			}
+			s = strconv.FormatBool(b)
+		default:
+			return "", errors.New("expected a boolean")
+		}
+	default:
+		v, ok := value.(string)
+		if !ok {
+			return "", errors.New("expected a string")
+		}
+		s = strings.TrimSpace(v)
+	}
+
+	if utf8.RuneCountInString(s) > MaxAttributeValueLength {
+		return "", fmt.Errorf("must be at most %d characters", MaxAttributeValueLength)
+	}
+
+	switch d.Type {
+	case AttributeTypeDate:
+		if _, err := time.Parse(attributeDateLayout, s); err != nil {
+			return "", errors.New("expected a date in YYYY-MM-DD format")
+		}
+	case AttributeTypeEnum:
+		if !slices.Contains(d.Options, s) {
+			return "", fmt.Errorf("must be one of %s", strings.Join(d.Options, ", "))
+		}
+	}
+
+	if d.Pattern != "" {
+		re, err := d.compilePattern()
+		if err != nil {
+			return "", err
+		}
+		if !re.MatchString(s) {
+			return "", fmt.Errorf("does not match pattern %s", d.Pattern)
+		}
+	}
+	return s, nil
+}
+
+// Decode 将保存的属性值转换为定义类型对应的值（number 为 float64，boolean 为 bool，其余为 string）
+func (d *AttributeDefinition) Decode(stored string) any {
+	switch d.Type {
+	case AttributeTypeNumber:
+		if f, err := strconv.ParseFloat(stored, 64); err == nil {
+			return f
+		}
+	case AttributeTypeBoolean:
+		if b, err := strconv.ParseBool(stored); err == nil {
+			return b
+		}
+	}
+	return stored
+}
+
+// AttributeSchema 全部自定义属性定义
+type AttributeSchema []*AttributeDefinition
+
+// Find 按键查找属性定义，不存在时返回 nil
+func (s AttributeSchema) Find(key string) *AttributeDefinition {
+	for _, d := range s {
+		if d.Key == key {
+			return d
+		}
+	}
+	return nil
+}
+
+// Apply 将属性变更合并到当前属性值并校验，返回新的属性值
+//
+// 变更值为 nil 或空字符串表示清除该属性。selfService 为 true 时表示用户编辑自己的资料：
+// 只能修改 UserEditable 的属性，必填校验也仅针对这些属性。
+// 未定义的属性会被拒绝；当前值中已被删除定义的属性会被丢弃。
+func (s AttributeSchema) Apply(current map[string]string, changes map[string]any, selfService bool) (map[string]string, error) {
+	result := make(map[string]string, len(current)+len(changes))
+	for key, value := range current {
+		if s.Find(key) != nil {
+			result[key] = value
+		}
+	}
+
+	for key, value := range changes {
+		d := s.Find(key)
+		if d == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAttribute, key)
+		}
+		if selfService && !d.UserEditable {
+			return nil, fmt.Errorf("%w: %s", ErrAttributeNotEditable, key)
+		}
+		if str, ok := value.(string); value == nil || (ok && strings.TrimSpace(str) == "") {
+			delete(result, key)
+			continue
+		}
+		normalized, err := d.Normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = normalized
+	}
+
+	for _, d := range s {
+		if !d.Required || (selfService && !d.UserEditable) {
+			continue
+		}
+		if _, ok := result[d.Key]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrAttributeRequired, d.Key)
+		}
+	}
+	return result, nil
+}
+
+// Values 返回可见的属性值（按定义类型转换），adminView 为 false 时隐藏仅管理员可见的属性
+func (s AttributeSchema) Values(stored map[string]string, adminView bool) map[string]any {
+	if len(stored) == 0 {
+		return nil
+	}
+	values := make(map[string]any, len(stored))
+	for _, d := range s {
+		raw, ok := stored[d.Key]
+		if !ok || (!adminView && !d.VisibleToUser()) {
+			continue
+		}
+		values[d.Key] = d.Decode(raw)
+	}
+	return values
+}
+
+// NormalizeFilters 校验并规范化属性过滤条件（键为属性键，值按属性类型规范化）
+func (s AttributeSchema) NormalizeFilters(filters map[string]string) (map[string]string, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	normalized := make(map[string]string, len(filters))
+	for key, value := range filters {
+		d := s.Find(key)
+		if d == nil {
+			return nil, fmt.Errorf("%w: unknown attribute %q", ErrInvalidListCriteria, key)
+		}
+		v, err := d.Normalize(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidListCriteria, err)
+		}
+		normalized[key] = v
+	}
+	return normalized, nil
+}
@@ -0,0 +1,180 @@
+package user
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     AttributeDefinition
+		wantErr bool
+	}{
+		{
+			name: "字符串属性",
+			def:  AttributeDefinition{Key: "employee_id", Type: AttributeTypeString, Pattern: `E\d{6}`, Visibility: AttributeVisibilityUser},
+		},
+		{
+			name: "枚举属性",
+			def:  AttributeDefinition{Key: "level", Type: AttributeTypeEnum, Options: []string{"junior", "senior"}, Visibility: AttributeVisibilityAdmin},
+		},
+		{
+			name:    "无效的键",
+			def:     AttributeDefinition{Key: "Employee-ID", Type: AttributeTypeString, Visibility: AttributeVisibilityUser},
+			wantErr: true,
+		},
+		{
+			name:    "不支持的类型",
+			def:     AttributeDefinition{Key: "photo", Type: "file", Visibility: AttributeVisibilityUser},
+			wantErr: true,
+		},
+		{
+			name:    "枚举缺少可选值",
+			def:     AttributeDefinition{Key: "level", Type: AttributeTypeEnum, Visibility: AttributeVisibilityUser},
+			wantErr: true,
+		},
+		{
+			name:    "可选值重复",
+			def:     AttributeDefinition{Key: "level", Type: AttributeTypeEnum, Options: []string{"a", "a"}, Visibility: AttributeVisibilityUser},
+			wantErr: true,
+		},
+		{
+			name:    "非枚举属性设置可选值",
+			def:     AttributeDefinition{Key: "phone", Type: AttributeTypeString, Options: []string{"a"}, Visibility: AttributeVisibilityUser},
+			wantErr: true,
+		},
+		{
+			name:    "无效的正则",
+			def:     AttributeDefinition{Key: "phone", Type: AttributeTypeString, Pattern: "[", Visibility: AttributeVisibilityUser},
+			wantErr: true,
+		},
+		{
+			name:    "仅管理员可见的属性不能由用户编辑",
+			def:     AttributeDefinition{Key: "salary", Type: AttributeTypeNumber, Visibility: AttributeVisibilityAdmin, UserEditable: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.def.Validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidAttributeDefinition)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAttributeDefinition_Normalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     AttributeDefinition
+		value   any
+		want    string
+		wantErr bool
+	}{
+		{name: "匹配正则", def: AttributeDefinition{Type: AttributeTypeString, Pattern: `E\d{6}`}, value: " E000001 ", want: "E000001"},
+		{name: "正则须完整匹配", def: AttributeDefinition{Type: AttributeTypeString, Pattern: `E\d{6}`}, value: "xE0000012", wantErr: true},
+		{name: "超出长度", def: AttributeDefinition{Type: AttributeTypeString}, value: strings.Repeat("a", MaxAttributeValueLength+1), wantErr: true},
+		{name: "数字", def: AttributeDefinition{Type: AttributeTypeNumber}, value: float64(42), want: "42"},
+		{name: "数字字符串", def: AttributeDefinition{Type: AttributeTypeNumber}, value: "3.50", want: "3.5"},
+		{name: "非数字", def: AttributeDefinition{Type: AttributeTypeNumber}, value: true, wantErr: true},
+		{name: "布尔值", def: AttributeDefinition{Type: AttributeTypeBoolean}, value: true, want: "true"},
+		{name: "布尔字符串", def: AttributeDefinition{Type: AttributeTypeBoolean}, value: "0", want: "false"},
+		{name: "日期", def: AttributeDefinition{Type: AttributeTypeDate}, value: "2024-02-29", want: "2024-02-29"},
+		{name: "无效日期", def: AttributeDefinition{Type: AttributeTypeDate}, value: "2023-02-29", wantErr: true},
+		{name: "枚举", def: AttributeDefinition{Type: AttributeTypeEnum, Options: []string{"a", "b"}}, value: "b", want: "b"},
+		{name: "不在可选值中", def: AttributeDefinition{Type: AttributeTypeEnum, Options: []string{"a", "b"}}, value: "c", wantErr: true},
+		{name: "字符串类型不接受数字", def: AttributeDefinition{Type: AttributeTypeString}, value: float64(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.def.Normalize(tt.value)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidAttributeValue)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAttributeSchema_Apply(t *testing.T) {
+	schema := AttributeSchema{
+		{Key: "employee_id", Type: AttributeTypeString, Required: true, Visibility: AttributeVisibilityUser},
+		{Key: "phone", Type: AttributeTypeString, Visibility: AttributeVisibilityUser, UserEditable: true},
+		{Key: "remote", Type: AttributeTypeBoolean, Visibility: AttributeVisibilityAdmin},
+	}
+
+	t.Run("合并并规范化", func(t *testing.T) {
+		got, err := schema.Apply(
+			map[string]string{"employee_id": "E1", "phone": "123", "removed": "x"},
+			map[string]any{"phone": nil, "remote": true},
+			false,
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"employee_id": "E1", "remote": "true"}, got, "清除 phone，丢弃已删除定义的属性")
+	})
+
+	t.Run("缺少必填属性", func(t *testing.T) {
+		_, err := schema.Apply(nil, map[string]any{"phone": "123"}, false)
+
+		require.ErrorIs(t, err, ErrAttributeRequired)
+	})
+
+	t.Run("空字符串清除必填属性", func(t *testing.T) {
+		_, err := schema.Apply(map[string]string{"employee_id": "E1"}, map[string]any{"employee_id": " "}, false)
+
+		require.ErrorIs(t, err, ErrAttributeRequired)
+	})
+
+	t.Run("未定义的属性", func(t *testing.T) {
+		_, err := schema.Apply(nil, map[string]any{"employee_id": "E1", "unknown": "x"}, false)
+
+		require.ErrorIs(t, err, ErrUnknownAttribute)
+	})
+
+	t.Run("用户只能编辑可编辑的属性", func(t *testing.T) {
+		got, err := schema.Apply(nil, map[string]any{"phone": "123"}, true)
+		require.NoError(t, err, "自助编辑不校验不可编辑的必填属性")
+		assert.Equal(t, map[string]string{"phone": "123"}, got)
+
+		_, err = schema.Apply(nil, map[string]any{"employee_id": "E1"}, true)
+		require.ErrorIs(t, err, ErrAttributeNotEditable)
+	})
+}
+
+func TestAttributeSchema_Values(t *testing.T) {
+	schema := AttributeSchema{
+		{Key: "level", Type: AttributeTypeNumber, Visibility: AttributeVisibilityUser},
+		{Key: "remote", Type: AttributeTypeBoolean, Visibility: AttributeVisibilityAdmin},
+	}
+	stored := map[string]string{"level": "3", "remote": "true", "removed": "x"}
+
+	assert.Equal(t, map[string]any{"level": float64(3), "remote": true}, schema.Values(stored, true))
+	assert.Equal(t, map[string]any{"level": float64(3)}, schema.Values(stored, false))
+	assert.Nil(t, schema.Values(nil, true))
+}
+
+func TestAttributeSchema_NormalizeFilters(t *testing.T) {
+	schema := AttributeSchema{{Key: "remote", Type: AttributeTypeBoolean, Visibility: AttributeVisibilityUser}}
+
+	got, err := schema.NormalizeFilters(map[string]string{"remote": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"remote": "true"}, got)
+
+	_, err = schema.NormalizeFilters(map[string]string{"unknown": "x"})
+	require.ErrorIs(t, err, ErrInvalidListCriteria)
+
+	_, err = schema.NormalizeFilters(map[string]string{"remote": "maybe"})
+	require.ErrorIs(t, err, ErrInvalidListCriteria)
+}
@@ -0,0 +1,83 @@
+package user
+
+import "time"
+
+// DataExportStatus 个人数据导出任务状态
+type DataExportStatus string
+
+// 数据导出任务状态常量
+const (
+	DataExportPending   DataExportStatus = "pending"   // 等待后台处理
+	DataExportRunning   DataExportStatus = "running"   // 生成中
+	DataExportCompleted DataExportStatus = "completed" // 已生成，可下载至 ExpiresAt
+	DataExportFailed    DataExportStatus = "failed"    // 生成失败
+)
+
+// DataExport 个人数据导出任务
+//
+// 用户自助请求导出本人数据（资料、令牌元数据、登录会话和操作记录），
+// 后台任务生成 ZIP 归档保存在 Archive 中，过期后归档被清除。
+type DataExport struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	UserID uint
+	Status DataExportStatus
+
+	FileName string
+	Archive  []byte
+	Size     int64
+	Error    string
+
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	ExpiresAt  *time.Time
+}
+
+// NewDataExport 创建待处理的数据导出任务
+func NewDataExport(userID uint) *DataExport {
+	return &DataExport{
+		UserID: userID,
+		Status: DataExportPending,
+	}
+}
+
+// Start 标记任务开始处理
+func (e *DataExport) Start(now time.Time) {
+	e.Status = DataExportRunning
+	e.StartedAt = &now
+}
+
+// Complete 保存生成的归档，归档在 ttl 后过期
+func (e *DataExport) Complete(fileName string, archive []byte, now time.Time, ttl time.Duration) {
+	expiresAt := now.Add(ttl)
+	e.Status = DataExportCompleted
+	e.FileName = fileName
+	e.Archive = archive
+	e.Size = int64(len(archive))
+	e.FinishedAt = &now
+	e.ExpiresAt = &expiresAt
+}
+
+// Fail 标记任务失败
+func (e *DataExport) Fail(reason string, now time.Time) {
+	e.Status = DataExportFailed
+	e.Error = reason
+	e.FinishedAt = &now
+}
+
+// IsActive 检查任务是否仍在排队或生成中
+func (e *DataExport) IsActive() bool {
+	return e.Status == DataExportPending || e.Status == DataExportRunning
+}
+
+// IsExpired 检查归档是否已过期
+func (e *DataExport) IsExpired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
+}
+
+// IsDownloadable 检查归档是否可以下载
+func (e *DataExport) IsDownloadable(now time.Time) bool {
+	return e.Status == DataExportCompleted && !e.IsExpired(now)
+}
@@ -0,0 +1,40 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataExport_Lifecycle(t *testing.T) {
+	now := time.Now()
+	export := NewDataExport(1)
+
+	assert.Equal(t, DataExportPending, export.Status)
+	assert.True(t, export.IsActive())
+	assert.False(t, export.IsDownloadable(now))
+
+	export.Start(now)
+	assert.Equal(t, DataExportRunning, export.Status)
+	assert.True(t, export.IsActive())
+
+	export.Complete("alice-data-export.zip", []byte("zip"), now, time.Hour)
+
+	assert.False(t, export.IsActive())
+	assert.Equal(t, int64(3), export.Size)
+	assert.True(t, export.IsDownloadable(now.Add(59*time.Minute)))
+	assert.True(t, export.IsExpired(now.Add(time.Hour)))
+	assert.False(t, export.IsDownloadable(now.Add(time.Hour)), "过期后不可下载")
+}
+
+func TestDataExport_Fail(t *testing.T) {
+	export := NewDataExport(1)
+
+	export.Fail("database unavailable", time.Now())
+
+	assert.Equal(t, DataExportFailed, export.Status)
+	assert.Equal(t, "database unavailable", export.Error)
+	assert.False(t, export.IsActive())
+	assert.False(t, export.IsDownloadable(time.Now()))
+}
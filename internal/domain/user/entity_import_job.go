@@ -0,0 +1,143 @@
+package user
+
+import "time"
+
+// ImportJobStatus 用户导入任务状态
+type ImportJobStatus string
+
+// 导入任务状态常量
+const (
+	ImportJobPending   ImportJobStatus = "pending"   // 等待后台处理
+	ImportJobRunning   ImportJobStatus = "running"   // 处理中
+	ImportJobCompleted ImportJobStatus = "completed" // 已完成（逐行结果见 Results）
+	ImportJobFailed    ImportJobStatus = "failed"    // 整体失败（如处理过程中数据库异常）
+)
+
+// 导入行结果状态
+const (
+	ImportRowCreated = "created" // 已创建
+	ImportRowValid   = "valid"   // 预检通过（dry-run）
+	ImportRowFailed  = "failed"  // 校验或创建失败
+)
+
+// ImportRow 待导入的一行用户数据
+type ImportRow struct {
+	Line     int      `json:"line"` // 源文件中的行号（含表头，从 1 开始）
+	Username string   `json:"username"`
+	Email    string   `json:"email"`
+	Password string   `json:"-"` // 明文密码，仅在解析后、创建任务前存在，不随任务保存
+	FullName string   `json:"full_name"`
+	Status   string   `json:"status"`
+	Roles    []string `json:"roles"` // 角色名称
+
+	// 创建任务前由明文密码生成，任务数据中只保存哈希（预检任务不生成哈希）
+	PasswordHash  string `json:"password_hash,omitempty"`
+	PasswordError string `json:"password_error,omitempty"` // 密码为空或不符合策略的原因
+}
+
+// ImportRowResult 单行导入结果
+type ImportRowResult struct {
+	Line     int    `json:"line"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ImportJob 用户导入任务
+//
+// 上传的文件解析为 Rows 后保存在任务中，逐行校验并创建用户，结果追加到 Results。
+// Rows 中的密码在任务创建前已替换为哈希，任务结束后 Rows 即被清空，仅保留逐行结果供下载报告。
+type ImportJob struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	CreatedBy uint
+	FileName  string
+	Format    string
+	DryRun    bool
+	Status    ImportJobStatus
+
+	Total     int
+	Processed int
+	Succeeded int
+	Failed    int
+
+	Rows    []ImportRow
+	Results []ImportRowResult
+	Error   string
+
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// NewImportJob 创建待处理的导入任务
+func NewImportJob(createdBy uint, fileName, format string, dryRun bool, rows []ImportRow) *ImportJob {
+	return &ImportJob{
+		CreatedBy: createdBy,
+		FileName:  fileName,
+		Format:    format,
+		DryRun:    dryRun,
+		Status:    ImportJobPending,
+		Total:     len(rows),
+		Rows:      rows,
+		Results:   make([]ImportRowResult, 0, len(rows)),
+	}
+}
+
+// Start 标记任务开始处理
+func (j *ImportJob) Start(now time.Time) {
+	j.Status = ImportJobRunning
+	j.StartedAt = &now
+}
+
+// PendingRows 返回尚未处理的行（任务中断后可从断点继续）
+func (j *ImportJob) PendingRows() []ImportRow {
+	if j.Processed >= len(j.Rows) {
+		return nil
+	}
+	return j.Rows[j.Processed:]
+}
+
+// Record 记录一行的处理结果
+func (j *ImportJob) Record(result ImportRowResult) {
+	j.Results = append(j.Results, result)
+	j.Processed++
+	if result.Status == ImportRowFailed {
+		j.Failed++
+	} else {
+		j.Succeeded++
+	}
+}
+
+// Complete 标记任务完成并清除含密码的原始数据
+func (j *ImportJob) Complete(now time.Time) {
+	j.Status = ImportJobCompleted
+	j.FinishedAt = &now
+	j.Rows = nil
+}
+
+// Fail 标记任务失败并清除含密码的原始数据
+func (j *ImportJob) Fail(reason string, now time.Time) {
+	j.Status = ImportJobFailed
+	j.Error = reason
+	j.FinishedAt = &now
+	j.Rows = nil
+}
+
+// IsFinished 检查任务是否已结束
+func (j *ImportJob) IsFinished() bool {
+	return j.Status == ImportJobCompleted || j.Status == ImportJobFailed
+}
+
+// Progress 返回处理进度百分比（0-100）
+func (j *ImportJob) Progress() int {
+	if j.Total == 0 {
+		if j.IsFinished() {
+			return 100
+		}
+		return 0
+	}
+	return j.Processed * 100 / j.Total
+}
@@ -0,0 +1,48 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportJob_Lifecycle(t *testing.T) {
+	now := time.Now()
+	job := NewImportJob(1, "users.csv", "csv", false, []ImportRow{
+		{Line: 2, Username: "alice", Password: "secret"},
+		{Line: 3, Username: "bob", Password: "secret"},
+	})
+
+	assert.Equal(t, ImportJobPending, job.Status)
+	assert.Equal(t, 2, job.Total)
+	assert.Equal(t, 0, job.Progress())
+
+	job.Start(now)
+	job.Record(ImportRowResult{Line: 2, Username: "alice", Status: ImportRowCreated})
+
+	assert.Equal(t, ImportJobRunning, job.Status)
+	assert.Equal(t, 50, job.Progress())
+	assert.Equal(t, []ImportRow{{Line: 3, Username: "bob", Password: "secret"}}, job.PendingRows(), "中断后从未处理的行继续")
+
+	job.Record(ImportRowResult{Line: 3, Username: "bob", Status: ImportRowFailed, Error: "用户名已存在"})
+	job.Complete(now)
+
+	assert.True(t, job.IsFinished())
+	assert.Equal(t, 100, job.Progress())
+	assert.Equal(t, 1, job.Succeeded)
+	assert.Equal(t, 1, job.Failed)
+	assert.Nil(t, job.Rows, "完成后清除含密码的原始数据")
+	assert.Len(t, job.Results, 2)
+}
+
+func TestImportJob_Fail(t *testing.T) {
+	job := NewImportJob(1, "users.xlsx", "xlsx", true, []ImportRow{{Line: 2}})
+
+	job.Fail("database unavailable", time.Now())
+
+	assert.Equal(t, ImportJobFailed, job.Status)
+	assert.Equal(t, "database unavailable", job.Error)
+	assert.Nil(t, job.Rows)
+	assert.NotNil(t, job.FinishedAt)
+}
@@ -0,0 +1,74 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// DefaultInvitationTTL 邀请链接默认有效期
+const DefaultInvitationTTL = 72 * time.Hour
+
+// Invitation 用户邀请实体
+//
+// 管理员邀请用户时创建一个 inactive 状态的待激活用户，并生成一次性令牌。
+// 令牌仅保存 SHA-256 哈希，受邀用户通过邮件中的链接设置密码后账号被激活。
+type Invitation struct {
+	ID         uint
+	UserID     uint
+	TokenHash  string
+	InvitedBy  uint
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// NewInvitation 创建邀请并返回明文令牌（仅此一次可见）
+func NewInvitation(userID, invitedBy uint, ttl time.Duration, now time.Time) (*Invitation, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	return &Invitation{
+		UserID:    userID,
+		TokenHash: HashInvitationToken(token),
+		InvitedBy: invitedBy,
+		ExpiresAt: now.Add(ttl),
+	}, token, nil
+}
+
+// HashInvitationToken 计算邀请令牌哈希
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAccepted 检查邀请是否已被接受
+func (i *Invitation) IsAccepted() bool {
+	return i.AcceptedAt != nil
+}
+
+// IsExpired 检查邀请是否过期
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// CanAccept 检查邀请当前是否可被接受
+func (i *Invitation) CanAccept(now time.Time) error {
+	if i.IsAccepted() {
+		return ErrInvitationAccepted
+	}
+	if i.IsExpired(now) {
+		return ErrInvitationExpired
+	}
+	return nil
+}
+
+// Accept 标记邀请已接受（领域行为）
+func (i *Invitation) Accept(now time.Time) {
+	i.AcceptedAt = &now
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvitation(t *testing.T) {
+	now := time.Now()
+
+	inv, token, err := NewInvitation(1, 2, DefaultInvitationTTL, now)
+
+	require.NoError(t, err)
+	assert.Len(t, token, 64)
+	assert.Equal(t, HashInvitationToken(token), inv.TokenHash)
+	assert.NotEqual(t, token, inv.TokenHash, "仅保存哈希")
+	assert.Equal(t, now.Add(DefaultInvitationTTL), inv.ExpiresAt)
+}
+
+func TestInvitation_CanAccept(t *testing.T) {
+	now := time.Now()
+
+	t.Run("有效邀请", func(t *testing.T) {
+		inv := &Invitation{ExpiresAt: now.Add(time.Hour)}
+		assert.NoError(t, inv.CanAccept(now))
+	})
+
+	t.Run("已过期", func(t *testing.T) {
+		inv := &Invitation{ExpiresAt: now.Add(-time.Second)}
+		assert.ErrorIs(t, inv.CanAccept(now), ErrInvitationExpired)
+	})
+
+	t.Run("已接受", func(t *testing.T) {
+		inv := &Invitation{ExpiresAt: now.Add(time.Hour)}
+		inv.Accept(now)
+		assert.ErrorIs(t, inv.CanAccept(now), ErrInvitationAccepted)
+	})
+}
@@ -0,0 +1,84 @@
+package user
+
+import "time"
+
+// RoleAssignment 用户角色授权（用户与角色的关联）
+//
+// StartsAt 和 ExpiresAt 均为空时为永久授权；设置任一字段即为限时授权：
+//   - StartsAt 在未来时为计划授权，到达开始时间前不生效
+//   - ExpiresAt 到达后授权失效，由后台任务移除
+//
+// 权限查询按生效时间窗口过滤，后台任务负责在授权生效和到期时
+// 发布事件使权限缓存失效。ActivatedAt 记录计划授权被后台任务激活的时间。
+type RoleAssignment struct {
+	UserID      uint
+	RoleID      uint
+	RoleName    string
+	StartsAt    *time.Time
+	ExpiresAt   *time.Time
+	ActivatedAt *time.Time
+	CreatedAt   time.Time
+}
+
+// 授权状态
+const (
+	AssignmentStatusActive    = "active"
+	AssignmentStatusScheduled = "scheduled"
+	AssignmentStatusExpired   = "expired"
+)
+
+// NewRoleAssignment 创建角色授权并校验时间窗口
+// 立即生效的授权直接标记为已激活，无需等待后台任务
+func NewRoleAssignment(userID, roleID uint, startsAt, expiresAt *time.Time, now time.Time) (*RoleAssignment, error) {
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, ErrInvalidAssignmentWindow
+		}
+		if startsAt != nil && !expiresAt.After(*startsAt) {
+			return nil, ErrInvalidAssignmentWindow
+		}
+	}
+
+	a := &RoleAssignment{
+		UserID:    userID,
+		RoleID:    roleID,
+		StartsAt:  startsAt,
+		ExpiresAt: expiresAt,
+	}
+	if !a.IsScheduledAt(now) {
+		a.ActivatedAt = &now
+	}
+	return a, nil
+}
+
+// IsTemporary 是否为限时授权
+func (a *RoleAssignment) IsTemporary() bool {
+	return a.StartsAt != nil || a.ExpiresAt != nil
+}
+
+// IsScheduledAt 指定时间点授权是否尚未开始
+func (a *RoleAssignment) IsScheduledAt(now time.Time) bool {
+	return a.StartsAt != nil && a.StartsAt.After(now)
+}
+
+// IsExpiredAt 指定时间点授权是否已过期
+func (a *RoleAssignment) IsExpiredAt(now time.Time) bool {
+	return a.ExpiresAt != nil && !a.ExpiresAt.After(now)
+}
+
+// IsActiveAt 指定时间点授权是否生效
+func (a *RoleAssignment) IsActiveAt(now time.Time) bool {
+	return !a.IsScheduledAt(now) && !a.IsExpiredAt(now)
+}
+
+// StatusAt 指定时间点的授权状态
+func (a *RoleAssignment) StatusAt(now time.Time) string {
+	switch {
+	case a.IsExpiredAt(now):
+		return AssignmentStatusExpired
+	case a.IsScheduledAt(now):
+		return AssignmentStatusScheduled
+	default:
+		return AssignmentStatusActive
+	}
+}
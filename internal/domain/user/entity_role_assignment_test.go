@@ -0,0 +1,54 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRoleAssignment(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	soon := now.Add(time.Hour)
+	later := now.Add(48 * time.Hour)
+
+	t.Run("永久授权立即激活", func(t *testing.T) {
+		a, err := NewRoleAssignment(1, 2, nil, nil, now)
+
+		require.NoError(t, err)
+		assert.False(t, a.IsTemporary())
+		require.NotNil(t, a.ActivatedAt)
+		assert.Equal(t, AssignmentStatusActive, a.StatusAt(now))
+	})
+
+	t.Run("计划授权等待激活", func(t *testing.T) {
+		a, err := NewRoleAssignment(1, 2, &soon, &later, now)
+
+		require.NoError(t, err)
+		assert.True(t, a.IsTemporary())
+		assert.Nil(t, a.ActivatedAt)
+		assert.Equal(t, AssignmentStatusScheduled, a.StatusAt(now))
+		assert.Equal(t, AssignmentStatusActive, a.StatusAt(soon))
+		assert.Equal(t, AssignmentStatusExpired, a.StatusAt(later))
+	})
+
+	t.Run("开始时间已过视为立即生效", func(t *testing.T) {
+		a, err := NewRoleAssignment(1, 2, &past, &soon, now)
+
+		require.NoError(t, err)
+		assert.NotNil(t, a.ActivatedAt)
+		assert.True(t, a.IsActiveAt(now))
+	})
+
+	t.Run("到期时间已过", func(t *testing.T) {
+		_, err := NewRoleAssignment(1, 2, nil, &past, now)
+		assert.ErrorIs(t, err, ErrInvalidAssignmentWindow)
+	})
+
+	t.Run("到期时间早于开始时间", func(t *testing.T) {
+		_, err := NewRoleAssignment(1, 2, &later, &soon, now)
+		assert.ErrorIs(t, err, ErrInvalidAssignmentWindow)
+	})
+}
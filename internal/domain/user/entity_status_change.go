@@ -0,0 +1,32 @@
+package user
+
+import "time"
+
+// StatusChange 用户状态变更记录
+//
+// 每次状态变更（包括更新封禁原因或期限）追加一条记录，用于追溯封禁与解封历史。
+type StatusChange struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	UserID      uint       `json:"user_id"`
+	FromStatus  string     `json:"from_status"`
+	ToStatus    string     `json:"to_status"`
+	Reason      string     `json:"reason,omitempty"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+
+	// OperatorID 操作者，0 表示系统（如封禁到期自动解封）
+	OperatorID uint `json:"operator_id"`
+}
+
+// NewStatusChange 根据变更后的用户创建状态变更记录
+func NewStatusChange(u *User, fromStatus, reason string, operatorID uint) *StatusChange {
+	return &StatusChange{
+		UserID:      u.ID,
+		FromStatus:  fromStatus,
+		ToStatus:    u.Status,
+		Reason:      reason,
+		BannedUntil: u.BannedUntil,
+		OperatorID:  operatorID,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 )
 
@@ -22,13 +23,113 @@ type User struct {
 	Bio      string `json:"bio"`
 	Status   string `json:"status"`
 
+	// 封禁信息：BannedUntil 为空表示永久封禁，到期后由后台任务自动解封
+	BanReason   string     `json:"ban_reason,omitempty"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+
+	// SessionsRevokedAt 会话吊销时间，此前签发的令牌（访问令牌与刷新令牌）均失效
+	SessionsRevokedAt *time.Time `json:"-"`
+
+	// AvatarVersion 已上传头像的版本（内容摘要），为空时 Avatar 为外部地址或未设置头像
+	AvatarVersion string `json:"-"`
+
+	// Department 所属部门，可作为 ABAC 策略的主体/资源属性
+	Department string `json:"department"`
+
+	// Attributes 自定义属性值（键为 [AttributeDefinition] 的 Key，值为规范化后的字符串），
+	// 由查询仓储随用户加载，通过 CommandRepository.SaveAttributes 保存
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// 密码生命周期：管理员创建/重置后需首次修改；PasswordChangedAt 为空时以 CreatedAt 计算有效期
+	MustChangePassword bool       `json:"must_change_password"`
+	PasswordChangedAt  *time.Time `json:"password_changed_at,omitempty"`
+
+	// 身份来源：local 为本地账号；其余为外部目录（如 ldap），ExternalID 为目录中的唯一标识（如 DN）
+	AuthSource string `json:"auth_source"`
+	ExternalID string `json:"-"`
+
+	// 自助删除：请求后进入宽限期，DeletionScheduledAt 到期后由后台任务完成删除；宽限期内登录即取消
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at,omitempty"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+
+	// 登录活动：由登录与个人访问令牌认证经 [LoginRecorder] 异步批量写入，Update 不会覆盖
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	LastLoginIP string     `json:"last_login_ip,omitempty"`
+	LoginCount  int64      `json:"login_count"`
+
 	// RBAC: Many-to-Many relationship with roles
 	Roles []role.Role `json:"roles,omitempty"`
+
+	// GroupRoles 经由所在用户组获得的角色（只读，由查询仓储随角色一并加载）
+	GroupRoles []role.Role `json:"group_roles,omitempty"`
+}
+
+// 用户状态常量。
+const (
+	StatusActive   = "active"
+	StatusInactive = "inactive"
+	StatusBanned   = "banned"
+)
+
+// IsValidStatus 检查是否为有效的用户状态
+func IsValidStatus(status string) bool {
+	return status == StatusActive || status == StatusInactive || status == StatusBanned
+}
+
+// 强制修改密码原因常量。
+// 登录响应据此告知前端引导用户修改密码的原因。
+const (
+	PasswordChangeReasonAdminReset = "admin_reset" // 管理员创建或重置了密码
+	PasswordChangeReasonExpired    = "expired"     // 密码超过最长有效期
+)
+
+// AvatarSizes 上传头像处理后保存的边长（像素），从大到小
+var AvatarSizes = []int{256, 128, 64}
+
+// 身份来源常量。
+const (
+	AuthSourceLocal = "local"
+	AuthSourceLDAP  = "ldap"
+)
+
+// PolicyAttributes 返回用于 ABAC 策略判定的用户属性（作为主体或资源）
+func (u *User) PolicyAttributes() policy.Attributes {
+	return policy.Attributes{
+		"id":          u.ID,
+		"username":    u.Username,
+		"email":       u.Email,
+		"department":  u.Department,
+		"status":      u.Status,
+		"auth_source": u.AuthSource,
+	}
+}
+
+// IsExternallyManaged 是否为外部目录管理的用户（密码不能在本地修改）
+func (u *User) IsExternallyManaged() bool {
+	return u.AuthSource != "" && u.AuthSource != AuthSourceLocal
 }
 
-// HasRole 检查用户是否拥有指定角色
+// EffectiveRoles 返回用户的有效角色：直接分配的角色与用户组角色的并集（按角色 ID 去重）
+func (u *User) EffectiveRoles() []role.Role {
+	if len(u.GroupRoles) == 0 {
+		return u.Roles
+	}
+
+	seen := make(map[uint]struct{}, len(u.Roles)+len(u.GroupRoles))
+	roles := make([]role.Role, 0, len(u.Roles)+len(u.GroupRoles))
+	for _, r := range slices.Concat(u.Roles, u.GroupRoles) {
+		if _, ok := seen[r.ID]; ok {
+			continue
+		}
+		seen[r.ID] = struct{}{}
+		roles = append(roles, r)
+	}
+	return roles
+}
+
+// HasRole 检查用户是否拥有指定角色（含用户组角色）
 func (u *User) HasRole(roleName string) bool {
-	for _, r := range u.Roles {
+	for _, r := range u.EffectiveRoles() {
 		if r.Name == roleName {
 			return true
 		}
@@ -41,10 +142,10 @@ func (u *User) HasAnyRole(roleNames ...string) bool {
 	return slices.ContainsFunc(roleNames, u.HasRole)
 }
 
-// HasPermission 检查用户是否拥有指定权限
+// HasPermission 检查用户是否拥有指定权限（包含角色继承的权限和用户组角色的权限）
 func (u *User) HasPermission(permissionCode string) bool {
-	for _, r := range u.Roles {
-		for _, p := range r.Permissions {
+	for _, r := range u.EffectiveRoles() {
+		for _, p := range r.EffectivePermissions() {
 			if p.Code == permissionCode {
 				return true
 			}
@@ -53,20 +154,21 @@ func (u *User) HasPermission(permissionCode string) bool {
 	return false
 }
 
-// GetRoleNames 获取用户所有角色名称
+// GetRoleNames 获取用户所有有效角色名称（含用户组角色）
 func (u *User) GetRoleNames() []string {
-	names := make([]string, 0, len(u.Roles))
-	for _, r := range u.Roles {
+	roles := u.EffectiveRoles()
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
 		names = append(names, r.Name)
 	}
 	return names
 }
 
-// GetPermissions 获取用户所有去重后的权限
+// GetPermissions 获取用户所有去重后的权限（包含角色继承的权限和用户组角色的权限）
 func (u *User) GetPermissions() []role.Permission {
 	permissionMap := make(map[uint]role.Permission)
-	for _, r := range u.Roles {
-		for _, p := range r.Permissions {
+	for _, r := range u.EffectiveRoles() {
+		for _, p := range r.EffectivePermissions() {
 			permissionMap[p.ID] = p
 		}
 	}
@@ -111,11 +213,13 @@ func (u *User) IsInactive() bool {
 // Activate 激活用户
 func (u *User) Activate() {
 	u.Status = "active"
+	u.clearBan()
 }
 
 // Deactivate 停用用户
 func (u *User) Deactivate() {
 	u.Status = "inactive"
+	u.clearBan()
 }
 
 // Ban 禁用用户
@@ -123,6 +227,139 @@ func (u *User) Ban() {
 	u.Status = "banned"
 }
 
+// ChangeStatus 变更用户状态（领域行为）
+//
+// 封禁时 until 为空表示永久封禁，否则须晚于 now；封禁同时吊销已签发的会话。
+// 对已封禁用户再次封禁用于更新原因与期限；其余状态不变的变更返回 ErrUserStatusUnchanged。
+func (u *User) ChangeStatus(status, reason string, until *time.Time, now time.Time) error {
+	if !IsValidStatus(status) {
+		return ErrInvalidUserStatus
+	}
+	if status != StatusBanned && until != nil {
+		return ErrInvalidBanExpiry
+	}
+	if status == u.Status && status != StatusBanned {
+		return ErrUserStatusUnchanged
+	}
+
+	switch status {
+	case StatusActive:
+		u.Activate()
+	case StatusInactive:
+		u.Deactivate()
+	case StatusBanned:
+		if until != nil && !until.After(now) {
+			return ErrInvalidBanExpiry
+		}
+		u.Ban()
+		u.BanReason = reason
+		u.BannedUntil = until
+		u.RevokeSessions(now)
+	}
+	return nil
+}
+
+// IsBanExpired 检查限时封禁是否已到期
+func (u *User) IsBanExpired(now time.Time) bool {
+	return u.IsBanned() && u.BannedUntil != nil && !now.Before(*u.BannedUntil)
+}
+
+// RevokeSessions 吊销 now 之前签发的全部会话（领域行为）
+// 令牌签发时间精确到秒，因此按秒截断，同一秒内签发的令牌也视为已吊销
+func (u *User) RevokeSessions(now time.Time) {
+	t := now.Truncate(time.Second)
+	u.SessionsRevokedAt = &t
+}
+
+// IsSessionRevoked 检查在 issuedAt 签发的令牌是否已被吊销
+func (u *User) IsSessionRevoked(issuedAt time.Time) bool {
+	return u.SessionsRevokedAt != nil && !issuedAt.After(*u.SessionsRevokedAt)
+}
+
+// clearBan 清除封禁信息
+func (u *User) clearBan() {
+	u.BanReason = ""
+	u.BannedUntil = nil
+}
+
+// RequirePasswordChange 要求用户下次登录时修改密码（领域行为）
+func (u *User) RequirePasswordChange() {
+	u.MustChangePassword = true
+}
+
+// MarkPasswordChanged 记录密码已修改并清除强制修改标记（领域行为）
+func (u *User) MarkPasswordChanged(at time.Time) {
+	u.MustChangePassword = false
+	u.PasswordChangedAt = &at
+}
+
+// IsPasswordExpired 检查密码是否超过最长有效期，maxAge <= 0 表示不限制
+func (u *User) IsPasswordExpired(maxAge time.Duration, now time.Time) bool {
+	if maxAge <= 0 {
+		return false
+	}
+	changedAt := u.CreatedAt
+	if u.PasswordChangedAt != nil {
+		changedAt = *u.PasswordChangedAt
+	}
+	return now.Sub(changedAt) > maxAge
+}
+
+// PasswordChangeReason 返回需要强制修改密码的原因，无需修改时返回空字符串
+func (u *User) PasswordChangeReason(maxAge time.Duration, now time.Time) string {
+	if u.MustChangePassword {
+		return PasswordChangeReasonAdminReset
+	}
+	if u.IsPasswordExpired(maxAge, now) {
+		return PasswordChangeReasonExpired
+	}
+	return ""
+}
+
+// ScheduleDeletion 请求删除账号，宽限期 grace 结束后删除（领域行为）
+// 已在宽限期内时保持原计划不变
+func (u *User) ScheduleDeletion(now time.Time, grace time.Duration) {
+	if u.IsDeletionPending() {
+		return
+	}
+	scheduledAt := now.Add(max(grace, 0))
+	u.DeletionRequestedAt = &now
+	u.DeletionScheduledAt = &scheduledAt
+}
+
+// CancelDeletion 取消待执行的删除，返回是否存在待执行的删除（领域行为）
+func (u *User) CancelDeletion() bool {
+	if !u.IsDeletionPending() {
+		return false
+	}
+	u.DeletionRequestedAt = nil
+	u.DeletionScheduledAt = nil
+	return true
+}
+
+// IsDeletionPending 检查账号是否处于删除宽限期
+func (u *User) IsDeletionPending() bool {
+	return u.DeletionScheduledAt != nil
+}
+
+// IsDeletionDue 检查宽限期是否已结束，可以完成删除
+func (u *User) IsDeletionDue(now time.Time) bool {
+	return u.DeletionScheduledAt != nil && !now.Before(*u.DeletionScheduledAt)
+}
+
+// LastActiveAt 返回最近活跃时间：最近登录时间，从未登录时为创建时间
+func (u *User) LastActiveAt() time.Time {
+	if u.LastLoginAt != nil {
+		return *u.LastLoginAt
+	}
+	return u.CreatedAt
+}
+
+// IsIdleSince 检查用户自 cutoff 起是否未再登录（从未登录时按创建时间计算）
+func (u *User) IsIdleSince(cutoff time.Time) bool {
+	return u.LastActiveAt().Before(cutoff)
+}
+
 // AssignRole 分配角色（领域行为）
 func (u *User) AssignRole(r role.Role) error {
 	if u.HasRole(r.Name) {
@@ -154,7 +391,33 @@ func (u *User) UpdateProfile(fullName, avatar, bio string) {
 		u.FullName = fullName
 	}
 	if avatar != "" {
-		u.Avatar = avatar
+		u.SetAvatarURL(avatar)
 	}
 	u.Bio = bio // Bio can be empty
 }
+
+// SetAvatarURL 设置外部头像地址，替换已上传的头像（领域行为）
+func (u *User) SetAvatarURL(url string) {
+	if url == u.Avatar {
+		return
+	}
+	u.Avatar = url
+	u.AvatarVersion = ""
+}
+
+// SetUploadedAvatar 设置已上传头像的访问地址与版本（领域行为）
+func (u *User) SetUploadedAvatar(url, version string) {
+	u.Avatar = url
+	u.AvatarVersion = version
+}
+
+// ClearAvatar 清除头像，回退为默认头像（领域行为）
+func (u *User) ClearAvatar() {
+	u.Avatar = ""
+	u.AvatarVersion = ""
+}
+
+// HasUploadedAvatar 是否使用已上传的头像
+func (u *User) HasUploadedAvatar() bool {
+	return u.AvatarVersion != ""
+}
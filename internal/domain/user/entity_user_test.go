@@ -2,6 +2,7 @@ package user
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -282,6 +283,27 @@ func TestUser_GetPermissionCodes(t *testing.T) {
 	}
 }
 
+func TestUser_EffectiveRoles(t *testing.T) {
+	viewer := newTestRole(1, "viewer", newTestPermission(1, "user:read"))
+	editor := newTestRole(2, "editor", newTestPermission(2, "user:write"))
+
+	t.Run("无用户组角色时返回直接角色", func(t *testing.T) {
+		u := newTestUser(viewer)
+
+		assert.Equal(t, []role.Role{viewer}, u.EffectiveRoles())
+	})
+
+	t.Run("合并用户组角色并去重", func(t *testing.T) {
+		u := newTestUser(viewer)
+		u.GroupRoles = []role.Role{editor, viewer}
+
+		assert.Equal(t, []string{"viewer", "editor"}, u.GetRoleNames())
+		assert.ElementsMatch(t, []string{"user:read", "user:write"}, u.GetPermissionCodes())
+		assert.True(t, u.HasRole("editor"))
+		assert.True(t, u.HasPermission("user:write"))
+	})
+}
+
 func TestUser_IsAdmin(t *testing.T) {
 	tests := []struct {
 		name string
@@ -407,6 +429,43 @@ func TestUser_StateTransitions(t *testing.T) {
 	})
 }
 
+func TestUser_PasswordChangeReason(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	maxAge := 90 * 24 * time.Hour
+
+	t.Run("管理员重置后需修改", func(t *testing.T) {
+		user := &User{CreatedAt: now}
+		user.RequirePasswordChange()
+		assert.Equal(t, PasswordChangeReasonAdminReset, user.PasswordChangeReason(maxAge, now))
+	})
+
+	t.Run("超过有效期需修改", func(t *testing.T) {
+		changedAt := now.Add(-91 * 24 * time.Hour)
+		user := &User{PasswordChangedAt: &changedAt}
+		assert.True(t, user.IsPasswordExpired(maxAge, now))
+		assert.Equal(t, PasswordChangeReasonExpired, user.PasswordChangeReason(maxAge, now))
+	})
+
+	t.Run("未设置修改时间时以创建时间计算", func(t *testing.T) {
+		user := &User{CreatedAt: now.Add(-100 * 24 * time.Hour)}
+		assert.True(t, user.IsPasswordExpired(maxAge, now))
+	})
+
+	t.Run("未启用有效期不过期", func(t *testing.T) {
+		user := &User{CreatedAt: now.Add(-1000 * 24 * time.Hour)}
+		assert.False(t, user.IsPasswordExpired(0, now))
+		assert.Empty(t, user.PasswordChangeReason(0, now))
+	})
+
+	t.Run("修改密码后清除标记", func(t *testing.T) {
+		user := &User{MustChangePassword: true}
+		user.MarkPasswordChanged(now)
+		assert.False(t, user.MustChangePassword)
+		require.NotNil(t, user.PasswordChangedAt)
+		assert.Empty(t, user.PasswordChangeReason(maxAge, now))
+	})
+}
+
 func TestUser_AssignRole(t *testing.T) {
 	t.Run("成功分配新角色", func(t *testing.T) {
 		user := newTestUser()
@@ -553,6 +612,121 @@ func TestUser_UpdateProfile(t *testing.T) {
 	})
 }
 
+func TestUser_Avatar(t *testing.T) {
+	u := &User{}
+	u.SetUploadedAvatar("/api/avatars/1?v=abc", "abc")
+	assert.True(t, u.HasUploadedAvatar())
+
+	// 相同地址保留版本
+	u.SetAvatarURL("/api/avatars/1?v=abc")
+	assert.Equal(t, "abc", u.AvatarVersion)
+
+	// 外部地址替换已上传头像
+	u.SetAvatarURL("https://example.com/a.png")
+	assert.False(t, u.HasUploadedAvatar())
+	assert.Equal(t, "https://example.com/a.png", u.Avatar)
+
+	u.ClearAvatar()
+	assert.Empty(t, u.Avatar)
+	assert.False(t, u.HasUploadedAvatar())
+}
+
+func TestUser_ScheduleDeletion(t *testing.T) {
+	now := time.Now()
+
+	t.Run("进入宽限期", func(t *testing.T) {
+		user := &User{}
+
+		user.ScheduleDeletion(now, 14*24*time.Hour)
+
+		require.True(t, user.IsDeletionPending())
+		assert.Equal(t, now, *user.DeletionRequestedAt)
+		assert.Equal(t, now.Add(14*24*time.Hour), *user.DeletionScheduledAt)
+		assert.False(t, user.IsDeletionDue(now))
+		assert.True(t, user.IsDeletionDue(now.Add(14*24*time.Hour)))
+	})
+
+	t.Run("重复申请保留原计划", func(t *testing.T) {
+		user := &User{}
+		user.ScheduleDeletion(now, time.Hour)
+
+		user.ScheduleDeletion(now.Add(time.Minute), 48*time.Hour)
+
+		assert.Equal(t, now.Add(time.Hour), *user.DeletionScheduledAt)
+	})
+
+	t.Run("宽限期为0时立即到期", func(t *testing.T) {
+		user := &User{}
+
+		user.ScheduleDeletion(now, 0)
+
+		assert.True(t, user.IsDeletionDue(now))
+	})
+
+	t.Run("取消删除", func(t *testing.T) {
+		user := &User{}
+		user.ScheduleDeletion(now, time.Hour)
+
+		assert.True(t, user.CancelDeletion())
+		assert.False(t, user.IsDeletionPending())
+		assert.Nil(t, user.DeletionRequestedAt)
+		assert.False(t, user.CancelDeletion(), "没有待执行的删除")
+	})
+}
+
+func TestUser_ChangeStatus(t *testing.T) {
+	now := time.Now()
+	until := now.Add(24 * time.Hour)
+	past := now.Add(-time.Hour)
+
+	t.Run("限时封禁吊销会话", func(t *testing.T) {
+		user := &User{Status: StatusActive}
+
+		require.NoError(t, user.ChangeStatus(StatusBanned, "spam", &until, now))
+
+		assert.True(t, user.IsBanned())
+		assert.Equal(t, "spam", user.BanReason)
+		assert.Equal(t, until, *user.BannedUntil)
+		assert.True(t, user.IsSessionRevoked(now.Truncate(time.Second)), "令牌签发时间精确到秒")
+		assert.True(t, user.IsSessionRevoked(now.Add(-time.Minute)))
+		assert.False(t, user.IsSessionRevoked(now.Add(time.Second)))
+		assert.False(t, user.IsBanExpired(now))
+		assert.True(t, user.IsBanExpired(until))
+	})
+
+	t.Run("解封清除封禁信息", func(t *testing.T) {
+		user := &User{Status: StatusActive}
+		require.NoError(t, user.ChangeStatus(StatusBanned, "spam", &until, now))
+
+		require.NoError(t, user.ChangeStatus(StatusActive, "appeal", nil, now))
+
+		assert.Equal(t, StatusActive, user.Status)
+		assert.Empty(t, user.BanReason)
+		assert.Nil(t, user.BannedUntil)
+		assert.NotNil(t, user.SessionsRevokedAt, "解封不恢复已吊销的会话")
+	})
+
+	t.Run("已封禁用户可调整封禁期限", func(t *testing.T) {
+		user := &User{Status: StatusBanned}
+
+		require.NoError(t, user.ChangeStatus(StatusBanned, "permanent", nil, now))
+
+		assert.Nil(t, user.BannedUntil)
+		assert.False(t, user.IsBanExpired(now.Add(365*24*time.Hour)))
+	})
+
+	t.Run("无效变更", func(t *testing.T) {
+		user := &User{Status: StatusActive}
+
+		require.ErrorIs(t, user.ChangeStatus("deleted", "", nil, now), ErrInvalidUserStatus)
+		require.ErrorIs(t, user.ChangeStatus(StatusActive, "", nil, now), ErrUserStatusUnchanged)
+		require.ErrorIs(t, user.ChangeStatus(StatusInactive, "", &until, now), ErrInvalidBanExpiry)
+		require.ErrorIs(t, user.ChangeStatus(StatusBanned, "", &past, now), ErrInvalidBanExpiry)
+		assert.Equal(t, StatusActive, user.Status)
+		assert.Nil(t, user.SessionsRevokedAt)
+	})
+}
+
 func TestUser_EdgeCases(t *testing.T) {
 	t.Run("nil Roles slice", func(t *testing.T) {
 		user := &User{Roles: nil}
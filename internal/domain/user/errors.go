@@ -22,9 +22,24 @@ var (
 	// ErrRoleNotFound 角色不存在
 	ErrRoleNotFound = errors.New("role not found")
 
+	// ErrPrivilegedRoleAssignment 含管理权限的角色只能经角色分配接口授予（受双人审批保护）
+	ErrPrivilegedRoleAssignment = errors.New("privileged roles must be granted through role assignment")
+
+	// ErrInvalidAssignmentWindow 角色授权时间窗口无效（到期时间须晚于开始时间和当前时间）
+	ErrInvalidAssignmentWindow = errors.New("role assignment must expire after it starts and in the future")
+
 	// ErrInvalidUserStatus 无效的用户状态
 	ErrInvalidUserStatus = errors.New("invalid user status")
 
+	// ErrUserStatusUnchanged 用户已处于目标状态
+	ErrUserStatusUnchanged = errors.New("user is already in the requested status")
+
+	// ErrInvalidBanExpiry 封禁到期时间无效（仅封禁可设置，且须晚于当前时间）
+	ErrInvalidBanExpiry = errors.New("ban expiry must be in the future and only applies to bans")
+
+	// ErrCannotChangeOwnStatus 不能变更自己的状态
+	ErrCannotChangeOwnStatus = errors.New("cannot change your own status")
+
 	// ErrCannotDeleteSelf 不能删除自己
 	ErrCannotDeleteSelf = errors.New("cannot delete yourself")
 
@@ -33,4 +48,73 @@ var (
 
 	// ErrInvalidPassword 密码错误
 	ErrInvalidPassword = errors.New("invalid password")
+
+	// ErrPasswordManagedExternally 目录用户的密码由外部身份源管理
+	ErrPasswordManagedExternally = errors.New("password is managed by the external directory")
+
+	// ErrUserNotPending 用户不是待审批状态
+	ErrUserNotPending = errors.New("user is not pending approval")
+
+	// ErrInvalidListCriteria 列表查询条件无效（未知排序字段、游标与排序不匹配等）
+	ErrInvalidListCriteria = errors.New("invalid list criteria")
+
+	// ErrDataExportNotFound 数据导出任务不存在
+	ErrDataExportNotFound = errors.New("data export not found")
+
+	// ErrDataExportNotReady 数据导出归档尚未生成或生成失败
+	ErrDataExportNotReady = errors.New("data export is not ready")
+
+	// ErrDataExportExpired 数据导出归档已过期
+	ErrDataExportExpired = errors.New("data export has expired")
+
+	// ErrUnsupportedAvatarType 头像图片类型不受支持
+	ErrUnsupportedAvatarType = errors.New("unsupported avatar image type")
+
+	// ErrAvatarTooLarge 头像文件超过大小上限
+	ErrAvatarTooLarge = errors.New("avatar file is too large")
+
+	// ErrInvalidAvatar 头像图片无法解析或尺寸超限
+	ErrInvalidAvatar = errors.New("invalid avatar image")
+
+	// ErrImportJobNotFound 导入任务不存在
+	ErrImportJobNotFound = errors.New("import job not found")
+
+	// ErrUnsupportedImportFormat 不支持的导入文件格式
+	ErrUnsupportedImportFormat = errors.New("unsupported import file format")
+
+	// ErrInvalidImportFile 导入文件无法解析（缺少必需列、列映射无效等）
+	ErrInvalidImportFile = errors.New("invalid import file")
+
+	// ErrTooManyImportRows 导入文件行数超过上限
+	ErrTooManyImportRows = errors.New("too many rows in import file")
+
+	// ErrInvitationNotFound 邀请不存在
+	ErrInvitationNotFound = errors.New("invitation not found")
+
+	// ErrInvitationExpired 邀请已过期
+	ErrInvitationExpired = errors.New("invitation has expired")
+
+	// ErrInvitationAccepted 邀请已被接受
+	ErrInvitationAccepted = errors.New("invitation has already been accepted")
+
+	// ErrAttributeDefinitionNotFound 自定义属性定义不存在
+	ErrAttributeDefinitionNotFound = errors.New("attribute definition not found")
+
+	// ErrAttributeKeyExists 自定义属性键已存在
+	ErrAttributeKeyExists = errors.New("attribute key already exists")
+
+	// ErrInvalidAttributeDefinition 自定义属性定义无效
+	ErrInvalidAttributeDefinition = errors.New("invalid attribute definition")
+
+	// ErrUnknownAttribute 未定义的自定义属性
+	ErrUnknownAttribute = errors.New("unknown attribute")
+
+	// ErrInvalidAttributeValue 自定义属性值不符合定义
+	ErrInvalidAttributeValue = errors.New("invalid attribute value")
+
+	// ErrAttributeRequired 缺少必填的自定义属性
+	ErrAttributeRequired = errors.New("attribute is required")
+
+	// ErrAttributeNotEditable 用户不能编辑该自定义属性
+	ErrAttributeNotEditable = errors.New("attribute is not editable by user")
 )
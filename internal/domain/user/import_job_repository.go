@@ -0,0 +1,26 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+// ImportJobCommandRepository 用户导入任务写仓储接口
+type ImportJobCommandRepository interface {
+	// Create 创建导入任务
+	Create(ctx context.Context, job *ImportJob) error
+
+	// Save 保存任务进度、结果与状态
+	Save(ctx context.Context, job *ImportJob) error
+
+	// ClaimNext 认领最早的待处理任务并标记为处理中，没有可认领任务时返回 nil；
+	// 进度在 staleBefore 之前未更新的处理中任务（worker 中断）也可被重新认领。
+	// 使用条件更新保证同一任务只被一个 worker 认领
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*ImportJob, error)
+}
+
+// ImportJobQueryRepository 用户导入任务读仓储接口
+type ImportJobQueryRepository interface {
+	// GetByID 获取导入任务，不存在时返回 ErrImportJobNotFound
+	GetByID(ctx context.Context, id uint) (*ImportJob, error)
+}
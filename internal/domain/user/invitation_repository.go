@@ -0,0 +1,21 @@
+package user
+
+import "context"
+
+// InvitationCommandRepository 邀请写仓储接口
+type InvitationCommandRepository interface {
+	// Create 创建邀请
+	Create(ctx context.Context, invitation *Invitation) error
+
+	// MarkAccepted 标记邀请已接受
+	MarkAccepted(ctx context.Context, invitation *Invitation) error
+
+	// DeleteByUserID 删除用户的所有邀请（重新邀请时使旧链接失效）
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
+
+// InvitationQueryRepository 邀请读仓储接口
+type InvitationQueryRepository interface {
+	// FindByTokenHash 根据令牌哈希查找邀请
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Invitation, error)
+}
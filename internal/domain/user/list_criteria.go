@@ -0,0 +1,176 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+)
+
+// 可排序字段
+const (
+	SortByID        = "id"
+	SortByUsername  = "username"
+	SortByEmail     = "email"
+	SortByFullName  = "full_name"
+	SortByStatus    = "status"
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+
+	SortByLastLoginAt = "last_login_at" // 从未登录的用户按 NeverLoggedInSortTime 排序（最早）
+	SortByLoginCount  = "login_count"
+)
+
+// NeverLoggedInSortTime 从未登录的用户按最近登录时间排序时使用的值
+// 以确定的非空值参与排序与键集分页，避免不同数据库对 NULL 排序位置的差异
+var NeverLoggedInSortTime = time.Unix(0, 0).UTC()
+
+// sortableFields 允许排序的字段
+var sortableFields = map[string]bool{
+	SortByID: true, SortByUsername: true, SortByEmail: true, SortByFullName: true,
+	SortByStatus: true, SortByCreatedAt: true, SortByUpdatedAt: true,
+	SortByLastLoginAt: true, SortByLoginCount: true,
+}
+
+// SortField 排序字段
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListCriteria 用户列表查询条件
+//
+// 所有过滤条件之间为 AND 关系；未设置的条件不参与过滤。
+// 设置 After 时使用键集分页（忽略 Offset），否则使用偏移分页。
+type ListCriteria struct {
+	Keyword  string   // 用户名、邮箱或全名模糊匹配
+	Statuses []string // 状态（任一匹配）
+	RoleIDs  []uint   // 拥有任一角色（直接分配或经由用户组，仅计当前生效的授权）
+
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+
+	TwoFAEnabled *bool // 是否启用了任一第二因素（TOTP 或邮件/短信验证码）
+
+	LastLoginFrom *time.Time
+	LastLoginTo   *time.Time
+	NeverLoggedIn bool       // 仅返回从未成功登录的用户
+	IdleBefore    *time.Time // 仅返回最近登录（从未登录时为创建时间）早于该时间的用户
+	LoginCountMin *int64     // 登录次数下限（含）
+	LoginCountMax *int64     // 登录次数上限（含）
+
+	Attributes map[string]string // 自定义属性精确匹配（键为属性键，值为规范化后的值，均需匹配）
+
+	DeletionDueBefore *time.Time // 仅返回删除宽限期在该时间之前（含）结束的用户
+	BanExpiredBefore  *time.Time // 仅返回限时封禁在该时间之前（含）到期的已封禁用户
+
+	Policy *policy.Filter // 操作者 ABAC 策略转换的过滤条件，为 nil 时不限制
+
+	Sort   []SortField // 排序，为空时按 ID 升序
+	After  *Cursor     // 键集分页游标：返回排序位于该位置之后的用户
+	Offset int
+	Limit  int
+}
+
+// Cursor 键集分页位置：上一页最后一个用户在各排序字段上的值（与 OrderBy 一一对应）
+type Cursor struct {
+	Values []any
+}
+
+// Validate 校验排序字段和游标
+func (c ListCriteria) Validate() error {
+	seen := make(map[string]bool, len(c.Sort))
+	for _, s := range c.Sort {
+		if !sortableFields[s.Field] {
+			return fmt.Errorf("%w: unsupported sort field %q", ErrInvalidListCriteria, s.Field)
+		}
+		if seen[s.Field] {
+			return fmt.Errorf("%w: duplicate sort field %q", ErrInvalidListCriteria, s.Field)
+		}
+		seen[s.Field] = true
+	}
+	if c.After != nil && len(c.After.Values) != len(c.OrderBy()) {
+		return fmt.Errorf("%w: cursor does not match sort order", ErrInvalidListCriteria)
+	}
+	return nil
+}
+
+// OrderBy 返回实际使用的排序：请求的排序字段加上 ID 作为唯一的最终排序键，
+// 保证相同排序值的用户顺序稳定，键集分页不会遗漏或重复
+func (c ListCriteria) OrderBy() []SortField {
+	order := make([]SortField, 0, len(c.Sort)+1)
+	for _, s := range c.Sort {
+		if s.Field == SortByID {
+			return append(order, s)
+		}
+		order = append(order, s)
+	}
+	return append(order, SortField{Field: SortByID})
+}
+
+// CursorFor 生成位于指定用户之后的游标
+func (c ListCriteria) CursorFor(u *User) *Cursor {
+	order := c.OrderBy()
+	values := make([]any, 0, len(order))
+	for _, s := range order {
+		values = append(values, u.SortValue(s.Field))
+	}
+	return &Cursor{Values: values}
+}
+
+// ParseSort 解析排序表达式，如 "status,-created_at"（"-" 前缀表示降序）
+func ParseSort(expr string) ([]SortField, error) {
+	var fields []SortField
+	for part := range strings.SplitSeq(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if !sortableFields[field.Field] {
+			return nil, fmt.Errorf("%w: unsupported sort field %q", ErrInvalidListCriteria, field.Field)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// IsTimeSortField 检查排序字段是否为时间类型
+func IsTimeSortField(field string) bool {
+	return field == SortByCreatedAt || field == SortByUpdatedAt || field == SortByLastLoginAt
+}
+
+// IsNumericSortField 检查排序字段是否为数值类型
+func IsNumericSortField(field string) bool {
+	return field == SortByID || field == SortByLoginCount
+}
+
+// SortValue 返回用户在排序字段上的值
+func (u *User) SortValue(field string) any {
+	switch field {
+	case SortByUsername:
+		return u.Username
+	case SortByEmail:
+		return u.Email
+	case SortByFullName:
+		return u.FullName
+	case SortByStatus:
+		return u.Status
+	case SortByCreatedAt:
+		return u.CreatedAt
+	case SortByUpdatedAt:
+		return u.UpdatedAt
+	case SortByLastLoginAt:
+		if u.LastLoginAt == nil {
+			return NeverLoggedInSortTime
+		}
+		return *u.LastLoginAt
+	case SortByLoginCount:
+		return u.LoginCount
+	default:
+		return u.ID
+	}
+}
@@ -0,0 +1,57 @@
+package user
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// LoginActivity 一次成功的认证（密码登录、2FA 登录或个人访问令牌认证）
+type LoginActivity struct {
+	UserID uint
+	At     time.Time
+	IP     string
+	// Repeat 同一使用时段内的重复认证（如个人访问令牌的后续请求），
+	// 只刷新最近登录时间与 IP，不计入登录次数
+	Repeat bool
+}
+
+// LoginSummary 一批登录活动按用户汇总的结果
+type LoginSummary struct {
+	UserID uint
+	LastAt time.Time
+	LastIP string
+	Count  int64 // 计入登录次数的认证数
+}
+
+// LoginRecorder 登录活动记录器
+// Record 不得阻塞调用方，由实现异步批量写入
+type LoginRecorder interface {
+	Record(activity LoginActivity)
+}
+
+// SummarizeLogins 按用户汇总登录活动：取最近一次认证的时间与 IP，累计非重复认证的次数。
+// 结果按用户 ID 升序排列，批量写入时加锁顺序一致。
+func SummarizeLogins(activities []LoginActivity) []LoginSummary {
+	byUser := make(map[uint]*LoginSummary, len(activities))
+	for _, a := range activities {
+		s, ok := byUser[a.UserID]
+		if !ok {
+			s = &LoginSummary{UserID: a.UserID, LastAt: a.At, LastIP: a.IP}
+			byUser[a.UserID] = s
+		} else if !a.At.Before(s.LastAt) {
+			s.LastAt = a.At
+			s.LastIP = a.IP
+		}
+		if !a.Repeat {
+			s.Count++
+		}
+	}
+
+	result := make([]LoginSummary, 0, len(byUser))
+	for _, s := range byUser {
+		result = append(result, *s)
+	}
+	slices.SortFunc(result, func(a, b LoginSummary) int { return cmp.Compare(a.UserID, b.UserID) })
+	return result
+}
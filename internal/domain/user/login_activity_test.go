@@ -0,0 +1,40 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeLogins(t *testing.T) {
+	base := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	summaries := SummarizeLogins([]LoginActivity{
+		{UserID: 2, At: base.Add(time.Minute), IP: "10.0.0.2"},
+		{UserID: 1, At: base.Add(2 * time.Minute), IP: "10.0.0.9"},
+		// 乱序到达的较早记录不覆盖最近登录
+		{UserID: 1, At: base, IP: "10.0.0.1"},
+		{UserID: 2, At: base.Add(3 * time.Minute), IP: "10.0.0.3", Repeat: true},
+	})
+
+	assert.Equal(t, []LoginSummary{
+		{UserID: 1, LastAt: base.Add(2 * time.Minute), LastIP: "10.0.0.9", Count: 2},
+		{UserID: 2, LastAt: base.Add(3 * time.Minute), LastIP: "10.0.0.3", Count: 1},
+	}, summaries)
+
+	assert.Empty(t, SummarizeLogins(nil))
+}
+
+func TestUser_IsIdleSince(t *testing.T) {
+	now := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	cutoff := now.AddDate(0, 0, -90)
+	recent := now.AddDate(0, 0, -10)
+	old := now.AddDate(0, 0, -100)
+
+	assert.False(t, (&User{CreatedAt: old, LastLoginAt: &recent}).IsIdleSince(cutoff))
+	assert.True(t, (&User{CreatedAt: old, LastLoginAt: &old}).IsIdleSince(cutoff))
+	// 从未登录时按创建时间计算
+	assert.True(t, (&User{CreatedAt: old}).IsIdleSince(cutoff))
+	assert.False(t, (&User{CreatedAt: recent}).IsIdleSince(cutoff))
+}
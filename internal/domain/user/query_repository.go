@@ -24,17 +24,17 @@ type AuthQueryRepository interface {
 	// GetByEmail 根据邮箱获取用户
 	GetByEmail(ctx context.Context, email string) (*User, error)
 
-	// GetByUsernameWithRoles 根据用户名获取用户（包含角色和权限信息）
+	// GetByUsernameWithRoles 根据用户名获取用户（包含直接角色、用户组角色和权限信息）
 	GetByUsernameWithRoles(ctx context.Context, username string) (*User, error)
 
-	// GetByEmailWithRoles 根据邮箱获取用户（包含角色和权限信息）
+	// GetByEmailWithRoles 根据邮箱获取用户（包含直接角色、用户组角色和权限信息）
 	GetByEmailWithRoles(ctx context.Context, email string) (*User, error)
 }
 
 // DetailQueryRepository 详情查询接口
 // 用于需要关联数据的场景
 type DetailQueryRepository interface {
-	// GetByIDWithRoles 根据 ID 获取用户（包含角色和权限信息）
+	// GetByIDWithRoles 根据 ID 获取用户（包含直接角色、用户组角色和权限信息）
 	GetByIDWithRoles(ctx context.Context, id uint) (*User, error)
 }
 
@@ -65,6 +65,12 @@ type ListQueryRepository interface {
 
 	// CountBySearch 统计搜索结果数量
 	CountBySearch(ctx context.Context, keyword string) (int64, error)
+
+	// ListByCriteria 按条件查询用户列表（包含直接角色），支持多字段排序、偏移分页和键集分页
+	ListByCriteria(ctx context.Context, criteria ListCriteria) ([]*User, error)
+
+	// CountByCriteria 统计匹配条件的用户数量（忽略排序和分页）
+	CountByCriteria(ctx context.Context, criteria ListCriteria) (int64, error)
 }
 
 // RoleQueryRepository 角色关联查询接口
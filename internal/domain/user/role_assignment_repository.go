@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+// RoleAssignmentCommandRepository 角色授权写仓储接口
+type RoleAssignmentCommandRepository interface {
+	// Grant 创建或覆盖用户对某角色的授权（同一用户同一角色仅保留一条授权）
+	Grant(ctx context.Context, assignment *RoleAssignment) error
+
+	// MarkActivated 标记计划授权已激活
+	MarkActivated(ctx context.Context, userID, roleID uint, at time.Time) error
+
+	// Expire 移除已到期的授权，授权期间被重新授予（未到期）时不删除并返回 false
+	Expire(ctx context.Context, userID, roleID uint, now time.Time) (bool, error)
+}
+
+// RoleAssignmentQueryRepository 角色授权读仓储接口
+// 与用户角色查询不同，这里返回包含未生效和已过期在内的全部授权
+type RoleAssignmentQueryRepository interface {
+	// ListByUser 获取用户的全部角色授权
+	ListByUser(ctx context.Context, userID uint) ([]*RoleAssignment, error)
+
+	// ListDueActivations 获取已到开始时间但尚未激活的计划授权
+	ListDueActivations(ctx context.Context, now time.Time, limit int) ([]*RoleAssignment, error)
+
+	// ListExpired 获取已到期的授权
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*RoleAssignment, error)
+
+	// ListExpiring 获取在 (now, until] 内到期的生效授权，按到期时间升序
+	ListExpiring(ctx context.Context, now, until time.Time, offset, limit int) ([]*RoleAssignment, int64, error)
+}
@@ -0,0 +1,15 @@
+package user
+
+import "context"
+
+// StatusHistoryCommandRepository 用户状态变更记录写仓储接口
+type StatusHistoryCommandRepository interface {
+	// Create 追加状态变更记录
+	Create(ctx context.Context, change *StatusChange) error
+}
+
+// StatusHistoryQueryRepository 用户状态变更记录读仓储接口
+type StatusHistoryQueryRepository interface {
+	// ListByUser 按时间倒序分页列出用户的状态变更记录
+	ListByUser(ctx context.Context, userID uint, offset, limit int) ([]*StatusChange, int64, error)
+}
@@ -69,6 +69,17 @@ func (s *authServiceImpl) GenerateAccessToken(ctx context.Context, userID uint,
 	return token, expiresAt, nil
 }
 
+// GenerateScopedAccessToken 生成受限作用域的访问令牌
+func (s *authServiceImpl) GenerateScopedAccessToken(ctx context.Context, userID uint, username, scope string) (string, time.Time, error) {
+	token, err := s.jwtManager.GenerateScopedAccessToken(userID, username, "", scope)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate scoped access token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.jwtManager.accessTokenDuration)
+	return token, expiresAt, nil
+}
+
 // GenerateRefreshToken 生成刷新令牌
 func (s *authServiceImpl) GenerateRefreshToken(ctx context.Context, userID uint) (string, time.Time, error) {
 	token, err := s.jwtManager.GenerateRefreshToken(userID)
@@ -100,17 +111,21 @@ func (s *authServiceImpl) ValidateAccessToken(ctx context.Context, token string)
 }
 
 // ValidateRefreshToken 验证刷新令牌
-func (s *authServiceImpl) ValidateRefreshToken(ctx context.Context, token string) (uint, error) {
+func (s *authServiceImpl) ValidateRefreshToken(ctx context.Context, token string) (uint, time.Time, error) {
 	claims, err := s.jwtManager.ValidateToken(token)
 	if err != nil {
-		return 0, domainAuth.ErrInvalidToken
+		return 0, time.Time{}, domainAuth.ErrInvalidToken
 	}
 
 	if time.Unix(claims.ExpiresAt.Unix(), 0).Before(time.Now()) {
-		return 0, domainAuth.ErrTokenExpired
+		return 0, time.Time{}, domainAuth.ErrTokenExpired
 	}
 
-	return claims.UserID, nil
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	return claims.UserID, issuedAt, nil
 }
 
 // GeneratePATToken 生成个人访问令牌
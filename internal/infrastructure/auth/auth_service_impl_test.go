@@ -357,14 +357,15 @@ func TestAuthService_ValidateRefreshToken(t *testing.T) {
 	t.Run("验证有效刷新令牌", func(t *testing.T) {
 		token, _, _ := svc.GenerateRefreshToken(ctx, 456)
 
-		userID, err := svc.ValidateRefreshToken(ctx, token)
+		userID, issuedAt, err := svc.ValidateRefreshToken(ctx, token)
 
 		require.NoError(t, err, "ValidateRefreshToken() 应该成功")
 		assert.Equal(t, uint(456), userID, "UserID 应该匹配")
+		assert.WithinDuration(t, time.Now(), issuedAt, 2*time.Second, "签发时间应该为当前时间")
 	})
 
 	t.Run("无效刷新令牌", func(t *testing.T) {
-		_, err := svc.ValidateRefreshToken(ctx, "invalid.refresh.token")
+		_, _, err := svc.ValidateRefreshToken(ctx, "invalid.refresh.token")
 
 		assert.ErrorIs(t, err, domainAuth.ErrInvalidToken, "无效刷新令牌应该返回 ErrInvalidToken")
 	})
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ClientCertService 将 mTLS 客户端证书映射为服务账号
+//
+// 证书由 TLS 层使用 client CA 校验，本服务只负责按证书 CN 查找映射的用户，
+// 未配置映射的证书一律拒绝。
+type ClientCertService struct {
+	userQueryRepo user.QueryRepository
+	accounts      map[string]string // CN -> username
+}
+
+// NewClientCertService 创建客户端证书认证服务
+// mapping 格式: cn1=user1,cn2=user2
+func NewClientCertService(userQueryRepo user.QueryRepository, mapping string) (*ClientCertService, error) {
+	accounts, err := ParseClientCertAccounts(mapping)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientCertService{
+		userQueryRepo: userQueryRepo,
+		accounts:      accounts,
+	}, nil
+}
+
+// ParseClientCertAccounts 解析 CN 到用户名的映射配置
+func ParseClientCertAccounts(mapping string) (map[string]string, error) {
+	accounts := make(map[string]string)
+	for entry := range strings.SplitSeq(mapping, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		cn, username, ok := strings.Cut(entry, "=")
+		cn, username = strings.TrimSpace(cn), strings.TrimSpace(username)
+		if !ok || cn == "" || username == "" {
+			return nil, fmt.Errorf("invalid client cert account mapping: %q", entry)
+		}
+		accounts[cn] = username
+	}
+	return accounts, nil
+}
+
+// Enabled 是否配置了任何证书映射
+func (s *ClientCertService) Enabled() bool {
+	return len(s.accounts) > 0
+}
+
+// Authenticate 根据已验证的客户端证书返回映射的服务账号
+func (s *ClientCertService) Authenticate(ctx context.Context, cert *x509.Certificate) (*user.User, error) {
+	username, ok := s.accounts[cert.Subject.CommonName]
+	if !ok {
+		return nil, fmt.Errorf("client certificate %q is not mapped to an account", cert.Subject.CommonName)
+	}
+
+	u, err := s.userQueryRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, errors.New("service account not found")
+	}
+	if !u.CanLogin() {
+		return nil, errors.New("service account is disabled")
+	}
+
+	return u, nil
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClientCertAccounts(t *testing.T) {
+	t.Run("解析多个映射", func(t *testing.T) {
+		accounts, err := ParseClientCertAccounts("ci-runner=svc-ci, backup = svc-backup")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ci-runner": "svc-ci", "backup": "svc-backup"}, accounts)
+	})
+
+	t.Run("空配置", func(t *testing.T) {
+		accounts, err := ParseClientCertAccounts("")
+
+		require.NoError(t, err)
+		assert.Empty(t, accounts)
+	})
+
+	t.Run("格式错误", func(t *testing.T) {
+		_, err := ParseClientCertAccounts("ci-runner")
+		require.Error(t, err)
+
+		_, err = ParseClientCertAccounts("=svc-ci")
+		require.Error(t, err)
+	})
+}
@@ -28,6 +28,14 @@
 // PAT 认证：
 //   - [PATService]: 个人访问令牌认证服务
 //   - 支持令牌验证和权限检查
+//   - 支持 HTTP Basic（用户名 + PAT）凭证校验
+//
+// 登录活动：
+//   - [LoginActivityRecorder]: 异步批量写入最近登录时间、IP 与登录次数
+//   - 个人访问令牌空闲超过 30 分钟后再次使用计为一次新的登录
+//
+// 客户端证书：
+//   - [ClientCertService]: mTLS 客户端证书 CN 到服务账号的映射
 //
 // 权限缓存：
 //   - [PermissionCacheService]: 用户权限缓存服务
@@ -6,8 +6,12 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	domainAuth "github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
 )
 
+// ScopePasswordChange 强制修改密码的受限令牌作用域
+const ScopePasswordChange = domainAuth.TokenScopePasswordChange
+
 // Claims JWT 自定义声明
 type Claims struct {
 	jwt.RegisteredClaims
@@ -19,6 +23,9 @@ type Claims struct {
 	// 新 token 不再包含这些字段，权限信息改为从缓存/数据库实时查询
 	Roles       []string `json:"roles,omitempty"`       // Deprecated: 仅用于向后兼容
 	Permissions []string `json:"permissions,omitempty"` // Deprecated: 仅用于向后兼容
+
+	// Scope 令牌作用域，为空表示完整令牌；受限令牌仅能访问作用域允许的接口
+	Scope string `json:"scope,omitempty"`
 }
 
 // JWTManager JWT 管理器
@@ -40,10 +47,17 @@ func NewJWTManager(secretKey string, accessTokenDuration, refreshTokenDuration t
 // GenerateAccessToken 生成访问令牌
 // 新架构：Token 只包含 user_id/username/email，权限信息从缓存/数据库实时查询
 func (m *JWTManager) GenerateAccessToken(userID uint, username, email string) (string, error) {
+	return m.GenerateScopedAccessToken(userID, username, email, "")
+}
+
+// GenerateScopedAccessToken 生成带作用域的访问令牌
+// scope 为空时等同于 GenerateAccessToken
+func (m *JWTManager) GenerateScopedAccessToken(userID uint, username, email, scope string) (string, error) {
 	claims := Claims{
 		UserID:   userID,
 		Username: username,
 		Email:    email,
+		Scope:    scope,
 		// Roles 和 Permissions 不再包含在 token 中
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.accessTokenDuration)),
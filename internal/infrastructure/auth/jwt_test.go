@@ -66,6 +66,26 @@ func TestJWTManager_GenerateAccessToken(t *testing.T) {
 	})
 }
 
+func TestJWTManager_GenerateScopedAccessToken(t *testing.T) {
+	manager := NewJWTManager("test-secret", time.Hour, 24*time.Hour)
+
+	t.Run("包含作用域", func(t *testing.T) {
+		token, err := manager.GenerateScopedAccessToken(1, "testuser", "", ScopePasswordChange)
+		require.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, ScopePasswordChange, claims.Scope)
+	})
+
+	t.Run("普通令牌无作用域", func(t *testing.T) {
+		token, _ := manager.GenerateAccessToken(1, "testuser", "")
+		claims, err := manager.ValidateToken(token)
+		require.NoError(t, err)
+		assert.Empty(t, claims.Scope)
+	})
+}
+
 func TestJWTManager_GenerateRefreshToken(t *testing.T) {
 	manager := NewJWTManager("test-secret", time.Hour, 24*time.Hour)
 
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+const (
+	// LoginActivityFlushInterval 登录活动的默认写入间隔，决定最近登录时间的最大延迟
+	LoginActivityFlushInterval = 2 * time.Second
+	// loginActivityBatchSize 待写入条数达到该值时立即写入，不等待定时器
+	loginActivityBatchSize = 500
+	// loginActivityMaxPending 待写入条数上限，数据库不可用时丢弃新记录，避免内存无限增长
+	loginActivityMaxPending = 10000
+)
+
+// LoginActivityRecorder 登录活动记录器（实现 user.LoginRecorder）
+//
+// 登录路径只把记录追加到内存队列，由后台协程定期或在队列达到批量大小时
+// 按用户汇总后批量写入，避免每次登录都同步更新 users 表。
+// 写入失败的批次仅记录日志：登录活动是统计信息，不重试。
+type LoginActivityRecorder struct {
+	repo          user.CommandRepository
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	pending []user.LoginActivity
+	dropped int
+
+	flushCh   chan struct{}
+	stopCh    chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ user.LoginRecorder = (*LoginActivityRecorder)(nil)
+
+// NewLoginActivityRecorder 创建登录活动记录器并启动后台写入协程
+// flushInterval <= 0 时使用 LoginActivityFlushInterval
+func NewLoginActivityRecorder(repo user.CommandRepository, flushInterval time.Duration) *LoginActivityRecorder {
+	if flushInterval <= 0 {
+		flushInterval = LoginActivityFlushInterval
+	}
+	r := &LoginActivityRecorder{
+		repo:          repo,
+		flushInterval: flushInterval,
+		flushCh:       make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Record 追加一条登录活动，不阻塞调用方
+func (r *LoginActivityRecorder) Record(activity user.LoginActivity) {
+	r.mu.Lock()
+	if len(r.pending) >= loginActivityMaxPending {
+		r.dropped++
+		r.mu.Unlock()
+		return
+	}
+	r.pending = append(r.pending, activity)
+	full := len(r.pending) >= loginActivityBatchSize
+	r.mu.Unlock()
+
+	if full {
+		select {
+		case r.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush 立即写入队列中的登录活动
+func (r *LoginActivityRecorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	batch := r.pending
+	dropped := r.dropped
+	r.pending = nil
+	r.dropped = 0
+	r.mu.Unlock()
+
+	if dropped > 0 {
+		slog.Warn("Login activity queue full, records dropped", "dropped", dropped)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return r.repo.RecordLogins(ctx, user.SummarizeLogins(batch))
+}
+
+// Close 停止后台协程并写入剩余的登录活动
+func (r *LoginActivityRecorder) Close() {
+	r.closeOnce.Do(func() {
+		close(r.stopCh)
+		<-r.done
+	})
+}
+
+// run 后台写入循环
+func (r *LoginActivityRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-r.flushCh:
+		case <-r.stopCh:
+			r.flush()
+			return
+		}
+		r.flush()
+	}
+}
+
+// flush 写入一批登录活动，失败时记录日志
+func (r *LoginActivityRecorder) flush() {
+	if err := r.Flush(context.Background()); err != nil {
+		slog.Error("Failed to record login activity", "error", err)
+	}
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// loginRecordingRepo 只实现 RecordLogins 的用户命令仓储
+type loginRecordingRepo struct {
+	user.CommandRepository
+
+	mu      sync.Mutex
+	batches [][]user.LoginSummary
+}
+
+func (r *loginRecordingRepo) RecordLogins(_ context.Context, summaries []user.LoginSummary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, summaries)
+	return nil
+}
+
+func (r *loginRecordingRepo) recorded() [][]user.LoginSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches
+}
+
+func TestLoginActivityRecorder(t *testing.T) {
+	now := time.Now()
+
+	t.Run("按用户汇总后批量写入", func(t *testing.T) {
+		repo := &loginRecordingRepo{}
+		recorder := NewLoginActivityRecorder(repo, time.Hour)
+		defer recorder.Close()
+
+		recorder.Record(user.LoginActivity{UserID: 1, At: now, IP: "10.0.0.1"})
+		recorder.Record(user.LoginActivity{UserID: 1, At: now.Add(time.Second), IP: "10.0.0.2"})
+		recorder.Record(user.LoginActivity{UserID: 2, At: now, IP: "10.0.0.3"})
+		require.NoError(t, recorder.Flush(context.Background()))
+
+		batches := repo.recorded()
+		require.Len(t, batches, 1)
+		assert.Equal(t, []user.LoginSummary{
+			{UserID: 1, LastAt: now.Add(time.Second), LastIP: "10.0.0.2", Count: 2},
+			{UserID: 2, LastAt: now, LastIP: "10.0.0.3", Count: 1},
+		}, batches[0])
+
+		// 队列为空时不写入
+		require.NoError(t, recorder.Flush(context.Background()))
+		assert.Len(t, repo.recorded(), 1)
+	})
+
+	t.Run("关闭时写入剩余记录", func(t *testing.T) {
+		repo := &loginRecordingRepo{}
+		recorder := NewLoginActivityRecorder(repo, time.Hour)
+
+		recorder.Record(user.LoginActivity{UserID: 3, At: now, IP: "10.0.0.4"})
+		recorder.Close()
+		recorder.Close()
+
+		batches := repo.recorded()
+		require.Len(t, batches, 1)
+		assert.Equal(t, uint(3), batches[0][0].UserID)
+	})
+
+	t.Run("达到批量大小时立即写入", func(t *testing.T) {
+		repo := &loginRecordingRepo{}
+		recorder := NewLoginActivityRecorder(repo, time.Hour)
+		defer recorder.Close()
+
+		for i := range loginActivityBatchSize {
+			recorder.Record(user.LoginActivity{UserID: uint(i%10) + 1, At: now})
+		}
+
+		assert.Eventually(t, func() bool { return len(repo.recorded()) == 1 }, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestPATService_RecordLogin(t *testing.T) {
+	now := time.Now()
+	repo := &loginRecordingRepo{}
+	recorder := NewLoginActivityRecorder(repo, time.Hour)
+	defer recorder.Close()
+	svc := &PATService{loginRecorder: recorder}
+
+	recent := now.Add(-time.Minute)
+	idle := now.Add(-patLoginIdleGap)
+	svc.recordLogin(1, "10.0.0.1", nil, now)
+	svc.recordLogin(2, "10.0.0.2", &recent, now)
+	svc.recordLogin(3, "10.0.0.3", &idle, now)
+	require.NoError(t, recorder.Flush(context.Background()))
+
+	batches := repo.recorded()
+	require.Len(t, batches, 1)
+	counts := make(map[uint]int64)
+	for _, s := range batches[0] {
+		counts[s.UserID] = s.Count
+	}
+	// 空闲间隔内的连续使用不计入登录次数
+	assert.Equal(t, map[uint]int64{1: 1, 2: 0, 3: 1}, counts)
+}
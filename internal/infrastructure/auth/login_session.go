@@ -100,6 +100,25 @@ func (s *LoginSessionService) VerifySessionToken(ctx context.Context, token stri
 	return sessionData, nil
 }
 
+// PeekSessionToken 校验会话token但不消费
+// 用于二次认证前下发邮件/短信验证码等需要保留会话的场景
+func (s *LoginSessionService) PeekSessionToken(ctx context.Context, token string) (*LoginSessionData, error) {
+	if token == "" {
+		return nil, errors.New("session token is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sessionData, exists := s.sessions[token]
+	if !exists || sessionData.IsExpired() {
+		return nil, errors.New("invalid or expired session token")
+	}
+
+	data := *sessionData
+	return &data, nil
+}
+
 // cleanupExpired 定期清理过期会话
 func (s *LoginSessionService) cleanupExpired() {
 	ticker := time.NewTicker(1 * time.Minute)
@@ -8,29 +8,41 @@ import (
 	"time"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 )
 
+// patLoginIdleGap 令牌空闲超过该时长后再次使用计为一次新的登录，
+// 期间的后续请求只刷新最近登录时间与 IP
+const patLoginIdleGap = 30 * time.Minute
+
 // PATService handles Personal Access Token business logic
 type PATService struct {
 	patCommandRepo pat.CommandRepository
 	patQueryRepo   pat.QueryRepository
 	userQueryRepo  user.QueryRepository
+	settingQuery   setting.QueryRepository
 	tokenGen       *TokenGenerator
+	loginRecorder  user.LoginRecorder
 }
 
 // NewPATService creates a new PAT service
+// loginRecorder 为 nil 时不记录令牌认证的登录活动
 func NewPATService(
 	patCommandRepo pat.CommandRepository,
 	patQueryRepo pat.QueryRepository,
 	userQueryRepo user.QueryRepository,
+	settingQuery setting.QueryRepository,
 	tokenGen *TokenGenerator,
+	loginRecorder user.LoginRecorder,
 ) *PATService {
 	return &PATService{
 		patCommandRepo: patCommandRepo,
 		patQueryRepo:   patQueryRepo,
 		userQueryRepo:  userQueryRepo,
+		settingQuery:   settingQuery,
 		tokenGen:       tokenGen,
+		loginRecorder:  loginRecorder,
 	}
 }
 
@@ -197,9 +209,15 @@ func (s *PATService) DeleteToken(ctx context.Context, userID, tokenID uint) erro
 // ValidateToken validates a PAT and returns associated permissions
 // This is used in the authentication middleware
 func (s *PATService) ValidateToken(ctx context.Context, plainToken string) (*pat.PersonalAccessToken, error) {
+	token, _, err := s.validateToken(ctx, plainToken)
+	return token, err
+}
+
+// validateToken 校验令牌并异步刷新最近使用时间，同时返回本次使用前的最近使用时间
+func (s *PATService) validateToken(ctx context.Context, plainToken string) (*pat.PersonalAccessToken, *time.Time, error) {
 	// Validate format
 	if !s.tokenGen.ValidateTokenFormat(plainToken) {
-		return nil, errors.New("invalid token format")
+		return nil, nil, errors.New("invalid token format")
 	}
 
 	// Hash the token
@@ -208,13 +226,14 @@ func (s *PATService) ValidateToken(ctx context.Context, plainToken string) (*pat
 	// Find token in database
 	token, err := s.patQueryRepo.FindByToken(ctx, tokenHash)
 	if err != nil {
-		return nil, errors.New("invalid token")
+		return nil, nil, errors.New("invalid token")
 	}
 
 	// Check if token is active
 	if !token.IsActive() {
-		return nil, errors.New("token is inactive or expired")
+		return nil, nil, errors.New("token is inactive or expired")
 	}
+	previousUse := token.LastUsedAt
 
 	// Update last used time (asynchronously to avoid blocking)
 	// 使用 WithoutCancel 保留 trace 信息，但不受请求取消影响
@@ -224,26 +243,82 @@ func (s *PATService) ValidateToken(ctx context.Context, plainToken string) (*pat
 		_ = s.patCommandRepo.Update(updateCtx, token)
 	}(context.WithoutCancel(ctx))
 
-	return token, nil
+	return token, previousUse, nil
 }
 
 // ValidateTokenWithIP validates a PAT and checks IP whitelist
+// 认证通过后记录令牌所属用户的登录活动
 func (s *PATService) ValidateTokenWithIP(ctx context.Context, plainToken, clientIP string) (*pat.PersonalAccessToken, error) {
-	token, err := s.ValidateToken(ctx, plainToken)
+	token, previousUse, err := s.validateTokenWithIP(ctx, plainToken, clientIP)
 	if err != nil {
 		return nil, err
 	}
 
+	s.recordLogin(token.UserID, clientIP, previousUse, time.Now())
+	return token, nil
+}
+
+// validateTokenWithIP 校验令牌及 IP 白名单，不记录登录活动
+func (s *PATService) validateTokenWithIP(ctx context.Context, plainToken, clientIP string) (*pat.PersonalAccessToken, *time.Time, error) {
+	token, previousUse, err := s.validateToken(ctx, plainToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Check IP whitelist if configured
 	if len(token.IPWhitelist) > 0 {
 		if !slices.Contains(token.IPWhitelist, clientIP) {
-			return nil, fmt.Errorf("access denied: IP %s not in whitelist", clientIP)
+			return nil, nil, fmt.Errorf("access denied: IP %s not in whitelist", clientIP)
 		}
 	}
 
+	return token, previousUse, nil
+}
+
+// recordLogin 记录令牌认证的登录活动，令牌在空闲间隔内的连续使用不计入登录次数
+func (s *PATService) recordLogin(userID uint, clientIP string, previousUse *time.Time, now time.Time) {
+	if s.loginRecorder == nil {
+		return
+	}
+	s.loginRecorder.Record(user.LoginActivity{
+		UserID: userID,
+		At:     now,
+		IP:     clientIP,
+		Repeat: previousUse != nil && now.Sub(*previousUse) < patLoginIdleGap,
+	})
+}
+
+// ValidateBasicCredentials validates HTTP Basic credentials (username + PAT, git-style)
+// 令牌必须属于该用户名对应的用户，同时执行 IP 白名单检查；全部校验通过后才记录登录活动
+func (s *PATService) ValidateBasicCredentials(ctx context.Context, username, plainToken, clientIP string) (*pat.PersonalAccessToken, error) {
+	token, previousUse, err := s.validateTokenWithIP(ctx, plainToken, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userQueryRepo.GetByID(ctx, token.UserID)
+	if err != nil || owner.Username != username {
+		return nil, errors.New("invalid credentials")
+	}
+
+	s.recordLogin(token.UserID, clientIP, previousUse, time.Now())
 	return token, nil
 }
 
+// PasswordChangeScope 返回令牌所属用户当前允许的作用域
+// 用户必须修改密码（管理员重置或密码过期）时返回 ScopePasswordChange，
+// 与密码登录签发的受限令牌一致，令牌只能访问修改密码接口；否则返回空字符串
+func (s *PATService) PasswordChangeScope(ctx context.Context, userID uint) (string, error) {
+	owner, err := s.userQueryRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token owner: %w", err)
+	}
+	if owner.PasswordChangeReason(setting.PasswordMaxAge(ctx, s.settingQuery), time.Now()) == "" {
+		return "", nil
+	}
+	return ScopePasswordChange, nil
+}
+
 // DeleteAllUserTokens deletes all tokens for a user (e.g., on password change)
 func (s *PATService) DeleteAllUserTokens(ctx context.Context, userID uint) error {
 	return s.patCommandRepo.DeleteByUserID(ctx, userID)
@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// stubUserQueryRepo 按 ID 返回预置用户（角色随用户预置）
+type stubUserQueryRepo struct {
+	user.QueryRepository
+
+	users map[uint]*user.User
+}
+
+func (r *stubUserQueryRepo) GetByID(_ context.Context, id uint) (*user.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return u, nil
+}
+
+func (r *stubUserQueryRepo) GetByIDWithRoles(ctx context.Context, id uint) (*user.User, error) {
+	return r.GetByID(ctx, id)
+}
+
+// stubPATQueryRepo 按令牌哈希返回预置 PAT（返回副本，避免与异步刷新使用时间竞争）
+type stubPATQueryRepo struct {
+	pat.QueryRepository
+
+	tokens map[string]pat.PersonalAccessToken
+}
+
+func (r *stubPATQueryRepo) FindByToken(_ context.Context, tokenHash string) (*pat.PersonalAccessToken, error) {
+	token, ok := r.tokens[tokenHash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &token, nil
+}
+
+// stubPATCommandRepo 忽略最近使用时间的刷新
+type stubPATCommandRepo struct {
+	pat.CommandRepository
+}
+
+func (r *stubPATCommandRepo) Update(context.Context, *pat.PersonalAccessToken) error {
+	return nil
+}
+
+// capturingLoginRecorder 记录收到的登录活动
+type capturingLoginRecorder struct {
+	activities []user.LoginActivity
+}
+
+func (r *capturingLoginRecorder) Record(activity user.LoginActivity) {
+	r.activities = append(r.activities, activity)
+}
+
+// stubSettingQueryRepo 按键返回预置配置
+type stubSettingQueryRepo struct {
+	setting.QueryRepository
+
+	settings map[string]*setting.Setting
+}
+
+func (r *stubSettingQueryRepo) FindByKey(_ context.Context, key string) (*setting.Setting, error) {
+	return r.settings[key], nil
+}
+
+func TestPATService_PasswordChangeScope(t *testing.T) {
+	longAgo := time.Now().AddDate(0, 0, -100)
+	recently := time.Now().AddDate(0, 0, -1)
+	users := &stubUserQueryRepo{users: map[uint]*user.User{
+		1: {ID: 1, PasswordChangedAt: &recently},
+		2: {ID: 2, PasswordChangedAt: &recently, MustChangePassword: true},
+		3: {ID: 3, PasswordChangedAt: &longAgo},
+	}}
+	maxAge := &stubSettingQueryRepo{settings: map[string]*setting.Setting{
+		setting.KeyPasswordMaxAgeDays: {Key: setting.KeyPasswordMaxAgeDays, Value: "90", ValueType: setting.ValueTypeNumber},
+	}}
+
+	tests := []struct {
+		name     string
+		settings setting.QueryRepository
+		userID   uint
+		want     string
+	}{
+		{"无需修改密码", maxAge, 1, ""},
+		{"管理员重置密码", maxAge, 2, ScopePasswordChange},
+		{"密码已过期", maxAge, 3, ScopePasswordChange},
+		{"未配置最长有效期", nil, 3, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPATService(nil, nil, users, tt.settings, NewTokenGenerator(), nil)
+
+			scope, err := svc.PasswordChangeScope(context.Background(), tt.userID)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, scope)
+		})
+	}
+
+	t.Run("用户不存在", func(t *testing.T) {
+		svc := NewPATService(nil, nil, users, nil, NewTokenGenerator(), nil)
+
+		_, err := svc.PasswordChangeScope(context.Background(), 99)
+
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
+
+func TestPATService_ValidateBasicCredentials(t *testing.T) {
+	users := &stubUserQueryRepo{users: map[uint]*user.User{
+		1: {ID: 1, Username: "alice"},
+	}}
+	tokenGen := NewTokenGenerator()
+	plain, hash, prefix, err := tokenGen.GeneratePAT()
+	require.NoError(t, err)
+	patQuery := &stubPATQueryRepo{tokens: map[string]pat.PersonalAccessToken{
+		hash: {ID: 1, UserID: 1, Token: hash, TokenPrefix: prefix, Status: pat.StatusActive},
+	}}
+
+	tests := []struct {
+		name       string
+		username   string
+		wantErr    bool
+		wantLogins int
+	}{
+		{"用户名与令牌所属用户一致", "alice", false, 1},
+		{"用户名与令牌所属用户不一致时不记录登录", "bob", true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &capturingLoginRecorder{}
+			svc := NewPATService(&stubPATCommandRepo{}, patQuery, users, nil, tokenGen, recorder)
+
+			token, err := svc.ValidateBasicCredentials(context.Background(), tt.username, plain, "10.0.0.1")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, token)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, uint(1), token.UserID)
+			}
+			assert.Len(t, recorder.activities, tt.wantLogins)
+		})
+	}
+}
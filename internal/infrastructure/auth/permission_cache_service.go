@@ -6,8 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
+	domainAuth "github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 	"github.com/redis/go-redis/v9"
 )
@@ -16,72 +21,176 @@ import (
 type UserPermissions struct {
 	Roles       []string `json:"roles"`
 	Permissions []string `json:"permissions"`
+
+	// SessionsRevokedAt 会话吊销时间（仅全局权限缓存记录）
+	SessionsRevokedAt *time.Time `json:"sessions_revoked_at,omitempty"`
 }
 
 // PermissionCacheService 权限缓存服务
 // 使用 Redis 缓存用户权限信息，提升高并发场景下的性能
 // 缓存失效策略：5分钟 TTL + 权限变更时主动清除
+// 租户上下文中的权限按 (用户, 组织) 单独缓存，与全局权限互不覆盖
+// 全局权限包含用户直接持有的角色与所在用户组的角色
 type PermissionCacheService struct {
-	redis         *redis.Client
-	userQueryRepo user.QueryRepository
-	keyPrefix     string        // Redis key 前缀
-	cacheTTL      time.Duration // 缓存过期时间
+	redis          *redis.Client
+	userQueryRepo  user.QueryRepository
+	roleQueryRepo  role.QueryRepository
+	orgQueryRepo   organization.QueryRepository
+	groupQueryRepo group.QueryRepository
+	keyPrefix      string        // Redis key 前缀
+	cacheTTL       time.Duration // 缓存过期时间
 }
 
+var _ domainAuth.PermissionCacheInspector = (*PermissionCacheService)(nil)
+
 // NewPermissionCacheService 创建权限缓存服务
 func NewPermissionCacheService(
 	redisClient *redis.Client,
 	userQueryRepo user.QueryRepository,
+	roleQueryRepo role.QueryRepository,
+	orgQueryRepo organization.QueryRepository,
+	groupQueryRepo group.QueryRepository,
 	keyPrefix string,
 ) *PermissionCacheService {
 	return &PermissionCacheService{
-		redis:         redisClient,
-		userQueryRepo: userQueryRepo,
-		keyPrefix:     keyPrefix,
-		cacheTTL:      5 * time.Minute, // 5分钟过期
+		redis:          redisClient,
+		userQueryRepo:  userQueryRepo,
+		roleQueryRepo:  roleQueryRepo,
+		orgQueryRepo:   orgQueryRepo,
+		groupQueryRepo: groupQueryRepo,
+		keyPrefix:      keyPrefix,
+		cacheTTL:       5 * time.Minute, // 5分钟过期
 	}
 }
 
 // GetUserPermissions 获取用户权限（先查缓存，未命中则查数据库）
 func (s *PermissionCacheService) GetUserPermissions(ctx context.Context, userID uint) ([]string, []string, error) {
+	entry, err := s.getUserEntry(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entry.Roles, entry.Permissions, nil
+}
+
+// IsSessionRevoked 检查在 issuedAt 签发的令牌是否已随用户会话一并吊销
+// 与权限共用缓存，状态变更事件会清除缓存，使吊销立即生效
+func (s *PermissionCacheService) IsSessionRevoked(ctx context.Context, userID uint, issuedAt time.Time) (bool, error) {
+	entry, err := s.getUserEntry(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return entry.SessionsRevokedAt != nil && !issuedAt.After(*entry.SessionsRevokedAt), nil
+}
+
+// getUserEntry 获取用户的全局缓存条目（先查缓存，未命中则查数据库并回填）
+func (s *PermissionCacheService) getUserEntry(ctx context.Context, userID uint) (*UserPermissions, error) {
+	key := s.getCacheKey(userID)
+
 	// 1. 尝试从 Redis 读取缓存
-	cached, err := s.getFromCache(ctx, userID)
+	cached, err := s.getFromCache(ctx, key)
 	if err == nil && cached != nil {
-		return cached.Roles, cached.Permissions, nil
+		return cached, nil
 	}
 
 	// 2. 缓存未命中，查询数据库
 	u, err := s.userQueryRepo.GetByIDWithRoles(ctx, userID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	roles := u.GetRoleNames()
-	permissions := u.GetPermissionCodes()
+	entry := &UserPermissions{
+		Roles:             u.GetRoleNames(),
+		Permissions:       u.GetPermissionCodes(),
+		SessionsRevokedAt: u.SessionsRevokedAt,
+	}
 
 	// 3. 写入 Redis 缓存（异步写入，不阻塞请求）
-	// 使用 WithoutCancel 保留 trace 信息，配合独立超时
-	go func(cacheUserID uint, cacheRoles, cachePerms []string) {
-		cacheCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Second)
-		defer cancel()
+	s.setToCacheAsync(ctx, key, userID, entry)
 
-		if err := s.setToCache(cacheCtx, cacheUserID, cacheRoles, cachePerms); err != nil {
-			slog.Warn("Failed to cache user permissions",
-				"user_id", cacheUserID,
-				"error", err,
-			)
-		}
-	}(userID, roles, permissions)
+	return entry, nil
+}
+
+// GetMemberPermissions 获取用户在组织内的角色和权限（全局角色与组织内角色的并集）
+// 用户不是组织成员时返回 organization.ErrNotMember
+func (s *PermissionCacheService) GetMemberPermissions(ctx context.Context, organizationID, userID uint) ([]string, []string, error) {
+	key := s.getMemberCacheKey(organizationID, userID)
+
+	cached, err := s.getFromCache(ctx, key)
+	if err == nil && cached != nil {
+		return cached.Roles, cached.Permissions, nil
+	}
+
+	member, err := s.orgQueryRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	globalRoles, globalPermissions, err := s.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles := mergeUnique(globalRoles, member.GetRoleNames())
+	permissions := mergeUnique(globalPermissions, member.GetPermissionCodes())
+
+	s.setToCacheAsync(ctx, key, userID, &UserPermissions{Roles: roles, Permissions: permissions})
 
 	return roles, permissions, nil
 }
 
+// InspectPermissions 查看用户的权限缓存现状，不回填缓存
+// organizationID 为 0 时查看全局权限缓存
+func (s *PermissionCacheService) InspectPermissions(ctx context.Context, organizationID, userID uint) (*domainAuth.PermissionCacheEntry, error) {
+	key := s.getCacheKey(userID)
+	if organizationID != 0 {
+		key = s.getMemberCacheKey(organizationID, userID)
+	}
+
+	cached, err := s.getFromCache(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if cached == nil {
+		return &domainAuth.PermissionCacheEntry{}, nil
+	}
+
+	ttl, err := s.redis.TTL(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis ttl error: %w", err)
+	}
+
+	return &domainAuth.PermissionCacheEntry{
+		Cached:      true,
+		Roles:       cached.Roles,
+		Permissions: cached.Permissions,
+		TTL:         max(ttl, 0),
+	}, nil
+}
+
+// InvalidateMember 清除用户在指定组织内的权限缓存
+// 用于成员加入、移除及组织内角色变更场景
+func (s *PermissionCacheService) InvalidateMember(ctx context.Context, organizationID, userID uint) error {
+	if err := s.redis.Del(ctx, s.getMemberCacheKey(organizationID, userID)).Err(); err != nil {
+		slog.Warn("Failed to invalidate member cache",
+			"organization_id", organizationID,
+			"user_id", userID,
+			"error", err,
+		)
+		return err
+	}
+	return nil
+}
+
 // InvalidateUser 清除指定用户的权限缓存
 // 用于用户角色变更、用户状态变更等场景
+// 同时清除该用户在各组织内的权限缓存
 func (s *PermissionCacheService) InvalidateUser(ctx context.Context, userID uint) error {
-	key := s.getCacheKey(userID)
+	keys, err := s.userCacheKeys(ctx, userID)
+	if err != nil {
+		return err
+	}
 
-	if err := s.redis.Del(ctx, key).Err(); err != nil {
+	if err := s.redis.Del(ctx, keys...).Err(); err != nil {
 		slog.Warn("Failed to invalidate user cache",
 			"user_id", userID,
 			"error", err,
@@ -96,19 +205,70 @@ func (s *PermissionCacheService) InvalidateUser(ctx context.Context, userID uint
 	return nil
 }
 
-// InvalidateUsersWithRole 清除拥有指定角色的所有用户缓存
-// 用于角色权限变更场景（需要使所有拥有该角色的用户缓存失效）
+// InvalidateUsersWithRole 清除拥有指定角色或其后代角色的所有用户缓存
+// 用于角色权限变更场景（后代角色继承该角色的权限，其用户缓存同样需要失效）
 func (s *PermissionCacheService) InvalidateUsersWithRole(ctx context.Context, roleID uint) error {
-	// 查询所有拥有该角色的用户
-	userIDs, err := s.userQueryRepo.GetUserIDsByRole(ctx, roleID)
-	if err != nil {
-		return fmt.Errorf("failed to get users with role %d: %w", roleID, err)
+	roleIDs := []uint{roleID}
+	if s.roleQueryRepo != nil {
+		hierarchy, err := s.roleQueryRepo.GetHierarchy(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get role hierarchy: %w", err)
+		}
+		roleIDs = append(roleIDs, hierarchy.Descendants(roleID)...)
 	}
 
-	// 批量清除缓存
-	keys := make([]string, 0, len(userIDs))
-	for _, userID := range userIDs {
-		keys = append(keys, s.getCacheKey(userID))
+	// 查询所有拥有这些角色的用户
+	seen := make(map[uint]struct{})
+	var userIDs []uint
+	for _, id := range roleIDs {
+		ids, err := s.userQueryRepo.GetUserIDsByRole(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get users with role %d: %w", id, err)
+		}
+		for _, userID := range ids {
+			if _, ok := seen[userID]; !ok {
+				seen[userID] = struct{}{}
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+
+	// 组织内持有这些角色的成员
+	if s.orgQueryRepo != nil {
+		for _, id := range roleIDs {
+			ids, err := s.orgQueryRepo.GetUserIDsByRole(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get members with role %d: %w", id, err)
+			}
+			for _, userID := range ids {
+				if _, ok := seen[userID]; !ok {
+					seen[userID] = struct{}{}
+					userIDs = append(userIDs, userID)
+				}
+			}
+		}
+	}
+
+	// 经由用户组持有这些角色的用户
+	if s.groupQueryRepo != nil {
+		for _, id := range roleIDs {
+			ids, err := s.groupQueryRepo.GetUserIDsByRole(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get group users with role %d: %w", id, err)
+			}
+			for _, userID := range ids {
+				if _, ok := seen[userID]; !ok {
+					seen[userID] = struct{}{}
+					userIDs = append(userIDs, userID)
+				}
+			}
+		}
+	}
+
+	// 批量清除缓存（含各组织内的权限缓存）
+	keys, err := s.usersCacheKeys(ctx, userIDs)
+	if err != nil {
+		return err
 	}
 
 	if len(keys) > 0 {
@@ -130,6 +290,39 @@ func (s *PermissionCacheService) InvalidateUsersWithRole(ctx context.Context, ro
 	return nil
 }
 
+// InvalidateGroupMembers 清除用户组全部成员的权限缓存
+// 用于用户组角色变更场景
+func (s *PermissionCacheService) InvalidateGroupMembers(ctx context.Context, groupID uint) error {
+	userIDs, err := s.groupQueryRepo.GetMemberIDs(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group members: %w", err)
+	}
+
+	keys, err := s.usersCacheKeys(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := s.redis.Del(ctx, keys...).Err(); err != nil {
+		slog.Warn("Failed to invalidate group members cache",
+			"group_id", groupID,
+			"user_count", len(userIDs),
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("Group members permissions cache invalidated",
+		"group_id", groupID,
+		"user_count", len(userIDs),
+	)
+
+	return nil
+}
+
 // InvalidateAllUsers 清除所有用户权限缓存（慎用，仅用于全局权限系统变更）
 func (s *PermissionCacheService) InvalidateAllUsers(ctx context.Context) error {
 	pattern := s.keyPrefix + "user:perms:*"
@@ -161,9 +354,7 @@ func (s *PermissionCacheService) InvalidateAllUsers(ctx context.Context) error {
 }
 
 // getFromCache 从 Redis 读取缓存
-func (s *PermissionCacheService) getFromCache(ctx context.Context, userID uint) (*UserPermissions, error) {
-	key := s.getCacheKey(userID)
-
+func (s *PermissionCacheService) getFromCache(ctx context.Context, key string) (*UserPermissions, error) {
 	data, err := s.redis.Get(ctx, key).Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -182,16 +373,26 @@ func (s *PermissionCacheService) getFromCache(ctx context.Context, userID uint)
 	return &perms, nil
 }
 
-// setToCache 写入 Redis 缓存
-func (s *PermissionCacheService) setToCache(ctx context.Context, userID uint, roles, permissions []string) error {
-	key := s.getCacheKey(userID)
+// setToCacheAsync 异步写入缓存，不阻塞请求
+// 使用 WithoutCancel 保留 trace 信息，配合独立超时
+func (s *PermissionCacheService) setToCacheAsync(ctx context.Context, key string, userID uint, entry *UserPermissions) {
+	go func() {
+		cacheCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Second)
+		defer cancel()
 
-	perms := UserPermissions{
-		Roles:       roles,
-		Permissions: permissions,
-	}
+		if err := s.setToCache(cacheCtx, key, entry); err != nil {
+			slog.Warn("Failed to cache user permissions",
+				"user_id", userID,
+				"key", key,
+				"error", err,
+			)
+		}
+	}()
+}
 
-	data, err := json.Marshal(perms)
+// setToCache 写入 Redis 缓存
+func (s *PermissionCacheService) setToCache(ctx context.Context, key string, entry *UserPermissions) error {
+	data, err := json.Marshal(entry)
 	if err != nil {
 		return fmt.Errorf("failed to marshal permissions: %w", err)
 	}
@@ -203,7 +404,53 @@ func (s *PermissionCacheService) setToCache(ctx context.Context, userID uint, ro
 	return nil
 }
 
+// userCacheKeys 返回用户的全局权限缓存 key 及其在各组织内的权限缓存 key
+func (s *PermissionCacheService) userCacheKeys(ctx context.Context, userID uint) ([]string, error) {
+	keys := []string{s.getCacheKey(userID)}
+
+	iter := s.redis.Scan(ctx, 0, s.getCacheKey(userID)+":org:*", 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan member cache keys: %w", err)
+	}
+	return keys, nil
+}
+
+// usersCacheKeys 返回多个用户的全部权限缓存 key
+func (s *PermissionCacheService) usersCacheKeys(ctx context.Context, userIDs []uint) ([]string, error) {
+	keys := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		userKeys, err := s.userCacheKeys(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, userKeys...)
+	}
+	return keys, nil
+}
+
 // getCacheKey 生成 Redis 缓存 key
 func (s *PermissionCacheService) getCacheKey(userID uint) string {
 	return fmt.Sprintf("%suser:perms:%d", s.keyPrefix, userID)
 }
+
+// getMemberCacheKey 生成用户在组织内的权限缓存 key
+func (s *PermissionCacheService) getMemberCacheKey(organizationID, userID uint) string {
+	return fmt.Sprintf("%s:org:%d", s.getCacheKey(userID), organizationID)
+}
+
+// mergeUnique 合并两个字符串列表并去重，保持首次出现的顺序
+func mergeUnique(a, b []string) []string {
+	seen := make(map[string]struct{}, len(a)+len(b))
+	result := make([]string, 0, len(a)+len(b))
+	for _, v := range slices.Concat(a, b) {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+	return result
+}
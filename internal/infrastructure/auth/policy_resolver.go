@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// PolicyResolver 基于角色权限授予解析 ABAC 策略
+// 主体属性取自用户实体，授予沿角色继承链向上收集；
+// 租户上下文中同时收集用户在该组织内持有的角色，与权限缓存和授权解释一致
+type PolicyResolver struct {
+	userQueryRepo user.QueryRepository
+	roleQueryRepo role.QueryRepository
+	orgQueryRepo  organization.QueryRepository
+}
+
+var _ policy.Resolver = (*PolicyResolver)(nil)
+
+// NewPolicyResolver 创建策略解析器
+func NewPolicyResolver(userQueryRepo user.QueryRepository, roleQueryRepo role.QueryRepository, orgQueryRepo organization.QueryRepository) *PolicyResolver {
+	return &PolicyResolver{
+		userQueryRepo: userQueryRepo,
+		roleQueryRepo: roleQueryRepo,
+		orgQueryRepo:  orgQueryRepo,
+	}
+}
+
+// Resolve 解析用户在指定权限上的授权策略
+//
+// 任一覆盖该权限的授予未附加条件时不做限制；否则仅附加条件满足时允许访问。
+// 没有任何角色授予覆盖该权限时按纯 RBAC 结果拒绝：决策不含策略且不是 Unrestricted，
+// Allows 恒为 false，Filter 不匹配任何资源。
+func (r *PolicyResolver) Resolve(ctx context.Context, userID uint, permission string) (*policy.Decision, error) {
+	u, err := r.userQueryRepo.GetByIDWithRoles(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	hierarchy, err := r.roleQueryRepo.GetHierarchy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role hierarchy: %w", err)
+	}
+
+	held, err := r.heldRoles(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]struct{})
+	var roleIDs []uint
+	for _, ur := range held {
+		for _, id := range append([]uint{ur.ID}, hierarchy.Ancestors(ur.ID)...) {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				roleIDs = append(roleIDs, id)
+			}
+		}
+	}
+
+	grants, err := r.roleQueryRepo.GetGrants(ctx, roleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get grants: %w", err)
+	}
+
+	decision := &policy.Decision{Subject: u.PolicyAttributes()}
+	for _, g := range grants {
+		if !g.Covers(permission) {
+			continue
+		}
+		if !g.IsConditional() {
+			decision.Unrestricted = true
+			return decision, nil
+		}
+
+		p, err := policy.Parse(g.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("invalid condition on role %d permission %s: %w", g.RoleID, g.PermissionCode, err)
+		}
+		decision.Policies = append(decision.Policies, p)
+	}
+
+	return decision, nil
+}
+
+// heldRoles 返回用户持有的角色：直接角色、用户组角色，以及租户上下文中的组织内角色
+func (r *PolicyResolver) heldRoles(ctx context.Context, u *user.User) ([]role.Role, error) {
+	roles := u.EffectiveRoles()
+
+	organizationID, ok := organization.TenantFromContext(ctx)
+	if !ok || r.orgQueryRepo == nil {
+		return roles, nil
+	}
+	member, err := r.orgQueryRepo.GetMember(ctx, organizationID, u.ID)
+	if err != nil {
+		if errors.Is(err, organization.ErrNotMember) {
+			return roles, nil
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return slices.Concat(roles, member.Roles), nil
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// stubRoleQueryRepo 返回预置的继承关系与权限授予
+type stubRoleQueryRepo struct {
+	role.QueryRepository
+
+	hierarchy role.Hierarchy
+	grants    []role.Grant
+}
+
+func (r *stubRoleQueryRepo) GetHierarchy(context.Context) (role.Hierarchy, error) {
+	return r.hierarchy, nil
+}
+
+func (r *stubRoleQueryRepo) GetGrants(_ context.Context, roleIDs []uint) ([]role.Grant, error) {
+	var grants []role.Grant
+	for _, g := range r.grants {
+		if slices.Contains(roleIDs, g.RoleID) {
+			grants = append(grants, g)
+		}
+	}
+	return grants, nil
+}
+
+// stubOrgQueryRepo 按 (组织, 用户) 返回预置成员
+type stubOrgQueryRepo struct {
+	organization.QueryRepository
+
+	members map[uint]map[uint]*organization.Member
+}
+
+func (r *stubOrgQueryRepo) GetMember(_ context.Context, organizationID, userID uint) (*organization.Member, error) {
+	if m, ok := r.members[organizationID][userID]; ok {
+		return m, nil
+	}
+	return nil, organization.ErrNotMember
+}
+
+func TestPolicyResolver_Resolve(t *testing.T) {
+	// 角色：1 auditor（无条件）、2 self-auditor（附加条件）、3 junior 继承自 1、4 ops（通配符）、5 org-auditor（组织内角色）
+	users := &stubUserQueryRepo{users: map[uint]*user.User{
+		1: {ID: 1, Roles: []role.Role{{ID: 1}}},
+		2: {ID: 2, Roles: []role.Role{{ID: 2}}},
+		3: {ID: 3, Roles: []role.Role{{ID: 3}}},
+		4: {ID: 4, Roles: []role.Role{{ID: 4}}},
+		5: {ID: 5, Roles: []role.Role{{ID: 6}}},
+	}}
+	roles := &stubRoleQueryRepo{
+		hierarchy: role.Hierarchy{3: 1},
+		grants: []role.Grant{
+			{RoleID: 1, PermissionCode: "admin:audit:read"},
+			{RoleID: 2, PermissionCode: "admin:audit:read", Condition: "resource.user_id == subject.id"},
+			{RoleID: 4, PermissionCode: "admin:*:*"},
+			{RoleID: 5, PermissionCode: "admin:audit:read"},
+			{RoleID: 6, PermissionCode: "user:profile:read"},
+		},
+	}
+	orgs := &stubOrgQueryRepo{members: map[uint]map[uint]*organization.Member{
+		7: {5: {OrganizationID: 7, UserID: 5, Roles: []role.Role{{ID: 5}}}},
+	}}
+	resolver := NewPolicyResolver(users, roles, orgs)
+	tenant := organization.WithTenant(context.Background(), 7)
+	otherTenant := organization.WithTenant(context.Background(), 8)
+
+	own := policy.Attributes{"user_id": uint(2)}
+	others := policy.Attributes{"user_id": uint(9)}
+
+	tests := []struct {
+		name             string
+		ctx              context.Context
+		userID           uint
+		wantUnrestricted bool
+		wantPolicies     int
+		allowsOwn        bool
+		allowsOthers     bool
+	}{
+		{"无条件授予", context.Background(), 1, true, 0, true, true},
+		{"附加条件的授予", context.Background(), 2, false, 1, true, false},
+		{"经由父角色继承的授予", context.Background(), 3, true, 0, true, true},
+		{"通配符授予", context.Background(), 4, true, 0, true, true},
+		{"没有角色授予覆盖该权限时拒绝", context.Background(), 5, false, 0, false, false},
+		{"租户上下文中收集组织内角色", tenant, 5, true, 0, true, true},
+		{"非组织成员仅使用全局角色", otherTenant, 5, false, 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := resolver.Resolve(tt.ctx, tt.userID, "admin:audit:read")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUnrestricted, decision.Unrestricted)
+			assert.Len(t, decision.Policies, tt.wantPolicies)
+			assert.Equal(t, tt.allowsOwn, decision.Allows(own))
+			assert.Equal(t, tt.allowsOthers, decision.Allows(others))
+
+			filter, err := decision.Filter()
+			require.NoError(t, err)
+			assert.Equal(t, !tt.allowsOwn && !tt.allowsOthers, filter.MatchesNothing())
+		})
+	}
+}
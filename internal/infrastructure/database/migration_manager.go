@@ -42,7 +42,10 @@ func (m *MigrationManager) Up() error {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
-	// 2. 执行所有模型的迁移
+	// 2. 删除已废弃的旧索引，再执行所有模型的迁移
+	if err := dropLegacyIndexes(m.db, m.models); err != nil {
+		return err
+	}
 	if err := m.db.AutoMigrate(m.models...); err != nil {
 		return fmt.Errorf("failed to migrate models: %w", err)
 	}
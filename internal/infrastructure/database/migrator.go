@@ -27,6 +27,10 @@ func (m *Migrator) AutoMigrate(models ...any) error {
 
 	slog.Info("Starting database migration", "model_count", len(models))
 
+	if err := dropLegacyIndexes(m.db, models); err != nil {
+		return err
+	}
+
 	if err := m.db.AutoMigrate(models...); err != nil {
 		return fmt.Errorf("auto migration failed: %w", err)
 	}
@@ -35,6 +39,33 @@ func (m *Migrator) AutoMigrate(models ...any) error {
 	return nil
 }
 
+// LegacyIndexer 由需要在迁移前删除旧索引的模型实现
+// 适用于索引定义变更（如改为部分唯一索引）而 AutoMigrate 不会删除旧索引的场景
+type LegacyIndexer interface {
+	LegacyIndexes() []string
+}
+
+// dropLegacyIndexes 删除模型声明的旧索引，索引不存在时跳过
+func dropLegacyIndexes(db *gorm.DB, models []any) error {
+	migrator := db.Migrator()
+	for _, model := range models {
+		indexer, ok := model.(LegacyIndexer)
+		if !ok || !migrator.HasTable(model) {
+			continue
+		}
+		for _, name := range indexer.LegacyIndexes() {
+			if !migrator.HasIndex(model, name) {
+				continue
+			}
+			if err := migrator.DropIndex(model, name); err != nil {
+				return fmt.Errorf("failed to drop legacy index %s: %w", name, err)
+			}
+			slog.Info("Legacy index dropped", "index", name)
+		}
+	}
+	return nil
+}
+
 // DropTables 删除表 (谨慎使用！)
 func (m *Migrator) DropTables(models ...any) error {
 	if len(models) == 0 {
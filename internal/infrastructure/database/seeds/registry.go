@@ -1,12 +1,16 @@
 package seeds
 
-import "github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/database"
+import (
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/database"
+)
 
 // DefaultSeeders returns the default ordered seeders that bootstrap the system.
 // Keep RBAC first because it provisions permissions/roles required by other seeders.
-func DefaultSeeders() []database.Seeder {
+// permissions 为路由声明的权限定义，见 http.CollectRoutePermissions。
+func DefaultSeeders(permissions []role.PermissionDefinition) []database.Seeder {
 	return []database.Seeder{
-		&RBACSeeder{},
+		&RBACSeeder{Permissions: permissions},
 		&UserSeeder{},
 		&SettingSeeder{},
 	}
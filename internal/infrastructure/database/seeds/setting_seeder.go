@@ -24,15 +24,27 @@ func (s *SettingSeeder) Seed(ctx context.Context, db *gorm.DB) error {
 		{Key: "general.timezone", Value: "Asia/Shanghai", Category: "general", ValueType: "string", Label: "时区"},
 		{Key: "general.language", Value: "zh-CN", Category: "general", ValueType: "string", Label: "语言"},
 		{Key: "general.theme", Value: "light", Category: "general", ValueType: "string", Label: "主题"},
+		{Key: "general.trash_retention_days", Value: "30", Category: "general", ValueType: "number", Label: "回收站保留期（天）"},
 		// Security 安全设置
 		{Key: "security.session_timeout", Value: "30", Category: "security", ValueType: "number", Label: "会话超时时间"},
 		{Key: "security.password_min_length", Value: "8", Category: "security", ValueType: "number", Label: "密码最小长度"},
 		{Key: "security.enable_twofa", Value: "false", Category: "security", ValueType: "boolean", Label: "强制启用两步验证"},
 		{Key: "security.max_login_attempts", Value: "5", Category: "security", ValueType: "number", Label: "最大登录尝试次数"},
+		{Key: "security.registration_mode", Value: "open", Category: "security", ValueType: "string", Label: "注册模式"},
+		{Key: "security.registration_email_domains", Value: "[]", Category: "security", ValueType: "json", Label: "允许注册的邮箱域名"},
+		{Key: "security.password_max_age_days", Value: "0", Category: "security", ValueType: "number", Label: "密码最长有效期（天）"},
+		{Key: "security.account_deletion_grace_days", Value: "14", Category: "security", ValueType: "number", Label: "账号删除宽限期（天）"},
+		{Key: "security.inactive_account_days", Value: "0", Category: "security", ValueType: "number", Label: "不活跃账号自动停用（天）"},
 		// Notification 通知设置
 		{Key: "notification.enable_notifications", Value: "true", Category: "notification", ValueType: "boolean", Label: "启用系统通知"},
 		{Key: "notification.enable_email", Value: "true", Category: "notification", ValueType: "boolean", Label: "启用邮件通知"},
 		{Key: "notification.enable_sms", Value: "false", Category: "notification", ValueType: "boolean", Label: "启用短信通知"},
+		// Preference 用户偏好默认值（用户可覆盖）
+		{Key: "preference.locale", Value: "zh-CN", Category: "preference", ValueType: "string", Label: "语言"},
+		{Key: "preference.timezone", Value: "Asia/Shanghai", Category: "preference", ValueType: "string", Label: "时区"},
+		{Key: "preference.theme", Value: "light", Category: "preference", ValueType: "string", Label: "主题"},
+		{Key: "preference.notify_security_email", Value: "true", Category: "preference", ValueType: "boolean", Label: "接收安全提醒邮件"},
+		{Key: "preference.notify_product_email", Value: "false", Category: "preference", ValueType: "boolean", Label: "接收产品动态邮件"},
 		// Backup 备份设置
 		{Key: "backup.enable_backup", Value: "false", Category: "backup", ValueType: "boolean", Label: "启用自动备份"},
 		{Key: "backup.backup_frequency", Value: "24", Category: "backup", ValueType: "number", Label: "备份频率"},
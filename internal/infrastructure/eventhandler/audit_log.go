@@ -2,6 +2,7 @@ package eventhandler
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
@@ -39,6 +40,8 @@ func (h *AuditLogHandler) Handle(ctx context.Context, e event.Event) error {
 		return h.handleUserDeleted(ctx, evt)
 	case *events.UserRoleAssignedEvent:
 		return h.handleUserRoleAssigned(ctx, evt)
+	case *events.UserStatusChangedEvent:
+		return h.handleUserStatusChanged(ctx, evt)
 	case *events.RolePermissionsChangedEvent:
 		return h.handleRolePermissionsChanged(ctx, evt)
 	default:
@@ -137,6 +140,25 @@ func (h *AuditLogHandler) handleUserRoleAssigned(ctx context.Context, evt *event
 	return h.createAuditLog(ctx, log, "user_role_assigned")
 }
 
+// handleUserStatusChanged 处理用户状态变更事件
+func (h *AuditLogHandler) handleUserStatusChanged(ctx context.Context, evt *events.UserStatusChangedEvent) error {
+	details := fmt.Sprintf("%s -> %s", evt.OldStatus, evt.NewStatus)
+	if evt.Reason != "" {
+		details += ": " + evt.Reason
+	}
+
+	log := &auditlog.AuditLog{
+		UserID:     evt.OperatorID,
+		Action:     "change_status",
+		Resource:   "user",
+		ResourceID: evt.AggregateID(),
+		Details:    details,
+		Status:     "success",
+	}
+
+	return h.createAuditLog(ctx, log, "user_status_changed")
+}
+
 // handleRolePermissionsChanged 处理角色权限变更事件
 func (h *AuditLogHandler) handleRolePermissionsChanged(ctx context.Context, evt *events.RolePermissionsChangedEvent) error {
 	log := &auditlog.AuditLog{
@@ -11,7 +11,7 @@ import (
 )
 
 // CacheInvalidationHandler 缓存失效处理器
-// 处理角色权限变更和用户角色分配事件，自动失效相关缓存
+// 处理角色权限变更、用户角色分配、用户状态变更和用户组变更事件，自动失效相关缓存
 type CacheInvalidationHandler struct {
 	permissionCache *auth.PermissionCacheService
 	userQueryRepo   user.QueryRepository
@@ -39,6 +39,14 @@ func (h *CacheInvalidationHandler) Handle(ctx context.Context, e event.Event) er
 		return h.handleRolePermissionsChanged(ctx, evt)
 	case *events.UserDeletedEvent:
 		return h.handleUserDeleted(ctx, evt)
+	case *events.UserStatusChangedEvent:
+		return h.handleUserStatusChanged(ctx, evt)
+	case *events.OrganizationMemberChangedEvent:
+		return h.handleOrganizationMemberChanged(ctx, evt)
+	case *events.GroupMembershipChangedEvent:
+		return h.handleGroupMembershipChanged(ctx, evt)
+	case *events.GroupRolesChangedEvent:
+		return h.handleGroupRolesChanged(ctx, evt)
 	default:
 		// 忽略不处理的事件
 		return nil
@@ -66,39 +74,21 @@ func (h *CacheInvalidationHandler) handleUserRoleAssigned(ctx context.Context, e
 }
 
 // handleRolePermissionsChanged 处理角色权限变更事件
-// 失效拥有该角色的所有用户的权限缓存
+// 失效拥有该角色及其后代角色的所有用户的权限缓存
 func (h *CacheInvalidationHandler) handleRolePermissionsChanged(ctx context.Context, evt *events.RolePermissionsChangedEvent) error {
 	h.logger.Info("invalidating permission cache for role",
 		"event", evt.EventName(),
 		"role_id", evt.RoleID,
 	)
 
-	// 获取拥有该角色的所有用户
-	userIDs, err := h.userQueryRepo.GetUserIDsByRole(ctx, evt.RoleID)
-	if err != nil {
-		h.logger.Error("failed to get users by role",
+	if err := h.permissionCache.InvalidateUsersWithRole(ctx, evt.RoleID); err != nil {
+		h.logger.Error("failed to invalidate permission cache for role",
 			"role_id", evt.RoleID,
 			"error", err,
 		)
-		return nil
-	}
-
-	// 逐个失效用户缓存
-	for _, userID := range userIDs {
-		if err := h.permissionCache.InvalidateUser(ctx, userID); err != nil {
-			h.logger.Error("failed to invalidate user permission cache",
-				"user_id", userID,
-				"error", err,
-			)
-			// 继续处理其他用户
-		}
+		// 缓存失效失败不应该阻塞业务流程，只记录错误
 	}
 
-	h.logger.Info("invalidated permission cache for users",
-		"role_id", evt.RoleID,
-		"user_count", len(userIDs),
-	)
-
 	return nil
 }
 
@@ -120,5 +110,84 @@ func (h *CacheInvalidationHandler) handleUserDeleted(ctx context.Context, evt *e
 	return nil
 }
 
+// handleUserStatusChanged 处理用户状态变更事件
+// 失效用户缓存，使认证时重新读取会话吊销时间
+func (h *CacheInvalidationHandler) handleUserStatusChanged(ctx context.Context, evt *events.UserStatusChangedEvent) error {
+	h.logger.Info("invalidating cache for user status change",
+		"event", evt.EventName(),
+		"user_id", evt.UserID,
+		"new_status", evt.NewStatus,
+	)
+
+	if err := h.permissionCache.InvalidateUser(ctx, evt.UserID); err != nil {
+		h.logger.Error("failed to invalidate user cache after status change",
+			"user_id", evt.UserID,
+			"error", err,
+		)
+	}
+
+	return nil
+}
+
+// handleOrganizationMemberChanged 处理组织成员变更事件
+// 失效成员在该组织内的权限缓存
+func (h *CacheInvalidationHandler) handleOrganizationMemberChanged(ctx context.Context, evt *events.OrganizationMemberChangedEvent) error {
+	h.logger.Info("invalidating permission cache for organization member",
+		"event", evt.EventName(),
+		"organization_id", evt.OrganizationID,
+		"user_id", evt.UserID,
+	)
+
+	if err := h.permissionCache.InvalidateMember(ctx, evt.OrganizationID, evt.UserID); err != nil {
+		h.logger.Error("failed to invalidate member permission cache",
+			"organization_id", evt.OrganizationID,
+			"user_id", evt.UserID,
+			"error", err,
+		)
+	}
+
+	return nil
+}
+
+// handleGroupMembershipChanged 处理用户组成员变更事件
+// 失效加入或离开用户组的用户的权限缓存
+func (h *CacheInvalidationHandler) handleGroupMembershipChanged(ctx context.Context, evt *events.GroupMembershipChangedEvent) error {
+	h.logger.Info("invalidating permission cache for group members",
+		"event", evt.EventName(),
+		"group_id", evt.GroupID,
+		"user_count", len(evt.UserIDs),
+	)
+
+	for _, userID := range evt.UserIDs {
+		if err := h.permissionCache.InvalidateUser(ctx, userID); err != nil {
+			h.logger.Error("failed to invalidate group member permission cache",
+				"group_id", evt.GroupID,
+				"user_id", userID,
+				"error", err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// handleGroupRolesChanged 处理用户组角色变更事件
+// 失效该用户组全部成员的权限缓存
+func (h *CacheInvalidationHandler) handleGroupRolesChanged(ctx context.Context, evt *events.GroupRolesChangedEvent) error {
+	h.logger.Info("invalidating permission cache for group",
+		"event", evt.EventName(),
+		"group_id", evt.GroupID,
+	)
+
+	if err := h.permissionCache.InvalidateGroupMembers(ctx, evt.GroupID); err != nil {
+		h.logger.Error("failed to invalidate permission cache for group",
+			"group_id", evt.GroupID,
+			"error", err,
+		)
+	}
+
+	return nil
+}
+
 // Ensure interface is implemented
 var _ event.EventHandler = (*CacheInvalidationHandler)(nil)
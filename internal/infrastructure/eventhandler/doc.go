@@ -10,6 +10,8 @@
 //   - user.role_assigned: 用户权限缓存失效
 //   - user.deleted: 删除用户的权限缓存
 //   - role.permissions_changed: 拥有该角色的所有用户权限缓存失效
+//   - group.membership_changed: 加入或离开用户组的用户权限缓存失效
+//   - group.roles_changed: 用户组全部成员的权限缓存失效
 //
 // # 审计日志
 //
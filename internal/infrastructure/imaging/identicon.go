@@ -0,0 +1,101 @@
+package imaging
+
+import (
+	"crypto/sha256"
+	"image"
+	"image/color"
+)
+
+// identiconGrid identicon 的格子数（左右对称）
+const identiconGrid = 5
+
+// identiconBackground identicon 背景色
+var identiconBackground = color.NRGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+
+// Identicon 根据种子生成确定性的对称像素头像（PNG）
+//
+// 种子的 SHA-256 摘要决定前景色与 5×5 网格中左半部分（含中列）的填充，
+// 右半部分镜像左半部分；相同种子与尺寸总是生成相同的图片。
+func Identicon(seed string, size int) ([]byte, error) {
+	sum := sha256.Sum256([]byte(seed))
+	fg := identiconColor(sum[0], sum[1])
+
+	// 四周各留半格边距
+	cell := max(size/(identiconGrid+1), 1)
+	offset := (size - cell*identiconGrid) / 2
+
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	fill(img, img.Bounds(), identiconBackground)
+
+	half := (identiconGrid + 1) / 2
+	for row := range identiconGrid {
+		for col := range half {
+			// 从摘要第 2 字节开始，每格取一个比特
+			bit := row*half + col
+			if sum[2+bit/8]>>(bit%8)&1 == 0 {
+				continue
+			}
+			for _, c := range []int{col, identiconGrid - 1 - col} {
+				x := offset + c*cell
+				y := offset + row*cell
+				fill(img, image.Rect(x, y, x+cell, y+cell), fg)
+			}
+		}
+	}
+
+	return EncodePNG(img)
+}
+
+// identiconColor 由摘要字节生成饱和度与亮度适中的前景色
+func identiconColor(hueByte, lightByte byte) color.NRGBA {
+	h := float64(hueByte) / 256 * 360
+	l := 0.45 + float64(lightByte%20)/100
+	return hslToRGB(h, 0.55, l)
+}
+
+// hslToRGB 将 HSL 转换为 RGB
+func hslToRGB(h, s, l float64) color.NRGBA {
+	c := (1 - abs(2*l-1)) * s
+	hp := h / 60
+	x := c * (1 - abs(mod2(hp)-1))
+
+	var r, g, b float64
+	switch {
+	case hp < 1:
+		r, g = c, x
+	case hp < 2:
+		r, g = x, c
+	case hp < 3:
+		g, b = c, x
+	case hp < 4:
+		g, b = x, c
+	case hp < 5:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+
+	m := l - c/2
+	return color.NRGBA{R: uint8((r + m) * 255), G: uint8((g + m) * 255), B: uint8((b + m) * 255), A: 0xff}
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// mod2 返回 v 对 2 取模（v >= 0）
+func mod2(v float64) float64 {
+	return v - 2*float64(int(v/2))
+}
+
+// fill 用纯色填充矩形区域
+func fill(img *image.NRGBA, rect image.Rectangle, c color.NRGBA) {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Package imaging 提供头像图片的校验、裁剪缩放与默认头像（identicon）生成。
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+
+	// 注册 GIF/JPEG 解码器
+	_ "image/gif"
+	_ "image/jpeg"
+
+	"golang.org/x/image/draw"
+)
+
+// MaxPixels 允许解码的最大像素数，防止解压炸弹耗尽内存
+const MaxPixels = 40_000_000
+
+// ContentTypePNG 处理后图片的格式
+const ContentTypePNG = "image/png"
+
+// SupportedContentTypes 允许上传的图片类型
+var SupportedContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+var (
+	// ErrUnsupportedType 图片类型不受支持
+	ErrUnsupportedType = errors.New("unsupported image type")
+	// ErrInvalidImage 图片无法解码或尺寸超限
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// DetectContentType 按文件内容识别图片类型，不受支持时返回 ErrUnsupportedType
+func DetectContentType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	for _, t := range SupportedContentTypes {
+		if contentType == t {
+			return contentType, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+}
+
+// Decode 校验类型与尺寸后解码图片
+func Decode(data []byte) (image.Image, error) {
+	if _, err := DetectContentType(data); err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d exceeds size limit", ErrInvalidImage, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+	}
+	return img, nil
+}
+
+// CropSquare 以中心为基准裁剪为正方形
+func CropSquare(img image.Image) image.Image {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	rect := image.Rect(x0, y0, x0+side, y0+side)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(dst, dst.Bounds(), img, rect.Min, draw.Src)
+	return dst
+}
+
+// Resize 将正方形图片缩放到 size×size
+func Resize(img image.Image, size int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return dst
+}
+
+// SquareSizes 中心裁剪后按各尺寸缩放并编码为 PNG，返回尺寸到图片内容的映射
+func SquareSizes(img image.Image, sizes []int) (map[int][]byte, error) {
+	square := CropSquare(img)
+
+	result := make(map[int][]byte, len(sizes))
+	for _, size := range sizes {
+		data, err := EncodePNG(Resize(square, size))
+		if err != nil {
+			return nil, err
+		}
+		result[size] = data
+	}
+	return result, nil
+}
+
+// EncodePNG 编码为 PNG
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testImage 生成左半红、右半蓝的 w×h 图片
+func testImage(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.NRGBA{R: 0xff, A: 0xff}
+			if x >= w/2 {
+				c = color.NRGBA{B: 0xff, A: 0xff}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDecode(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(40, 20), nil))
+
+	img, err := Decode(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 40, img.Bounds().Dx())
+
+	_, err = Decode([]byte("<svg xmlns='http://www.w3.org/2000/svg'></svg>"))
+	require.ErrorIs(t, err, ErrUnsupportedType)
+
+	// PNG 头合法但内容被截断
+	buf.Reset()
+	require.NoError(t, png.Encode(&buf, testImage(40, 20)))
+	_, err = Decode(buf.Bytes()[:60])
+	require.ErrorIs(t, err, ErrInvalidImage)
+}
+
+func TestSquareSizes(t *testing.T) {
+	sizes, err := SquareSizes(testImage(300, 100), []int{64, 32})
+	require.NoError(t, err)
+	require.Len(t, sizes, 2)
+
+	img, err := png.Decode(bytes.NewReader(sizes[64]))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 64, 64), img.Bounds())
+
+	// 中心裁剪 300×100 的中间 100×100：左半红、右半蓝
+	r, _, b, _ := img.At(5, 32).RGBA()
+	assert.Greater(t, r, b)
+	r, _, b, _ = img.At(58, 32).RGBA()
+	assert.Greater(t, b, r)
+}
+
+func TestIdenticon(t *testing.T) {
+	a, err := Identicon("user:1", 120)
+	require.NoError(t, err)
+	again, err := Identicon("user:1", 120)
+	require.NoError(t, err)
+	other, err := Identicon("user:2", 120)
+	require.NoError(t, err)
+
+	assert.Equal(t, a, again, "相同种子生成相同图片")
+	assert.NotEqual(t, a, other)
+
+	img, err := png.Decode(bytes.NewReader(a))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 120, 120), img.Bounds())
+
+	// 120 像素时每格 20 像素、边距 10 像素，图案左右对称
+	for y := 0; y < 120; y += 7 {
+		for x := range 60 {
+			assert.Equal(t, img.At(x, y), img.At(119-x, y))
+		}
+	}
+}
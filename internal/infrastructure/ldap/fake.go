@@ -0,0 +1,160 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// FakeDirectory 进程内的目录实现，用于测试和本地开发
+//
+// 仅支持简单绑定和由等值条件组成的 AND 过滤器（如 (&(objectClass=person)(uid=alice))）。
+type FakeDirectory struct {
+	mu      sync.RWMutex
+	entries map[string]*fakeEntry // 小写 DN -> 条目
+}
+
+type fakeEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+// NewFakeDirectory 创建空的进程内目录
+func NewFakeDirectory() *FakeDirectory {
+	return &FakeDirectory{entries: make(map[string]*fakeEntry)}
+}
+
+// AddEntry 添加或替换条目，password 为空时该条目无法绑定
+func (d *FakeDirectory) AddEntry(dn, password string, attributes map[string][]string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries[strings.ToLower(dn)] = &fakeEntry{dn: dn, password: password, attributes: attributes}
+}
+
+// RemoveEntry 删除条目
+func (d *FakeDirectory) RemoveEntry(dn string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.entries, strings.ToLower(dn))
+}
+
+// Dialer 返回连接到该目录的 [Dialer]
+func (d *FakeDirectory) Dialer() Dialer {
+	return func(context.Context) (Conn, error) {
+		return &fakeConn{dir: d}, nil
+	}
+}
+
+// fakeConn 进程内目录连接
+type fakeConn struct {
+	dir    *FakeDirectory
+	closed bool
+}
+
+func (c *fakeConn) Bind(username, password string) error {
+	if c.closed {
+		return errors.New("connection closed")
+	}
+	c.dir.mu.RLock()
+	defer c.dir.mu.RUnlock()
+
+	e, ok := c.dir.entries[strings.ToLower(username)]
+	if !ok || e.password == "" || e.password != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	return nil
+}
+
+// equalityTerm 匹配过滤器中的 (attr=value) 等值条件
+var equalityTerm = regexp.MustCompile(`\(([A-Za-z][A-Za-z0-9-]*)=([^()]*)\)`)
+
+func (c *fakeConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	if c.closed {
+		return nil, errors.New("connection closed")
+	}
+	c.dir.mu.RLock()
+	defer c.dir.mu.RUnlock()
+
+	terms := equalityTerm.FindAllStringSubmatch(req.Filter, -1)
+	baseDN := strings.ToLower(req.BaseDN)
+
+	result := &ldap.SearchResult{}
+	for key, e := range c.dir.entries {
+		if baseDN != "" && !strings.HasSuffix(key, baseDN) {
+			continue
+		}
+		if !e.matches(terms) {
+			continue
+		}
+		result.Entries = append(result.Entries, ldap.NewEntry(e.dn, e.selectAttributes(req.Attributes)))
+		if req.SizeLimit > 0 && len(result.Entries) >= req.SizeLimit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (c *fakeConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+// matches 所有等值条件都满足时匹配（属性名和值均不区分大小写）
+func (e *fakeEntry) matches(terms [][]string) bool {
+	for _, term := range terms {
+		value := unescapeFilterValue(term[2])
+		found := false
+		for name, values := range e.attributes {
+			if !strings.EqualFold(name, term[1]) {
+				continue
+			}
+			for _, v := range values {
+				if strings.EqualFold(v, value) {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// selectAttributes 返回请求的属性，未指定时返回全部
+func (e *fakeEntry) selectAttributes(requested []string) map[string][]string {
+	if len(requested) == 0 {
+		return e.attributes
+	}
+	selected := make(map[string][]string)
+	for name, values := range e.attributes {
+		for _, r := range requested {
+			if strings.EqualFold(name, r) {
+				selected[name] = values
+			}
+		}
+	}
+	return selected
+}
+
+// unescapeFilterValue 还原 ldap.EscapeFilter 转义的 \xx 序列
+func unescapeFilterValue(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+2 < len(value) {
+			if n, err := strconv.ParseUint(value[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
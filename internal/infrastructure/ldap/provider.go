@@ -0,0 +1,234 @@
+// Package ldap 提供 LDAP/Active Directory 身份提供者的基础设施实现。
+//
+// [Provider] 实现 [domain/auth.IdentityProvider]：
+//  1. 使用服务账号绑定（未配置时匿名）并按用户名搜索用户条目
+//  2. 使用用户 DN 和密码绑定验证凭证
+//  3. 读取邮箱、显示名称和所属组，按组映射本地角色
+//
+// 目录连接通过 [Dialer] 建立，测试和本地开发可使用进程内的 [FakeDirectory]。
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// ProviderName LDAP 身份提供者名称（同时作为用户的 AuthSource）
+const ProviderName = user.AuthSourceLDAP
+
+// Conn 目录连接（*ldap.Conn 的子集，便于替换为进程内实现）
+type Conn interface {
+	Bind(username, password string) error
+	Search(req *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close() error
+}
+
+// Dialer 建立目录连接
+type Dialer func(ctx context.Context) (Conn, error)
+
+// Config LDAP 配置
+type Config struct {
+	URL                string
+	BindDN             string
+	BindPassword       string
+	BaseDN             string
+	UserFilter         string // 包含一个 %s 占位符（已转义的用户名）
+	UsernameAttribute  string
+	EmailAttribute     string
+	NameAttribute      string
+	GroupAttribute     string
+	GroupRoles         map[string]string // 组 CN（或完整 DN）-> 本地角色名称，匹配不区分大小写
+	StartTLS           bool
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+}
+
+// Provider LDAP 身份提供者
+type Provider struct {
+	cfg    Config
+	dialer Dialer
+}
+
+var _ auth.IdentityProvider = (*Provider)(nil)
+
+// NewProvider 创建 LDAP 身份提供者，dialer 为 nil 时按 cfg.URL 连接真实目录
+func NewProvider(cfg Config, dialer Dialer) *Provider {
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = "(uid=%s)"
+	}
+	if cfg.UsernameAttribute == "" {
+		cfg.UsernameAttribute = "uid"
+	}
+	if cfg.EmailAttribute == "" {
+		cfg.EmailAttribute = "mail"
+	}
+	if cfg.NameAttribute == "" {
+		cfg.NameAttribute = "cn"
+	}
+	if cfg.GroupAttribute == "" {
+		cfg.GroupAttribute = "memberOf"
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	p := &Provider{cfg: cfg, dialer: dialer}
+	if p.dialer == nil {
+		p.dialer = p.dial
+	}
+	return p
+}
+
+// Name 返回提供者名称
+func (p *Provider) Name() string {
+	return ProviderName
+}
+
+// Authenticate 搜索用户并以用户 DN 绑定验证密码
+func (p *Provider) Authenticate(ctx context.Context, username, password string) (*auth.ExternalIdentity, error) {
+	// 空密码会被大多数目录视为匿名绑定而"成功"，必须拒绝
+	if username == "" || password == "" {
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	conn, err := p.dialer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to directory: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if p.cfg.BindDN != "" {
+		if err = conn.Bind(p.cfg.BindDN, p.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind service account: %w", err)
+		}
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		p.cfg.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(p.cfg.Timeout.Seconds()), false,
+		fmt.Sprintf(p.cfg.UserFilter, ldap.EscapeFilter(username)),
+		[]string{p.cfg.UsernameAttribute, p.cfg.EmailAttribute, p.cfg.NameAttribute, p.cfg.GroupAttribute},
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search directory: %w", err)
+	}
+	if len(result.Entries) != 1 {
+		return nil, auth.ErrInvalidCredentials
+	}
+	entry := result.Entries[0]
+
+	if err = conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, auth.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to bind user: %w", err)
+	}
+
+	groups := entry.GetEqualFoldAttributeValues(p.cfg.GroupAttribute)
+	identity := &auth.ExternalIdentity{
+		Provider:   ProviderName,
+		ExternalID: entry.DN,
+		Username:   entry.GetEqualFoldAttributeValue(p.cfg.UsernameAttribute),
+		Email:      entry.GetEqualFoldAttributeValue(p.cfg.EmailAttribute),
+		FullName:   entry.GetEqualFoldAttributeValue(p.cfg.NameAttribute),
+		Groups:     groups,
+		Roles:      p.mapRoles(groups),
+	}
+	if identity.Username == "" {
+		identity.Username = username
+	}
+
+	return identity, nil
+}
+
+// mapRoles 将目录组映射为本地角色名称（去重，保持组顺序）
+func (p *Provider) mapRoles(groups []string) []string {
+	var roles []string
+	seen := make(map[string]bool)
+	for _, group := range groups {
+		role, ok := p.lookupGroupRole(group)
+		if !ok || seen[role] {
+			continue
+		}
+		seen[role] = true
+		roles = append(roles, role)
+	}
+	return roles
+}
+
+// lookupGroupRole 先按完整 DN，再按组 CN 查找映射
+func (p *Provider) lookupGroupRole(group string) (string, bool) {
+	for key, role := range p.cfg.GroupRoles {
+		if strings.EqualFold(key, group) || strings.EqualFold(key, groupCN(group)) {
+			return role, true
+		}
+	}
+	return "", false
+}
+
+// groupCN 返回组 DN 的首个 RDN 值，非 DN 时原样返回
+func groupCN(group string) string {
+	dn, err := ldap.ParseDN(group)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return group
+	}
+	return dn.RDNs[0].Attributes[0].Value
+}
+
+// dial 连接真实目录
+func (p *Provider) dial(ctx context.Context) (Conn, error) {
+	if p.cfg.URL == "" {
+		return nil, errors.New("ldap url is not configured")
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: p.cfg.InsecureSkipVerify} //nolint:gosec // 由配置显式开启，仅用于自签名测试环境
+	conn, err := ldap.DialURL(p.cfg.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: p.cfg.Timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(p.cfg.Timeout)
+
+	if p.cfg.StartTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetTimeout(time.Until(deadline))
+	}
+	return conn, nil
+}
+
+// ParseGroupRoles 解析组到角色的映射配置
+// 格式: group1=role1;group2=role2，组可以是 CN 或完整 DN（以最后一个 "=" 分隔角色）
+func ParseGroupRoles(mapping string) (map[string]string, error) {
+	roles := make(map[string]string)
+	for entry := range strings.SplitSeq(mapping, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		idx := strings.LastIndex(entry, "=")
+		if idx <= 0 || idx == len(entry)-1 {
+			return nil, fmt.Errorf("invalid ldap group role mapping: %q", entry)
+		}
+		roles[strings.TrimSpace(entry[:idx])] = strings.TrimSpace(entry[idx+1:])
+	}
+	return roles, nil
+}
@@ -0,0 +1,118 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auth"
+)
+
+func newTestDirectory() *FakeDirectory {
+	dir := NewFakeDirectory()
+	dir.AddEntry("cn=svc-app,ou=services,dc=example,dc=com", "svc-secret", map[string][]string{
+		"cn": {"svc-app"},
+	})
+	dir.AddEntry("uid=alice,ou=people,dc=example,dc=com", "alice-pass", map[string][]string{
+		"objectClass": {"person"},
+		"uid":         {"alice"},
+		"mail":        {"alice@example.com"},
+		"cn":          {"Alice Liddell"},
+		"memberOf":    {"cn=admins,ou=groups,dc=example,dc=com", "cn=staff,ou=groups,dc=example,dc=com"},
+	})
+	return dir
+}
+
+func newTestProvider(dir *FakeDirectory) *Provider {
+	return NewProvider(Config{
+		BindDN:       "cn=svc-app,ou=services,dc=example,dc=com",
+		BindPassword: "svc-secret",
+		BaseDN:       "ou=people,dc=example,dc=com",
+		UserFilter:   "(&(objectClass=person)(uid=%s))",
+		GroupRoles:   map[string]string{"admins": "admin", "cn=staff,ou=groups,dc=example,dc=com": "user", "ADMINS-2": "admin"},
+	}, dir.Dialer())
+}
+
+func TestProvider_Authenticate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("认证成功并映射角色", func(t *testing.T) {
+		p := newTestProvider(newTestDirectory())
+
+		identity, err := p.Authenticate(ctx, "alice", "alice-pass")
+
+		require.NoError(t, err)
+		assert.Equal(t, ProviderName, identity.Provider)
+		assert.Equal(t, "uid=alice,ou=people,dc=example,dc=com", identity.ExternalID)
+		assert.Equal(t, "alice", identity.Username)
+		assert.Equal(t, "alice@example.com", identity.Email)
+		assert.Equal(t, "Alice Liddell", identity.FullName)
+		assert.Len(t, identity.Groups, 2)
+		assert.Equal(t, []string{"admin", "user"}, identity.Roles)
+	})
+
+	t.Run("密码错误", func(t *testing.T) {
+		p := newTestProvider(newTestDirectory())
+
+		_, err := p.Authenticate(ctx, "alice", "wrong")
+
+		require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("空密码不允许匿名绑定", func(t *testing.T) {
+		p := newTestProvider(newTestDirectory())
+
+		_, err := p.Authenticate(ctx, "alice", "")
+
+		require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("用户不存在", func(t *testing.T) {
+		p := newTestProvider(newTestDirectory())
+
+		_, err := p.Authenticate(ctx, "bob", "whatever")
+
+		require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("过滤器注入被转义", func(t *testing.T) {
+		p := newTestProvider(newTestDirectory())
+
+		_, err := p.Authenticate(ctx, "*)(uid=alice", "alice-pass")
+
+		require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("服务账号绑定失败", func(t *testing.T) {
+		dir := newTestDirectory()
+		dir.RemoveEntry("cn=svc-app,ou=services,dc=example,dc=com")
+		p := newTestProvider(dir)
+
+		_, err := p.Authenticate(ctx, "alice", "alice-pass")
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+}
+
+func TestParseGroupRoles(t *testing.T) {
+	t.Run("解析 CN 和 DN", func(t *testing.T) {
+		roles, err := ParseGroupRoles("admins=admin; cn=staff,ou=groups,dc=example,dc=com=user")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"admins":                               "admin",
+			"cn=staff,ou=groups,dc=example,dc=com": "user",
+		}, roles)
+	})
+
+	t.Run("格式错误", func(t *testing.T) {
+		_, err := ParseGroupRoles("admins")
+		require.Error(t, err)
+
+		_, err = ParseGroupRoles("admins=")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,99 @@
+// Package mail 提供邮件发送的基础设施实现。
+//
+// 提供两种实现：
+//   - [SMTPMailer]: 通过 SMTP 服务器发送邮件（生产环境）
+//   - [LogMailer]: 仅将邮件内容输出到日志（开发环境或未配置 SMTP 时）
+//
+// 使用 [New] 根据配置自动选择实现。
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mailer 邮件发送接口
+type Mailer interface {
+	// Send 发送纯文本邮件
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// Config SMTP 配置
+type Config struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// New 根据配置创建 Mailer
+// 未配置 SMTP 主机时返回 [LogMailer]
+func New(cfg Config) Mailer {
+	if cfg.Host == "" {
+		return NewLogMailer()
+	}
+	return NewSMTPMailer(cfg)
+}
+
+// SMTPMailer 基于 net/smtp 的邮件发送实现
+type SMTPMailer struct {
+	cfg Config
+}
+
+// NewSMTPMailer 创建 SMTP 邮件发送器
+func NewSMTPMailer(cfg Config) *SMTPMailer {
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send 发送纯文本邮件
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	if err := smtp.SendMail(addr, auth, m.cfg.From, []string{to}, buildMessage(m.cfg.From, to, subject, body)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
+
+// LogMailer 将邮件输出到日志的实现（开发环境使用）
+type LogMailer struct{}
+
+// NewLogMailer 创建日志邮件发送器
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send 将邮件内容写入日志
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	slog.InfoContext(ctx, "mail (log only)", "to", to, "subject", subject, "body", body)
+	return nil
+}
+
+// buildMessage 构造 RFC 5322 邮件内容
+func buildMessage(from, to, subject, body string) []byte {
+	var sb strings.Builder
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + to + "\r\n")
+	sb.WriteString("Subject: " + subject + "\r\n")
+	sb.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(body)
+	return []byte(sb.String())
+}
@@ -0,0 +1,45 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	"gorm.io/gorm"
+)
+
+// changeRequestCommandRepository 变更请求命令仓储的 GORM 实现
+// 嵌入 GenericCommandRepository 以复用 Create
+type changeRequestCommandRepository struct {
+	*GenericCommandRepository[approval.ChangeRequest, *ChangeRequestModel]
+}
+
+// NewChangeRequestCommandRepository 创建变更请求命令仓储实例
+func NewChangeRequestCommandRepository(db *gorm.DB) approval.CommandRepository {
+	return &changeRequestCommandRepository{
+		GenericCommandRepository: NewGenericCommandRepository(
+			db, newChangeRequestModelFromEntity,
+		),
+	}
+}
+
+// UpdateStatus 条件更新状态与审批信息，当前状态不为 expected 时返回 ErrChangeRequestNotPending
+func (r *changeRequestCommandRepository) UpdateStatus(ctx context.Context, request *approval.ChangeRequest, expected approval.Status) error {
+	result := r.DB().WithContext(ctx).Model(&ChangeRequestModel{}).
+		Where("id = ? AND status = ?", request.ID, string(expected)).
+		Updates(map[string]any{
+			"status":         string(request.Status),
+			"reviewer_id":    request.ReviewerID,
+			"reviewer_name":  request.ReviewerName,
+			"review_comment": request.ReviewComment,
+			"reviewed_at":    request.ReviewedAt,
+			"error":          request.Error,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update change request status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return approval.ErrChangeRequestNotPending
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+)
+
+// ChangeRequestModel 变更请求的 GORM 持久化模型
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type ChangeRequestModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Operation  string `gorm:"size:50;index;not null"`
+	ResourceID string `gorm:"size:50"`
+	Payload    string `gorm:"type:text;not null"`
+	Status     string `gorm:"size:20;index;not null"`
+
+	RequesterID   uint   `gorm:"index;not null"`
+	RequesterName string `gorm:"size:50"`
+
+	ReviewerID    *uint
+	ReviewerName  string `gorm:"size:50"`
+	ReviewComment string `gorm:"size:500"`
+	ReviewedAt    *time.Time
+
+	Error string `gorm:"type:text"`
+
+	ExpiresAt time.Time `gorm:"index;not null"`
+}
+
+// TableName 指定变更请求表名
+func (ChangeRequestModel) TableName() string {
+	return "change_requests"
+}
+
+func newChangeRequestModelFromEntity(entity *approval.ChangeRequest) *ChangeRequestModel {
+	if entity == nil {
+		return nil
+	}
+
+	return &ChangeRequestModel{
+		ID:            entity.ID,
+		CreatedAt:     entity.CreatedAt,
+		UpdatedAt:     entity.UpdatedAt,
+		Operation:     entity.Operation,
+		ResourceID:    entity.ResourceID,
+		Payload:       entity.Payload,
+		Status:        string(entity.Status),
+		RequesterID:   entity.RequesterID,
+		RequesterName: entity.RequesterName,
+		ReviewerID:    entity.ReviewerID,
+		ReviewerName:  entity.ReviewerName,
+		ReviewComment: entity.ReviewComment,
+		ReviewedAt:    entity.ReviewedAt,
+		Error:         entity.Error,
+		ExpiresAt:     entity.ExpiresAt,
+	}
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity（实现 Model[E] 接口）
+func (m *ChangeRequestModel) ToEntity() *approval.ChangeRequest {
+	if m == nil {
+		return nil
+	}
+
+	return &approval.ChangeRequest{
+		ID:            m.ID,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+		Operation:     m.Operation,
+		ResourceID:    m.ResourceID,
+		Payload:       m.Payload,
+		Status:        approval.Status(m.Status),
+		RequesterID:   m.RequesterID,
+		RequesterName: m.RequesterName,
+		ReviewerID:    m.ReviewerID,
+		ReviewerName:  m.ReviewerName,
+		ReviewComment: m.ReviewComment,
+		ReviewedAt:    m.ReviewedAt,
+		Error:         m.Error,
+		ExpiresAt:     m.ExpiresAt,
+	}
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	"gorm.io/gorm"
+)
+
+// changeRequestQueryRepository 变更请求查询仓储的 GORM 实现
+// 嵌入 GenericQueryRepository 以复用 GetByID
+type changeRequestQueryRepository struct {
+	*GenericQueryRepository[approval.ChangeRequest, *ChangeRequestModel]
+}
+
+// NewChangeRequestQueryRepository 创建变更请求查询仓储实例
+func NewChangeRequestQueryRepository(db *gorm.DB) approval.QueryRepository {
+	return &changeRequestQueryRepository{
+		GenericQueryRepository: NewGenericQueryRepository[approval.ChangeRequest, *ChangeRequestModel](
+			db, approval.ErrChangeRequestNotFound,
+		),
+	}
+}
+
+// List 按过滤条件分页获取变更请求（按创建时间倒序）
+func (r *changeRequestQueryRepository) List(ctx context.Context, filter approval.FilterOptions, offset, limit int) ([]*approval.ChangeRequest, int64, error) {
+	query := r.DB().WithContext(ctx).Model(&ChangeRequestModel{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.Operation != "" {
+		query = query.Where("operation = ?", filter.Operation)
+	}
+	if filter.RequesterID != nil {
+		query = query.Where("requester_id = ?", *filter.RequesterID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count change requests: %w", err)
+	}
+
+	var models []*ChangeRequestModel
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list change requests: %w", err)
+	}
+
+	return r.ModelsToEntities(models), total, nil
+}
+
+// ListExpired 获取已过期但仍为待审批状态的请求
+func (r *changeRequestQueryRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*approval.ChangeRequest, error) {
+	var models []*ChangeRequestModel
+	if err := r.DB().WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", string(approval.StatusPending), now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expired change requests: %w", err)
+	}
+
+	return r.ModelsToEntities(models), nil
+}
@@ -0,0 +1,20 @@
+package persistence
+
+import (
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/approval"
+	"gorm.io/gorm"
+)
+
+// ApprovalRepositories 聚合变更请求读写仓储
+type ApprovalRepositories struct {
+	Command approval.CommandRepository
+	Query   approval.QueryRepository
+}
+
+// NewApprovalRepositories 创建变更请求仓储聚合实例
+func NewApprovalRepositories(db *gorm.DB) ApprovalRepositories {
+	return ApprovalRepositories{
+		Command: NewChangeRequestCommandRepository(db),
+		Query:   NewChangeRequestQueryRepository(db),
+	}
+}
@@ -60,3 +60,17 @@ func (r *auditLogCommandRepository) BatchCreate(ctx context.Context, logs []*aud
 
 	return nil
 }
+
+// AnonymizeUser replaces the username of the user's entries with pseudonym and clears IP address and user agent
+func (r *auditLogCommandRepository) AnonymizeUser(ctx context.Context, userID uint, pseudonym string) error {
+	if err := r.DB().WithContext(ctx).Model(&AuditLogModel{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{
+			"username":   pseudonym,
+			"ip_address": "",
+			"user_agent": "",
+		}).Error; err != nil {
+		return fmt.Errorf("failed to anonymize audit logs: %w", err)
+	}
+	return nil
+}
@@ -9,6 +9,17 @@ import (
 	"gorm.io/gorm"
 )
 
+// auditLogPolicyColumns 审计日志可用于策略过滤的资源属性
+var auditLogPolicyColumns = map[string]string{
+	"id":          "id",
+	"user_id":     "user_id",
+	"username":    "username",
+	"action":      "action",
+	"resource":    "resource",
+	"resource_id": "resource_id",
+	"status":      "status",
+}
+
 // auditLogQueryRepository 审计日志查询仓储的 GORM 实现
 type auditLogQueryRepository struct {
 	db *gorm.DB
@@ -58,6 +69,12 @@ func (r *auditLogQueryRepository) List(ctx context.Context, filter auditlog.Filt
 	if filter.EndDate != nil {
 		query = query.Where("created_at <= ?", *filter.EndDate)
 	}
+	if filter.Policy != nil {
+		var err error
+		if query, err = applyPolicyFilter(query, *filter.Policy, auditLogPolicyColumns); err != nil {
+			return nil, 0, err
+		}
+	}
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -130,6 +147,12 @@ func (r *auditLogQueryRepository) Count(ctx context.Context, filter auditlog.Fil
 	if filter.EndDate != nil {
 		query = query.Where("created_at <= ?", *filter.EndDate)
 	}
+	if filter.Policy != nil {
+		var err error
+		if query, err = applyPolicyFilter(query, *filter.Policy, auditLogPolicyColumns); err != nil {
+			return 0, err
+		}
+	}
 
 	if err := query.Count(&total).Error; err != nil {
 		return 0, fmt.Errorf("failed to count audit logs: %w", err)
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// ErrSoftDeletedNotFound 指定 ID 的软删除记录不存在（记录不存在或未被删除）。
+var ErrSoftDeletedNotFound = errors.New("soft-deleted record not found")
+
 // Model 定义 GORM Model 必须实现的接口，用于转换回 Domain Entity。
 // 泛型参数 E 是对应的 Domain Entity 类型。
 type Model[E any] interface {
@@ -79,6 +83,37 @@ func (r *GenericCommandRepository[E, M]) Delete(ctx context.Context, id uint) er
 	return nil
 }
 
+// Restore 恢复软删除的实体。记录不存在或未被删除时返回 [ErrSoftDeletedNotFound]。
+func (r *GenericCommandRepository[E, M]) Restore(ctx context.Context, id uint) error {
+	var model M
+	result := r.db.WithContext(ctx).Unscoped().Model(&model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrSoftDeletedNotFound
+	}
+	return nil
+}
+
+// Purge 永久删除已软删除的实体，未被软删除的记录不受影响。
+// 记录不存在或未被删除时返回 [ErrSoftDeletedNotFound]。
+func (r *GenericCommandRepository[E, M]) Purge(ctx context.Context, id uint) error {
+	var model M
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Delete(&model)
+	if result.Error != nil {
+		return fmt.Errorf("failed to purge: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrSoftDeletedNotFound
+	}
+	return nil
+}
+
 // DB 返回底层的 GORM DB 实例，用于子类实现特殊方法。
 func (r *GenericCommandRepository[E, M]) DB() *gorm.DB {
 	return r.db
@@ -147,6 +182,51 @@ func (r *GenericQueryRepository[E, M]) Exists(ctx context.Context, id uint) (boo
 	return count > 0, nil
 }
 
+// ListDeleted 获取已软删除的实体列表（按删除时间倒序分页）及总数。
+func (r *GenericQueryRepository[E, M]) ListDeleted(ctx context.Context, offset, limit int) ([]*E, int64, error) {
+	var model M
+	var total int64
+	base := r.db.WithContext(ctx).Unscoped().Model(&model).Where("deleted_at IS NOT NULL")
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count deleted: %w", err)
+	}
+
+	var models []M
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").Offset(offset).Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list deleted: %w", err)
+	}
+	return r.modelsToEntities(models), total, nil
+}
+
+// GetDeletedByID 获取已软删除的实体。记录不存在或未被删除时返回 [ErrSoftDeletedNotFound]。
+func (r *GenericQueryRepository[E, M]) GetDeletedByID(ctx context.Context, id uint) (*E, error) {
+	var model M
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSoftDeletedNotFound
+		}
+		return nil, fmt.Errorf("failed to get deleted by id: %w", err)
+	}
+	return model.ToEntity(), nil
+}
+
+// DeletedIDsBefore 返回删除时间早于 cutoff 的实体 ID（按删除时间升序，最多 limit 条）。
+func (r *GenericQueryRepository[E, M]) DeletedIDsBefore(ctx context.Context, cutoff time.Time, limit int) ([]uint, error) {
+	var model M
+	var ids []uint
+	if err := r.db.WithContext(ctx).Unscoped().Model(&model).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Order("deleted_at ASC, id ASC").Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expired deleted ids: %w", err)
+	}
+	return ids, nil
+}
+
 // DB 返回底层的 GORM DB 实例，用于子类实现特殊查询。
 func (r *GenericQueryRepository[E, M]) DB() *gorm.DB {
 	return r.db
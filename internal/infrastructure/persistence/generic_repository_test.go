@@ -3,10 +3,12 @@ package persistence
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -149,6 +151,58 @@ func TestGenericCommandRepository_Delete(t *testing.T) {
 	})
 }
 
+// TestGenericRepository_SoftDeleteLifecycle 测试泛型仓储的回收站方法
+func TestGenericRepository_SoftDeleteLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := setupGenericTestDB(t)
+	cmdRepo := NewGenericCommandRepository[role.Role, *RoleModel](db, newRoleModelFromEntity)
+	queryRepo := NewGenericQueryRepository[role.Role, *RoleModel](db, nil)
+
+	active := &role.Role{Name: "active", DisplayName: "Active"}
+	deleted := &role.Role{Name: "deleted", DisplayName: "Deleted"}
+	require.NoError(t, cmdRepo.Create(ctx, active))
+	require.NoError(t, cmdRepo.Create(ctx, deleted))
+	require.NoError(t, cmdRepo.Delete(ctx, deleted.ID))
+
+	t.Run("只列出已删除记录", func(t *testing.T) {
+		items, total, err := queryRepo.ListDeleted(ctx, 0, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, items, 1)
+		assert.Equal(t, deleted.ID, items[0].ID)
+
+		ids, err := queryRepo.DeletedIDsBefore(ctx, time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{deleted.ID}, ids)
+	})
+
+	t.Run("未删除的记录不能恢复或永久删除", func(t *testing.T) {
+		require.ErrorIs(t, cmdRepo.Restore(ctx, active.ID), ErrSoftDeletedNotFound)
+		require.ErrorIs(t, cmdRepo.Purge(ctx, active.ID), ErrSoftDeletedNotFound)
+
+		_, err := queryRepo.GetDeletedByID(ctx, active.ID)
+		require.ErrorIs(t, err, ErrSoftDeletedNotFound)
+	})
+
+	t.Run("恢复后可正常查询", func(t *testing.T) {
+		require.NoError(t, cmdRepo.Restore(ctx, deleted.ID))
+
+		found, err := queryRepo.GetByID(ctx, deleted.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "deleted", found.Name)
+	})
+
+	t.Run("永久删除后记录不存在", func(t *testing.T) {
+		require.NoError(t, cmdRepo.Delete(ctx, deleted.ID))
+		require.NoError(t, cmdRepo.Purge(ctx, deleted.ID))
+
+		var count int64
+		require.NoError(t, db.Unscoped().Model(&RoleModel{}).Where("id = ?", deleted.ID).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
+
 // TestGenericQueryRepository_GetByID 测试泛型读仓储的 GetByID 方法
 func TestGenericQueryRepository_GetByID(t *testing.T) {
 	ctx := context.Background()
@@ -252,3 +306,72 @@ func TestPermissionCommandRepository_GenericCRUD(t *testing.T) {
 		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
 	})
 }
+
+// TestTenantScope 测试租户上下文中仓储自动附加租户条件
+func TestTenantScope(t *testing.T) {
+	db := setupGenericTestDB(t)
+	require.NoError(t, RegisterTenantScope(db))
+
+	platformCtx := context.Background()
+	tenantA := organization.WithTenant(platformCtx, 1)
+	tenantB := organization.WithTenant(platformCtx, 2)
+
+	cmdRepo := NewRoleCommandRepository(db)
+	qryRepo := NewRoleQueryRepository(db)
+
+	global := &role.Role{Name: "viewer", DisplayName: "Viewer"}
+	require.NoError(t, cmdRepo.Create(platformCtx, global))
+	assert.Nil(t, global.OrganizationID, "平台级创建的角色为全局角色")
+
+	roleA := &role.Role{Name: "editor", DisplayName: "Editor A"}
+	require.NoError(t, cmdRepo.Create(tenantA, roleA))
+	require.NotNil(t, roleA.OrganizationID, "租户上下文中创建的角色自动归属该租户")
+	assert.Equal(t, uint(1), *roleA.OrganizationID)
+
+	roleB := &role.Role{Name: "editor", DisplayName: "Editor B"}
+	require.NoError(t, cmdRepo.Create(tenantB, roleB), "不同租户可以使用相同角色名")
+
+	t.Run("查询仅返回本租户和全局记录", func(t *testing.T) {
+		roles, total, err := qryRepo.List(tenantA, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		names := []string{roles[0].DisplayName, roles[1].DisplayName}
+		assert.ElementsMatch(t, []string{"Viewer", "Editor A"}, names)
+
+		found, err := qryRepo.FindByID(tenantA, roleB.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found, "不能读取其他租户的记录")
+	})
+
+	t.Run("平台级查询不受限制", func(t *testing.T) {
+		_, total, err := qryRepo.List(platformCtx, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+	})
+
+	t.Run("租户不能修改全局或其他租户的记录", func(t *testing.T) {
+		g := *global
+		g.DisplayName = "Hijacked"
+		err := cmdRepo.Update(tenantA, &g)
+		require.ErrorIs(t, err, organization.ErrTenantMismatch)
+
+		require.ErrorIs(t, cmdRepo.Delete(tenantA, global.ID), organization.ErrTenantMismatch)
+		require.ErrorIs(t, cmdRepo.SetPermissions(tenantA, global.ID, nil), organization.ErrTenantMismatch)
+
+		var model RoleModel
+		require.NoError(t, db.First(&model, global.ID).Error)
+		assert.Equal(t, "Viewer", model.DisplayName)
+		assert.Nil(t, model.OrganizationID)
+	})
+
+	t.Run("租户可以修改本租户记录", func(t *testing.T) {
+		a := *roleA
+		a.DisplayName = "Editor A2"
+		require.NoError(t, cmdRepo.Update(tenantA, &a))
+		require.NoError(t, cmdRepo.Delete(tenantA, roleA.ID))
+
+		exists, err := qryRepo.Exists(platformCtx, roleA.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
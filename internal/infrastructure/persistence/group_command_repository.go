@@ -0,0 +1,91 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// groupCommandRepository 用户组命令仓储的 GORM 实现
+// 嵌入 GenericCommandRepository 以复用基础 CRUD 操作
+type groupCommandRepository struct {
+	*GenericCommandRepository[group.Group, *UserGroupModel]
+}
+
+// NewGroupCommandRepository 创建用户组命令仓储实例
+func NewGroupCommandRepository(db *gorm.DB) group.CommandRepository {
+	return &groupCommandRepository{
+		GenericCommandRepository: NewGenericCommandRepository(
+			db, newUserGroupModelFromEntity,
+		),
+	}
+}
+
+// Create、Update 方法由 GenericCommandRepository 提供（模型不携带角色，不会写入关联）
+
+// Delete 删除用户组，并移除成员关系与角色分配
+func (r *groupCommandRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&UserGroupModel{ID: id}).Association("Roles").Clear(); err != nil {
+			return fmt.Errorf("failed to clear group roles: %w", err)
+		}
+		if err := tx.Where("group_id = ?", id).Delete(&UserGroupMemberModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete group members: %w", err)
+		}
+		if err := tx.Delete(&UserGroupModel{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		return nil
+	})
+}
+
+// AddMembers 添加成员，已是成员的用户忽略
+func (r *groupCommandRepository) AddMembers(ctx context.Context, groupID uint, userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	models := make([]UserGroupMemberModel, 0, len(userIDs))
+	for _, userID := range userIDs {
+		models = append(models, UserGroupMemberModel{GroupID: groupID, UserID: userID})
+	}
+	if err := r.DB().WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to add group members: %w", err)
+	}
+	return nil
+}
+
+// RemoveMembers 移除成员，非成员的用户忽略
+func (r *groupCommandRepository) RemoveMembers(ctx context.Context, groupID uint, userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	if err := r.DB().WithContext(ctx).
+		Where("group_id = ? AND user_id IN ?", groupID, userIDs).
+		Delete(&UserGroupMemberModel{}).Error; err != nil {
+		return fmt.Errorf("failed to remove group members: %w", err)
+	}
+	return nil
+}
+
+// SetRoles 设置用户组的角色（替换现有角色）
+func (r *groupCommandRepository) SetRoles(ctx context.Context, groupID uint, roleIDs []uint) error {
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var roles []RoleModel
+		if len(roleIDs) > 0 {
+			if err := tx.Where("id IN ?", roleIDs).Find(&roles).Error; err != nil {
+				return fmt.Errorf("failed to find roles: %w", err)
+			}
+		}
+		if err := tx.Model(&UserGroupModel{ID: groupID}).Association("Roles").Replace(roles); err != nil {
+			return fmt.Errorf("failed to set group roles: %w", err)
+		}
+		return nil
+	})
+}
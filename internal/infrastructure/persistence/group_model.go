@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"gorm.io/gorm"
+)
+
+// UserGroupModel 定义用户组的 GORM 持久化模型
+// 用户组持有的角色保存在 user_group_roles 关联表
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type UserGroupModel struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Name        string         `gorm:"size:50;uniqueIndex;not null"`
+	Description string         `gorm:"size:255"`
+	Roles       []RoleModel    `gorm:"many2many:user_group_roles;joinForeignKey:GroupID;joinReferences:RoleID"`
+}
+
+// TableName 指定用户组表名
+func (UserGroupModel) TableName() string {
+	return "user_groups"
+}
+
+func newUserGroupModelFromEntity(entity *group.Group) *UserGroupModel {
+	if entity == nil {
+		return nil
+	}
+
+	model := &UserGroupModel{
+		ID:          entity.ID,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+		Name:        entity.Name,
+		Description: entity.Description,
+	}
+
+	if entity.DeletedAt != nil {
+		model.DeletedAt = gorm.DeletedAt{Time: *entity.DeletedAt, Valid: true}
+	}
+
+	return model
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity（实现 Model[E] 接口）
+func (m *UserGroupModel) ToEntity() *group.Group {
+	if m == nil {
+		return nil
+	}
+
+	entity := &group.Group{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Name:        m.Name,
+		Description: m.Description,
+		Roles:       mapRoleModelsToEntities(m.Roles),
+	}
+
+	if m.DeletedAt.Valid {
+		t := m.DeletedAt.Time
+		entity.DeletedAt = &t
+	}
+
+	return entity
+}
+
+// UserGroupMemberModel 定义用户组成员关系的 GORM 持久化模型
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type UserGroupMemberModel struct {
+	GroupID   uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"primaryKey;index"`
+	CreatedAt time.Time
+}
+
+// TableName 指定用户组成员表名
+func (UserGroupMemberModel) TableName() string {
+	return "user_group_members"
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *UserGroupMemberModel) ToEntity() *group.Member {
+	if m == nil {
+		return nil
+	}
+
+	return &group.Member{
+		GroupID:   m.GroupID,
+		UserID:    m.UserID,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func mapUserGroupModels(models []UserGroupModel) []*group.Group {
+	groups := make([]*group.Group, 0, len(models))
+	for i := range models {
+		groups = append(groups, models[i].ToEntity())
+	}
+	return groups
+}
@@ -0,0 +1,144 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"gorm.io/gorm"
+)
+
+// groupQueryRepository 用户组查询仓储的 GORM 实现
+type groupQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupQueryRepository 创建用户组查询仓储实例
+func NewGroupQueryRepository(db *gorm.DB) group.QueryRepository {
+	return &groupQueryRepository{db: db}
+}
+
+// GetByID 根据 ID 获取用户组（包含角色）
+func (r *groupQueryRepository) GetByID(ctx context.Context, id uint) (*group.Group, error) {
+	var model UserGroupModel
+	if err := r.db.WithContext(ctx).Preload("Roles").First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, group.ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get group by id: %w", err)
+	}
+	return model.ToEntity(), nil
+}
+
+// GetByName 根据名称获取用户组（包含角色）
+func (r *groupQueryRepository) GetByName(ctx context.Context, name string) (*group.Group, error) {
+	var model UserGroupModel
+	if err := r.db.WithContext(ctx).Preload("Roles").Where("name = ?", name).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, group.ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get group by name: %w", err)
+	}
+	return model.ToEntity(), nil
+}
+
+// ExistsByName 检查用户组名称是否已存在
+func (r *groupQueryRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&UserGroupModel{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check group name: %w", err)
+	}
+	return count > 0, nil
+}
+
+// List 分页获取用户组列表（包含角色）
+func (r *groupQueryRepository) List(ctx context.Context, offset, limit int) ([]*group.Group, int64, error) {
+	var total int64
+	query := r.db.WithContext(ctx).Model(&UserGroupModel{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count groups: %w", err)
+	}
+
+	var models []UserGroupModel
+	if err := query.Preload("Roles").Order("id ASC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list groups: %w", err)
+	}
+	return mapUserGroupModels(models), total, nil
+}
+
+// ListByUser 获取用户所在的全部用户组（包含角色）
+func (r *groupQueryRepository) ListByUser(ctx context.Context, userID uint) ([]*group.Group, error) {
+	var models []UserGroupModel
+	if err := r.db.WithContext(ctx).
+		Preload("Roles").
+		Where("id IN (?)", r.db.Model(&UserGroupMemberModel{}).Select("group_id").Where("user_id = ?", userID)).
+		Order("name ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list groups by user: %w", err)
+	}
+	return mapUserGroupModels(models), nil
+}
+
+// ListMembers 分页获取用户组成员
+func (r *groupQueryRepository) ListMembers(ctx context.Context, groupID uint, offset, limit int) ([]*group.Member, int64, error) {
+	var total int64
+	query := r.db.WithContext(ctx).Model(&UserGroupMemberModel{}).Where("group_id = ?", groupID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count group members: %w", err)
+	}
+
+	var models []UserGroupMemberModel
+	if err := query.Order("user_id ASC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list group members: %w", err)
+	}
+
+	members := make([]*group.Member, 0, len(models))
+	for i := range models {
+		members = append(members, models[i].ToEntity())
+	}
+	return members, total, nil
+}
+
+// GetMemberIDs 获取用户组全部成员的用户 ID
+func (r *groupQueryRepository) GetMemberIDs(ctx context.Context, groupID uint) ([]uint, error) {
+	var userIDs []uint
+	if err := r.db.WithContext(ctx).
+		Model(&UserGroupMemberModel{}).
+		Where("group_id = ?", groupID).
+		Order("user_id ASC").
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get group member ids: %w", err)
+	}
+	return userIDs, nil
+}
+
+// GetUserIDsByRole 获取经由用户组持有指定角色的用户 ID
+func (r *groupQueryRepository) GetUserIDsByRole(ctx context.Context, roleID uint) ([]uint, error) {
+	var userIDs []uint
+	if err := r.db.WithContext(ctx).
+		Model(&UserGroupMemberModel{}).
+		Distinct("user_id").
+		Where("group_id IN (?)", r.db.Table("user_group_roles").Select("group_id").Where("role_id = ?", roleID)).
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get group user ids by role: %w", err)
+	}
+	return userIDs, nil
+}
+
+// loadGroupRoles 加载用户经由所在用户组获得的角色（含直接权限，不含已删除的用户组和角色）
+func loadGroupRoles(ctx context.Context, db *gorm.DB, userID uint) ([]role.Role, error) {
+	groupIDs := db.Model(&UserGroupModel{}).Select("id").
+		Where("id IN (?)", db.Model(&UserGroupMemberModel{}).Select("group_id").Where("user_id = ?", userID))
+
+	var models []RoleModel
+	if err := db.WithContext(ctx).
+		Preload("Permissions").
+		Where("id IN (?)", db.Table("user_group_roles").Select("role_id").Where("group_id IN (?)", groupIDs)).
+		Order("id ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to load group roles: %w", err)
+	}
+	return mapRoleModelsToEntities(models), nil
+}
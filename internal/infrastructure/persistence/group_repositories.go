@@ -0,0 +1,20 @@
+package persistence
+
+import (
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"gorm.io/gorm"
+)
+
+// GroupRepositories 聚合用户组读写仓储
+type GroupRepositories struct {
+	Command group.CommandRepository
+	Query   group.QueryRepository
+}
+
+// NewGroupRepositories 创建用户组仓储聚合实例
+func NewGroupRepositories(db *gorm.DB) GroupRepositories {
+	return GroupRepositories{
+		Command: NewGroupCommandRepository(db),
+		Query:   NewGroupQueryRepository(db),
+	}
+}
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// invitationCommandRepository 用户邀请命令仓储的 GORM 实现
+type invitationCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationCommandRepository 创建用户邀请命令仓储实例
+func NewInvitationCommandRepository(db *gorm.DB) user.InvitationCommandRepository {
+	return &invitationCommandRepository{db: db}
+}
+
+// Create 创建邀请
+func (r *invitationCommandRepository) Create(ctx context.Context, invitation *user.Invitation) error {
+	model := newInvitationModelFromEntity(invitation)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	invitation.ID = model.ID
+	invitation.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// MarkAccepted 标记邀请已接受
+func (r *invitationCommandRepository) MarkAccepted(ctx context.Context, invitation *user.Invitation) error {
+	if err := r.db.WithContext(ctx).Model(&InvitationModel{}).
+		Where("id = ?", invitation.ID).
+		Update("accepted_at", invitation.AcceptedAt).Error; err != nil {
+		return fmt.Errorf("failed to mark invitation accepted: %w", err)
+	}
+	return nil
+}
+
+// DeleteByUserID 删除用户的所有邀请
+func (r *invitationCommandRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&InvitationModel{}).Error; err != nil {
+		return fmt.Errorf("failed to delete invitations: %w", err)
+	}
+	return nil
+}
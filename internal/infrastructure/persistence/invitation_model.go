@@ -0,0 +1,59 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// InvitationModel 用户邀请的 GORM 实体
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type InvitationModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	UserID     uint      `gorm:"index;not null"`
+	TokenHash  string    `gorm:"uniqueIndex;size:64;not null"`
+	InvitedBy  uint      `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	AcceptedAt *time.Time
+}
+
+// TableName 指定用户邀请表名
+func (InvitationModel) TableName() string {
+	return "user_invitations"
+}
+
+func newInvitationModelFromEntity(entity *user.Invitation) *InvitationModel {
+	if entity == nil {
+		return nil
+	}
+
+	return &InvitationModel{
+		ID:         entity.ID,
+		CreatedAt:  entity.CreatedAt,
+		UserID:     entity.UserID,
+		TokenHash:  entity.TokenHash,
+		InvitedBy:  entity.InvitedBy,
+		ExpiresAt:  entity.ExpiresAt,
+		AcceptedAt: entity.AcceptedAt,
+	}
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *InvitationModel) ToEntity() *user.Invitation {
+	if m == nil {
+		return nil
+	}
+
+	return &user.Invitation{
+		ID:         m.ID,
+		CreatedAt:  m.CreatedAt,
+		UserID:     m.UserID,
+		TokenHash:  m.TokenHash,
+		InvitedBy:  m.InvitedBy,
+		ExpiresAt:  m.ExpiresAt,
+		AcceptedAt: m.AcceptedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// invitationQueryRepository 用户邀请查询仓储的 GORM 实现
+type invitationQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationQueryRepository 创建用户邀请查询仓储实例
+func NewInvitationQueryRepository(db *gorm.DB) user.InvitationQueryRepository {
+	return &invitationQueryRepository{db: db}
+}
+
+// FindByTokenHash 根据令牌哈希查找邀请
+func (r *invitationQueryRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*user.Invitation, error) {
+	var model InvitationModel
+	if err := r.db.WithContext(ctx).
+		Where("token_hash = ?", tokenHash).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, user.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+
+	return model.ToEntity(), nil
+}
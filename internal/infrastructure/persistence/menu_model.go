@@ -11,16 +11,18 @@ import (
 //
 //nolint:recvcheck // TableName uses value receiver per GORM convention
 type MenuModel struct {
-	ID        uint   `gorm:"primaryKey"`
-	Title     string `gorm:"type:varchar(100);not null"`
-	Path      string `gorm:"type:varchar(255);not null"`
-	Icon      string `gorm:"type:varchar(100)"`
-	ParentID  *uint  `gorm:"index"`
-	Order     int    `gorm:"default:0"`
-	Visible   bool   `gorm:"default:true"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID       uint   `gorm:"primaryKey"`
+	Title    string `gorm:"type:varchar(100);not null"`
+	Path     string `gorm:"type:varchar(255);not null"`
+	Icon     string `gorm:"type:varchar(100)"`
+	ParentID *uint  `gorm:"index"`
+	Order    int    `gorm:"default:0"`
+	Visible  bool   `gorm:"default:true"`
+	// Permission 访问菜单所需的权限代码（支持通配符），空表示不限制
+	Permission string `gorm:"type:varchar(100);default:''"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
 }
 
 // TableName 指定菜单表名
@@ -34,15 +36,16 @@ func newMenuModelFromEntity(entity *menu.Menu) *MenuModel {
 	}
 
 	model := &MenuModel{
-		ID:        entity.ID,
-		Title:     entity.Title,
-		Path:      entity.Path,
-		Icon:      entity.Icon,
-		ParentID:  entity.ParentID,
-		Order:     entity.Order,
-		Visible:   entity.Visible,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
+		ID:         entity.ID,
+		Title:      entity.Title,
+		Path:       entity.Path,
+		Icon:       entity.Icon,
+		ParentID:   entity.ParentID,
+		Order:      entity.Order,
+		Visible:    entity.Visible,
+		Permission: entity.Permission,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
 	}
 
 	if entity.DeletedAt != nil {
@@ -59,15 +62,16 @@ func (m *MenuModel) ToEntity() *menu.Menu {
 	}
 
 	entity := &menu.Menu{
-		ID:        m.ID,
-		Title:     m.Title,
-		Path:      m.Path,
-		Icon:      m.Icon,
-		ParentID:  m.ParentID,
-		Order:     m.Order,
-		Visible:   m.Visible,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
+		ID:         m.ID,
+		Title:      m.Title,
+		Path:       m.Path,
+		Icon:       m.Icon,
+		ParentID:   m.ParentID,
+		Order:      m.Order,
+		Visible:    m.Visible,
+		Permission: m.Permission,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
 	}
 
 	if m.DeletedAt.Valid {
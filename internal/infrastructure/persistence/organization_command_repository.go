@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"gorm.io/gorm"
+)
+
+// organizationCommandRepository 组织命令仓储的 GORM 实现
+// 嵌入 GenericCommandRepository 以复用基础 CRUD 操作
+type organizationCommandRepository struct {
+	*GenericCommandRepository[organization.Organization, *OrganizationModel]
+}
+
+// NewOrganizationCommandRepository 创建组织命令仓储实例
+func NewOrganizationCommandRepository(db *gorm.DB) organization.CommandRepository {
+	return &organizationCommandRepository{
+		GenericCommandRepository: NewGenericCommandRepository(
+			db, newOrganizationModelFromEntity,
+		),
+	}
+}
+
+// Create、Update 方法由 GenericCommandRepository 提供
+
+// Delete 删除组织，并移除所有成员关系
+func (r *organizationCommandRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var members []OrganizationMemberModel
+		if err := tx.Where("organization_id = ?", id).Find(&members).Error; err != nil {
+			return fmt.Errorf("failed to find members: %w", err)
+		}
+		for i := range members {
+			if err := tx.Model(&members[i]).Association("Roles").Clear(); err != nil {
+				return fmt.Errorf("failed to clear member roles: %w", err)
+			}
+		}
+		if err := tx.Where("organization_id = ?", id).Delete(&OrganizationMemberModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete members: %w", err)
+		}
+		if err := tx.Delete(&OrganizationModel{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		return nil
+	})
+}
+
+// AddMember 添加组织成员
+func (r *organizationCommandRepository) AddMember(ctx context.Context, member *organization.Member) error {
+	model := &OrganizationMemberModel{
+		OrganizationID: member.OrganizationID,
+		UserID:         member.UserID,
+	}
+	if err := r.DB().WithContext(ctx).Omit("Roles").Create(model).Error; err != nil {
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+	if saved := model.ToEntity(); saved != nil {
+		*member = *saved
+	}
+	return nil
+}
+
+// RemoveMember 移除组织成员
+func (r *organizationCommandRepository) RemoveMember(ctx context.Context, organizationID, userID uint) error {
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		model, err := r.findMember(tx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(model).Association("Roles").Clear(); err != nil {
+			return fmt.Errorf("failed to clear member roles: %w", err)
+		}
+		if err := tx.Delete(model).Error; err != nil {
+			return fmt.Errorf("failed to remove member: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetMemberRoles 设置成员在组织内的角色（替换现有角色）
+func (r *organizationCommandRepository) SetMemberRoles(ctx context.Context, organizationID, userID uint, roleIDs []uint) error {
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		model, err := r.findMember(tx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+
+		var roles []RoleModel
+		if len(roleIDs) > 0 {
+			if err := tx.Where("id IN ?", roleIDs).Find(&roles).Error; err != nil {
+				return fmt.Errorf("failed to find roles: %w", err)
+			}
+		}
+		if err := tx.Model(model).Association("Roles").Replace(roles); err != nil {
+			return fmt.Errorf("failed to set member roles: %w", err)
+		}
+		return nil
+	})
+}
+
+// findMember 查找成员记录，不存在时返回 ErrNotMember
+func (r *organizationCommandRepository) findMember(tx *gorm.DB, organizationID, userID uint) (*OrganizationMemberModel, error) {
+	var model OrganizationMemberModel
+	if err := tx.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, organization.ErrNotMember
+		}
+		return nil, fmt.Errorf("failed to find member: %w", err)
+	}
+	return &model, nil
+}
@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"gorm.io/gorm"
+)
+
+// OrganizationModel 定义组织的 GORM 持久化模型
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type OrganizationModel struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Name        string         `gorm:"size:100;not null"`
+	Slug        string         `gorm:"size:63;uniqueIndex;not null"`
+	Description string         `gorm:"size:255"`
+	Status      string         `gorm:"size:20;default:'active';not null"`
+}
+
+// TableName 指定组织表名
+func (OrganizationModel) TableName() string {
+	return "organizations"
+}
+
+func newOrganizationModelFromEntity(entity *organization.Organization) *OrganizationModel {
+	if entity == nil {
+		return nil
+	}
+
+	model := &OrganizationModel{
+		ID:          entity.ID,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+		Name:        entity.Name,
+		Slug:        entity.Slug,
+		Description: entity.Description,
+		Status:      entity.Status,
+	}
+
+	if entity.DeletedAt != nil {
+		model.DeletedAt = gorm.DeletedAt{Time: *entity.DeletedAt, Valid: true}
+	}
+
+	return model
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity（实现 Model[E] 接口）
+func (m *OrganizationModel) ToEntity() *organization.Organization {
+	if m == nil {
+		return nil
+	}
+
+	entity := &organization.Organization{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Name:        m.Name,
+		Slug:        m.Slug,
+		Description: m.Description,
+		Status:      m.Status,
+	}
+
+	if m.DeletedAt.Valid {
+		t := m.DeletedAt.Time
+		entity.DeletedAt = &t
+	}
+
+	return entity
+}
+
+// OrganizationMemberModel 定义组织成员的 GORM 持久化模型
+// 成员在组织内持有的角色保存在 organization_member_roles 关联表
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type OrganizationMemberModel struct {
+	ID             uint `gorm:"primaryKey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID uint        `gorm:"uniqueIndex:idx_org_members_org_user,priority:1;not null"`
+	UserID         uint        `gorm:"uniqueIndex:idx_org_members_org_user,priority:2;index;not null"`
+	Roles          []RoleModel `gorm:"many2many:organization_member_roles;joinForeignKey:MemberID;joinReferences:RoleID"`
+}
+
+// TableName 指定组织成员表名
+func (OrganizationMemberModel) TableName() string {
+	return "organization_members"
+}
+
+// sharedAcrossTenants 成员关系严格按租户隔离
+func (OrganizationMemberModel) sharedAcrossTenants() bool {
+	return false
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *OrganizationMemberModel) ToEntity() *organization.Member {
+	if m == nil {
+		return nil
+	}
+
+	return &organization.Member{
+		ID:             m.ID,
+		OrganizationID: m.OrganizationID,
+		UserID:         m.UserID,
+		Roles:          mapRoleModelsToEntities(m.Roles),
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
@@ -0,0 +1,141 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"gorm.io/gorm"
+)
+
+// organizationQueryRepository 组织查询仓储的 GORM 实现
+type organizationQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationQueryRepository 创建组织查询仓储实例
+func NewOrganizationQueryRepository(db *gorm.DB) organization.QueryRepository {
+	return &organizationQueryRepository{db: db}
+}
+
+// GetByID 根据 ID 获取组织
+func (r *organizationQueryRepository) GetByID(ctx context.Context, id uint) (*organization.Organization, error) {
+	var model OrganizationModel
+	if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, organization.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization by id: %w", err)
+	}
+	return model.ToEntity(), nil
+}
+
+// GetBySlug 根据标识获取组织
+func (r *organizationQueryRepository) GetBySlug(ctx context.Context, slug string) (*organization.Organization, error) {
+	var model OrganizationModel
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, organization.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization by slug: %w", err)
+	}
+	return model.ToEntity(), nil
+}
+
+// ExistsBySlug 检查组织标识是否已存在
+func (r *organizationQueryRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&OrganizationModel{}).Where("slug = ?", slug).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check organization slug: %w", err)
+	}
+	return count > 0, nil
+}
+
+// List 分页获取组织列表
+func (r *organizationQueryRepository) List(ctx context.Context, offset, limit int) ([]*organization.Organization, int64, error) {
+	var total int64
+	query := r.db.WithContext(ctx).Model(&OrganizationModel{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count organizations: %w", err)
+	}
+
+	var models []OrganizationModel
+	if err := query.Order("id ASC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return mapOrganizationModels(models), total, nil
+}
+
+// ListByUser 获取用户加入的所有组织
+func (r *organizationQueryRepository) ListByUser(ctx context.Context, userID uint) ([]*organization.Organization, error) {
+	var models []OrganizationModel
+	if err := r.db.WithContext(ctx).
+		Where("id IN (?)", r.db.Model(&OrganizationMemberModel{}).Select("organization_id").Where("user_id = ?", userID)).
+		Order("name ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list organizations by user: %w", err)
+	}
+	return mapOrganizationModels(models), nil
+}
+
+// GetMember 获取组织成员（包含角色及继承权限）
+func (r *organizationQueryRepository) GetMember(ctx context.Context, organizationID, userID uint) (*organization.Member, error) {
+	var model OrganizationMemberModel
+	if err := r.db.WithContext(ctx).
+		Preload("Roles.Permissions").
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, organization.ErrNotMember
+		}
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	member := model.ToEntity()
+	if err := attachInheritedPermissions(ctx, r.db, member.Roles); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// ListMembers 分页获取组织成员（包含角色）
+func (r *organizationQueryRepository) ListMembers(ctx context.Context, organizationID uint, offset, limit int) ([]*organization.Member, int64, error) {
+	var total int64
+	query := r.db.WithContext(ctx).Model(&OrganizationMemberModel{}).Where("organization_id = ?", organizationID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count members: %w", err)
+	}
+
+	var models []OrganizationMemberModel
+	if err := query.Preload("Roles").Order("id ASC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	members := make([]*organization.Member, 0, len(models))
+	for i := range models {
+		members = append(members, models[i].ToEntity())
+	}
+	return members, total, nil
+}
+
+// GetUserIDsByRole 获取在任意组织内持有指定角色的用户 ID
+func (r *organizationQueryRepository) GetUserIDsByRole(ctx context.Context, roleID uint) ([]uint, error) {
+	var userIDs []uint
+	if err := r.db.WithContext(ctx).
+		Model(&OrganizationMemberModel{}).
+		Distinct("user_id").
+		Where("id IN (?)", r.db.Table("organization_member_roles").Select("member_id").Where("role_id = ?", roleID)).
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get member user ids by role: %w", err)
+	}
+	return userIDs, nil
+}
+
+func mapOrganizationModels(models []OrganizationModel) []*organization.Organization {
+	orgs := make([]*organization.Organization, 0, len(models))
+	for i := range models {
+		orgs = append(orgs, models[i].ToEntity())
+	}
+	return orgs
+}
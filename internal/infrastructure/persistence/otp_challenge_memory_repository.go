@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+const (
+	// otpChallengeCleanupInterval 清理间隔（10分钟）
+	otpChallengeCleanupInterval = 10 * time.Minute
+	// otpChallengeRetention 挑战记录保留时间（覆盖限频统计窗口）
+	otpChallengeRetention = 2 * time.Hour
+)
+
+// otpChallengeMemoryRepository 内存一次性验证码挑战仓储实现
+// 🔒 安全策略：
+// - 并发安全（使用 sync.Mutex）
+// - 存取均复制实体，避免调用方绕过仓储修改状态
+// - 读取与保存在同一把锁内完成（Update），验证码不会被并发重复消费
+// - 自动清理长时间未发送的挑战（保留期覆盖限频窗口）
+type otpChallengeMemoryRepository struct {
+	data      map[string]*twofa.OTPChallenge
+	mu        sync.Mutex
+	stopClean chan struct{}
+}
+
+var _ twofa.ChallengeRepository = (*otpChallengeMemoryRepository)(nil)
+
+// NewOTPChallengeMemoryRepository 创建内存验证码挑战仓储
+func NewOTPChallengeMemoryRepository() *otpChallengeMemoryRepository {
+	repo := &otpChallengeMemoryRepository{
+		data:      make(map[string]*twofa.OTPChallenge),
+		stopClean: make(chan struct{}),
+	}
+
+	// 启动定期清理协程
+	go repo.cleanupExpired()
+
+	return repo
+}
+
+// Update 在互斥锁内完成读取、修改与保存
+func (r *otpChallengeMemoryRepository) Update(ctx context.Context, userID uint, method twofa.Method, purpose twofa.OTPPurpose, fn func(*twofa.OTPChallenge) (*twofa.OTPChallenge, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := otpChallengeKey(userID, method, purpose)
+	var current *twofa.OTPChallenge
+	if c, ok := r.data[key]; ok {
+		cp := *c
+		current = &cp
+	}
+
+	next, err := fn(current)
+	if next != nil {
+		cp := *next
+		r.data[key] = &cp
+	}
+	return err
+}
+
+// Delete 删除挑战
+func (r *otpChallengeMemoryRepository) Delete(ctx context.Context, userID uint, method twofa.Method, purpose twofa.OTPPurpose) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.data, otpChallengeKey(userID, method, purpose))
+	return nil
+}
+
+// Close 关闭仓储（停止清理协程）
+func (r *otpChallengeMemoryRepository) Close() error {
+	close(r.stopClean)
+	return nil
+}
+
+// cleanupExpired 定期清理长时间未使用的挑战
+func (r *otpChallengeMemoryRepository) cleanupExpired() {
+	ticker := time.NewTicker(otpChallengeCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.mu.Lock()
+			for key, c := range r.data {
+				if time.Since(c.LastSentAt) > otpChallengeRetention {
+					delete(r.data, key)
+				}
+			}
+			r.mu.Unlock()
+		case <-r.stopClean:
+			return
+		}
+	}
+}
+
+func otpChallengeKey(userID uint, method twofa.Method, purpose twofa.OTPPurpose) string {
+	return fmt.Sprintf("%d:%s:%s", userID, method, purpose)
+}
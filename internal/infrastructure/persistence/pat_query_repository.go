@@ -44,7 +44,7 @@ func (r *patQueryRepository) FindByID(ctx context.Context, id uint) (*pat.Person
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("token not found")
+			return nil, pat.ErrTokenNotFound
 		}
 		return nil, fmt.Errorf("failed to find PAT by ID: %w", err)
 	}
@@ -75,6 +75,15 @@ func (p *permissionQueryRepository) List(ctx context.Context, page, limit int) (
 	return mapPermissionModelsToEntities(models), total, nil
 }
 
+// ListAll 获取全部权限（按代码排序）
+func (p *permissionQueryRepository) ListAll(ctx context.Context) ([]role.Permission, error) {
+	var models []PermissionModel
+	if err := p.db.WithContext(ctx).Order("code").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list all permissions: %w", err)
+	}
+	return mapPermissionModelsToEntities(models), nil
+}
+
 // ListByResource 根据资源获取权限列表
 func (p *permissionQueryRepository) ListByResource(ctx context.Context, resource string) ([]role.Permission, error) {
 	var models []PermissionModel
@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// applyPolicyFilter 将 ABAC 策略过滤条件转换为查询条件
+// columns 为资源属性到数据库列的白名单映射，未登记的属性视为无法转换
+func applyPolicyFilter(query *gorm.DB, filter policy.Filter, columns map[string]string) (*gorm.DB, error) {
+	if filter.Unrestricted {
+		return query, nil
+	}
+	if filter.MatchesNothing() {
+		return query.Where("1 = 0"), nil
+	}
+
+	alternatives := make([]clause.Expression, 0, len(filter.Alternatives))
+	for _, predicates := range filter.Alternatives {
+		conditions := make([]clause.Expression, 0, len(predicates))
+		for _, p := range predicates {
+			column, ok := columns[p.Field]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown resource attribute %q", policy.ErrUnsupportedFilter, p.Field)
+			}
+			if p.Op == policy.OpNotEqual {
+				conditions = append(conditions, clause.Neq{Column: clause.Column{Name: column}, Value: p.Value})
+			} else {
+				conditions = append(conditions, clause.Eq{Column: clause.Column{Name: column}, Value: p.Value})
+			}
+		}
+		alternatives = append(alternatives, clause.And(conditions...))
+	}
+	return query.Where(clause.Or(alternatives...)), nil
+}
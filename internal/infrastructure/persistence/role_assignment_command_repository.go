@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// roleAssignmentCommandRepository 角色授权命令仓储的 GORM 实现
+type roleAssignmentCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleAssignmentCommandRepository 创建角色授权命令仓储实例
+func NewRoleAssignmentCommandRepository(db *gorm.DB) user.RoleAssignmentCommandRepository {
+	return &roleAssignmentCommandRepository{db: db}
+}
+
+// Grant 创建或覆盖用户对某角色的授权
+func (r *roleAssignmentCommandRepository) Grant(ctx context.Context, assignment *user.RoleAssignment) error {
+	model := newUserRoleModelFromEntity(assignment)
+	if err := r.db.WithContext(ctx).
+		Omit("Role").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "role_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"starts_at", "expires_at", "activated_at"}),
+		}).
+		Create(model).Error; err != nil {
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	assignment.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// MarkActivated 标记计划授权已激活
+func (r *roleAssignmentCommandRepository) MarkActivated(ctx context.Context, userID, roleID uint, at time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Model(&UserRoleModel{}).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Update("activated_at", at).Error; err != nil {
+		return fmt.Errorf("failed to mark role assignment activated: %w", err)
+	}
+	return nil
+}
+
+// Expire 移除已到期的授权
+// 条件中再次校验到期时间，避免删除在此期间被重新授予的授权
+func (r *roleAssignmentCommandRepository) Expire(ctx context.Context, userID, roleID uint, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND role_id = ? AND expires_at IS NOT NULL AND expires_at <= ?", userID, roleID, now).
+		Delete(&UserRoleModel{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to expire role assignment: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// roleAssignmentQueryRepository 角色授权查询仓储的 GORM 实现
+// 全部查询使用 Unscoped 跳过生效时间窗口作用域
+type roleAssignmentQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleAssignmentQueryRepository 创建角色授权查询仓储实例
+func NewRoleAssignmentQueryRepository(db *gorm.DB) user.RoleAssignmentQueryRepository {
+	return &roleAssignmentQueryRepository{db: db}
+}
+
+// ListByUser 获取用户的全部角色授权
+func (r *roleAssignmentQueryRepository) ListByUser(ctx context.Context, userID uint) ([]*user.RoleAssignment, error) {
+	var models []UserRoleModel
+	if err := r.db.WithContext(ctx).Unscoped().
+		Preload("Role").
+		Where("user_id = ?", userID).
+		Order("role_id").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list role assignments: %w", err)
+	}
+	return mapUserRoleModelsToEntities(models), nil
+}
+
+// ListDueActivations 获取已到开始时间但尚未激活的计划授权
+func (r *roleAssignmentQueryRepository) ListDueActivations(ctx context.Context, now time.Time, limit int) ([]*user.RoleAssignment, error) {
+	var models []UserRoleModel
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("activated_at IS NULL AND starts_at IS NOT NULL AND starts_at <= ?", now).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("starts_at").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list due role activations: %w", err)
+	}
+	return mapUserRoleModelsToEntities(models), nil
+}
+
+// ListExpired 获取已到期的授权
+func (r *roleAssignmentQueryRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*user.RoleAssignment, error) {
+	var models []UserRoleModel
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("expires_at IS NOT NULL AND expires_at <= ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expired role assignments: %w", err)
+	}
+	return mapUserRoleModelsToEntities(models), nil
+}
+
+// ListExpiring 获取在 (now, until] 内到期的生效授权
+func (r *roleAssignmentQueryRepository) ListExpiring(ctx context.Context, now, until time.Time, offset, limit int) ([]*user.RoleAssignment, int64, error) {
+	query := r.db.WithContext(ctx).Unscoped().
+		Model(&UserRoleModel{}).
+		Where("expires_at > ? AND expires_at <= ?", now, until).
+		Where("starts_at IS NULL OR starts_at <= ?", now)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count expiring role assignments: %w", err)
+	}
+
+	var models []UserRoleModel
+	if err := query.
+		Preload("Role").
+		Order("expires_at").
+		Offset(offset).
+		Limit(limit).
+		Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list expiring role assignments: %w", err)
+	}
+	return mapUserRoleModelsToEntities(models), total, nil
+}
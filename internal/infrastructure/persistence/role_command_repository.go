@@ -7,6 +7,7 @@ import (
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // roleCommandRepository 角色命令仓储的 GORM 实现
@@ -24,13 +25,50 @@ func NewRoleCommandRepository(db *gorm.DB) role.CommandRepository {
 	}
 }
 
-// Create、Update、Delete 方法由 GenericCommandRepository 提供
+// Create、Update 方法由 GenericCommandRepository 提供
+
+// Delete 删除角色，并解除子角色对其的继承关系
+// 租户上下文中不能删除全局角色或其他组织的角色
+func (r *roleCommandRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model RoleModel
+		if err := tx.First(&model, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to find role: %w", err)
+		}
+		if err := ensureTenantWritable(ctx, model.OrganizationID); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&RoleModel{}).Where("parent_id = ?", id).Update("parent_id", nil).Error; err != nil {
+			return fmt.Errorf("failed to detach child roles: %w", err)
+		}
+		if err := tx.Delete(&RoleModel{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		return nil
+	})
+}
 
 // SetPermissions 设置角色权限 (替换现有权限)
+// 被移除权限上附加的策略条件一并清除
 func (r *roleCommandRepository) SetPermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
-	return r.modifyPermissions(ctx, roleID, permissionIDs,
+	if err := r.modifyPermissions(ctx, roleID, permissionIDs,
 		func(assoc *gorm.Association, perms []PermissionModel) error { return assoc.Replace(perms) },
-		"failed to set permissions")
+		"failed to set permissions"); err != nil {
+		return err
+	}
+
+	query := r.DB().WithContext(ctx).Where("role_id = ?", roleID)
+	if len(permissionIDs) > 0 {
+		query = query.Where("permission_id NOT IN ?", permissionIDs)
+	}
+	if err := query.Delete(&RolePermissionPolicyModel{}).Error; err != nil {
+		return fmt.Errorf("failed to clean up permission conditions: %w", err)
+	}
+	return nil
 }
 
 // AddPermissions 为角色添加权限
@@ -47,7 +85,38 @@ func (r *roleCommandRepository) RemovePermissions(ctx context.Context, roleID ui
 		"failed to remove permissions")
 }
 
+// SetPermissionCondition 为角色权限授予设置策略条件，条件为空时移除
+func (r *roleCommandRepository) SetPermissionCondition(ctx context.Context, roleID, permissionID uint, condition string) error {
+	db := r.DB().WithContext(ctx)
+
+	var roleModel RoleModel
+	if err := db.Select("id", "organization_id").First(&roleModel, roleID).Error; err != nil {
+		return fmt.Errorf("failed to find role: %w", err)
+	}
+	if err := ensureTenantWritable(ctx, roleModel.OrganizationID); err != nil {
+		return err
+	}
+
+	if condition == "" {
+		if err := db.Where("role_id = ? AND permission_id = ?", roleID, permissionID).
+			Delete(&RolePermissionPolicyModel{}).Error; err != nil {
+			return fmt.Errorf("failed to remove permission condition: %w", err)
+		}
+		return nil
+	}
+
+	model := RolePermissionPolicyModel{RoleID: roleID, PermissionID: permissionID, Condition: condition}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role_id"}, {Name: "permission_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"condition", "updated_at"}),
+	}).Create(&model).Error; err != nil {
+		return fmt.Errorf("failed to set permission condition: %w", err)
+	}
+	return nil
+}
+
 // modifyPermissions 通用权限操作方法，减少重复代码
+// 关联表写入不经过租户回调，需显式检查角色归属
 func (r *roleCommandRepository) modifyPermissions(ctx context.Context, roleID uint, permissionIDs []uint, operation func(*gorm.Association, []PermissionModel) error, errMsg string) error {
 	var roleModel RoleModel
 	if err := r.DB().WithContext(ctx).First(&roleModel, roleID).Error; err != nil {
@@ -56,6 +125,9 @@ func (r *roleCommandRepository) modifyPermissions(ctx context.Context, roleID ui
 		}
 		return fmt.Errorf("failed to find role: %w", err)
 	}
+	if err := ensureTenantWritable(ctx, roleModel.OrganizationID); err != nil {
+		return err
+	}
 
 	var permissions []PermissionModel
 	if err := r.DB().WithContext(ctx).Find(&permissions, permissionIDs).Error; err != nil {
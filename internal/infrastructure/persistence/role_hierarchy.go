@@ -0,0 +1,102 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
+	"gorm.io/gorm"
+)
+
+// loadRoleHierarchy 读取所有未删除角色的父子关系
+// 角色数量有限，整体加载后在内存中遍历，避免依赖数据库的递归查询能力
+func loadRoleHierarchy(ctx context.Context, db *gorm.DB) (role.Hierarchy, error) {
+	var rows []struct {
+		ID       uint
+		ParentID uint
+	}
+	if err := db.WithContext(ctx).
+		Model(&RoleModel{}).
+		Select("id", "parent_id").
+		Where("parent_id IS NOT NULL").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load role hierarchy: %w", err)
+	}
+
+	hierarchy := make(role.Hierarchy, len(rows))
+	for _, row := range rows {
+		hierarchy[row.ID] = row.ParentID
+	}
+	return hierarchy, nil
+}
+
+// loadRolePermissions 批量读取角色的直接权限，按角色 ID 分组
+func loadRolePermissions(ctx context.Context, db *gorm.DB, roleIDs []uint) (map[uint][]role.Permission, error) {
+	if len(roleIDs) == 0 {
+		return map[uint][]role.Permission{}, nil
+	}
+
+	var models []RoleModel
+	if err := db.WithContext(ctx).
+		Preload("Permissions").
+		Where("id IN ?", roleIDs).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to load role permissions: %w", err)
+	}
+
+	result := make(map[uint][]role.Permission, len(models))
+	for i := range models {
+		result[models[i].ID] = mapPermissionModelsToEntities(models[i].Permissions)
+	}
+	return result, nil
+}
+
+// unionPermissions 按 roleIDs 顺序合并各角色的权限并按权限 ID 去重
+func unionPermissions(permissionsByRole map[uint][]role.Permission, roleIDs []uint) []role.Permission {
+	seen := make(map[uint]struct{})
+	var permissions []role.Permission
+	for _, id := range roleIDs {
+		for _, p := range permissionsByRole[id] {
+			if _, ok := seen[p.ID]; ok {
+				continue
+			}
+			seen[p.ID] = struct{}{}
+			permissions = append(permissions, p)
+		}
+	}
+	return permissions
+}
+
+// attachInheritedPermissions 为角色填充从祖先角色继承的权限
+// 已删除的祖先角色及其权限不会被继承
+func attachInheritedPermissions(ctx context.Context, db *gorm.DB, roles []role.Role) error {
+	if len(roles) == 0 {
+		return nil
+	}
+
+	hierarchy, err := loadRoleHierarchy(ctx, db)
+	if err != nil {
+		return err
+	}
+	if len(hierarchy) == 0 {
+		return nil
+	}
+
+	ancestorsByRole := make(map[uint][]uint, len(roles))
+	var ancestorIDs []uint
+	for i := range roles {
+		ancestors := hierarchy.Ancestors(roles[i].ID)
+		ancestorsByRole[roles[i].ID] = ancestors
+		ancestorIDs = append(ancestorIDs, ancestors...)
+	}
+
+	permissionsByRole, err := loadRolePermissions(ctx, db, ancestorIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range roles {
+		roles[i].InheritedPermissions = unionPermissions(permissionsByRole, ancestorsByRole[roles[i].ID])
+	}
+	return nil
+}
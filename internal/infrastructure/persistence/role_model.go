@@ -14,12 +14,15 @@ type RoleModel struct {
 	ID          uint `gorm:"primaryKey"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt    `gorm:"index"`
-	Name        string            `gorm:"size:50;uniqueIndex;not null"`
-	DisplayName string            `gorm:"size:100;not null"`
-	Description string            `gorm:"size:255"`
-	IsSystem    bool              `gorm:"default:false;not null"`
-	Permissions []PermissionModel `gorm:"many2many:role_permissions;"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Name        string         `gorm:"size:50;uniqueIndex:idx_roles_global_name,where:organization_id IS NULL;uniqueIndex:idx_roles_org_name,priority:2;not null"`
+	DisplayName string         `gorm:"size:100;not null"`
+	Description string         `gorm:"size:255"`
+	IsSystem    bool           `gorm:"default:false;not null"`
+	ParentID    *uint          `gorm:"index"`
+	// OrganizationID 所属组织，为空表示全局角色；角色名在全局角色之间、同一组织内分别唯一
+	OrganizationID *uint             `gorm:"uniqueIndex:idx_roles_org_name,priority:1"`
+	Permissions    []PermissionModel `gorm:"many2many:role_permissions;"`
 }
 
 // TableName 指定角色表名
@@ -27,20 +30,27 @@ func (RoleModel) TableName() string {
 	return "roles"
 }
 
+// sharedAcrossTenants 全局角色（organization_id 为空）对所有租户可见
+func (RoleModel) sharedAcrossTenants() bool {
+	return true
+}
+
 func newRoleModelFromEntity(entity *role.Role) *RoleModel {
 	if entity == nil {
 		return nil
 	}
 
 	model := &RoleModel{
-		ID:          entity.ID,
-		CreatedAt:   entity.CreatedAt,
-		UpdatedAt:   entity.UpdatedAt,
-		Name:        entity.Name,
-		DisplayName: entity.DisplayName,
-		Description: entity.Description,
-		IsSystem:    entity.IsSystem,
-		Permissions: mapPermissionEntitiesToModels(entity.Permissions),
+		ID:             entity.ID,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+		Name:           entity.Name,
+		DisplayName:    entity.DisplayName,
+		Description:    entity.Description,
+		IsSystem:       entity.IsSystem,
+		ParentID:       entity.ParentID,
+		OrganizationID: entity.OrganizationID,
+		Permissions:    mapPermissionEntitiesToModels(entity.Permissions),
 	}
 
 	if entity.DeletedAt != nil {
@@ -57,14 +67,16 @@ func (m *RoleModel) ToEntity() *role.Role {
 	}
 
 	entity := &role.Role{
-		ID:          m.ID,
-		CreatedAt:   m.CreatedAt,
-		UpdatedAt:   m.UpdatedAt,
-		Name:        m.Name,
-		DisplayName: m.DisplayName,
-		Description: m.Description,
-		IsSystem:    m.IsSystem,
-		Permissions: mapPermissionModelsToEntities(m.Permissions),
+		ID:             m.ID,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+		Name:           m.Name,
+		DisplayName:    m.DisplayName,
+		Description:    m.Description,
+		IsSystem:       m.IsSystem,
+		ParentID:       m.ParentID,
+		OrganizationID: m.OrganizationID,
+		Permissions:    mapPermissionModelsToEntities(m.Permissions),
 	}
 
 	if m.DeletedAt.Valid {
@@ -0,0 +1,20 @@
+package persistence
+
+import "time"
+
+// RolePermissionPolicyModel 角色权限授予上附加的 ABAC 策略条件
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type RolePermissionPolicyModel struct {
+	ID           uint `gorm:"primaryKey"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	RoleID       uint   `gorm:"not null;uniqueIndex:idx_role_permission_policy"`
+	PermissionID uint   `gorm:"not null;uniqueIndex:idx_role_permission_policy"`
+	Condition    string `gorm:"size:1000;not null"`
+}
+
+// TableName 指定角色权限策略表名
+func (RolePermissionPolicyModel) TableName() string {
+	return "role_permission_policies"
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/role"
 	"gorm.io/gorm"
@@ -118,3 +120,62 @@ func (r *roleQueryRepository) ExistsByName(ctx context.Context, name string) (bo
 	}
 	return count > 0, nil
 }
+
+// GetHierarchy 获取所有角色的继承关系
+func (r *roleQueryRepository) GetHierarchy(ctx context.Context) (role.Hierarchy, error) {
+	return loadRoleHierarchy(ctx, r.db)
+}
+
+// GetEffectivePermissions 获取角色及其所有祖先角色权限的并集
+func (r *roleQueryRepository) GetEffectivePermissions(ctx context.Context, roleID uint) ([]role.Permission, error) {
+	hierarchy, err := loadRoleHierarchy(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs := append([]uint{roleID}, hierarchy.Ancestors(roleID)...)
+	permissionsByRole, err := loadRolePermissions(ctx, r.db, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := unionPermissions(permissionsByRole, roleIDs)
+	slices.SortFunc(permissions, func(a, b role.Permission) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return permissions, nil
+}
+
+// GetGrants 获取角色的直接权限授予及其策略条件
+func (r *roleQueryRepository) GetGrants(ctx context.Context, roleIDs []uint) ([]role.Grant, error) {
+	permissionsByRole, err := loadRolePermissions(ctx, r.db, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []RolePermissionPolicyModel
+	if len(roleIDs) > 0 {
+		if err := r.db.WithContext(ctx).Where("role_id IN ?", roleIDs).Find(&policies).Error; err != nil {
+			return nil, fmt.Errorf("failed to load permission conditions: %w", err)
+		}
+	}
+
+	type grantKey struct{ roleID, permissionID uint }
+	conditions := make(map[grantKey]string, len(policies))
+	for _, p := range policies {
+		conditions[grantKey{p.RoleID, p.PermissionID}] = p.Condition
+	}
+
+	var grants []role.Grant
+	for _, roleID := range roleIDs {
+		for _, p := range permissionsByRole[roleID] {
+			grants = append(grants, role.Grant{
+				RoleID:         roleID,
+				PermissionID:   p.ID,
+				PermissionCode: p.Code,
+				Condition:      conditions[grantKey{roleID, p.ID}],
+			})
+		}
+	}
+	return grants, nil
+}
@@ -9,6 +9,10 @@ import (
 type SettingRepositories struct {
 	Command setting.CommandRepository
 	Query   setting.QueryRepository
+
+	// 用户偏好覆盖值
+	UserCommand setting.UserSettingCommandRepository
+	UserQuery   setting.UserSettingQueryRepository
 }
 
 // NewSettingRepositories 创建配置仓储聚合实例
@@ -16,5 +20,8 @@ func NewSettingRepositories(db *gorm.DB) SettingRepositories {
 	return SettingRepositories{
 		Command: NewSettingCommandRepository(db),
 		Query:   NewSettingQueryRepository(db),
+
+		UserCommand: NewUserSettingCommandRepository(db),
+		UserQuery:   NewUserSettingQueryRepository(db),
 	}
 }
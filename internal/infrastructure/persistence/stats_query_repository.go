@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/stats"
 	"gorm.io/gorm"
@@ -67,6 +68,19 @@ func (r *statsQueryRepository) GetSystemStats(recentLogsLimit int) (*stats.Syste
 	}
 	s.TotalMenus = menus
 
+	// 统计限时角色授权
+	temporary, err := r.GetTemporaryRoleGrants()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get temporary role grants: %w", err)
+	}
+	s.TemporaryRoleGrants = temporary
+
+	scheduled, err := r.GetScheduledRoleGrants()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled role grants: %w", err)
+	}
+	s.ScheduledRoleGrants = scheduled
+
 	// 获取最近审计日志
 	logs, err := r.GetRecentAuditLogs(recentLogsLimit)
 	if err != nil {
@@ -137,6 +151,32 @@ func (r *statsQueryRepository) GetTotalMenus() (int64, error) {
 	return count, nil
 }
 
+// GetTemporaryRoleGrants 获取生效中的限时角色授权数量
+func (r *statsQueryRepository) GetTemporaryRoleGrants() (int64, error) {
+	var count int64
+	now := time.Now()
+	err := r.db.Table("user_roles").
+		Where("expires_at > ?", now).
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetScheduledRoleGrants 获取尚未开始的计划角色授权数量
+func (r *statsQueryRepository) GetScheduledRoleGrants() (int64, error) {
+	var count int64
+	err := r.db.Table("user_roles").
+		Where("starts_at > ?", time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // GetRecentAuditLogs 获取最近的审计日志
 func (r *statsQueryRepository) GetRecentAuditLogs(limit int) ([]stats.AuditLogSummary, error) {
 	var logs []stats.AuditLogSummary
@@ -0,0 +1,187 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/organization"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 租户隔离字段
+const (
+	tenantField  = "OrganizationID"
+	tenantColumn = "organization_id"
+)
+
+// tenantScopedModel 按租户隔离的 GORM 模型（需包含 OrganizationID 字段）
+//
+// 当 context 携带租户（见 organization.WithTenant）时，
+// RegisterTenantScope 注册的回调自动为查询、更新、删除附加 organization_id 条件，
+// 并为新建记录填充 organization_id，仓储实现无需手动处理租户条件。
+type tenantScopedModel interface {
+	// sharedAcrossTenants 返回 true 时 organization_id 为 NULL 的全局记录对所有租户可读（不可写）
+	sharedAcrossTenants() bool
+}
+
+// RegisterTenantScope 为数据库连接注册租户隔离回调
+// 未携带租户的 context 视为平台级访问，不附加任何条件
+func RegisterTenantScope(db *gorm.DB) error {
+	callbacks := []struct {
+		name     string
+		register func() error
+	}{
+		{"tenant:query", func() error {
+			return db.Callback().Query().Before("gorm:query").Register("tenant:query", tenantReadScope)
+		}},
+		{"tenant:row", func() error {
+			return db.Callback().Row().Before("gorm:row").Register("tenant:row", tenantReadScope)
+		}},
+		{"tenant:update", func() error {
+			return db.Callback().Update().Before("gorm:update").Register("tenant:update", tenantWriteScope)
+		}},
+		{"tenant:delete", func() error {
+			return db.Callback().Delete().Before("gorm:delete").Register("tenant:delete", tenantWriteScope)
+		}},
+		{"tenant:create", func() error {
+			return db.Callback().Create().Before("gorm:create").Register("tenant:create", tenantAssign)
+		}},
+	}
+
+	for _, cb := range callbacks {
+		if err := cb.register(); err != nil {
+			return fmt.Errorf("failed to register %s callback: %w", cb.name, err)
+		}
+	}
+	return nil
+}
+
+// tenantOf 返回语句的当前租户及模型的租户隔离方式
+func tenantOf(db *gorm.DB) (uint, tenantScopedModel, bool) {
+	if db.Statement.Schema == nil || db.Statement.Context == nil {
+		return 0, nil, false
+	}
+	tenantID, ok := organization.TenantFromContext(db.Statement.Context)
+	if !ok {
+		return 0, nil, false
+	}
+	model, ok := reflect.New(db.Statement.Schema.ModelType).Interface().(tenantScopedModel)
+	if !ok || db.Statement.Schema.LookUpField(tenantField) == nil {
+		return 0, nil, false
+	}
+	return tenantID, model, true
+}
+
+// tenantReadScope 查询时仅返回当前租户的记录（以及共享的全局记录）
+func tenantReadScope(db *gorm.DB) {
+	tenantID, model, ok := tenantOf(db)
+	if !ok {
+		return
+	}
+
+	column := clause.Column{Table: clause.CurrentTable, Name: tenantColumn}
+	condition := clause.Expression(clause.Eq{Column: column, Value: tenantID})
+	if model.sharedAcrossTenants() {
+		condition = clause.Or(condition, clause.Eq{Column: column, Value: nil})
+	}
+	addTenantCondition(db.Statement, condition)
+}
+
+// tenantWriteScope 更新、删除时仅作用于当前租户的记录，全局记录不可被租户修改
+func tenantWriteScope(db *gorm.DB) {
+	tenantID, _, ok := tenantOf(db)
+	if !ok {
+		return
+	}
+	addTenantCondition(db.Statement, clause.Eq{
+		Column: clause.Column{Table: clause.CurrentTable, Name: tenantColumn},
+		Value:  tenantID,
+	})
+}
+
+// addTenantCondition 追加租户条件
+// 已有的单个 OR 条件先整体包裹为 AND，避免与租户条件组合后改变语义（与 GORM 软删除处理一致）
+func addTenantCondition(stmt *gorm.Statement, condition clause.Expression) {
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			for _, expr := range where.Exprs {
+				if orCond, ok := expr.(clause.OrConditions); ok && len(orCond.Exprs) == 1 {
+					where.Exprs = []clause.Expression{clause.And(where.Exprs...)}
+					c.Expression = where
+					stmt.Clauses["WHERE"] = c
+					break
+				}
+			}
+		}
+	}
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{condition}})
+}
+
+// tenantAssign 新建记录时填充当前租户，拒绝写入其他租户的记录
+//
+// GORM Save 在更新未命中任何行时会回退为 ON CONFLICT 覆盖插入，
+// 租户上下文中这意味着目标记录属于其他租户或为全局记录，直接拒绝以免覆盖。
+func tenantAssign(db *gorm.DB) {
+	tenantID, _, ok := tenantOf(db)
+	if !ok {
+		return
+	}
+	if c, upsert := db.Statement.Clauses["ON CONFLICT"]; upsert {
+		// 关联保存使用 DO NOTHING，不会覆盖已有记录
+		if onConflict, ok := c.Expression.(clause.OnConflict); !ok || !onConflict.DoNothing {
+			_ = db.AddError(organization.ErrTenantMismatch)
+		}
+		return
+	}
+
+	field := db.Statement.Schema.LookUpField(tenantField)
+	assign := func(rv reflect.Value) {
+		value, isZero := field.ValueOf(db.Statement.Context, rv)
+		if isZero {
+			if err := field.Set(db.Statement.Context, rv, tenantID); err != nil {
+				_ = db.AddError(err)
+			}
+			return
+		}
+		if !sameTenant(value, tenantID) {
+			_ = db.AddError(organization.ErrTenantMismatch)
+		}
+	}
+
+	switch rv := db.Statement.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range rv.Len() {
+			assign(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		assign(rv)
+	default:
+	}
+}
+
+// sameTenant 判断字段值是否为指定租户
+func sameTenant(value any, tenantID uint) bool {
+	switch v := value.(type) {
+	case uint:
+		return v == tenantID
+	case *uint:
+		return v != nil && *v == tenantID
+	default:
+		return false
+	}
+}
+
+// ensureTenantWritable 检查已加载记录能否在当前租户上下文中修改
+// 用于不经过 GORM 更新回调的写操作（如多对多关联替换）
+func ensureTenantWritable(ctx context.Context, organizationID *uint) error {
+	tenantID, ok := organization.TenantFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if organizationID == nil || *organizationID != tenantID {
+		return organization.ErrTenantMismatch
+	}
+	return nil
+}
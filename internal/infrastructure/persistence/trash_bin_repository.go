@@ -0,0 +1,239 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/auditlog"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/pat"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// softDeleteBin 基于泛型仓储 ListDeleted/Restore/Purge 能力的回收站实现
+// 资源差异（展示名称、恢复冲突检查、从属数据清理）由回调注入
+type softDeleteBin[E any, M Model[E]] struct {
+	resource string
+	db       *gorm.DB
+
+	// describe 将实体转换为回收站条目（无需设置 Resource）
+	describe func(*E) *trash.Item
+	// checkRestore 可选，在恢复前于同一事务内检查唯一键与上级记录
+	checkRestore func(tx *gorm.DB, entity *E) error
+	// beforePurge 可选，在永久删除前于同一事务内检查引用并清理从属数据
+	beforePurge func(tx *gorm.DB, entity *E) error
+}
+
+// Resource 返回资源类型
+func (b *softDeleteBin[E, M]) Resource() string {
+	return b.resource
+}
+
+// List 按删除时间倒序分页列出已删除记录
+func (b *softDeleteBin[E, M]) List(ctx context.Context, offset, limit int) ([]*trash.Item, int64, error) {
+	entities, total, err := NewGenericQueryRepository[E, M](b.db, nil).ListDeleted(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*trash.Item, 0, len(entities))
+	for _, e := range entities {
+		item := b.describe(e)
+		item.Resource = b.resource
+		items = append(items, item)
+	}
+	return items, total, nil
+}
+
+// Restore 在事务内检查冲突并恢复记录
+func (b *softDeleteBin[E, M]) Restore(ctx context.Context, id uint) error {
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entity, err := NewGenericQueryRepository[E, M](tx, nil).GetDeletedByID(ctx, id)
+		if err != nil {
+			return translateSoftDeleteError(err)
+		}
+		if b.checkRestore != nil {
+			if err := b.checkRestore(tx, entity); err != nil {
+				return err
+			}
+		}
+		return translateSoftDeleteError(NewGenericCommandRepository[E, M](tx, nil).Restore(ctx, id))
+	})
+}
+
+// Purge 在事务内清理从属数据并永久删除记录
+func (b *softDeleteBin[E, M]) Purge(ctx context.Context, id uint) error {
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entity, err := NewGenericQueryRepository[E, M](tx, nil).GetDeletedByID(ctx, id)
+		if err != nil {
+			return translateSoftDeleteError(err)
+		}
+		if b.beforePurge != nil {
+			if err := b.beforePurge(tx, entity); err != nil {
+				return err
+			}
+		}
+		return translateSoftDeleteError(NewGenericCommandRepository[E, M](tx, nil).Purge(ctx, id))
+	})
+}
+
+// ExpiredIDs 返回删除时间早于 cutoff 的记录 ID
+func (b *softDeleteBin[E, M]) ExpiredIDs(ctx context.Context, cutoff time.Time, limit int) ([]uint, error) {
+	return NewGenericQueryRepository[E, M](b.db, nil).DeletedIDsBefore(ctx, cutoff, limit)
+}
+
+// translateSoftDeleteError 将泛型仓储的软删除错误转换为回收站领域错误
+func translateSoftDeleteError(err error) error {
+	if errors.Is(err, ErrSoftDeletedNotFound) {
+		return trash.ErrItemNotFound
+	}
+	return err
+}
+
+// deletedAtOf 返回实体删除时间，未删除时返回零值
+func deletedAtOf(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// activeRowExists 检查未删除记录中是否存在满足条件的行
+func activeRowExists(tx *gorm.DB, model any, query string, args ...any) (bool, error) {
+	var count int64
+	if err := tx.Model(model).Where(query, args...).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check conflicts: %w", err)
+	}
+	return count > 0, nil
+}
+
+// NewTrashBins 创建所有支持回收站的资源仓储
+func NewTrashBins(db *gorm.DB) []trash.Bin {
+	return []trash.Bin{
+		NewUserTrashBin(db),
+		NewMenuTrashBin(db),
+		NewTokenTrashBin(db),
+		NewAuditLogTrashBin(db),
+	}
+}
+
+// NewUserTrashBin 创建用户回收站
+// 恢复时用户名或邮箱已被其他有效用户占用视为冲突；永久删除时清理用户的从属数据并匿名化审计日志
+func NewUserTrashBin(db *gorm.DB) trash.Bin {
+	return &softDeleteBin[user.User, *UserModel]{
+		resource: trash.ResourceUsers,
+		db:       db,
+		describe: func(u *user.User) *trash.Item {
+			return &trash.Item{ID: u.ID, Label: fmt.Sprintf("%s <%s>", u.Username, u.Email), DeletedAt: deletedAtOf(u.DeletedAt)}
+		},
+		checkRestore: func(tx *gorm.DB, u *user.User) error {
+			taken, err := activeRowExists(tx, &UserModel{}, "username = ? AND id <> ?", u.Username, u.ID)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return fmt.Errorf("%w: username %q is used by another user", trash.ErrRestoreConflict, u.Username)
+			}
+			taken, err = activeRowExists(tx, &UserModel{}, "email = ? AND id <> ?", u.Email, u.ID)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return fmt.Errorf("%w: email %q is used by another user", trash.ErrRestoreConflict, u.Email)
+			}
+			return nil
+		},
+		beforePurge: func(tx *gorm.DB, u *user.User) error {
+			dependents := []any{
+				&UserRoleModel{}, &UserAttributeValueModel{}, &UserSettingModel{},
+				&UserGroupMemberModel{}, &OrganizationMemberModel{},
+				&PersonalAccessTokenModel{}, &TwoFAModel{}, &TwoFAChannelModel{},
+				&InvitationModel{}, &UserDataExportModel{}, &UserStatusChangeModel{},
+			}
+			for _, model := range dependents {
+				if err := tx.Unscoped().Where("user_id = ?", u.ID).Delete(model).Error; err != nil {
+					return fmt.Errorf("failed to purge user data: %w", err)
+				}
+			}
+			// 审计日志作为操作记录保留，仅去除可识别身份的用户名
+			if err := tx.Unscoped().Model(&AuditLogModel{}).
+				Where("user_id = ?", u.ID).
+				Update("username", fmt.Sprintf("deleted-user-%d", u.ID)).Error; err != nil {
+				return fmt.Errorf("failed to anonymize audit logs: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// NewMenuTrashBin 创建菜单回收站
+// 父菜单仍处于删除状态时不能恢复子菜单；仍有子菜单（含已删除）引用时不能永久删除
+func NewMenuTrashBin(db *gorm.DB) trash.Bin {
+	return &softDeleteBin[menu.Menu, *MenuModel]{
+		resource: trash.ResourceMenus,
+		db:       db,
+		describe: func(m *menu.Menu) *trash.Item {
+			return &trash.Item{ID: m.ID, Label: fmt.Sprintf("%s (%s)", m.Title, m.Path), DeletedAt: deletedAtOf(m.DeletedAt)}
+		},
+		checkRestore: func(tx *gorm.DB, m *menu.Menu) error {
+			if m.ParentID == nil {
+				return nil
+			}
+			exists, err := activeRowExists(tx, &MenuModel{}, "id = ?", *m.ParentID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: parent menu %d is deleted", trash.ErrRestoreConflict, *m.ParentID)
+			}
+			return nil
+		},
+		beforePurge: func(tx *gorm.DB, m *menu.Menu) error {
+			var children int64
+			if err := tx.Unscoped().Model(&MenuModel{}).Where("parent_id = ?", m.ID).Count(&children).Error; err != nil {
+				return fmt.Errorf("failed to check child menus: %w", err)
+			}
+			if children > 0 {
+				return fmt.Errorf("%w: menu %d still has child menus", trash.ErrPurgeConflict, m.ID)
+			}
+			return nil
+		},
+	}
+}
+
+// NewTokenTrashBin 创建个人访问令牌回收站
+// 令牌所属用户已删除时不能恢复
+func NewTokenTrashBin(db *gorm.DB) trash.Bin {
+	return &softDeleteBin[pat.PersonalAccessToken, *PersonalAccessTokenModel]{
+		resource: trash.ResourceTokens,
+		db:       db,
+		describe: func(t *pat.PersonalAccessToken) *trash.Item {
+			return &trash.Item{ID: t.ID, Label: fmt.Sprintf("%s (%s, user #%d)", t.Name, t.TokenPrefix, t.UserID), DeletedAt: deletedAtOf(t.DeletedAt)}
+		},
+		checkRestore: func(tx *gorm.DB, t *pat.PersonalAccessToken) error {
+			exists, err := activeRowExists(tx, &UserModel{}, "id = ?", t.UserID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: owner user %d is deleted", trash.ErrRestoreConflict, t.UserID)
+			}
+			return nil
+		},
+	}
+}
+
+// NewAuditLogTrashBin 创建审计日志回收站
+func NewAuditLogTrashBin(db *gorm.DB) trash.Bin {
+	return &softDeleteBin[auditlog.AuditLog, *AuditLogModel]{
+		resource: trash.ResourceAuditLogs,
+		db:       db,
+		describe: func(l *auditlog.AuditLog) *trash.Item {
+			return &trash.Item{ID: l.ID, Label: fmt.Sprintf("%s %s by %s", l.Action, l.Resource, l.Username), DeletedAt: deletedAtOf(l.DeletedAt)}
+		},
+	}
+}
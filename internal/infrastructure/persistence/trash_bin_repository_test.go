@@ -0,0 +1,155 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/trash"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// setupTrashTestDB 在用户测试库基础上迁移回收站涉及的其他表
+func setupTrashTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&MenuModel{}, &AuditLogModel{}, &PersonalAccessTokenModel{}, &OrganizationMemberModel{},
+		&TwoFAModel{}, &TwoFAChannelModel{}, &InvitationModel{}, &UserDataExportModel{}, &UserStatusChangeModel{},
+	))
+	return db
+}
+
+func createDeletedTestUser(t *testing.T, db *gorm.DB, username, email string) *user.User {
+	t.Helper()
+
+	ctx := context.Background()
+	repo := NewUserCommandRepository(db)
+	u := &user.User{Username: username, Email: email, Password: "hashed", Status: "active"}
+	require.NoError(t, repo.Create(ctx, u))
+	require.NoError(t, repo.Delete(ctx, u.ID))
+	return u
+}
+
+func TestUserTrashBin_ListAndRestore(t *testing.T) {
+	ctx := context.Background()
+	db := setupTrashTestDB(t)
+	bin := NewUserTrashBin(db)
+
+	deleted := createDeletedTestUser(t, db, "alice", "alice@example.com")
+
+	items, total, err := bin.List(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, items, 1)
+	assert.Equal(t, deleted.ID, items[0].ID)
+	assert.Equal(t, trash.ResourceUsers, items[0].Resource)
+	assert.Equal(t, "alice <alice@example.com>", items[0].Label)
+	assert.False(t, items[0].DeletedAt.IsZero())
+
+	require.NoError(t, bin.Restore(ctx, deleted.ID))
+
+	restored, err := NewUserQueryRepository(db).GetByID(ctx, deleted.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", restored.Username)
+
+	// 已恢复的记录不在回收站中
+	require.ErrorIs(t, bin.Restore(ctx, deleted.ID), trash.ErrItemNotFound)
+}
+
+func TestUserTrashBin_RestoreConflict(t *testing.T) {
+	ctx := context.Background()
+	db := setupTrashTestDB(t)
+	bin := NewUserTrashBin(db)
+
+	deleted := createDeletedTestUser(t, db, "bob", "bob@example.com")
+
+	// 软删除后用户名可被新用户复用
+	reuse := &user.User{Username: "bob", Email: "bob2@example.com", Password: "hashed", Status: "active"}
+	require.NoError(t, NewUserCommandRepository(db).Create(ctx, reuse))
+
+	err := bin.Restore(ctx, deleted.ID)
+	require.ErrorIs(t, err, trash.ErrRestoreConflict)
+	assert.Contains(t, err.Error(), "username")
+
+	// 冲突时记录仍保留在回收站
+	_, total, err := bin.List(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
+
+func TestUserTrashBin_Purge(t *testing.T) {
+	ctx := context.Background()
+	db := setupTrashTestDB(t)
+	bin := NewUserTrashBin(db)
+
+	deleted := createDeletedTestUser(t, db, "carol", "carol@example.com")
+	require.NoError(t, db.Create(&UserSettingModel{UserID: deleted.ID, Key: "preference.locale", Value: "en"}).Error)
+	require.NoError(t, db.Create(&AuditLogModel{UserID: deleted.ID, Username: "carol", Action: "login", Resource: "auth"}).Error)
+
+	// 未删除的用户不能被永久删除
+	active := &user.User{Username: "dave", Email: "dave@example.com", Password: "hashed", Status: "active"}
+	require.NoError(t, NewUserCommandRepository(db).Create(ctx, active))
+	require.ErrorIs(t, bin.Purge(ctx, active.ID), trash.ErrItemNotFound)
+
+	require.NoError(t, bin.Purge(ctx, deleted.ID))
+
+	var count int64
+	require.NoError(t, db.Unscoped().Model(&UserModel{}).Where("id = ?", deleted.ID).Count(&count).Error)
+	assert.Zero(t, count)
+	require.NoError(t, db.Model(&UserSettingModel{}).Where("user_id = ?", deleted.ID).Count(&count).Error)
+	assert.Zero(t, count)
+
+	var log AuditLogModel
+	require.NoError(t, db.Where("user_id = ?", deleted.ID).First(&log).Error)
+	assert.Equal(t, "deleted-user-1", log.Username)
+}
+
+func TestMenuTrashBin_ParentChecks(t *testing.T) {
+	ctx := context.Background()
+	db := setupTrashTestDB(t)
+	bin := NewMenuTrashBin(db)
+	repo := NewMenuCommandRepository(db)
+
+	parent := &menu.Menu{Title: "系统", Path: "/system", Visible: true}
+	require.NoError(t, repo.Create(ctx, parent))
+	child := &menu.Menu{Title: "用户", Path: "/system/users", ParentID: &parent.ID, Visible: true}
+	require.NoError(t, repo.Create(ctx, child))
+	require.NoError(t, repo.Delete(ctx, child.ID))
+	require.NoError(t, repo.Delete(ctx, parent.ID))
+
+	require.ErrorIs(t, bin.Restore(ctx, child.ID), trash.ErrRestoreConflict)
+	require.ErrorIs(t, bin.Purge(ctx, parent.ID), trash.ErrPurgeConflict)
+
+	require.NoError(t, bin.Restore(ctx, parent.ID))
+	require.NoError(t, bin.Restore(ctx, child.ID))
+}
+
+func TestTrashBin_ExpiredIDs(t *testing.T) {
+	ctx := context.Background()
+	db := setupTrashTestDB(t)
+	bin := NewAuditLogTrashBin(db)
+
+	old := &AuditLogModel{UserID: 1, Username: "u", Action: "login", Resource: "auth"}
+	recent := &AuditLogModel{UserID: 1, Username: "u", Action: "logout", Resource: "auth"}
+	require.NoError(t, db.Create(old).Error)
+	require.NoError(t, db.Create(recent).Error)
+	now := time.Now()
+	require.NoError(t, db.Model(old).Update("deleted_at", now.AddDate(0, 0, -40)).Error)
+	require.NoError(t, db.Model(recent).Update("deleted_at", now.AddDate(0, 0, -1)).Error)
+
+	ids, err := bin.ExpiredIDs(ctx, now.AddDate(0, 0, -30), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{old.ID}, ids)
+
+	require.NoError(t, bin.Purge(ctx, old.ID))
+	_, total, err := bin.List(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+	"gorm.io/gorm"
+)
+
+// twofaChannelCommandRepository 2FA 验证通道命令仓储的 GORM 实现
+type twofaChannelCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewTwoFAChannelCommandRepository 创建 2FA 验证通道命令仓储实例
+func NewTwoFAChannelCommandRepository(db *gorm.DB) twofa.ChannelCommandRepository {
+	return &twofaChannelCommandRepository{db: db}
+}
+
+// Save 创建或更新验证通道（按 user_id + method 唯一）
+func (r *twofaChannelCommandRepository) Save(ctx context.Context, channel *twofa.Channel) error {
+	model := newTwoFAChannelModelFromEntity(channel)
+	var persisted TwoFAChannelModel
+	result := r.db.WithContext(ctx).
+		Where(TwoFAChannelModel{UserID: model.UserID, Method: model.Method}).
+		Assign(map[string]any{
+			"destination":  model.Destination,
+			"enabled":      model.Enabled,
+			"verified_at":  model.VerifiedAt,
+			"last_used_at": model.LastUsedAt,
+		}).
+		FirstOrCreate(&persisted)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to save 2FA channel: %w", result.Error)
+	}
+
+	if entity := persisted.ToEntity(); entity != nil {
+		*channel = *entity
+	}
+
+	return nil
+}
+
+// Delete 删除指定方式的验证通道
+func (r *twofaChannelCommandRepository) Delete(ctx context.Context, userID uint, method twofa.Method) error {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND method = ?", userID, string(method)).
+		Delete(&TwoFAChannelModel{})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete 2FA channel: %w", result.Error)
+	}
+
+	return nil
+}
+
+// DeleteByUserID 删除用户的全部验证通道
+func (r *twofaChannelCommandRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&TwoFAChannelModel{})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete 2FA channels: %w", result.Error)
+	}
+
+	return nil
+}
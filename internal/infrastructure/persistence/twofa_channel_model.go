@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+)
+
+// TwoFAChannelModel 2FA 验证通道（邮件/短信）的 GORM 实体
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type TwoFAChannelModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	UserID      uint   `gorm:"uniqueIndex:idx_user_2fa_channel;not null"`
+	Method      string `gorm:"uniqueIndex:idx_user_2fa_channel;size:20;not null"`
+	Destination string `gorm:"size:255;not null"`
+	Enabled     bool   `gorm:"default:false;not null"`
+	VerifiedAt  *time.Time
+	LastUsedAt  *time.Time
+}
+
+// TableName 指定 2FA 验证通道表名
+func (TwoFAChannelModel) TableName() string {
+	return "user_2fa_channels"
+}
+
+func newTwoFAChannelModelFromEntity(entity *twofa.Channel) *TwoFAChannelModel {
+	if entity == nil {
+		return nil
+	}
+
+	return &TwoFAChannelModel{
+		ID:          entity.ID,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+		UserID:      entity.UserID,
+		Method:      string(entity.Method),
+		Destination: entity.Destination,
+		Enabled:     entity.Enabled,
+		VerifiedAt:  entity.VerifiedAt,
+		LastUsedAt:  entity.LastUsedAt,
+	}
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *TwoFAChannelModel) ToEntity() *twofa.Channel {
+	if m == nil {
+		return nil
+	}
+
+	return &twofa.Channel{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		UserID:      m.UserID,
+		Method:      twofa.Method(m.Method),
+		Destination: m.Destination,
+		Enabled:     m.Enabled,
+		VerifiedAt:  m.VerifiedAt,
+		LastUsedAt:  m.LastUsedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+	"gorm.io/gorm"
+)
+
+// twofaChannelQueryRepository 2FA 验证通道查询仓储的 GORM 实现
+type twofaChannelQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewTwoFAChannelQueryRepository 创建 2FA 验证通道查询仓储实例
+func NewTwoFAChannelQueryRepository(db *gorm.DB) twofa.ChannelQueryRepository {
+	return &twofaChannelQueryRepository{db: db}
+}
+
+// FindByUserIDAndMethod 查找指定方式的验证通道
+func (r *twofaChannelQueryRepository) FindByUserIDAndMethod(ctx context.Context, userID uint, method twofa.Method) (*twofa.Channel, error) {
+	var model TwoFAChannelModel
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND method = ?", userID, string(method)).
+		First(&model)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil //nolint:nilnil // returns nil for not found, valid pattern
+		}
+		return nil, fmt.Errorf("failed to find 2FA channel: %w", result.Error)
+	}
+
+	return model.ToEntity(), nil
+}
+
+// ListByUserID 列出用户的全部验证通道
+func (r *twofaChannelQueryRepository) ListByUserID(ctx context.Context, userID uint) ([]*twofa.Channel, error) {
+	var models []TwoFAChannelModel
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("id ASC").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list 2FA channels: %w", err)
+	}
+
+	channels := make([]*twofa.Channel, 0, len(models))
+	for i := range models {
+		channels = append(channels, models[i].ToEntity())
+	}
+	return channels, nil
+}
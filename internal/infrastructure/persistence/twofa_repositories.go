@@ -9,12 +9,18 @@ import (
 type TwoFARepositories struct {
 	Command twofa.CommandRepository
 	Query   twofa.QueryRepository
+
+	// 邮件/短信验证通道
+	ChannelCommand twofa.ChannelCommandRepository
+	ChannelQuery   twofa.ChannelQueryRepository
 }
 
 // NewTwoFARepositories 创建两步验证仓储聚合实例
 func NewTwoFARepositories(db *gorm.DB) TwoFARepositories {
 	return TwoFARepositories{
-		Command: NewTwoFACommandRepository(db),
-		Query:   NewTwoFAQueryRepository(db),
+		Command:        NewTwoFACommandRepository(db),
+		Query:          NewTwoFAQueryRepository(db),
+		ChannelCommand: NewTwoFAChannelCommandRepository(db),
+		ChannelQuery:   NewTwoFAChannelQueryRepository(db),
 	}
 }
@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// UserAttributeDefinitionModel 自定义用户属性定义的 GORM 持久化模型
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type UserAttributeDefinitionModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Key         string `gorm:"uniqueIndex;size:50;not null"`
+	Label       string `gorm:"size:100;not null"`
+	Description string `gorm:"size:500"`
+	Type        string `gorm:"size:20;not null"`
+
+	Required bool
+	Pattern  string   `gorm:"size:500"`
+	Options  []string `gorm:"type:text;serializer:json"`
+
+	Visibility   string `gorm:"size:20;not null"`
+	UserEditable bool
+	SortOrder    int `gorm:"default:0"`
+}
+
+// TableName 指定自定义用户属性定义表名
+func (UserAttributeDefinitionModel) TableName() string {
+	return "user_attribute_definitions"
+}
+
+func newUserAttributeDefinitionModelFromEntity(entity *user.AttributeDefinition) *UserAttributeDefinitionModel {
+	return &UserAttributeDefinitionModel{
+		ID:           entity.ID,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+		Key:          entity.Key,
+		Label:        entity.Label,
+		Description:  entity.Description,
+		Type:         entity.Type,
+		Required:     entity.Required,
+		Pattern:      entity.Pattern,
+		Options:      entity.Options,
+		Visibility:   entity.Visibility,
+		UserEditable: entity.UserEditable,
+		SortOrder:    entity.SortOrder,
+	}
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *UserAttributeDefinitionModel) ToEntity() *user.AttributeDefinition {
+	return &user.AttributeDefinition{
+		ID:           m.ID,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+		Key:          m.Key,
+		Label:        m.Label,
+		Description:  m.Description,
+		Type:         m.Type,
+		Required:     m.Required,
+		Pattern:      m.Pattern,
+		Options:      m.Options,
+		Visibility:   m.Visibility,
+		UserEditable: m.UserEditable,
+		SortOrder:    m.SortOrder,
+	}
+}
+
+// UserAttributeValueModel 用户自定义属性值的 GORM 持久化模型（EAV）
+// 每个用户每个属性一行，(attribute_key, value) 索引用于用户列表按属性过滤
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type UserAttributeValueModel struct {
+	UserID       uint   `gorm:"primaryKey"`
+	AttributeKey string `gorm:"primaryKey;size:50;index:idx_user_attribute_values_key_value,priority:1"`
+	Value        string `gorm:"size:500;not null;index:idx_user_attribute_values_key_value,priority:2"`
+}
+
+// TableName 指定用户自定义属性值表名
+func (UserAttributeValueModel) TableName() string {
+	return "user_attribute_values"
+}
+
+// mapAttributeValueModels 将属性值行转换为属性映射
+func mapAttributeValueModels(models []UserAttributeValueModel) map[string]string {
+	if len(models) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(models))
+	for _, m := range models {
+		values[m.AttributeKey] = m.Value
+	}
+	return values
+}
@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// attributeDefinitionCommandRepository 自定义属性定义命令仓储的 GORM 实现
+type attributeDefinitionCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewAttributeDefinitionCommandRepository 创建自定义属性定义命令仓储实例
+func NewAttributeDefinitionCommandRepository(db *gorm.DB) user.AttributeDefinitionCommandRepository {
+	return &attributeDefinitionCommandRepository{db: db}
+}
+
+// Create 创建属性定义
+func (r *attributeDefinitionCommandRepository) Create(ctx context.Context, def *user.AttributeDefinition) error {
+	model := newUserAttributeDefinitionModelFromEntity(def)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create attribute definition: %w", err)
+	}
+	*def = *model.ToEntity()
+	return nil
+}
+
+// Update 更新属性定义
+func (r *attributeDefinitionCommandRepository) Update(ctx context.Context, def *user.AttributeDefinition) error {
+	model := newUserAttributeDefinitionModelFromEntity(def)
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update attribute definition: %w", err)
+	}
+	*def = *model.ToEntity()
+	return nil
+}
+
+// Delete 删除属性定义及所有用户在该属性上的值
+func (r *attributeDefinitionCommandRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model UserAttributeDefinitionModel
+		if err := tx.First(&model, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return user.ErrAttributeDefinitionNotFound
+			}
+			return fmt.Errorf("failed to get attribute definition: %w", err)
+		}
+		if err := tx.Where("attribute_key = ?", model.Key).Delete(&UserAttributeValueModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete attribute values: %w", err)
+		}
+		if err := tx.Delete(&model).Error; err != nil {
+			return fmt.Errorf("failed to delete attribute definition: %w", err)
+		}
+		return nil
+	})
+}
+
+// attributeDefinitionQueryRepository 自定义属性定义查询仓储的 GORM 实现
+type attributeDefinitionQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewAttributeDefinitionQueryRepository 创建自定义属性定义查询仓储实例
+func NewAttributeDefinitionQueryRepository(db *gorm.DB) user.AttributeDefinitionQueryRepository {
+	return &attributeDefinitionQueryRepository{db: db}
+}
+
+// GetByID 根据 ID 获取属性定义
+func (r *attributeDefinitionQueryRepository) GetByID(ctx context.Context, id uint) (*user.AttributeDefinition, error) {
+	var model UserAttributeDefinitionModel
+	if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, user.ErrAttributeDefinitionNotFound
+		}
+		return nil, fmt.Errorf("failed to get attribute definition: %w", err)
+	}
+	return model.ToEntity(), nil
+}
+
+// ExistsByKey 检查属性键是否已存在
+func (r *attributeDefinitionQueryRepository) ExistsByKey(ctx context.Context, key string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&UserAttributeDefinitionModel{}).Where("key = ?", key).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check attribute key existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// List 按排序值与 ID 列出全部属性定义
+func (r *attributeDefinitionQueryRepository) List(ctx context.Context) ([]*user.AttributeDefinition, error) {
+	var models []UserAttributeDefinitionModel
+	if err := r.db.WithContext(ctx).Order("sort_order ASC").Order("id ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list attribute definitions: %w", err)
+	}
+
+	defs := make([]*user.AttributeDefinition, 0, len(models))
+	for i := range models {
+		defs = append(defs, models[i].ToEntity())
+	}
+	return defs, nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // userCommandRepository 用户命令仓储的 GORM 实现
@@ -24,9 +26,24 @@ func NewUserCommandRepository(db *gorm.DB) user.CommandRepository {
 	}
 }
 
-// Create、Update、Delete 方法由 GenericCommandRepository 提供
+// Create、Delete 方法由 GenericCommandRepository 提供
 
-// AssignRoles 为用户分配角色（替换现有角色）
+// userLoginActivityColumns 由 RecordLogins 维护的登录活动列
+var userLoginActivityColumns = []string{"last_login_at", "last_login_ip", "login_count"}
+
+// Update 全量更新用户，不写登录活动列
+// 登录活动异步批量写入，避免用登录前读取的旧值覆盖
+func (r *userCommandRepository) Update(ctx context.Context, entity *user.User) error {
+	model := newUserModelFromEntity(entity)
+	if err := r.DB().WithContext(ctx).Omit(userLoginActivityColumns...).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update: %w", err)
+	}
+	*entity = *model.ToEntity()
+	return nil
+}
+
+// AssignRoles 为用户分配角色（替换现有的永久授权）
+// 限时授权由到期任务管理，不受影响；列表中的角色若存在限时授权则转为永久授权
 func (r *userCommandRepository) AssignRoles(ctx context.Context, userID uint, roleIDs []uint) error {
 	var u UserModel
 	if err := r.DB().WithContext(ctx).First(&u, userID).Error; err != nil {
@@ -36,24 +53,46 @@ func (r *userCommandRepository) AssignRoles(ctx context.Context, userID uint, ro
 		return fmt.Errorf("failed to find user: %w", err)
 	}
 
-	// 如果 roleIDs 为空，清空用户的所有角色
-	if len(roleIDs) == 0 {
-		if err := r.DB().WithContext(ctx).Model(&u).Association("Roles").Clear(); err != nil {
-			return fmt.Errorf("failed to clear roles: %w", err)
+	var roles []RoleModel
+	if len(roleIDs) > 0 {
+		if err := r.DB().WithContext(ctx).Find(&roles, roleIDs).Error; err != nil {
+			return fmt.Errorf("failed to find roles: %w", err)
 		}
-		return nil
 	}
 
-	var roles []RoleModel
-	if err := r.DB().WithContext(ctx).Find(&roles, roleIDs).Error; err != nil {
-		return fmt.Errorf("failed to find roles: %w", err)
-	}
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		keep := make([]uint, 0, len(roles))
+		for _, role := range roles {
+			keep = append(keep, role.ID)
+		}
 
-	if err := r.DB().WithContext(ctx).Model(&u).Association("Roles").Replace(roles); err != nil {
-		return fmt.Errorf("failed to assign roles: %w", err)
-	}
+		stale := tx.Where("user_id = ? AND starts_at IS NULL AND expires_at IS NULL", userID)
+		if len(keep) > 0 {
+			stale = stale.Where("role_id NOT IN ?", keep)
+		}
+		if err := stale.Delete(&UserRoleModel{}).Error; err != nil {
+			return fmt.Errorf("failed to remove roles: %w", err)
+		}
 
-	return nil
+		if len(keep) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		links := make([]UserRoleModel, 0, len(keep))
+		for _, roleID := range keep {
+			links = append(links, UserRoleModel{UserID: userID, RoleID: roleID, ActivatedAt: &now})
+		}
+		if err := tx.Omit("Role").
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "role_id"}},
+				DoUpdates: clause.Assignments(map[string]any{"starts_at": nil, "expires_at": nil}),
+			}).
+			Create(&links).Error; err != nil {
+			return fmt.Errorf("failed to assign roles: %w", err)
+		}
+		return nil
+	})
 }
 
 // RemoveRoles 移除用户的角色
@@ -79,21 +118,132 @@ func (r *userCommandRepository) RemoveRoles(ctx context.Context, userID uint, ro
 }
 
 // UpdatePassword 更新用户密码
+// 同时清除强制修改标记并记录修改时间
 func (r *userCommandRepository) UpdatePassword(ctx context.Context, userID uint, hashedPassword string) error {
 	if err := r.DB().WithContext(ctx).Model(&UserModel{}).
 		Where("id = ?", userID).
-		Update("password", hashedPassword).Error; err != nil {
+		Updates(map[string]any{
+			"password":             hashedPassword,
+			"must_change_password": false,
+			"password_changed_at":  time.Now(),
+		}).Error; err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
 	return nil
 }
 
-// UpdateStatus 更新用户状态
+// ResetPassword 管理员重置用户密码
+// 设置强制修改标记，用户下次登录后必须修改密码
+func (r *userCommandRepository) ResetPassword(ctx context.Context, userID uint, hashedPassword string) error {
+	if err := r.DB().WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"password":             hashedPassword,
+			"must_change_password": true,
+			"password_changed_at":  time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新用户状态，非封禁状态同时清除封禁信息
 func (r *userCommandRepository) UpdateStatus(ctx context.Context, userID uint, status string) error {
+	updates := map[string]any{"status": status}
+	if status != user.StatusBanned {
+		updates["ban_reason"] = ""
+		updates["banned_until"] = nil
+	}
 	if err := r.DB().WithContext(ctx).Model(&UserModel{}).
 		Where("id = ?", userID).
-		Update("status", status).Error; err != nil {
+		Updates(updates).Error; err != nil {
 		return fmt.Errorf("failed to update status: %w", err)
 	}
 	return nil
 }
+
+// SaveStatus 保存状态、封禁信息与会话吊销时间
+func (r *userCommandRepository) SaveStatus(ctx context.Context, u *user.User) error {
+	if err := r.DB().WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", u.ID).
+		Updates(map[string]any{
+			"status":              u.Status,
+			"ban_reason":          u.BanReason,
+			"banned_until":        u.BannedUntil,
+			"sessions_revoked_at": u.SessionsRevokedAt,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to save status: %w", err)
+	}
+	return nil
+}
+
+// UpdateAvatar 更新头像地址与版本
+func (r *userCommandRepository) UpdateAvatar(ctx context.Context, userID uint, avatar, version string) error {
+	if err := r.DB().WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"avatar":         avatar,
+			"avatar_version": version,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update avatar: %w", err)
+	}
+	return nil
+}
+
+// SaveAttributes 替换用户的全部自定义属性值
+func (r *userCommandRepository) SaveAttributes(ctx context.Context, userID uint, values map[string]string) error {
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&UserAttributeValueModel{}).Error; err != nil {
+			return fmt.Errorf("failed to clear attributes: %w", err)
+		}
+		if len(values) == 0 {
+			return nil
+		}
+
+		models := make([]UserAttributeValueModel, 0, len(values))
+		for key, value := range values {
+			models = append(models, UserAttributeValueModel{UserID: userID, AttributeKey: key, Value: value})
+		}
+		if err := tx.Create(&models).Error; err != nil {
+			return fmt.Errorf("failed to save attributes: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateDeletionSchedule 更新账号删除计划，均为 nil 时取消
+func (r *userCommandRepository) UpdateDeletionSchedule(ctx context.Context, userID uint, requestedAt, scheduledAt *time.Time) error {
+	if err := r.DB().WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"deletion_requested_at": requestedAt,
+			"deletion_scheduled_at": scheduledAt,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update deletion schedule: %w", err)
+	}
+	return nil
+}
+
+// RecordLogins 批量写入登录活动
+// 最近登录时间只前进不后退（多实例并发写入时保留最新的一次），登录次数累加
+func (r *userCommandRepository) RecordLogins(ctx context.Context, summaries []user.LoginSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	return r.DB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, s := range summaries {
+			newer := "last_login_at IS NULL OR last_login_at <= ?"
+			if err := tx.Model(&UserModel{}).
+				Where("id = ?", s.UserID).
+				UpdateColumns(map[string]any{
+					"last_login_at": gorm.Expr("CASE WHEN "+newer+" THEN ? ELSE last_login_at END", s.LastAt, s.LastAt),
+					"last_login_ip": gorm.Expr("CASE WHEN "+newer+" THEN ? ELSE last_login_ip END", s.LastAt, s.LastIP),
+					"login_count":   gorm.Expr("login_count + ?", s.Count),
+				}).Error; err != nil {
+				return fmt.Errorf("failed to record logins: %w", err)
+			}
+		}
+		return nil
+	})
+}
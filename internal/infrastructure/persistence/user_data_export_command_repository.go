@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// dataExportClaimAttempts 认领任务时与其他 worker 竞争失败后的重试次数
+const dataExportClaimAttempts = 3
+
+// dataExportCommandRepository 个人数据导出任务命令仓储的 GORM 实现
+type dataExportCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewDataExportCommandRepository 创建个人数据导出任务命令仓储实例
+func NewDataExportCommandRepository(db *gorm.DB) user.DataExportCommandRepository {
+	return &dataExportCommandRepository{db: db}
+}
+
+// Create 创建导出任务
+func (r *dataExportCommandRepository) Create(ctx context.Context, export *user.DataExport) error {
+	model := newUserDataExportModelFromEntity(export)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	export.ID = model.ID
+	export.CreatedAt = model.CreatedAt
+	export.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Save 保存任务状态与归档
+func (r *dataExportCommandRepository) Save(ctx context.Context, export *user.DataExport) error {
+	model := newUserDataExportModelFromEntity(export)
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to save data export: %w", err)
+	}
+
+	export.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// ClaimNext 认领最早的待处理任务（或停滞的生成中任务）
+func (r *dataExportCommandRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*user.DataExport, error) {
+	for range dataExportClaimAttempts {
+		var model UserDataExportModel
+		err := r.db.WithContext(ctx).
+			Omit("archive").
+			Where("status = ? OR (status = ? AND updated_at < ?)",
+				string(user.DataExportPending), string(user.DataExportRunning), staleBefore).
+			Order("id ASC").
+			First(&model).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil //nolint:nilnil // 没有可认领的任务
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find data export: %w", err)
+		}
+
+		// 条件更新：状态与更新时间均未变化才认领成功
+		now := time.Now()
+		result := r.db.WithContext(ctx).Model(&UserDataExportModel{}).
+			Where("id = ? AND status = ? AND updated_at = ?", model.ID, model.Status, model.UpdatedAt).
+			Updates(map[string]any{"status": string(user.DataExportRunning), "updated_at": now})
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to claim data export: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		export := model.ToEntity()
+		export.Status = user.DataExportRunning
+		export.UpdatedAt = now
+		return export, nil
+	}
+
+	return nil, nil //nolint:nilnil // 竞争失败，留待下一轮
+}
+
+// PurgeExpired 清除已过期任务的归档内容
+func (r *dataExportCommandRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&UserDataExportModel{}).
+		Where("expires_at <= ? AND size > 0", now).
+		Updates(map[string]any{"archive": nil, "size": 0})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge expired data exports: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteByUserID 删除用户的全部导出任务
+func (r *dataExportCommandRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&UserDataExportModel{}).Error; err != nil {
+		return fmt.Errorf("failed to delete data exports: %w", err)
+	}
+	return nil
+}
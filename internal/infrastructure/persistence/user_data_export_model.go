@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// UserDataExportModel 个人数据导出任务的 GORM 持久化模型
+// 生成的 ZIP 归档以二进制列保存，过期后清空
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type UserDataExportModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index"`
+
+	UserID uint   `gorm:"index;not null"`
+	Status string `gorm:"size:20;index;not null"`
+
+	FileName string `gorm:"size:255"`
+	Archive  []byte
+	Size     int64
+	Error    string `gorm:"type:text"`
+
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	ExpiresAt  *time.Time `gorm:"index"`
+}
+
+// TableName 指定个人数据导出任务表名
+func (UserDataExportModel) TableName() string {
+	return "user_data_exports"
+}
+
+func newUserDataExportModelFromEntity(entity *user.DataExport) *UserDataExportModel {
+	if entity == nil {
+		return nil
+	}
+
+	return &UserDataExportModel{
+		ID:         entity.ID,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+		UserID:     entity.UserID,
+		Status:     string(entity.Status),
+		FileName:   entity.FileName,
+		Archive:    entity.Archive,
+		Size:       entity.Size,
+		Error:      entity.Error,
+		StartedAt:  entity.StartedAt,
+		FinishedAt: entity.FinishedAt,
+		ExpiresAt:  entity.ExpiresAt,
+	}
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *UserDataExportModel) ToEntity() *user.DataExport {
+	if m == nil {
+		return nil
+	}
+
+	return &user.DataExport{
+		ID:         m.ID,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+		UserID:     m.UserID,
+		Status:     user.DataExportStatus(m.Status),
+		FileName:   m.FileName,
+		Archive:    m.Archive,
+		Size:       m.Size,
+		Error:      m.Error,
+		StartedAt:  m.StartedAt,
+		FinishedAt: m.FinishedAt,
+		ExpiresAt:  m.ExpiresAt,
+	}
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// dataExportQueryRepository 个人数据导出任务查询仓储的 GORM 实现
+type dataExportQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewDataExportQueryRepository 创建个人数据导出任务查询仓储实例
+func NewDataExportQueryRepository(db *gorm.DB) user.DataExportQueryRepository {
+	return &dataExportQueryRepository{db: db}
+}
+
+// GetByID 获取导出任务（包含归档）
+func (r *dataExportQueryRepository) GetByID(ctx context.Context, id uint) (*user.DataExport, error) {
+	var model UserDataExportModel
+	if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, user.ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+	return model.ToEntity(), nil
+}
+
+// FindActiveByUserID 获取用户排队或生成中的导出任务
+func (r *dataExportQueryRepository) FindActiveByUserID(ctx context.Context, userID uint) (*user.DataExport, error) {
+	var model UserDataExportModel
+	err := r.db.WithContext(ctx).
+		Omit("archive").
+		Where("user_id = ? AND status IN ?", userID,
+			[]string{string(user.DataExportPending), string(user.DataExportRunning)}).
+		Order("id DESC").
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil //nolint:nilnil // 没有进行中的任务
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active data export: %w", err)
+	}
+	return model.ToEntity(), nil
+}
@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// importJobClaimAttempts 认领任务时与其他 worker 竞争失败后的重试次数
+const importJobClaimAttempts = 3
+
+// importJobCommandRepository 用户导入任务命令仓储的 GORM 实现
+type importJobCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobCommandRepository 创建用户导入任务命令仓储实例
+func NewImportJobCommandRepository(db *gorm.DB) user.ImportJobCommandRepository {
+	return &importJobCommandRepository{db: db}
+}
+
+// Create 创建导入任务
+func (r *importJobCommandRepository) Create(ctx context.Context, job *user.ImportJob) error {
+	model := newUserImportJobModelFromEntity(job)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	job.ID = model.ID
+	job.CreatedAt = model.CreatedAt
+	job.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Save 保存任务进度、结果与状态
+func (r *importJobCommandRepository) Save(ctx context.Context, job *user.ImportJob) error {
+	model := newUserImportJobModelFromEntity(job)
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		return fmt.Errorf("failed to save import job: %w", err)
+	}
+
+	job.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// ClaimNext 认领最早的待处理任务（或进度停滞的处理中任务）
+func (r *importJobCommandRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*user.ImportJob, error) {
+	for range importJobClaimAttempts {
+		var model UserImportJobModel
+		err := r.db.WithContext(ctx).
+			Where("status = ? OR (status = ? AND updated_at < ?)",
+				string(user.ImportJobPending), string(user.ImportJobRunning), staleBefore).
+			Order("id ASC").
+			First(&model).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil //nolint:nilnil // 没有可认领的任务
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find import job: %w", err)
+		}
+
+		// 条件更新：状态与更新时间均未变化才认领成功
+		now := time.Now()
+		result := r.db.WithContext(ctx).Model(&UserImportJobModel{}).
+			Where("id = ? AND status = ? AND updated_at = ?", model.ID, model.Status, model.UpdatedAt).
+			Updates(map[string]any{"status": string(user.ImportJobRunning), "updated_at": now})
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to claim import job: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		job := model.ToEntity()
+		job.Status = user.ImportJobRunning
+		job.UpdatedAt = now
+		return job, nil
+	}
+
+	return nil, nil //nolint:nilnil // 竞争失败，留待下一轮
+}
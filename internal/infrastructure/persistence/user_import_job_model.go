@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// UserImportJobModel 用户导入任务的 GORM 持久化模型
+// 原始行与逐行结果以 JSON 保存在文本列中
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type UserImportJobModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index"`
+
+	CreatedBy uint   `gorm:"index;not null"`
+	FileName  string `gorm:"size:255"`
+	Format    string `gorm:"size:10"`
+	DryRun    bool
+	Status    string `gorm:"size:20;index;not null"`
+
+	Total     int
+	Processed int
+	Succeeded int
+	Failed    int
+
+	Rows    []user.ImportRow       `gorm:"type:text;serializer:json"`
+	Results []user.ImportRowResult `gorm:"type:text;serializer:json"`
+	Error   string                 `gorm:"type:text"`
+
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// TableName 指定用户导入任务表名
+func (UserImportJobModel) TableName() string {
+	return "user_import_jobs"
+}
+
+func newUserImportJobModelFromEntity(entity *user.ImportJob) *UserImportJobModel {
+	if entity == nil {
+		return nil
+	}
+
+	return &UserImportJobModel{
+		ID:         entity.ID,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+		CreatedBy:  entity.CreatedBy,
+		FileName:   entity.FileName,
+		Format:     entity.Format,
+		DryRun:     entity.DryRun,
+		Status:     string(entity.Status),
+		Total:      entity.Total,
+		Processed:  entity.Processed,
+		Succeeded:  entity.Succeeded,
+		Failed:     entity.Failed,
+		Rows:       entity.Rows,
+		Results:    entity.Results,
+		Error:      entity.Error,
+		StartedAt:  entity.StartedAt,
+		FinishedAt: entity.FinishedAt,
+	}
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *UserImportJobModel) ToEntity() *user.ImportJob {
+	if m == nil {
+		return nil
+	}
+
+	return &user.ImportJob{
+		ID:         m.ID,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+		CreatedBy:  m.CreatedBy,
+		FileName:   m.FileName,
+		Format:     m.Format,
+		DryRun:     m.DryRun,
+		Status:     user.ImportJobStatus(m.Status),
+		Total:      m.Total,
+		Processed:  m.Processed,
+		Succeeded:  m.Succeeded,
+		Failed:     m.Failed,
+		Rows:       m.Rows,
+		Results:    m.Results,
+		Error:      m.Error,
+		StartedAt:  m.StartedAt,
+		FinishedAt: m.FinishedAt,
+	}
+}
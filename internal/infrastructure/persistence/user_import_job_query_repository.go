@@ -0,0 +1,32 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// importJobQueryRepository 用户导入任务查询仓储的 GORM 实现
+type importJobQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobQueryRepository 创建用户导入任务查询仓储实例
+func NewImportJobQueryRepository(db *gorm.DB) user.ImportJobQueryRepository {
+	return &importJobQueryRepository{db: db}
+}
+
+// GetByID 获取导入任务
+func (r *importJobQueryRepository) GetByID(ctx context.Context, id uint) (*user.ImportJob, error) {
+	var model UserImportJobModel
+	if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, user.ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+	return model.ToEntity(), nil
+}
@@ -16,15 +16,41 @@ type UserModel struct {
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 
-	Username string `gorm:"uniqueIndex;size:50;not null"`
-	Email    string `gorm:"uniqueIndex;size:100;not null"`
+	// 用户名与邮箱仅在未删除用户中唯一，软删除用户的用户名/邮箱可被重新使用
+	Username string `gorm:"uniqueIndex:idx_users_username_active,where:deleted_at IS NULL;size:50;not null"`
+	Email    string `gorm:"uniqueIndex:idx_users_email_active,where:deleted_at IS NULL;size:100;not null"`
 	Password string `gorm:"size:255;not null"`
 	FullName string `gorm:"size:100"`
 	Avatar   string `gorm:"size:255"`
 	Bio      string `gorm:"type:text"`
 	Status   string `gorm:"size:20;default:'active'"`
 
+	BanReason         string     `gorm:"size:500"`
+	BannedUntil       *time.Time `gorm:"index"`
+	SessionsRevokedAt *time.Time
+
+	AvatarVersion string `gorm:"size:64"`
+
+	Department string `gorm:"size:100;index"`
+
+	MustChangePassword bool `gorm:"default:false"`
+	PasswordChangedAt  *time.Time
+
+	AuthSource string `gorm:"size:20;default:'local';not null"`
+	ExternalID string `gorm:"size:255;index"`
+
+	DeletionRequestedAt *time.Time
+	DeletionScheduledAt *time.Time `gorm:"index"`
+
+	// 登录活动由 RecordLogins 批量写入，Update 不写这些列
+	LastLoginAt *time.Time `gorm:"index"`
+	LastLoginIP string     `gorm:"size:45"`
+	LoginCount  int64      `gorm:"default:0;not null"`
+
 	Roles []RoleModel `gorm:"many2many:user_roles;joinForeignKey:UserID;joinReferences:RoleID;foreignKey:ID;references:ID"`
+
+	// AttributeValues 自定义属性值（只读关联，由查询仓储预加载，通过 SaveAttributes 保存）
+	AttributeValues []UserAttributeValueModel `gorm:"foreignKey:UserID"`
 }
 
 // TableName 指定用户表名
@@ -32,23 +58,53 @@ func (UserModel) TableName() string {
 	return "users"
 }
 
+// LegacyIndexes 返回迁移前需删除的旧索引
+// 早期版本的用户名/邮箱唯一索引包含已删除用户，会阻止用户名复用
+func (UserModel) LegacyIndexes() []string {
+	return []string{"idx_users_username", "idx_users_email"}
+}
+
 func newUserModelFromEntity(entity *user.User) *UserModel {
 	if entity == nil {
 		return nil
 	}
 
 	model := &UserModel{
-		ID:        entity.ID,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
-		Username:  entity.Username,
-		Email:     entity.Email,
-		Password:  entity.Password,
-		FullName:  entity.FullName,
-		Avatar:    entity.Avatar,
-		Bio:       entity.Bio,
-		Status:    entity.Status,
-		Roles:     mapRoleEntitiesToModels(entity.Roles),
+		ID:         entity.ID,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+		Username:   entity.Username,
+		Email:      entity.Email,
+		Password:   entity.Password,
+		FullName:   entity.FullName,
+		Avatar:     entity.Avatar,
+		Bio:        entity.Bio,
+		Department: entity.Department,
+		Status:     entity.Status,
+		Roles:      mapRoleEntitiesToModels(entity.Roles),
+
+		AvatarVersion: entity.AvatarVersion,
+
+		BanReason:         entity.BanReason,
+		BannedUntil:       entity.BannedUntil,
+		SessionsRevokedAt: entity.SessionsRevokedAt,
+
+		MustChangePassword: entity.MustChangePassword,
+		PasswordChangedAt:  entity.PasswordChangedAt,
+
+		AuthSource: entity.AuthSource,
+		ExternalID: entity.ExternalID,
+
+		DeletionRequestedAt: entity.DeletionRequestedAt,
+		DeletionScheduledAt: entity.DeletionScheduledAt,
+
+		LastLoginAt: entity.LastLoginAt,
+		LastLoginIP: entity.LastLoginIP,
+		LoginCount:  entity.LoginCount,
+	}
+
+	if model.AuthSource == "" {
+		model.AuthSource = user.AuthSourceLocal
 	}
 
 	if entity.DeletedAt != nil {
@@ -65,17 +121,38 @@ func (m *UserModel) ToEntity() *user.User {
 	}
 
 	entity := &user.User{
-		ID:        m.ID,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
-		Username:  m.Username,
-		Email:     m.Email,
-		Password:  m.Password,
-		FullName:  m.FullName,
-		Avatar:    m.Avatar,
-		Bio:       m.Bio,
-		Status:    m.Status,
-		Roles:     mapRoleModelsToEntities(m.Roles),
+		ID:         m.ID,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+		Username:   m.Username,
+		Email:      m.Email,
+		Password:   m.Password,
+		FullName:   m.FullName,
+		Avatar:     m.Avatar,
+		Bio:        m.Bio,
+		Department: m.Department,
+		Status:     m.Status,
+		Roles:      mapRoleModelsToEntities(m.Roles),
+		Attributes: mapAttributeValueModels(m.AttributeValues),
+
+		AvatarVersion: m.AvatarVersion,
+
+		BanReason:         m.BanReason,
+		BannedUntil:       m.BannedUntil,
+		SessionsRevokedAt: m.SessionsRevokedAt,
+
+		MustChangePassword: m.MustChangePassword,
+		PasswordChangedAt:  m.PasswordChangedAt,
+
+		AuthSource: m.AuthSource,
+		ExternalID: m.ExternalID,
+
+		DeletionRequestedAt: m.DeletionRequestedAt,
+		DeletionScheduledAt: m.DeletionScheduledAt,
+
+		LastLoginAt: m.LastLoginAt,
+		LastLoginIP: m.LastLoginIP,
+		LoginCount:  m.LoginCount,
 	}
 
 	if m.DeletedAt.Valid {
@@ -4,11 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// userPolicyColumns 用户可用于策略过滤的资源属性（与 User.PolicyAttributes 对应）
+var userPolicyColumns = map[string]string{
+	"id":          "users.id",
+	"username":    "users.username",
+	"email":       "users.email",
+	"department":  "users.department",
+	"status":      "users.status",
+	"auth_source": "users.auth_source",
+}
+
 // userQueryRepository 用户查询仓储的 GORM 实现
 type userQueryRepository struct {
 	db *gorm.DB
@@ -22,7 +34,7 @@ func NewUserQueryRepository(db *gorm.DB) user.QueryRepository {
 // GetByID 根据 ID 获取用户
 func (r *userQueryRepository) GetByID(ctx context.Context, id uint) (*user.User, error) {
 	var model UserModel
-	if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("AttributeValues").First(&model, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, user.ErrUserNotFound
 		}
@@ -60,13 +72,31 @@ func (r *userQueryRepository) GetByIDWithRoles(ctx context.Context, id uint) (*u
 	var model UserModel
 	if err := r.db.WithContext(ctx).
 		Preload("Roles.Permissions").
+		Preload("AttributeValues").
 		First(&model, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, user.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by id with roles: %w", err)
 	}
-	return model.ToEntity(), nil
+	return r.withEffectiveRoles(ctx, model.ToEntity())
+}
+
+// withEffectiveRoles 为用户填充经由用户组获得的角色，并为全部角色填充继承自父角色的权限
+func (r *userQueryRepository) withEffectiveRoles(ctx context.Context, u *user.User) (*user.User, error) {
+	groupRoles, err := loadGroupRoles(ctx, r.db, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	u.GroupRoles = groupRoles
+
+	if err := attachInheritedPermissions(ctx, r.db, u.Roles); err != nil {
+		return nil, err
+	}
+	if err := attachInheritedPermissions(ctx, r.db, u.GroupRoles); err != nil {
+		return nil, err
+	}
+	return u, nil
 }
 
 // GetByUsernameWithRoles 根据用户名获取用户（包含角色和权限信息）
@@ -81,7 +111,7 @@ func (r *userQueryRepository) GetByUsernameWithRoles(ctx context.Context, userna
 		}
 		return nil, fmt.Errorf("failed to get user by username with roles: %w", err)
 	}
-	return model.ToEntity(), nil
+	return r.withEffectiveRoles(ctx, model.ToEntity())
 }
 
 // GetByEmailWithRoles 根据邮箱获取用户（包含角色和权限信息）
@@ -96,7 +126,7 @@ func (r *userQueryRepository) GetByEmailWithRoles(ctx context.Context, email str
 		}
 		return nil, fmt.Errorf("failed to get user by email with roles: %w", err)
 	}
-	return model.ToEntity(), nil
+	return r.withEffectiveRoles(ctx, model.ToEntity())
 }
 
 // List 获取用户列表 (分页)
@@ -167,6 +197,180 @@ func (r *userQueryRepository) CountBySearch(ctx context.Context, keyword string)
 	return count, nil
 }
 
+// ListByCriteria 按条件查询用户列表（包含直接角色）
+func (r *userQueryRepository) ListByCriteria(ctx context.Context, criteria user.ListCriteria) ([]*user.User, error) {
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+
+	query, err := applyUserCriteria(r.db.WithContext(ctx).Model(&UserModel{}), criteria)
+	if err != nil {
+		return nil, err
+	}
+	query = query.Preload("Roles").Preload("AttributeValues")
+
+	order := criteria.OrderBy()
+	if criteria.After != nil {
+		cond, args := userKeysetCondition(order, criteria.After.Values)
+		query = query.Where(cond, args...)
+	} else if criteria.Offset > 0 {
+		query = query.Offset(criteria.Offset)
+	}
+	query = query.Order(userOrderBy(order))
+	if criteria.Limit > 0 {
+		query = query.Limit(criteria.Limit)
+	}
+
+	var models []UserModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list users by criteria: %w", err)
+	}
+
+	return mapUserModelsToEntities(models), nil
+}
+
+// CountByCriteria 统计匹配条件的用户数量
+func (r *userQueryRepository) CountByCriteria(ctx context.Context, criteria user.ListCriteria) (int64, error) {
+	query, err := applyUserCriteria(r.db.WithContext(ctx).Model(&UserModel{}), criteria)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count users by criteria: %w", err)
+	}
+	return count, nil
+}
+
+// applyUserCriteria 追加过滤条件（不含排序和分页）
+func applyUserCriteria(db *gorm.DB, c user.ListCriteria) (*gorm.DB, error) {
+	if c.Keyword != "" {
+		like := "%" + c.Keyword + "%"
+		db = db.Where("users.username LIKE ? OR users.email LIKE ? OR users.full_name LIKE ?", like, like, like)
+	}
+	if len(c.Statuses) > 0 {
+		db = db.Where("users.status IN ?", c.Statuses)
+	}
+	if len(c.RoleIDs) > 0 {
+		direct := db.Session(&gorm.Session{NewDB: true}).Model(&UserRoleModel{}).
+			Select("user_id").Where("role_id IN ?", c.RoleIDs)
+		groupIDs := db.Session(&gorm.Session{NewDB: true}).Model(&UserGroupModel{}).Select("id").
+			Where("id IN (?)", db.Session(&gorm.Session{NewDB: true}).Table("user_group_roles").
+				Select("group_id").Where("role_id IN ?", c.RoleIDs))
+		viaGroup := db.Session(&gorm.Session{NewDB: true}).Model(&UserGroupMemberModel{}).
+			Select("user_id").Where("group_id IN (?)", groupIDs)
+		db = db.Where("users.id IN (?) OR users.id IN (?)", direct, viaGroup)
+	}
+	if c.CreatedFrom != nil {
+		db = db.Where("users.created_at >= ?", *c.CreatedFrom)
+	}
+	if c.CreatedTo != nil {
+		db = db.Where("users.created_at < ?", *c.CreatedTo)
+	}
+	if c.UpdatedFrom != nil {
+		db = db.Where("users.updated_at >= ?", *c.UpdatedFrom)
+	}
+	if c.UpdatedTo != nil {
+		db = db.Where("users.updated_at < ?", *c.UpdatedTo)
+	}
+	if c.TwoFAEnabled != nil {
+		totp := db.Session(&gorm.Session{NewDB: true}).Model(&TwoFAModel{}).Select("user_id").Where("enabled = ?", true)
+		channels := db.Session(&gorm.Session{NewDB: true}).Model(&TwoFAChannelModel{}).Select("user_id").Where("enabled = ?", true)
+		if *c.TwoFAEnabled {
+			db = db.Where("users.id IN (?) OR users.id IN (?)", totp, channels)
+		} else {
+			db = db.Where("users.id NOT IN (?) AND users.id NOT IN (?)", totp, channels)
+		}
+	}
+	if c.LastLoginFrom != nil {
+		db = db.Where("users.last_login_at >= ?", *c.LastLoginFrom)
+	}
+	if c.LastLoginTo != nil {
+		db = db.Where("users.last_login_at < ?", *c.LastLoginTo)
+	}
+	if c.NeverLoggedIn {
+		db = db.Where("users.last_login_at IS NULL")
+	}
+	if c.IdleBefore != nil {
+		db = db.Where("COALESCE(users.last_login_at, users.created_at) < ?", *c.IdleBefore)
+	}
+	if c.LoginCountMin != nil {
+		db = db.Where("users.login_count >= ?", *c.LoginCountMin)
+	}
+	if c.LoginCountMax != nil {
+		db = db.Where("users.login_count <= ?", *c.LoginCountMax)
+	}
+	if c.DeletionDueBefore != nil {
+		db = db.Where("users.deletion_scheduled_at <= ?", *c.DeletionDueBefore)
+	}
+	if c.BanExpiredBefore != nil {
+		db = db.Where("users.status = ? AND users.banned_until <= ?", user.StatusBanned, *c.BanExpiredBefore)
+	}
+	for key, value := range c.Attributes {
+		matching := db.Session(&gorm.Session{NewDB: true}).Model(&UserAttributeValueModel{}).
+			Select("user_id").Where("attribute_key = ? AND value = ?", key, value)
+		db = db.Where("users.id IN (?)", matching)
+	}
+	if c.Policy != nil {
+		return applyPolicyFilter(db, *c.Policy, userPolicyColumns)
+	}
+	return db, nil
+}
+
+// userKeysetCondition 构造键集分页条件：排序元组严格位于游标之后
+//
+// 对排序 (f1, f2, ..., id) 与游标值 (v1, v2, ..., vn) 生成
+// (f1 > v1) OR (f1 = v1 AND f2 > v2) OR ...，降序字段使用 "<"。
+// 字段名来自已校验的排序字段白名单。
+func userKeysetCondition(order []user.SortField, values []any) (string, []any) {
+	var (
+		branches []string
+		args     []any
+	)
+	for i, s := range order {
+		parts := make([]string, 0, i+1)
+		for j := range i {
+			column, vars := userSortColumn(order[j].Field)
+			parts = append(parts, column+" = ?")
+			args = append(append(args, vars...), values[j])
+		}
+		op := ">"
+		if s.Desc {
+			op = "<"
+		}
+		column, vars := userSortColumn(s.Field)
+		parts = append(parts, column+" "+op+" ?")
+		args = append(append(args, vars...), values[i])
+		branches = append(branches, "("+strings.Join(parts, " AND ")+")")
+	}
+	return strings.Join(branches, " OR "), args
+}
+
+// userOrderBy 构造排序子句，排序字段来自已校验的白名单
+func userOrderBy(order []user.SortField) clause.OrderBy {
+	columns := make([]string, 0, len(order))
+	var vars []any
+	for _, s := range order {
+		column, columnVars := userSortColumn(s.Field)
+		if s.Desc {
+			column += " DESC"
+		}
+		columns = append(columns, column)
+		vars = append(vars, columnVars...)
+	}
+	return clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(columns, ", "), Vars: vars, WithoutParentheses: true}}
+}
+
+// userSortColumn 返回排序字段对应的 SQL 表达式及其参数
+// 最近登录时间可能为空，以 NeverLoggedInSortTime 代替，使排序与键集分页条件一致
+func userSortColumn(field string) (string, []any) {
+	if field == user.SortByLastLoginAt {
+		return "COALESCE(users.last_login_at, ?)", []any{user.NeverLoggedInSortTime}
+	}
+	return "users." + field, nil
+}
+
 // Exists 检查用户是否存在
 func (r *userQueryRepository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
@@ -9,6 +9,24 @@ import (
 type UserRepositories struct {
 	Command user.CommandRepository
 	Query   user.QueryRepository
+
+	InvitationCommand user.InvitationCommandRepository
+	InvitationQuery   user.InvitationQueryRepository
+
+	RoleAssignmentCommand user.RoleAssignmentCommandRepository
+	RoleAssignmentQuery   user.RoleAssignmentQueryRepository
+
+	ImportJobCommand user.ImportJobCommandRepository
+	ImportJobQuery   user.ImportJobQueryRepository
+
+	DataExportCommand user.DataExportCommandRepository
+	DataExportQuery   user.DataExportQueryRepository
+
+	StatusHistoryCommand user.StatusHistoryCommandRepository
+	StatusHistoryQuery   user.StatusHistoryQueryRepository
+
+	AttributeDefinitionCommand user.AttributeDefinitionCommandRepository
+	AttributeDefinitionQuery   user.AttributeDefinitionQueryRepository
 }
 
 // NewUserRepositories 创建聚合实例，同时初始化 Command/Query 仓储
@@ -16,5 +34,23 @@ func NewUserRepositories(db *gorm.DB) UserRepositories {
 	return UserRepositories{
 		Command: NewUserCommandRepository(db),
 		Query:   NewUserQueryRepository(db),
+
+		InvitationCommand: NewInvitationCommandRepository(db),
+		InvitationQuery:   NewInvitationQueryRepository(db),
+
+		RoleAssignmentCommand: NewRoleAssignmentCommandRepository(db),
+		RoleAssignmentQuery:   NewRoleAssignmentQueryRepository(db),
+
+		ImportJobCommand: NewImportJobCommandRepository(db),
+		ImportJobQuery:   NewImportJobQueryRepository(db),
+
+		DataExportCommand: NewDataExportCommandRepository(db),
+		DataExportQuery:   NewDataExportQueryRepository(db),
+
+		StatusHistoryCommand: NewStatusHistoryCommandRepository(db),
+		StatusHistoryQuery:   NewStatusHistoryQueryRepository(db),
+
+		AttributeDefinitionCommand: NewAttributeDefinitionCommandRepository(db),
+		AttributeDefinitionQuery:   NewAttributeDefinitionQueryRepository(db),
 	}
 }
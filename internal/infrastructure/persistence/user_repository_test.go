@@ -3,10 +3,13 @@ package persistence
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/group"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/policy"
 	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -22,8 +25,13 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	})
 	require.NoError(t, err, "无法连接测试数据库")
 
+	require.NoError(t, RegisterJoinTables(db))
+
 	// 迁移所有需要的表
-	err = db.AutoMigrate(&UserModel{}, &RoleModel{}, &PermissionModel{})
+	err = db.AutoMigrate(
+		&UserModel{}, &RoleModel{}, &PermissionModel{}, &UserRoleModel{}, &UserGroupModel{}, &UserGroupMemberModel{},
+		&UserAttributeDefinitionModel{}, &UserAttributeValueModel{}, &UserSettingModel{},
+	)
 	require.NoError(t, err, "数据库迁移失败")
 
 	return db
@@ -265,6 +273,100 @@ func TestUserCommandRepository_AssignRoles(t *testing.T) {
 	})
 }
 
+func TestRoleAssignmentRepository_TimeBound(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	setup := func(t *testing.T) (*gorm.DB, *user.User, *RoleModel, *RoleModel, *RoleModel) {
+		t.Helper()
+		db := setupTestDB(t)
+		u := &user.User{Username: "oncall", Email: "oncall@example.com", Password: "password", Status: "active"}
+		require.NoError(t, NewUserCommandRepository(db).Create(ctx, u))
+		return db, u, createTestRole(t, db, "viewer"), createTestRole(t, db, "oncall"), createTestRole(t, db, "contractor")
+	}
+
+	t.Run("角色查询仅包含生效中的授权", func(t *testing.T) {
+		db, u, viewer, oncall, contractor := setup(t)
+		userRepo := NewUserCommandRepository(db)
+		grants := NewRoleAssignmentCommandRepository(db)
+
+		require.NoError(t, userRepo.AssignRoles(ctx, u.ID, []uint{viewer.ID}))
+		soon, later := now.Add(time.Hour), now.Add(2*time.Hour)
+		require.NoError(t, grants.Grant(ctx, &user.RoleAssignment{UserID: u.ID, RoleID: oncall.ID, StartsAt: &soon, ExpiresAt: &later}))
+		past := now.Add(-time.Minute)
+		require.NoError(t, grants.Grant(ctx, &user.RoleAssignment{UserID: u.ID, RoleID: contractor.ID, ExpiresAt: &past}))
+
+		roleIDs, err := NewUserQueryRepository(db).GetRoles(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{viewer.ID}, roleIDs, "计划授权和已过期授权不生效")
+
+		all, err := NewRoleAssignmentQueryRepository(db).ListByUser(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
+
+	t.Run("永久角色替换不影响限时授权", func(t *testing.T) {
+		db, u, viewer, oncall, _ := setup(t)
+		userRepo := NewUserCommandRepository(db)
+
+		expires := now.Add(time.Hour)
+		require.NoError(t, NewRoleAssignmentCommandRepository(db).Grant(ctx, &user.RoleAssignment{UserID: u.ID, RoleID: oncall.ID, ExpiresAt: &expires}))
+		require.NoError(t, userRepo.AssignRoles(ctx, u.ID, []uint{viewer.ID}))
+		require.NoError(t, userRepo.AssignRoles(ctx, u.ID, []uint{}))
+
+		roleIDs, err := NewUserQueryRepository(db).GetRoles(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{oncall.ID}, roleIDs)
+	})
+
+	t.Run("到期授权查询与移除", func(t *testing.T) {
+		db, u, viewer, oncall, contractor := setup(t)
+		grants := NewRoleAssignmentCommandRepository(db)
+		query := NewRoleAssignmentQueryRepository(db)
+
+		past, soon, later := now.Add(-time.Minute), now.Add(time.Hour), now.Add(30*24*time.Hour)
+		require.NoError(t, grants.Grant(ctx, &user.RoleAssignment{UserID: u.ID, RoleID: viewer.ID, ExpiresAt: &past}))
+		require.NoError(t, grants.Grant(ctx, &user.RoleAssignment{UserID: u.ID, RoleID: oncall.ID, ExpiresAt: &soon}))
+		require.NoError(t, grants.Grant(ctx, &user.RoleAssignment{UserID: u.ID, RoleID: contractor.ID, ExpiresAt: &later}))
+
+		expiring, total, err := query.ListExpiring(ctx, now, now.Add(7*24*time.Hour), 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, expiring, 1)
+		assert.Equal(t, "oncall", expiring[0].RoleName)
+
+		expired, err := query.ListExpired(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+
+		removed, err := grants.Expire(ctx, u.ID, viewer.ID, now)
+		require.NoError(t, err)
+		assert.True(t, removed)
+
+		removed, err = grants.Expire(ctx, u.ID, oncall.ID, now)
+		require.NoError(t, err)
+		assert.False(t, removed, "未到期的授权不会被移除")
+	})
+
+	t.Run("计划授权到期激活", func(t *testing.T) {
+		db, u, _, oncall, _ := setup(t)
+		grants := NewRoleAssignmentCommandRepository(db)
+		query := NewRoleAssignmentQueryRepository(db)
+
+		startsAt := now.Add(-time.Second)
+		require.NoError(t, grants.Grant(ctx, &user.RoleAssignment{UserID: u.ID, RoleID: oncall.ID, StartsAt: &startsAt}))
+
+		due, err := query.ListDueActivations(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+
+		require.NoError(t, grants.MarkActivated(ctx, u.ID, oncall.ID, now))
+		due, err = query.ListDueActivations(ctx, now, 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+	})
+}
+
 func TestUserCommandRepository_RemoveRoles(t *testing.T) {
 	ctx := context.Background()
 
@@ -326,6 +428,42 @@ func TestUserCommandRepository_UpdatePassword(t *testing.T) {
 		err = db.First(&model, u.ID).Error
 		require.NoError(t, err)
 		assert.Equal(t, "new_password_hash", model.Password)
+		assert.False(t, model.MustChangePassword)
+		assert.NotNil(t, model.PasswordChangedAt)
+	})
+}
+
+func TestUserCommandRepository_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("重置密码并要求修改", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewUserCommandRepository(db)
+
+		u := &user.User{
+			Username: "testuser",
+			Email:    "test@example.com",
+			Password: "old_password",
+			Status:   "active",
+		}
+		err := repo.Create(ctx, u)
+		require.NoError(t, err)
+
+		err = repo.ResetPassword(ctx, u.ID, "temp_password_hash")
+		require.NoError(t, err)
+
+		var model UserModel
+		err = db.First(&model, u.ID).Error
+		require.NoError(t, err)
+		assert.Equal(t, "temp_password_hash", model.Password)
+		assert.True(t, model.MustChangePassword)
+
+		// 用户自行修改后清除标记
+		err = repo.UpdatePassword(ctx, u.ID, "new_password_hash")
+		require.NoError(t, err)
+		err = db.First(&model, u.ID).Error
+		require.NoError(t, err)
+		assert.False(t, model.MustChangePassword)
 	})
 }
 
@@ -357,6 +495,122 @@ func TestUserCommandRepository_UpdateStatus(t *testing.T) {
 	})
 }
 
+func TestUserCommandRepository_UpdateDeletionSchedule(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	cmdRepo := NewUserCommandRepository(db)
+	qryRepo := NewUserQueryRepository(db)
+
+	now := time.Now().Truncate(time.Second)
+	due := &user.User{Username: "due", Email: "due@example.com", Password: "password", Status: "active"}
+	later := &user.User{Username: "later", Email: "later@example.com", Password: "password", Status: "active"}
+	require.NoError(t, cmdRepo.Create(ctx, due))
+	require.NoError(t, cmdRepo.Create(ctx, later))
+
+	dueAt, laterAt := now.Add(-time.Hour), now.Add(time.Hour)
+	require.NoError(t, cmdRepo.UpdateDeletionSchedule(ctx, due.ID, &now, &dueAt))
+	require.NoError(t, cmdRepo.UpdateDeletionSchedule(ctx, later.ID, &now, &laterAt))
+
+	users, err := qryRepo.ListByCriteria(ctx, user.ListCriteria{DeletionDueBefore: &now, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, due.ID, users[0].ID)
+	assert.True(t, users[0].IsDeletionDue(now))
+
+	// 取消删除
+	require.NoError(t, cmdRepo.UpdateDeletionSchedule(ctx, due.ID, nil, nil))
+	got, err := qryRepo.GetByID(ctx, due.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsDeletionPending())
+}
+
+func TestUserCommandRepository_SaveStatus(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	cmdRepo := NewUserCommandRepository(db)
+	qryRepo := NewUserQueryRepository(db)
+
+	now := time.Now().Truncate(time.Second)
+	expired := &user.User{Username: "expired", Email: "expired@example.com", Password: "password", Status: "active"}
+	permanent := &user.User{Username: "permanent", Email: "permanent@example.com", Password: "password", Status: "active"}
+	require.NoError(t, cmdRepo.Create(ctx, expired))
+	require.NoError(t, cmdRepo.Create(ctx, permanent))
+
+	until := now.Add(time.Hour)
+	require.NoError(t, expired.ChangeStatus(user.StatusBanned, "spam", &until, now))
+	require.NoError(t, cmdRepo.SaveStatus(ctx, expired))
+	require.NoError(t, permanent.ChangeStatus(user.StatusBanned, "fraud", nil, now))
+	require.NoError(t, cmdRepo.SaveStatus(ctx, permanent))
+
+	got, err := qryRepo.GetByID(ctx, expired.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsBanned())
+	assert.Equal(t, "spam", got.BanReason)
+	require.NotNil(t, got.BannedUntil)
+	assert.True(t, until.Equal(*got.BannedUntil))
+	assert.True(t, got.IsSessionRevoked(now))
+
+	// 仅到期的限时封禁会被列出，永久封禁不会
+	later := now.Add(2 * time.Hour)
+	users, err := qryRepo.ListByCriteria(ctx, user.ListCriteria{BanExpiredBefore: &later, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, expired.ID, users[0].ID)
+
+	// 通过 UpdateStatus 解封时清除封禁信息
+	require.NoError(t, cmdRepo.UpdateStatus(ctx, expired.ID, user.StatusActive))
+	got, err = qryRepo.GetByID(ctx, expired.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.BanReason)
+	assert.Nil(t, got.BannedUntil)
+}
+
+func TestUserCommandRepository_SaveAttributes(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	cmdRepo := NewUserCommandRepository(db)
+	qryRepo := NewUserQueryRepository(db)
+	defCmdRepo := NewAttributeDefinitionCommandRepository(db)
+
+	alice := &user.User{Username: "alice", Email: "alice@example.com", Password: "password", Status: "active"}
+	bob := &user.User{Username: "bob", Email: "bob@example.com", Password: "password", Status: "active"}
+	require.NoError(t, cmdRepo.Create(ctx, alice))
+	require.NoError(t, cmdRepo.Create(ctx, bob))
+
+	require.NoError(t, cmdRepo.SaveAttributes(ctx, alice.ID, map[string]string{"employee_id": "E000001", "remote": "true"}))
+	require.NoError(t, cmdRepo.SaveAttributes(ctx, bob.ID, map[string]string{"employee_id": "E000002", "remote": "true"}))
+
+	got, err := qryRepo.GetByID(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"employee_id": "E000001", "remote": "true"}, got.Attributes)
+
+	// 替换全部属性值
+	require.NoError(t, cmdRepo.SaveAttributes(ctx, alice.ID, map[string]string{"remote": "false"}))
+	got, err = qryRepo.GetByIDWithRoles(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"remote": "false"}, got.Attributes)
+
+	// 按属性过滤（多个属性均需匹配）
+	users, err := qryRepo.ListByCriteria(ctx, user.ListCriteria{Attributes: map[string]string{"remote": "true"}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, bob.ID, users[0].ID)
+	assert.Equal(t, "E000002", users[0].Attributes["employee_id"])
+
+	count, err := qryRepo.CountByCriteria(ctx, user.ListCriteria{Attributes: map[string]string{"remote": "true", "employee_id": "E000001"}})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// 删除属性定义时同时删除属性值
+	def := &user.AttributeDefinition{Key: "employee_id", Label: "工号", Type: user.AttributeTypeString, Visibility: user.AttributeVisibilityUser}
+	require.NoError(t, defCmdRepo.Create(ctx, def))
+	require.NoError(t, defCmdRepo.Delete(ctx, def.ID))
+	got, err = qryRepo.GetByID(ctx, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"remote": "true"}, got.Attributes)
+	require.ErrorIs(t, defCmdRepo.Delete(ctx, def.ID), user.ErrAttributeDefinitionNotFound)
+}
+
 func TestUserQueryRepository_GetByID(t *testing.T) {
 	ctx := context.Background()
 
@@ -582,6 +836,183 @@ func TestUserQueryRepository_Count(t *testing.T) {
 	})
 }
 
+func TestUserQueryRepository_ListByCriteria(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	cmdRepo := NewUserCommandRepository(db)
+	queryRepo := NewUserQueryRepository(db)
+	groupRepo := NewGroupCommandRepository(db)
+
+	editor := createTestRole(t, db, "editor")
+
+	// alice/carol 为 active，bob/dave 为 inactive；carol 直接拥有 editor，dave 经由用户组拥有 editor
+	ids := make(map[string]uint)
+	for _, spec := range []struct{ name, status string }{
+		{"alice", "active"}, {"bob", "inactive"}, {"carol", "active"}, {"dave", "inactive"},
+	} {
+		u := &user.User{Username: spec.name, Email: spec.name + "@example.com", Password: "password", Status: spec.status}
+		require.NoError(t, cmdRepo.Create(ctx, u))
+		ids[spec.name] = u.ID
+	}
+	require.NoError(t, cmdRepo.AssignRoles(ctx, ids["carol"], []uint{editor.ID}))
+	editors := &group.Group{Name: "editors"}
+	require.NoError(t, groupRepo.Create(ctx, editors))
+	require.NoError(t, groupRepo.SetRoles(ctx, editors.ID, []uint{editor.ID}))
+	require.NoError(t, groupRepo.AddMembers(ctx, editors.ID, []uint{ids["dave"]}))
+
+	usernames := func(users []*user.User) []string {
+		names := make([]string, 0, len(users))
+		for _, u := range users {
+			names = append(names, u.Username)
+		}
+		return names
+	}
+
+	t.Run("按状态过滤并统计", func(t *testing.T) {
+		criteria := user.ListCriteria{Statuses: []string{"inactive"}}
+		users, err := queryRepo.ListByCriteria(ctx, criteria)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob", "dave"}, usernames(users))
+
+		count, err := queryRepo.CountByCriteria(ctx, criteria)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("按角色过滤包含用户组角色", func(t *testing.T) {
+		users, err := queryRepo.ListByCriteria(ctx, user.ListCriteria{RoleIDs: []uint{editor.ID}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"carol", "dave"}, usernames(users))
+		require.Len(t, users[0].Roles, 1)
+	})
+
+	t.Run("多字段排序与键集分页", func(t *testing.T) {
+		criteria := user.ListCriteria{
+			Sort:  []user.SortField{{Field: user.SortByStatus}, {Field: user.SortByUsername, Desc: true}},
+			Limit: 3,
+		}
+		first, err := queryRepo.ListByCriteria(ctx, criteria)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"carol", "alice", "dave"}, usernames(first))
+
+		criteria.After = criteria.CursorFor(first[len(first)-1])
+		second, err := queryRepo.ListByCriteria(ctx, criteria)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob"}, usernames(second))
+	})
+
+	t.Run("拒绝不支持的排序字段", func(t *testing.T) {
+		_, err := queryRepo.ListByCriteria(ctx, user.ListCriteria{Sort: []user.SortField{{Field: "password"}}})
+		require.ErrorIs(t, err, user.ErrInvalidListCriteria)
+	})
+
+	t.Run("按策略过滤", func(t *testing.T) {
+		criteria := user.ListCriteria{Policy: &policy.Filter{Alternatives: [][]policy.Predicate{
+			{{Field: "status", Op: policy.OpEqual, Value: "active"}, {Field: "username", Op: policy.OpNotEqual, Value: "alice"}},
+			{{Field: "id", Op: policy.OpEqual, Value: ids["bob"]}},
+		}}}
+		users, err := queryRepo.ListByCriteria(ctx, criteria)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob", "carol"}, usernames(users))
+
+		count, err := queryRepo.CountByCriteria(ctx, user.ListCriteria{Policy: &policy.Filter{}})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		_, err = queryRepo.ListByCriteria(ctx, user.ListCriteria{Policy: &policy.Filter{Alternatives: [][]policy.Predicate{
+			{{Field: "password", Op: policy.OpEqual, Value: "x"}},
+		}}})
+		require.ErrorIs(t, err, policy.ErrUnsupportedFilter)
+	})
+}
+
+func TestUserCommandRepository_RecordLogins(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	cmdRepo := NewUserCommandRepository(db)
+	queryRepo := NewUserQueryRepository(db)
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	ids := make(map[string]uint)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		u := &user.User{Username: name, Email: name + "@example.com", Password: "password", Status: "active"}
+		require.NoError(t, cmdRepo.Create(ctx, u))
+		ids[name] = u.ID
+	}
+
+	require.NoError(t, cmdRepo.RecordLogins(ctx, []user.LoginSummary{
+		{UserID: ids["alice"], LastAt: base.Add(10 * time.Minute), LastIP: "10.0.0.1", Count: 2},
+		{UserID: ids["bob"], LastAt: base, LastIP: "10.0.0.2", Count: 1},
+	}))
+
+	t.Run("累加次数且最近登录时间不后退", func(t *testing.T) {
+		require.NoError(t, cmdRepo.RecordLogins(ctx, []user.LoginSummary{
+			{UserID: ids["alice"], LastAt: base, LastIP: "10.0.0.9", Count: 1},
+		}))
+
+		alice, err := queryRepo.GetByID(ctx, ids["alice"])
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), alice.LoginCount)
+		assert.Equal(t, "10.0.0.1", alice.LastLoginIP)
+		require.NotNil(t, alice.LastLoginAt)
+		assert.True(t, alice.LastLoginAt.Equal(base.Add(10*time.Minute)))
+	})
+
+	t.Run("更新用户不覆盖登录活动", func(t *testing.T) {
+		stale := &user.User{ID: ids["bob"], Username: "bob", Email: "bob@example.com", Password: "password", Status: "active", FullName: "Bob"}
+		require.NoError(t, cmdRepo.Update(ctx, stale))
+
+		bob, err := queryRepo.GetByID(ctx, ids["bob"])
+		require.NoError(t, err)
+		assert.Equal(t, "Bob", bob.FullName)
+		assert.Equal(t, int64(1), bob.LoginCount)
+		assert.Equal(t, "10.0.0.2", bob.LastLoginIP)
+	})
+
+	t.Run("按最近登录过滤", func(t *testing.T) {
+		from := base.Add(time.Minute)
+		users, err := queryRepo.ListByCriteria(ctx, user.ListCriteria{LastLoginFrom: &from})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "alice", users[0].Username)
+
+		users, err = queryRepo.ListByCriteria(ctx, user.ListCriteria{NeverLoggedIn: true})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "carol", users[0].Username)
+
+		idleBefore := base.Add(time.Minute)
+		users, err = queryRepo.ListByCriteria(ctx, user.ListCriteria{IdleBefore: &idleBefore})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "bob", users[0].Username)
+
+		minCount := int64(2)
+		count, err := queryRepo.CountByCriteria(ctx, user.ListCriteria{LoginCountMin: &minCount})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("按最近登录排序，从未登录排在最早", func(t *testing.T) {
+		criteria := user.ListCriteria{Sort: []user.SortField{{Field: user.SortByLastLoginAt, Desc: true}}, Limit: 2}
+		first, err := queryRepo.ListByCriteria(ctx, criteria)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		assert.Equal(t, "alice", first[0].Username)
+		assert.Equal(t, "bob", first[1].Username)
+
+		criteria.After = criteria.CursorFor(first[1])
+		second, err := queryRepo.ListByCriteria(ctx, criteria)
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.Equal(t, "carol", second[0].Username)
+
+		byCount, err := queryRepo.ListByCriteria(ctx, user.ListCriteria{Sort: []user.SortField{{Field: user.SortByLoginCount, Desc: true}}})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", byCount[0].Username)
+	})
+}
+
 func TestUserQueryRepository_GetByIDWithRoles(t *testing.T) {
 	ctx := context.Background()
 
@@ -615,6 +1046,40 @@ func TestUserQueryRepository_GetByIDWithRoles(t *testing.T) {
 		assert.Len(t, found.Roles, 1)
 		assert.Equal(t, "admin", found.Roles[0].Name)
 	})
+
+	t.Run("包含用户组角色", func(t *testing.T) {
+		db := setupTestDB(t)
+		cmdRepo := NewUserCommandRepository(db)
+		queryRepo := NewUserQueryRepository(db)
+		groupRepo := NewGroupCommandRepository(db)
+
+		viewer := createTestRole(t, db, "viewer")
+		editor := createTestRole(t, db, "editor")
+		auditor := createTestRole(t, db, "auditor")
+
+		u := &user.User{Username: "groupuser", Email: "group@example.com", Password: "password", Status: "active"}
+		require.NoError(t, cmdRepo.Create(ctx, u))
+		require.NoError(t, cmdRepo.AssignRoles(ctx, u.ID, []uint{viewer.ID}))
+
+		engineering := &group.Group{Name: "engineering"}
+		require.NoError(t, groupRepo.Create(ctx, engineering))
+		require.NoError(t, groupRepo.SetRoles(ctx, engineering.ID, []uint{viewer.ID, editor.ID}))
+		require.NoError(t, groupRepo.AddMembers(ctx, engineering.ID, []uint{u.ID}))
+
+		// 已删除的用户组不再授予角色
+		legacy := &group.Group{Name: "legacy"}
+		require.NoError(t, groupRepo.Create(ctx, legacy))
+		require.NoError(t, groupRepo.SetRoles(ctx, legacy.ID, []uint{auditor.ID}))
+		require.NoError(t, groupRepo.AddMembers(ctx, legacy.ID, []uint{u.ID, u.ID}))
+		require.NoError(t, db.Delete(&UserGroupModel{}, legacy.ID).Error)
+
+		found, err := queryRepo.GetByIDWithRoles(ctx, u.ID)
+
+		require.NoError(t, err)
+		require.Len(t, found.Roles, 1)
+		assert.Len(t, found.GroupRoles, 2)
+		assert.Equal(t, []string{"viewer", "editor"}, found.GetRoleNames())
+	})
 }
 
 func TestUserModel_Mapping(t *testing.T) {
@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// UserRoleModel 用户角色关联表（自定义连接表，承载限时授权的时间窗口）
+//
+// 通过 RegisterJoinTables 注册为 UserModel.Roles 的连接表后，
+// 预加载用户角色时自动排除未开始和已过期的授权；需要查看全部授权时使用 Unscoped。
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type UserRoleModel struct {
+	UserID uint `gorm:"primaryKey"`
+	RoleID uint `gorm:"primaryKey"`
+
+	StartsAt    *time.Time `gorm:"index"`
+	ExpiresAt   *time.Time `gorm:"index"`
+	ActivatedAt *time.Time
+	CreatedAt   time.Time
+
+	Role RoleModel `gorm:"foreignKey:RoleID"`
+
+	// Window 查询作用域标记，不对应数据库列
+	Window activeGrantWindow `gorm:"-"`
+}
+
+// TableName 指定用户角色关联表名
+func (UserRoleModel) TableName() string {
+	return "user_roles"
+}
+
+// RegisterJoinTables 注册自定义连接表
+// 需在使用仓储和自动迁移之前对每个数据库连接调用一次
+func RegisterJoinTables(db *gorm.DB) error {
+	if err := db.SetupJoinTable(&UserModel{}, "Roles", &UserRoleModel{}); err != nil {
+		return fmt.Errorf("failed to setup user_roles join table: %w", err)
+	}
+	return nil
+}
+
+func newUserRoleModelFromEntity(entity *user.RoleAssignment) *UserRoleModel {
+	if entity == nil {
+		return nil
+	}
+
+	return &UserRoleModel{
+		UserID:      entity.UserID,
+		RoleID:      entity.RoleID,
+		StartsAt:    entity.StartsAt,
+		ExpiresAt:   entity.ExpiresAt,
+		ActivatedAt: entity.ActivatedAt,
+		CreatedAt:   entity.CreatedAt,
+	}
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *UserRoleModel) ToEntity() *user.RoleAssignment {
+	if m == nil {
+		return nil
+	}
+
+	return &user.RoleAssignment{
+		UserID:      m.UserID,
+		RoleID:      m.RoleID,
+		RoleName:    m.Role.Name,
+		StartsAt:    m.StartsAt,
+		ExpiresAt:   m.ExpiresAt,
+		ActivatedAt: m.ActivatedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func mapUserRoleModelsToEntities(models []UserRoleModel) []*user.RoleAssignment {
+	result := make([]*user.RoleAssignment, 0, len(models))
+	for i := range models {
+		result = append(result, models[i].ToEntity())
+	}
+	return result
+}
+
+// activeGrantWindow 授权生效时间窗口作用域
+// 与 gorm.DeletedAt 的软删除作用域相同，通过字段类型为模型的查询追加条件
+type activeGrantWindow struct{}
+
+// QueryClauses 实现 schema.QueryClausesInterface
+func (activeGrantWindow) QueryClauses(*schema.Field) []clause.Interface {
+	return []clause.Interface{activeGrantWindowClause{}}
+}
+
+// activeGrantWindowClause 仅保留当前生效的授权：已开始（或无开始时间）且未过期（或永久）
+type activeGrantWindowClause struct{}
+
+func (activeGrantWindowClause) Name() string               { return "" }
+func (activeGrantWindowClause) Build(clause.Builder)       {}
+func (activeGrantWindowClause) MergeClause(*clause.Clause) {}
+
+// ModifyStatement 追加生效时间窗口条件，Unscoped 查询不追加
+func (activeGrantWindowClause) ModifyStatement(stmt *gorm.Statement) {
+	if _, ok := stmt.Clauses["grant_window_enabled"]; ok || stmt.Unscoped {
+		return
+	}
+
+	now := time.Now()
+	startsAt := clause.Column{Table: clause.CurrentTable, Name: "starts_at"}
+	expiresAt := clause.Column{Table: clause.CurrentTable, Name: "expires_at"}
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Or(clause.Eq{Column: startsAt, Value: nil}, clause.Lte{Column: startsAt, Value: now}),
+		clause.Or(clause.Eq{Column: expiresAt, Value: nil}, clause.Gt{Column: expiresAt, Value: now}),
+	}})
+	stmt.Clauses["grant_window_enabled"] = clause.Clause{}
+}
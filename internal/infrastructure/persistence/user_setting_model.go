@@ -0,0 +1,55 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+)
+
+// UserSettingModel 用户偏好覆盖值的 GORM 实体
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type UserSettingModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"uniqueIndex:idx_user_settings_user_key;not null"`
+	Key       string `gorm:"uniqueIndex:idx_user_settings_user_key;size:100;not null"`
+	Value     string `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName 指定用户偏好表名
+func (UserSettingModel) TableName() string {
+	return "user_settings"
+}
+
+func newUserSettingModelFromEntity(entity *setting.UserSetting) *UserSettingModel {
+	if entity == nil {
+		return nil
+	}
+
+	return &UserSettingModel{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		Key:       entity.Key,
+		Value:     entity.Value,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *UserSettingModel) ToEntity() *setting.UserSetting {
+	if m == nil {
+		return nil
+	}
+
+	return &setting.UserSetting{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Key:       m.Key,
+		Value:     m.Value,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
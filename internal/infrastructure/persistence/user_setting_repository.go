@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// userSettingCommandRepository 用户偏好命令仓储的 GORM 实现
+type userSettingCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewUserSettingCommandRepository 创建用户偏好命令仓储实例
+func NewUserSettingCommandRepository(db *gorm.DB) setting.UserSettingCommandRepository {
+	return &userSettingCommandRepository{db: db}
+}
+
+// Upsert 按 (user_id, key) 插入或更新覆盖值
+func (r *userSettingCommandRepository) Upsert(ctx context.Context, settings []*setting.UserSetting) error {
+	if len(settings) == 0 {
+		return nil
+	}
+
+	models := make([]*UserSettingModel, 0, len(settings))
+	for _, s := range settings {
+		if model := newUserSettingModelFromEntity(s); model != nil {
+			models = append(models, model)
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(models).Error; err != nil {
+		return fmt.Errorf("failed to upsert user settings: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByKeys 删除用户指定键的覆盖值
+func (r *userSettingCommandRepository) DeleteByKeys(ctx context.Context, userID uint, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND key IN ?", userID, keys).
+		Delete(&UserSettingModel{}).Error; err != nil {
+		return fmt.Errorf("failed to delete user settings: %w", err)
+	}
+	return nil
+}
+
+// userSettingQueryRepository 用户偏好查询仓储的 GORM 实现
+type userSettingQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewUserSettingQueryRepository 创建用户偏好查询仓储实例
+func NewUserSettingQueryRepository(db *gorm.DB) setting.UserSettingQueryRepository {
+	return &userSettingQueryRepository{db: db}
+}
+
+// FindByUserID 查找用户的全部覆盖值
+func (r *userSettingQueryRepository) FindByUserID(ctx context.Context, userID uint) ([]*setting.UserSetting, error) {
+	var models []UserSettingModel
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("key ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find user settings: %w", err)
+	}
+
+	settings := make([]*setting.UserSetting, 0, len(models))
+	for i := range models {
+		settings = append(settings, models[i].ToEntity())
+	}
+	return settings, nil
+}
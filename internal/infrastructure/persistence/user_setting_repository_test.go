@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/setting"
+)
+
+func TestUserSettingRepository_UpsertAndDelete(t *testing.T) {
+	db := setupTestDB(t)
+	cmdRepo := NewUserSettingCommandRepository(db)
+	qryRepo := NewUserSettingQueryRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, cmdRepo.Upsert(ctx, []*setting.UserSetting{
+		{UserID: 1, Key: setting.KeyPreferenceLocale, Value: "en"},
+		{UserID: 1, Key: setting.KeyPreferenceTheme, Value: "dark"},
+		{UserID: 2, Key: setting.KeyPreferenceLocale, Value: "zh-CN"},
+	}))
+
+	// 同一用户同一键再次写入时更新而非新增
+	require.NoError(t, cmdRepo.Upsert(ctx, []*setting.UserSetting{
+		{UserID: 1, Key: setting.KeyPreferenceLocale, Value: "ja"},
+	}))
+
+	got, err := qryRepo.FindByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, setting.KeyPreferenceLocale, got[0].Key)
+	assert.Equal(t, "ja", got[0].Value)
+
+	require.NoError(t, cmdRepo.DeleteByKeys(ctx, 1, []string{setting.KeyPreferenceLocale}))
+
+	got, err = qryRepo.FindByUserID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, setting.KeyPreferenceTheme, got[0].Key)
+
+	other, err := qryRepo.FindByUserID(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, other, 1)
+}
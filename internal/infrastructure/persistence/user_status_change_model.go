@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// UserStatusChangeModel 用户状态变更记录的 GORM 持久化模型
+//
+//nolint:recvcheck // TableName uses value receiver per GORM convention
+type UserStatusChangeModel struct {
+	ID        uint      `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"index"`
+
+	UserID      uint   `gorm:"index;not null"`
+	FromStatus  string `gorm:"size:20;not null"`
+	ToStatus    string `gorm:"size:20;not null"`
+	Reason      string `gorm:"size:500"`
+	BannedUntil *time.Time
+	OperatorID  uint `gorm:"index"`
+}
+
+// TableName 指定用户状态变更记录表名
+func (UserStatusChangeModel) TableName() string {
+	return "user_status_changes"
+}
+
+func newUserStatusChangeModelFromEntity(entity *user.StatusChange) *UserStatusChangeModel {
+	if entity == nil {
+		return nil
+	}
+
+	return &UserStatusChangeModel{
+		ID:          entity.ID,
+		CreatedAt:   entity.CreatedAt,
+		UserID:      entity.UserID,
+		FromStatus:  entity.FromStatus,
+		ToStatus:    entity.ToStatus,
+		Reason:      entity.Reason,
+		BannedUntil: entity.BannedUntil,
+		OperatorID:  entity.OperatorID,
+	}
+}
+
+// ToEntity 将 GORM Model 转换为 Domain Entity
+func (m *UserStatusChangeModel) ToEntity() *user.StatusChange {
+	if m == nil {
+		return nil
+	}
+
+	return &user.StatusChange{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UserID:      m.UserID,
+		FromStatus:  m.FromStatus,
+		ToStatus:    m.ToStatus,
+		Reason:      m.Reason,
+		BannedUntil: m.BannedUntil,
+		OperatorID:  m.OperatorID,
+	}
+}
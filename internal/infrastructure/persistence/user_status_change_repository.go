@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// statusHistoryCommandRepository 用户状态变更记录命令仓储的 GORM 实现
+type statusHistoryCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewStatusHistoryCommandRepository 创建用户状态变更记录命令仓储实例
+func NewStatusHistoryCommandRepository(db *gorm.DB) user.StatusHistoryCommandRepository {
+	return &statusHistoryCommandRepository{db: db}
+}
+
+// Create 追加状态变更记录
+func (r *statusHistoryCommandRepository) Create(ctx context.Context, change *user.StatusChange) error {
+	model := newUserStatusChangeModelFromEntity(change)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create status change: %w", err)
+	}
+
+	change.ID = model.ID
+	change.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// statusHistoryQueryRepository 用户状态变更记录查询仓储的 GORM 实现
+type statusHistoryQueryRepository struct {
+	db *gorm.DB
+}
+
+// NewStatusHistoryQueryRepository 创建用户状态变更记录查询仓储实例
+func NewStatusHistoryQueryRepository(db *gorm.DB) user.StatusHistoryQueryRepository {
+	return &statusHistoryQueryRepository{db: db}
+}
+
+// ListByUser 按时间倒序分页列出用户的状态变更记录
+func (r *statusHistoryQueryRepository) ListByUser(ctx context.Context, userID uint, offset, limit int) ([]*user.StatusChange, int64, error) {
+	query := r.db.WithContext(ctx).Model(&UserStatusChangeModel{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count status changes: %w", err)
+	}
+
+	var models []UserStatusChangeModel
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list status changes: %w", err)
+	}
+
+	changes := make([]*user.StatusChange, 0, len(models))
+	for i := range models {
+		changes = append(changes, models[i].ToEntity())
+	}
+	return changes, total, nil
+}
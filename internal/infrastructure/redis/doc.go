@@ -29,11 +29,23 @@
 //	var cached User
 //	err = queryRepo.Get(ctx, "user:1", &cached)
 //
+// # 菜单树缓存
+//
+// [NewMenuTreeCache] 实现 menu.TreeCache，按权限集合缓存裁剪后的菜单树，
+// 通过递增版本号整体失效。
+//
+// # 定时任务锁
+//
+// [NewSchedulerLocker] 实现 scheduler.Locker，多个 worker 实例运行调度器时
+// 按任务名加锁，保证同一任务在每个执行间隔内只在一个实例上运行。
+//
 // # 键前缀约定
 //
 // 推荐的键命名规范：
 //   - 用户相关：user:{id}
 //   - 权限缓存：perm:{user_id}
+//   - 菜单树缓存：menu:tree:{version}:{permission_set}
+//   - 定时任务锁：scheduler:lock:{job}
 //   - 会话相关：session:{token}
 //   - 验证码：captcha:{id}
 //   - 令牌刷新：refresh:{token}
@@ -0,0 +1,106 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/menu"
+)
+
+// menuTreeCacheTTL 菜单树缓存过期时间
+const menuTreeCacheTTL = 10 * time.Minute
+
+// menuTreeCache 基于 Redis 的菜单树缓存
+//
+// 键格式：{prefix}menu:tree:{version}:{permissionSetKey}
+// 失效时递增版本号，旧版本的键随 TTL 自然过期，无需扫描删除。
+type menuTreeCache struct {
+	client    *redis.Client
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewMenuTreeCache 创建菜单树缓存实例
+func NewMenuTreeCache(client *redis.Client, keyPrefix string) menu.TreeCache {
+	return &menuTreeCache{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       menuTreeCacheTTL,
+	}
+}
+
+// Get 获取权限集合对应的菜单树
+func (c *menuTreeCache) Get(ctx context.Context, permissionSetKey string) ([]*menu.Menu, bool) {
+	version, err := c.version(ctx)
+	if err != nil {
+		slog.Warn("failed to read menu tree cache version", "error", err)
+		return nil, false
+	}
+
+	data, err := c.client.Get(ctx, c.treeKey(version, permissionSetKey)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			slog.Warn("failed to read menu tree cache", "error", err)
+		}
+		return nil, false
+	}
+
+	var menus []*menu.Menu
+	if err := json.Unmarshal(data, &menus); err != nil {
+		slog.Warn("failed to decode menu tree cache", "error", err)
+		return nil, false
+	}
+	return menus, true
+}
+
+// Set 缓存权限集合对应的菜单树
+func (c *menuTreeCache) Set(ctx context.Context, permissionSetKey string, menus []*menu.Menu) {
+	version, err := c.version(ctx)
+	if err != nil {
+		slog.Warn("failed to read menu tree cache version", "error", err)
+		return
+	}
+
+	data, err := json.Marshal(menus)
+	if err != nil {
+		slog.Warn("failed to encode menu tree cache", "error", err)
+		return
+	}
+
+	if err := c.client.Set(ctx, c.treeKey(version, permissionSetKey), data, c.ttl).Err(); err != nil {
+		slog.Warn("failed to write menu tree cache", "error", err)
+	}
+}
+
+// Invalidate 递增版本号，使所有已缓存的菜单树失效
+func (c *menuTreeCache) Invalidate(ctx context.Context) {
+	if err := c.client.Incr(ctx, c.versionKey()).Err(); err != nil {
+		slog.Error("failed to invalidate menu tree cache", "error", err)
+	}
+}
+
+// version 读取当前缓存版本号，不存在时为 0
+func (c *menuTreeCache) version(ctx context.Context) (int64, error) {
+	version, err := c.client.Get(ctx, c.versionKey()).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get menu tree cache version: %w", err)
+	}
+	return version, nil
+}
+
+func (c *menuTreeCache) versionKey() string {
+	return c.keyPrefix + "menu:tree:version"
+}
+
+func (c *menuTreeCache) treeKey(version int64, permissionSetKey string) string {
+	return fmt.Sprintf("%smenu:tree:%d:%s", c.keyPrefix, version, permissionSetKey)
+}
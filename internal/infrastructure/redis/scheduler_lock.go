@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SchedulerLocker 基于 SET NX 的定时任务锁，实现 scheduler.Locker
+//
+// 多个 worker 实例同时运行调度器时，同一任务在锁的有效期内只有一个实例能够执行。
+type SchedulerLocker struct {
+	client    *redis.Client
+	keyPrefix string
+	owner     string // 锁持有者标识（主机名:PID），便于排查
+}
+
+// NewSchedulerLocker 创建定时任务锁
+func NewSchedulerLocker(client *redis.Client, keyPrefix string) *SchedulerLocker {
+	host, _ := os.Hostname()
+	return &SchedulerLocker{
+		client:    client,
+		keyPrefix: keyPrefix,
+		owner:     fmt.Sprintf("%s:%d", host, os.Getpid()),
+	}
+}
+
+// TryLock 尝试获取任务锁，锁在 ttl 后自动过期
+func (l *SchedulerLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ok, err := l.client.SetNX(ctx, l.keyPrefix+"scheduler:lock:"+key, l.owner, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire scheduler lock %s: %w", key, err)
+	}
+	return ok, nil
+}
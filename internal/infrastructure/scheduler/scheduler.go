@@ -0,0 +1,136 @@
+// Package scheduler 提供进程内的周期性任务调度
+//
+// 每个任务在独立的协程中按固定间隔执行，同一任务不会并发执行。
+// 多实例部署时通过 [Locker] 按任务名加锁，锁在一个执行间隔内有效，
+// 使每个间隔内同一任务只在一个实例上执行；任务本身仍应保持幂等（仓储操作按条件更新）。
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job 周期性任务
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Locker 分布式任务锁
+//
+// TryLock 在锁空闲时获取锁并返回 true，锁在 ttl 后自动释放；锁已被其他实例持有时返回 false。
+type Locker interface {
+	TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// Scheduler 周期性任务调度器
+type Scheduler struct {
+	locker  Locker
+	jobs    []Job
+	wg      sync.WaitGroup
+	stopCh  chan struct{}
+	stopped bool
+	mu      sync.Mutex
+}
+
+// New 创建调度器
+// locker 为 nil 时不加锁，仅适用于单实例运行调度器的部署
+func New(locker Locker, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		locker: locker,
+		jobs:   jobs,
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Start 启动调度器，阻塞直到 Stop 被调用或 ctx 取消
+// 每个任务启动时立即执行一次，之后按间隔执行
+func (s *Scheduler) Start(ctx context.Context) {
+	slog.Info("Starting scheduler", "jobs", len(s.jobs))
+
+	for _, job := range s.jobs {
+		if job.Interval <= 0 || job.Run == nil {
+			slog.Warn("Skipping invalid scheduled job", "job", job.Name)
+			continue
+		}
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+
+	s.wg.Wait()
+	slog.Info("Scheduler stopped")
+}
+
+// Stop 停止调度器，正在执行的任务会运行完成
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return
+	}
+
+	slog.Info("Stopping scheduler...")
+	close(s.stopCh)
+	s.stopped = true
+}
+
+// loop 任务执行循环
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-s.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run 执行一次任务，捕获 panic 避免影响其他任务
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Scheduled job panicked", "job", job.Name, "panic", r)
+		}
+	}()
+
+	if !s.acquire(ctx, job) {
+		return
+	}
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		slog.Error("Scheduled job failed", "job", job.Name, "error", err)
+		return
+	}
+	slog.Debug("Scheduled job completed", "job", job.Name, "duration", time.Since(start))
+}
+
+// acquire 获取任务在本次间隔内的执行权，锁不主动释放，到期后由下一个间隔重新竞争
+func (s *Scheduler) acquire(ctx context.Context, job Job) bool {
+	if s.locker == nil {
+		return true
+	}
+
+	acquired, err := s.locker.TryLock(ctx, job.Name, job.Interval)
+	if err != nil {
+		slog.Error("Failed to acquire scheduled job lock", "job", job.Name, "error", err)
+		return false
+	}
+	if !acquired {
+		slog.Debug("Scheduled job is running on another instance", "job", job.Name)
+	}
+	return acquired
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_RunsJobsUntilStopped(t *testing.T) {
+	var ok, failing, panicking atomic.Int32
+
+	s := New(nil,
+		Job{Name: "ok", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+			ok.Add(1)
+			return nil
+		}},
+		Job{Name: "failing", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+			failing.Add(1)
+			return errors.New("boom")
+		}},
+		Job{Name: "panicking", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+			panicking.Add(1)
+			panic("boom")
+		}},
+		Job{Name: "invalid"},
+	)
+
+	done := make(chan struct{})
+	go func() {
+		s.Start(context.Background())
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return ok.Load() >= 2 && failing.Load() >= 2 && panicking.Load() >= 2
+	}, time.Second, 5*time.Millisecond, "失败或 panic 的任务应继续按间隔执行")
+
+	s.Stop()
+	s.Stop() // 重复调用安全
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+}
+
+// memoryLocker 进程内模拟的分布式锁
+type memoryLocker struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	err     error
+}
+
+func (l *memoryLocker) TryLock(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.err != nil {
+		return false, l.err
+	}
+	now := time.Now()
+	if l.expires[key].After(now) {
+		return false, nil
+	}
+	l.expires[key] = now.Add(ttl)
+	return true, nil
+}
+
+func TestScheduler_Locker(t *testing.T) {
+	t.Run("多个实例共享锁时每个间隔只执行一次", func(t *testing.T) {
+		var runs atomic.Int32
+		locker := &memoryLocker{expires: map[string]time.Time{}}
+		job := Job{Name: "shared", Interval: time.Hour, Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		}}
+
+		instances := []*Scheduler{New(locker, job), New(locker, job), New(locker, job)}
+		var wg sync.WaitGroup
+		for _, s := range instances {
+			wg.Go(func() { s.Start(context.Background()) })
+		}
+
+		assert.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, 5*time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		for _, s := range instances {
+			s.Stop()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), runs.Load())
+	})
+
+	t.Run("获取锁失败时跳过本次执行", func(t *testing.T) {
+		var runs atomic.Int32
+		locker := &memoryLocker{expires: map[string]time.Time{}, err: errors.New("redis unavailable")}
+		s := New(locker, Job{Name: "job", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		}})
+
+		done := make(chan struct{})
+		go func() {
+			s.Start(context.Background())
+			close(done)
+		}()
+		time.Sleep(30 * time.Millisecond)
+		s.Stop()
+		<-done
+
+		assert.Zero(t, runs.Load())
+	})
+}
@@ -0,0 +1,84 @@
+// Package storage 提供用户上传文件（头像等）的存储实现。
+//
+// 提供两种实现：
+//   - [LocalStorage]: 写入本地文件系统（单机部署或开发环境）
+//   - [S3Storage]: 写入 S3 兼容对象存储（AWS S3、MinIO 等）
+//
+// 使用 [New] 根据配置选择实现。
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// 存储驱动
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = errors.New("object not found")
+
+// ErrInvalidKey 对象键无效（为空、绝对路径或包含 ..）
+var ErrInvalidKey = errors.New("invalid object key")
+
+// Storage 对象存储接口，对象以 / 分隔的键寻址
+type Storage interface {
+	// Put 写入对象，已存在时覆盖
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+
+	// Get 读取对象，不存在时返回 ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Delete 删除对象，不存在时不报错
+	Delete(ctx context.Context, key string) error
+}
+
+// Config 存储配置
+type Config struct {
+	Driver   string
+	LocalDir string
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PathStyle bool
+}
+
+// New 根据配置创建存储实现
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		return NewLocalStorage(cfg.LocalDir)
+	case DriverS3:
+		return NewS3Storage(S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+	}
+}
+
+// cleanKey 校验并规范化对象键，防止越出存储根目录
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
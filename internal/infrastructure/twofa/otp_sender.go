@@ -0,0 +1,100 @@
+package twofa
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/mail"
+)
+
+// EmailSender 通过邮件投递一次性验证码
+type EmailSender struct {
+	mailer mail.Mailer
+	issuer string
+	ttl    time.Duration
+}
+
+var _ domainTwoFA.OTPSender = (*EmailSender)(nil)
+
+// NewEmailSender 创建邮件验证码投递器
+func NewEmailSender(mailer mail.Mailer, issuer string, ttl time.Duration) *EmailSender {
+	return &EmailSender{mailer: mailer, issuer: issuer, ttl: ttl}
+}
+
+// Method 返回邮件验证方式
+func (s *EmailSender) Method() domainTwoFA.Method {
+	return domainTwoFA.MethodEmail
+}
+
+// Send 发送验证码邮件
+func (s *EmailSender) Send(ctx context.Context, destination, code string) error {
+	subject := fmt.Sprintf("[%s] Verification code", s.issuer)
+	return s.mailer.Send(ctx, destination, subject, domainTwoFA.OTPMessage(code, s.ttl))
+}
+
+// SMSSender 通过短信服务商投递一次性验证码
+type SMSSender struct {
+	provider domainTwoFA.SMSProvider
+	issuer   string
+	ttl      time.Duration
+}
+
+var _ domainTwoFA.OTPSender = (*SMSSender)(nil)
+
+// NewSMSSender 创建短信验证码投递器
+func NewSMSSender(provider domainTwoFA.SMSProvider, issuer string, ttl time.Duration) *SMSSender {
+	return &SMSSender{provider: provider, issuer: issuer, ttl: ttl}
+}
+
+// Method 返回短信验证方式
+func (s *SMSSender) Method() domainTwoFA.Method {
+	return domainTwoFA.MethodSMS
+}
+
+// Send 发送验证码短信
+func (s *SMSSender) Send(ctx context.Context, destination, code string) error {
+	return s.provider.SendSMS(ctx, destination, fmt.Sprintf("[%s] %s", s.issuer, domainTwoFA.OTPMessage(code, s.ttl)))
+}
+
+// SMSMessage 假短信服务商记录的短信
+type SMSMessage struct {
+	Phone   string
+	Message string
+	SentAt  time.Time
+}
+
+// FakeSMSProvider 假短信服务商（开发与测试使用）
+// 不真正发送短信，仅记录消息并输出日志
+type FakeSMSProvider struct {
+	mu       sync.Mutex
+	messages []SMSMessage
+}
+
+var _ domainTwoFA.SMSProvider = (*FakeSMSProvider)(nil)
+
+// NewFakeSMSProvider 创建假短信服务商
+func NewFakeSMSProvider() *FakeSMSProvider {
+	return &FakeSMSProvider{}
+}
+
+// SendSMS 记录短信
+func (p *FakeSMSProvider) SendSMS(ctx context.Context, phone, message string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, SMSMessage{Phone: phone, Message: message, SentAt: time.Now()})
+	slog.InfoContext(ctx, "sms (fake provider)", "phone", phone, "message", message)
+	return nil
+}
+
+// Messages 返回已记录的全部短信
+func (p *FakeSMSProvider) Messages() []SMSMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]SMSMessage(nil), p.messages...)
+}
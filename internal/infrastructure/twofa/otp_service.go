@@ -0,0 +1,275 @@
+package twofa
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+	"github.com/lwmacct/251117-go-ddd-template/internal/domain/user"
+)
+
+// phonePattern 手机号格式（E.164 宽松校验）
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{6,20}$`)
+
+// OTPService 邮件/短信一次性验证码服务
+//
+// 负责 OTP 通道的绑定、确认、解绑，以及登录时验证码的下发与校验。
+// 验证码的过期、限频和一次性使用由 [domainTwoFA.OTPChallenge] 保证。
+type OTPService struct {
+	channelCommandRepo domainTwoFA.ChannelCommandRepository
+	channelQueryRepo   domainTwoFA.ChannelQueryRepository
+	challengeRepo      domainTwoFA.ChallengeRepository
+	userQueryRepo      user.QueryRepository
+	senders            map[domainTwoFA.Method]domainTwoFA.OTPSender
+	policy             *domainTwoFA.OTPPolicy
+	now                func() time.Time
+}
+
+var _ domainTwoFA.OTPService = (*OTPService)(nil)
+
+// NewOTPService 创建一次性验证码服务
+// 仅注册了 sender 的通道方式可被绑定和使用
+func NewOTPService(
+	channelCommandRepo domainTwoFA.ChannelCommandRepository,
+	channelQueryRepo domainTwoFA.ChannelQueryRepository,
+	challengeRepo domainTwoFA.ChallengeRepository,
+	userQueryRepo user.QueryRepository,
+	policy *domainTwoFA.OTPPolicy,
+	senders ...domainTwoFA.OTPSender,
+) *OTPService {
+	if policy == nil {
+		policy = domainTwoFA.DefaultOTPPolicy()
+	}
+	m := make(map[domainTwoFA.Method]domainTwoFA.OTPSender, len(senders))
+	for _, s := range senders {
+		m[s.Method()] = s
+	}
+	return &OTPService{
+		channelCommandRepo: channelCommandRepo,
+		channelQueryRepo:   channelQueryRepo,
+		challengeRepo:      challengeRepo,
+		userQueryRepo:      userQueryRepo,
+		senders:            m,
+		policy:             policy,
+		now:                time.Now,
+	}
+}
+
+// SetupChannel 绑定通道并发送确认码
+func (s *OTPService) SetupChannel(ctx context.Context, userID uint, method domainTwoFA.Method, destination string) (*domainTwoFA.ChannelSetupResult, error) {
+	if _, ok := s.senders[method]; !ok || !method.IsOTPChannel() {
+		return nil, domainTwoFA.ErrUnsupportedMethod
+	}
+
+	destination, err := s.resolveDestination(ctx, userID, method, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := s.channelQueryRepo.FindByUserIDAndMethod(ctx, userID, method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 2FA channel: %w", err)
+	}
+	if channel != nil && channel.IsEnabled() {
+		return nil, domainTwoFA.ErrChannelAlreadyEnabled
+	}
+
+	if err := s.issue(ctx, userID, method, domainTwoFA.OTPPurposeEnroll, destination); err != nil {
+		return nil, err
+	}
+
+	// 保存未启用的通道，确认后启用
+	if err := s.channelCommandRepo.Save(ctx, &domainTwoFA.Channel{
+		UserID:      userID,
+		Method:      method,
+		Destination: destination,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save 2FA channel: %w", err)
+	}
+
+	return &domainTwoFA.ChannelSetupResult{
+		Method:      method,
+		Destination: domainTwoFA.MaskDestination(method, destination),
+	}, nil
+}
+
+// VerifyAndEnableChannel 校验确认码并启用通道
+func (s *OTPService) VerifyAndEnableChannel(ctx context.Context, userID uint, method domainTwoFA.Method, code string) error {
+	channel, err := s.channelQueryRepo.FindByUserIDAndMethod(ctx, userID, method)
+	if err != nil {
+		return fmt.Errorf("failed to get 2FA channel: %w", err)
+	}
+	if channel == nil {
+		return domainTwoFA.ErrChannelNotFound
+	}
+	if channel.IsEnabled() {
+		return domainTwoFA.ErrChannelAlreadyEnabled
+	}
+
+	if err := s.verify(ctx, userID, method, domainTwoFA.OTPPurposeEnroll, code); err != nil {
+		return err
+	}
+
+	channel.Enable()
+	if err := s.channelCommandRepo.Save(ctx, channel); err != nil {
+		return fmt.Errorf("failed to enable 2FA channel: %w", err)
+	}
+	return nil
+}
+
+// DisableChannel 解绑通道
+func (s *OTPService) DisableChannel(ctx context.Context, userID uint, method domainTwoFA.Method) error {
+	if !method.IsOTPChannel() {
+		return domainTwoFA.ErrUnsupportedMethod
+	}
+	channel, err := s.channelQueryRepo.FindByUserIDAndMethod(ctx, userID, method)
+	if err != nil {
+		return fmt.Errorf("failed to get 2FA channel: %w", err)
+	}
+	if channel == nil {
+		return domainTwoFA.ErrChannelNotFound
+	}
+
+	_ = s.challengeRepo.Delete(ctx, userID, method, domainTwoFA.OTPPurposeEnroll)
+	_ = s.challengeRepo.Delete(ctx, userID, method, domainTwoFA.OTPPurposeLogin)
+	return s.channelCommandRepo.Delete(ctx, userID, method)
+}
+
+// SendLoginCode 向已启用的通道发送登录验证码
+func (s *OTPService) SendLoginCode(ctx context.Context, userID uint, method domainTwoFA.Method) (string, error) {
+	channel, err := s.enabledChannel(ctx, userID, method)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.issue(ctx, userID, method, domainTwoFA.OTPPurposeLogin, channel.Destination); err != nil {
+		return "", err
+	}
+	return channel.MaskedDestination(), nil
+}
+
+// VerifyLoginCode 校验登录验证码
+func (s *OTPService) VerifyLoginCode(ctx context.Context, userID uint, method domainTwoFA.Method, code string) (bool, error) {
+	channel, err := s.enabledChannel(ctx, userID, method)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.verify(ctx, userID, method, domainTwoFA.OTPPurposeLogin, code); err != nil {
+		if errors.Is(err, domainTwoFA.ErrInvalidOTPCode) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	channel.MarkUsed()
+	_ = s.channelCommandRepo.Save(ctx, channel)
+	return true, nil
+}
+
+// EnabledMethods 返回用户已启用的 OTP 通道方式
+func (s *OTPService) EnabledMethods(ctx context.Context, userID uint) ([]domainTwoFA.Method, error) {
+	channels, err := s.channelQueryRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list 2FA channels: %w", err)
+	}
+
+	methods := make([]domainTwoFA.Method, 0, len(channels))
+	for _, c := range channels {
+		if _, ok := s.senders[c.Method]; ok && c.IsEnabled() {
+			methods = append(methods, c.Method)
+		}
+	}
+	return methods, nil
+}
+
+// enabledChannel 获取已启用且有投递器的通道
+func (s *OTPService) enabledChannel(ctx context.Context, userID uint, method domainTwoFA.Method) (*domainTwoFA.Channel, error) {
+	if _, ok := s.senders[method]; !ok {
+		return nil, domainTwoFA.ErrUnsupportedMethod
+	}
+	channel, err := s.channelQueryRepo.FindByUserIDAndMethod(ctx, userID, method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 2FA channel: %w", err)
+	}
+	if channel == nil || !channel.IsEnabled() {
+		return nil, domainTwoFA.ErrChannelNotEnabled
+	}
+	return channel, nil
+}
+
+// issue 生成并下发验证码（受限频策略约束）
+//
+// 限频检查与记录在仓储的原子更新内完成，投递在其后进行；
+// 投递失败同样计入限频，避免并发请求绕过发送次数限制。
+func (s *OTPService) issue(ctx context.Context, userID uint, method domainTwoFA.Method, purpose domainTwoFA.OTPPurpose, destination string) error {
+	code, err := domainTwoFA.GenerateOTPCode(s.policy.CodeLength, rand.Read)
+	if err != nil {
+		return fmt.Errorf("failed to generate OTP code: %w", err)
+	}
+
+	err = s.challengeRepo.Update(ctx, userID, method, purpose, func(challenge *domainTwoFA.OTPChallenge) (*domainTwoFA.OTPChallenge, error) {
+		if challenge == nil {
+			challenge = &domainTwoFA.OTPChallenge{UserID: userID, Method: method, Purpose: purpose}
+		}
+		now := s.now()
+		if !challenge.CanSend(now, s.policy) {
+			return nil, domainTwoFA.ErrOTPThrottled
+		}
+		challenge.Issue(code, destination, now, s.policy)
+		return challenge, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.senders[method].Send(ctx, destination, code); err != nil {
+		return fmt.Errorf("failed to deliver OTP code: %w", err)
+	}
+	return nil
+}
+
+// verify 校验验证码，成功后使其失效（校验与消费为一次原子更新）
+func (s *OTPService) verify(ctx context.Context, userID uint, method domainTwoFA.Method, purpose domainTwoFA.OTPPurpose, code string) error {
+	return s.challengeRepo.Update(ctx, userID, method, purpose, func(challenge *domainTwoFA.OTPChallenge) (*domainTwoFA.OTPChallenge, error) {
+		if challenge == nil {
+			return nil, domainTwoFA.ErrOTPNotFound
+		}
+		verifyErr := challenge.Verify(strings.TrimSpace(code), s.now(), s.policy)
+		if verifyErr == nil {
+			challenge.Consume()
+		}
+		return challenge, verifyErr
+	})
+}
+
+// resolveDestination 校验或补全投递地址
+func (s *OTPService) resolveDestination(ctx context.Context, userID uint, method domainTwoFA.Method, destination string) (string, error) {
+	destination = strings.TrimSpace(destination)
+
+	switch method {
+	case domainTwoFA.MethodEmail:
+		if destination == "" {
+			u, err := s.userQueryRepo.GetByID(ctx, userID)
+			if err != nil {
+				return "", fmt.Errorf("user not found: %w", err)
+			}
+			destination = u.Email
+		}
+		if !strings.Contains(destination, "@") {
+			return "", domainTwoFA.ErrDestinationRequired
+		}
+	case domainTwoFA.MethodSMS:
+		if !phonePattern.MatchString(destination) {
+			return "", domainTwoFA.ErrDestinationRequired
+		}
+	case domainTwoFA.MethodTOTP:
+		return "", domainTwoFA.ErrUnsupportedMethod
+	}
+	return destination, nil
+}
@@ -0,0 +1,152 @@
+package twofa
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	domainTwoFA "github.com/lwmacct/251117-go-ddd-template/internal/domain/twofa"
+	"github.com/lwmacct/251117-go-ddd-template/internal/infrastructure/persistence"
+)
+
+var codePattern = regexp.MustCompile(`\b(\d{6})\b`)
+
+func setupOTPService(t *testing.T) (*OTPService, *FakeSMSProvider) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&persistence.UserModel{}, &persistence.TwoFAChannelModel{}))
+
+	repos := persistence.NewTwoFARepositories(db)
+	userRepos := persistence.NewUserRepositories(db)
+	provider := NewFakeSMSProvider()
+	policy := domainTwoFA.DefaultOTPPolicy()
+
+	svc := NewOTPService(
+		repos.ChannelCommand,
+		repos.ChannelQuery,
+		persistence.NewOTPChallengeMemoryRepository(),
+		userRepos.Query,
+		policy,
+		NewSMSSender(provider, "Test", policy.TTL),
+	)
+	return svc, provider
+}
+
+func lastCode(t *testing.T, provider *FakeSMSProvider) string {
+	t.Helper()
+
+	msgs := provider.Messages()
+	require.NotEmpty(t, msgs)
+	m := codePattern.FindStringSubmatch(msgs[len(msgs)-1].Message)
+	require.Len(t, m, 2)
+	return m[1]
+}
+
+func TestOTPService_SMSEnrollAndLogin(t *testing.T) {
+	ctx := context.Background()
+	svc, provider := setupOTPService(t)
+
+	// 绑定
+	result, err := svc.SetupChannel(ctx, 1, domainTwoFA.MethodSMS, "+8613812345678")
+	require.NoError(t, err)
+	assert.Equal(t, "****5678", result.Destination)
+
+	methods, err := svc.EnabledMethods(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, methods, "确认前不应启用")
+
+	require.NoError(t, svc.VerifyAndEnableChannel(ctx, 1, domainTwoFA.MethodSMS, lastCode(t, provider)))
+
+	methods, err = svc.EnabledMethods(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []domainTwoFA.Method{domainTwoFA.MethodSMS}, methods)
+
+	// 登录
+	_, err = svc.SendLoginCode(ctx, 1, domainTwoFA.MethodSMS)
+	require.NoError(t, err)
+	code := lastCode(t, provider)
+
+	ok, err := svc.VerifyLoginCode(ctx, 1, domainTwoFA.MethodSMS, "000000")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = svc.VerifyLoginCode(ctx, 1, domainTwoFA.MethodSMS, code)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 一次性使用
+	_, err = svc.VerifyLoginCode(ctx, 1, domainTwoFA.MethodSMS, code)
+	assert.ErrorIs(t, err, domainTwoFA.ErrOTPNotFound)
+}
+
+func TestOTPService_ConcurrentVerifyConsumesOnce(t *testing.T) {
+	ctx := context.Background()
+	svc, provider := setupOTPService(t)
+
+	_, err := svc.SetupChannel(ctx, 1, domainTwoFA.MethodSMS, "+8613812345678")
+	require.NoError(t, err)
+	require.NoError(t, svc.VerifyAndEnableChannel(ctx, 1, domainTwoFA.MethodSMS, lastCode(t, provider)))
+	_, err = svc.SendLoginCode(ctx, 1, domainTwoFA.MethodSMS)
+	require.NoError(t, err)
+	code := lastCode(t, provider)
+
+	// 并发提交同一验证码，只能有一次校验成功
+	var succeeded atomic.Int32
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			if ok, _ := svc.VerifyLoginCode(ctx, 1, domainTwoFA.MethodSMS, code); ok {
+				succeeded.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), succeeded.Load())
+}
+
+func TestOTPService_Throttle(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupOTPService(t)
+
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	_, err := svc.SetupChannel(ctx, 1, domainTwoFA.MethodSMS, "+8613812345678")
+	require.NoError(t, err)
+
+	_, err = svc.SetupChannel(ctx, 1, domainTwoFA.MethodSMS, "+8613812345678")
+	require.ErrorIs(t, err, domainTwoFA.ErrOTPThrottled)
+
+	now = now.Add(time.Minute)
+	_, err = svc.SetupChannel(ctx, 1, domainTwoFA.MethodSMS, "+8613812345678")
+	require.NoError(t, err)
+}
+
+func TestOTPService_UnsupportedMethod(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupOTPService(t)
+
+	// 未注册邮件投递器
+	_, err := svc.SetupChannel(ctx, 1, domainTwoFA.MethodEmail, "a@example.com")
+	require.ErrorIs(t, err, domainTwoFA.ErrUnsupportedMethod)
+
+	_, err = svc.SendLoginCode(ctx, 1, domainTwoFA.MethodSMS)
+	require.ErrorIs(t, err, domainTwoFA.ErrChannelNotEnabled)
+
+	_, err = svc.SetupChannel(ctx, 1, domainTwoFA.MethodSMS, "not-a-phone")
+	require.ErrorIs(t, err, domainTwoFA.ErrDestinationRequired)
+}
@@ -11,11 +11,13 @@
 //   - Verify: 验证 TOTP 码或恢复码
 //   - Disable: 禁用用户的 2FA
 //   - GetStatus: 查询 2FA 启用状态和剩余恢复码数量
+//   - [OTPService]: 邮件/短信一次性验证码通道（见 otp_service.go）
 //
 // 安全设计：
 //   - TOTP 密钥使用 80 位（10 字节）随机数
 //   - 恢复码为一次性使用，使用后自动删除
 //   - 密钥存储在数据库中，不对外暴露
+//   - 邮件/短信验证码限频、限时、一次性使用
 package twofa
 
 import (